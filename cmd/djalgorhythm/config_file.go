@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// structuredConfigExtensions are the config file types read by viper instead of being loaded like a .env file.
+var structuredConfigExtensions = []string{".yaml", ".yml", ".toml", ".json"}
+
+// isStructuredConfigFile reports whether the config file is a YAML, TOML or JSON file.
+func isStructuredConfigFile(path string) bool {
+	return slices.Contains(structuredConfigExtensions, strings.ToLower(filepath.Ext(path)))
+}
+
+// loadStructuredConfig reads a YAML, TOML or JSON config file into viper.
+// Keys are flag names, optionally grouped in sections: "telegram: {bot-token: ...}" sets --telegram-bot-token
+// and "app: {vip-user-ids: [...]}" sets --vip-user-ids. Lists are joined with commas like their flags expect.
+// Environment variables and flags still take precedence over the file.
+func loadStructuredConfig(path string) error {
+	fileViper := viper.New()
+	fileViper.SetConfigFile(path)
+	if err := fileViper.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	settings := make(map[string]any)
+	flattenConfigSettings(nil, fileViper.AllSettings(), settings)
+	if err := viper.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to apply config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// flattenConfigSettings maps the nested settings of a config file onto flag names.
+// Unknown keys are reported, as they are most likely typos.
+func flattenConfigSettings(sections []string, settings, flags map[string]any) {
+	for key, value := range settings {
+		key = strings.ReplaceAll(key, "_", "-")
+
+		if nested, ok := value.(map[string]any); ok {
+			flattenConfigSettings(append(slices.Clone(sections), key), nested, flags)
+			continue
+		}
+
+		flag := configFlagName(sections, key)
+		if flag == "" {
+			warnConfig("Unknown setting %q in config file. Use the name of a flag, e.g. log-level",
+				strings.Join(append(slices.Clone(sections), key), "."))
+			continue
+		}
+
+		if list, ok := value.([]any); ok {
+			items := make([]string, 0, len(list))
+			for _, item := range list {
+				items = append(items, fmt.Sprint(item))
+			}
+			value = strings.Join(items, ",")
+		}
+		flags[flag] = value
+	}
+}
+
+// configFlagName returns the flag set by a key of a config file section, preferring the flag prefixed
+// with the sections (telegram: bot-token is --telegram-bot-token) over the plain one
+// (app: vip-user-ids is --vip-user-ids). Returns an empty name if there is no such flag.
+func configFlagName(sections []string, key string) string {
+	candidates := []string{strings.Join(append(slices.Clone(sections), key), "-")}
+	if len(sections) > 1 {
+		candidates = append(candidates, sections[len(sections)-1]+"-"+key)
+	}
+	candidates = append(candidates, key)
+
+	for _, name := range candidates {
+		if rootCmd.PersistentFlags().Lookup(name) != nil {
+			return name
+		}
+	}
+	return ""
+}
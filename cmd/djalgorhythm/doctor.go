@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"djalgorhythm/internal/spotify"
+)
+
+// doctorMusicRequest is sent to the LLM provider to check that it is reachable.
+const doctorMusicRequest = "Queen - Bohemian Rhapsody"
+
+// doctorReport prints the result of each doctor check and counts the critical failures.
+type doctorReport struct {
+	failed int
+}
+
+// pass reports a successful check.
+func (r *doctorReport) pass(check, detail string) {
+	fmt.Printf("✅ %s: %s\n", check, detail)
+}
+
+// fail reports a failed check. Critical failures make doctor exit non-zero, others are printed as warnings.
+func (r *doctorReport) fail(check string, critical bool, err error) {
+	if !critical {
+		fmt.Printf("⚠️ %s: %v\n", check, err)
+		return
+	}
+	fmt.Printf("❌ %s: %v\n", check, err)
+	r.failed++
+}
+
+func newDoctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the configuration and access to the chat, Spotify and the LLM provider",
+		Long: `Check the whole setup and print a pass/fail report per check: the configuration, the chat
+bot login and group access, the Spotify authorization and playlist write permission, the LLM
+provider and the active Spotify device. Exits non-zero if any critical check fails, so it can
+run in deployment pipelines. A missing active device is only reported as a warning.`,
+		Args: cobra.NoArgs,
+		RunE: runDoctor,
+	}
+}
+
+func runDoctor(_ *cobra.Command, _ []string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	report := &doctorReport{}
+	if err := validateConfig(); err != nil {
+		report.fail("Configuration", true, err)
+		return fmt.Errorf("%d critical checks failed", report.failed)
+	}
+	report.pass("Configuration", "valid")
+
+	checkDoctorChat(ctx, report)
+	checkDoctorSpotify(ctx, report)
+	checkDoctorLLM(ctx, report)
+
+	if report.failed > 0 {
+		return fmt.Errorf("%d critical checks failed", report.failed)
+	}
+	fmt.Println("\nAll critical checks passed")
+	return nil
+}
+
+// checkDoctorChat checks that the bot can log in and access the configured group or room.
+func checkDoctorChat(ctx context.Context, report *doctorReport) {
+	frontend := createChatFrontend()
+	if err := frontend.Start(ctx); err != nil {
+		report.fail("Chat login and group access", true, err)
+		return
+	}
+
+	me, err := frontend.GetMe(ctx)
+	if err != nil {
+		report.fail("Chat bot account", true, err)
+		return
+	}
+	report.pass("Chat bot account", me.Username)
+	report.pass("Chat group access", "the bot can access the group")
+}
+
+// checkDoctorSpotify checks the saved Spotify authorization, the playlist write permission and the active device.
+// The OAuth flow isn't started, since doctor must not wait for a browser login.
+func checkDoctorSpotify(ctx context.Context, report *doctorReport) {
+	spotifyClient := spotify.NewClient(&config.Spotify, logger.Named("spotify"), nil, nil)
+	spotifyClient.SetRetryPolicy(config.App.MaxRetries, time.Duration(config.App.MaxRetryWaitSecs)*time.Second)
+	user, err := spotifyClient.AuthenticateWithSavedToken(ctx)
+	if err != nil {
+		report.fail("Spotify authorization", true, fmt.Errorf("%w, start DJAlgoRhythm once to authorize", err))
+		return
+	}
+	report.pass("Spotify authorization", "authorized as "+user)
+
+	checkDoctorPlaylist(ctx, report, spotifyClient)
+
+	active, err := spotifyClient.HasActiveDevice(ctx)
+	switch {
+	case err != nil:
+		report.fail("Spotify device", false, err)
+	case !active:
+		report.fail("Spotify device", false, errors.New("no active device, start playback before the event"))
+	default:
+		report.pass("Spotify device", "an active device is available")
+	}
+}
+
+// checkDoctorPlaylist checks that the authenticated Spotify account can add tracks to the target playlist.
+func checkDoctorPlaylist(ctx context.Context, report *doctorReport, spotifyClient *spotify.Client) {
+	writable, err := spotifyClient.CanModifyPlaylist(ctx, config.Spotify.PlaylistID)
+	if err != nil {
+		report.fail("Spotify playlist", true, err)
+		return
+	}
+	if !writable {
+		report.fail("Spotify playlist", true, playlistNotWritableError(config.Spotify.PlaylistID))
+		return
+	}
+	report.pass("Spotify playlist", fmt.Sprintf("tracks can be added to %s", config.Spotify.PlaylistID))
+}
+
+// checkDoctorLLM checks that the configured LLM provider answers requests.
+func checkDoctorLLM(ctx context.Context, report *doctorReport) {
+	llmProvider, err := createLLMProvider()
+	if err != nil {
+		report.fail("LLM provider", true, err)
+		return
+	}
+	if llmProvider == nil {
+		report.pass("LLM provider", "none configured")
+		return
+	}
+
+	if _, err := llmProvider.IsNotMusicRequest(ctx, doctorMusicRequest); err != nil {
+		report.fail("LLM provider", true, err)
+		return
+	}
+	report.pass("LLM provider", fmt.Sprintf("%s answered a test request", config.LLM.Provider))
+}
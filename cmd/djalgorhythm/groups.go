@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat/telegram"
+	"djalgorhythm/internal/core"
+	"djalgorhythm/internal/i18n"
+	"djalgorhythm/internal/spotify"
+	"djalgorhythm/internal/store"
+)
+
+// groupServices holds the services of an additional group served by the same bot.
+type groupServices struct {
+	dispatcher *core.Dispatcher
+	spotify    *spotify.Client
+	eventLog   *store.EventLog
+}
+
+// parseGroupList parses comma-separated groupID=playlistID pairs of additional groups, each optionally followed
+// by semicolon-separated settings of the group, e.g. -100123=playlistID;language=ch_be;admin-approval=true.
+// Invalid entries and groups listed twice, or as the primary group, are reported and skipped.
+func parseGroupList(value string, primaryGroupID int64) []core.GroupConfig {
+	var groups []core.GroupConfig
+	seen := map[int64]bool{primaryGroupID: true}
+
+	for _, entry := range parseIDList(value) {
+		settings := strings.Split(entry, ";")
+		groupIDValue, playlistID, found := strings.Cut(settings[0], "=")
+		groupID, err := strconv.ParseInt(strings.TrimSpace(groupIDValue), 10, 64)
+		playlistID = strings.TrimSpace(playlistID)
+		if !found || err != nil || groupID == 0 || playlistID == "" {
+			warnConfig("Invalid --telegram-groups entry (%s), expected groupID=playlistID. Skipping it", entry)
+			continue
+		}
+		if seen[groupID] {
+			warnConfig("Group %d is listed more than once in --telegram-groups or is the primary group. Skipping it",
+				groupID)
+			continue
+		}
+
+		seen[groupID] = true
+		group := core.GroupConfig{GroupID: groupID, PlaylistID: playlistID}
+		for _, setting := range settings[1:] {
+			parseGroupSetting(&group, setting)
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// parseGroupSetting applies a key=value setting of a --telegram-groups entry to the group.
+// Unknown or invalid settings are reported and ignored, so the group uses the primary setting.
+func parseGroupSetting(group *core.GroupConfig, setting string) {
+	key, value, _ := strings.Cut(setting, "=")
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if key == "" {
+		return
+	}
+
+	switch key {
+	case "language":
+		if !slices.Contains(i18n.GetSupportedLanguages(), value) {
+			warnConfig("Unsupported language '%s' for group %d, using the primary language. Supported languages: %s",
+				value, group.GroupID, strings.Join(i18n.GetSupportedLanguages(), ", "))
+			return
+		}
+		group.Language = value
+	case "admin-approval", "admin-needs-approval":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			warnConfig("Invalid %s setting (%s) for group %d, expected true or false. Using the primary setting",
+				key, value, group.GroupID)
+			return
+		}
+		if key == "admin-approval" {
+			group.AdminApproval = &enabled
+		} else {
+			group.AdminNeedsApproval = &enabled
+		}
+	case "community-approval":
+		votes, err := strconv.Atoi(value)
+		if err != nil || votes < 0 {
+			warnConfig("Invalid community-approval setting (%s) for group %d, expected a number of votes. "+
+				"Using the primary setting", value, group.GroupID)
+			return
+		}
+		group.CommunityApproval = &votes
+	default:
+		warnConfig("Unknown setting '%s' for group %d in --telegram-groups. Supported settings: "+
+			"language, admin-approval, admin-needs-approval, community-approval", key, group.GroupID)
+	}
+}
+
+// groupConfig returns the configuration of an additional group: the primary configuration with the group's
+// chat, playlist and settings, and the Spotify token and state files of the group next to the primary ones.
+// Topics are specific to the primary group, so additional groups are served as a whole.
+func groupConfig(base *core.Config, group core.GroupConfig) *core.Config {
+	cfg := *base
+	cfg.Telegram.GroupID = group.GroupID
+	cfg.Telegram.Groups = nil
+	cfg.Telegram.TopicID = 0
+	cfg.Spotify.PlaylistID = group.PlaylistID
+	cfg.Spotify.TokenPath = groupStatePath(base.Spotify.TokenPath, group.GroupID)
+	cfg.App.ShadowQueuePath = groupStatePath(base.App.ShadowQueuePath, group.GroupID)
+	cfg.App.UserQuotaPath = groupStatePath(base.App.UserQuotaPath, group.GroupID)
+	cfg.App.LeaderboardPath = groupStatePath(base.App.LeaderboardPath, group.GroupID)
+	cfg.App.EventLogPath = groupStatePath(base.App.EventLogPath, group.GroupID)
+
+	if group.Language != "" {
+		cfg.App.Language = group.Language
+	}
+	if group.AdminApproval != nil {
+		cfg.Telegram.AdminApproval = *group.AdminApproval
+	}
+	if group.AdminNeedsApproval != nil {
+		cfg.Telegram.AdminNeedsApproval = *group.AdminNeedsApproval
+	}
+	if group.CommunityApproval != nil {
+		cfg.Telegram.CommunityApproval = *group.CommunityApproval
+	}
+	return &cfg
+}
+
+// groupStatePath returns the path of a group's state file, e.g. spotify_token_-100123.json
+// for spotify_token.json. Empty paths stay empty, as the state isn't persisted.
+func groupStatePath(path string, groupID int64) string {
+	if path == "" {
+		return ""
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s_%d%s", strings.TrimSuffix(path, ext), groupID, ext)
+}
+
+// createGroupServices creates a dispatcher for each additional group, with a frontend sharing the bot of the
+// primary group, and its own Spotify login, playlist, deduplication and state. The admin warning subscribers,
+// who opt in through the bot's private chat, are shared with the primary group.
+func createGroupServices(ctx context.Context, frontend *telegram.Frontend, llmProvider core.LLMProvider,
+	metricsRecorder core.MetricsRecorder, warningSubscribers core.WarningSubscriberStore) ([]*groupServices, error) {
+	groups := make([]*groupServices, 0, len(config.Telegram.Groups))
+
+	for _, group := range config.Telegram.Groups {
+		cfg := groupConfig(config, group)
+		groupLogger := logger.With(zap.Int64("group_id", group.GroupID))
+
+		groupFrontend := frontend.AddGroup(newTelegramConfig(cfg))
+		// Keep the group's core config in sync when the group is upgraded to a supergroup
+		groupFrontend.SetCoreGroupIDPointer(&cfg.Telegram.GroupID)
+
+		spotifyClient := spotify.NewClient(&cfg.Spotify, groupLogger.Named("spotify"), llmProvider, metricsRecorder)
+		spotifyClient.SetRetryPolicy(cfg.App.MaxRetries, time.Duration(cfg.App.MaxRetryWaitSecs)*time.Second)
+		groupLogger.Info("Authenticating the Spotify account of the group",
+			zap.String("token_path", cfg.Spotify.TokenPath))
+		if err := spotifyClient.Authenticate(ctx); err != nil {
+			return nil, fmt.Errorf("failed to authenticate with Spotify for group %d: %w", group.GroupID, err)
+		}
+		if err := checkPlaylistPermissions(ctx, spotifyClient, cfg.Spotify.PlaylistID); err != nil {
+			return nil, fmt.Errorf("group %d: %w", group.GroupID, err)
+		}
+
+		quota, err := createUserQuota(cfg)
+		if err != nil {
+			return nil, err
+		}
+		leaderboard, err := createLeaderboard(cfg)
+		if err != nil {
+			return nil, err
+		}
+		eventLog, err := createEventLog(cfg)
+		if err != nil {
+			return nil, err
+		}
+		// Avoid passing a typed nil pointer as a non-nil interface
+		var eventLogger core.EventLogger
+		if eventLog != nil {
+			eventLogger = eventLog
+		}
+
+		dedup := store.NewDedupStore(defaultDedupStoreCapacity, defaultDedupStoreFalsePositiveRate)
+		dispatcher := core.NewDispatcher(cfg, groupFrontend, spotifyClient, llmProvider, dedup, quota,
+			createTrackCooldown(cfg), leaderboard, warningSubscribers, metricsRecorder, eventLogger,
+			newMusicLinkManagerAdapter(),
+			groupLogger.Named("dispatcher"))
+
+		groupLogger.Info("Serving additional Telegram group",
+			zap.String("spotify_playlist", cfg.Spotify.PlaylistID))
+		groups = append(groups, &groupServices{dispatcher: dispatcher, spotify: spotifyClient, eventLog: eventLog})
+	}
+
+	return groups, nil
+}
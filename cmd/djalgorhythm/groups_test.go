@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+
+	"djalgorhythm/internal/core"
+)
+
+func TestParseGroupList(t *testing.T) {
+	groups := parseGroupList("-100=first, -200=second;language=ch_be;admin-approval=true;community-approval=3,"+
+		"-300=third;language=xx;admin-needs-approval=maybe;unknown=1, -100=duplicate, invalid", -1)
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %+v", groups)
+	}
+
+	if first := groups[0]; first.GroupID != -100 || first.PlaylistID != "first" || first.Language != "" ||
+		first.AdminApproval != nil || first.CommunityApproval != nil {
+		t.Errorf("Expected the first group without settings, got %+v", first)
+	}
+
+	second := groups[1]
+	if second.GroupID != -200 || second.PlaylistID != "second" || second.Language != "ch_be" {
+		t.Errorf("Expected the second group with its language, got %+v", second)
+	}
+	if second.AdminApproval == nil || !*second.AdminApproval || second.CommunityApproval == nil ||
+		*second.CommunityApproval != 3 || second.AdminNeedsApproval != nil {
+		t.Errorf("Expected the approval settings of the second group, got %+v", second)
+	}
+
+	if third := groups[2]; third.PlaylistID != "third" || third.Language != "" || third.AdminNeedsApproval != nil {
+		t.Errorf("Expected invalid settings of the third group to be ignored, got %+v", third)
+	}
+}
+
+func TestGroupConfig(t *testing.T) {
+	base := &core.Config{}
+	base.App.Language = "en"
+	base.App.ShadowQueuePath = "shadow_queue.json"
+	base.Telegram.AdminApproval = false
+	base.Telegram.CommunityApproval = 5
+	base.Spotify.TokenPath = "spotify_token.json"
+
+	adminApproval, votes := true, 2
+	cfg := groupConfig(base, core.GroupConfig{
+		GroupID: -200, PlaylistID: "second", Language: "ch_be", AdminApproval: &adminApproval, CommunityApproval: &votes,
+	})
+	if cfg.Telegram.GroupID != -200 || cfg.Spotify.PlaylistID != "second" ||
+		cfg.Spotify.TokenPath != "spotify_token_-200.json" || cfg.App.ShadowQueuePath != "shadow_queue_-200.json" {
+		t.Errorf("Expected the group's chat, playlist and state files, got %+v", cfg)
+	}
+	if cfg.App.Language != "ch_be" || !cfg.Telegram.AdminApproval || cfg.Telegram.CommunityApproval != 2 {
+		t.Errorf("Expected the group's settings, got %+v %+v", cfg.App.Language, cfg.Telegram)
+	}
+	if base.App.Language != "en" || base.Telegram.AdminApproval || base.Telegram.CommunityApproval != 5 {
+		t.Error("Expected the primary configuration to stay unchanged")
+	}
+
+	if shared := groupConfig(base, core.GroupConfig{GroupID: -300, PlaylistID: "third"}); shared.App.Language != "en" ||
+		shared.Telegram.CommunityApproval != 5 {
+		t.Errorf("Expected a group without settings to share the primary ones, got %+v", shared.Telegram)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -21,11 +22,13 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/matrix"
 	"djalgorhythm/internal/chat/telegram"
 	"djalgorhythm/internal/core"
 	httpserver "djalgorhythm/internal/http"
 	"djalgorhythm/internal/i18n"
 	"djalgorhythm/internal/llm"
+	"djalgorhythm/internal/metrics"
 	"djalgorhythm/internal/spotify"
 	"djalgorhythm/internal/store"
 )
@@ -33,15 +36,40 @@ import (
 const (
 	defaultServerHost                     = "127.0.0.1"
 	defaultServerPort                     = 8080
+	defaultServerTimeoutSecs              = 10
+	defaultServerIdleTimeoutSecs          = 60
 	defaultConfirmTimeoutSecs             = 120
 	defaultAdminConfirmTimeoutSecs        = 3600
+	defaultVIPConfirmTimeoutSecs          = 600
 	defaultQueueTrackApprovalTimeoutSecs  = 30
 	defaultMaxQueueTrackReplacements      = 3
+	defaultDisambiguationChoices          = 3
 	defaultQueueAheadDurationSecs         = 90
 	defaultQueueCheckIntervalSecs         = 45
 	defaultShadowQueueMaintenanceInterval = 5
 	defaultShadowQueueMaxAgeHours         = 2
+	defaultShadowQueueSaveIntervalSecs    = 60
 	defaultFloodLimitPerMinute            = 6
+	defaultMaxURLsPerMessage              = 3
+	defaultMaxConcurrentRequests          = 4
+	defaultUserQuotaWindowHours           = 24
+	defaultMaxRetries                     = 3
+	defaultMaxRetryWaitSecs               = 30
+	defaultFailedAdditionRetries          = 3
+	defaultEventLogMaxSizeMB              = 10
+	defaultBumpCooldownMins               = 30
+	defaultSkipCooldownMins               = 60
+	defaultMaxAlbumTracks                 = 25
+	defaultLLMTimeoutSecs                 = 15
+	defaultLLMCircuitBreakerFailures      = 5
+	defaultLLMCircuitBreakerCooldownSecs  = 60
+	defaultTelegramReconnectMaxBackoff    = 60
+	defaultPhoneticMatchPercent           = 30
+	defaultRecentlyPlayedHours            = 3
+	defaultAutoDJWarningPercent           = 80
+	bytesPerMB                            = 1024 * 1024
+	maxPercent                            = 100
+	countryCodeLength                     = 2
 	defaultDedupStoreCapacity             = 10000
 	defaultDedupStoreFalsePositiveRate    = 0.001
 	shutdownTimeoutSecs                   = 30
@@ -54,6 +82,9 @@ var (
 	cfgFile string
 	config  *core.Config
 	logger  *zap.Logger
+
+	// configWarnings collects the configuration problems reported at startup, see --strict-config.
+	configWarnings []string
 )
 
 var rootCmd = &cobra.Command{
@@ -75,31 +106,104 @@ func main() {
 func init() {
 	cobra.OnInitialize(initConfig)
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .env)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "",
+		"config file, a .env file or structured .yaml, .toml or .json (default is .env)")
 	rootCmd.PersistentFlags().String("log-level", "info", "log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().String("log-format", "text", "log format (json, text)")
+	rootCmd.PersistentFlags().Bool("strict-config", false,
+		"Refuse to start on configuration warnings instead of working around them (for CI-style deployments)")
 	rootCmd.PersistentFlags().String("telegram-bot-token", "", "Telegram bot token")
 	rootCmd.PersistentFlags().Int64("telegram-group-id", 0, "Telegram group ID")
+	rootCmd.PersistentFlags().String("telegram-groups", "",
+		"Additional groups served by the bot as comma-separated groupID=playlistID pairs, each with its own Spotify login "+
+			"and optional ;key=value settings (language, admin-approval, admin-needs-approval, community-approval)")
+	rootCmd.PersistentFlags().Int("telegram-reconnect-max-backoff-secs", defaultTelegramReconnectMaxBackoff,
+		"Maximum seconds between attempts to reconnect the Telegram update loop")
+	rootCmd.PersistentFlags().Bool("telegram-welcome-message", true,
+		"Post an introduction explaining how to request songs when the bot is added to the group")
+	rootCmd.PersistentFlags().Int("telegram-topic-id", 0,
+		"Topic of a forum group to listen and reply in (0 uses the whole group)")
+	rootCmd.PersistentFlags().Bool("matrix-enabled", false, "Use Matrix instead of Telegram as chat frontend")
+	rootCmd.PersistentFlags().String("matrix-homeserver-url", "", "Matrix homeserver URL (e.g. https://matrix.org)")
+	rootCmd.PersistentFlags().String("matrix-access-token", "", "Matrix access token of the bot account")
+	rootCmd.PersistentFlags().String("matrix-room-id", "", "Matrix room ID or alias to monitor")
 	rootCmd.PersistentFlags().String("spotify-client-id", "", "Spotify client ID")
 	rootCmd.PersistentFlags().String("spotify-client-secret", "", "Spotify client secret")
 	rootCmd.PersistentFlags().String("spotify-playlist-id", "", "Spotify playlist ID")
+	rootCmd.PersistentFlags().String("spotify-market", "",
+		"ISO country code whose track availability searches reflect (empty uses the Spotify account's country)")
+	rootCmd.PersistentFlags().String("spotify-device-name", "",
+		"Spotify device to transfer playback to when no device is active (empty disables)")
+	rootCmd.PersistentFlags().String("recommendation-strategy", core.RecommendationStrategyPlaylist,
+		"How queue-filling tracks are found (playlist, audio-features)")
+	rootCmd.PersistentFlags().String("recommendation-source-playlists", "",
+		"Comma-separated playlist IDs queue-filling tracks are drawn from before searching playlists")
+	rootCmd.PersistentFlags().String("fallback-genre", "",
+		"Genre or search query queue-filling tracks are found with while the playlist is empty (empty waits for requests)")
+	rootCmd.PersistentFlags().Int("recently-played-hours", defaultRecentlyPlayedHours,
+		"Hours in which played tracks aren't picked again when filling the queue, even if removed from the playlist (0 disables)")
 	rootCmd.PersistentFlags().String("spotify-oauth-bind-host", "",
 		"Host for OAuth callback server to bind to (defaults to server-host, use 0.0.0.0 in containers)")
-	rootCmd.PersistentFlags().String("llm-provider", "", "LLM provider (openai, anthropic, ollama) - REQUIRED")
+	rootCmd.PersistentFlags().Bool("oauth-qr", false,
+		"Print the Spotify authorization URL as a QR code and serve it at /qr of the OAuth callback server")
+	rootCmd.PersistentFlags().String("llm-provider", "",
+		"LLM provider (openai, compatible, anthropic, ollama) - REQUIRED")
 	rootCmd.PersistentFlags().String("llm-model", "", "LLM model name")
 	rootCmd.PersistentFlags().String("llm-api-key", "", "LLM API key")
+	rootCmd.PersistentFlags().String("llm-base-url", "",
+		"LLM API base URL (required for the compatible provider)")
+	rootCmd.PersistentFlags().Int("llm-timeout-secs", defaultLLMTimeoutSecs,
+		"Timeout in seconds for a single LLM request before falling back to non-LLM behavior")
+	rootCmd.PersistentFlags().Int("llm-circuit-breaker-failures", defaultLLMCircuitBreakerFailures,
+		"Consecutive LLM failures after which LLM requests are skipped for a cooldown (0 disables)")
+	rootCmd.PersistentFlags().Int("llm-circuit-breaker-cooldown-secs", defaultLLMCircuitBreakerCooldownSecs,
+		"Seconds LLM requests are skipped after the circuit breaker opened")
 	rootCmd.PersistentFlags().String("server-host", defaultServerHost, "HTTP server host")
 	rootCmd.PersistentFlags().Int("server-port", defaultServerPort, "HTTP server port")
+	rootCmd.PersistentFlags().Int("server-read-timeout-secs", defaultServerTimeoutSecs,
+		"Seconds the HTTP server waits for a request to be read")
+	rootCmd.PersistentFlags().Int("server-write-timeout-secs", defaultServerTimeoutSecs,
+		"Seconds the HTTP server takes at most to write a response")
+	rootCmd.PersistentFlags().Int("server-idle-timeout-secs", defaultServerIdleTimeoutSecs,
+		"Seconds the HTTP server keeps idle keep-alive connections open")
+	rootCmd.PersistentFlags().Int("server-shutdown-timeout-secs", defaultServerTimeoutSecs,
+		"Seconds in-flight HTTP requests may take to finish on shutdown")
+	rootCmd.PersistentFlags().String("server-tls-cert", "",
+		"TLS certificate file to serve HTTPS with (empty serves HTTP)")
+	rootCmd.PersistentFlags().String("server-tls-key", "", "TLS private key file of the certificate")
+	rootCmd.PersistentFlags().Int("server-https-redirect-port", 0,
+		"Port redirecting plain HTTP requests to HTTPS, e.g. 80 (0 disables, requires TLS)")
+	rootCmd.PersistentFlags().Bool("metrics-enabled", true, "Expose Prometheus metrics at /metrics")
+	rootCmd.PersistentFlags().Bool("dashboard-enabled", false,
+		"Serve a live dashboard with the current track, queue and pending approvals at /")
+	rootCmd.PersistentFlags().String("server-admin-token", "",
+		"Bearer token protecting the /approvals admin endpoints (empty disables them)")
 	rootCmd.PersistentFlags().Int("confirm-timeout-secs", defaultConfirmTimeoutSecs, "Confirmation timeout in seconds")
 	rootCmd.PersistentFlags().Int("confirm-admin-timeout-secs", defaultAdminConfirmTimeoutSecs,
 		"Admin confirmation timeout in seconds")
+	rootCmd.PersistentFlags().Int("confirm-vip-timeout-secs", defaultVIPConfirmTimeoutSecs,
+		"VIP user confirmation timeout in seconds")
+	rootCmd.PersistentFlags().String("vip-user-ids", "",
+		"Comma-separated user IDs that get the VIP confirmation timeout")
+	rootCmd.PersistentFlags().Bool("vip-bypass-approval", false,
+		"Add song requests of --vip-user-ids without admin or community approval")
 	rootCmd.PersistentFlags().Int("queue-track-approval-timeout-secs", defaultQueueTrackApprovalTimeoutSecs,
 		"Queue track approval timeout in seconds")
+	rootCmd.PersistentFlags().String("queue-approval-timeout-action", core.QueueApprovalTimeoutAccept,
+		"What happens to queue tracks nobody decided on before the approval timeout (accept, reject, replace)")
 	rootCmd.PersistentFlags().Int("max-queue-track-replacements", defaultMaxQueueTrackReplacements,
 		"Maximum queue track replacement attempts before auto-accepting")
+	rootCmd.PersistentFlags().Int("max-consecutive-same-artist", 0,
+		"Skip queue-filling tracks whose artist is among the last N played or queued tracks (0 disables)")
+	rootCmd.PersistentFlags().Int("max-playlist-size", 0,
+		"Maximum number of playlist tracks, the oldest played are removed to make room for new ones (0 disables)")
+	rootCmd.PersistentFlags().Int("autodj-warning-percent", defaultAutoDJWarningPercent,
+		"Warn admins when the auto-DJ picked more than this percentage of the last 10 added tracks (0 disables)")
 	rootCmd.PersistentFlags().Bool("admin-needs-approval", false, "Require approval even for admins (for testing)")
 	rootCmd.PersistentFlags().Int("community-approval", 0,
 		"Number of 👍 reactions needed to bypass admin approval (0 disables feature)")
+	rootCmd.PersistentFlags().Int("community-approval-percent", 0,
+		"Percentage of group members whose 👍 reactions bypass admin approval (overrides --community-approval, 0 disables)")
 	rootCmd.PersistentFlags().Int("queue-ahead-duration-secs", defaultQueueAheadDurationSecs,
 		"Target queue duration in seconds")
 	rootCmd.PersistentFlags().Int("queue-check-interval-secs", defaultQueueCheckIntervalSecs,
@@ -108,14 +212,106 @@ func init() {
 		"Shadow queue maintenance interval in minutes")
 	rootCmd.PersistentFlags().Int("shadow-queue-max-age-hours", defaultShadowQueueMaxAgeHours,
 		"Maximum age of shadow queue items in hours")
+	rootCmd.PersistentFlags().String("shadow-queue-path", "",
+		"File to persist the shadow queue across restarts (empty keeps it in memory)")
+	rootCmd.PersistentFlags().Int("shadow-queue-save-interval-secs", defaultShadowQueueSaveIntervalSecs,
+		"Interval in seconds at which the shadow queue is persisted")
 	supportedLangs := strings.Join(i18n.GetSupportedLanguages(), ", ")
 	rootCmd.PersistentFlags().String("language", i18n.DefaultLanguage,
 		fmt.Sprintf("Bot language (%s)", supportedLangs))
+	rootCmd.PersistentFlags().Bool("auto-detect-language", false,
+		"Reply to song requests in the requester's detected language when supported")
+	rootCmd.PersistentFlags().String("messages-file", "",
+		"JSON or TOML file with custom wording for bot messages, merged over the bundled language")
+	rootCmd.PersistentFlags().String("added-reaction", core.DefaultAddedReaction,
+		"Reaction on requests added to the playlist (must be a reaction the chat allows)")
+	rootCmd.PersistentFlags().String("queued-reaction", core.DefaultQueuedReaction,
+		"Reaction on requests queued to play next (must be a reaction the chat allows)")
 	rootCmd.PersistentFlags().Int("flood-limit-per-minute", defaultFloodLimitPerMinute,
 		"Maximum messages per user per minute")
+	rootCmd.PersistentFlags().Int("flood-burst", 0,
+		"Extra messages a user may send in quick succession on top of --flood-limit-per-minute")
+	rootCmd.PersistentFlags().Int("max-urls-per-message", defaultMaxURLsPerMessage,
+		"Maximum links processed per message, further links are ignored")
+	rootCmd.PersistentFlags().Int("max-concurrent-requests", defaultMaxConcurrentRequests,
+		"Maximum song requests processed simultaneously, further requests wait briefly (0 for no limit)")
+	rootCmd.PersistentFlags().Int("max-requests-per-user", 0,
+		"Maximum accepted songs per user and quota window (0 disables quotas)")
+	rootCmd.PersistentFlags().Int("user-quota-window-hours", defaultUserQuotaWindowHours,
+		"Hours after which a user's request quota resets")
+	rootCmd.PersistentFlags().String("user-quota-path", "",
+		"File to persist user request quotas across restarts (empty keeps quotas in memory)")
+	rootCmd.PersistentFlags().String("event-log-path", "",
+		"File to append a JSON line for every added track (empty disables the event log)")
+	rootCmd.PersistentFlags().Int("event-log-max-size-mb", defaultEventLogMaxSizeMB,
+		"Size in megabytes after which the event log is rotated")
+	rootCmd.PersistentFlags().String("leaderboard-path", "",
+		"File to persist the /leaderboard request counts across events (empty keeps them in memory)")
+	rootCmd.PersistentFlags().Bool("shutdown-summary", false,
+		"Append the number of songs added and the top requester of the session to the shutdown message")
+	rootCmd.PersistentFlags().String("warning-subscribers-path", "./warning_subscribers.json",
+		"File to persist the users who opted in to admin warnings with /start (empty keeps them in memory)")
+	rootCmd.PersistentFlags().String("warning-subscribe-token", "",
+		"Deep-link payload letting non-admins opt in to admin warnings via t.me/<bot>?start=<token> (empty: admins only)")
+	rootCmd.PersistentFlags().Int("max-retries", defaultMaxRetries,
+		"Maximum retries for rate-limited Spotify requests")
+	rootCmd.PersistentFlags().Int("max-retry-wait-secs", defaultMaxRetryWaitSecs,
+		"Longest wait in seconds before retrying a rate-limited Spotify request, longer Retry-After waits fail right away")
+	rootCmd.PersistentFlags().Int("failed-addition-retries", defaultFailedAdditionRetries,
+		"Background retries with backoff of failed playlist additions before giving up (0 disables)")
+	rootCmd.PersistentFlags().Bool("announce-now-playing", false,
+		"Post a \"now playing\" message to the group whenever the track changes")
+	rootCmd.PersistentFlags().Bool("show-album-art", false,
+		"Post approval and now playing messages with the album cover art (text only on frontends without photos)")
+	rootCmd.PersistentFlags().Bool("enforce-playback-settings", false,
+		"Turn shuffle and repeat off whenever they are changed instead of only warning admins")
+	rootCmd.PersistentFlags().Bool("block-explicit", false, "Reject song requests for explicit tracks")
+	rootCmd.PersistentFlags().Bool("prefer-clean", false, "Rank explicit tracks below clean ones in search results")
+	rootCmd.PersistentFlags().Int("phonetic-match-percent", defaultPhoneticMatchPercent,
+		"Weight in percent of how titles sound when ranking search results, helping misspelled requests (0 disables)")
+	rootCmd.PersistentFlags().Int("min-track-secs", 0,
+		"Minimum track duration in seconds for requests and queue filling (0 disables)")
+	rootCmd.PersistentFlags().Int("max-track-secs", 0,
+		"Maximum track duration in seconds for requests and queue filling (0 disables)")
+	rootCmd.PersistentFlags().Int("near-duplicate-threshold-percent", 0,
+		"Title similarity in percent above which a request for the same artist counts as near-duplicate (0 disables)")
+	rootCmd.PersistentFlags().Int("skip-cooldown-mins", defaultSkipCooldownMins,
+		"Minutes before a skipped track is queued again when filling the queue (0 disables)")
+	rootCmd.PersistentFlags().Int("track-cooldown-mins", 0,
+		"Minutes before an added track can be requested again, even if removed from the playlist (0 disables)")
+	rootCmd.PersistentFlags().Int("bump-votes", 0,
+		"Number of 👍 reactions needed to bump a duplicate request to play next (0 disables feature)")
+	rootCmd.PersistentFlags().Int("bump-cooldown-mins", defaultBumpCooldownMins,
+		"Minutes before the same track can be bumped again")
+	rootCmd.PersistentFlags().Int("max-album-tracks", defaultMaxAlbumTracks,
+		"Maximum number of tracks a shared album may have to be added as a whole (0 disables album links)")
+	rootCmd.PersistentFlags().Bool("delete-request-messages", false,
+		"Delete request messages shortly after their song was added (the bot must be an admin allowed to delete messages)")
+	rootCmd.PersistentFlags().Bool("allow-episodes", false,
+		"Queue shared Spotify podcast episodes instead of rejecting them")
+	rootCmd.PersistentFlags().Bool("disambiguation-buttons", false,
+		"Let requesters pick among the best search results with buttons instead of confirming the best one (Telegram only)")
+	rootCmd.PersistentFlags().Int("disambiguation-choices", defaultDisambiguationChoices,
+		"Number of search results offered with --disambiguation-buttons")
+	rootCmd.PersistentFlags().Int("auto-accept-threshold", 0,
+		"Search relevance in percent above which a top result clearly ahead of the others is added without confirmation (0 disables)")
+	rootCmd.PersistentFlags().Bool("require-membership", false,
+		"Only accept song requests of group members, rejecting users who left or were never members (Telegram only)")
+	rootCmd.PersistentFlags().Int("min-membership-mins", 0,
+		"Minutes a user must have been in the group before requesting songs with --require-membership (0 disables)")
+	rootCmd.PersistentFlags().String("request-prefix", "",
+		"Prefix marking messages as song requests, e.g. !play, other messages are ignored (empty treats all messages as requests)")
+	rootCmd.PersistentFlags().String("request-hours", "",
+		"Daily hours during which song requests are accepted, e.g. 18:00-02:00 (empty accepts requests any time)")
+	rootCmd.PersistentFlags().String("request-timezone", "",
+		"IANA time zone of the request hours, e.g. Europe/Zurich (empty uses the local time zone)")
 	rootCmd.PersistentFlags().Bool("generate-env-example", false,
 		"Generate .env.example file from current configuration and exit")
 
+	rootCmd.AddCommand(newPlaylistCmd())
+	rootCmd.AddCommand(newSeedCmd())
+	rootCmd.AddCommand(newDoctorCmd())
+
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to bind flags: %v\n", err)
 		os.Exit(1)
@@ -129,7 +325,12 @@ func initConfig() {
 		envFile = cfgFile
 	}
 
-	if err := gotenv.Load(envFile); err != nil {
+	if isStructuredConfigFile(envFile) {
+		// YAML, TOML and JSON files are read by viper, below environment variables and flags
+		if err := loadStructuredConfig(envFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading config file: %v\n", err)
+		}
+	} else if err := gotenv.Load(envFile); err != nil {
 		// Don't exit if .env file doesn't exist, just warn
 		if !os.IsNotExist(err) {
 			fmt.Fprintf(os.Stderr, "Error loading .env file: %v\n", err)
@@ -148,20 +349,62 @@ func buildConfig() *core.Config {
 	cfg := core.DefaultConfig()
 
 	configureTelegram(cfg)
+	configureMatrix(cfg)
+	configureServer(cfg)
 	configureSpotify(cfg)
 	configureLLM(cfg)
-	configureServer(cfg)
 	configureApp(cfg)
+	configureRequestFilters(cfg)
 
 	return cfg
 }
 
+// warnConfig reports a configuration problem that was worked around and remembers it for --strict-config.
+func warnConfig(format string, args ...any) {
+	warning := fmt.Sprintf(format, args...)
+	fmt.Printf("Warning: %s\n", warning)
+	configWarnings = append(configWarnings, warning)
+}
+
+// validTimeoutSecs returns the timeout of the given flag, or the fallback if it isn't a positive number of seconds.
+func validTimeoutSecs(flag string, secs, fallback int) int {
+	if secs > 0 {
+		return secs
+	}
+	warnConfig("Invalid --%s (%d), using default (%d). Set it to a positive number of seconds", flag, secs, fallback)
+	return fallback
+}
+
 func configureTelegram(cfg *core.Config) {
 	cfg.Telegram.BotToken = viper.GetString("telegram-bot-token")
 	cfg.Telegram.GroupID = viper.GetInt64("telegram-group-id")
 	cfg.Telegram.AdminApproval = viper.GetBool("admin-approval")
 	cfg.Telegram.AdminNeedsApproval = viper.GetBool("admin-needs-approval")
 	cfg.Telegram.CommunityApproval = viper.GetInt("community-approval")
+	cfg.Telegram.CommunityApprovalPercent = viper.GetInt("community-approval-percent")
+	if cfg.Telegram.CommunityApprovalPercent < 0 || cfg.Telegram.CommunityApprovalPercent > maxPercent {
+		warnConfig("Invalid community approval percent (%d), disabling percentage-based approval",
+			cfg.Telegram.CommunityApprovalPercent)
+		cfg.Telegram.CommunityApprovalPercent = 0
+	}
+	cfg.Telegram.ReconnectMaxBackoffSecs = viper.GetInt("telegram-reconnect-max-backoff-secs")
+	if cfg.Telegram.ReconnectMaxBackoffSecs <= 0 {
+		cfg.Telegram.ReconnectMaxBackoffSecs = core.DefaultTelegramReconnectMaxBackoffSecs
+	}
+	cfg.Telegram.WelcomeMessage = viper.GetBool("telegram-welcome-message")
+	cfg.Telegram.TopicID = viper.GetInt("telegram-topic-id")
+	if cfg.Telegram.TopicID < 0 {
+		warnConfig("Invalid Telegram topic ID (%d), using the whole group", cfg.Telegram.TopicID)
+		cfg.Telegram.TopicID = 0
+	}
+	cfg.Telegram.Groups = parseGroupList(viper.GetString("telegram-groups"), cfg.Telegram.GroupID)
+}
+
+func configureMatrix(cfg *core.Config) {
+	cfg.Matrix.Enabled = viper.GetBool("matrix-enabled")
+	cfg.Matrix.HomeserverURL = viper.GetString("matrix-homeserver-url")
+	cfg.Matrix.AccessToken = viper.GetString("matrix-access-token")
+	cfg.Matrix.RoomID = viper.GetString("matrix-room-id")
 }
 
 func configureSpotify(cfg *core.Config) {
@@ -169,7 +412,37 @@ func configureSpotify(cfg *core.Config) {
 	cfg.Spotify.ClientSecret = viper.GetString("spotify-client-secret")
 	cfg.Spotify.RedirectURL = viper.GetString("spotify-redirect-url")
 	cfg.Spotify.OAuthBindHost = viper.GetString("spotify-oauth-bind-host")
+	cfg.Spotify.OAuthQR = viper.GetBool("oauth-qr")
 	cfg.Spotify.PlaylistID = viper.GetString("spotify-playlist-id")
+	cfg.Spotify.DeviceName = viper.GetString("spotify-device-name")
+	cfg.Spotify.PreferClean = viper.GetBool("prefer-clean")
+	cfg.Spotify.PhoneticMatchPercent = viper.GetInt("phonetic-match-percent")
+	if cfg.Spotify.PhoneticMatchPercent < 0 || cfg.Spotify.PhoneticMatchPercent > maxPercent {
+		warnConfig("Invalid phonetic match percent (%d), using %d",
+			cfg.Spotify.PhoneticMatchPercent, core.DefaultPhoneticMatchPercent)
+		cfg.Spotify.PhoneticMatchPercent = core.DefaultPhoneticMatchPercent
+	}
+	cfg.Spotify.Market = strings.ToUpper(strings.TrimSpace(viper.GetString("spotify-market")))
+	if cfg.Spotify.Market != "" && !isCountryCode(cfg.Spotify.Market) {
+		warnConfig("Invalid Spotify market (%s), using the Spotify account's country", cfg.Spotify.Market)
+		cfg.Spotify.Market = ""
+	}
+	cfg.Spotify.RecommendationStrategy = strings.ToLower(strings.TrimSpace(viper.GetString("recommendation-strategy")))
+	switch cfg.Spotify.RecommendationStrategy {
+	case core.RecommendationStrategyPlaylist, core.RecommendationStrategyAudioFeatures:
+	default:
+		warnConfig("Unknown recommendation strategy (%s), using %s",
+			cfg.Spotify.RecommendationStrategy, core.RecommendationStrategyPlaylist)
+		cfg.Spotify.RecommendationStrategy = core.RecommendationStrategyPlaylist
+	}
+	cfg.Spotify.RecommendationSourcePlaylists = parseIDList(viper.GetString("recommendation-source-playlists"))
+	cfg.Spotify.FallbackGenre = strings.TrimSpace(viper.GetString("fallback-genre"))
+	cfg.Spotify.RecentlyPlayedHours = viper.GetInt("recently-played-hours")
+	if cfg.Spotify.RecentlyPlayedHours < 0 {
+		warnConfig("Invalid recently played hours (%d), disabling the recently played exclusion",
+			cfg.Spotify.RecentlyPlayedHours)
+		cfg.Spotify.RecentlyPlayedHours = 0
+	}
 	cfg.Spotify.TokenPath = viper.GetString("spotify-token-path")
 	if cfg.Spotify.TokenPath == "" {
 		cfg.Spotify.TokenPath = "./spotify_token.json"
@@ -181,13 +454,32 @@ func configureSpotify(cfg *core.Config) {
 		if serverHost == defaultServerHost {
 			serverHost = "127.0.0.1" // Use localhost for OAuth callback
 		}
-		cfg.Spotify.RedirectURL = "http://" + net.JoinHostPort(serverHost, strconv.Itoa(cfg.Server.Port)) + "/callback"
+		scheme := "http"
+		if cfg.Server.TLSCertFile != "" {
+			scheme = "https"
+		}
+		cfg.Spotify.RedirectURL = scheme + "://" + net.JoinHostPort(serverHost, strconv.Itoa(cfg.Server.Port)) + "/callback"
 	}
 
 	// Default OAuth bind host to server host if not explicitly set
 	if cfg.Spotify.OAuthBindHost == "" {
 		cfg.Spotify.OAuthBindHost = cfg.Server.Host
 	}
+	cfg.Spotify.OAuthTLSCertFile = cfg.Server.TLSCertFile
+	cfg.Spotify.OAuthTLSKeyFile = cfg.Server.TLSKeyFile
+}
+
+// isCountryCode reports whether code is a two-letter ISO 3166-1 alpha-2 country code.
+func isCountryCode(code string) bool {
+	if len(code) != countryCodeLength {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
 }
 
 func configureLLM(cfg *core.Config) {
@@ -195,6 +487,20 @@ func configureLLM(cfg *core.Config) {
 	cfg.LLM.Model = viper.GetString("llm-model")
 	cfg.LLM.APIKey = viper.GetString("llm-api-key")
 	cfg.LLM.BaseURL = viper.GetString("llm-base-url")
+
+	// Timeout and circuit breaker configuration to stay responsive during provider outages
+	cfg.LLM.TimeoutSecs = viper.GetInt("llm-timeout-secs")
+	if cfg.LLM.TimeoutSecs <= 0 {
+		cfg.LLM.TimeoutSecs = core.DefaultLLMTimeoutSecs
+	}
+	cfg.LLM.CircuitBreakerFailures = viper.GetInt("llm-circuit-breaker-failures")
+	if cfg.LLM.CircuitBreakerFailures < 0 {
+		cfg.LLM.CircuitBreakerFailures = core.DefaultLLMCircuitBreakerFailures
+	}
+	cfg.LLM.CircuitBreakerCooldownSecs = viper.GetInt("llm-circuit-breaker-cooldown-secs")
+	if cfg.LLM.CircuitBreakerCooldownSecs <= 0 {
+		cfg.LLM.CircuitBreakerCooldownSecs = core.DefaultLLMCircuitBreakerCooldownSecs
+	}
 }
 
 func configureServer(cfg *core.Config) {
@@ -203,33 +509,86 @@ func configureServer(cfg *core.Config) {
 		cfg.Server.Host = defaultServerHost
 	}
 	cfg.Server.Port = viper.GetInt("server-port")
+	cfg.Server.ReadTimeout = time.Duration(validTimeoutSecs("server-read-timeout-secs",
+		viper.GetInt("server-read-timeout-secs"), core.DefaultTimeoutSeconds)) * time.Second
+	cfg.Server.WriteTimeout = time.Duration(validTimeoutSecs("server-write-timeout-secs",
+		viper.GetInt("server-write-timeout-secs"), core.DefaultTimeoutSeconds)) * time.Second
+	cfg.Server.IdleTimeout = time.Duration(validTimeoutSecs("server-idle-timeout-secs",
+		viper.GetInt("server-idle-timeout-secs"), core.DefaultServerIdleTimeoutSecs)) * time.Second
+	cfg.Server.ShutdownTimeout = time.Duration(validTimeoutSecs("server-shutdown-timeout-secs",
+		viper.GetInt("server-shutdown-timeout-secs"), core.DefaultTimeoutSeconds)) * time.Second
+	cfg.Server.TLSCertFile = viper.GetString("server-tls-cert")
+	cfg.Server.TLSKeyFile = viper.GetString("server-tls-key")
+	cfg.Server.HTTPRedirectPort = max(viper.GetInt("server-https-redirect-port"), 0)
+	cfg.Server.MetricsEnabled = viper.GetBool("metrics-enabled")
+	cfg.Server.AdminToken = viper.GetString("server-admin-token")
+	cfg.Server.DashboardEnabled = viper.GetBool("dashboard-enabled")
 	cfg.Log.Level = viper.GetString("log-level")
 	cfg.Log.Format = viper.GetString("log-format")
 }
 
 func configureApp(cfg *core.Config) {
-	cfg.App.ConfirmTimeoutSecs = viper.GetInt("confirm-timeout-secs")
-	cfg.App.ConfirmAdminTimeoutSecs = viper.GetInt("confirm-admin-timeout-secs")
-	cfg.App.QueueTrackApprovalTimeoutSecs = viper.GetInt("queue-track-approval-timeout-secs")
+	cfg.App.ConfirmTimeoutSecs = validTimeoutSecs("confirm-timeout-secs",
+		viper.GetInt("confirm-timeout-secs"), core.DefaultConfirmTimeoutSecs)
+	cfg.App.ConfirmAdminTimeoutSecs = validTimeoutSecs("confirm-admin-timeout-secs",
+		viper.GetInt("confirm-admin-timeout-secs"), core.DefaultConfirmAdminTimeoutSecs)
+	cfg.App.ConfirmVIPTimeoutSecs = validTimeoutSecs("confirm-vip-timeout-secs",
+		viper.GetInt("confirm-vip-timeout-secs"), core.DefaultConfirmVIPTimeoutSecs)
+	cfg.App.VIPUserIDs = parseIDList(viper.GetString("vip-user-ids"))
+	cfg.App.VIPBypassApproval = viper.GetBool("vip-bypass-approval")
+	cfg.App.QueueTrackApprovalTimeoutSecs = validTimeoutSecs("queue-track-approval-timeout-secs",
+		viper.GetInt("queue-track-approval-timeout-secs"), core.DefaultQueueTrackApprovalTimeoutSecs)
+	cfg.App.QueueApprovalTimeoutAction = strings.ToLower(strings.TrimSpace(viper.GetString("queue-approval-timeout-action")))
+	switch cfg.App.QueueApprovalTimeoutAction {
+	case core.QueueApprovalTimeoutAccept, core.QueueApprovalTimeoutReject, core.QueueApprovalTimeoutReplace:
+	default:
+		warnConfig("Unknown queue approval timeout action (%s), using %s",
+			cfg.App.QueueApprovalTimeoutAction, core.QueueApprovalTimeoutAccept)
+		cfg.App.QueueApprovalTimeoutAction = core.QueueApprovalTimeoutAccept
+	}
 	cfg.App.MaxQueueTrackReplacements = viper.GetInt("max-queue-track-replacements")
 
 	// Queue-ahead configuration
 	cfg.App.QueueAheadDurationSecs = viper.GetInt("queue-ahead-duration-secs")
 	cfg.App.QueueCheckIntervalSecs = viper.GetInt("queue-check-interval-secs")
+	cfg.App.MaxConsecutiveSameArtist = viper.GetInt("max-consecutive-same-artist")
+	if cfg.App.MaxConsecutiveSameArtist < 0 {
+		warnConfig("Invalid max consecutive same artist (%d), disabling artist diversity",
+			cfg.App.MaxConsecutiveSameArtist)
+		cfg.App.MaxConsecutiveSameArtist = 0
+	}
+	cfg.App.MaxPlaylistSize = viper.GetInt("max-playlist-size")
+	if cfg.App.MaxPlaylistSize < 0 {
+		warnConfig("Invalid max playlist size (%d), disabling the playlist size cap", cfg.App.MaxPlaylistSize)
+		cfg.App.MaxPlaylistSize = 0
+	}
+	cfg.App.AutoDJWarningPercent = viper.GetInt("autodj-warning-percent")
+	if cfg.App.AutoDJWarningPercent < 0 || cfg.App.AutoDJWarningPercent > maxPercent {
+		warnConfig("Invalid auto-DJ warning percent (%d), disabling the auto-DJ warning", cfg.App.AutoDJWarningPercent)
+		cfg.App.AutoDJWarningPercent = 0
+	}
 
 	// Shadow queue configuration
 	cfg.App.ShadowQueueMaintenanceIntervalSecs = viper.GetInt("shadow-queue-maintenance-interval-secs")
 	if cfg.App.ShadowQueueMaintenanceIntervalSecs <= 0 {
-		fmt.Printf("Warning: Invalid shadow queue maintenance interval (%d), using default (%d)\n",
-			cfg.App.ShadowQueueMaintenanceIntervalSecs, core.DefaultShadowQueueMaintenanceIntervalSecs)
+		// Only set through the environment, so an unset value just means the default
+		if viper.IsSet("shadow-queue-maintenance-interval-secs") {
+			warnConfig("Invalid shadow queue maintenance interval (%d), using default (%d)",
+				cfg.App.ShadowQueueMaintenanceIntervalSecs, core.DefaultShadowQueueMaintenanceIntervalSecs)
+		}
 		cfg.App.ShadowQueueMaintenanceIntervalSecs = core.DefaultShadowQueueMaintenanceIntervalSecs
 	}
 	cfg.App.ShadowQueueMaxAgeHours = viper.GetInt("shadow-queue-max-age-hours")
 	if cfg.App.ShadowQueueMaxAgeHours <= 0 {
-		fmt.Printf("Warning: Invalid shadow queue max age (%d), using default (%d)\n",
+		warnConfig("Invalid shadow queue max age (%d), using default (%d)",
 			cfg.App.ShadowQueueMaxAgeHours, core.DefaultShadowQueueMaxAgeHours)
 		cfg.App.ShadowQueueMaxAgeHours = core.DefaultShadowQueueMaxAgeHours
 	}
+	cfg.App.ShadowQueuePath = viper.GetString("shadow-queue-path")
+	cfg.App.ShadowQueueSaveIntervalSecs = viper.GetInt("shadow-queue-save-interval-secs")
+	if cfg.App.ShadowQueueSaveIntervalSecs <= 0 {
+		cfg.App.ShadowQueueSaveIntervalSecs = core.DefaultShadowQueueSaveIntervalSecs
+	}
 
 	// Language configuration with validation
 	cfg.App.Language = viper.GetString("language")
@@ -247,16 +606,197 @@ func configureApp(cfg *core.Config) {
 		}
 	}
 	if !isSupported {
-		fmt.Fprintf(os.Stderr, "Warning: Unsupported language '%s', falling back to '%s'. Supported languages: %s\n",
+		warnConfig("Unsupported language '%s', falling back to '%s'. Supported languages: %s",
 			cfg.App.Language, i18n.DefaultLanguage, strings.Join(supportedLanguages, ", "))
 		cfg.App.Language = i18n.DefaultLanguage
 	}
+	cfg.App.AutoDetectLanguage = viper.GetBool("auto-detect-language")
+	cfg.App.MessagesFile = viper.GetString("messages-file")
+	cfg.App.AddedReaction = strings.TrimSpace(viper.GetString("added-reaction"))
+	if cfg.App.AddedReaction == "" {
+		cfg.App.AddedReaction = core.DefaultAddedReaction
+	}
+	cfg.App.QueuedReaction = strings.TrimSpace(viper.GetString("queued-reaction"))
+	if cfg.App.QueuedReaction == "" {
+		cfg.App.QueuedReaction = core.DefaultQueuedReaction
+	}
 
 	// Flood prevention configuration
 	cfg.App.FloodLimitPerMinute = viper.GetInt("flood-limit-per-minute")
 	if cfg.App.FloodLimitPerMinute <= 0 {
 		cfg.App.FloodLimitPerMinute = core.DefaultFloodLimitPerMinute
 	}
+	cfg.App.FloodBurst = viper.GetInt("flood-burst")
+	if cfg.App.FloodBurst < 0 {
+		warnConfig("Invalid flood burst (%d), disabling the flood burst", cfg.App.FloodBurst)
+		cfg.App.FloodBurst = 0
+	}
+	cfg.App.MaxURLsPerMessage = viper.GetInt("max-urls-per-message")
+	if cfg.App.MaxURLsPerMessage <= 0 {
+		cfg.App.MaxURLsPerMessage = core.DefaultMaxURLsPerMessage
+	}
+	cfg.App.MaxConcurrentRequests = viper.GetInt("max-concurrent-requests")
+	if cfg.App.MaxConcurrentRequests < 0 {
+		warnConfig("Invalid max concurrent requests (%d), disabling the limit", cfg.App.MaxConcurrentRequests)
+		cfg.App.MaxConcurrentRequests = 0
+	}
+
+	// Per-user request quota configuration
+	cfg.App.MaxRequestsPerUser = viper.GetInt("max-requests-per-user")
+	cfg.App.UserQuotaWindowHours = viper.GetInt("user-quota-window-hours")
+	if cfg.App.UserQuotaWindowHours <= 0 {
+		cfg.App.UserQuotaWindowHours = core.DefaultUserQuotaWindowHours
+	}
+	cfg.App.UserQuotaPath = viper.GetString("user-quota-path")
+
+	// Added track event log configuration
+	cfg.App.EventLogPath = viper.GetString("event-log-path")
+	cfg.App.EventLogMaxSizeMB = viper.GetInt("event-log-max-size-mb")
+	if cfg.App.EventLogMaxSizeMB <= 0 {
+		cfg.App.EventLogMaxSizeMB = core.DefaultEventLogMaxSizeMB
+	}
+
+	// Requester leaderboard configuration
+	cfg.App.LeaderboardPath = viper.GetString("leaderboard-path")
+	cfg.App.ShutdownSummary = viper.GetBool("shutdown-summary")
+
+	// Admin warning subscriptions
+	cfg.App.WarningSubscribersPath = viper.GetString("warning-subscribers-path")
+	cfg.App.WarningSubscribeToken = viper.GetString("warning-subscribe-token")
+
+	// Rate limit retry configuration
+	cfg.App.MaxRetries = viper.GetInt("max-retries")
+	if cfg.App.MaxRetries < 0 {
+		cfg.App.MaxRetries = core.DefaultMaxRetries
+	}
+	cfg.App.MaxRetryWaitSecs = viper.GetInt("max-retry-wait-secs")
+	if cfg.App.MaxRetryWaitSecs <= 0 {
+		cfg.App.MaxRetryWaitSecs = core.DefaultMaxRetryWaitSecs
+	}
+	cfg.App.FailedAdditionRetries = viper.GetInt("failed-addition-retries")
+	if cfg.App.FailedAdditionRetries < 0 {
+		cfg.App.FailedAdditionRetries = core.DefaultFailedAdditionRetries
+	}
+
+	cfg.App.AnnounceNowPlaying = viper.GetBool("announce-now-playing")
+	cfg.App.ShowAlbumArt = viper.GetBool("show-album-art")
+	cfg.App.EnforcePlaybackSettings = viper.GetBool("enforce-playback-settings")
+}
+
+// parseIDList splits a comma-separated list of IDs, dropping empty entries.
+func parseIDList(value string) []string {
+	var ids []string
+	for id := range strings.SplitSeq(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// configureRequestFilters reads the settings that restrict which song requests are accepted.
+func configureRequestFilters(cfg *core.Config) {
+	cfg.App.BlockExplicit = viper.GetBool("block-explicit")
+
+	// Track duration limits configuration
+	cfg.App.MinTrackSecs = max(viper.GetInt("min-track-secs"), 0)
+	cfg.App.MaxTrackSecs = max(viper.GetInt("max-track-secs"), 0)
+	if cfg.App.MaxTrackSecs > 0 && cfg.App.MinTrackSecs > cfg.App.MaxTrackSecs {
+		warnConfig("Minimum track duration (%ds) exceeds the maximum (%ds), disabling track duration limits",
+			cfg.App.MinTrackSecs, cfg.App.MaxTrackSecs)
+		cfg.App.MinTrackSecs = 0
+		cfg.App.MaxTrackSecs = 0
+	}
+
+	// Near-duplicate detection configuration
+	cfg.App.NearDuplicateThresholdPercent = viper.GetInt("near-duplicate-threshold-percent")
+	if cfg.App.NearDuplicateThresholdPercent < 0 || cfg.App.NearDuplicateThresholdPercent > maxPercent {
+		warnConfig("Invalid near-duplicate threshold (%d%%), disabling near-duplicate detection",
+			cfg.App.NearDuplicateThresholdPercent)
+		cfg.App.NearDuplicateThresholdPercent = 0
+	}
+
+	// Track re-request cooldown configuration
+	cfg.App.TrackCooldownMins = viper.GetInt("track-cooldown-mins")
+	if cfg.App.TrackCooldownMins < 0 {
+		warnConfig("Invalid track cooldown (%d), disabling track cooldown", cfg.App.TrackCooldownMins)
+		cfg.App.TrackCooldownMins = 0
+	}
+
+	cfg.App.SkipCooldownMins = viper.GetInt("skip-cooldown-mins")
+	if cfg.App.SkipCooldownMins < 0 {
+		warnConfig("Invalid skip cooldown (%d), disabling skip cooldown", cfg.App.SkipCooldownMins)
+		cfg.App.SkipCooldownMins = 0
+	}
+
+	// Duplicate request bump vote configuration
+	cfg.App.BumpVotes = viper.GetInt("bump-votes")
+	cfg.App.BumpCooldownMins = viper.GetInt("bump-cooldown-mins")
+	if cfg.App.BumpCooldownMins < 0 {
+		cfg.App.BumpCooldownMins = core.DefaultBumpCooldownMins
+	}
+
+	// Album link configuration
+	cfg.App.MaxAlbumTracks = viper.GetInt("max-album-tracks")
+	if cfg.App.MaxAlbumTracks < 0 {
+		warnConfig("Invalid max album tracks (%d), disabling album links", cfg.App.MaxAlbumTracks)
+		cfg.App.MaxAlbumTracks = 0
+	}
+
+	cfg.App.AllowEpisodes = viper.GetBool("allow-episodes")
+
+	// Disambiguation configuration
+	cfg.App.DisambiguationButtons = viper.GetBool("disambiguation-buttons")
+	cfg.App.DisambiguationChoices = viper.GetInt("disambiguation-choices")
+	if cfg.App.DisambiguationChoices < 2 {
+		warnConfig("Invalid disambiguation choices (%d), using default %d",
+			cfg.App.DisambiguationChoices, core.DefaultDisambiguationChoices)
+		cfg.App.DisambiguationChoices = core.DefaultDisambiguationChoices
+	}
+	cfg.App.AutoAcceptThreshold = viper.GetInt("auto-accept-threshold")
+	if cfg.App.AutoAcceptThreshold < 0 || cfg.App.AutoAcceptThreshold > maxPercent {
+		warnConfig("Invalid auto-accept threshold (%d%%), disabling auto-accept", cfg.App.AutoAcceptThreshold)
+		cfg.App.AutoAcceptThreshold = 0
+	}
+
+	// Membership configuration
+	cfg.App.RequireMembership = viper.GetBool("require-membership")
+	cfg.App.MinMembershipMins = viper.GetInt("min-membership-mins")
+	if cfg.App.MinMembershipMins < 0 {
+		warnConfig("Invalid min membership minutes (%d), disabling the membership age check", cfg.App.MinMembershipMins)
+		cfg.App.MinMembershipMins = 0
+	}
+
+	// Request cleanup configuration
+	cfg.App.DeleteRequestMessages = viper.GetBool("delete-request-messages")
+	cfg.App.RequestPrefix = strings.TrimSpace(viper.GetString("request-prefix"))
+
+	configureRequestHours(cfg)
+}
+
+// configureRequestHours reads the daily hours during which song requests are accepted.
+func configureRequestHours(cfg *core.Config) {
+	requestHours := viper.GetString("request-hours")
+	if requestHours == "" {
+		return
+	}
+
+	location := time.Local
+	if timezone := viper.GetString("request-timezone"); timezone != "" {
+		loaded, err := time.LoadLocation(timezone)
+		if err != nil {
+			warnConfig("Invalid request time zone (%s), using the local time zone", timezone)
+		} else {
+			location = loaded
+		}
+	}
+
+	schedule, err := core.ParseSchedule(requestHours, location)
+	if err != nil {
+		warnConfig("Invalid request hours (%s), accepting requests any time: %v", requestHours, err)
+		return
+	}
+	cfg.App.RequestSchedule = schedule
 }
 
 func buildLogger(level, format string) *zap.Logger {
@@ -293,7 +833,10 @@ func buildLogger(level, format string) *zap.Logger {
 	return builtLogger
 }
 
-const noneProvider = "none"
+const (
+	noneProvider       = "none"
+	compatibleProvider = "compatible"
+)
 
 func runDJAlgoRhythm(cmd *cobra.Command, _ []string) error {
 	// Handle generate-env-example flag
@@ -317,6 +860,7 @@ func runDJAlgoRhythm(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	defer services.closeEventLog()
 
 	return runServices(ctx, services)
 }
@@ -328,6 +872,25 @@ type services struct {
 	httpServer *httpserver.Server
 	dispatcher *core.Dispatcher
 	dedup      *store.DedupStore
+	eventLog   *store.EventLog
+	groups     []*groupServices
+}
+
+// closeEventLog closes the event log files on shutdown.
+func (s *services) closeEventLog() {
+	eventLogs := []*store.EventLog{s.eventLog}
+	for _, group := range s.groups {
+		eventLogs = append(eventLogs, group.eventLog)
+	}
+
+	for _, eventLog := range eventLogs {
+		if eventLog == nil {
+			continue
+		}
+		if err := eventLog.Close(); err != nil {
+			logger.Warn("Failed to close event log", zap.Error(err))
+		}
+	}
 }
 
 func initializeServices(ctx context.Context) (*services, error) {
@@ -340,17 +903,75 @@ func initializeServices(ctx context.Context) (*services, error) {
 		return nil, err
 	}
 
-	spotifyClient := spotify.NewClient(&config.Spotify, logger.Named("spotify"), llmProvider)
+	// Metrics are on by default; a nil recorder disables all instrumentation.
+	var metricsRegistry *metrics.Registry
+	var metricsRecorder core.MetricsRecorder
+	if config.Server.MetricsEnabled {
+		metricsRegistry = metrics.New()
+		metricsRecorder = metricsRegistry
+		llmProvider = metrics.InstrumentLLMProvider(llmProvider, metricsRegistry)
+	}
+
+	spotifyClient := spotify.NewClient(&config.Spotify, logger.Named("spotify"), llmProvider, metricsRecorder)
+	spotifyClient.SetRetryPolicy(config.App.MaxRetries, time.Duration(config.App.MaxRetryWaitSecs)*time.Second)
 	if authErr := spotifyClient.Authenticate(ctx); authErr != nil {
 		return nil, fmt.Errorf("failed to authenticate with Spotify: %w", authErr)
 	}
+	if permErr := checkPlaylistPermissions(ctx, spotifyClient, config.Spotify.PlaylistID); permErr != nil {
+		return nil, permErr
+	}
+
+	quota, err := createUserQuota(config)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard, err := createLeaderboard(config)
+	if err != nil {
+		return nil, err
+	}
+
+	warningSubscribers, err := createWarningSubscribers(config)
+	if err != nil {
+		return nil, err
+	}
+
+	eventLog, err := createEventLog(config)
+	if err != nil {
+		return nil, err
+	}
+	// Avoid passing a typed nil pointer as a non-nil interface
+	var eventLogger core.EventLogger
+	if eventLog != nil {
+		eventLogger = eventLog
+	}
 
 	// Create music link manager for multi-provider support.
-	musicLinkMgr := core.NewMusicLinkManagerAdapter()
+	musicLinkMgr := newMusicLinkManagerAdapter()
+
+	dispatcher := core.NewDispatcher(config, frontend, spotifyClient, llmProvider, dedup, quota, createTrackCooldown(config),
+		leaderboard, warningSubscribers, metricsRecorder, eventLogger, musicLinkMgr, logger.Named("dispatcher"))
+	// The dashboard is opt-in; a nil source keeps the plain home page.
+	var dashboard httpserver.DashboardSource
+	if config.Server.DashboardEnabled {
+		dashboard = dispatcher
+	}
+
+	var groups []*groupServices
+	if telegramFrontend, ok := frontend.(*telegram.Frontend); ok {
+		groups, err = createGroupServices(ctx, telegramFrontend, llmProvider, metricsRecorder, warningSubscribers)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	httpServer := httpserver.NewServer(&config.Server, logger.Named("http"))
-	dispatcher := core.NewDispatcher(config, frontend, spotifyClient, llmProvider, dedup, musicLinkMgr,
-		logger.Named("dispatcher"))
+	// Every group has its own Spotify login, so re-authorizations go back to the client that started them
+	spotifyClients := []*spotify.Client{spotifyClient}
+	for _, group := range groups {
+		spotifyClients = append(spotifyClients, group.spotify)
+	}
+	httpServer := httpserver.NewServer(&config.Server, dispatcher, dispatcher, dashboard,
+		spotify.ReauthorizationRouter(spotifyClients...), metricsRegistry, logger.Named("http"))
 
 	return &services{
 		frontend:   frontend,
@@ -359,20 +980,17 @@ func initializeServices(ctx context.Context) (*services, error) {
 		httpServer: httpServer,
 		dispatcher: dispatcher,
 		dedup:      dedup,
+		eventLog:   eventLog,
+		groups:     groups,
 	}, nil
 }
 
 func createChatFrontend() chat.Frontend {
-	telegramConfig := &telegram.Config{
-		BotToken:            config.Telegram.BotToken,
-		GroupID:             config.Telegram.GroupID,
-		AdminApproval:       config.Telegram.AdminApproval,
-		AdminNeedsApproval:  config.Telegram.AdminNeedsApproval,
-		CommunityApproval:   config.Telegram.CommunityApproval,
-		Language:            config.App.Language,
-		FloodLimitPerMinute: config.App.FloodLimitPerMinute,
+	if config.Matrix.Enabled {
+		return createMatrixFrontend()
 	}
-	frontend := telegram.NewFrontend(telegramConfig, logger.Named("telegram"))
+
+	frontend := telegram.NewFrontend(newTelegramConfig(config), logger.Named("telegram"))
 
 	// Pass pointer to core config's GroupID to enable automatic migration sync
 	frontend.SetCoreGroupIDPointer(&config.Telegram.GroupID)
@@ -383,6 +1001,138 @@ func createChatFrontend() chat.Frontend {
 	return frontend
 }
 
+// newTelegramConfig returns the Telegram frontend configuration of the group configured in cfg.
+func newTelegramConfig(cfg *core.Config) *telegram.Config {
+	return &telegram.Config{
+		BotToken:                 cfg.Telegram.BotToken,
+		GroupID:                  cfg.Telegram.GroupID,
+		AdminApproval:            cfg.Telegram.AdminApproval,
+		AdminNeedsApproval:       cfg.Telegram.AdminNeedsApproval,
+		CommunityApproval:        cfg.Telegram.CommunityApproval,
+		CommunityApprovalPercent: cfg.Telegram.CommunityApprovalPercent,
+		Language:                 cfg.App.Language,
+		MessageOverrides:         cfg.App.MessageOverrides,
+		FloodLimitPerMinute:      cfg.App.FloodLimitPerMinute,
+		FloodBurst:               cfg.App.FloodBurst,
+		MaxURLsPerMessage:        cfg.App.MaxURLsPerMessage,
+		ReconnectMaxBackoff:      time.Duration(cfg.Telegram.ReconnectMaxBackoffSecs) * time.Second,
+		WelcomeMessage:           cfg.Telegram.WelcomeMessage,
+		TopicID:                  cfg.Telegram.TopicID,
+		RequestPrefix:            cfg.App.RequestPrefix,
+	}
+}
+
+func createMatrixFrontend() chat.Frontend {
+	matrixConfig := &matrix.Config{
+		HomeserverURL:       config.Matrix.HomeserverURL,
+		AccessToken:         config.Matrix.AccessToken,
+		RoomID:              config.Matrix.RoomID,
+		AdminApproval:       config.Telegram.AdminApproval,
+		Language:            config.App.Language,
+		MessageOverrides:    config.App.MessageOverrides,
+		FloodLimitPerMinute: config.App.FloodLimitPerMinute,
+		FloodBurst:          config.App.FloodBurst,
+		RequestPrefix:       config.App.RequestPrefix,
+	}
+
+	logger.Info("Using Matrix as chat frontend",
+		zap.String("homeserver", config.Matrix.HomeserverURL),
+		zap.String("room", config.Matrix.RoomID),
+		zap.Bool("admin_approval", config.Telegram.AdminApproval),
+		zap.String("language", config.App.Language))
+	return matrix.NewFrontend(matrixConfig, logger.Named("matrix"))
+}
+
+func createUserQuota(cfg *core.Config) (core.UserQuotaStore, error) {
+	if cfg.App.MaxRequestsPerUser <= 0 {
+		return nil, nil
+	}
+
+	quota, err := store.NewUserQuota(cfg.App.MaxRequestsPerUser,
+		time.Duration(cfg.App.UserQuotaWindowHours)*time.Hour, cfg.App.UserQuotaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user quota store: %w", err)
+	}
+
+	logger.Info("Per-user request quotas enabled",
+		zap.Int("max_requests_per_user", cfg.App.MaxRequestsPerUser),
+		zap.Int("window_hours", cfg.App.UserQuotaWindowHours),
+		zap.String("path", cfg.App.UserQuotaPath))
+	return quota, nil
+}
+
+func createTrackCooldown(cfg *core.Config) core.TrackCooldownStore {
+	if cfg.App.TrackCooldownMins <= 0 {
+		return nil
+	}
+
+	logger.Info("Track re-request cooldown enabled",
+		zap.Int("cooldown_mins", cfg.App.TrackCooldownMins))
+	return store.NewCooldown(time.Duration(cfg.App.TrackCooldownMins) * time.Minute)
+}
+
+func createLeaderboard(cfg *core.Config) (core.LeaderboardStore, error) {
+	leaderboard, err := store.NewLeaderboard(cfg.App.LeaderboardPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create leaderboard store: %w", err)
+	}
+
+	if cfg.App.LeaderboardPath != "" {
+		logger.Info("Requester leaderboard persisted",
+			zap.String("path", cfg.App.LeaderboardPath))
+	}
+	return leaderboard, nil
+}
+
+func createWarningSubscribers(cfg *core.Config) (core.WarningSubscriberStore, error) {
+	warningSubscribers, err := store.NewWarningSubscribers(cfg.App.WarningSubscribersPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create warning subscribers store: %w", err)
+	}
+
+	if cfg.App.WarningSubscribersPath != "" {
+		logger.Info("Admin warning subscribers persisted",
+			zap.String("path", cfg.App.WarningSubscribersPath))
+	}
+	return warningSubscribers, nil
+}
+
+func createEventLog(cfg *core.Config) (*store.EventLog, error) {
+	if cfg.App.EventLogPath == "" {
+		return nil, nil
+	}
+
+	eventLog, err := store.NewEventLog(cfg.App.EventLogPath, int64(cfg.App.EventLogMaxSizeMB)*bytesPerMB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event log: %w", err)
+	}
+
+	logger.Info("Added track event log enabled",
+		zap.String("path", cfg.App.EventLogPath),
+		zap.Int("max_size_mb", cfg.App.EventLogMaxSizeMB))
+	return eventLog, nil
+}
+
+// checkPlaylistPermissions fails early if the authenticated Spotify account can't add tracks to the playlist.
+// Playlists that can't be checked, e.g. because they don't exist, are left to the playlist health monitor.
+func checkPlaylistPermissions(ctx context.Context, spotifyClient *spotify.Client, playlistID string) error {
+	writable, err := spotifyClient.CanModifyPlaylist(ctx, playlistID)
+	if err != nil {
+		logger.Warn("Could not check playlist permissions", zap.String("playlist_id", playlistID), zap.Error(err))
+		return nil
+	}
+	if !writable {
+		return playlistNotWritableError(playlistID)
+	}
+	return nil
+}
+
+// playlistNotWritableError explains why tracks can't be added to the playlist.
+func playlistNotWritableError(playlistID string) error {
+	return fmt.Errorf("the Spotify account can't add tracks to playlist %s: "+
+		"it must own the playlist or the playlist must be collaborative", playlistID)
+}
+
 func createLLMProvider() (core.LLMProvider, error) {
 	if config.LLM.Provider != noneProvider && config.LLM.Provider != "" {
 		provider, err := llm.NewProvider(&config.LLM, logger.Named("llm"))
@@ -405,6 +1155,12 @@ func runServices(ctx context.Context, svcs *services) error {
 		return svcs.dispatcher.Start(gCtx)
 	})
 
+	for _, group := range svcs.groups {
+		g.Go(func() error {
+			return group.dispatcher.Start(gCtx)
+		})
+	}
+
 	logger.Info("DJAlgoRhythm started successfully",
 		zap.String("http_addr", fmt.Sprintf("%s:%d", config.Server.Host, config.Server.Port)))
 
@@ -413,23 +1169,33 @@ func runServices(ctx context.Context, svcs *services) error {
 		// Still call Stop to send shutdown message
 		shutdownCtx, shutdownCancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownGracePeriod)
 		defer shutdownCancel()
-		if stopErr := svcs.dispatcher.Stop(shutdownCtx); stopErr != nil {
-			logger.Debug("Failed to stop dispatcher gracefully", zap.Error(stopErr))
-		}
+		svcs.stopDispatchers(shutdownCtx)
 		return err
 	}
 
 	// Graceful shutdown - call Stop to send shutdown message
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownGracePeriod)
 	defer shutdownCancel()
-	if err := svcs.dispatcher.Stop(shutdownCtx); err != nil {
-		logger.Debug("Failed to stop dispatcher gracefully", zap.Error(err))
-	}
+	svcs.stopDispatchers(shutdownCtx)
 
 	logger.Info("DJAlgoRhythm stopped gracefully")
 	return nil
 }
 
+// stopDispatchers stops the dispatchers of all groups, sending their shutdown messages.
+func (s *services) stopDispatchers(ctx context.Context) {
+	dispatchers := []*core.Dispatcher{s.dispatcher}
+	for _, group := range s.groups {
+		dispatchers = append(dispatchers, group.dispatcher)
+	}
+
+	for _, dispatcher := range dispatchers {
+		if err := dispatcher.Stop(ctx); err != nil {
+			logger.Debug("Failed to stop dispatcher gracefully", zap.Error(err))
+		}
+	}
+}
+
 func promptForTelegramGroup() (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeoutSecs*time.Second)
 	defer cancel()
@@ -438,13 +1204,18 @@ func promptForTelegramGroup() (int64, error) {
 
 	// Create a temporary Telegram frontend to list groups
 	telegramConfig := &telegram.Config{
-		BotToken:            config.Telegram.BotToken,
-		GroupID:             0, // Temporary - we'll set this after selection
-		AdminApproval:       config.Telegram.AdminApproval,
-		AdminNeedsApproval:  config.Telegram.AdminNeedsApproval,
-		CommunityApproval:   config.Telegram.CommunityApproval,
-		Language:            config.App.Language,
-		FloodLimitPerMinute: config.App.FloodLimitPerMinute,
+		BotToken:                 config.Telegram.BotToken,
+		GroupID:                  0, // Temporary - we'll set this after selection
+		AdminApproval:            config.Telegram.AdminApproval,
+		AdminNeedsApproval:       config.Telegram.AdminNeedsApproval,
+		CommunityApproval:        config.Telegram.CommunityApproval,
+		CommunityApprovalPercent: config.Telegram.CommunityApprovalPercent,
+		Language:                 config.App.Language,
+		MessageOverrides:         config.App.MessageOverrides,
+		FloodLimitPerMinute:      config.App.FloodLimitPerMinute,
+		FloodBurst:               config.App.FloodBurst,
+		MaxURLsPerMessage:        config.App.MaxURLsPerMessage,
+		ReconnectMaxBackoff:      time.Duration(config.Telegram.ReconnectMaxBackoffSecs) * time.Second,
 	}
 
 	tempFrontend := telegram.NewFrontend(telegramConfig, logger.Named("telegram-setup"))
@@ -507,19 +1278,138 @@ func validateConfig() error {
 		return err
 	}
 
+	validateConfigCombinations()
+	if viper.GetBool("strict-config") && len(configWarnings) > 0 {
+		return fmt.Errorf("--strict-config refuses %d configuration warning(s): %s",
+			len(configWarnings), strings.Join(configWarnings, "; "))
+	}
+
 	if err := validateSpotifyConfig(); err != nil {
 		return err
 	}
 
+	if err := validateServerConfig(); err != nil {
+		return err
+	}
+
 	if err := validateLLMConfig(); err != nil {
 		return err
 	}
 
+	if err := loadMessageOverrides(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateServerConfig checks that TLS is configured with both a certificate and its key.
+func validateServerConfig() error {
+	if (config.Server.TLSCertFile == "") != (config.Server.TLSKeyFile == "") {
+		return errors.New("TLS needs both --server-tls-cert and --server-tls-key")
+	}
+	if config.Server.HTTPRedirectPort > 0 && config.Server.HTTPRedirectPort == config.Server.Port {
+		return fmt.Errorf("--server-https-redirect-port (%d) must differ from --server-port",
+			config.Server.HTTPRedirectPort)
+	}
+	return nil
+}
+
+// validateConfigCombinations warns about settings that contradict each other or have no effect together.
+func validateConfigCombinations() {
+	approval := config.Telegram
+	if !approval.AdminApproval {
+		if approval.CommunityApproval > 0 || approval.CommunityApprovalPercent > 0 {
+			warnConfig("Community approval only applies to requests awaiting admin approval. " +
+				"Enable --admin-approval or remove --community-approval and --community-approval-percent")
+		}
+		if approval.AdminNeedsApproval {
+			warnConfig("--admin-needs-approval has no effect without --admin-approval. Enable --admin-approval or remove it")
+		}
+	}
+
+	if slices.Contains(config.Spotify.RecommendationSourcePlaylists, config.Spotify.PlaylistID) {
+		warnConfig("--recommendation-source-playlists contains the target playlist, whose tracks are never queued again. " +
+			"Remove it from the source playlists")
+	}
+
+	if config.Server.HTTPRedirectPort > 0 && config.Server.TLSCertFile == "" {
+		warnConfig("--server-https-redirect-port has no effect without TLS. Set --server-tls-cert and --server-tls-key or remove it")
+	}
+
+	if config.App.DisambiguationButtons && config.Matrix.Enabled {
+		warnConfig("--disambiguation-buttons is not supported by Matrix, which has no inline buttons. " +
+			"Asking to confirm the best search result instead")
+		config.App.DisambiguationButtons = false
+	}
+
+	if config.App.MinMembershipMins > 0 && !config.App.RequireMembership {
+		warnConfig("--min-membership-mins has no effect without --require-membership. Enable --require-membership or remove it")
+	}
+
+	if config.App.RequireMembership && config.Matrix.Enabled {
+		warnConfig("--require-membership is not supported by Matrix, which can't look up group members. " +
+			"Accepting requests of everyone instead")
+		config.App.RequireMembership = false
+	}
+
+	if len(config.Telegram.Groups) > 0 && config.Matrix.Enabled {
+		warnConfig("--telegram-groups is not supported by Matrix, which monitors a single room. " +
+			"Serving only --matrix-room-id instead")
+		config.Telegram.Groups = nil
+	}
+
+	if config.Telegram.TopicID != 0 && config.Matrix.Enabled {
+		warnConfig("--telegram-topic-id is not supported by Matrix, which has no topics. " +
+			"Serving the whole --matrix-room-id instead")
+		config.Telegram.TopicID = 0
+	}
+
+	if config.App.BlockExplicit && config.Spotify.PreferClean {
+		warnConfig("--prefer-clean has no effect with --block-explicit, as explicit tracks are rejected anyway. " +
+			"Remove one of them")
+	}
+
+	// Admins and VIPs get the longest timeout among their roles, so shorter ones are ignored
+	if config.App.ConfirmAdminTimeoutSecs < config.App.ConfirmTimeoutSecs {
+		warnConfig("--confirm-admin-timeout-secs (%d) is shorter than --confirm-timeout-secs (%d) and has no effect. "+
+			"Raise it", config.App.ConfirmAdminTimeoutSecs, config.App.ConfirmTimeoutSecs)
+	}
+	if config.App.ConfirmVIPTimeoutSecs < config.App.ConfirmTimeoutSecs {
+		warnConfig("--confirm-vip-timeout-secs (%d) is shorter than --confirm-timeout-secs (%d) and has no effect. "+
+			"Raise it", config.App.ConfirmVIPTimeoutSecs, config.App.ConfirmTimeoutSecs)
+	}
+}
+
+// loadMessageOverrides reads the messages file and checks its overrides against the bundled messages,
+// so invalid wording is reported at startup instead of garbling messages later.
+func loadMessageOverrides() error {
+	if config.App.MessagesFile == "" {
+		return nil
+	}
+
+	overrides, err := i18n.ReadOverridesFile(config.App.MessagesFile)
+	if err != nil {
+		return err
+	}
+
+	if err := i18n.NewLocalizer(config.App.Language).LoadOverrides(overrides); err != nil {
+		return fmt.Errorf("invalid messages file %s: %w", config.App.MessagesFile, err)
+	}
+
+	config.App.MessageOverrides = overrides
+	logger.Info("Loaded custom messages",
+		zap.String("file", config.App.MessagesFile),
+		zap.Int("count", len(overrides)))
 	return nil
 }
 
 func validateChatFrontends() error {
-	// Validate Telegram configuration (required)
+	if config.Matrix.Enabled {
+		return validateMatrixConfig()
+	}
+
+	// Validate Telegram configuration (required unless Matrix is used)
 	if config.Telegram.BotToken == "" {
 		return errors.New("telegram bot token is required")
 	}
@@ -536,6 +1426,20 @@ func validateChatFrontends() error {
 	return nil
 }
 
+func validateMatrixConfig() error {
+	if config.Matrix.HomeserverURL == "" {
+		return errors.New("matrix homeserver URL is required")
+	}
+	if config.Matrix.AccessToken == "" {
+		return errors.New("matrix access token is required")
+	}
+	if config.Matrix.RoomID == "" {
+		return errors.New("matrix room ID is required")
+	}
+
+	return nil
+}
+
 func validateSpotifyConfig() error {
 	if config.Spotify.ClientID == "" {
 		return errors.New("spotify client ID is required")
@@ -555,11 +1459,11 @@ func validateSpotifyConfig() error {
 func validateLLMConfig() error {
 	// AI provider is now required
 	if config.LLM.Provider == "" || config.LLM.Provider == noneProvider {
-		return errors.New("AI provider is required - please configure one of: openai, anthropic, ollama")
+		return errors.New("AI provider is required - please configure one of: openai, compatible, anthropic, ollama")
 	}
 
 	// Validate supported providers
-	validProviders := []string{"openai", "anthropic", "ollama"}
+	validProviders := []string{"openai", compatibleProvider, "anthropic", "ollama"}
 	isValid := false
 	for _, provider := range validProviders {
 		if config.LLM.Provider == provider {
@@ -572,6 +1476,14 @@ func validateLLMConfig() error {
 			config.LLM.Provider, strings.Join(validProviders, ", "))
 	}
 
+	// OpenAI-compatible endpoints are self-hosted, so they need a base URL but not necessarily a key
+	if config.LLM.Provider == compatibleProvider {
+		if config.LLM.BaseURL == "" {
+			return errors.New("base URL is required for compatible provider - set --llm-base-url")
+		}
+		return nil
+	}
+
 	// API key required for cloud providers
 	if config.LLM.APIKey == "" && config.LLM.Provider != "ollama" {
 		return fmt.Errorf("API key is required for %s provider", config.LLM.Provider)
@@ -613,11 +1525,14 @@ func generateEnvExampleContent(cmd *cobra.Command) string {
 
 	// Generate sections
 	generateTelegramSection(&content, cmd)
+	generateMatrixSection(&content, cmd)
 	generateSpotifySection(&content, cmd)
 	generateLLMSection(&content, cmd)
+	generateLLMResilienceSection(&content, cmd)
 	generateAppSection(&content, cmd)
 	generateServerSection(&content, cmd)
 	generateLoggingSection(&content, cmd)
+	generateStrictConfigSection(&content, cmd)
 	generateQuickSetupGuide(&content)
 
 	return content.String()
@@ -638,19 +1553,36 @@ func generateTelegramSection(content *strings.Builder, cmd *cobra.Command) {
 	content.WriteString("## -----------------------------------------------------------------------------\n")
 	content.WriteString("## Telegram Bot Setup\n")
 	content.WriteString("## -----------------------------------------------------------------------------\n")
-	content.WriteString("## CLI: --telegram-bot-token, --telegram-group-id\n")
+	content.WriteString("## CLI: --telegram-bot-token, --telegram-group-id, --telegram-reconnect-max-backoff-secs,\n")
+	content.WriteString("##      --telegram-welcome-message, --telegram-topic-id, --telegram-groups\n")
 
 	content.WriteString("## Bot token from @BotFather (REQUIRED)\n")
 	fmt.Fprintf(content, "%s=123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11\n",
 		flagToEnvVar("telegram-bot-token"))
 	content.WriteString("## Group ID (auto-detected if not set, get from @userinfobot)\n")
 	fmt.Fprintf(content, "%s=-100xxxxxxxxxx\n", flagToEnvVar("telegram-group-id"))
+	reconnectDefault := getDefaultValueString(cmd, "telegram-reconnect-max-backoff-secs")
+	fmt.Fprintf(content, "## Maximum seconds between reconnect attempts when polling fails (default: %s)\n", reconnectDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("telegram-reconnect-max-backoff-secs"), reconnectDefault)
+	welcomeDefault := getDefaultValueString(cmd, "telegram-welcome-message")
+	fmt.Fprintf(content, "## Post an introduction when the bot is added to the group (default: %s)\n", welcomeDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("telegram-welcome-message"), welcomeDefault)
+	content.WriteString("## Topic of a forum group to listen and reply in (optional, 0 uses the whole group)\n")
+	content.WriteString("## The topic ID is the number after the group in a topic link, e.g. 42 in t.me/c/123/42\n")
+	fmt.Fprintf(content, "# %s=42\n", flagToEnvVar("telegram-topic-id"))
+	content.WriteString("## Additional groups served by the bot as groupID=playlistID pairs (optional)\n")
+	content.WriteString("## Each group logs in to its own Spotify account and keeps its own state files\n")
+	content.WriteString("## Optional ;key=value settings override language, admin-approval, admin-needs-approval\n")
+	content.WriteString("## and community-approval for the group\n")
+	fmt.Fprintf(content, "# %s=-100yyyyyyyyyy=37i9dQZF1DXcBWIGoYBM5M;language=ch_be;admin-approval=true\n",
+		flagToEnvVar("telegram-groups"))
 	content.WriteString("\n")
 	content.WriteString("## Admin and Community Approval\n")
-	content.WriteString("## CLI: --admin-needs-approval, --community-approval\n")
+	content.WriteString("## CLI: --admin-needs-approval, --community-approval, --community-approval-percent\n")
 
 	adminDefault := getDefaultValueString(cmd, "admin-needs-approval")
 	communityDefault := getDefaultValueString(cmd, "community-approval")
+	communityPercentDefault := getDefaultValueString(cmd, "community-approval-percent")
 
 	content.WriteString("## Require admin approval for all songs (default: false)\n")
 	fmt.Fprintf(content, "%s=false\n", flagToEnvVar("admin-approval"))
@@ -658,10 +1590,46 @@ func generateTelegramSection(content *strings.Builder, cmd *cobra.Command) {
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("admin-needs-approval"), adminDefault)
 	fmt.Fprintf(content, "## 👍 reactions to bypass admin approval, 0=disabled (default: %s)\n", communityDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("community-approval"), communityDefault)
+	fmt.Fprintf(content, "## %% of group members whose 👍 bypass admin approval, overrides above, 0=disabled (default: %s)\n",
+		communityPercentDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("community-approval-percent"), communityPercentDefault)
+	content.WriteString("\n")
+	content.WriteString("## Duplicate Request Bumping\n")
+	content.WriteString("## CLI: --bump-votes, --bump-cooldown-mins\n")
+
+	bumpVotesDefault := getDefaultValueString(cmd, "bump-votes")
+	bumpCooldownDefault := getDefaultValueString(cmd, "bump-cooldown-mins")
+
+	fmt.Fprintf(content, "## 👍 reactions to bump a duplicate request to play next, 0=disabled (default: %s)\n",
+		bumpVotesDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("bump-votes"), bumpVotesDefault)
+	fmt.Fprintf(content, "## Minutes before the same track can be bumped again (default: %s)\n", bumpCooldownDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("bump-cooldown-mins"), bumpCooldownDefault)
+	content.WriteString("\n")
+}
+
+func generateMatrixSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Matrix Bot Setup (alternative to Telegram)\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --matrix-enabled, --matrix-homeserver-url, --matrix-access-token, --matrix-room-id\n")
+	content.WriteString("## Room members with power level 50 (moderator) or higher are admins.\n")
+	content.WriteString("## The approval settings above apply to Matrix as well.\n")
+
+	matrixDefault := getDefaultValueString(cmd, "matrix-enabled")
+
+	fmt.Fprintf(content, "## Use Matrix instead of Telegram (default: %s)\n", matrixDefault)
+	fmt.Fprintf(content, "# %s=true\n", flagToEnvVar("matrix-enabled"))
+	content.WriteString("## Homeserver URL of the bot account\n")
+	fmt.Fprintf(content, "# %s=https://matrix.org\n", flagToEnvVar("matrix-homeserver-url"))
+	content.WriteString("## Access token of the bot account (Element: Settings > Help & About > Access Token)\n")
+	fmt.Fprintf(content, "# %s=syt_xxxxxxxxxxxxxxxx\n", flagToEnvVar("matrix-access-token"))
+	content.WriteString("## Room ID or alias to monitor (the bot joins it on startup)\n")
+	fmt.Fprintf(content, "# %s=#party:matrix.org\n", flagToEnvVar("matrix-room-id"))
 	content.WriteString("\n")
 }
 
-func generateSpotifySection(content *strings.Builder, _ *cobra.Command) {
+func generateSpotifySection(content *strings.Builder, cmd *cobra.Command) {
 	content.WriteString("## =============================================================================\n")
 	content.WriteString("## SPOTIFY CONFIGURATION - Required\n")
 	content.WriteString("## =============================================================================\n")
@@ -679,8 +1647,31 @@ func generateSpotifySection(content *strings.Builder, _ *cobra.Command) {
 	fmt.Fprintf(content, "%s=http://127.0.0.1:8080/callback\n", flagToEnvVar("spotify-redirect-url"))
 	content.WriteString("## OAuth server bind address (default: same as server-host, use 0.0.0.0 in containers)\n")
 	fmt.Fprintf(content, "# %s=0.0.0.0\n", flagToEnvVar("spotify-oauth-bind-host"))
+	content.WriteString("## Print the authorization URL as a QR code for headless setups (default: false)\n")
+	fmt.Fprintf(content, "# %s=true\n", flagToEnvVar("oauth-qr"))
 	content.WriteString("## Token storage path (default: ./spotify_token.json)\n")
 	fmt.Fprintf(content, "%s=./spotify_token.json\n", flagToEnvVar("spotify-token-path"))
+	content.WriteString("## Device to transfer playback to when no device is active (optional)\n")
+	fmt.Fprintf(content, "# %s=Living Room\n", flagToEnvVar("spotify-device-name"))
+	content.WriteString("## Country code whose track availability searches reflect (default: the account's country)\n")
+	fmt.Fprintf(content, "# %s=CH\n", flagToEnvVar("spotify-market"))
+	phoneticDefault := getDefaultValueString(cmd, "phonetic-match-percent")
+	fmt.Fprintf(content, "## Weight in percent of how titles sound when ranking search results, 0 disables (default: %s)\n",
+		phoneticDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("phonetic-match-percent"), phoneticDefault)
+
+	strategyDefault := getDefaultValueString(cmd, "recommendation-strategy")
+	fmt.Fprintf(content, "## How queue-filling tracks are found: playlist, audio-features (default: %s)\n", strategyDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("recommendation-strategy"), strategyDefault)
+	content.WriteString("## Comma-separated playlist IDs to draw queue-filling tracks from before searching (optional)\n")
+	fmt.Fprintf(content, "# %s=37i9dQZF1DXcBWIGoYBM5M,37i9dQZF1DX0XUsuxWHRQd\n",
+		flagToEnvVar("recommendation-source-playlists"))
+	content.WriteString("## Genre or search query to start queue filling with while the playlist is empty (optional)\n")
+	fmt.Fprintf(content, "# %s=genre:house\n", flagToEnvVar("fallback-genre"))
+	recentlyPlayedDefault := getDefaultValueString(cmd, "recently-played-hours")
+	fmt.Fprintf(content, "## Hours in which played tracks aren't picked again when filling the queue, 0 disables (default: %s)\n",
+		recentlyPlayedDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("recently-played-hours"), recentlyPlayedDefault)
 	content.WriteString("\n")
 }
 
@@ -692,8 +1683,8 @@ func generateLLMSection(content *strings.Builder, _ *cobra.Command) {
 	content.WriteString("## -----------------------------------------------------------------------------\n")
 	content.WriteString("## LLM Provider Selection\n")
 	content.WriteString("## -----------------------------------------------------------------------------\n")
-	content.WriteString("## CLI: --llm-provider, --llm-api-key, --llm-model\n")
-	content.WriteString("## Provider: openai, anthropic, ollama (REQUIRED)\n")
+	content.WriteString("## CLI: --llm-provider, --llm-api-key, --llm-model, --llm-base-url\n")
+	content.WriteString("## Provider: openai, compatible, anthropic, ollama (REQUIRED)\n")
 	fmt.Fprintf(content, "%s=openai\n", flagToEnvVar("llm-provider"))
 	content.WriteString("\n")
 	content.WriteString("## -----------------------------------------------------------------------------\n")
@@ -706,6 +1697,17 @@ func generateLLMSection(content *strings.Builder, _ *cobra.Command) {
 	fmt.Fprintf(content, "# %s=gpt-4o-mini\n", flagToEnvVar("llm-model"))
 	content.WriteString("\n")
 	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## OpenAI-Compatible Configuration (vLLM, LM Studio, LocalAI, ...)\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Uncomment these lines and set DJALGORHYTHM_LLM_PROVIDER=compatible\n")
+	content.WriteString("## Base URL of the OpenAI-compatible API (REQUIRED)\n")
+	fmt.Fprintf(content, "# %s=http://localhost:8000/v1\n", flagToEnvVar("llm-base-url"))
+	content.WriteString("## Model name served by the endpoint\n")
+	fmt.Fprintf(content, "# %s=meta-llama/Llama-3.1-8B-Instruct\n", flagToEnvVar("llm-model"))
+	content.WriteString("## API key (optional, only if the endpoint requires one)\n")
+	fmt.Fprintf(content, "# %s=...\n", flagToEnvVar("llm-api-key"))
+	content.WriteString("\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
 	content.WriteString("## Anthropic Configuration\n")
 	content.WriteString("## -----------------------------------------------------------------------------\n")
 	content.WriteString("## Uncomment these lines and set DJALGORHYTHM_LLM_PROVIDER=anthropic\n")
@@ -725,6 +1727,25 @@ func generateLLMSection(content *strings.Builder, _ *cobra.Command) {
 	content.WriteString("\n")
 }
 
+func generateLLMResilienceSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## LLM Resilience - Stay responsive when the provider is slow or down\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --llm-timeout-secs, --llm-circuit-breaker-failures, --llm-circuit-breaker-cooldown-secs\n")
+
+	timeoutDefault := getDefaultValueString(cmd, "llm-timeout-secs")
+	failuresDefault := getDefaultValueString(cmd, "llm-circuit-breaker-failures")
+	cooldownDefault := getDefaultValueString(cmd, "llm-circuit-breaker-cooldown-secs")
+
+	fmt.Fprintf(content, "## Timeout per LLM request before falling back (default: %s)\n", timeoutDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("llm-timeout-secs"), timeoutDefault)
+	fmt.Fprintf(content, "## Consecutive failures that open the circuit breaker, 0 disables (default: %s)\n", failuresDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("llm-circuit-breaker-failures"), failuresDefault)
+	fmt.Fprintf(content, "## Seconds to skip LLM requests once the circuit breaker opened (default: %s)\n", cooldownDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("llm-circuit-breaker-cooldown-secs"), cooldownDefault)
+	content.WriteString("\n")
+}
+
 func generateAppSection(content *strings.Builder, cmd *cobra.Command) {
 	content.WriteString("## =============================================================================\n")
 	content.WriteString("## APPLICATION SETTINGS\n")
@@ -735,19 +1756,44 @@ func generateAppSection(content *strings.Builder, cmd *cobra.Command) {
 	generateAppQueueSection(content, cmd)
 	generateAppShadowQueueSection(content, cmd)
 	generateAppFloodPreventionSection(content, cmd)
+	generateAppUserQuotaSection(content, cmd)
+	generateAppContentFilterSection(content, cmd)
+	generateAppNearDuplicateSection(content, cmd)
+	generateAppDisambiguationSection(content, cmd)
+	generateAppMembershipSection(content, cmd)
+	generateAppTrackCooldownSection(content, cmd)
+	generateAppAlbumSection(content, cmd)
+	generateAppEpisodeSection(content, cmd)
+	generateAppRequestCleanupSection(content, cmd)
+	generateAppRequestPrefixSection(content)
+	generateAppRequestHoursSection(content)
+	generateAppEventLogSection(content, cmd)
+	generateAppLeaderboardSection(content)
+	generateAppWarningSubscriptionSection(content)
 }
 
 func generateAppLocalizationSection(content *strings.Builder, cmd *cobra.Command) {
 	content.WriteString("## -----------------------------------------------------------------------------\n")
 	content.WriteString("## Localization\n")
 	content.WriteString("## -----------------------------------------------------------------------------\n")
-	content.WriteString("## CLI: --language\n")
+	content.WriteString("## CLI: --language, --auto-detect-language, --messages-file, --added-reaction, --queued-reaction\n")
 
 	langDefault := getDefaultValueString(cmd, "language")
+	autoDetectDefault := getDefaultValueString(cmd, "auto-detect-language")
 	supportedLangs := strings.Join(i18n.GetSupportedLanguages(), ", ")
 
 	fmt.Fprintf(content, "## Bot language: %s (default: %s)\n", supportedLangs, langDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("language"), langDefault)
+	fmt.Fprintf(content, "## Reply in the requester's detected language when supported (default: %s)\n", autoDetectDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("auto-detect-language"), autoDetectDefault)
+	content.WriteString("## JSON or TOML file with custom wording for bot messages (optional)\n")
+	fmt.Fprintf(content, "# %s=./messages.toml\n", flagToEnvVar("messages-file"))
+	addedReactionDefault := getDefaultValueString(cmd, "added-reaction")
+	queuedReactionDefault := getDefaultValueString(cmd, "queued-reaction")
+	fmt.Fprintf(content, "## Reaction on requests added to the playlist (default: %s)\n", addedReactionDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("added-reaction"), addedReactionDefault)
+	fmt.Fprintf(content, "## Reaction on requests queued to play next (default: %s)\n", queuedReactionDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("queued-reaction"), queuedReactionDefault)
 	content.WriteString("\n")
 }
 
@@ -759,17 +1805,39 @@ func generateAppTimeoutsSection(content *strings.Builder, cmd *cobra.Command) {
 
 	confirmDefault := getDefaultValueString(cmd, "confirm-timeout-secs")
 	confirmAdminDefault := getDefaultValueString(cmd, "confirm-admin-timeout-secs")
+	confirmVIPDefault := getDefaultValueString(cmd, "confirm-vip-timeout-secs")
 	queueApprovalDefault := getDefaultValueString(cmd, "queue-track-approval-timeout-secs")
+	queueApprovalActionDefault := getDefaultValueString(cmd, "queue-approval-timeout-action")
 	maxReplacementsDefault := getDefaultValueString(cmd, "max-queue-track-replacements")
+	maxRetriesDefault := getDefaultValueString(cmd, "max-retries")
+	maxRetryWaitDefault := getDefaultValueString(cmd, "max-retry-wait-secs")
+	failedAdditionRetriesDefault := getDefaultValueString(cmd, "failed-addition-retries")
 
 	fmt.Fprintf(content, "## User confirmation timeout (default: %s)\n", confirmDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("confirm-timeout-secs"), confirmDefault)
 	fmt.Fprintf(content, "## Admin confirmation timeout (default: %s)\n", confirmAdminDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("confirm-admin-timeout-secs"), confirmAdminDefault)
+	fmt.Fprintf(content, "## VIP user confirmation timeout (default: %s)\n", confirmVIPDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("confirm-vip-timeout-secs"), confirmVIPDefault)
+	content.WriteString("## Comma-separated user IDs that get the VIP confirmation timeout (optional)\n")
+	fmt.Fprintf(content, "# %s=123456789,987654321\n", flagToEnvVar("vip-user-ids"))
+	content.WriteString("## Add song requests of VIP users without admin or community approval (default: false)\n")
+	fmt.Fprintf(content, "# %s=true\n", flagToEnvVar("vip-bypass-approval"))
 	fmt.Fprintf(content, "## Queue track approval timeout (default: %s)\n", queueApprovalDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("queue-track-approval-timeout-secs"), queueApprovalDefault)
+	fmt.Fprintf(content, "## Action on queue track approval timeout: accept, reject or replace (default: %s)\n",
+		queueApprovalActionDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("queue-approval-timeout-action"), queueApprovalActionDefault)
 	fmt.Fprintf(content, "## Max replacement attempts before auto-accept (default: %s)\n", maxReplacementsDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("max-queue-track-replacements"), maxReplacementsDefault)
+	fmt.Fprintf(content, "## Max retries for rate-limited Spotify requests (default: %s)\n", maxRetriesDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("max-retries"), maxRetriesDefault)
+	fmt.Fprintf(content, "## Longest wait before retrying a rate-limited Spotify request (default: %s)\n",
+		maxRetryWaitDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("max-retry-wait-secs"), maxRetryWaitDefault)
+	fmt.Fprintf(content, "## Background retries of failed playlist additions, 0 disables (default: %s)\n",
+		failedAdditionRetriesDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("failed-addition-retries"), failedAdditionRetriesDefault)
 	content.WriteString("\n")
 }
 
@@ -777,15 +1845,40 @@ func generateAppQueueSection(content *strings.Builder, cmd *cobra.Command) {
 	content.WriteString("## -----------------------------------------------------------------------------\n")
 	content.WriteString("## Queue Management - Ensures continuous playback\n")
 	content.WriteString("## -----------------------------------------------------------------------------\n")
-	content.WriteString("## CLI: --queue-ahead-duration-secs, --queue-check-interval-secs\n")
+	content.WriteString("## CLI: --queue-ahead-duration-secs, --queue-check-interval-secs, --announce-now-playing,\n")
+	content.WriteString("##      --max-consecutive-same-artist, --skip-cooldown-mins, --enforce-playback-settings,\n")
+	content.WriteString("##      --autodj-warning-percent, --show-album-art, --max-playlist-size\n")
 
 	queueAheadDefault := getDefaultValueString(cmd, "queue-ahead-duration-secs")
 	queueCheckDefault := getDefaultValueString(cmd, "queue-check-interval-secs")
+	announceDefault := getDefaultValueString(cmd, "announce-now-playing")
+	albumArtDefault := getDefaultValueString(cmd, "show-album-art")
+	sameArtistDefault := getDefaultValueString(cmd, "max-consecutive-same-artist")
+	skipCooldownDefault := getDefaultValueString(cmd, "skip-cooldown-mins")
+	enforceDefault := getDefaultValueString(cmd, "enforce-playback-settings")
+	autoDJDefault := getDefaultValueString(cmd, "autodj-warning-percent")
+	maxPlaylistSizeDefault := getDefaultValueString(cmd, "max-playlist-size")
 
 	fmt.Fprintf(content, "## Target queue duration ahead of current song (default: %s)\n", queueAheadDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("queue-ahead-duration-secs"), queueAheadDefault)
 	fmt.Fprintf(content, "## How often to check queue status (default: %s)\n", queueCheckDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("queue-check-interval-secs"), queueCheckDefault)
+	fmt.Fprintf(content, "## Announce the current track in the group on changes (default: %s)\n", announceDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("announce-now-playing"), announceDefault)
+	fmt.Fprintf(content, "## Post approval and now playing messages with the album cover art (default: %s)\n", albumArtDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("show-album-art"), albumArtDefault)
+	fmt.Fprintf(content, "## Avoid queue-filling tracks by the last N artists, 0 disables (default: %s)\n", sameArtistDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("max-consecutive-same-artist"), sameArtistDefault)
+	fmt.Fprintf(content, "## Minutes before skipped tracks are queued again, 0 disables (default: %s)\n", skipCooldownDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("skip-cooldown-mins"), skipCooldownDefault)
+	fmt.Fprintf(content, "## Turn shuffle and repeat off on changes instead of only warning admins (default: %s)\n",
+		enforceDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("enforce-playback-settings"), enforceDefault)
+	fmt.Fprintf(content, "## Warn admins when the auto-DJ picked more than this %% of the last 10 tracks, 0 disables (default: %s)\n",
+		autoDJDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("autodj-warning-percent"), autoDJDefault)
+	fmt.Fprintf(content, "## Remove the oldest playlist tracks beyond this size, 0 disables (default: %s)\n", maxPlaylistSizeDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("max-playlist-size"), maxPlaylistSizeDefault)
 	content.WriteString("## Warning timeout for queue sync issues (default: 30)\n")
 	fmt.Fprintf(content, "%s=30\n", flagToEnvVar("queue-sync-warning-timeout-minutes"))
 	content.WriteString("\n")
@@ -795,16 +1888,23 @@ func generateAppShadowQueueSection(content *strings.Builder, cmd *cobra.Command)
 	content.WriteString("## -----------------------------------------------------------------------------\n")
 	content.WriteString("## Shadow Queue - Maintains reliable queue state tracking\n")
 	content.WriteString("## -----------------------------------------------------------------------------\n")
-	content.WriteString("## CLI: --shadow-queue-maintenance-interval-mins, --shadow-queue-max-age-hours\n")
+	content.WriteString("## CLI: --shadow-queue-maintenance-interval-mins, --shadow-queue-max-age-hours,\n")
+	content.WriteString("##      --shadow-queue-path, --shadow-queue-save-interval-secs\n")
 
 	shadowMaintenanceDefault := getDefaultValueString(cmd, "shadow-queue-maintenance-interval-mins")
 	shadowMaxAgeDefault := getDefaultValueString(cmd, "shadow-queue-max-age-hours")
+	shadowSaveIntervalDefault := getDefaultValueString(cmd, "shadow-queue-save-interval-secs")
 
 	fmt.Fprintf(content, "## Maintenance interval in seconds (CLI uses minutes!) (default: from %s mins)\n",
 		shadowMaintenanceDefault)
 	fmt.Fprintf(content, "%s=30\n", flagToEnvVar("shadow-queue-maintenance-interval-secs"))
 	fmt.Fprintf(content, "## Max age of shadow queue items (default: %s)\n", shadowMaxAgeDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("shadow-queue-max-age-hours"), shadowMaxAgeDefault)
+	content.WriteString("## Persist the shadow queue across restarts (optional)\n")
+	fmt.Fprintf(content, "# %s=./shadow_queue.json\n", flagToEnvVar("shadow-queue-path"))
+	fmt.Fprintf(content, "## Save interval of the persisted shadow queue in seconds (default: %s)\n",
+		shadowSaveIntervalDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("shadow-queue-save-interval-secs"), shadowSaveIntervalDefault)
 	content.WriteString("\n")
 }
 
@@ -812,12 +1912,240 @@ func generateAppFloodPreventionSection(content *strings.Builder, cmd *cobra.Comm
 	content.WriteString("## -----------------------------------------------------------------------------\n")
 	content.WriteString("## Flood Prevention - Anti-spam protection\n")
 	content.WriteString("## -----------------------------------------------------------------------------\n")
-	content.WriteString("## CLI: --flood-limit-per-minute\n")
+	content.WriteString("## CLI: --flood-limit-per-minute, --flood-burst\n")
 
 	floodDefault := getDefaultValueString(cmd, "flood-limit-per-minute")
+	floodBurstDefault := getDefaultValueString(cmd, "flood-burst")
 
 	fmt.Fprintf(content, "## Max messages per user per minute (default: %s)\n", floodDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("flood-limit-per-minute"), floodDefault)
+	fmt.Fprintf(content, "## Extra messages allowed in quick succession on top of the limit (default: %s)\n", floodBurstDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("flood-burst"), floodBurstDefault)
+	content.WriteString("\n")
+
+	content.WriteString("## CLI: --max-urls-per-message\n")
+
+	maxURLsDefault := getDefaultValueString(cmd, "max-urls-per-message")
+
+	fmt.Fprintf(content, "## Max links processed per message, further links are ignored (default: %s)\n", maxURLsDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("max-urls-per-message"), maxURLsDefault)
+	content.WriteString("\n")
+
+	content.WriteString("## CLI: --max-concurrent-requests\n")
+
+	concurrentDefault := getDefaultValueString(cmd, "max-concurrent-requests")
+
+	fmt.Fprintf(content, "## Max requests processed simultaneously, 0 for no limit (default: %s)\n", concurrentDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("max-concurrent-requests"), concurrentDefault)
+	content.WriteString("\n")
+}
+
+func generateAppUserQuotaSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## User Quotas - Limit songs per user and event\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --max-requests-per-user, --user-quota-window-hours, --user-quota-path\n")
+
+	maxRequestsDefault := getDefaultValueString(cmd, "max-requests-per-user")
+	windowDefault := getDefaultValueString(cmd, "user-quota-window-hours")
+
+	fmt.Fprintf(content, "## Max accepted songs per user and window, 0 disables (default: %s)\n", maxRequestsDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("max-requests-per-user"), maxRequestsDefault)
+	fmt.Fprintf(content, "## Hours until a user's quota resets (default: %s)\n", windowDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("user-quota-window-hours"), windowDefault)
+	content.WriteString("## Persist quotas across restarts (optional)\n")
+	fmt.Fprintf(content, "# %s=./user_quotas.json\n", flagToEnvVar("user-quota-path"))
+	content.WriteString("\n")
+}
+
+func generateAppContentFilterSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Content Filter - Family-friendly events\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --block-explicit, --prefer-clean, --min-track-secs, --max-track-secs\n")
+
+	blockDefault := getDefaultValueString(cmd, "block-explicit")
+	preferCleanDefault := getDefaultValueString(cmd, "prefer-clean")
+	minTrackDefault := getDefaultValueString(cmd, "min-track-secs")
+	maxTrackDefault := getDefaultValueString(cmd, "max-track-secs")
+
+	fmt.Fprintf(content, "## Reject requests for explicit tracks (default: %s)\n", blockDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("block-explicit"), blockDefault)
+	fmt.Fprintf(content, "## Rank explicit tracks below clean ones in search results (default: %s)\n", preferCleanDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("prefer-clean"), preferCleanDefault)
+	fmt.Fprintf(content, "## Reject tracks shorter than this many seconds, 0 disables (default: %s)\n", minTrackDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("min-track-secs"), minTrackDefault)
+	fmt.Fprintf(content, "## Reject tracks longer than this many seconds, 0 disables (default: %s)\n", maxTrackDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("max-track-secs"), maxTrackDefault)
+	content.WriteString("\n")
+}
+
+func generateAppNearDuplicateSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Near-Duplicates - Catch other versions of songs already in the playlist\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --near-duplicate-threshold-percent\n")
+
+	thresholdDefault := getDefaultValueString(cmd, "near-duplicate-threshold-percent")
+
+	fmt.Fprintf(content, "## Title similarity in percent to ask before adding, 0 disables (default: %s)\n", thresholdDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("near-duplicate-threshold-percent"), thresholdDefault)
+	content.WriteString("\n")
+}
+
+func generateAppDisambiguationSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Disambiguation Buttons - Let requesters pick among the best search results\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --disambiguation-buttons, --disambiguation-choices, --auto-accept-threshold\n")
+
+	buttonsDefault := getDefaultValueString(cmd, "disambiguation-buttons")
+	choicesDefault := getDefaultValueString(cmd, "disambiguation-choices")
+	autoAcceptDefault := getDefaultValueString(cmd, "auto-accept-threshold")
+
+	fmt.Fprintf(content, "## Offer the best search results as buttons, Telegram only (default: %s)\n", buttonsDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("disambiguation-buttons"), buttonsDefault)
+	fmt.Fprintf(content, "## Number of search results offered (default: %s)\n", choicesDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("disambiguation-choices"), choicesDefault)
+	fmt.Fprintf(content, "## Search relevance in percent to add a clear top result without confirmation, 0 disables (default: %s)\n",
+		autoAcceptDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("auto-accept-threshold"), autoAcceptDefault)
+	content.WriteString("\n")
+}
+
+func generateAppMembershipSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Requester Membership - Keep anonymous and freshly joined users out\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --require-membership, --min-membership-mins\n")
+
+	requireDefault := getDefaultValueString(cmd, "require-membership")
+	minMinsDefault := getDefaultValueString(cmd, "min-membership-mins")
+
+	fmt.Fprintf(content, "## Only accept requests of group members, Telegram only (default: %s)\n", requireDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("require-membership"), requireDefault)
+	fmt.Fprintf(content, "## Minutes members must have been in the group, 0 disables (default: %s)\n", minMinsDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("min-membership-mins"), minMinsDefault)
+	content.WriteString("\n")
+}
+
+func generateAppTrackCooldownSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Track Cooldown - Keep the crowd from re-requesting the same banger\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --track-cooldown-mins\n")
+
+	cooldownDefault := getDefaultValueString(cmd, "track-cooldown-mins")
+
+	fmt.Fprintf(content, "## Minutes before an added track can be requested again, 0 disables (default: %s)\n", cooldownDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("track-cooldown-mins"), cooldownDefault)
+	content.WriteString("\n")
+}
+
+func generateAppAlbumSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Album Links - Add every track of a shared Spotify album after confirmation\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --max-album-tracks\n")
+
+	maxTracksDefault := getDefaultValueString(cmd, "max-album-tracks")
+
+	fmt.Fprintf(content, "## Maximum tracks of an album that can be added at once, 0 disables (default: %s)\n", maxTracksDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("max-album-tracks"), maxTracksDefault)
+	content.WriteString("\n")
+}
+
+func generateAppEpisodeSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Podcast Episodes - Queue shared Spotify episodes next to the playlist\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --allow-episodes\n")
+
+	allowDefault := getDefaultValueString(cmd, "allow-episodes")
+
+	fmt.Fprintf(content, "## Queue episode links instead of rejecting them (default: %s)\n", allowDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("allow-episodes"), allowDefault)
+	content.WriteString("\n")
+}
+
+func generateAppRequestCleanupSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Request Cleanup - Keep the group tidy\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --delete-request-messages\n")
+
+	deleteDefault := getDefaultValueString(cmd, "delete-request-messages")
+
+	fmt.Fprintf(content, "## Delete request messages after their song was added, needs delete permission (default: %s)\n",
+		deleteDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("delete-request-messages"), deleteDefault)
+	content.WriteString("\n")
+}
+
+func generateAppRequestPrefixSection(content *strings.Builder) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Request Prefix - Only treat marked messages as song requests in chatty groups\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --request-prefix\n")
+	content.WriteString("## Messages must start with the prefix, others are ignored (default: empty, all messages are requests)\n")
+	fmt.Fprintf(content, "# %s=!play\n", flagToEnvVar("request-prefix"))
+	content.WriteString("\n")
+}
+
+func generateAppRequestHoursSection(content *strings.Builder) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Request Hours - Only accept requests during opening hours\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --request-hours\n")
+	content.WriteString("## Daily window, may span midnight (default: empty, accepts requests any time)\n")
+	fmt.Fprintf(content, "# %s=18:00-02:00\n", flagToEnvVar("request-hours"))
+	content.WriteString("\n")
+
+	content.WriteString("## CLI: --request-timezone\n")
+	content.WriteString("## IANA time zone of the request hours (default: empty, uses the local time zone)\n")
+	fmt.Fprintf(content, "# %s=Europe/Zurich\n", flagToEnvVar("request-timezone"))
+	content.WriteString("\n")
+}
+
+func generateAppEventLogSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Event Log - JSON line per added track for post-event analytics\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --event-log-path, --event-log-max-size-mb\n")
+
+	maxSizeDefault := getDefaultValueString(cmd, "event-log-max-size-mb")
+
+	content.WriteString("## Path of the event log, empty disables (optional)\n")
+	fmt.Fprintf(content, "# %s=./events.jsonl\n", flagToEnvVar("event-log-path"))
+	fmt.Fprintf(content, "## Rotate the event log after this many megabytes (default: %s)\n", maxSizeDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("event-log-max-size-mb"), maxSizeDefault)
+	content.WriteString("\n")
+}
+
+func generateAppLeaderboardSection(content *strings.Builder) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Leaderboard - All-time top requesters for recurring events\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --leaderboard-path, --shutdown-summary\n")
+
+	content.WriteString("## Persist /leaderboard request counts across restarts (optional)\n")
+	fmt.Fprintf(content, "# %s=./leaderboard.json\n", flagToEnvVar("leaderboard-path"))
+	content.WriteString("## Sum up the songs added and the top requester when going offline (default: false)\n")
+	fmt.Fprintf(content, "%s=false\n", flagToEnvVar("shutdown-summary"))
+	content.WriteString("\n")
+}
+
+func generateAppWarningSubscriptionSection(content *strings.Builder) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Admin Warning Subscriptions - Warnings by DM for operators sending /start to the bot\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --warning-subscribers-path, --warning-subscribe-token\n")
+
+	content.WriteString("## File persisting the users who opted in to admin warnings across restarts\n")
+	content.WriteString("## (default: ./warning_subscribers.json, empty keeps them in memory)\n")
+	fmt.Fprintf(content, "%s=./warning_subscribers.json\n", flagToEnvVar("warning-subscribers-path"))
+	content.WriteString("## Let non-admins opt in via https://t.me/<bot>?start=<token> (optional, keep it secret)\n")
+	fmt.Fprintf(content, "# %s=\n", flagToEnvVar("warning-subscribe-token"))
 	content.WriteString("\n")
 }
 
@@ -825,7 +2153,8 @@ func generateServerSection(content *strings.Builder, cmd *cobra.Command) {
 	content.WriteString("## -----------------------------------------------------------------------------\n")
 	content.WriteString("## HTTP Server Configuration\n")
 	content.WriteString("## -----------------------------------------------------------------------------\n")
-	content.WriteString("## CLI: --server-host, --server-port\n")
+	content.WriteString("## CLI: --server-host, --server-port, --metrics-enabled, --server-admin-token, --dashboard-enabled,\n")
+	content.WriteString("##      --server-*-timeout-secs, --server-tls-cert, --server-tls-key, --server-https-redirect-port\n")
 
 	hostDefault := getDefaultValueString(cmd, "server-host")
 	portDefault := getDefaultValueString(cmd, "server-port")
@@ -834,6 +2163,27 @@ func generateServerSection(content *strings.Builder, cmd *cobra.Command) {
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("server-host"), "127.0.0.1")
 	fmt.Fprintf(content, "## Server port (default: %s)\n", portDefault)
 	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("server-port"), portDefault)
+	content.WriteString("## Expose Prometheus metrics at /metrics (default: true)\n")
+	fmt.Fprintf(content, "%s=true\n", flagToEnvVar("metrics-enabled"))
+	content.WriteString("## Bearer token for the /approvals admin endpoints (default: empty, endpoints disabled)\n")
+	fmt.Fprintf(content, "%s=\n", flagToEnvVar("server-admin-token"))
+	content.WriteString("## Serve a live dashboard with the current track, queue and pending approvals at / (default: false)\n")
+	fmt.Fprintf(content, "%s=false\n", flagToEnvVar("dashboard-enabled"))
+
+	timeoutDefault := getDefaultValueString(cmd, "server-read-timeout-secs")
+	idleDefault := getDefaultValueString(cmd, "server-idle-timeout-secs")
+	fmt.Fprintf(content, "## Seconds to read a request and to write a response (default: %s)\n", timeoutDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("server-read-timeout-secs"), timeoutDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("server-write-timeout-secs"), timeoutDefault)
+	fmt.Fprintf(content, "## Seconds idle keep-alive connections stay open (default: %s)\n", idleDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("server-idle-timeout-secs"), idleDefault)
+	fmt.Fprintf(content, "## Seconds in-flight requests may take to finish on shutdown (default: %s)\n", timeoutDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("server-shutdown-timeout-secs"), timeoutDefault)
+	content.WriteString("## Serve HTTPS, e.g. to expose the Spotify OAuth callback publicly (default: HTTP)\n")
+	fmt.Fprintf(content, "# %s=/etc/djalgorhythm/tls/cert.pem\n", flagToEnvVar("server-tls-cert"))
+	fmt.Fprintf(content, "# %s=/etc/djalgorhythm/tls/key.pem\n", flagToEnvVar("server-tls-key"))
+	content.WriteString("## Port redirecting plain HTTP to HTTPS (default: 0, disabled)\n")
+	fmt.Fprintf(content, "# %s=80\n", flagToEnvVar("server-https-redirect-port"))
 	content.WriteString("\n")
 }
 
@@ -852,6 +2202,20 @@ func generateLoggingSection(content *strings.Builder, cmd *cobra.Command) {
 	content.WriteString("\n")
 }
 
+func generateStrictConfigSection(content *strings.Builder, cmd *cobra.Command) {
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## Startup Checks - Fail fast on questionable configuration\n")
+	content.WriteString("## -----------------------------------------------------------------------------\n")
+	content.WriteString("## CLI: --strict-config\n")
+
+	strictDefault := getDefaultValueString(cmd, "strict-config")
+
+	content.WriteString("## Refuse to start on configuration warnings, e.g. contradicting settings\n")
+	fmt.Fprintf(content, "## instead of working around them (default: %s)\n", strictDefault)
+	fmt.Fprintf(content, "%s=%s\n", flagToEnvVar("strict-config"), strictDefault)
+	content.WriteString("\n")
+}
+
 func generateQuickSetupGuide(content *strings.Builder) {
 	generateSetupGuideHeader(content)
 	generateSetupSteps(content)
@@ -916,7 +2280,7 @@ func generateTroubleshootingSection(content *strings.Builder) {
 	content.WriteString("## - Check rate limits and quotas for paid providers\n")
 	content.WriteString("\n")
 	content.WriteString("## Issue: \"Songs not being added\"\n")
-	content.WriteString("## - Check playlist permissions (must be public or owned by auth user)\n")
+	content.WriteString("## - Check playlist permissions (must be owned by the auth user or collaborative)\n")
 	content.WriteString("## - Verify Spotify Premium account (required for queue manipulation)\n")
 	content.WriteString("## - Check logs with DJALGORHYTHM_LOG_LEVEL=debug\n")
 }
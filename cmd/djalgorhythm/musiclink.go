@@ -1,31 +1,33 @@
-package core
+package main
 
 import (
 	"context"
 
+	"djalgorhythm/internal/core"
 	"djalgorhythm/pkg/musiclink"
 )
 
 // musicLinkManagerAdapter adapts pkg/musiclink.ManagerAdapter to core.MusicLinkResolver.
+// It lives here rather than in core, since pkg/musiclink uses pkg/text, which depends on core.
 type musicLinkManagerAdapter struct {
 	manager *musiclink.ManagerAdapter
 }
 
-// NewMusicLinkManagerAdapter creates a new adapter for the music link manager.
-func NewMusicLinkManagerAdapter() MusicLinkResolver {
+// newMusicLinkManagerAdapter creates a new adapter for the music link manager.
+func newMusicLinkManagerAdapter() core.MusicLinkResolver {
 	return &musicLinkManagerAdapter{
 		manager: musiclink.NewManagerAdapter(),
 	}
 }
 
 // Resolve resolves a music link to track information.
-func (a *musicLinkManagerAdapter) Resolve(ctx context.Context, url string) (*MusicLinkTrackInfo, error) {
+func (a *musicLinkManagerAdapter) Resolve(ctx context.Context, url string) (*core.MusicLinkTrackInfo, error) {
 	info, err := a.manager.Resolve(ctx, url)
 	if err != nil {
 		return nil, err
 	}
 
-	return &MusicLinkTrackInfo{
+	return &core.MusicLinkTrackInfo{
 		Title:  info.Title,
 		Artist: info.Artist,
 		ISRC:   info.ISRC,
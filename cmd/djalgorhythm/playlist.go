@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"djalgorhythm/internal/spotify"
+)
+
+const playlistBackupFilePermissions = 0600
+
+// playlistBackup is the JSON file written by "playlist backup" and read by "playlist restore".
+type playlistBackup struct {
+	PlaylistID string    `json:"playlist_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	TrackURIs  []string  `json:"track_uris"`
+}
+
+func newPlaylistCmd() *cobra.Command {
+	playlistCmd := &cobra.Command{
+		Use:   "playlist",
+		Short: "Back up and restore the target Spotify playlist",
+	}
+
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Write the tracks of the target playlist to a JSON file",
+		Args:  cobra.NoArgs,
+		RunE:  runPlaylistBackup,
+	}
+	backupCmd.Flags().StringP("output", "o", "",
+		"Backup file to write (default: playlist-backup-<playlist>-<timestamp>.json)")
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Replace the target playlist with the tracks from a backup file",
+		Long: `Replace the target playlist with the tracks from a backup file, removing everything
+added since the backup was taken. Restores into --spotify-playlist-id, or into the
+playlist the backup was taken from if none is configured.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runPlaylistRestore,
+	}
+
+	playlistCmd.AddCommand(backupCmd, restoreCmd)
+	return playlistCmd
+}
+
+func runPlaylistBackup(cmd *cobra.Command, _ []string) error {
+	if err := validateSpotifyConfig(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	spotifyClient, err := createPlaylistSpotifyClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	trackURIs, err := spotifyClient.ExportPlaylist(ctx, config.Spotify.PlaylistID)
+	if err != nil {
+		return fmt.Errorf("failed to export playlist: %w", err)
+	}
+
+	backup := playlistBackup{
+		PlaylistID: config.Spotify.PlaylistID,
+		CreatedAt:  time.Now().UTC(),
+		TrackURIs:  trackURIs,
+	}
+
+	outputPath, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to read output flag: %w", err)
+	}
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("playlist-backup-%s-%s.json", backup.PlaylistID, backup.CreatedAt.Format("20060102-150405"))
+	}
+
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal playlist backup: %w", err)
+	}
+	if err := os.WriteFile(outputPath, data, playlistBackupFilePermissions); err != nil {
+		return fmt.Errorf("failed to write playlist backup: %w", err)
+	}
+
+	fmt.Printf("✅ Backed up %d tracks of playlist %s to %s\n", len(trackURIs), backup.PlaylistID, outputPath)
+	return nil
+}
+
+func runPlaylistRestore(_ *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read playlist backup: %w", err)
+	}
+
+	var backup playlistBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return fmt.Errorf("failed to parse playlist backup: %w", err)
+	}
+
+	if config.Spotify.PlaylistID == "" {
+		config.Spotify.PlaylistID = backup.PlaylistID
+	}
+	if err := validateSpotifyConfig(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	spotifyClient, err := createPlaylistSpotifyClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	trackIDs := make([]string, 0, len(backup.TrackURIs))
+	for _, trackURI := range backup.TrackURIs {
+		trackID, err := spotifyClient.ExtractTrackID(trackURI)
+		if err != nil {
+			return fmt.Errorf("invalid track %q in playlist backup: %w", trackURI, err)
+		}
+		trackIDs = append(trackIDs, trackID)
+	}
+
+	if err := spotifyClient.ReplacePlaylistContents(ctx, config.Spotify.PlaylistID, trackIDs); err != nil {
+		return fmt.Errorf("failed to restore playlist: %w", err)
+	}
+
+	fmt.Printf("✅ Restored %d tracks from %s to playlist %s\n", len(trackIDs), args[0], config.Spotify.PlaylistID)
+	return nil
+}
+
+// createPlaylistSpotifyClient creates an authenticated Spotify client for the playlist commands.
+func createPlaylistSpotifyClient(ctx context.Context) (*spotify.Client, error) {
+	spotifyClient := spotify.NewClient(&config.Spotify, logger.Named("spotify"), nil, nil)
+	spotifyClient.SetRetryPolicy(config.App.MaxRetries, time.Duration(config.App.MaxRetryWaitSecs)*time.Second)
+	if err := spotifyClient.Authenticate(ctx); err != nil {
+		return nil, fmt.Errorf("failed to authenticate with Spotify: %w", err)
+	}
+	return spotifyClient, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"djalgorhythm/internal/core"
+	"djalgorhythm/internal/spotify"
+)
+
+// seedCommentPrefix starts lines of a seed file that are ignored.
+const seedCommentPrefix = "#"
+
+func newSeedCmd() *cobra.Command {
+	seedCmd := &cobra.Command{
+		Use:   "seed <file>",
+		Short: "Add the songs listed in a file to the target playlist",
+		Long: `Add the songs listed in a file to the target playlist before an event. Each line is a
+Spotify track link or a search like "artist - title"; empty lines and lines starting with #
+are skipped. Songs already in the playlist or listed twice are added once. Exits with an error
+if any line could not be resolved or added.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runSeed,
+	}
+	seedCmd.Flags().Bool("dry-run", false, "Show the songs that would be added without changing the playlist")
+	return seedCmd
+}
+
+func runSeed(cmd *cobra.Command, args []string) error {
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to read dry-run flag: %w", err)
+	}
+
+	lines, err := readSeedFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := validateSpotifyConfig(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	spotifyClient, err := createPlaylistSpotifyClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	trackURIs, err := spotifyClient.ExportPlaylist(ctx, config.Spotify.PlaylistID)
+	if err != nil {
+		return fmt.Errorf("failed to read playlist: %w", err)
+	}
+	known := make(map[string]struct{}, len(trackURIs)+len(lines))
+	for _, trackURI := range trackURIs {
+		if trackID, err := spotifyClient.ExtractTrackID(trackURI); err == nil {
+			known[trackID] = struct{}{}
+		}
+	}
+
+	var added, skipped, failed int
+	for _, line := range lines {
+		track, err := resolveSeedTrack(ctx, spotifyClient, line)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", line, err)
+			failed++
+			continue
+		}
+
+		if _, duplicate := known[track.ID]; duplicate {
+			fmt.Printf("⏭️ %s - %s is already in the playlist\n", track.Artist, track.Title)
+			skipped++
+			continue
+		}
+		known[track.ID] = struct{}{}
+
+		if dryRun {
+			fmt.Printf("➕ Would add %s - %s\n", track.Artist, track.Title)
+			added++
+			continue
+		}
+
+		if err := spotifyClient.AddToPlaylist(ctx, config.Spotify.PlaylistID, track.ID); err != nil {
+			fmt.Printf("❌ %s: failed to add %s - %s: %v\n", line, track.Artist, track.Title, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ Added %s - %s\n", track.Artist, track.Title)
+		added++
+	}
+
+	if dryRun {
+		fmt.Printf("\nDry run: %d would be added, %d already in the playlist, %d failed\n", added, skipped, failed)
+	} else {
+		fmt.Printf("\n%d added to playlist %s, %d already in the playlist, %d failed\n",
+			added, config.Spotify.PlaylistID, skipped, failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d seed lines failed", failed, len(lines))
+	}
+	return nil
+}
+
+// readSeedFile returns the song lines of a seed file, without empty lines and comments.
+func readSeedFile(path string) ([]string, error) {
+	file, err := os.Open(path) // #nosec G304 The seed file is chosen by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to open seed file: %w", err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, seedCommentPrefix) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read seed file: %w", err)
+	}
+	return lines, nil
+}
+
+// resolveSeedTrack resolves a seed file line to a track, by its Spotify link or by searching for it.
+func resolveSeedTrack(ctx context.Context, spotifyClient *spotify.Client, line string) (*core.Track, error) {
+	if trackID, err := spotifyClient.ExtractTrackID(line); err == nil {
+		return spotifyClient.GetTrack(ctx, trackID)
+	}
+	if strings.HasPrefix(line, "http://") || strings.HasPrefix(line, "https://") {
+		return nil, errors.New("not a Spotify track link")
+	}
+
+	tracks, err := spotifyClient.SearchTrack(ctx, line)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	if len(tracks) == 0 {
+		return nil, errors.New("no matching track found")
+	}
+	return &tracks[0], nil
+}
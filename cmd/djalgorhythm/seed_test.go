@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReadSeedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.txt")
+	content := "# Warm-up\n" +
+		"https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC\n" +
+		"\n" +
+		"   Daft Punk - One More Time   \n" +
+		"  # indented comment\n" +
+		"Queen - Bohemian Rhapsody"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write seed file: %v", err)
+	}
+
+	lines, err := readSeedFile(path)
+	if err != nil {
+		t.Fatalf("readSeedFile failed: %v", err)
+	}
+
+	want := []string{
+		"https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC",
+		"Daft Punk - One More Time",
+		"Queen - Bohemian Rhapsody",
+	}
+	if !slices.Equal(lines, want) {
+		t.Errorf("Expected %q, got %q", want, lines)
+	}
+}
+
+func TestReadSeedFile_MissingFile(t *testing.T) {
+	if _, err := readSeedFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("Expected an error for a missing seed file")
+	}
+}
@@ -0,0 +1,545 @@
+// Package fake provides an in-memory chat.Frontend for tests, with programmable responses
+// and inspection of everything the bot sent.
+package fake
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/i18n"
+)
+
+// BotUserID is the user ID of the fake bot returned by GetMe.
+const BotUserID int64 = 1
+
+// DefaultMaxMessageLength is the message length limit reported by MaxMessageLength unless set otherwise.
+const DefaultMaxMessageLength = 4096
+
+// ErrNoMessageHandler is returned by Deliver if Listen has not been called yet.
+var ErrNoMessageHandler = errors.New("no message handler registered, call Listen first")
+
+// SentMessage is a message the bot sent through the fake frontend.
+type SentMessage struct {
+	ID        string
+	ChatID    string // Chat ID, or the user ID for direct messages
+	ReplyToID string
+	Text      string
+	Direct    bool          // Sent with SendDirectMessage
+	TrackID   string        // Set for queue track approval messages
+	Choices   []chat.Choice // Set for messages sent with SendChoices
+	PhotoURL  string        // Set for messages sent with SendPhoto, the text is the caption
+}
+
+// SentReaction is a reaction the bot added to a message.
+type SentReaction struct {
+	ChatID    string
+	MessageID string
+	Reaction  chat.Reaction
+}
+
+// commandRegistration holds a registered chat command handler.
+type commandRegistration struct {
+	adminOnly bool
+	handler   chat.CommandHandler
+}
+
+// Frontend is an in-memory chat.Frontend. It never blocks on approvals: queued decisions are
+// returned in order and an empty queue behaves like a timeout.
+type Frontend struct {
+	mutex     sync.Mutex
+	nextID    int
+	localizer *i18n.Localizer
+
+	// Programmable responses
+	admins             []string
+	approvals          []bool
+	adminApprovals     []bool
+	communityApprovals []bool
+	adminApproval      bool
+	memberCount        int
+	maxMessageLength   int
+	chatMembers        map[int64]*chat.ChatMember
+
+	// Recorded output
+	sent      []SentMessage
+	reactions []SentReaction
+	removed   []SentReaction
+	deleted   []string
+	pinned    []string
+	edited    map[string]string
+
+	// Registered handlers
+	messageHandler            func(*chat.Message)
+	queueTrackDecisionHandler func(ctx context.Context, trackID string, approved bool)
+	commandHandlers           map[string]commandRegistration
+	searchHandler             chat.SearchHandler
+	choiceHandler             chat.ChoiceHandler
+}
+
+// New creates an empty fake frontend without admins, replying in the default language.
+func New() *Frontend {
+	return &Frontend{
+		localizer:        i18n.NewLocalizer(i18n.DefaultLanguage),
+		maxMessageLength: DefaultMaxMessageLength,
+		edited:           make(map[string]string),
+		commandHandlers:  make(map[string]commandRegistration),
+	}
+}
+
+// SetLanguage sets the language of replies generated by the frontend itself and used by HasSentKey.
+func (f *Frontend) SetLanguage(language string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.localizer = i18n.NewLocalizer(language)
+}
+
+// SetAdmins sets the user IDs reported as group administrators.
+func (f *Frontend) SetAdmins(userIDs ...string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.admins = slices.Clone(userIDs)
+}
+
+// SetAdminApprovalEnabled sets whether the frontend reports admin approval as enabled.
+func (f *Frontend) SetAdminApprovalEnabled(enabled bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.adminApproval = enabled
+}
+
+// SetMemberCount sets the group member count returned by GetMemberCount.
+func (f *Frontend) SetMemberCount(count int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.memberCount = count
+}
+
+// SetChatMember sets the member returned by GetChatMember for the member's user ID.
+func (f *Frontend) SetChatMember(member *chat.ChatMember) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.chatMembers == nil {
+		f.chatMembers = make(map[int64]*chat.ChatMember)
+	}
+	f.chatMembers[member.User.ID] = member
+}
+
+// SetMaxMessageLength sets the message length limit reported by MaxMessageLength.
+func (f *Frontend) SetMaxMessageLength(length int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.maxMessageLength = length
+}
+
+// QueueApprovals queues the decisions returned by the next AwaitApproval calls.
+func (f *Frontend) QueueApprovals(decisions ...bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.approvals = append(f.approvals, decisions...)
+}
+
+// QueueAdminApprovals queues the decisions returned by the next AwaitAdminApproval calls.
+func (f *Frontend) QueueAdminApprovals(decisions ...bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.adminApprovals = append(f.adminApprovals, decisions...)
+}
+
+// QueueCommunityApprovals queues the decisions returned by the next AwaitCommunityApproval calls.
+func (f *Frontend) QueueCommunityApprovals(decisions ...bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.communityApprovals = append(f.communityApprovals, decisions...)
+}
+
+// Deliver passes an incoming message to the handler registered with Listen.
+func (f *Frontend) Deliver(msg *chat.Message) error {
+	f.mutex.Lock()
+	handler := f.messageHandler
+	f.mutex.Unlock()
+
+	if handler == nil {
+		return ErrNoMessageHandler
+	}
+	handler(msg)
+	return nil
+}
+
+// RunCommand runs the handler registered for the command in msg.Text, rejecting admin-only
+// commands from non-admins like the real frontends. Returns false if no handler is registered.
+func (f *Frontend) RunCommand(ctx context.Context, msg *chat.Message) bool {
+	command := strings.TrimPrefix(strings.ToLower(strings.Fields(msg.Text + " ")[0]), "/")
+
+	f.mutex.Lock()
+	registration, exists := f.commandHandlers[command]
+	f.mutex.Unlock()
+
+	if !exists {
+		return false
+	}
+
+	if registration.adminOnly && !f.isAdmin(msg.SenderID) {
+		f.record(SentMessage{ChatID: msg.ChatID, ReplyToID: msg.ID, Text: f.translate("error.command.admin_only")})
+		return true
+	}
+
+	registration.handler(ctx, msg)
+	return true
+}
+
+// DecideQueueTrack answers a queue track approval as if an admin pressed the button.
+func (f *Frontend) DecideQueueTrack(ctx context.Context, trackID string, approved bool) {
+	f.mutex.Lock()
+	handler := f.queueTrackDecisionHandler
+	f.mutex.Unlock()
+
+	if handler != nil {
+		handler(ctx, trackID, approved)
+	}
+}
+
+// PickChoice picks a choice as if the user in msg pressed its button on the message msg.ID.
+func (f *Frontend) PickChoice(ctx context.Context, msg *chat.Message, choiceID string) {
+	f.mutex.Lock()
+	handler := f.choiceHandler
+	f.mutex.Unlock()
+
+	if handler != nil {
+		handler(ctx, msg, choiceID)
+	}
+}
+
+// SentMessages returns all messages sent so far, in order.
+func (f *Frontend) SentMessages() []SentMessage {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return slices.Clone(f.sent)
+}
+
+// LastSent returns the last message sent, or false if nothing was sent.
+func (f *Frontend) LastSent() (SentMessage, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.sent) == 0 {
+		return SentMessage{}, false
+	}
+	return f.sent[len(f.sent)-1], true
+}
+
+// HasSent reports whether a message with exactly the given text was sent.
+// Compare against localizer output to assert a specific localized reply.
+func (f *Frontend) HasSent(text string) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return slices.ContainsFunc(f.sent, func(msg SentMessage) bool { return msg.Text == text })
+}
+
+// HasSentKey reports whether a message with the localized text of the key was sent.
+func (f *Frontend) HasSentKey(key string, args ...any) bool {
+	return f.HasSent(f.translate(key, args...))
+}
+
+// translate localizes a message key in the frontend's language.
+func (f *Frontend) translate(key string, args ...any) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.localizer.T(key, args...)
+}
+
+// Reactions returns all reactions added so far, in order.
+func (f *Frontend) Reactions() []SentReaction {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return slices.Clone(f.reactions)
+}
+
+// HasReacted reports whether the reaction was added to the message.
+func (f *Frontend) HasReacted(messageID string, r chat.Reaction) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return slices.ContainsFunc(f.reactions, func(reaction SentReaction) bool {
+		return reaction.MessageID == messageID && reaction.Reaction == r
+	})
+}
+
+// RemovedReactions returns all reactions removed so far, in order.
+func (f *Frontend) RemovedReactions() []SentReaction {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return slices.Clone(f.removed)
+}
+
+// DeletedMessages returns the IDs of all deleted messages, in order.
+func (f *Frontend) DeletedMessages() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return slices.Clone(f.deleted)
+}
+
+// PinnedMessages returns the IDs of the currently pinned messages, in pin order.
+func (f *Frontend) PinnedMessages() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return slices.Clone(f.pinned)
+}
+
+// EditedText returns the latest text a message was edited to, or false if it was not edited.
+func (f *Frontend) EditedText(messageID string) (string, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	text, ok := f.edited[messageID]
+	return text, ok
+}
+
+// SearchHandler returns the handler registered with SetSearchHandler.
+func (f *Frontend) SearchHandler() chat.SearchHandler {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.searchHandler
+}
+
+// Reset clears all recorded output, keeping handlers and programmed responses.
+func (f *Frontend) Reset() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.sent = nil
+	f.reactions = nil
+	f.removed = nil
+	f.deleted = nil
+	f.pinned = nil
+	f.edited = make(map[string]string)
+}
+
+// record stores a sent message under a new ID and returns the ID.
+func (f *Frontend) record(msg SentMessage) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.nextID++
+	msg.ID = strconv.Itoa(f.nextID)
+	f.sent = append(f.sent, msg)
+	return msg.ID
+}
+
+// popDecision removes and returns the first queued decision, or false if the queue is empty.
+func (f *Frontend) popDecision(queue *[]bool) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(*queue) == 0 {
+		return false
+	}
+	decision := (*queue)[0]
+	*queue = (*queue)[1:]
+	return decision
+}
+
+func (f *Frontend) isAdmin(userID string) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return slices.Contains(f.admins, userID)
+}
+
+// Start implements chat.Frontend.
+func (f *Frontend) Start(_ context.Context) error {
+	return nil
+}
+
+// Listen registers the message handler and blocks until ctx is done.
+func (f *Frontend) Listen(ctx context.Context, handler func(*chat.Message)) error {
+	f.mutex.Lock()
+	f.messageHandler = handler
+	f.mutex.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
+
+// SendText records a message sent to a chat.
+func (f *Frontend) SendText(_ context.Context, chatID, replyToID, text string) (string, error) {
+	return f.record(SentMessage{ChatID: chatID, ReplyToID: replyToID, Text: text}), nil
+}
+
+// MaxMessageLength returns the limit set with SetMaxMessageLength. Sent texts are recorded unsplit.
+func (f *Frontend) MaxMessageLength() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.maxMessageLength
+}
+
+// React records a reaction.
+func (f *Frontend) React(_ context.Context, chatID, msgID string, r chat.Reaction) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.reactions = append(f.reactions, SentReaction{ChatID: chatID, MessageID: msgID, Reaction: r})
+	return nil
+}
+
+// RemoveReaction records the removal of a reaction.
+func (f *Frontend) RemoveReaction(_ context.Context, chatID, msgID string, r chat.Reaction) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.removed = append(f.removed, SentReaction{ChatID: chatID, MessageID: msgID, Reaction: r})
+	return nil
+}
+
+// AwaitApproval records the prompt and returns the next queued approval decision.
+func (f *Frontend) AwaitApproval(_ context.Context, origin *chat.Message, prompt string, _ int) (bool, error) {
+	f.record(SentMessage{ChatID: origin.ChatID, ReplyToID: origin.ID, Text: prompt})
+	return f.popDecision(&f.approvals), nil
+}
+
+// IsAdminApprovalEnabled reports whether admin approval was enabled with SetAdminApprovalEnabled.
+func (f *Frontend) IsAdminApprovalEnabled() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.adminApproval
+}
+
+// AwaitAdminApproval returns the next queued admin approval decision.
+func (f *Frontend) AwaitAdminApproval(_ context.Context, _ *chat.Message, _, _, _, _ string, _ int) (bool, error) {
+	return f.popDecision(&f.adminApprovals), nil
+}
+
+// IsUserAdmin reports whether the user was configured as admin with SetAdmins.
+func (f *Frontend) IsUserAdmin(_ context.Context, _, userID string) (bool, error) {
+	return f.isAdmin(userID), nil
+}
+
+// DeleteMessage records a deleted message.
+func (f *Frontend) DeleteMessage(_ context.Context, _, msgID string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.deleted = append(f.deleted, msgID)
+	return nil
+}
+
+// AwaitCommunityApproval returns the next queued community approval decision.
+func (f *Frontend) AwaitCommunityApproval(_ context.Context, _ string, requiredReactions, _ int,
+	_ int64) (bool, error) {
+	if requiredReactions <= 0 {
+		return false, nil
+	}
+	return f.popDecision(&f.communityApprovals), nil
+}
+
+// GetMemberCount returns the member count set with SetMemberCount.
+func (f *Frontend) GetMemberCount(_ context.Context) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.memberCount, nil
+}
+
+// GetAdminUserIDs returns the admins set with SetAdmins.
+func (f *Frontend) GetAdminUserIDs(_ context.Context, _ string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return slices.Clone(f.admins), nil
+}
+
+// SendDirectMessage records a direct message to a user.
+func (f *Frontend) SendDirectMessage(_ context.Context, userID, text string) (string, error) {
+	return f.record(SentMessage{ChatID: userID, Text: text, Direct: true}), nil
+}
+
+// SendQueueTrackApproval records a queue track approval message; answer it with DecideQueueTrack.
+func (f *Frontend) SendQueueTrackApproval(_ context.Context, chatID, trackID, message string) (string, error) {
+	return f.record(SentMessage{ChatID: chatID, Text: message, TrackID: trackID}), nil
+}
+
+// SetQueueTrackDecisionHandler sets the handler called by DecideQueueTrack.
+func (f *Frontend) SetQueueTrackDecisionHandler(handler func(ctx context.Context, trackID string, approved bool)) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.queueTrackDecisionHandler = handler
+}
+
+// SetCommandHandler registers a command handler run by RunCommand.
+func (f *Frontend) SetCommandHandler(command string, adminOnly bool, handler chat.CommandHandler) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.commandHandlers[strings.ToLower(command)] = commandRegistration{adminOnly: adminOnly, handler: handler}
+}
+
+// SetSearchHandler stores the inline search handler, see SearchHandler.
+func (f *Frontend) SetSearchHandler(handler chat.SearchHandler) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.searchHandler = handler
+}
+
+// SendPhoto records a photo message with its caption as text.
+func (f *Frontend) SendPhoto(_ context.Context, chatID, replyToID, photoURL, caption string) (string, error) {
+	return f.record(SentMessage{ChatID: chatID, ReplyToID: replyToID, Text: caption, PhotoURL: photoURL}), nil
+}
+
+// SendChoices records a message with choices; pick one with PickChoice.
+func (f *Frontend) SendChoices(_ context.Context, chatID, replyToID, text string, choices []chat.Choice) (string, error) {
+	return f.record(SentMessage{ChatID: chatID, ReplyToID: replyToID, Text: text, Choices: choices}), nil
+}
+
+// SetChoiceHandler sets the handler called by PickChoice.
+func (f *Frontend) SetChoiceHandler(handler chat.ChoiceHandler) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.choiceHandler = handler
+}
+
+// EditMessage records the new text of a message.
+func (f *Frontend) EditMessage(_ context.Context, _, messageID, newText string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.edited[messageID] = newText
+	return nil
+}
+
+// PinMessage records a pinned message.
+func (f *Frontend) PinMessage(_ context.Context, _, msgID string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.pinned = append(f.pinned, msgID)
+	return nil
+}
+
+// UnpinMessage removes a message from the pinned messages.
+func (f *Frontend) UnpinMessage(_ context.Context, _, msgID string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.pinned = slices.DeleteFunc(f.pinned, func(id string) bool { return id == msgID })
+	return nil
+}
+
+// GetMe returns the fake bot user.
+func (f *Frontend) GetMe(_ context.Context) (*chat.User, error) {
+	return &chat.User{ID: BotUserID, IsBot: true, FirstName: "DJAlgoRhythm", Username: "fake_bot"}, nil
+}
+
+// GetChatMember returns members set with SetChatMember, the bot as administrator and
+// other users as admin or member according to SetAdmins.
+func (f *Frontend) GetChatMember(_ context.Context, _, userID int64) (*chat.ChatMember, error) {
+	f.mutex.Lock()
+	member, ok := f.chatMembers[userID]
+	f.mutex.Unlock()
+	if ok {
+		return member, nil
+	}
+
+	status := "member"
+	if userID == BotUserID || f.isAdmin(strconv.FormatInt(userID, 10)) {
+		status = "administrator"
+	}
+	return &chat.ChatMember{Status: status, User: &chat.User{ID: userID}}, nil
+}
@@ -0,0 +1,113 @@
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Compile-time check that the fake implements the frontend interface.
+var _ chat.Frontend = (*Frontend)(nil)
+
+func TestFrontend_QueuedApprovals(t *testing.T) {
+	ctx := context.Background()
+	frontend := New()
+	origin := &chat.Message{ID: "10", ChatID: "-100", SenderID: "7"}
+
+	frontend.QueueApprovals(true, false)
+
+	for i, expected := range []bool{true, false, false} {
+		approved, err := frontend.AwaitApproval(ctx, origin, "Add this?", 10)
+		if err != nil {
+			t.Fatalf("AwaitApproval returned error: %v", err)
+		}
+		if approved != expected {
+			t.Errorf("Approval %d: expected %v, got %v", i, expected, approved)
+		}
+	}
+
+	if !frontend.HasSent("Add this?") {
+		t.Error("Approval prompt should be recorded as sent message")
+	}
+}
+
+func TestFrontend_RecordsOutput(t *testing.T) {
+	ctx := context.Background()
+	frontend := New()
+
+	id, _ := frontend.SendText(ctx, "-100", "10", "hello")
+	_ = frontend.React(ctx, "-100", "10", chat.ReactionThumbsUp)
+	_ = frontend.EditMessage(ctx, "-100", id, "edited")
+	_ = frontend.DeleteMessage(ctx, "-100", id)
+
+	if last, ok := frontend.LastSent(); !ok || last.Text != "hello" || last.ReplyToID != "10" {
+		t.Errorf("Unexpected last sent message: %+v", last)
+	}
+	if !frontend.HasReacted("10", chat.ReactionThumbsUp) {
+		t.Error("Reaction should be recorded")
+	}
+	if text, ok := frontend.EditedText(id); !ok || text != "edited" {
+		t.Errorf("Expected edited text, got %q", text)
+	}
+	if deleted := frontend.DeletedMessages(); len(deleted) != 1 || deleted[0] != id {
+		t.Errorf("Unexpected deleted messages: %v", deleted)
+	}
+
+	frontend.Reset()
+	if len(frontend.SentMessages()) != 0 || len(frontend.Reactions()) != 0 {
+		t.Error("Reset should clear recorded output")
+	}
+}
+
+func TestFrontend_AdminOnlyCommand(t *testing.T) {
+	ctx := context.Background()
+	frontend := New()
+	frontend.SetAdmins("1")
+
+	calls := 0
+	frontend.SetCommandHandler("skip", true, func(_ context.Context, _ *chat.Message) { calls++ })
+
+	if !frontend.RunCommand(ctx, &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", Text: "/skip"}) {
+		t.Fatal("Registered command should be handled")
+	}
+	if calls != 0 || !frontend.HasSentKey("error.command.admin_only") {
+		t.Error("Non-admins should get the admin-only reply")
+	}
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "2", ChatID: "-100", SenderID: "1", Text: "/SKIP now"})
+	if calls != 1 {
+		t.Errorf("Admin command should run the handler once, got %d calls", calls)
+	}
+
+	if frontend.RunCommand(ctx, &chat.Message{Text: "/unknown"}) {
+		t.Error("Unknown commands should not be handled")
+	}
+}
+
+func TestFrontend_DeliverRequiresListen(t *testing.T) {
+	frontend := New()
+	if err := frontend.Deliver(&chat.Message{Text: "hi"}); err == nil {
+		t.Error("Deliver without Listen should fail")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan *chat.Message, 1)
+	done := make(chan struct{})
+	go func() {
+		_ = frontend.Listen(ctx, func(msg *chat.Message) { received <- msg })
+		close(done)
+	}()
+
+	// Wait for Listen to register the handler
+	for frontend.Deliver(&chat.Message{Text: "hi"}) != nil {
+		time.Sleep(time.Millisecond)
+	}
+	if msg := <-received; msg.Text != "hi" {
+		t.Errorf("Unexpected delivered message: %+v", msg)
+	}
+
+	cancel()
+	<-done
+}
@@ -3,6 +3,7 @@ package chat
 
 import (
 	"context"
+	"time"
 )
 
 // Message represents a normalized chat message from any frontend.
@@ -13,8 +14,11 @@ type Message struct {
 	SenderName string
 	Text       string
 	URLs       []string
+	Requests   []string // Song queries of a free-text message asking for several songs (empty otherwise)
 	IsGroup    bool
-	Raw        any // underlying library message struct
+	ReplyToID  string // ID of the message this message replies to (empty if not a reply)
+	Language   string // Detected ISO 639-1 language code of the text (empty if unknown)
+	Raw        any    // underlying library message struct
 }
 
 // Reaction represents standard emoji reactions.
@@ -25,8 +29,37 @@ const (
 	ReactionThumbsUp   Reaction = "👍"
 	ReactionThumbsDown Reaction = "👎"
 	ReactionYawning    Reaction = "🥱"
+	ReactionSkip       Reaction = "⏭️"
+	ReactionQuota      Reaction = "🙈"
+	ReactionPaused     Reaction = "😴"
+	ReactionVolume     Reaction = "👌"
+	ReactionProcessing Reaction = "👀"
 )
 
+// CommandHandler handles a chat command (e.g. /skip) sent to the bot.
+type CommandHandler func(ctx context.Context, msg *Message)
+
+// SearchResult represents a track offered to a user in inline search results.
+type SearchResult struct {
+	ID     string
+	Title  string
+	Artist string
+	URL    string
+}
+
+// SearchHandler searches tracks for an inline query typed by a user.
+type SearchHandler func(ctx context.Context, query string) ([]SearchResult, error)
+
+// Choice represents a selectable option attached to a bot message (e.g. an inline button).
+type Choice struct {
+	ID    string // Passed to the choice handler when picked
+	Label string
+}
+
+// ChoiceHandler handles a user picking a choice. The message carries the chat, the picking user
+// and the ID of the bot message the choice was attached to.
+type ChoiceHandler func(ctx context.Context, msg *Message, choiceID string)
+
 // User represents a Telegram user.
 type User struct {
 	ID        int64  `json:"id"`
@@ -55,6 +88,8 @@ type ChatMember struct {
 	CanEditMessages        bool   `json:"can_edit_messages,omitempty"`
 	CanDeleteVideoChats    bool   `json:"can_delete_video_chats,omitempty"`
 	CanManageVideoChatsOld bool   `json:"can_manage_voice_chats,omitempty"` // Legacy field
+
+	JoinedAt time.Time `json:"-"` // When the member was seen joining the chat (zero if unknown)
 }
 
 // Frontend defines the unified interface for all chat integrations.
@@ -66,11 +101,22 @@ type Frontend interface {
 	Listen(ctx context.Context, handler func(*Message)) error
 
 	// SendText sends a text message to the specified chat, optionally as a reply
+	// Messages longer than MaxMessageLength are split between lines and sent as several messages
 	SendText(ctx context.Context, chatID string, replyToID string, text string) (string, error)
 
+	// SendPhoto sends a photo from a URL with a caption, optionally as a reply
+	// Frontends without photos, and captions too long for one, send the caption as text only
+	SendPhoto(ctx context.Context, chatID, replyToID, photoURL, caption string) (string, error)
+
+	// MaxMessageLength returns the maximum length of a single message in UTF-16 code units
+	MaxMessageLength() int
+
 	// React adds an emoji reaction to a message
 	React(ctx context.Context, chatID string, msgID string, r Reaction) error
 
+	// RemoveReaction removes a reaction added with React, unless another reaction has replaced it since
+	RemoveReaction(ctx context.Context, chatID string, msgID string, r Reaction) error
+
 	// AwaitApproval waits for user approval via reaction or inline buttons
 	// Returns true if approved within timeout, false otherwise
 	AwaitApproval(ctx context.Context, origin *Message, prompt string, timeoutSec int) (approved bool, err error)
@@ -99,9 +145,31 @@ type Frontend interface {
 	// SetQueueTrackDecisionHandler sets the handler for queue track approval/denial decisions
 	SetQueueTrackDecisionHandler(handler func(ctx context.Context, trackID string, approved bool))
 
+	// SetCommandHandler sets the handler for a chat command (without the leading slash)
+	// If adminOnly is set, non-admin senders are rejected by the frontend before the handler runs
+	// The start command is also passed on from private chats with the bot, with Message.IsGroup unset
+	SetCommandHandler(command string, adminOnly bool, handler CommandHandler)
+
+	// SetSearchHandler sets the handler for inline track searches (e.g. "@botname song name")
+	// Picking a result posts the track URL to the chat, where it is handled like any other request
+	SetSearchHandler(handler SearchHandler)
+
+	// SendChoices sends a message with selectable choices, optionally as a reply
+	// Frontends without inline buttons send the text only
+	SendChoices(ctx context.Context, chatID, replyToID, text string, choices []Choice) (string, error)
+
+	// SetChoiceHandler sets the handler for choices picked from messages sent with SendChoices
+	SetChoiceHandler(handler ChoiceHandler)
+
 	// EditMessage edits an existing message by ID (returns error if not supported)
 	EditMessage(ctx context.Context, chatID, messageID, newText string) error
 
+	// PinMessage pins a message in the chat without notifying members (returns error if not supported)
+	PinMessage(ctx context.Context, chatID, msgID string) error
+
+	// UnpinMessage unpins a pinned message (returns error if not supported)
+	UnpinMessage(ctx context.Context, chatID, msgID string) error
+
 	// GetMe returns information about the bot user
 	GetMe(ctx context.Context) (*User, error)
 
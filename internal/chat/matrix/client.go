@@ -0,0 +1,276 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	clientAPIPrefix = "/_matrix/client/v3"
+	// httpTimeoutMargin is added to the long-poll sync timeout for the HTTP client timeout.
+	httpTimeoutMargin = 15 * time.Second
+
+	eventTypeMessage     = "m.room.message"
+	eventTypeReaction    = "m.reaction"
+	eventTypePowerLevels = "m.room.power_levels"
+	msgTypeText          = "m.text"
+	msgTypeNotice        = "m.notice"
+	relTypeAnnotation    = "m.annotation"
+	relTypeReplace       = "m.replace"
+)
+
+// syncFilter limits sync responses to the timeline events the frontend handles.
+const syncFilter = `{"presence":{"types":[]},"account_data":{"types":[]},` +
+	`"room":{"ephemeral":{"types":[]},"account_data":{"types":[]},"state":{"types":[]},` +
+	`"timeline":{"types":["m.room.message","m.reaction","m.room.power_levels"]}}}`
+
+// client is a minimal Matrix client-server API client authenticated with an access token.
+type client struct {
+	homeserverURL string
+	accessToken   string
+	httpClient    *http.Client
+	txnCounter    atomic.Uint64
+}
+
+// apiError is the error body returned by the Matrix client-server API.
+type apiError struct {
+	ErrCode string `json:"errcode"`
+	Message string `json:"error"`
+}
+
+// event is a Matrix room event as returned by /sync.
+type event struct {
+	Type    string          `json:"type"`
+	EventID string          `json:"event_id"`
+	Sender  string          `json:"sender"`
+	Content json.RawMessage `json:"content"`
+}
+
+// messageContent is the content of m.room.message events.
+type messageContent struct {
+	MsgType    string          `json:"msgtype"`
+	Body       string          `json:"body"`
+	RelatesTo  *relatesTo      `json:"m.relates_to,omitempty"`
+	NewContent *messageContent `json:"m.new_content,omitempty"`
+}
+
+// reactionContent is the content of m.reaction events.
+type reactionContent struct {
+	RelatesTo *relatesTo `json:"m.relates_to,omitempty"`
+}
+
+// relatesTo describes the relation of an event to another event (replies, reactions and edits).
+type relatesTo struct {
+	RelType   string     `json:"rel_type,omitempty"`
+	EventID   string     `json:"event_id,omitempty"`
+	Key       string     `json:"key,omitempty"`
+	InReplyTo *inReplyTo `json:"m.in_reply_to,omitempty"`
+}
+
+// inReplyTo references the event a message replies to.
+type inReplyTo struct {
+	EventID string `json:"event_id"`
+}
+
+// syncResponse is the subset of the /sync response used by the frontend.
+type syncResponse struct {
+	NextBatch string `json:"next_batch"`
+	Rooms     struct {
+		Join map[string]struct {
+			Timeline struct {
+				Events []event `json:"events"`
+			} `json:"timeline"`
+		} `json:"join"`
+	} `json:"rooms"`
+}
+
+// powerLevels is the content of the m.room.power_levels state event.
+type powerLevels struct {
+	Users        map[string]int `json:"users"`
+	UsersDefault int            `json:"users_default"`
+}
+
+// levelOf returns the power level of a user in the room.
+func (p *powerLevels) levelOf(userID string) int {
+	if level, ok := p.Users[userID]; ok {
+		return level
+	}
+	return p.UsersDefault
+}
+
+func newClient(homeserverURL, accessToken string, syncTimeout time.Duration) *client {
+	return &client{
+		homeserverURL: strings.TrimSuffix(homeserverURL, "/"),
+		accessToken:   accessToken,
+		httpClient:    &http.Client{Timeout: syncTimeout + httpTimeoutMargin},
+	}
+}
+
+// do sends a request to the client-server API and decodes the JSON response into out (if not nil).
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	endpoint := c.homeserverURL + clientAPIPrefix + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("matrix request %s %s failed: %w", method, path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiError
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("matrix request %s %s failed with status %d: %s %s",
+			method, path, resp.StatusCode, apiErr.ErrCode, apiErr.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode matrix response: %w", err)
+	}
+	return nil
+}
+
+// nextTxnID returns a transaction ID that is unique for this client.
+func (c *client) nextTxnID() string {
+	return fmt.Sprintf("djalgorhythm-%d-%d", time.Now().UnixNano(), c.txnCounter.Add(1))
+}
+
+// whoAmI returns the user ID the access token belongs to.
+func (c *client) whoAmI(ctx context.Context) (string, error) {
+	var resp struct {
+		UserID string `json:"user_id"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/account/whoami", nil, nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.UserID, nil
+}
+
+// joinRoom joins a room by ID or alias and returns the room ID.
+func (c *client) joinRoom(ctx context.Context, roomIDOrAlias string) (string, error) {
+	var resp struct {
+		RoomID string `json:"room_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/join/"+url.PathEscape(roomIDOrAlias), nil, struct{}{}, &resp); err != nil {
+		return "", err
+	}
+	return resp.RoomID, nil
+}
+
+// sync long-polls for new events since the given batch token (empty for an initial sync).
+func (c *client) sync(ctx context.Context, since string, timeout time.Duration) (*syncResponse, error) {
+	query := url.Values{
+		"timeout": {strconv.FormatInt(timeout.Milliseconds(), 10)},
+		"filter":  {syncFilter},
+	}
+	if since != "" {
+		query.Set("since", since)
+	}
+
+	var resp syncResponse
+	if err := c.do(ctx, http.MethodGet, "/sync", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// sendEvent sends a room event and returns its event ID.
+func (c *client) sendEvent(ctx context.Context, roomID, eventType string, content any) (string, error) {
+	path := fmt.Sprintf("/rooms/%s/send/%s/%s",
+		url.PathEscape(roomID), url.PathEscape(eventType), url.PathEscape(c.nextTxnID()))
+
+	var resp struct {
+		EventID string `json:"event_id"`
+	}
+	if err := c.do(ctx, http.MethodPut, path, nil, content, &resp); err != nil {
+		return "", err
+	}
+	if resp.EventID == "" {
+		return "", errors.New("homeserver returned no event ID")
+	}
+	return resp.EventID, nil
+}
+
+// redact redacts (deletes) an event.
+func (c *client) redact(ctx context.Context, roomID, eventID string) error {
+	path := fmt.Sprintf("/rooms/%s/redact/%s/%s",
+		url.PathEscape(roomID), url.PathEscape(eventID), url.PathEscape(c.nextTxnID()))
+	return c.do(ctx, http.MethodPut, path, nil, struct{}{}, nil)
+}
+
+// powerLevels returns the power levels of a room.
+func (c *client) powerLevels(ctx context.Context, roomID string) (*powerLevels, error) {
+	path := fmt.Sprintf("/rooms/%s/state/%s/", url.PathEscape(roomID), eventTypePowerLevels)
+
+	var levels powerLevels
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &levels); err != nil {
+		return nil, err
+	}
+	return &levels, nil
+}
+
+// joinedMembers returns the user IDs of the joined members of a room.
+func (c *client) joinedMembers(ctx context.Context, roomID string) ([]string, error) {
+	var resp struct {
+		Joined map[string]json.RawMessage `json:"joined"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/rooms/"+url.PathEscape(roomID)+"/joined_members", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	members := make([]string, 0, len(resp.Joined))
+	for userID := range resp.Joined {
+		members = append(members, userID)
+	}
+	return members, nil
+}
+
+// createDirectRoom creates a direct message room with a user and returns the room ID.
+func (c *client) createDirectRoom(ctx context.Context, userID string) (string, error) {
+	body := map[string]any{
+		"is_direct": true,
+		"invite":    []string{userID},
+		"preset":    "trusted_private_chat",
+	}
+
+	var resp struct {
+		RoomID string `json:"room_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/createRoom", nil, body, &resp); err != nil {
+		return "", err
+	}
+	return resp.RoomID, nil
+}
@@ -0,0 +1,1137 @@
+// Package matrix provides Matrix integration using the Matrix client-server API.
+package matrix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/flood"
+	"djalgorhythm/internal/i18n"
+	"djalgorhythm/pkg/text"
+)
+
+const (
+	// adminPowerLevel is the power level from which room members count as administrators (Element's "Moderator").
+	adminPowerLevel = 50
+	// syncTimeout is how long a sync request waits for new events.
+	syncTimeout = 30 * time.Second
+	// Backoff between failed sync requests.
+	syncRetryMinDelay = time.Second
+	syncRetryMaxDelay = time.Minute
+	cleanupTimeout    = 5 * time.Second // Timeout for cleanup operations
+	// memberCountCacheTTL is how long the room member count is cached.
+	memberCountCacheTTL = 5 * time.Minute
+	// powerLevelsCacheTTL is how long room power levels are cached. Synced changes replace them right away,
+	// so this only bounds how long a change missed in a gap of the sync timeline goes unnoticed.
+	powerLevelsCacheTTL = 10 * time.Minute
+	// maxTrackedRequesters bounds how many message senders are remembered for community approval.
+	maxTrackedRequesters = 1000
+	// maxTrackedReactions bounds how many reactions of the bot are remembered for removal.
+	maxTrackedReactions = 1000
+	// variationSelector is appended to some emoji reaction keys by Matrix clients.
+	variationSelector = "\ufe0f"
+	// maxMessageLength in UTF-16 code units keeps message events of 3-byte characters below the 64 KiB event size limit.
+	maxMessageLength = 15000
+)
+
+// errNotSupported is returned for Telegram-specific operations that have no Matrix equivalent.
+var errNotSupported = errors.New("not supported by the Matrix frontend")
+
+// Config holds Matrix-specific configuration.
+type Config struct {
+	HomeserverURL       string            // Base URL of the homeserver (e.g. https://matrix.example.org)
+	AccessToken         string            // Access token of the bot account
+	RoomID              string            // ID or alias of the room to monitor
+	AdminApproval       bool              // Whether admin approval is required for songs
+	Language            string            // Bot language for user-facing messages
+	MessageOverrides    map[string]string // Custom wording merged over the bundled messages
+	FloodLimitPerMinute int               // Maximum messages per user per minute
+	FloodBurst          int               // Extra messages per user in quick succession on top of the limit
+	RequestPrefix       string            // Prefix marking messages as song requests, e.g. "!play" (empty treats all as requests)
+}
+
+// Frontend implements the chat.Frontend interface for Matrix.
+type Frontend struct {
+	config    *Config
+	logger    *zap.Logger
+	client    *client
+	parser    *text.Parser
+	localizer *i18n.Localizer
+	floodgate *flood.Floodgate
+
+	userID    string // Matrix user ID of the bot, resolved on Start
+	roomID    string // Room ID of the configured room, resolved on Start
+	nextBatch string // Sync token of the last processed batch
+
+	// Message handling
+	messageHandler func(*chat.Message)
+
+	// Queue track decision handling
+	queueTrackDecisionHandler func(ctx context.Context, trackID string, approved bool)
+	queueTrackMutex           sync.Mutex
+	pendingQueueTracks        map[string]string // event ID -> track ID
+
+	// Command handling
+	commandMutex    sync.RWMutex
+	commandHandlers map[string]commandRegistration
+
+	// Reaction-based approval tracking
+	voteMutex    sync.RWMutex
+	pendingVotes map[string]*reactionVote   // event ID of the reacted message -> vote
+	originVotes  map[string][]*reactionVote // "chatID_msgID" of the request -> votes
+
+	// Admin approval cancellation, e.g. after community approval succeeded
+	adminApprovalMutex   sync.Mutex
+	adminApprovalCancels map[string]context.CancelFunc // "chatID_msgID" of the request -> cancel
+
+	// Requesters of recent messages, to keep them from approving their own requests
+	requesterMutex sync.Mutex
+	requesters     map[string]string // event ID -> user ID
+	requesterOrder []string
+
+	// Reactions of the bot, to redact them on removal
+	reactionMutex  sync.Mutex
+	reactionEvents map[string]string // "chatID_msgID_reaction" -> reaction event ID
+	reactionOrder  []string
+
+	// Direct message rooms with users, created on demand
+	directRoomMutex sync.Mutex
+	directRooms     map[string]string // user ID -> room ID
+
+	// Cached room member count for percentage-based community approval
+	memberCountMutex     sync.Mutex
+	memberCount          int
+	memberCountCheckedAt time.Time
+
+	// Cached power levels per room, for admin checks on every command and reaction
+	powerLevelsMutex sync.Mutex
+	powerLevels      map[string]cachedPowerLevels // room ID -> power levels
+}
+
+// cachedPowerLevels holds the power levels of a room and when they were fetched or last changed.
+type cachedPowerLevels struct {
+	levels    *powerLevels
+	updatedAt time.Time
+}
+
+// commandRegistration holds a registered chat command handler.
+type commandRegistration struct {
+	adminOnly bool
+	handler   chat.CommandHandler
+}
+
+// voteFunc evaluates a reaction and reports whether it decides the vote and how.
+type voteFunc func(ctx context.Context, sender string, r chat.Reaction) (approved, decided bool)
+
+// reactionVote tracks a pending decision made by reacting to a message.
+type reactionVote struct {
+	vote     voteFunc
+	decision chan bool
+}
+
+// NewFrontend creates a new Matrix frontend.
+func NewFrontend(config *Config, logger *zap.Logger) *Frontend {
+	// Use configured language, fallback to default if not set
+	language := config.Language
+	if language == "" {
+		language = i18n.DefaultLanguage
+	}
+
+	localizer := i18n.NewLocalizer(language)
+	if err := localizer.LoadOverrides(config.MessageOverrides); err != nil {
+		logger.Warn("Ignoring invalid message overrides", zap.Error(err))
+	}
+
+	return &Frontend{
+		config:               config,
+		logger:               logger,
+		client:               newClient(config.HomeserverURL, config.AccessToken, syncTimeout),
+		parser:               text.NewParser(),
+		localizer:            localizer,
+		floodgate:            flood.New(config.FloodLimitPerMinute, config.FloodBurst),
+		pendingQueueTracks:   make(map[string]string),
+		commandHandlers:      make(map[string]commandRegistration),
+		pendingVotes:         make(map[string]*reactionVote),
+		originVotes:          make(map[string][]*reactionVote),
+		adminApprovalCancels: make(map[string]context.CancelFunc),
+		requesters:           make(map[string]string),
+		reactionEvents:       make(map[string]string),
+		directRooms:          make(map[string]string),
+		powerLevels:          make(map[string]cachedPowerLevels),
+	}
+}
+
+// Start authenticates the bot, joins the configured room and skips events sent before startup.
+func (f *Frontend) Start(ctx context.Context) error {
+	f.logger.Info("Starting Matrix frontend",
+		zap.String("homeserver", f.config.HomeserverURL),
+		zap.String("room", f.config.RoomID))
+
+	userID, err := f.client.whoAmI(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate with homeserver: %w", err)
+	}
+	f.userID = userID
+
+	roomID, err := f.client.joinRoom(ctx, f.config.RoomID)
+	if err != nil {
+		return fmt.Errorf("failed to join room %s: %w", f.config.RoomID, err)
+	}
+	f.roomID = roomID
+
+	// Initial sync without timeout so only events sent after startup are handled
+	resp, err := f.client.sync(ctx, "", 0)
+	if err != nil {
+		return fmt.Errorf("failed to run initial sync: %w", err)
+	}
+	f.nextBatch = resp.NextBatch
+
+	f.logger.Info("Matrix frontend started successfully",
+		zap.String("user_id", f.userID),
+		zap.String("room_id", f.roomID))
+	return nil
+}
+
+// Listen long-polls the homeserver for events and calls the handler for each message until ctx is done.
+func (f *Frontend) Listen(ctx context.Context, handler func(*chat.Message)) error {
+	f.messageHandler = handler
+
+	delay := syncRetryMinDelay
+	for ctx.Err() == nil {
+		resp, err := f.client.sync(ctx, f.nextBatch, syncTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			f.logger.Warn("Matrix sync failed, retrying", zap.Error(err), zap.Duration("delay", delay))
+			select {
+			case <-ctx.Done():
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, syncRetryMaxDelay)
+			continue
+		}
+
+		delay = syncRetryMinDelay
+		f.nextBatch = resp.NextBatch
+		f.handleSync(ctx, resp)
+	}
+
+	return nil
+}
+
+// handleSync processes the timeline events of a sync response.
+func (f *Frontend) handleSync(ctx context.Context, resp *syncResponse) {
+	for roomID, room := range resp.Rooms.Join {
+		for i := range room.Timeline.Events {
+			evt := &room.Timeline.Events[i]
+
+			// Power level changes apply whoever made them
+			if evt.Type == eventTypePowerLevels {
+				f.updatePowerLevels(roomID, evt)
+				continue
+			}
+
+			// Ignore events sent by the bot itself
+			if evt.Sender == f.userID {
+				continue
+			}
+
+			switch evt.Type {
+			case eventTypeMessage:
+				if roomID == f.roomID {
+					f.handleMessage(ctx, evt)
+				}
+			case eventTypeReaction:
+				f.handleReaction(ctx, evt)
+			}
+		}
+	}
+}
+
+// handleMessage processes a message sent to the configured room.
+func (f *Frontend) handleMessage(ctx context.Context, evt *event) {
+	var content messageContent
+	if err := json.Unmarshal(evt.Content, &content); err != nil {
+		f.logger.Debug("Ignoring message with invalid content", zap.String("event_id", evt.EventID), zap.Error(err))
+		return
+	}
+
+	// Only handle plain text messages; edits and notices (other bots) are ignored
+	if content.MsgType != msgTypeText || (content.RelatesTo != nil && content.RelatesTo.RelType == relTypeReplace) {
+		return
+	}
+
+	message := f.convertMessage(evt, &content)
+	f.recordRequester(message.ID, message.SenderID)
+
+	// Check flood prevention - block messages that exceed rate limit
+	if !f.checkFlood(ctx, message) {
+		return
+	}
+
+	if registration, ok := f.lookupCommand(message.Text); ok {
+		go f.handleCommand(ctx, registration, message)
+		return
+	}
+
+	// With a request prefix, chatter with incidental links isn't a song request
+	if _, ok := text.StripRequestPrefix(stripReplyFallback(content.Body), f.config.RequestPrefix); !ok {
+		f.logger.Debug("Ignoring message without request prefix", zap.String("event_id", evt.EventID))
+		return
+	}
+
+	// Handle messages concurrently so approvals can be answered while a request waits
+	if f.messageHandler != nil {
+		go f.messageHandler(message)
+	}
+}
+
+// checkFlood applies flood prevention to a message and reacts if it was blocked.
+// Returns true if the message should be processed.
+func (f *Frontend) checkFlood(ctx context.Context, msg *chat.Message) bool {
+	if f.floodgate.CheckMessage(msg.ChatID, msg.SenderID) {
+		return true
+	}
+
+	f.logger.Debug("Message blocked due to flood prevention",
+		zap.String("chatID", msg.ChatID),
+		zap.String("userID", msg.SenderID),
+		zap.String("userName", msg.SenderName))
+
+	// React to indicate the message was blocked
+	if err := f.React(ctx, msg.ChatID, msg.ID, chat.ReactionYawning); err != nil {
+		f.logger.Debug("Failed to add flood reaction to message", zap.Error(err))
+	}
+	return false
+}
+
+// convertMessage converts a Matrix message event to the unified message format.
+func (f *Frontend) convertMessage(evt *event, content *messageContent) *chat.Message {
+	replyToID := ""
+	if content.RelatesTo != nil && content.RelatesTo.InReplyTo != nil {
+		replyToID = content.RelatesTo.InReplyTo.EventID
+	}
+
+	body, _ := text.StripRequestPrefix(stripReplyFallback(content.Body), f.config.RequestPrefix)
+	urls := f.parser.ParseMessage(body).URLs
+	var requests []string
+	if len(urls) == 0 {
+		requests = f.parser.RequestQueries(body)
+	}
+
+	return &chat.Message{
+		ID:         evt.EventID,
+		ChatID:     f.roomID,
+		SenderID:   evt.Sender,
+		SenderName: displayNameFromUserID(evt.Sender),
+		Text:       body,
+		URLs:       urls,
+		Requests:   requests,
+		IsGroup:    true,
+		ReplyToID:  replyToID,
+		Language:   text.DetectLanguage(body),
+		Raw:        evt,
+	}
+}
+
+// stripReplyFallback removes the quoted "> " lines clients prepend to the body of replies.
+func stripReplyFallback(body string) string {
+	if !strings.HasPrefix(body, "> ") {
+		return body
+	}
+
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, ">") {
+			return strings.TrimSpace(strings.Join(lines[i:], "\n"))
+		}
+	}
+	return ""
+}
+
+// displayNameFromUserID returns the localpart of a Matrix user ID like "@alice:example.org".
+func displayNameFromUserID(userID string) string {
+	localpart, _, _ := strings.Cut(strings.TrimPrefix(userID, "@"), ":")
+	return localpart
+}
+
+// recordRequester remembers the user a message was sent by or on behalf of.
+func (f *Frontend) recordRequester(eventID, userID string) {
+	if eventID == "" || userID == "" {
+		return
+	}
+
+	f.requesterMutex.Lock()
+	defer f.requesterMutex.Unlock()
+
+	if _, exists := f.requesters[eventID]; !exists {
+		f.requesterOrder = append(f.requesterOrder, eventID)
+	}
+	f.requesters[eventID] = userID
+
+	if len(f.requesterOrder) > maxTrackedRequesters {
+		delete(f.requesters, f.requesterOrder[0])
+		f.requesterOrder = f.requesterOrder[1:]
+	}
+}
+
+// requesterOf returns the user a message was sent by or on behalf of (empty if unknown).
+func (f *Frontend) requesterOf(eventID string) string {
+	f.requesterMutex.Lock()
+	defer f.requesterMutex.Unlock()
+	return f.requesters[eventID]
+}
+
+// SetCommandHandler sets the handler for a chat command (without the leading slash).
+func (f *Frontend) SetCommandHandler(command string, adminOnly bool, handler chat.CommandHandler) {
+	f.commandMutex.Lock()
+	defer f.commandMutex.Unlock()
+
+	f.commandHandlers[strings.ToLower(command)] = commandRegistration{
+		adminOnly: adminOnly,
+		handler:   handler,
+	}
+}
+
+// SetSearchHandler is a no-op: Matrix has no inline queries, tracks are requested by message instead.
+func (f *Frontend) SetSearchHandler(_ chat.SearchHandler) {}
+
+// SendPhoto sends the caption only: photos would have to be uploaded to the homeserver first.
+func (f *Frontend) SendPhoto(ctx context.Context, chatID, replyToID, _, caption string) (string, error) {
+	return f.SendText(ctx, chatID, replyToID, caption)
+}
+
+// SendChoices sends the text only: Matrix has no inline buttons, so the choices cannot be picked.
+func (f *Frontend) SendChoices(ctx context.Context, chatID, replyToID, text string, _ []chat.Choice) (string, error) {
+	return f.SendText(ctx, chatID, replyToID, text)
+}
+
+// SetChoiceHandler is a no-op: Matrix messages carry no choices to pick.
+func (f *Frontend) SetChoiceHandler(_ chat.ChoiceHandler) {}
+
+// lookupCommand returns the registered command a message invokes, if any.
+func (f *Frontend) lookupCommand(messageText string) (commandRegistration, bool) {
+	f.commandMutex.RLock()
+	defer f.commandMutex.RUnlock()
+
+	registration, exists := f.commandHandlers[parseCommand(messageText)]
+	return registration, exists
+}
+
+// handleCommand runs a command handler, rejecting admin-only commands from non-admins.
+func (f *Frontend) handleCommand(ctx context.Context, registration commandRegistration, message *chat.Message) {
+	if registration.adminOnly && !f.isAdmin(ctx, message.SenderID) {
+		f.logger.Debug("Rejected admin-only command from non-admin",
+			zap.String("command", parseCommand(message.Text)),
+			zap.String("userID", message.SenderID))
+		if _, err := f.SendText(ctx, message.ChatID, message.ID, f.localizer.T("error.command.admin_only")); err != nil {
+			f.logger.Debug("Failed to send admin-only reply", zap.Error(err))
+		}
+		return
+	}
+
+	registration.handler(ctx, message)
+}
+
+// parseCommand extracts the lowercase command name from a message like "/skip args" or "!skip args".
+func parseCommand(messageText string) string {
+	fields := strings.Fields(messageText)
+	if len(fields) == 0 || (!strings.HasPrefix(fields[0], "/") && !strings.HasPrefix(fields[0], "!")) {
+		return ""
+	}
+
+	return strings.ToLower(fields[0][1:])
+}
+
+// resolveRoomID maps the configured room (which may be an alias) to its room ID.
+func (f *Frontend) resolveRoomID(chatID string) string {
+	if chatID == f.config.RoomID && f.roomID != "" {
+		return f.roomID
+	}
+	return chatID
+}
+
+// SendText sends a text message to the specified room, optionally as a reply.
+// Long messages are split into several messages, of which the event ID of the first is returned.
+func (f *Frontend) SendText(ctx context.Context, chatID, replyToID, message string) (string, error) {
+	chunks := chat.SplitMessage(message, maxMessageLength)
+
+	eventID, err := f.sendTextChunk(ctx, chatID, replyToID, chunks[0])
+	if err != nil {
+		return "", err
+	}
+	for _, chunk := range chunks[1:] {
+		if _, err := f.sendTextChunk(ctx, chatID, replyToID, chunk); err != nil {
+			return eventID, err
+		}
+	}
+
+	return eventID, nil
+}
+
+// MaxMessageLength returns the maximum length of a Matrix message in UTF-16 code units.
+func (f *Frontend) MaxMessageLength() int {
+	return maxMessageLength
+}
+
+// sendTextChunk sends a notice of at most maxMessageLength UTF-16 code units.
+func (f *Frontend) sendTextChunk(ctx context.Context, chatID, replyToID, message string) (string, error) {
+	content := &messageContent{
+		MsgType: msgTypeNotice,
+		Body:    message,
+	}
+	if replyToID != "" {
+		content.RelatesTo = &relatesTo{InReplyTo: &inReplyTo{EventID: replyToID}}
+	}
+
+	eventID, err := f.client.sendEvent(ctx, f.resolveRoomID(chatID), eventTypeMessage, content)
+	if err != nil {
+		return "", fmt.Errorf("failed to send message: %w", err)
+	}
+
+	// Replies act on behalf of the requester, e.g. for community approval
+	if replyToID != "" {
+		f.recordRequester(eventID, f.requesterOf(replyToID))
+	}
+
+	return eventID, nil
+}
+
+// DeleteMessage redacts a message by its event ID.
+func (f *Frontend) DeleteMessage(ctx context.Context, chatID, msgID string) error {
+	if err := f.client.redact(ctx, f.resolveRoomID(chatID), msgID); err != nil {
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+	return nil
+}
+
+// React adds an emoji reaction to a message.
+func (f *Frontend) React(ctx context.Context, chatID, msgID string, r chat.Reaction) error {
+	content := &reactionContent{
+		RelatesTo: &relatesTo{
+			RelType: relTypeAnnotation,
+			EventID: msgID,
+			Key:     string(r),
+		},
+	}
+
+	eventID, err := f.client.sendEvent(ctx, f.resolveRoomID(chatID), eventTypeReaction, content)
+	if err != nil {
+		return fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	f.rememberReaction(reactionKey(chatID, msgID, r), eventID)
+	return nil
+}
+
+// RemoveReaction redacts a reaction the bot added to a message.
+func (f *Frontend) RemoveReaction(ctx context.Context, chatID, msgID string, r chat.Reaction) error {
+	key := reactionKey(chatID, msgID, r)
+
+	f.reactionMutex.Lock()
+	eventID, found := f.reactionEvents[key]
+	delete(f.reactionEvents, key)
+	f.reactionOrder = slices.DeleteFunc(f.reactionOrder, func(k string) bool { return k == key })
+	f.reactionMutex.Unlock()
+
+	if !found {
+		return nil
+	}
+	if err := f.client.redact(ctx, f.resolveRoomID(chatID), eventID); err != nil {
+		return fmt.Errorf("failed to remove reaction: %w", err)
+	}
+	return nil
+}
+
+// rememberReaction records the event of a reaction by the bot, forgetting the oldest beyond maxTrackedReactions.
+func (f *Frontend) rememberReaction(key, eventID string) {
+	f.reactionMutex.Lock()
+	defer f.reactionMutex.Unlock()
+
+	if _, known := f.reactionEvents[key]; !known {
+		f.reactionOrder = append(f.reactionOrder, key)
+	}
+	f.reactionEvents[key] = eventID
+
+	if len(f.reactionOrder) > maxTrackedReactions {
+		delete(f.reactionEvents, f.reactionOrder[0])
+		f.reactionOrder = f.reactionOrder[1:]
+	}
+}
+
+// reactionKey identifies a reaction of the bot to a message.
+func reactionKey(chatID, messageID string, r chat.Reaction) string {
+	return originKey(chatID, messageID) + "_" + string(r)
+}
+
+// EditMessage replaces the text of a message sent by the bot.
+// An empty text leaves the message unchanged, as there are no buttons to remove.
+func (f *Frontend) EditMessage(ctx context.Context, chatID, messageID, newText string) error {
+	if newText == "" {
+		return nil
+	}
+
+	content := &messageContent{
+		MsgType: msgTypeNotice,
+		Body:    "* " + newText,
+		RelatesTo: &relatesTo{
+			RelType: relTypeReplace,
+			EventID: messageID,
+		},
+		NewContent: &messageContent{
+			MsgType: msgTypeNotice,
+			Body:    newText,
+		},
+	}
+
+	if _, err := f.client.sendEvent(ctx, f.resolveRoomID(chatID), eventTypeMessage, content); err != nil {
+		return fmt.Errorf("failed to edit message: %w", err)
+	}
+
+	f.logger.Debug("Edited message",
+		zap.String("chatID", chatID),
+		zap.String("messageID", messageID),
+		zap.String("newText", newText))
+
+	return nil
+}
+
+// PinMessage is not supported by the Matrix frontend.
+func (f *Frontend) PinMessage(_ context.Context, _, _ string) error {
+	return fmt.Errorf("pin message: %w", errNotSupported)
+}
+
+// UnpinMessage is not supported by the Matrix frontend.
+func (f *Frontend) UnpinMessage(_ context.Context, _, _ string) error {
+	return fmt.Errorf("unpin message: %w", errNotSupported)
+}
+
+// SendDirectMessage sends a message to a user in a direct message room, creating the room if needed.
+func (f *Frontend) SendDirectMessage(ctx context.Context, userID, message string) (string, error) {
+	roomID, err := f.directRoom(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	eventID, err := f.client.sendEvent(ctx, roomID, eventTypeMessage, &messageContent{
+		MsgType: msgTypeNotice,
+		Body:    message,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send direct message to %s: %w", userID, err)
+	}
+	return eventID, nil
+}
+
+// directRoom returns the direct message room with a user, creating it on first use.
+func (f *Frontend) directRoom(ctx context.Context, userID string) (string, error) {
+	f.directRoomMutex.Lock()
+	defer f.directRoomMutex.Unlock()
+
+	if roomID, ok := f.directRooms[userID]; ok {
+		return roomID, nil
+	}
+
+	roomID, err := f.client.createDirectRoom(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to create direct message room with %s: %w", userID, err)
+	}
+	f.directRooms[userID] = roomID
+	return roomID, nil
+}
+
+// handleReaction processes a reaction to a message awaiting a decision.
+func (f *Frontend) handleReaction(ctx context.Context, evt *event) {
+	var content reactionContent
+	if err := json.Unmarshal(evt.Content, &content); err != nil || content.RelatesTo == nil ||
+		content.RelatesTo.RelType != relTypeAnnotation {
+		return
+	}
+
+	targetID := content.RelatesTo.EventID
+	reaction := normalizeReaction(content.RelatesTo.Key)
+
+	f.voteMutex.RLock()
+	vote := f.pendingVotes[targetID]
+	f.voteMutex.RUnlock()
+
+	if vote != nil {
+		if approved, decided := vote.vote(ctx, evt.Sender, reaction); decided {
+			sendApprovalDecision(vote.decision, approved)
+		}
+		return
+	}
+
+	f.handleQueueTrackReaction(ctx, evt.Sender, targetID, reaction)
+}
+
+// normalizeReaction strips the emoji variation selector some clients add to reaction keys.
+func normalizeReaction(key string) chat.Reaction {
+	return chat.Reaction(strings.TrimSuffix(key, variationSelector))
+}
+
+// thumbsDecision maps 👍 and 👎 reactions to a decision.
+func thumbsDecision(r chat.Reaction) (approved, decided bool) {
+	switch r {
+	case chat.ReactionThumbsUp:
+		return true, true
+	case chat.ReactionThumbsDown:
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// communityVote counts distinct 👍 reactions and decides once enough were given.
+// Reactions by the excluded users (the bot and the requester) are not counted.
+func communityVote(requiredReactions int, excluded ...string) voteFunc {
+	var mutex sync.Mutex
+	reactedUsers := make(map[string]bool)
+
+	return func(_ context.Context, sender string, r chat.Reaction) (bool, bool) {
+		if r != chat.ReactionThumbsUp || sender == "" || slices.Contains(excluded, sender) {
+			return false, false
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		reactedUsers[sender] = true
+		return true, len(reactedUsers) >= requiredReactions
+	}
+}
+
+// registerVote tracks reactions to the given messages until the returned cleanup function is called.
+// If originKey is set, the vote can also be decided with ResolveApproval.
+func (f *Frontend) registerVote(vote *reactionVote, originKey string, eventIDs ...string) func() {
+	f.voteMutex.Lock()
+	defer f.voteMutex.Unlock()
+
+	for _, eventID := range eventIDs {
+		f.pendingVotes[eventID] = vote
+	}
+	if originKey != "" {
+		f.originVotes[originKey] = append(f.originVotes[originKey], vote)
+	}
+
+	return func() {
+		f.voteMutex.Lock()
+		defer f.voteMutex.Unlock()
+
+		for _, eventID := range eventIDs {
+			delete(f.pendingVotes, eventID)
+		}
+		if originKey != "" {
+			f.originVotes[originKey] = slices.DeleteFunc(f.originVotes[originKey],
+				func(v *reactionVote) bool { return v == vote })
+			if len(f.originVotes[originKey]) == 0 {
+				delete(f.originVotes, originKey)
+			}
+		}
+	}
+}
+
+// awaitVote waits for a vote to be decided, returning false on timeout.
+func awaitVote(ctx context.Context, vote *reactionVote, timeoutSec int) bool {
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSec)*time.Second)
+	defer cancel()
+
+	select {
+	case approved := <-vote.decision:
+		return approved
+	case <-timeoutCtx.Done():
+		return false
+	}
+}
+
+// originKey identifies the request message a vote belongs to.
+func originKey(chatID, messageID string) string {
+	return chatID + "_" + messageID
+}
+
+// addVoteReactions adds 👍 and 👎 reactions to a prompt so they can be picked with a single tap.
+func (f *Frontend) addVoteReactions(ctx context.Context, roomID, eventID string) {
+	for _, r := range []chat.Reaction{chat.ReactionThumbsUp, chat.ReactionThumbsDown} {
+		if err := f.React(ctx, roomID, eventID, r); err != nil {
+			f.logger.Debug("Failed to add vote reaction", zap.String("event_id", eventID), zap.Error(err))
+		}
+	}
+}
+
+// redactAfterVote deletes a prompt once its vote is over, even if ctx was canceled.
+func (f *Frontend) redactAfterVote(ctx context.Context, roomID, eventID string) {
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+
+	if err := f.DeleteMessage(cleanupCtx, roomID, eventID); err != nil {
+		f.logger.Debug("Failed to delete prompt message", zap.Error(err))
+	}
+}
+
+// AwaitApproval asks the requester to confirm with a 👍 or 👎 reaction on the prompt.
+func (f *Frontend) AwaitApproval(ctx context.Context, origin *chat.Message, prompt string,
+	timeoutSec int) (bool, error) {
+	promptID, err := f.SendText(ctx, origin.ChatID, origin.ID, prompt)
+	if err != nil {
+		return false, fmt.Errorf("failed to send approval prompt: %w", err)
+	}
+	defer f.redactAfterVote(ctx, origin.ChatID, promptID)
+
+	vote := &reactionVote{
+		vote: func(_ context.Context, sender string, r chat.Reaction) (bool, bool) {
+			if sender != origin.SenderID {
+				return false, false
+			}
+			return thumbsDecision(r)
+		},
+		decision: make(chan bool, 1),
+	}
+	defer f.registerVote(vote, originKey(origin.ChatID, origin.ID), promptID)()
+
+	f.addVoteReactions(ctx, origin.ChatID, promptID)
+
+	return awaitVote(ctx, vote, timeoutSec), nil
+}
+
+// IsAdminApprovalEnabled returns whether admin approval is required for songs.
+func (f *Frontend) IsAdminApprovalEnabled() bool {
+	return f.config.AdminApproval
+}
+
+// AwaitAdminApproval asks the room administrators by direct message to approve a song with a reaction.
+// The preview URL is listed below the song link if it is not empty.
+func (f *Frontend) AwaitAdminApproval(ctx context.Context, origin *chat.Message,
+	songInfo, songURL, previewURL, trackMood string, timeoutSec int) (bool, error) {
+	adminIDs, err := f.GetAdminUserIDs(ctx, f.roomID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get room admins: %w", err)
+	}
+
+	if len(adminIDs) == 0 {
+		f.logger.Warn("No room administrators found, auto-approving")
+		return true, nil
+	}
+
+	key := originKey(origin.ChatID, origin.ID)
+	ctx, cancel := context.WithCancel(ctx)
+	f.adminApprovalMutex.Lock()
+	f.adminApprovalCancels[key] = cancel
+	f.adminApprovalMutex.Unlock()
+	defer func() {
+		cancel()
+		f.adminApprovalMutex.Lock()
+		delete(f.adminApprovalCancels, key)
+		f.adminApprovalMutex.Unlock()
+	}()
+
+	link := songURL
+	if previewURL != "" {
+		link += f.localizer.T("format.preview", previewURL)
+	}
+	prompt := f.localizer.T("admin.approval_prompt", origin.SenderName, songInfo, link, trackMood)
+	sentMessages := f.sendToAdmins(ctx, adminIDs, prompt)
+	if len(sentMessages) == 0 {
+		return false, errors.New("failed to send admin approval request to any admin")
+	}
+	defer func() {
+		for eventID, roomID := range sentMessages {
+			f.redactAfterVote(ctx, roomID, eventID)
+		}
+	}()
+
+	vote := &reactionVote{
+		vote: func(voteCtx context.Context, sender string, r chat.Reaction) (bool, bool) {
+			if !f.isAdmin(voteCtx, sender) {
+				return false, false
+			}
+			return thumbsDecision(r)
+		},
+		decision: make(chan bool, 1),
+	}
+	defer f.registerVote(vote, key, slices.Collect(maps.Keys(sentMessages))...)()
+
+	approved := awaitVote(ctx, vote, timeoutSec)
+	f.logger.Info("Admin approval finished",
+		zap.String("message_id", origin.ID),
+		zap.Bool("approved", approved))
+	return approved, nil
+}
+
+// sendToAdmins sends the approval prompt to each admin and returns the event IDs mapped to their rooms.
+func (f *Frontend) sendToAdmins(ctx context.Context, adminIDs []string, prompt string) map[string]string {
+	sentMessages := make(map[string]string, len(adminIDs))
+	for _, adminID := range adminIDs {
+		eventID, err := f.SendDirectMessage(ctx, adminID, prompt)
+		if err != nil {
+			f.logger.Warn("Failed to send admin approval request", zap.String("admin_id", adminID), zap.Error(err))
+			continue
+		}
+
+		roomID, _ := f.directRoom(ctx, adminID)
+		f.addVoteReactions(ctx, roomID, eventID)
+		sentMessages[eventID] = roomID
+	}
+	return sentMessages
+}
+
+// CancelAdminApproval cancels an ongoing admin approval, which deletes the approval requests.
+func (f *Frontend) CancelAdminApproval(_ context.Context, origin *chat.Message) {
+	f.adminApprovalMutex.Lock()
+	defer f.adminApprovalMutex.Unlock()
+
+	if cancel, ok := f.adminApprovalCancels[originKey(origin.ChatID, origin.ID)]; ok {
+		f.logger.Debug("Canceling admin approval", zap.String("message_id", origin.ID))
+		cancel()
+	}
+}
+
+// ResolveApproval answers a pending user confirmation or admin approval for a message
+// as if the reaction had been added. Returns false if no such approval is pending.
+func (f *Frontend) ResolveApproval(chatID, messageID string, approved bool) bool {
+	f.voteMutex.RLock()
+	defer f.voteMutex.RUnlock()
+
+	resolved := false
+	for _, vote := range f.originVotes[originKey(f.resolveRoomID(chatID), messageID)] {
+		if sendApprovalDecision(vote.decision, approved) {
+			resolved = true
+		}
+	}
+	return resolved
+}
+
+// sendApprovalDecision delivers a decision without blocking if one is already queued.
+func sendApprovalDecision(ch chan bool, approved bool) bool {
+	select {
+	case ch <- approved:
+		return true
+	default:
+		return false
+	}
+}
+
+// AwaitCommunityApproval waits for enough community 👍 reactions on a message.
+// The requester is looked up from the message the bot replied to, as Matrix user IDs are not numeric.
+func (f *Frontend) AwaitCommunityApproval(ctx context.Context, msgID string, requiredReactions, timeoutSec int,
+	_ int64) (bool, error) {
+	// If no reactions are required the vote is disabled, return false immediately
+	if requiredReactions <= 0 {
+		return false, nil
+	}
+
+	vote := &reactionVote{
+		vote:     communityVote(requiredReactions, f.userID, f.requesterOf(msgID)),
+		decision: make(chan bool, 1),
+	}
+	defer f.registerVote(vote, "", msgID)()
+
+	f.logger.Debug("Started community approval tracking",
+		zap.String("message_id", msgID),
+		zap.Int("required_reactions", requiredReactions),
+		zap.Int("timeout_sec", timeoutSec))
+
+	approved := awaitVote(ctx, vote, timeoutSec)
+	f.logger.Debug("Community approval finished",
+		zap.String("message_id", msgID),
+		zap.Bool("approved", approved))
+	return approved, nil
+}
+
+// SendQueueTrackApproval sends a queue track approval message that admins answer with a 👍 or 👎 reaction.
+func (f *Frontend) SendQueueTrackApproval(ctx context.Context, chatID, trackID, message string) (string, error) {
+	eventID, err := f.SendText(ctx, chatID, "", message)
+	if err != nil {
+		return "", fmt.Errorf("failed to send queue track approval message: %w", err)
+	}
+
+	f.queueTrackMutex.Lock()
+	f.pendingQueueTracks[eventID] = trackID
+	f.queueTrackMutex.Unlock()
+
+	f.addVoteReactions(ctx, chatID, eventID)
+
+	f.logger.Debug("Sent queue track approval message",
+		zap.String("chatID", chatID),
+		zap.String("trackID", trackID),
+		zap.String("messageID", eventID))
+
+	return eventID, nil
+}
+
+// SetQueueTrackDecisionHandler sets the handler for queue track approval/denial decisions.
+func (f *Frontend) SetQueueTrackDecisionHandler(handler func(ctx context.Context, trackID string, approved bool)) {
+	f.queueTrackDecisionHandler = handler
+}
+
+// handleQueueTrackReaction processes an admin's reaction to a queue track approval message.
+func (f *Frontend) handleQueueTrackReaction(ctx context.Context, sender, eventID string, r chat.Reaction) {
+	f.queueTrackMutex.Lock()
+	trackID, pending := f.pendingQueueTracks[eventID]
+	f.queueTrackMutex.Unlock()
+
+	approved, decided := thumbsDecision(r)
+	if !pending || !decided || !f.isAdmin(ctx, sender) {
+		return
+	}
+
+	f.queueTrackMutex.Lock()
+	delete(f.pendingQueueTracks, eventID)
+	f.queueTrackMutex.Unlock()
+
+	// Delete the message on denial, like the Telegram frontend does
+	if !approved {
+		if err := f.DeleteMessage(ctx, f.roomID, eventID); err != nil {
+			f.logger.Debug("Failed to delete queue track message", zap.Error(err))
+		}
+	}
+
+	if f.queueTrackDecisionHandler != nil {
+		go f.queueTrackDecisionHandler(ctx, trackID, approved)
+	}
+
+	f.logger.Info("Queue track decision processed",
+		zap.String("trackID", trackID),
+		zap.Bool("approved", approved),
+		zap.String("userID", sender))
+}
+
+// isAdmin checks if a user is an admin of the configured room, logging errors as non-admin.
+func (f *Frontend) isAdmin(ctx context.Context, userID string) bool {
+	isAdmin, err := f.IsUserAdmin(ctx, f.roomID, userID)
+	if err != nil {
+		f.logger.Warn("Failed to check admin status", zap.String("userID", userID), zap.Error(err))
+	}
+	return isAdmin
+}
+
+// IsUserAdmin checks if a user has at least moderator power level in the room.
+func (f *Frontend) IsUserAdmin(ctx context.Context, chatID, userID string) (bool, error) {
+	roomID := f.resolveRoomID(chatID)
+
+	// Only check admin status for the configured room
+	if roomID != f.roomID {
+		return false, nil
+	}
+
+	levels, err := f.roomPowerLevels(ctx, roomID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get power levels: %w", err)
+	}
+
+	return levels.levelOf(userID) >= adminPowerLevel, nil
+}
+
+// GetAdminUserIDs returns the users with at least moderator power level in the room, excluding the bot.
+func (f *Frontend) GetAdminUserIDs(ctx context.Context, chatID string) ([]string, error) {
+	roomID := f.resolveRoomID(chatID)
+
+	// Only get admins for the configured room
+	if roomID != f.roomID {
+		return nil, fmt.Errorf("chat ID %s does not match configured room %s", chatID, f.config.RoomID)
+	}
+
+	levels, err := f.roomPowerLevels(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get power levels: %w", err)
+	}
+
+	return adminUserIDs(levels, f.userID), nil
+}
+
+// roomPowerLevels returns the power levels of a room, cached until a change is synced or the cache expires.
+func (f *Frontend) roomPowerLevels(ctx context.Context, roomID string) (*powerLevels, error) {
+	f.powerLevelsMutex.Lock()
+	defer f.powerLevelsMutex.Unlock()
+
+	if cached, ok := f.powerLevels[roomID]; ok && time.Since(cached.updatedAt) < powerLevelsCacheTTL {
+		return cached.levels, nil
+	}
+
+	levels, err := f.client.powerLevels(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	f.powerLevels[roomID] = cachedPowerLevels{levels: levels, updatedAt: time.Now()}
+	return levels, nil
+}
+
+// updatePowerLevels replaces the cached power levels of a room with the content of a synced power level change.
+func (f *Frontend) updatePowerLevels(roomID string, evt *event) {
+	var levels powerLevels
+	if err := json.Unmarshal(evt.Content, &levels); err != nil {
+		f.logger.Warn("Failed to parse power levels, fetching them again",
+			zap.String("roomID", roomID),
+			zap.Error(err))
+		f.powerLevelsMutex.Lock()
+		delete(f.powerLevels, roomID)
+		f.powerLevelsMutex.Unlock()
+		return
+	}
+
+	f.powerLevelsMutex.Lock()
+	f.powerLevels[roomID] = cachedPowerLevels{levels: &levels, updatedAt: time.Now()}
+	f.powerLevelsMutex.Unlock()
+
+	f.logger.Debug("Room power levels changed", zap.String("roomID", roomID))
+}
+
+// adminUserIDs returns the sorted IDs of users with admin power level, excluding the bot.
+func adminUserIDs(levels *powerLevels, botUserID string) []string {
+	admins := make([]string, 0, len(levels.Users))
+	for userID, level := range levels.Users {
+		if level >= adminPowerLevel && userID != botUserID {
+			admins = append(admins, userID)
+		}
+	}
+	slices.Sort(admins)
+	return admins
+}
+
+// GetMemberCount returns the number of members in the configured room, cached for a short interval.
+func (f *Frontend) GetMemberCount(ctx context.Context) (int, error) {
+	f.memberCountMutex.Lock()
+	defer f.memberCountMutex.Unlock()
+
+	if f.memberCount > 0 && time.Since(f.memberCountCheckedAt) < memberCountCacheTTL {
+		return f.memberCount, nil
+	}
+
+	members, err := f.client.joinedMembers(ctx, f.roomID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get member count of room %s: %w", f.roomID, err)
+	}
+
+	f.memberCount = len(members)
+	f.memberCountCheckedAt = time.Now()
+
+	return f.memberCount, nil
+}
+
+// GetMe returns information about the bot user.
+// Matrix user IDs are not numeric, so only the username is set.
+func (f *Frontend) GetMe(ctx context.Context) (*chat.User, error) {
+	userID, err := f.client.whoAmI(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bot user info: %w", err)
+	}
+
+	return &chat.User{
+		IsBot:    true,
+		Username: userID,
+	}, nil
+}
+
+// GetChatMember is not supported, as Matrix rooms and users have no numeric IDs.
+func (f *Frontend) GetChatMember(_ context.Context, _, _ int64) (*chat.ChatMember, error) {
+	return nil, fmt.Errorf("get chat member: %w", errNotSupported)
+}
@@ -0,0 +1,253 @@
+package matrix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "Slash command", text: "/skip", expected: "skip"},
+		{name: "Bang command", text: "!skip", expected: "skip"},
+		{name: "Command with arguments", text: "/Stats reset", expected: "stats"},
+		{name: "Not a command", text: "skip this song", expected: ""},
+		{name: "Empty text", text: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCommand(tt.text); got != tt.expected {
+				t.Errorf("parseCommand(%q) = %q, want %q", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStripReplyFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{name: "Plain message", body: "play something", expected: "play something"},
+		{name: "Reply with fallback", body: "> <@alice:example.org> play it\n> again\n\nyes please", expected: "yes please"},
+		{name: "Only fallback", body: "> <@alice:example.org> hi", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripReplyFallback(tt.body); got != tt.expected {
+				t.Errorf("stripReplyFallback(%q) = %q, want %q", tt.body, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDisplayNameFromUserID(t *testing.T) {
+	if got := displayNameFromUserID("@alice:example.org"); got != "alice" {
+		t.Errorf("Expected localpart alice, got %q", got)
+	}
+}
+
+func TestThumbsDecision(t *testing.T) {
+	if approved, decided := thumbsDecision(chat.ReactionThumbsUp); !approved || !decided {
+		t.Error("👍 should approve")
+	}
+	if approved, decided := thumbsDecision(chat.ReactionThumbsDown); approved || !decided {
+		t.Error("👎 should deny")
+	}
+	if _, decided := thumbsDecision(chat.ReactionYawning); decided {
+		t.Error("Other reactions should not decide")
+	}
+	if normalizeReaction("👍️") != chat.ReactionThumbsUp {
+		t.Error("Variation selector should be stripped from reaction keys")
+	}
+}
+
+func TestCommunityVote(t *testing.T) {
+	ctx := context.Background()
+	vote := communityVote(2, "@bot:example.org", "@requester:example.org")
+
+	if _, decided := vote(ctx, "@requester:example.org", chat.ReactionThumbsUp); decided {
+		t.Error("Requester should not be able to approve their own request")
+	}
+	if _, decided := vote(ctx, "@bot:example.org", chat.ReactionThumbsUp); decided {
+		t.Error("Bot reactions should not count")
+	}
+	if _, decided := vote(ctx, "@alice:example.org", chat.ReactionThumbsUp); decided {
+		t.Error("One reaction should not be enough")
+	}
+	if _, decided := vote(ctx, "@alice:example.org", chat.ReactionThumbsUp); decided {
+		t.Error("Repeated reactions by the same user should not count twice")
+	}
+	if _, decided := vote(ctx, "@bob:example.org", chat.ReactionThumbsDown); decided {
+		t.Error("👎 should not count")
+	}
+	if approved, decided := vote(ctx, "@bob:example.org", chat.ReactionThumbsUp); !approved || !decided {
+		t.Error("Two distinct 👍 reactions should approve")
+	}
+}
+
+func TestAdminUserIDs(t *testing.T) {
+	levels := &powerLevels{
+		Users: map[string]int{
+			"@owner:example.org": 100,
+			"@mod:example.org":   50,
+			"@user:example.org":  0,
+			"@bot:example.org":   100,
+		},
+	}
+
+	admins := adminUserIDs(levels, "@bot:example.org")
+	if strings.Join(admins, ",") != "@mod:example.org,@owner:example.org" {
+		t.Errorf("Unexpected admins: %v", admins)
+	}
+
+	if levels.levelOf("@unknown:example.org") != 0 {
+		t.Error("Unknown users should get the default power level")
+	}
+}
+
+func TestSendTextAsReply(t *testing.T) {
+	var received messageContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPut || !strings.Contains(r.URL.Path, "/rooms/!room:example.org/send/m.room.message/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		_, _ = w.Write([]byte(`{"event_id":"$reply"}`))
+	}))
+	defer server.Close()
+
+	frontend := NewFrontend(&Config{
+		HomeserverURL: server.URL,
+		AccessToken:   "test-token",
+		RoomID:        "#party:example.org",
+	}, zap.NewNop())
+	frontend.roomID = "!room:example.org"
+	frontend.recordRequester("$request", "@alice:example.org")
+
+	eventID, err := frontend.SendText(context.Background(), "#party:example.org", "$request", "Added!")
+	if err != nil {
+		t.Fatalf("SendText failed: %v", err)
+	}
+
+	if eventID != "$reply" {
+		t.Errorf("Expected event ID $reply, got %q", eventID)
+	}
+	if received.Body != "Added!" || received.RelatesTo == nil || received.RelatesTo.InReplyTo.EventID != "$request" {
+		t.Errorf("Unexpected message content: %+v", received)
+	}
+	if requester := frontend.requesterOf("$reply"); requester != "@alice:example.org" {
+		t.Errorf("Reply should be attributed to the requester, got %q", requester)
+	}
+}
+
+func TestRemoveReaction(t *testing.T) {
+	var redacted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/send/m.reaction/"):
+			_, _ = w.Write([]byte(`{"event_id":"$reaction"}`))
+		case strings.Contains(r.URL.Path, "/redact/"):
+			redacted = append(redacted, r.URL.Path)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	frontend := NewFrontend(&Config{HomeserverURL: server.URL, AccessToken: "test-token"}, zap.NewNop())
+	ctx := context.Background()
+
+	if err := frontend.React(ctx, "!room:example.org", "$request", chat.ReactionProcessing); err != nil {
+		t.Fatalf("React failed: %v", err)
+	}
+	if err := frontend.RemoveReaction(ctx, "!room:example.org", "$request", chat.ReactionThumbsUp); err != nil {
+		t.Fatalf("RemoveReaction failed: %v", err)
+	}
+	if len(redacted) != 0 {
+		t.Fatalf("Expected reactions the bot didn't add to be left alone, got %v", redacted)
+	}
+
+	if err := frontend.RemoveReaction(ctx, "!room:example.org", "$request", chat.ReactionProcessing); err != nil {
+		t.Fatalf("RemoveReaction failed: %v", err)
+	}
+	if len(redacted) != 1 || !strings.Contains(redacted[0], "/redact/$reaction/") {
+		t.Errorf("Expected the reaction event to be redacted, got %v", redacted)
+	}
+}
+
+func TestIsUserAdmin_CachesPowerLevels(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/state/m.room.power_levels/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests++
+		_, _ = w.Write([]byte(`{"users":{"@mod:example.org":50}}`))
+	}))
+	defer server.Close()
+
+	frontend := NewFrontend(&Config{HomeserverURL: server.URL, AccessToken: "test-token"}, zap.NewNop())
+	frontend.roomID = "!room:example.org"
+	ctx := context.Background()
+
+	for range 3 {
+		if isAdmin, err := frontend.IsUserAdmin(ctx, "!room:example.org", "@mod:example.org"); err != nil || !isAdmin {
+			t.Fatalf("IsUserAdmin() = %v, %v; want the moderator to be an admin", isAdmin, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the power levels to be fetched once, got %d requests", requests)
+	}
+
+	// A synced power level change replaces the cached levels without another request
+	frontend.handleSync(ctx, syncResponseWithEvents("!room:example.org", event{
+		Type:    eventTypePowerLevels,
+		Sender:  "@owner:example.org",
+		Content: json.RawMessage(`{"users":{"@alice:example.org":50}}`),
+	}))
+	if isAdmin, _ := frontend.IsUserAdmin(ctx, "!room:example.org", "@mod:example.org"); isAdmin {
+		t.Error("Expected the demoted moderator to no longer be an admin")
+	}
+	if isAdmin, _ := frontend.IsUserAdmin(ctx, "!room:example.org", "@alice:example.org"); !isAdmin {
+		t.Error("Expected the promoted user to be an admin")
+	}
+	if requests != 1 {
+		t.Errorf("Expected no request after the synced change, got %d requests", requests)
+	}
+}
+
+// syncResponseWithEvents returns a sync response with the timeline events of a joined room.
+func syncResponseWithEvents(roomID string, events ...event) *syncResponse {
+	resp := &syncResponse{}
+	resp.Rooms.Join = map[string]struct {
+		Timeline struct {
+			Events []event `json:"events"`
+		} `json:"timeline"`
+	}{}
+	room := resp.Rooms.Join[roomID]
+	room.Timeline.Events = events
+	resp.Rooms.Join[roomID] = room
+	return resp
+}
@@ -0,0 +1,75 @@
+package chat
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// MessageLength returns the length of text in UTF-16 code units, the unit of Telegram's message length limits.
+// Characters outside the Basic Multilingual Plane, like most emoji, count twice.
+func MessageLength(text string) int {
+	length := 0
+	for _, r := range text {
+		length += utf16.RuneLen(r)
+	}
+	return length
+}
+
+// SplitMessage splits text into chunks of at most maxLength UTF-16 code units, breaking between lines.
+// Lines longer than maxLength are broken at the limit. A maxLength of 0 or less disables splitting.
+// At least one chunk is always returned, even for text that is nothing but whitespace.
+func SplitMessage(text string, maxLength int) []string {
+	if maxLength <= 0 || MessageLength(text) <= maxLength {
+		return []string{text}
+	}
+
+	var chunks, lines []string
+	length := 0
+	flush := func() {
+		if chunk := strings.Join(lines, "\n"); strings.TrimSpace(chunk) != "" {
+			chunks = append(chunks, chunk)
+		}
+		lines = nil
+		length = 0
+	}
+
+	for line := range strings.SplitSeq(text, "\n") {
+		lineLength := MessageLength(line)
+		for lineLength > maxLength {
+			flush()
+			head, tail := cutAtLength(line, maxLength)
+			chunks = append(chunks, head)
+			line = tail
+			lineLength = MessageLength(line)
+		}
+
+		if len(lines) > 0 && length+1+lineLength > maxLength {
+			flush()
+		}
+		if len(lines) > 0 {
+			length++ // Line break
+		}
+		lines = append(lines, line)
+		length += lineLength
+	}
+	flush()
+
+	if len(chunks) == 0 {
+		head, _ := cutAtLength(text, maxLength)
+		return []string{head}
+	}
+	return chunks
+}
+
+// cutAtLength splits text after at most maxLength UTF-16 code units, never within a character.
+// The head keeps at least the first character, so a character wider than maxLength still makes progress.
+func cutAtLength(text string, maxLength int) (head, tail string) {
+	length := 0
+	for i, r := range text {
+		length += utf16.RuneLen(r)
+		if length > maxLength && i > 0 {
+			return text[:i], text[i:]
+		}
+	}
+	return text, ""
+}
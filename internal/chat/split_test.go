@@ -0,0 +1,100 @@
+package chat
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxLength int
+		expected  []string
+	}{
+		{name: "Short message", text: "one\ntwo", maxLength: 10, expected: []string{"one\ntwo"}},
+		{name: "No limit", text: "one\ntwo", maxLength: 0, expected: []string{"one\ntwo"}},
+		{
+			name:      "Split between lines",
+			text:      "first line\nsecond line\nthird",
+			maxLength: 17,
+			expected:  []string{"first line", "second line\nthird"},
+		},
+		{
+			name:      "Exactly at the limit",
+			text:      "12345\n12345\n1",
+			maxLength: 11,
+			expected:  []string{"12345\n12345", "1"},
+		},
+		{
+			name:      "Overlong line",
+			text:      "header\n" + strings.Repeat("x", 12) + "\nfooter",
+			maxLength: 6,
+			expected:  []string{"header", "xxxxxx", "xxxxxx", "footer"},
+		},
+		{
+			name:      "Multibyte characters",
+			text:      "ääää\nöööö",
+			maxLength: 5,
+			expected:  []string{"ääää", "öööö"},
+		},
+		{
+			name:      "Emoji count as two UTF-16 units",
+			text:      "🎵🎵🎵\n🎤🎤🎤",
+			maxLength: 6,
+			expected:  []string{"🎵🎵🎵", "🎤🎤🎤"},
+		},
+		{
+			name:      "Overlong emoji line is not broken within a character",
+			text:      "🎵🎵🎵",
+			maxLength: 3,
+			expected:  []string{"🎵", "🎵", "🎵"},
+		},
+		{
+			name:      "Overlong whitespace",
+			text:      strings.Repeat("\n", 8),
+			maxLength: 4,
+			expected:  []string{"\n\n\n\n"},
+		},
+		{
+			name:      "Blank lines at the break",
+			text:      "first\n\n\nsecond",
+			maxLength: 6,
+			expected:  []string{"first\n", "second"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := SplitMessage(tt.text, tt.maxLength)
+			if !slices.Equal(chunks, tt.expected) {
+				t.Errorf("SplitMessage(%q, %d) = %q, want %q", tt.text, tt.maxLength, chunks, tt.expected)
+			}
+			for _, chunk := range chunks {
+				if tt.maxLength > 0 && MessageLength(chunk) > tt.maxLength {
+					t.Errorf("Chunk %q exceeds the limit of %d", chunk, tt.maxLength)
+				}
+			}
+		})
+	}
+}
+
+func TestMessageLength(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"grüezi", 6},
+		{"🎵 song", 7},
+		{"👨‍💻", 5},
+	}
+
+	for _, tt := range tests {
+		if got := MessageLength(tt.text); got != tt.expected {
+			t.Errorf("MessageLength(%q) = %d, want %d", tt.text, got, tt.expected)
+		}
+	}
+}
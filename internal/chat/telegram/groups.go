@@ -0,0 +1,119 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+// AddGroup creates the frontend of an additional group served by the same bot. It shares the bot and
+// update loop of f, which routes the updates of the group to it; everything else is scoped to the group.
+// Must be called before Start.
+func (f *Frontend) AddGroup(config *Config) *Frontend {
+	group := NewFrontend(config, f.logger.With(zap.Int64("group_id", config.GroupID)))
+	group.primary = f
+	f.groups = append(f.groups, group)
+	return group
+}
+
+// startGroup starts the frontend of an additional group once the primary frontend created the bot.
+func (f *Frontend) startGroup(ctx context.Context) error {
+	select {
+	case <-f.primary.botReady:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	f.bot = f.primary.bot
+
+	if err := f.verifyGroupAccess(ctx); err != nil {
+		return fmt.Errorf("failed to verify group access: %w", err)
+	}
+
+	f.startedAt = time.Now()
+	f.logger.Info("Telegram group frontend started successfully")
+	return nil
+}
+
+// routed returns a bot handler running handler on the frontend of the group the update belongs to.
+func (f *Frontend) routed(handler func(*Frontend, context.Context, *bot.Bot, *models.Update)) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		handler(f.groupFrontend(update), ctx, b, update)
+	}
+}
+
+// groupFrontend returns the frontend of the group an update belongs to. Updates of other chats,
+// such as private messages and inline queries, are handled by the primary frontend.
+func (f *Frontend) groupFrontend(update *models.Update) *Frontend {
+	chatID, ok := updateGroupID(update)
+	if !ok {
+		return f
+	}
+
+	for _, group := range f.groups {
+		if group.config.GroupID == chatID {
+			return group
+		}
+	}
+	return f
+}
+
+// updateGroupID returns the ID of the group an update belongs to. Admin approval decisions are
+// sent in private chats, but their approval key names the group the request was made in.
+func updateGroupID(update *models.Update) (int64, bool) {
+	switch {
+	case update.Message != nil:
+		// The first message of an upgraded group belongs to the group it was migrated from
+		if update.Message.MigrateFromChatID != 0 {
+			return update.Message.MigrateFromChatID, true
+		}
+		return update.Message.Chat.ID, true
+	case update.EditedMessage != nil:
+		return update.EditedMessage.Chat.ID, true
+	case update.MessageReaction != nil:
+		return update.MessageReaction.Chat.ID, true
+	case update.MessageReactionCount != nil:
+		return update.MessageReactionCount.Chat.ID, true
+	case update.CallbackQuery != nil:
+		if chatID, ok := adminApprovalGroupID(update.CallbackQuery.Data); ok {
+			return chatID, true
+		}
+		if update.CallbackQuery.Message.Message != nil {
+			return update.CallbackQuery.Message.Message.Chat.ID, true
+		}
+	}
+	return 0, false
+}
+
+// adminApprovalGroupID parses the group ID from the callback data of an admin approval decision,
+// e.g. "admin_approve_admin_-100123_42_1700000000".
+func adminApprovalGroupID(callbackData string) (int64, bool) {
+	approvalKey := strings.TrimPrefix(strings.TrimPrefix(callbackData, "admin_approve_"), "admin_deny_")
+	if approvalKey == callbackData || !strings.HasPrefix(approvalKey, "admin_") {
+		return 0, false
+	}
+
+	chatID, _, _ := strings.Cut(strings.TrimPrefix(approvalKey, "admin_"), "_")
+	groupID, err := strconv.ParseInt(chatID, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return groupID, true
+}
+
+// migratedGroupID returns the new chat ID of the group if the message reports its upgrade to a supergroup,
+// either in the old group or as the first message of the new supergroup.
+func migratedGroupID(msg *models.Message, groupID int64) (int64, bool) {
+	if msg.Chat.ID == groupID && msg.MigrateToChatID != 0 {
+		return msg.MigrateToChatID, true
+	}
+	if msg.MigrateFromChatID != 0 && msg.MigrateFromChatID == groupID && msg.Chat.ID != groupID {
+		return msg.Chat.ID, true
+	}
+	return 0, false
+}
@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-telegram/bot"
@@ -26,6 +29,7 @@ const (
 	entityTypeURL         = "url"
 	chatTypeGroup         = "group"
 	chatTypeSuperGroup    = "supergroup"
+	chatTypePrivate       = "private"
 	groupDiscoveryTimeout = 15 // seconds for group discovery
 	thumbsUpEmoji         = "👍"
 	floodEmoji            = "🌊"
@@ -33,17 +37,60 @@ const (
 	botStopDelay       = 200 * time.Millisecond
 	discoveryFinalWait = 50 * time.Millisecond
 	cleanupTimeout     = 5 * time.Second // Timeout for cleanup operations
+	// memberCountCacheTTL is how long the group member count is cached.
+	memberCountCacheTTL = 5 * time.Minute
+	// inlineQueryFloodKey is the flood prevention chat key for inline queries, which are not bound to a chat.
+	inlineQueryFloodKey = "inline_query"
+	// inlineQueryCacheTimeSecs is how long Telegram may cache inline query results.
+	inlineQueryCacheTimeSecs = 30
+	// choiceCallbackPrefix prefixes the callback data of inline buttons sent with SendChoices.
+	choiceCallbackPrefix = "choice_"
+	// startCommand is sent by Telegram when a user opens a private chat with the bot or follows a deep link.
+	startCommand = "start"
+	// pollTimeout is the long poll timeout for getUpdates requests.
+	pollTimeout = time.Minute
+	// reconnectBaseDelay is the initial wait before restarting the update loop.
+	reconnectBaseDelay = time.Second
+	// reconnectStableAfter is how long the update loop must run before the backoff starts over.
+	reconnectStableAfter = time.Minute
+	// pollStallTimeout is how long getUpdates may keep failing before the update loop is restarted.
+	// The bot library retries failed polls forever on its own, so the update loop never returns by itself.
+	pollStallTimeout = 3 * pollTimeout
+	// pollStallChecks is how often per pollStallTimeout the update loop is checked for a stall.
+	pollStallChecks = 6
+	// getUpdatesMethod is the Bot API method used for long polling.
+	getUpdatesMethod = "/getUpdates"
+	// editRequestWindow is how long after sending a message links added by editing it are still requested.
+	editRequestWindow = 5 * time.Minute
+	// maxMessageLength is the maximum length of a Telegram message in UTF-16 code units.
+	maxMessageLength = 4096
+	// maxCaptionLength is the maximum length of a Telegram photo caption in UTF-16 code units.
+	maxCaptionLength = 1024
+	// memberJoinRetention is how long the join times of new members are remembered.
+	memberJoinRetention = 24 * time.Hour
+	// maxTrackedReactions bounds how many messages the reactions of the bot are remembered for.
+	maxTrackedReactions = 1000
+	// maxHandledApprovals bounds how many handled approvals are remembered to answer repeated button taps.
+	maxHandledApprovals = 1000
 )
 
 // Config holds Telegram-specific configuration.
 type Config struct {
-	BotToken            string
-	GroupID             int64  // Chat ID of the group to monitor
-	AdminApproval       bool   // Whether admin approval is required for songs
-	AdminNeedsApproval  bool   // Whether admins also need approval (for testing)
-	CommunityApproval   int    // Number of 👍 reactions needed to bypass admin approval (0 disables)
-	Language            string // Bot language for user-facing messages
-	FloodLimitPerMinute int    // Maximum messages per user per minute
+	BotToken                 string
+	GroupID                  int64             // Chat ID of the group to monitor
+	AdminApproval            bool              // Whether admin approval is required for songs
+	AdminNeedsApproval       bool              // Whether admins also need approval (for testing)
+	CommunityApproval        int               // Number of 👍 reactions needed to bypass admin approval (0 disables)
+	CommunityApprovalPercent int               // Percentage of group members whose 👍 reactions bypass admin approval (0 disables)
+	Language                 string            // Bot language for user-facing messages
+	MessageOverrides         map[string]string // Custom wording merged over the bundled messages
+	FloodLimitPerMinute      int               // Maximum messages per user per minute
+	FloodBurst               int               // Extra messages per user in quick succession on top of the limit
+	MaxURLsPerMessage        int               // Maximum links processed per message (0 disables the limit)
+	ReconnectMaxBackoff      time.Duration     // Maximum wait between reconnect attempts of the update loop
+	WelcomeMessage           bool              // Post an introduction when the bot is added to the group
+	TopicID                  int               // Topic of a forum group to listen and reply in (0 uses the whole group)
+	RequestPrefix            string            // Prefix marking messages as song requests, e.g. "!play" (empty treats all as requests)
 }
 
 // Frontend implements the chat.Frontend interface for Telegram.
@@ -56,12 +103,27 @@ type Frontend struct {
 	floodgate      *flood.Floodgate
 	coreGroupIDPtr *int64 // Pointer to core config's GroupID for migration sync
 
+	// Additional groups served by the same bot, and the primary frontend of an additional group
+	groups   []*Frontend
+	primary  *Frontend
+	botReady chan struct{} // Closed once the bot is created by Start
+
 	// Message handling
 	messageHandler func(*chat.Message)
 
 	// Queue track decision handling
 	queueTrackDecisionHandler func(ctx context.Context, trackID string, approved bool)
 
+	// Command handling
+	commandMutex    sync.RWMutex
+	commandHandlers map[string]commandRegistration
+
+	// Inline query search handling
+	searchHandler chat.SearchHandler
+
+	// Inline button choice handling
+	choiceHandler chat.ChoiceHandler
+
 	// Approval tracking
 	approvalMutex    sync.RWMutex
 	pendingApprovals map[string]*approvalContext
@@ -73,6 +135,71 @@ type Frontend struct {
 	// Community approval tracking
 	communityApprovalMutex    sync.RWMutex
 	pendingCommunityApprovals map[string]*communityApprovalContext
+
+	// Title of the group, named in replies to private messages
+	groupTitleMutex sync.RWMutex
+	groupTitle      string
+
+	// Join times of members seen joining the group, reported by GetChatMember
+	memberJoinsMutex sync.Mutex
+	memberJoins      map[int64]time.Time
+
+	// Cached group member count for percentage-based community approval
+	memberCountMutex     sync.Mutex
+	memberCount          int
+	memberCountCheckedAt time.Time
+
+	// Set while the last long poll for updates succeeded, and when a long poll last succeeded (Unix nanoseconds)
+	connected  atomic.Bool
+	lastPollAt atomic.Int64
+	// How long polling may fail before the update loop is restarted, see pollStallTimeout
+	pollStallTimeout time.Duration
+
+	// When the frontend was started and the chats the welcome was posted in, so it's posted once per group
+	// and not again for join messages delivered late after a restart
+	startedAt time.Time
+	welcomed  sync.Map // Chat ID (int64) -> struct{}
+
+	// Links of recent messages, so edits only request the links they add
+	sentLinks sentLinks
+
+	// Reactions of the bot, as Telegram replaces them instead of adding up
+	botReactions botReactions
+
+	// Approvals whose buttons were already answered, so double taps aren't processed twice
+	handledApprovals handledApprovals
+}
+
+// sentLinks remembers the links of messages sent within the edit request window.
+type sentLinks struct {
+	mutex   sync.Mutex
+	entries map[int]sentLinksEntry // message ID -> links
+}
+
+// botReactions remembers the current reaction of the bot on recent messages.
+type botReactions struct {
+	mutex   sync.Mutex
+	current map[string]chat.Reaction // "chatID_msgID" -> reaction
+	order   []string
+}
+
+// handledApprovals remembers the keys of recently decided user and admin approvals.
+type handledApprovals struct {
+	mutex sync.Mutex
+	keys  map[string]struct{}
+	order []string
+}
+
+// sentLinksEntry holds the links of a single message.
+type sentLinksEntry struct {
+	urls   []string
+	sentAt time.Time
+}
+
+// commandRegistration holds a registered chat command handler.
+type commandRegistration struct {
+	adminOnly bool
+	handler   chat.CommandHandler
 }
 
 // approvalContext tracks pending user approvals.
@@ -89,6 +216,7 @@ type adminApprovalContext struct {
 	originUserName string
 	songInfo       string
 	songURL        string
+	previewURL     string // Short audio preview of the song (empty if none)
 	trackMood      string
 	approved       chan bool
 	cancelCtx      context.Context //nolint:containedctx // Required for timeout cancellation management
@@ -116,15 +244,23 @@ func NewFrontend(config *Config, logger *zap.Logger) *Frontend {
 		language = i18n.DefaultLanguage
 	}
 
+	localizer := i18n.NewLocalizer(language)
+	if err := localizer.LoadOverrides(config.MessageOverrides); err != nil {
+		logger.Warn("Ignoring invalid message overrides", zap.Error(err))
+	}
+
 	return &Frontend{
 		config:                    config,
 		logger:                    logger,
 		parser:                    text.NewParser(),
-		localizer:                 i18n.NewLocalizer(language),
-		floodgate:                 flood.New(config.FloodLimitPerMinute),
+		localizer:                 localizer,
+		floodgate:                 flood.New(config.FloodLimitPerMinute, config.FloodBurst),
 		pendingApprovals:          make(map[string]*approvalContext),
 		pendingAdminApprovals:     make(map[string]*adminApprovalContext),
 		pendingCommunityApprovals: make(map[string]*communityApprovalContext),
+		commandHandlers:           make(map[string]commandRegistration),
+		botReady:                  make(chan struct{}),
+		pollStallTimeout:          pollStallTimeout,
 	}
 }
 
@@ -136,38 +272,54 @@ func (f *Frontend) SetCoreGroupIDPointer(groupIDPtr *int64) {
 
 // Start initializes the Telegram bot and begins listening for updates.
 func (f *Frontend) Start(ctx context.Context) error {
+	if f.primary != nil {
+		return f.startGroup(ctx)
+	}
+
 	f.logger.Info("Starting Telegram frontend",
 		zap.String("group_id", strconv.FormatInt(f.config.GroupID, 10)))
 
 	opts := []bot.Option{
-		bot.WithDefaultHandler(f.handleUpdate),
-		bot.WithCallbackQueryDataHandler("confirm_", bot.MatchTypePrefix, f.handleConfirmCallback),
-		bot.WithCallbackQueryDataHandler("reject_", bot.MatchTypePrefix, f.handleRejectCallback),
+		bot.WithDefaultHandler(f.routed((*Frontend).handleUpdate)),
+		bot.WithHTTPClient(pollTimeout, &pollTrackingClient{
+			client:     &http.Client{Timeout: pollTimeout},
+			connected:  &f.connected,
+			lastPollAt: &f.lastPollAt,
+		}),
+		bot.WithCallbackQueryDataHandler("confirm_", bot.MatchTypePrefix, f.routed((*Frontend).handleConfirmCallback)),
+		bot.WithCallbackQueryDataHandler("reject_", bot.MatchTypePrefix, f.routed((*Frontend).handleRejectCallback)),
 		bot.WithCallbackQueryDataHandler("admin_approve_", bot.MatchTypePrefix,
-			func(ctx context.Context, b *bot.Bot, update *models.Update) {
-				f.handleAdminApprovalCallback(ctx, b, update, true)
-			}),
+			f.routed(func(g *Frontend, ctx context.Context, b *bot.Bot, update *models.Update) {
+				g.handleAdminApprovalCallback(ctx, b, update, true)
+			})),
 
 		bot.WithCallbackQueryDataHandler("admin_deny_", bot.MatchTypePrefix,
-			func(ctx context.Context, b *bot.Bot, update *models.Update) {
-				f.handleAdminApprovalCallback(ctx, b, update, false)
-			}),
+			f.routed(func(g *Frontend, ctx context.Context, b *bot.Bot, update *models.Update) {
+				g.handleAdminApprovalCallback(ctx, b, update, false)
+			})),
 
 		bot.WithCallbackQueryDataHandler("queue_approve_", bot.MatchTypePrefix,
-			func(ctx context.Context, b *bot.Bot, update *models.Update) {
-				f.handleQueueTrackCallback(ctx, b, update, true)
-			}),
+			f.routed(func(g *Frontend, ctx context.Context, b *bot.Bot, update *models.Update) {
+				g.handleQueueTrackCallback(ctx, b, update, true)
+			})),
 
 		bot.WithCallbackQueryDataHandler("queue_deny_", bot.MatchTypePrefix,
-			func(ctx context.Context, b *bot.Bot, update *models.Update) {
-				f.handleQueueTrackCallback(ctx, b, update, false)
-			}),
+			f.routed(func(g *Frontend, ctx context.Context, b *bot.Bot, update *models.Update) {
+				g.handleQueueTrackCallback(ctx, b, update, false)
+			})),
+
+		bot.WithCallbackQueryDataHandler(choiceCallbackPrefix, bot.MatchTypePrefix,
+			f.routed((*Frontend).handleChoiceCallback)),
+		// Slash commands; unknown commands fall through to regular message handling
+		bot.WithMessageTextHandler("/", bot.MatchTypePrefix, f.routed((*Frontend).handleCommand)),
 		// Configure allowed updates to include reaction events for community approval
 		bot.WithAllowedUpdates([]string{
 			"message",
+			"edited_message",
 			"callback_query",
 			"message_reaction",
 			"message_reaction_count",
+			"inline_query",
 		}),
 	}
 
@@ -177,6 +329,7 @@ func (f *Frontend) Start(ctx context.Context) error {
 	}
 
 	f.bot = b
+	close(f.botReady)
 
 	// Verify bot can access the group (skip if GroupID is 0 for interactive setup)
 	if f.config.GroupID != 0 {
@@ -185,22 +338,181 @@ func (f *Frontend) Start(ctx context.Context) error {
 		}
 	}
 
+	f.startedAt = time.Now()
+	f.connected.Store(true)
 	f.logger.Info("Telegram frontend started successfully")
 	return nil
 }
 
 // Listen starts listening for messages and calls the handler for each message.
+// If polling stops before ctx is done, it is restarted with exponential backoff.
 func (f *Frontend) Listen(ctx context.Context, handler func(*chat.Message)) error {
 	f.messageHandler = handler
 
-	// Start the bot
-	f.bot.Start(ctx)
+	// Updates of additional groups are received by the primary frontend
+	if f.primary != nil {
+		<-ctx.Done()
+		return nil
+	}
+
+	f.listenWithReconnect(ctx, f.bot.Start, f.reverifyGroupAccess)
 
 	return nil
 }
 
+// IsConnected reports whether the last long poll for updates succeeded.
+func (f *Frontend) IsConnected() bool {
+	if f.primary != nil {
+		return f.primary.IsConnected()
+	}
+	return f.connected.Load()
+}
+
+// listenWithReconnect runs poll until ctx is done. Whenever poll returns early or stalls because no long poll
+// succeeded for the stall timeout, it waits with exponential backoff up to the configured maximum
+// and restarts it once verify succeeds.
+func (f *Frontend) listenWithReconnect(ctx context.Context, poll func(context.Context),
+	verify func(context.Context) error) {
+	backoff := f.initialReconnectBackoff()
+	attempt := 0
+
+	for {
+		startedAt := time.Now()
+		f.lastPollAt.Store(startedAt.UnixNano())
+
+		pollCtx, cancelPoll := context.WithCancel(ctx)
+		go f.watchPolling(pollCtx, cancelPoll)
+		poll(pollCtx)
+		cancelPoll()
+		f.connected.Store(false)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(startedAt) >= reconnectStableAfter {
+			backoff = f.initialReconnectBackoff()
+			attempt = 0
+		}
+
+		for {
+			attempt++
+			f.logger.Warn("Telegram update polling stopped, reconnecting",
+				zap.Int("attempt", attempt),
+				zap.Duration("backoff", backoff))
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = f.nextReconnectBackoff(backoff)
+
+			if err := verify(ctx); err != nil {
+				f.logger.Warn("Telegram reconnect failed", zap.Int("attempt", attempt), zap.Error(err))
+				continue
+			}
+			break
+		}
+
+		f.connected.Store(true)
+		f.logger.Info("Reconnected to Telegram", zap.Int("attempt", attempt))
+	}
+}
+
+// watchPolling cancels the update loop once no long poll succeeded for the stall timeout.
+func (f *Frontend) watchPolling(ctx context.Context, cancelPoll context.CancelFunc) {
+	ticker := time.NewTicker(f.pollStallTimeout / pollStallChecks)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if since := time.Since(time.Unix(0, f.lastPollAt.Load())); since >= f.pollStallTimeout {
+			f.logger.Warn("Telegram update polling stalled, restarting it", zap.Duration("sinceLastPoll", since))
+			cancelPoll()
+			return
+		}
+	}
+}
+
+// initialReconnectBackoff returns the wait before the first reconnect attempt.
+func (f *Frontend) initialReconnectBackoff() time.Duration {
+	return min(reconnectBaseDelay, f.maxReconnectBackoff())
+}
+
+// nextReconnectBackoff doubles the backoff, capped at the configured maximum.
+func (f *Frontend) nextReconnectBackoff(backoff time.Duration) time.Duration {
+	return min(backoff*2, f.maxReconnectBackoff())
+}
+
+// maxReconnectBackoff returns the configured maximum backoff, defaulting to reconnectStableAfter.
+func (f *Frontend) maxReconnectBackoff() time.Duration {
+	if f.config.ReconnectMaxBackoff <= 0 {
+		return reconnectStableAfter
+	}
+	return f.config.ReconnectMaxBackoff
+}
+
+// reverifyGroupAccess checks that the group is still reachable before polling is restarted.
+func (f *Frontend) reverifyGroupAccess(ctx context.Context) error {
+	if f.config.GroupID == 0 {
+		_, err := f.bot.GetMe(ctx)
+		return err
+	}
+	return f.verifyGroupAccess(ctx)
+}
+
+// pollTrackingClient records whether and when the latest getUpdates long poll succeeded,
+// since the bot library retries failed polls internally without reporting them.
+type pollTrackingClient struct {
+	client     bot.HttpClient
+	connected  *atomic.Bool
+	lastPollAt *atomic.Int64
+}
+
+// Do executes the request and updates the connection state for getUpdates requests.
+func (c *pollTrackingClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if strings.HasSuffix(req.URL.Path, getUpdatesMethod) && req.Context().Err() == nil {
+		succeeded := err == nil && resp.StatusCode == http.StatusOK
+		c.connected.Store(succeeded)
+		if succeeded {
+			c.lastPollAt.Store(time.Now().UnixNano())
+		}
+	}
+	return resp, err
+}
+
 // SendText sends a text message to the specified chat, optionally as a reply.
+// Long messages are split into several messages, of which the ID of the first is returned.
 func (f *Frontend) SendText(ctx context.Context, chatID, replyToID, message string) (string, error) {
+	chunks := chat.SplitMessage(message, maxMessageLength)
+
+	messageID, err := f.sendTextChunk(ctx, chatID, replyToID, chunks[0])
+	if err != nil {
+		return "", err
+	}
+	for _, chunk := range chunks[1:] {
+		if _, err := f.sendTextChunk(ctx, chatID, replyToID, chunk); err != nil {
+			return messageID, err
+		}
+	}
+
+	return messageID, nil
+}
+
+// MaxMessageLength returns the maximum length of a Telegram message in UTF-16 code units.
+func (f *Frontend) MaxMessageLength() int {
+	return maxMessageLength
+}
+
+// sendTextChunk sends a text message of at most maxMessageLength UTF-16 code units.
+func (f *Frontend) sendTextChunk(ctx context.Context, chatID, replyToID, message string) (string, error) {
 	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
 	if err != nil {
 		return "", fmt.Errorf("invalid chat ID: %w", err)
@@ -236,6 +548,42 @@ func (f *Frontend) SendText(ctx context.Context, chatID, replyToID, message stri
 	return strconv.Itoa(msg.ID), nil
 }
 
+// SendPhoto sends a photo from a URL with a caption, optionally as a reply.
+// Captions too long for a photo are sent as a text message instead.
+func (f *Frontend) SendPhoto(ctx context.Context, chatID, replyToID, photoURL, caption string) (string, error) {
+	if chat.MessageLength(caption) > maxCaptionLength {
+		return f.SendText(ctx, chatID, replyToID, caption)
+	}
+
+	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid chat ID: %w", err)
+	}
+
+	params := &bot.SendPhotoParams{
+		ChatID:  chatIDInt,
+		Photo:   &models.InputFileString{Data: photoURL},
+		Caption: caption,
+	}
+	if f.config.TopicID != 0 && chatIDInt == f.config.GroupID {
+		params.MessageThreadID = f.config.TopicID
+	}
+	if replyToID != "" {
+		messageID, parseErr := strconv.Atoi(replyToID)
+		if parseErr != nil {
+			return "", fmt.Errorf("invalid reply message ID: %w", parseErr)
+		}
+		params.ReplyParameters = &models.ReplyParameters{MessageID: messageID}
+	}
+
+	msg, err := f.bot.SendPhoto(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to send photo: %w", err)
+	}
+
+	return strconv.Itoa(msg.ID), nil
+}
+
 // DeleteMessage deletes a message by its ID.
 func (f *Frontend) DeleteMessage(ctx context.Context, chatID, msgID string) error {
 	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
@@ -294,6 +642,33 @@ func (f *Frontend) React(ctx context.Context, chatID, msgID string, r chat.React
 		return nil
 	}
 
+	f.botReactions.set(chatID+"_"+msgID, r)
+	return nil
+}
+
+// RemoveReaction clears the reaction of the bot if it is still the given one.
+func (f *Frontend) RemoveReaction(ctx context.Context, chatID, msgID string, r chat.Reaction) error {
+	if !f.botReactions.take(chatID+"_"+msgID, r) {
+		return nil
+	}
+
+	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid chat ID: %w", err)
+	}
+
+	messageID, err := strconv.Atoi(msgID)
+	if err != nil {
+		return fmt.Errorf("invalid message ID: %w", err)
+	}
+
+	if _, err := f.bot.SetMessageReaction(ctx, &bot.SetMessageReactionParams{
+		ChatID:    chatIDInt,
+		MessageID: messageID,
+		Reaction:  []models.ReactionType{},
+	}); err != nil {
+		return fmt.Errorf("failed to remove reaction: %w", err)
+	}
 	return nil
 }
 
@@ -426,14 +801,20 @@ func (f *Frontend) handleUpdate(ctx context.Context, _ *bot.Bot, update *models.
 	// Debug logging to track all incoming update types
 	f.logger.Debug("Received Telegram update",
 		zap.Bool("has_message", update.Message != nil),
+		zap.Bool("has_edited_message", update.EditedMessage != nil),
 		zap.Bool("has_callback_query", update.CallbackQuery != nil),
 		zap.Bool("has_message_reaction", update.MessageReaction != nil),
-		zap.Bool("has_message_reaction_count", update.MessageReactionCount != nil))
+		zap.Bool("has_message_reaction_count", update.MessageReactionCount != nil),
+		zap.Bool("has_inline_query", update.InlineQuery != nil))
 
 	if update.Message != nil {
 		f.handleMessage(ctx, update.Message)
 	}
 
+	if update.EditedMessage != nil {
+		f.handleEditedMessage(ctx, update.EditedMessage)
+	}
+
 	// Handle individual message reactions (for more granular tracking)
 	if update.MessageReaction != nil {
 		f.handleMessageReaction(ctx, update.MessageReaction)
@@ -443,12 +824,39 @@ func (f *Frontend) handleUpdate(ctx context.Context, _ *bot.Bot, update *models.
 	if update.MessageReactionCount != nil {
 		f.handleMessageReactionCount(ctx, update.MessageReactionCount)
 	}
+
+	if update.InlineQuery != nil {
+		f.handleInlineQuery(ctx, update.InlineQuery)
+	}
 }
 
 // handleMessage processes incoming messages.
 func (f *Frontend) handleMessage(ctx context.Context, msg *models.Message) {
+	// A group upgraded to a supergroup continues under a new chat ID
+	if newChatID, migrated := migratedGroupID(msg, f.config.GroupID); migrated {
+		f.logger.Info("Group was upgraded to a supergroup, updating chat ID",
+			zap.Int64("old_chat_id", f.config.GroupID),
+			zap.Int64("new_chat_id", newChatID))
+		f.migrateGroup(newChatID)
+		return
+	}
+
+	// The bot may be added to a group before it is configured, e.g. for the interactive group selection
+	f.welcomeIfAdded(ctx, msg)
+
 	// Only process messages from the configured group
 	if msg.Chat.ID != f.config.GroupID {
+		if msg.Chat.Type == chatTypePrivate {
+			f.handlePrivateMessage(ctx, msg)
+		}
+		return
+	}
+
+	// Remember when members joined, as Telegram doesn't report it for chat members
+	f.recordMemberJoins(msg)
+
+	// Only listen to the configured topic of a forum group
+	if !f.inTopic(msg) {
 		return
 	}
 
@@ -465,41 +873,360 @@ func (f *Frontend) handleMessage(ctx context.Context, msg *models.Message) {
 		return
 	}
 
+	// With a request prefix, chatter with incidental links isn't a song request
+	if !f.isRequest(msg) {
+		f.logger.Debug("Ignoring message without request prefix", zap.Int("messageID", msg.ID))
+		return
+	}
+
 	// Check flood prevention - block messages that exceed rate limit
+	if !f.checkFlood(ctx, msg) {
+		return
+	}
+
+	// Convert to unified message format
+	message := f.convertMessage(msg)
+	f.sentLinks.add(msg.ID, message.URLs, time.Unix(int64(msg.Date), 0))
+
+	// Check link flooding - block repeated links and limit links per message
+	if !f.checkLinkFlood(ctx, message) {
+		return
+	}
+
+	// Call the message handler
+	if f.messageHandler != nil {
+		f.messageHandler(message)
+	}
+}
+
+// handleEditedMessage requests links added by editing a recent message, e.g. to fix a typo in a link.
+// Edits that don't add links are ignored, so requests are never processed twice.
+func (f *Frontend) handleEditedMessage(ctx context.Context, msg *models.Message) {
+	if msg.Chat.ID != f.config.GroupID || !f.inTopic(msg) || msg.From.IsBot || strings.HasPrefix(msg.Text, "/") ||
+		!f.isRequest(msg) {
+		return
+	}
+
+	sentAt := time.Unix(int64(msg.Date), 0)
+	if time.Unix(int64(msg.EditDate), 0).Sub(sentAt) > editRequestWindow {
+		f.logger.Debug("Ignoring edit of an old message", zap.Int("messageID", msg.ID))
+		return
+	}
+
+	addedURLs := f.sentLinks.add(msg.ID, f.extractURLs(msg), sentAt)
+	if len(addedURLs) == 0 {
+		f.logger.Debug("Ignoring edit without new links", zap.Int("messageID", msg.ID))
+		return
+	}
+
+	if !f.checkFlood(ctx, msg) {
+		return
+	}
+
+	message := f.convertMessage(msg)
+	message.URLs = addedURLs
+	if !f.checkLinkFlood(ctx, message) {
+		return
+	}
+
+	f.logger.Info("Processing links added by an edit",
+		zap.Int("messageID", msg.ID),
+		zap.Strings("urls", addedURLs))
+
+	if f.messageHandler != nil {
+		f.messageHandler(message)
+	}
+}
+
+// add remembers the links of a message and returns the ones it didn't have before.
+// Messages sent before the edit request window are forgotten.
+func (s *sentLinks) add(messageID int, urls []string, sentAt time.Time) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.entries == nil {
+		s.entries = make(map[int]sentLinksEntry)
+	}
+	for id, entry := range s.entries {
+		if time.Since(entry.sentAt) > editRequestWindow {
+			delete(s.entries, id)
+		}
+	}
+
+	entry := s.entries[messageID]
+	var added []string
+	for _, url := range urls {
+		if !slices.Contains(entry.urls, url) && !slices.Contains(added, url) {
+			added = append(added, url)
+		}
+	}
+
+	s.entries[messageID] = sentLinksEntry{urls: append(entry.urls, added...), sentAt: sentAt}
+	return added
+}
+
+// set remembers the reaction of the bot on a message, forgetting the oldest message beyond maxTrackedReactions.
+func (b *botReactions) set(key string, r chat.Reaction) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.current == nil {
+		b.current = make(map[string]chat.Reaction)
+	}
+	if _, known := b.current[key]; !known {
+		b.order = append(b.order, key)
+	}
+	b.current[key] = r
+
+	if len(b.order) > maxTrackedReactions {
+		delete(b.current, b.order[0])
+		b.order = b.order[1:]
+	}
+}
+
+// take forgets the reaction on a message and reports whether it was the given one.
+func (b *botReactions) take(key string, r chat.Reaction) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.current[key] != r {
+		return false
+	}
+	delete(b.current, key)
+	b.order = slices.DeleteFunc(b.order, func(k string) bool { return k == key })
+	return true
+}
+
+// consume marks an approval as handled and reports whether it wasn't handled before.
+// The oldest approvals beyond maxHandledApprovals are forgotten.
+func (h *handledApprovals) consume(key string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if _, handled := h.keys[key]; handled {
+		return false
+	}
+	if h.keys == nil {
+		h.keys = make(map[string]struct{})
+	}
+	h.keys[key] = struct{}{}
+	h.order = append(h.order, key)
+
+	if len(h.order) > maxHandledApprovals {
+		delete(h.keys, h.order[0])
+		h.order = h.order[1:]
+	}
+	return true
+}
+
+// contains reports whether an approval was already handled.
+func (h *handledApprovals) contains(key string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	_, handled := h.keys[key]
+	return handled
+}
+
+// checkFlood applies flood prevention to a message and reacts if it was blocked.
+// Returns true if the message should be processed.
+func (f *Frontend) checkFlood(ctx context.Context, msg *models.Message) bool {
 	chatID := strconv.FormatInt(msg.Chat.ID, 10)
 	userID := strconv.FormatInt(msg.From.ID, 10)
-	if !f.floodgate.CheckMessage(chatID, userID) {
-		f.logger.Debug("Message blocked due to flood prevention",
-			zap.String("chatID", chatID),
-			zap.String("userID", userID),
-			zap.String("userName", f.getUserDisplayName(msg.From)))
+	if f.floodgate.CheckMessage(chatID, userID) {
+		return true
+	}
+
+	f.logger.Debug("Message blocked due to flood prevention",
+		zap.String("chatID", chatID),
+		zap.String("userID", userID),
+		zap.String("userName", f.getUserDisplayName(msg.From)))
+
+	// React with flood emoji to indicate the message was blocked
+	if err := f.React(ctx, chatID, strconv.Itoa(msg.ID), chat.ReactionYawning); err != nil {
+		f.logger.Debug("Failed to add flood reaction to message", zap.Error(err))
+	}
+	return false
+}
 
-		// React with flood emoji to indicate the message was blocked
-		if err := f.React(ctx, chatID, strconv.Itoa(msg.ID), chat.ReactionYawning); err != nil {
+// checkLinkFlood blocks messages repeating a link the sender just shared and trims
+// messages with too many links to the configured limit. Returns true if the message should be processed.
+func (f *Frontend) checkLinkFlood(ctx context.Context, message *chat.Message) bool {
+	if !f.floodgate.CheckLinks(message.ChatID, message.SenderID, message.URLs) {
+		f.logger.Debug("Message blocked due to repeated link",
+			zap.String("userID", message.SenderID),
+			zap.String("userName", message.SenderName))
+		if err := f.React(ctx, message.ChatID, message.ID, chat.ReactionYawning); err != nil {
 			f.logger.Debug("Failed to add flood reaction to message", zap.Error(err))
 		}
-		return
+		return false
+	}
+
+	maxURLs := f.config.MaxURLsPerMessage
+	if maxURLs <= 0 || len(message.URLs) <= maxURLs {
+		return true
+	}
+
+	f.logger.Debug("Message contains too many links",
+		zap.String("userID", message.SenderID),
+		zap.Int("links", len(message.URLs)),
+		zap.Int("maxLinks", maxURLs))
+	message.URLs = message.URLs[:maxURLs]
+
+	if err := f.React(ctx, message.ChatID, message.ID, chat.ReactionYawning); err != nil {
+		f.logger.Debug("Failed to add flood reaction to message", zap.Error(err))
+	}
+	if _, err := f.SendText(ctx, message.ChatID, message.ID, f.localizer.T("error.flood.too_many_links", maxURLs)); err != nil {
+		f.logger.Debug("Failed to send too many links reply", zap.Error(err))
+	}
+	return true
+}
+
+// convertMessage converts a Telegram message to the unified message format.
+func (f *Frontend) convertMessage(msg *models.Message) *chat.Message {
+	replyToID := ""
+	if msg.ReplyToMessage != nil {
+		replyToID = strconv.Itoa(msg.ReplyToMessage.ID)
 	}
 
-	// Extract URLs from the message
 	urls := f.extractURLs(msg)
+	requestText, _ := text.StripRequestPrefix(msg.Text, f.config.RequestPrefix)
+	var requests []string
+	if len(urls) == 0 {
+		requests = f.parser.RequestQueries(requestText)
+	}
 
-	// Convert to unified message format
-	message := chat.Message{
+	return &chat.Message{
 		ID:         strconv.Itoa(msg.ID),
 		ChatID:     strconv.FormatInt(msg.Chat.ID, 10),
 		SenderID:   strconv.FormatInt(msg.From.ID, 10),
 		SenderName: f.getUserDisplayName(msg.From),
-		Text:       msg.Text,
+		Text:       requestText,
 		URLs:       urls,
+		Requests:   requests,
 		IsGroup:    msg.Chat.Type == chatTypeGroup || msg.Chat.Type == chatTypeSuperGroup,
+		ReplyToID:  replyToID,
+		Language:   text.DetectLanguage(requestText),
 		Raw:        msg,
 	}
+}
 
-	// Call the message handler
-	if f.messageHandler != nil {
-		f.messageHandler(&message)
+// SetCommandHandler sets the handler for a chat command (without the leading slash).
+func (f *Frontend) SetCommandHandler(command string, adminOnly bool, handler chat.CommandHandler) {
+	f.commandMutex.Lock()
+	defer f.commandMutex.Unlock()
+
+	f.commandHandlers[strings.ToLower(command)] = commandRegistration{
+		adminOnly: adminOnly,
+		handler:   handler,
+	}
+}
+
+// SetSearchHandler sets the handler for inline track searches.
+func (f *Frontend) SetSearchHandler(handler chat.SearchHandler) {
+	f.searchHandler = handler
+}
+
+// handleInlineQuery answers "@botname song name" inline queries with matching tracks.
+// Picking a result posts the track URL, which then flows through the regular message handling.
+func (f *Frontend) handleInlineQuery(ctx context.Context, query *models.InlineQuery) {
+	searchQuery := strings.TrimSpace(query.Query)
+	if f.searchHandler == nil || searchQuery == "" || query.From == nil {
+		return
 	}
+
+	userID := strconv.FormatInt(query.From.ID, 10)
+	if !f.floodgate.CheckMessage(inlineQueryFloodKey, userID) {
+		f.logger.Debug("Inline query blocked due to flood prevention",
+			zap.String("userID", userID),
+			zap.String("userName", f.getUserDisplayName(query.From)))
+		return
+	}
+
+	results, err := f.searchHandler(ctx, searchQuery)
+	if err != nil {
+		f.logger.Debug("Inline search failed",
+			zap.String("query", searchQuery),
+			zap.Error(err))
+	}
+
+	_, err = f.bot.AnswerInlineQuery(ctx, &bot.AnswerInlineQueryParams{
+		InlineQueryID: query.ID,
+		Results:       buildInlineQueryResults(results),
+		CacheTime:     inlineQueryCacheTimeSecs,
+	})
+	if err != nil {
+		f.logger.Warn("Failed to answer inline query", zap.Error(err))
+	}
+}
+
+// buildInlineQueryResults converts search results to articles that post the track URL when picked.
+func buildInlineQueryResults(results []chat.SearchResult) []models.InlineQueryResult {
+	articles := make([]models.InlineQueryResult, 0, len(results))
+	for _, result := range results {
+		articles = append(articles, &models.InlineQueryResultArticle{
+			ID:          result.ID,
+			Title:       result.Title,
+			Description: result.Artist,
+			InputMessageContent: &models.InputTextMessageContent{
+				MessageText: result.URL,
+			},
+		})
+	}
+	return articles
+}
+
+// handleCommand processes slash commands sent to the group.
+// Messages that are not registered commands are handled like any other update.
+func (f *Frontend) handleCommand(ctx context.Context, b *bot.Bot, update *models.Update) {
+	msg := update.Message
+
+	f.commandMutex.RLock()
+	registration, exists := f.commandHandlers[parseCommand(msg.Text)]
+	f.commandMutex.RUnlock()
+
+	if !exists || msg.Chat.ID != f.config.GroupID || !f.inTopic(msg) || msg.From == nil || msg.From.IsBot {
+		f.handleUpdate(ctx, b, update)
+		return
+	}
+
+	if !f.checkFlood(ctx, msg) {
+		return
+	}
+
+	message := f.convertMessage(msg)
+
+	if registration.adminOnly {
+		isAdmin, err := f.IsUserAdmin(ctx, message.ChatID, message.SenderID)
+		if err != nil {
+			f.logger.Warn("Failed to check admin status for command", zap.Error(err))
+		}
+		if !isAdmin {
+			f.logger.Debug("Rejected admin-only command from non-admin",
+				zap.String("command", parseCommand(msg.Text)),
+				zap.String("userID", message.SenderID))
+			if _, err := f.SendText(ctx, message.ChatID, message.ID, f.localizer.T("error.command.admin_only")); err != nil {
+				f.logger.Debug("Failed to send admin-only reply", zap.Error(err))
+			}
+			return
+		}
+	}
+
+	registration.handler(ctx, message)
+}
+
+// parseCommand extracts the lowercase command name from a message like "/skip@MyBot args".
+func parseCommand(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+		return ""
+	}
+
+	command := strings.TrimPrefix(fields[0], "/")
+	if at := strings.Index(command, "@"); at >= 0 {
+		command = command[:at]
+	}
+
+	return strings.ToLower(command)
 }
 
 // handleMessageReactionCount processes incoming message reaction count updates for community approval.
@@ -713,7 +1440,7 @@ func (f *Frontend) handleApprovalCallback(ctx context.Context, b *bot.Bot, updat
 
 	approval := f.getApproval(approvalKey)
 	if approval == nil {
-		f.answerExpiredCallback(ctx, b, update.CallbackQuery.ID)
+		f.answerMissingApprovalCallback(ctx, b, update.CallbackQuery.ID, approvalKey)
 		return
 	}
 
@@ -721,6 +1448,11 @@ func (f *Frontend) handleApprovalCallback(ctx context.Context, b *bot.Bot, updat
 		return
 	}
 
+	if !f.handledApprovals.consume(approvalKey) {
+		f.answerHandledCallback(ctx, b, update.CallbackQuery.ID)
+		return
+	}
+
 	f.sendApprovalResult(ctx, b, update.CallbackQuery, approval, approved)
 }
 
@@ -757,6 +1489,26 @@ func (f *Frontend) answerExpiredCallback(ctx context.Context, b *bot.Bot, callba
 	}
 }
 
+// answerMissingApprovalCallback responds to a callback whose approval is no longer pending,
+// telling apart approvals that were already decided from expired ones.
+func (f *Frontend) answerMissingApprovalCallback(ctx context.Context, b *bot.Bot, callbackQueryID, approvalKey string) {
+	if f.handledApprovals.contains(approvalKey) {
+		f.answerHandledCallback(ctx, b, callbackQueryID)
+		return
+	}
+	f.answerExpiredCallback(ctx, b, callbackQueryID)
+}
+
+// answerHandledCallback responds to a callback of an approval that was already decided.
+func (f *Frontend) answerHandledCallback(ctx context.Context, b *bot.Bot, callbackQueryID string) {
+	if _, ansErr := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callbackQueryID,
+		Text:            f.localizer.T("callback.already_handled"),
+	}); ansErr != nil {
+		f.logger.Debug("Failed to answer callback query", zap.Error(ansErr))
+	}
+}
+
 // validateApprovalUser checks if the callback is from the authorized user.
 func (f *Frontend) validateApprovalUser(ctx context.Context, b *bot.Bot,
 	callbackQuery *models.CallbackQuery, approval *approvalContext) bool {
@@ -806,9 +1558,61 @@ func (f *Frontend) verifyGroupAccess(ctx context.Context) error {
 		zap.String("group_title", chatInfo.Title),
 		zap.String("group_type", string(chatInfo.Type)))
 
+	f.groupTitleMutex.Lock()
+	f.groupTitle = chatInfo.Title
+	f.groupTitleMutex.Unlock()
+
 	return nil
 }
 
+// handlePrivateMessage points users who message the bot directly to the group, without processing the message.
+// Only /start is passed on to its command handler, if one is registered.
+func (f *Frontend) handlePrivateMessage(ctx context.Context, msg *models.Message) {
+	if msg.From == nil || msg.From.IsBot {
+		return
+	}
+
+	// /start, also sent by deep links (t.me/<bot>?start=<payload>), is passed on if it is handled
+	if parseCommand(msg.Text) == startCommand {
+		f.commandMutex.RLock()
+		registration, exists := f.commandHandlers[startCommand]
+		f.commandMutex.RUnlock()
+
+		if exists {
+			if f.checkFlood(ctx, msg) {
+				registration.handler(ctx, f.convertMessage(msg))
+			}
+			return
+		}
+	}
+
+	f.logger.Debug("Ignoring private message, pointing the user to the group",
+		zap.Int64("userID", msg.From.ID))
+
+	chatID := strconv.FormatInt(msg.Chat.ID, 10)
+	if _, err := f.SendText(ctx, chatID, strconv.Itoa(msg.ID), f.privateChatReply()); err != nil {
+		f.logger.Debug("Failed to send private chat reply", zap.Error(err))
+	}
+}
+
+// privateChatReply returns the reply to private messages, naming the group if its title is known.
+func (f *Frontend) privateChatReply() string {
+	f.groupTitleMutex.RLock()
+	title := f.groupTitle
+	f.groupTitleMutex.RUnlock()
+
+	if title == "" {
+		return f.localizer.T("bot.private_chat_unknown_group")
+	}
+	return f.localizer.T("bot.private_chat", title)
+}
+
+// isRequest reports whether a message starts with the configured request prefix, or any message without one.
+func (f *Frontend) isRequest(msg *models.Message) bool {
+	_, ok := text.StripRequestPrefix(msg.Text, f.config.RequestPrefix)
+	return ok
+}
+
 // extractURLs extracts URLs from message entities.
 func (f *Frontend) extractURLs(msg *models.Message) []string {
 	var urls []string
@@ -895,8 +1699,9 @@ func extractAdminUser(admin *models.ChatMember) *models.User {
 }
 
 // AwaitAdminApproval requests approval from group administrators.
-func (f *Frontend) AwaitAdminApproval(
-	ctx context.Context, origin *chat.Message, songInfo, songURL, trackMood string, timeoutSec int) (bool, error) {
+// The preview URL is listed below the song link if it is not empty.
+func (f *Frontend) AwaitAdminApproval(ctx context.Context, origin *chat.Message,
+	songInfo, songURL, previewURL, trackMood string, timeoutSec int) (bool, error) {
 	// Get group administrators
 	adminIDs, err := f.GetGroupAdmins(ctx)
 	if err != nil {
@@ -915,6 +1720,7 @@ func (f *Frontend) AwaitAdminApproval(
 		originUserName: origin.SenderName,
 		songInfo:       songInfo,
 		songURL:        songURL,
+		previewURL:     previewURL,
 		trackMood:      trackMood,
 		approved:       make(chan bool, 1),
 		cancelCtx:      approvalCtx,
@@ -978,10 +1784,15 @@ func (f *Frontend) sendAdminApprovalRequests(ctx context.Context, adminIDs []int
 // buildAdminApprovalMessage creates the prompt text and keyboard for admin approval.
 func (f *Frontend) buildAdminApprovalMessage(approval *adminApprovalContext,
 	approvalKey string) (string, [][]models.InlineKeyboardButton) {
+	link := approval.songURL
+	if approval.previewURL != "" {
+		link += f.localizer.T("format.preview", approval.previewURL)
+	}
+
 	prompt := f.localizer.T("admin.approval_prompt",
 		approval.originUserName,
 		approval.songInfo,
-		approval.songURL,
+		link,
 		approval.trackMood)
 
 	keyboard := [][]models.InlineKeyboardButton{
@@ -1069,7 +1880,7 @@ func (f *Frontend) handleAdminApprovalCallback(ctx context.Context, b *bot.Bot,
 
 	approval := f.getAdminApproval(approvalKey)
 	if approval == nil {
-		f.answerExpiredCallback(ctx, b, update.CallbackQuery.ID)
+		f.answerMissingApprovalCallback(ctx, b, update.CallbackQuery.ID, approvalKey)
 		return
 	}
 
@@ -1080,6 +1891,12 @@ func (f *Frontend) handleAdminApprovalCallback(ctx context.Context, b *bot.Bot,
 		return
 	}
 
+	// Only the first decision counts, also when several admins answer at once
+	if !f.handledApprovals.consume(approvalKey) {
+		f.answerHandledCallback(ctx, b, update.CallbackQuery.ID)
+		return
+	}
+
 	f.processAdminDecision(ctx, b, update, approval, approved)
 }
 
@@ -1231,6 +2048,42 @@ func (f *Frontend) CancelAdminApproval(ctx context.Context, origin *chat.Message
 	}
 }
 
+// ResolveApproval answers a pending user confirmation or admin approval for a message
+// as if the button had been pressed. Returns false if no such approval is pending.
+func (f *Frontend) ResolveApproval(chatID, messageID string, approved bool) bool {
+	resolved := false
+
+	f.approvalMutex.RLock()
+	userKey := fmt.Sprintf("%s_%s_", chatID, messageID)
+	for key, approval := range f.pendingApprovals {
+		if strings.HasPrefix(key, userKey) && sendApprovalDecision(approval.approved, approved) {
+			resolved = true
+		}
+	}
+	f.approvalMutex.RUnlock()
+
+	f.adminApprovalMutex.RLock()
+	adminKey := fmt.Sprintf("admin_%s_%s_", chatID, messageID)
+	for key, approval := range f.pendingAdminApprovals {
+		if strings.HasPrefix(key, adminKey) && sendApprovalDecision(approval.approved, approved) {
+			resolved = true
+		}
+	}
+	f.adminApprovalMutex.RUnlock()
+
+	return resolved
+}
+
+// sendApprovalDecision delivers a decision without blocking if one is already queued.
+func sendApprovalDecision(ch chan bool, approved bool) bool {
+	select {
+	case ch <- approved:
+		return true
+	default:
+		return false
+	}
+}
+
 func (f *Frontend) isUserAdmin(userID int64, adminList []int64) bool {
 	for _, adminID := range adminList {
 		if userID == adminID {
@@ -1240,6 +2093,28 @@ func (f *Frontend) isUserAdmin(userID int64, adminList []int64) bool {
 	return false
 }
 
+// GetMemberCount returns the number of members in the configured group, cached for a short interval.
+func (f *Frontend) GetMemberCount(ctx context.Context) (int, error) {
+	f.memberCountMutex.Lock()
+	defer f.memberCountMutex.Unlock()
+
+	if f.memberCount > 0 && time.Since(f.memberCountCheckedAt) < memberCountCacheTTL {
+		return f.memberCount, nil
+	}
+
+	count, err := f.bot.GetChatMemberCount(ctx, &bot.GetChatMemberCountParams{
+		ChatID: f.config.GroupID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get member count of group %d: %w", f.config.GroupID, err)
+	}
+
+	f.memberCount = count
+	f.memberCountCheckedAt = time.Now()
+
+	return count, nil
+}
+
 // IsUserAdmin implements the chat.Frontend interface to check if a user is an admin.
 func (f *Frontend) IsUserAdmin(ctx context.Context, chatID, userID string) (bool, error) {
 	// Parse user ID
@@ -1568,11 +2443,11 @@ func (f *Frontend) logDiscoveryResults(groups []GroupInfo) {
 	time.Sleep(discoveryFinalWait)
 }
 
-// AwaitCommunityApproval waits for enough community 👍 reactions to bypass admin approval.
+// AwaitCommunityApproval waits for enough community 👍 reactions on a message.
 func (f *Frontend) AwaitCommunityApproval(ctx context.Context, msgID string, requiredReactions, timeoutSec int,
 	requesterUserID int64) (bool, error) {
-	// If community approval is disabled (0), return false immediately
-	if f.config.CommunityApproval <= 0 || requiredReactions <= 0 {
+	// If no reactions are required the vote is disabled, return false immediately
+	if requiredReactions <= 0 {
 		return false, nil
 	}
 
@@ -1676,6 +2551,89 @@ func (f *Frontend) SetQueueTrackDecisionHandler(handler func(ctx context.Context
 	f.queueTrackDecisionHandler = handler
 }
 
+// SendChoices sends a message with one inline button per choice, optionally as a reply.
+func (f *Frontend) SendChoices(ctx context.Context, chatID, replyToID, text string, choices []chat.Choice) (string, error) {
+	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid chat ID: %w", err)
+	}
+
+	keyboard := make([][]models.InlineKeyboardButton, 0, len(choices))
+	for _, choice := range choices {
+		keyboard = append(keyboard, []models.InlineKeyboardButton{{
+			Text:         choice.Label,
+			CallbackData: choiceCallbackPrefix + choice.ID,
+		}})
+	}
+
+	disabled := true
+	params := &bot.SendMessageParams{
+		ChatID:             chatIDInt,
+		Text:               text,
+		ReplyMarkup:        models.InlineKeyboardMarkup{InlineKeyboard: keyboard},
+		LinkPreviewOptions: &models.LinkPreviewOptions{IsDisabled: &disabled},
+	}
+	if replyToID != "" {
+		messageID, parseErr := strconv.Atoi(replyToID)
+		if parseErr != nil {
+			return "", fmt.Errorf("invalid reply message ID: %w", parseErr)
+		}
+		params.ReplyParameters = &models.ReplyParameters{MessageID: messageID}
+	}
+
+	sentMsg, err := f.sendMessageWithMigrationHandling(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to send choices message: %w", err)
+	}
+
+	return strconv.Itoa(sentMsg.ID), nil
+}
+
+// SetChoiceHandler sets the handler for choices picked from messages sent with SendChoices.
+func (f *Frontend) SetChoiceHandler(handler chat.ChoiceHandler) {
+	f.choiceHandler = handler
+}
+
+// handleChoiceCallback passes a picked inline button choice to the choice handler.
+func (f *Frontend) handleChoiceCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
+	query := update.CallbackQuery
+	if query == nil || query.Message.Message == nil {
+		return
+	}
+
+	if _, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: query.ID,
+	}); err != nil {
+		f.logger.Debug("Failed to answer callback query", zap.Error(err))
+	}
+
+	if f.choiceHandler == nil {
+		return
+	}
+
+	message := query.Message.Message
+	chatID := strconv.FormatInt(message.Chat.ID, 10)
+	userID := strconv.FormatInt(query.From.ID, 10)
+
+	// Picked choices can request tracks, so they count against the same flood limit as messages
+	if !f.floodgate.CheckMessage(chatID, userID) {
+		f.logger.Debug("Choice blocked due to flood prevention",
+			zap.String("userID", userID),
+			zap.String("userName", f.getUserDisplayName(&query.From)))
+		return
+	}
+
+	f.choiceHandler(ctx, &chat.Message{
+		ID:         strconv.Itoa(message.ID),
+		ChatID:     chatID,
+		SenderID:   userID,
+		SenderName: f.getUserDisplayName(&query.From),
+		IsGroup:    message.Chat.Type == chatTypeGroup || message.Chat.Type == chatTypeSuperGroup,
+		Language:   query.From.LanguageCode,
+		Raw:        query,
+	}, strings.TrimPrefix(query.Data, choiceCallbackPrefix))
+}
+
 // EditMessage edits an existing message by ID.
 func (f *Frontend) EditMessage(ctx context.Context, chatID, messageID, newText string) error {
 	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
@@ -1717,6 +2675,51 @@ func (f *Frontend) EditMessage(ctx context.Context, chatID, messageID, newText s
 	return nil
 }
 
+// PinMessage pins a message in the chat without notifying members.
+func (f *Frontend) PinMessage(ctx context.Context, chatID, msgID string) error {
+	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid chat ID: %w", err)
+	}
+
+	messageID, err := strconv.Atoi(msgID)
+	if err != nil {
+		return fmt.Errorf("invalid message ID: %w", err)
+	}
+
+	if _, err := f.bot.PinChatMessage(ctx, &bot.PinChatMessageParams{
+		ChatID:              chatIDInt,
+		MessageID:           messageID,
+		DisableNotification: true,
+	}); err != nil {
+		return fmt.Errorf("failed to pin message: %w", err)
+	}
+
+	return nil
+}
+
+// UnpinMessage unpins a pinned message.
+func (f *Frontend) UnpinMessage(ctx context.Context, chatID, msgID string) error {
+	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid chat ID: %w", err)
+	}
+
+	messageID, err := strconv.Atoi(msgID)
+	if err != nil {
+		return fmt.Errorf("invalid message ID: %w", err)
+	}
+
+	if _, err := f.bot.UnpinChatMessage(ctx, &bot.UnpinChatMessageParams{
+		ChatID:    chatIDInt,
+		MessageID: messageID,
+	}); err != nil {
+		return fmt.Errorf("failed to unpin message: %w", err)
+	}
+
+	return nil
+}
+
 // GetMe returns information about the bot user.
 func (f *Frontend) GetMe(ctx context.Context) (*chat.User, error) {
 	me, err := f.bot.GetMe(ctx)
@@ -1743,7 +2746,69 @@ func (f *Frontend) GetChatMember(ctx context.Context, chatID, userID int64) (*ch
 		return nil, fmt.Errorf("failed to get chat member: %w", err)
 	}
 
-	return f.convertToChatMember(member), nil
+	chatMember := f.convertToChatMember(member)
+	if chatMember.User != nil {
+		f.memberJoinsMutex.Lock()
+		chatMember.JoinedAt = f.memberJoins[chatMember.User.ID]
+		f.memberJoinsMutex.Unlock()
+	}
+	return chatMember, nil
+}
+
+// welcomeIfAdded posts an introduction explaining how to request songs when the bot was added to a group.
+func (f *Frontend) welcomeIfAdded(ctx context.Context, msg *models.Message) {
+	if len(msg.NewChatMembers) == 0 || !f.shouldWelcome(msg, f.bot.ID()) {
+		return
+	}
+
+	f.logger.Info("Bot was added to the group, posting welcome message")
+
+	chatID := strconv.FormatInt(msg.Chat.ID, 10)
+	if _, err := f.SendText(ctx, chatID, "", f.localizer.T("bot.welcome")); err != nil {
+		f.logger.Warn("Failed to send welcome message", zap.Error(err))
+	}
+}
+
+// shouldWelcome reports whether the message announces the bot with the given ID joining a group
+// and the welcome is still to be posted there. Join messages from before the start are delivered late
+// after a restart and don't count.
+func (f *Frontend) shouldWelcome(msg *models.Message, botID int64) bool {
+	if !f.config.WelcomeMessage || time.Unix(int64(msg.Date), 0).Before(f.startedAt.Truncate(time.Second)) {
+		return false
+	}
+
+	added := slices.ContainsFunc(msg.NewChatMembers, func(member models.User) bool {
+		return member.ID == botID
+	})
+	if !added {
+		return false
+	}
+	_, welcomed := f.welcomed.LoadOrStore(msg.Chat.ID, struct{}{})
+	return !welcomed
+}
+
+// recordMemberJoins remembers the join time of members added by a new_chat_members service message.
+func (f *Frontend) recordMemberJoins(msg *models.Message) {
+	if len(msg.NewChatMembers) == 0 {
+		return
+	}
+
+	joinedAt := time.Unix(int64(msg.Date), 0)
+
+	f.memberJoinsMutex.Lock()
+	defer f.memberJoinsMutex.Unlock()
+
+	if f.memberJoins == nil {
+		f.memberJoins = make(map[int64]time.Time)
+	}
+	for id, joined := range f.memberJoins {
+		if time.Since(joined) > memberJoinRetention {
+			delete(f.memberJoins, id)
+		}
+	}
+	for i := range msg.NewChatMembers {
+		f.memberJoins[msg.NewChatMembers[i].ID] = joinedAt
+	}
 }
 
 // convertToChatMember converts a Telegram ChatMember to our internal ChatMember structure.
@@ -1940,6 +3005,7 @@ func (f *Frontend) sendMessageWithMigrationHandling(
 	ctx context.Context,
 	params *bot.SendMessageParams,
 ) (*models.Message, error) {
+	f.setTopic(params)
 	msg, err := f.bot.SendMessage(ctx, params)
 	if err == nil {
 		return msg, nil
@@ -1964,7 +3030,35 @@ func (f *Frontend) sendMessageWithMigrationHandling(
 	f.logger.Info("Detected chat migration, updating chat ID and retrying",
 		zap.Int64("old_chat_id", oldChatID),
 		zap.Int64("new_chat_id", newChatID))
+	f.migrateGroup(newChatID)
+
+	// Retry with the new chat ID
+	params.ChatID = newChatID
+	retryMsg, retryErr := f.bot.SendMessage(ctx, params)
+	if retryErr != nil {
+		return nil, fmt.Errorf("failed to send message after migration: %w", retryErr)
+	}
+	return retryMsg, nil
+}
 
+// inTopic reports whether a group message belongs to the configured topic, or any message if no topic is configured.
+func (f *Frontend) inTopic(msg *models.Message) bool {
+	return f.config.TopicID == 0 || msg.MessageThreadID == f.config.TopicID
+}
+
+// setTopic posts messages to the group into the configured topic, so replies, approval prompts and
+// announcements stay in the thread the bot listens to.
+func (f *Frontend) setTopic(params *bot.SendMessageParams) {
+	if f.config.TopicID == 0 || params.MessageThreadID != 0 {
+		return
+	}
+	if chatID, ok := params.ChatID.(int64); ok && chatID == f.config.GroupID {
+		params.MessageThreadID = f.config.TopicID
+	}
+}
+
+// migrateGroup continues serving the group under the chat ID of the supergroup it was upgraded to.
+func (f *Frontend) migrateGroup(newChatID int64) {
 	// Update the chat ID in our config
 	f.config.GroupID = newChatID
 
@@ -1974,12 +3068,4 @@ func (f *Frontend) sendMessageWithMigrationHandling(
 		f.logger.Info("Updated core config GroupID after migration",
 			zap.Int64("new_group_id", newChatID))
 	}
-
-	// Retry with the new chat ID
-	params.ChatID = newChatID
-	retryMsg, retryErr := f.bot.SendMessage(ctx, params)
-	if retryErr != nil {
-		return nil, fmt.Errorf("failed to send message after migration: %w", retryErr)
-	}
-	return retryMsg, nil
 }
@@ -1,10 +1,22 @@
 package telegram
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
 	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
 )
 
 func TestNewFrontend(t *testing.T) {
@@ -137,3 +149,508 @@ func TestExtractURLsLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "Plain command", text: "/skip", expected: "skip"},
+		{name: "Command with bot suffix", text: "/skip@DJAlgoRhythmBot", expected: "skip"},
+		{name: "Command with arguments", text: "/Skip now please", expected: "skip"},
+		{name: "Not a command", text: "skip this song", expected: ""},
+		{name: "Empty text", text: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCommand(tt.text); got != tt.expected {
+				t.Errorf("parseCommand(%q) = %q, want %q", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildInlineQueryResults(t *testing.T) {
+	results := buildInlineQueryResults([]chat.SearchResult{
+		{ID: "track1", Title: "Song", Artist: "Artist", URL: "https://open.spotify.com/track/track1"},
+	})
+
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+
+	article, ok := results[0].(*models.InlineQueryResultArticle)
+	if !ok {
+		t.Fatalf("Expected article result, got %T", results[0])
+	}
+	if article.ID != "track1" || article.Title != "Song" || article.Description != "Artist" {
+		t.Errorf("Unexpected article %+v", article)
+	}
+
+	content, ok := article.InputMessageContent.(*models.InputTextMessageContent)
+	if !ok || content.MessageText != "https://open.spotify.com/track/track1" {
+		t.Errorf("Expected picking the result to post the track URL, got %+v", article.InputMessageContent)
+	}
+
+	if empty := buildInlineQueryResults(nil); empty == nil || len(empty) != 0 {
+		t.Errorf("Expected empty non-nil results, got %v", empty)
+	}
+}
+
+func TestListenWithReconnect(t *testing.T) {
+	frontend := NewFrontend(&Config{BotToken: "test-token", ReconnectMaxBackoff: 5 * time.Millisecond}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	verifications := 0
+	poll := func(ctx context.Context) {
+		polls++
+		if polls == 3 {
+			// Keep polling until shut down
+			if !frontend.IsConnected() {
+				t.Error("Expected the frontend to be connected after reconnecting")
+			}
+			cancel()
+			<-ctx.Done()
+		}
+	}
+	verify := func(context.Context) error {
+		verifications++
+		if verifications == 1 {
+			return errors.New("network unreachable")
+		}
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		frontend.listenWithReconnect(ctx, poll, verify)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected listening to stop once the context is canceled")
+	}
+
+	if polls != 3 || verifications != 3 {
+		t.Errorf("Expected 3 polls and 3 verifications, got %d and %d", polls, verifications)
+	}
+	if frontend.IsConnected() {
+		t.Error("Expected the frontend to be disconnected after shutdown")
+	}
+}
+
+func TestListenWithReconnect_RestartsStalledPolling(t *testing.T) {
+	frontend := NewFrontend(&Config{BotToken: "test-token", ReconnectMaxBackoff: 5 * time.Millisecond}, zap.NewNop())
+	frontend.pollStallTimeout = 20 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	poll := func(pollCtx context.Context) {
+		polls++
+		if polls == 2 {
+			cancel()
+		}
+		// Like bot.Start, only return once canceled, while no long poll succeeds
+		<-pollCtx.Done()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		frontend.listenWithReconnect(ctx, poll, func(context.Context) error { return nil })
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected listening to stop once the context is canceled")
+	}
+
+	if polls != 2 {
+		t.Errorf("Expected the stalled update loop to be restarted once, got %d polls", polls)
+	}
+}
+
+func TestPollTrackingClient(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	var connected atomic.Bool
+	var lastPollAt atomic.Int64
+	client := &pollTrackingClient{client: server.Client(), connected: &connected, lastPollAt: &lastPollAt}
+	poll := func(method string) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/bottoken"+method, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	poll(getUpdatesMethod)
+	polledAt := lastPollAt.Load()
+	if !connected.Load() || polledAt == 0 {
+		t.Fatal("Expected a successful long poll to be recorded")
+	}
+
+	status = http.StatusBadGateway
+	poll(getUpdatesMethod)
+	if connected.Load() || lastPollAt.Load() != polledAt {
+		t.Error("Expected a failed long poll to disconnect without updating the last poll time")
+	}
+
+	status = http.StatusOK
+	poll("/sendMessage")
+	if connected.Load() {
+		t.Error("Expected other requests not to change the connection state")
+	}
+}
+
+func TestNextReconnectBackoff(t *testing.T) {
+	frontend := NewFrontend(&Config{BotToken: "test-token", ReconnectMaxBackoff: 5 * time.Second}, zap.NewNop())
+
+	backoff := frontend.initialReconnectBackoff()
+	for _, want := range []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		backoff = frontend.nextReconnectBackoff(backoff)
+		if backoff != want {
+			t.Errorf("Expected backoff %v, got %v", want, backoff)
+		}
+	}
+}
+
+// linkMessage builds a group message with the URLs as url entities, sent at sentAt and edited at editedAt.
+func linkMessage(groupID int64, sentAt, editedAt time.Time, urls ...string) *models.Message {
+	msg := &models.Message{
+		ID:       42,
+		Chat:     models.Chat{ID: groupID, Type: chatTypeSuperGroup},
+		From:     &models.User{ID: 7, Username: "alice"},
+		Date:     int(sentAt.Unix()),
+		EditDate: int(editedAt.Unix()),
+		Text:     "listen to",
+	}
+	for _, url := range urls {
+		msg.Text += " "
+		msg.Entities = append(msg.Entities, models.MessageEntity{
+			Type: entityTypeURL, Offset: len(msg.Text), Length: len(url),
+		})
+		msg.Text += url
+	}
+	return msg
+}
+
+func TestHandleEditedMessage(t *testing.T) {
+	const groupID = int64(-100)
+	const typo = "https://open.spotify.com/track/typo"
+	const fixed = "https://open.spotify.com/track/fixed"
+
+	frontend := NewFrontend(&Config{BotToken: "test-token", GroupID: groupID, FloodLimitPerMinute: 10}, zap.NewNop())
+	var handled []*chat.Message
+	frontend.messageHandler = func(msg *chat.Message) { handled = append(handled, msg) }
+
+	ctx := context.Background()
+	sentAt := time.Now()
+
+	frontend.handleMessage(ctx, linkMessage(groupID, sentAt, time.Time{}, typo))
+	if len(handled) != 1 {
+		t.Fatalf("Expected the original message to be handled, got %d messages", len(handled))
+	}
+
+	// Editing the text without changing links doesn't request the link again
+	frontend.handleEditedMessage(ctx, linkMessage(groupID, sentAt, sentAt.Add(time.Minute), typo))
+	if len(handled) != 1 {
+		t.Fatalf("Expected an edit without new links to be ignored, got %d messages", len(handled))
+	}
+
+	frontend.handleEditedMessage(ctx, linkMessage(groupID, sentAt, sentAt.Add(time.Minute), fixed))
+	if len(handled) != 2 {
+		t.Fatalf("Expected the fixed link to be requested, got %d messages", len(handled))
+	}
+	if got := handled[1]; got.ID != "42" || len(got.URLs) != 1 || got.URLs[0] != fixed {
+		t.Errorf("Expected only the added link on the original message, got ID %s with %v", got.ID, got.URLs)
+	}
+
+	// Reverting to the typo doesn't request it again either
+	frontend.handleEditedMessage(ctx, linkMessage(groupID, sentAt, sentAt.Add(2*time.Minute), typo))
+	if len(handled) != 2 {
+		t.Errorf("Expected links of earlier versions to be ignored, got %d messages", len(handled))
+	}
+}
+
+func TestHandleEditedMessage_OutsideWindow(t *testing.T) {
+	const groupID = int64(-100)
+
+	frontend := NewFrontend(&Config{BotToken: "test-token", GroupID: groupID, FloodLimitPerMinute: 10}, zap.NewNop())
+	handled := 0
+	frontend.messageHandler = func(*chat.Message) { handled++ }
+
+	sentAt := time.Now().Add(-time.Hour)
+	frontend.handleEditedMessage(context.Background(),
+		linkMessage(groupID, sentAt, sentAt.Add(editRequestWindow+time.Second), "https://open.spotify.com/track/late"))
+	if handled != 0 {
+		t.Error("Expected links added long after sending to be ignored")
+	}
+}
+
+func TestHandleMessage_RequestPrefix(t *testing.T) {
+	const groupID = int64(-100)
+
+	frontend := NewFrontend(&Config{BotToken: "test-token", GroupID: groupID, FloodLimitPerMinute: 10,
+		RequestPrefix: "!play"}, zap.NewNop())
+	var handled []*chat.Message
+	frontend.messageHandler = func(msg *chat.Message) { handled = append(handled, msg) }
+
+	ctx := context.Background()
+	frontend.handleMessage(ctx, linkMessage(groupID, time.Now(), time.Time{}, "https://open.spotify.com/track/chatter"))
+	if len(handled) != 0 {
+		t.Fatalf("Expected a link without the request prefix to be ignored, got %d messages", len(handled))
+	}
+
+	frontend.handleMessage(ctx, &models.Message{
+		ID:   43,
+		Chat: models.Chat{ID: groupID, Type: chatTypeSuperGroup},
+		From: &models.User{ID: 7, Username: "alice"},
+		Text: "!play Creep by Radiohead; Yellow by Coldplay",
+	})
+	if len(handled) != 1 {
+		t.Fatalf("Expected the prefixed request to be handled, got %d messages", len(handled))
+	}
+	if got := handled[0]; got.Text != "Creep by Radiohead; Yellow by Coldplay" ||
+		!slices.Equal(got.Requests, []string{"Creep by Radiohead", "Yellow by Coldplay"}) {
+		t.Errorf("Expected the requests without the prefix, got %q with %v", got.Text, got.Requests)
+	}
+}
+
+func TestBotReactions(t *testing.T) {
+	var reactions botReactions
+
+	reactions.set("-100_1", chat.ReactionProcessing)
+	reactions.set("-100_1", chat.ReactionThumbsUp)
+	if reactions.take("-100_1", chat.ReactionProcessing) {
+		t.Error("Expected a replaced reaction not to be removed")
+	}
+
+	reactions.set("-100_2", chat.ReactionProcessing)
+	if !reactions.take("-100_2", chat.ReactionProcessing) {
+		t.Error("Expected the current reaction to be removed")
+	}
+	if reactions.take("-100_2", chat.ReactionProcessing) {
+		t.Error("Expected a removed reaction to be forgotten")
+	}
+
+	for i := range maxTrackedReactions + 1 {
+		reactions.set(strconv.Itoa(i), chat.ReactionProcessing)
+	}
+	if reactions.take("0", chat.ReactionProcessing) || len(reactions.current) != maxTrackedReactions {
+		t.Errorf("Expected the oldest reaction to be forgotten, tracking %d", len(reactions.current))
+	}
+}
+
+func TestHandledApprovals(t *testing.T) {
+	var approvals handledApprovals
+
+	if approvals.contains("-100_1_1") || !approvals.consume("-100_1_1") {
+		t.Fatal("Expected the first decision to be handled")
+	}
+	if approvals.consume("-100_1_1") || !approvals.contains("-100_1_1") {
+		t.Error("Expected a repeated decision to be recognized as already handled")
+	}
+	if !approvals.consume("admin_-100_1_1") {
+		t.Error("Expected admin approvals to be tracked separately")
+	}
+
+	for i := range maxHandledApprovals {
+		approvals.consume(strconv.Itoa(i))
+	}
+	if approvals.contains("-100_1_1") || len(approvals.keys) != maxHandledApprovals {
+		t.Errorf("Expected the oldest approval to be forgotten, tracking %d", len(approvals.keys))
+	}
+}
+
+func TestRecordMemberJoins(t *testing.T) {
+	f := &Frontend{memberJoins: map[int64]time.Time{3: time.Now().Add(-2 * memberJoinRetention)}}
+	joinedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	f.recordMemberJoins(&models.Message{Date: int(joinedAt.Unix()), NewChatMembers: []models.User{{ID: 1}, {ID: 2}}})
+
+	for _, id := range []int64{1, 2} {
+		if !f.memberJoins[id].Equal(joinedAt) {
+			t.Errorf("Expected member %d to have joined at %v, got %v", id, joinedAt, f.memberJoins[id])
+		}
+	}
+	if _, exists := f.memberJoins[3]; exists {
+		t.Error("Expected joins older than the retention to be forgotten")
+	}
+}
+
+func TestPrivateChatReply(t *testing.T) {
+	frontend := NewFrontend(&Config{BotToken: "test-token", GroupID: -123456789}, zap.NewNop())
+
+	if reply := frontend.privateChatReply(); reply != frontend.localizer.T("bot.private_chat_unknown_group") {
+		t.Errorf("Expected the generic reply before the group was verified, got %q", reply)
+	}
+
+	frontend.groupTitle = "Friday Party"
+	if reply := frontend.privateChatReply(); !strings.Contains(reply, "Friday Party") {
+		t.Errorf("Expected the reply to name the group, got %q", reply)
+	}
+}
+
+func TestShouldWelcome(t *testing.T) {
+	const botID = 42
+	startedAt := time.Now()
+	joined := func(date time.Time, ids ...int64) *models.Message {
+		msg := &models.Message{Date: int(date.Unix()), Chat: models.Chat{ID: -100}}
+		for _, id := range ids {
+			msg.NewChatMembers = append(msg.NewChatMembers, models.User{ID: id})
+		}
+		return msg
+	}
+
+	disabled := NewFrontend(&Config{BotToken: "test-token"}, zap.NewNop())
+	disabled.startedAt = startedAt
+	if disabled.shouldWelcome(joined(startedAt, botID), botID) {
+		t.Error("Expected no welcome with the welcome message disabled")
+	}
+
+	frontend := NewFrontend(&Config{BotToken: "test-token", WelcomeMessage: true}, zap.NewNop())
+	frontend.startedAt = startedAt
+	if frontend.shouldWelcome(joined(startedAt, 7), botID) {
+		t.Error("Expected no welcome when other members join")
+	}
+	if frontend.shouldWelcome(joined(startedAt.Add(-time.Hour), botID), botID) {
+		t.Error("Expected no welcome for a join delivered late after a restart")
+	}
+	if !frontend.shouldWelcome(joined(startedAt, 7, botID), botID) {
+		t.Error("Expected a welcome when the bot joins")
+	}
+	if frontend.shouldWelcome(joined(startedAt, botID), botID) {
+		t.Error("Expected the welcome to be posted only once")
+	}
+
+	otherGroup := joined(startedAt, botID)
+	otherGroup.Chat.ID = -200
+	if !frontend.shouldWelcome(otherGroup, botID) {
+		t.Error("Expected a welcome in every group the bot is added to, configured or not")
+	}
+}
+
+func TestGroupFrontend(t *testing.T) {
+	primary := NewFrontend(&Config{GroupID: -100}, zap.NewNop())
+	group := primary.AddGroup(&Config{GroupID: -200})
+
+	tests := []struct {
+		name     string
+		update   *models.Update
+		expected *Frontend
+	}{
+		{"primary group message", &models.Update{Message: &models.Message{Chat: models.Chat{ID: -100}}}, primary},
+		{"additional group message", &models.Update{Message: &models.Message{Chat: models.Chat{ID: -200}}}, group},
+		{"additional group reaction",
+			&models.Update{MessageReaction: &models.MessageReactionUpdated{Chat: models.Chat{ID: -200}}}, group},
+		{"private message", &models.Update{Message: &models.Message{Chat: models.Chat{ID: 42}}}, primary},
+		{"admin decision of additional group",
+			&models.Update{CallbackQuery: &models.CallbackQuery{Data: "admin_approve_admin_-200_7_1700000000"}}, group},
+		{"inline query", &models.Update{InlineQuery: &models.InlineQuery{Query: "song"}}, primary},
+		{"migrated additional group", &models.Update{
+			Message: &models.Message{Chat: models.Chat{ID: -1000200}, MigrateFromChatID: -200}}, group},
+	}
+
+	for _, tt := range tests {
+		if got := primary.groupFrontend(tt.update); got != tt.expected {
+			t.Errorf("%s: routed to group %d, expected %d", tt.name, got.config.GroupID, tt.expected.config.GroupID)
+		}
+	}
+}
+
+func TestMigratedGroupID(t *testing.T) {
+	tests := []struct {
+		name       string
+		msg        *models.Message
+		expectedID int64
+		expectedOK bool
+	}{
+		{"upgrade notice in old group", &models.Message{Chat: models.Chat{ID: -200}, MigrateToChatID: -1000200},
+			-1000200, true},
+		{"first message of supergroup", &models.Message{Chat: models.Chat{ID: -1000200}, MigrateFromChatID: -200},
+			-1000200, true},
+		{"other group upgraded", &models.Message{Chat: models.Chat{ID: -300}, MigrateToChatID: -1000300}, 0, false},
+		{"regular message", &models.Message{Chat: models.Chat{ID: -200}}, 0, false},
+	}
+
+	for _, tt := range tests {
+		id, ok := migratedGroupID(tt.msg, -200)
+		if id != tt.expectedID || ok != tt.expectedOK {
+			t.Errorf("%s: migratedGroupID() = %d, %v, expected %d, %v", tt.name, id, ok, tt.expectedID, tt.expectedOK)
+		}
+	}
+
+	coreGroupID := int64(-200)
+	f := NewFrontend(&Config{GroupID: -200}, zap.NewNop())
+	f.SetCoreGroupIDPointer(&coreGroupID)
+	f.handleMessage(context.Background(), &models.Message{Chat: models.Chat{ID: -200}, MigrateToChatID: -1000200})
+	if f.config.GroupID != -1000200 || coreGroupID != -1000200 {
+		t.Errorf("Expected group to continue as -1000200, got %d (core %d)", f.config.GroupID, coreGroupID)
+	}
+}
+
+func TestTopic(t *testing.T) {
+	f := NewFrontend(&Config{GroupID: -100, TopicID: 42}, zap.NewNop())
+
+	if !f.inTopic(&models.Message{MessageThreadID: 42}) || f.inTopic(&models.Message{MessageThreadID: 7}) ||
+		f.inTopic(&models.Message{}) {
+		t.Error("Expected only messages of topic 42 to be handled")
+	}
+
+	groupMessage := &bot.SendMessageParams{ChatID: int64(-100)}
+	f.setTopic(groupMessage)
+	privateMessage := &bot.SendMessageParams{ChatID: int64(7)}
+	f.setTopic(privateMessage)
+	if groupMessage.MessageThreadID != 42 || privateMessage.MessageThreadID != 0 {
+		t.Errorf("Expected only group messages in topic 42, got %d and %d",
+			groupMessage.MessageThreadID, privateMessage.MessageThreadID)
+	}
+
+	f = NewFrontend(&Config{GroupID: -100}, zap.NewNop())
+	groupMessage = &bot.SendMessageParams{ChatID: int64(-100)}
+	f.setTopic(groupMessage)
+	if !f.inTopic(&models.Message{MessageThreadID: 7}) || groupMessage.MessageThreadID != 0 {
+		t.Error("Expected the whole group to be served without a topic")
+	}
+}
+
+func TestBuildAdminApprovalMessage_Preview(t *testing.T) {
+	frontend := NewFrontend(&Config{BotToken: "test-token", GroupID: -123456789}, zap.NewNop())
+	approval := &adminApprovalContext{
+		originUserName: "@alice",
+		songInfo:       "Band - Song",
+		songURL:        "https://open.spotify.com/track/1",
+		trackMood:      "happy",
+	}
+
+	prompt, _ := frontend.buildAdminApprovalMessage(approval, "key")
+	if strings.Contains(prompt, "p.scdn.co") {
+		t.Errorf("Expected no preview line without a preview URL, got %q", prompt)
+	}
+
+	approval.previewURL = "https://p.scdn.co/mp3-preview/1"
+	prompt, _ = frontend.buildAdminApprovalMessage(approval, "key")
+	want := approval.songURL + frontend.localizer.T("format.preview", approval.previewURL)
+	if !strings.Contains(prompt, want) {
+		t.Errorf("Expected the preview line below the song link, got %q", prompt)
+	}
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
 	"go.uber.org/zap"
@@ -19,6 +20,9 @@ const (
 	WarningTypePermissions WarningType = "permissions" // Bot lacks admin permissions
 	WarningTypeSettings    WarningType = "settings"    // Playback settings not optimal
 	WarningTypeQueueSync   WarningType = "queue_sync"  // Shadow queue out of sync with Spotify queue
+	WarningTypePlaylist    WarningType = "playlist"    // Target playlist deleted or inaccessible
+	WarningTypeAuth        WarningType = "auth"        // Spotify authorization expired
+	WarningTypeAutoDJ      WarningType = "autodj"      // Auto-DJ picks most tracks as the crowd went quiet
 )
 
 // AdminWarningManager manages admin warning messages with automatic cleanup.
@@ -28,15 +32,19 @@ type AdminWarningManager struct {
 	warningMessages map[WarningType]map[string]string // type -> userID -> messageID
 	mutex           sync.RWMutex                      // protects all warning state
 	frontend        chat.Frontend                     // for sending/deleting messages
+	subscribers     WarningSubscriberStore            // users opted in to warnings by DM (optional)
 	logger          *zap.Logger                       // for logging
 }
 
 // NewAdminWarningManager creates a new admin warning manager.
-func NewAdminWarningManager(frontend chat.Frontend, logger *zap.Logger) *AdminWarningManager {
+// Warnings also reach the users of subscribers, if set, who opted in without being group admins.
+func NewAdminWarningManager(frontend chat.Frontend, subscribers WarningSubscriberStore,
+	logger *zap.Logger) *AdminWarningManager {
 	return &AdminWarningManager{
 		activeWarnings:  make(map[WarningType]bool),
 		warningMessages: make(map[WarningType]map[string]string),
 		frontend:        frontend,
+		subscribers:     subscribers,
 		logger:          logger,
 	}
 }
@@ -49,7 +57,8 @@ func (m *AdminWarningManager) ShouldSendWarning(warningType WarningType) bool {
 	return !m.activeWarnings[warningType] // Send warning only if no warning is currently active
 }
 
-// SendWarningToAdmins sends a warning message to all admin users and tracks message IDs for cleanup.
+// SendWarningToAdmins sends a warning message to all admin users and warning subscribers
+// and tracks message IDs for cleanup.
 func (m *AdminWarningManager) SendWarningToAdmins(
 	ctx context.Context,
 	warningType WarningType,
@@ -64,11 +73,12 @@ func (m *AdminWarningManager) SendWarningToAdmins(
 		m.warningMessages[warningType] = make(map[string]string)
 	}
 
+	recipients := m.recipients(adminUserIDs)
 	successCount := 0
 	var errors []string
 
 	// Send messages and track IDs for later deletion
-	for _, adminUserID := range adminUserIDs {
+	for _, adminUserID := range recipients {
 		msgID, err := m.frontend.SendDirectMessage(ctx, adminUserID, message)
 		if err != nil {
 			m.logger.Warn("Failed to send admin warning",
@@ -88,16 +98,31 @@ func (m *AdminWarningManager) SendWarningToAdmins(
 	m.logger.Info("Admin warning sent",
 		zap.String("warningType", string(warningType)),
 		zap.Int("successCount", successCount),
-		zap.Int("totalAdmins", len(adminUserIDs)),
+		zap.Int("totalAdmins", len(recipients)),
 		zap.Strings("errors", errors))
 
 	if len(errors) > 0 {
-		return fmt.Errorf("failed to send %d/%d warnings", len(errors), len(adminUserIDs))
+		return fmt.Errorf("failed to send %d/%d warnings", len(errors), len(recipients))
 	}
 
 	return nil
 }
 
+// recipients returns the admin users followed by the warning subscribers who aren't admins.
+func (m *AdminWarningManager) recipients(adminUserIDs []string) []string {
+	if m.subscribers == nil {
+		return adminUserIDs
+	}
+
+	recipients := slices.Clone(adminUserIDs)
+	for _, userID := range m.subscribers.UserIDs() {
+		if !slices.Contains(recipients, userID) {
+			recipients = append(recipients, userID)
+		}
+	}
+	return recipients
+}
+
 // ClearWarning clears the warning state and deletes sent messages when the issue is resolved.
 func (m *AdminWarningManager) ClearWarning(ctx context.Context, warningType WarningType) {
 	m.mutex.Lock()
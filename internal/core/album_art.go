@@ -0,0 +1,28 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Album Art
+// This module posts messages about a track with its album cover art, falling back to text
+// when disabled, when the album has no cover or when the photo can't be sent
+
+// sendTrackMessage sends a message about the track, as a photo of its album cover with the text as caption if enabled.
+func (d *Dispatcher) sendTrackMessage(ctx context.Context, chatID, replyToID string, track *Track,
+	text string) (string, error) {
+	if !d.config.App.ShowAlbumArt || track.ImageURL == "" {
+		return d.frontend.SendText(ctx, chatID, replyToID, text)
+	}
+
+	messageID, err := d.frontend.SendPhoto(ctx, chatID, replyToID, track.ImageURL, text)
+	if err != nil {
+		d.logger.Debug("Failed to send album art, sending text instead",
+			zap.String("trackID", track.ID),
+			zap.Error(err))
+		return d.frontend.SendText(ctx, chatID, replyToID, text)
+	}
+	return messageID, nil
+}
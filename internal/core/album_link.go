@@ -0,0 +1,217 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Album Links
+// This module adds every track of a shared Spotify album after the requester confirms,
+// skipping tracks that the filters of single-track requests would reject one by one
+
+// extractAlbumID returns the first Spotify album ID found in the message URLs.
+// Returns an empty string if album links are disabled or none of the URLs is an album.
+func (d *Dispatcher) extractAlbumID(urls []string) string {
+	if d.config.App.MaxAlbumTracks <= 0 {
+		return ""
+	}
+
+	for _, url := range urls {
+		if albumID, err := d.spotify.ExtractAlbumID(url); err == nil && albumID != "" {
+			return albumID
+		}
+	}
+	return ""
+}
+
+// handleAlbumLink asks the requester to confirm adding the new tracks of an album and adds them on approval.
+func (d *Dispatcher) handleAlbumLink(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message,
+	albumID string) {
+	localizer := d.localizerFor(originalMsg)
+
+	tracks, err := d.spotify.GetAlbumTracks(ctx, albumID)
+	if err != nil || len(tracks) == 0 {
+		d.logger.Error("Failed to get album tracks",
+			zap.String("albumID", albumID),
+			zap.Error(err))
+		d.replyError(ctx, msgCtx, originalMsg, localizer.T("error.album.not_found"))
+		return
+	}
+
+	if len(tracks) > d.config.App.MaxAlbumTracks {
+		d.replyError(ctx, msgCtx, originalMsg,
+			localizer.T("error.album.too_many_tracks", len(tracks), d.config.App.MaxAlbumTracks))
+		return
+	}
+
+	// Albums bypass the per-track approval workflows, so they are only accepted where no approval is needed
+	isAdmin := d.isUserAdmin(ctx, originalMsg)
+	if d.isAdminApprovalRequired() && (!isAdmin || d.isAdminNeedsApproval()) {
+		d.replyError(ctx, msgCtx, originalMsg, localizer.T("error.album.approval_required"))
+		return
+	}
+
+	newTracks := d.filterAlbumTracks(tracks)
+	if len(newTracks) == 0 {
+		d.recordRequestRejected(rejectReasonDuplicate)
+		d.replyError(ctx, msgCtx, originalMsg, localizer.T("error.album.nothing_new"))
+		return
+	}
+
+	if !isAdmin && d.isAlbumOverQuota(ctx, msgCtx, originalMsg, len(newTracks)) {
+		return
+	}
+
+	var duration time.Duration
+	for i := range newTracks {
+		duration += newTracks[i].Duration
+	}
+
+	prompt := d.formatMessageWithMention(originalMsg, localizer.T("prompt.album",
+		len(newTracks), formatQueueDuration(duration), newTracks[0].Album, newTracks[0].Artist))
+
+	msgCtx.State = StateConfirmationPrompt
+	d.awaitUserInput(ctx, msgCtx, originalMsg)
+	approved, err := d.frontend.AwaitApproval(ctx, originalMsg, prompt, d.confirmTimeoutSecs(ctx, msgCtx, originalMsg))
+	if err != nil {
+		d.logger.Error("Failed to get album approval", zap.Error(err))
+		d.replyError(ctx, msgCtx, originalMsg, localizer.T("error.generic"))
+		return
+	}
+
+	if !approved {
+		d.reactIgnored(ctx, originalMsg)
+		return
+	}
+
+	d.addAlbumTracks(ctx, msgCtx, originalMsg, newTracks)
+}
+
+// filterAlbumTracks returns the album tracks that a single request for each would not be rejected for.
+func (d *Dispatcher) filterAlbumTracks(tracks []Track) []Track {
+	newTracks := make([]Track, 0, len(tracks))
+	for i := range tracks {
+		if reason := d.trackRejectReason(&tracks[i]); reason != "" {
+			d.logger.Debug("Skipping album track",
+				zap.String("trackID", tracks[i].ID),
+				zap.String("title", tracks[i].Title),
+				zap.String("reason", reason))
+			continue
+		}
+		newTracks = append(newTracks, tracks[i])
+	}
+	return newTracks
+}
+
+// trackRejectReason runs the filters of single-track requests on a track without notifying anyone.
+// Returns the reason a request for the track would be rejected, or an empty string if it would be accepted.
+func (d *Dispatcher) trackRejectReason(track *Track) string {
+	switch {
+	case track.Unplayable:
+		return rejectReasonUnavailable
+	case d.dedup.Has(track.ID):
+		return rejectReasonDuplicate
+	case d.isExplicitBlocked(track):
+		return rejectReasonExplicit
+	case !d.isTrackDurationAllowed(track.Duration):
+		return rejectReasonDuration
+	case d.cooldownRemaining(track.ID) > 0:
+		return rejectReasonCooldown
+	case d.dedup.FindByISRC(track.ISRC) != "":
+		return rejectReasonDuplicate
+	default:
+		return ""
+	}
+}
+
+// isAlbumOverQuota checks whether the requester's quota can't cover all tracks and notifies them if so.
+func (d *Dispatcher) isAlbumOverQuota(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message,
+	trackCount int) bool {
+	if d.quota == nil {
+		return false
+	}
+
+	remaining := d.quota.Remaining(originalMsg.SenderID)
+	if remaining <= 0 {
+		return d.isUserQuotaExceeded(ctx, msgCtx, originalMsg)
+	}
+	if trackCount <= remaining {
+		return false
+	}
+
+	d.logger.Info("Album exceeds user request quota",
+		zap.String("userID", originalMsg.SenderID),
+		zap.Int("tracks", trackCount),
+		zap.Int("remaining", remaining))
+
+	d.recordRequestRejected(rejectReasonQuota)
+
+	if err := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, chat.ReactionQuota); err != nil {
+		d.logger.Debug("Failed to add quota reaction", zap.Error(err))
+	}
+
+	d.reactError(ctx, msgCtx, originalMsg,
+		d.localizerFor(originalMsg).T("error.album.quota_exceeded", trackCount, remaining))
+	return true
+}
+
+// addAlbumTracks adds the confirmed album tracks to the playlist and reports how many were added.
+func (d *Dispatcher) addAlbumTracks(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message,
+	tracks []Track) {
+	msgCtx.State = StateAddToPlaylist
+
+	added := 0
+	for i := range tracks {
+		track := &tracks[i]
+
+		// Another request may have added the track while waiting for confirmation
+		if d.dedup.Has(track.ID) {
+			continue
+		}
+
+		if err := d.addToPlaylistAndWakeQueueManager(ctx, track.ID); err != nil {
+			d.logger.Error("Failed to add album track to playlist",
+				zap.String("trackID", track.ID),
+				zap.Error(err))
+			continue
+		}
+
+		added++
+		d.metrics.IncSongsAdded()
+		d.recordUserQuota(originalMsg)
+		d.recordLeaderboard(originalMsg)
+		d.recordRecentAddition(originalMsg, track)
+		d.recordCrowdAddition(ctx)
+		d.logTrackAddedEvent(msgCtx, originalMsg, track)
+	}
+
+	localizer := d.localizerFor(originalMsg)
+	if added == 0 {
+		d.reactError(ctx, msgCtx, originalMsg, localizer.T("error.playlist.add_failed"))
+		return
+	}
+
+	msgCtx.State = StateReactAdded
+	d.recordRequestAccepted(originalMsg, &tracks[0])
+
+	d.logger.Info("Album tracks added",
+		zap.String("album", tracks[0].Album),
+		zap.Int("added", added),
+		zap.String("userID", originalMsg.SenderID))
+
+	if err := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, d.addedReaction(false)); err != nil {
+		d.logger.Error("Failed to react with thumbs up", zap.Error(err))
+	}
+
+	successMessage := d.formatMessageWithMention(originalMsg,
+		localizer.T("success.album_added", added, tracks[0].Album, tracks[0].Artist))
+	if _, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, successMessage); err != nil {
+		d.logger.Error("Failed to send album success message", zap.Error(err))
+	}
+
+	d.scheduleRequestMessageDeletion(originalMsg)
+}
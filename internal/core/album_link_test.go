@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// albumTestDedup reports the given tracks and recordings as already in the playlist.
+type albumTestDedup struct {
+	DedupStore
+	trackIDs map[string]bool
+	isrcs    map[string]string
+}
+
+func (s albumTestDedup) Has(trackID string) bool {
+	return s.trackIDs[trackID]
+}
+
+func (s albumTestDedup) FindByISRC(isrc string) string {
+	return s.isrcs[isrc]
+}
+
+// albumTestCooldown reports the given tracks as cooling down.
+type albumTestCooldown map[string]bool
+
+func (c albumTestCooldown) Remaining(trackID string) time.Duration {
+	if c[trackID] {
+		return time.Minute
+	}
+	return 0
+}
+
+func (c albumTestCooldown) Record(_ string) {}
+
+func TestFilterAlbumTracks(t *testing.T) {
+	d := &Dispatcher{
+		config: &Config{App: AppConfig{BlockExplicit: true, MinTrackSecs: 60, MaxTrackSecs: 600}},
+		dedup: albumTestDedup{
+			trackIDs: map[string]bool{"duplicate": true},
+			isrcs:    map[string]string{"GBUM71029604": "single"},
+		},
+		cooldown: albumTestCooldown{"cooling": true},
+		logger:   zap.NewNop(),
+	}
+
+	tracks := []Track{
+		{ID: "new1"},
+		{ID: "duplicate"},
+		{ID: "rerelease", ISRC: "GBUM71029604"},
+		{ID: "cooling"},
+		{ID: "explicit", Explicit: true},
+		{ID: "interlude", Duration: 30 * time.Second},
+		{ID: "epic", Duration: 20 * time.Minute},
+		{ID: "unplayable", Unplayable: true},
+		{ID: "new2", Duration: 4 * time.Minute},
+	}
+
+	newTracks := d.filterAlbumTracks(tracks)
+	if len(newTracks) != 2 || newTracks[0].ID != "new1" || newTracks[1].ID != "new2" {
+		t.Errorf("Expected only new1 and new2 to remain in album order, got %v", newTracks)
+	}
+
+	d.config.App = AppConfig{}
+	d.cooldown = nil
+	if newTracks := d.filterAlbumTracks(tracks); len(newTracks) != 6 {
+		t.Errorf("Expected only the duplicates and unplayable tracks to be filtered without other filters, got %v", newTracks)
+	}
+}
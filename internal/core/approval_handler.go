@@ -19,6 +19,9 @@ const (
 
 	// Track mood fallback.
 	unknownTrackMood = "unknown style"
+
+	// approvalSourceTimeout marks approvals that ended without a decision.
+	approvalSourceTimeout = "timeout"
 )
 
 // generateTrackMoodForCandidate generates track mood for a candidate and stores it in MessageContext.
@@ -29,15 +32,17 @@ func (d *Dispatcher) generateTrackMoodForCandidate(ctx context.Context, msgCtx *
 	}
 
 	if d.llm != nil {
-		if mood, moodErr := d.llm.GenerateTrackMood(ctx, []Track{*candidate}); moodErr != nil {
-			d.logger.Warn("Failed to generate track mood for user prompt, using fallback",
+		llmCtx, cancel := d.withLLMTimeout(ctx)
+		defer cancel()
+		if mood, moodErr := d.llm.GenerateTrackMood(llmCtx, []Track{*candidate}); moodErr != nil {
+			d.logger.Warn("Failed to generate track mood for user prompt, using audio features",
 				zap.Error(moodErr), zap.String("artist", candidate.Artist), zap.String("title", candidate.Title))
-			msgCtx.TrackMood = unknownTrackMood
+			msgCtx.TrackMood = d.audioFeatureMood(ctx, candidate.ID)
 		} else {
 			msgCtx.TrackMood = mood
 		}
 	} else {
-		msgCtx.TrackMood = unknownTrackMood
+		msgCtx.TrackMood = d.audioFeatureMood(ctx, candidate.ID)
 	}
 }
 
@@ -53,30 +58,33 @@ func (d *Dispatcher) promptEnhancedApproval(ctx context.Context, msgCtx *Message
 	// Generate track mood for this candidate
 	d.generateTrackMoodForCandidate(ctx, msgCtx, candidate)
 
-	// Build format components
+	// Build format components in the requester's language
+	localizer := d.localizerFor(originalMsg)
 	albumPart := ""
 	if candidate.Album != "" {
-		albumPart = d.localizer.T("format.album", candidate.Album)
+		albumPart = localizer.T("format.album", candidate.Album)
 	}
 
 	yearPart := ""
 	if candidate.Year > 0 {
-		yearPart = d.localizer.T("format.year", candidate.Year)
+		yearPart = localizer.T("format.year", candidate.Year)
 	}
 
 	urlPart := ""
 	if candidate.URL != "" {
-		urlPart = d.localizer.T("format.url", candidate.URL)
+		urlPart = localizer.T("format.url", candidate.URL)
 	}
 
-	prompt := d.localizer.T("prompt.enhanced_approval",
+	prompt := localizer.T("prompt.enhanced_approval",
 		candidate.Artist, candidate.Title, albumPart, yearPart, urlPart, msgCtx.TrackMood)
 	promptWithMention := d.formatMessageWithMention(originalMsg, prompt)
 
-	approved, err := d.frontend.AwaitApproval(ctx, originalMsg, promptWithMention, d.config.App.ConfirmTimeoutSecs)
+	d.awaitUserInput(ctx, msgCtx, originalMsg)
+	approved, err := d.frontend.AwaitApproval(ctx, originalMsg, promptWithMention,
+		d.confirmTimeoutSecs(ctx, msgCtx, originalMsg))
 	if err != nil {
 		d.logger.Error("Failed to get enhanced approval", zap.Error(err))
-		d.replyError(ctx, msgCtx, originalMsg, d.localizer.T("error.generic"))
+		d.replyError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.generic"))
 		return
 	}
 
@@ -90,7 +98,7 @@ func (d *Dispatcher) promptEnhancedApproval(ctx context.Context, msgCtx *Message
 // handleEnhancedApproval processes approval for enhanced candidates.
 func (d *Dispatcher) handleEnhancedApproval(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message) {
 	if len(msgCtx.Candidates) == 0 {
-		d.replyError(ctx, msgCtx, originalMsg, d.localizer.T("error.generic"))
+		d.replyError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.generic"))
 		return
 	}
 
@@ -100,7 +108,7 @@ func (d *Dispatcher) handleEnhancedApproval(ctx context.Context, msgCtx *Message
 	// Try to find the exact track ID from our previous search
 	tracks, err := d.spotify.SearchTrack(ctx, fmt.Sprintf("%s %s", best.Artist, best.Title))
 	if err != nil || len(tracks) == 0 {
-		d.replyError(ctx, msgCtx, originalMsg, d.localizer.T("error.spotify.not_found"))
+		d.replyError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.spotify.not_found"))
 		return
 	}
 
@@ -119,7 +127,7 @@ func (d *Dispatcher) handleEnhancedApproval(ctx context.Context, msgCtx *Message
 	}
 
 	if d.dedup.Has(trackID) {
-		d.reactDuplicate(ctx, msgCtx, originalMsg)
+		d.reactDuplicate(ctx, msgCtx, originalMsg, trackID)
 		return
 	}
 
@@ -166,13 +174,15 @@ func (d *Dispatcher) awaitAdminApproval(ctx context.Context, msgCtx *MessageCont
 	originalMsg *chat.Message, trackID string) {
 	msgCtx.State = StateAwaitAdminApproval
 
-	track, songInfo, songURL, trackMood, err := d.prepareTrackForApproval(ctx, trackID, msgCtx)
+	track, songInfo, songURL, previewURL, trackMood, err := d.prepareTrackForApproval(ctx, trackID, msgCtx)
 	if err != nil {
 		d.reactError(ctx, msgCtx, originalMsg, "Failed to get track information")
 		return
 	}
 
-	approvalMsgID := d.sendApprovalNotification(ctx, originalMsg, track, trackMood)
+	communityThreshold := d.communityApprovalThreshold(ctx)
+	d.awaitUserInput(ctx, msgCtx, originalMsg)
+	approvalMsgID := d.sendApprovalNotification(ctx, originalMsg, track, trackMood, communityThreshold)
 
 	adminFrontend, communityFrontend, err := d.validateApprovalSupport()
 	if err != nil {
@@ -181,21 +191,22 @@ func (d *Dispatcher) awaitAdminApproval(ctx context.Context, msgCtx *MessageCont
 		return
 	}
 
-	d.executeApprovalStrategy(ctx, msgCtx, originalMsg, trackID, songInfo, songURL, trackMood,
-		approvalMsgID, adminFrontend, communityFrontend)
+	d.executeApprovalStrategy(ctx, msgCtx, originalMsg, trackID, songInfo, songURL, previewURL, trackMood,
+		approvalMsgID, communityThreshold, adminFrontend, communityFrontend)
 }
 
 // prepareTrackForApproval gets track information and mood for approval.
 func (d *Dispatcher) prepareTrackForApproval(ctx context.Context, trackID string,
-	msgCtx *MessageContext) (track *Track, songInfo, songURL, trackMood string, err error) {
+	msgCtx *MessageContext) (track *Track, songInfo, songURL, previewURL, trackMood string, err error) {
 	track, err = d.spotify.GetTrack(ctx, trackID)
 	if err != nil {
 		d.logger.Error("Failed to get track info for admin approval", zap.Error(err))
-		return nil, "", "", "", err
+		return nil, "", "", "", "", err
 	}
 
 	songInfo = fmt.Sprintf("%s - %s", track.Artist, track.Title)
 	songURL = track.URL
+	previewURL = track.PreviewURL
 	trackMood = d.getOrGenerateTrackMood(ctx, msgCtx, track, trackID)
 
 	return
@@ -211,14 +222,16 @@ func (d *Dispatcher) getOrGenerateTrackMood(ctx context.Context, msgCtx *Message
 	}
 
 	if d.llm == nil {
-		return unknownTrackMood
+		return d.audioFeatureMood(ctx, trackID)
 	}
 
-	mood, err := d.llm.GenerateTrackMood(ctx, []Track{*track})
+	llmCtx, cancel := d.withLLMTimeout(ctx)
+	defer cancel()
+	mood, err := d.llm.GenerateTrackMood(llmCtx, []Track{*track})
 	if err != nil {
-		d.logger.Warn("Failed to generate track mood for approval, using fallback",
+		d.logger.Warn("Failed to generate track mood for approval, using audio features",
 			zap.Error(err), zap.String("trackID", trackID))
-		return unknownTrackMood
+		return d.audioFeatureMood(ctx, trackID)
 	}
 
 	return mood
@@ -226,9 +239,9 @@ func (d *Dispatcher) getOrGenerateTrackMood(ctx context.Context, msgCtx *Message
 
 // sendApprovalNotification sends the approval notification message and adds reactions.
 func (d *Dispatcher) sendApprovalNotification(ctx context.Context, originalMsg *chat.Message,
-	track *Track, trackMood string) string {
-	approvalMessage := d.formatCommunityApprovalMessage(track, trackMood)
-	approvalMsgID, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, approvalMessage)
+	track *Track, trackMood string, communityThreshold int) string {
+	approvalMessage := d.formatCommunityApprovalMessage(track, trackMood, communityThreshold)
+	approvalMsgID, err := d.sendTrackMessage(ctx, originalMsg.ChatID, originalMsg.ID, track, approvalMessage)
 	if err != nil {
 		d.logger.Error("Failed to notify user about admin approval", zap.Error(err))
 		return ""
@@ -243,14 +256,14 @@ func (d *Dispatcher) sendApprovalNotification(ctx context.Context, originalMsg *
 
 // validateApprovalSupport checks if the frontend supports required approval methods.
 func (d *Dispatcher) validateApprovalSupport() (adminInterface interface {
-	AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, trackMood string,
+	AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, previewURL, trackMood string,
 		timeoutSec int) (bool, error)
 }, communityInterface interface {
 	AwaitCommunityApproval(ctx context.Context, msgID string, requiredReactions int, timeoutSec int,
 		requesterUserID int64) (bool, error)
 }, err error) {
 	adminFrontend, supportsAdminApproval := d.frontend.(interface {
-		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, trackMood string,
+		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, previewURL, trackMood string,
 			timeoutSec int) (bool, error)
 	})
 
@@ -276,31 +289,71 @@ func (d *Dispatcher) validateApprovalSupport() (adminInterface interface {
 
 // executeApprovalStrategy decides between concurrent or admin-only approval.
 func (d *Dispatcher) executeApprovalStrategy(ctx context.Context, msgCtx *MessageContext,
-	originalMsg *chat.Message, trackID, songInfo, songURL, trackMood, approvalMsgID string,
+	originalMsg *chat.Message, trackID, songInfo, songURL, previewURL, trackMood, approvalMsgID string,
+	communityApprovalThreshold int,
 	adminFrontend interface {
-		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, trackMood string,
+		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, previewURL, trackMood string,
 			timeoutSec int) (bool, error)
 	},
 	communityFrontend interface {
 		AwaitCommunityApproval(ctx context.Context, msgID string, requiredReactions int, timeoutSec int,
 			requesterUserID int64) (bool, error)
 	}) {
-	communityApprovalThreshold := d.config.Telegram.CommunityApproval
 	if communityFrontend != nil && communityApprovalThreshold > 0 && approvalMsgID != "" {
-		d.awaitConcurrentApproval(ctx, msgCtx, originalMsg, trackID, songInfo, songURL, trackMood,
+		d.awaitConcurrentApproval(ctx, msgCtx, originalMsg, trackID, songInfo, songURL, previewURL, trackMood,
 			approvalMsgID, adminFrontend, communityFrontend, communityApprovalThreshold)
 	} else {
-		d.awaitAdminApprovalOnly(ctx, msgCtx, originalMsg, trackID, songInfo, songURL, trackMood,
+		d.awaitAdminApprovalOnly(ctx, msgCtx, originalMsg, trackID, songInfo, songURL, previewURL, trackMood,
 			approvalMsgID, adminFrontend)
 	}
 }
 
+// communityApprovalThreshold resolves the number of 👍 reactions needed for community approval.
+// With a configured percentage the threshold scales with the current group size,
+// falling back to the fixed count if the member count is unavailable.
+func (d *Dispatcher) communityApprovalThreshold(ctx context.Context) int {
+	percent := d.config.Telegram.CommunityApprovalPercent
+	if percent <= 0 {
+		return d.config.Telegram.CommunityApproval
+	}
+
+	memberCounter, ok := d.frontend.(interface {
+		GetMemberCount(ctx context.Context) (int, error)
+	})
+	if !ok {
+		return d.config.Telegram.CommunityApproval
+	}
+
+	memberCount, err := memberCounter.GetMemberCount(ctx)
+	if err != nil {
+		d.logger.Warn("Failed to get group member count, using fixed community approval threshold",
+			zap.Error(err))
+		return d.config.Telegram.CommunityApproval
+	}
+
+	threshold := scaledCommunityThreshold(memberCount, percent)
+	d.logger.Debug("Resolved community approval threshold",
+		zap.Int("memberCount", memberCount),
+		zap.Int("percent", percent),
+		zap.Int("threshold", threshold))
+
+	return threshold
+}
+
+// scaledCommunityThreshold calculates the reactions needed from a percentage of the group members.
+// The bot itself is not counted and at least one reaction is always required.
+func scaledCommunityThreshold(memberCount, percent int) int {
+	const percentBase = 100
+	eligibleMembers := max(memberCount-1, 0)
+	return max((eligibleMembers*percent+percentBase-1)/percentBase, 1)
+}
+
 // awaitConcurrentApproval runs both admin and community approval concurrently.
 func (d *Dispatcher) awaitConcurrentApproval(
 	ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message,
-	trackID, songInfo, songURL, trackMood, approvalMsgID string,
+	trackID, songInfo, songURL, previewURL, trackMood, approvalMsgID string,
 	adminFrontend interface {
-		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, trackMood string,
+		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, previewURL, trackMood string,
 			timeoutSec int) (bool, error)
 	},
 	communityFrontend interface {
@@ -311,7 +364,7 @@ func (d *Dispatcher) awaitConcurrentApproval(
 ) {
 	adminResult, communityResult, errorResult := d.createApprovalChannels()
 
-	d.startAdminApproval(ctx, adminResult, errorResult, adminFrontend, originalMsg, songInfo, songURL, trackMood)
+	d.startAdminApproval(ctx, adminResult, errorResult, adminFrontend, originalMsg, songInfo, songURL, previewURL, trackMood)
 	d.startCommunityApproval(ctx, communityResult, errorResult, communityFrontend, originalMsg,
 		approvalMsgID, communityThreshold)
 
@@ -331,11 +384,11 @@ func (d *Dispatcher) createApprovalChannels() (adminResult, communityResult chan
 // startAdminApproval starts the admin approval process in a goroutine..
 func (d *Dispatcher) startAdminApproval(ctx context.Context, adminResult chan bool, errorResult chan error,
 	adminFrontend interface {
-		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, trackMood string,
+		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, previewURL, trackMood string,
 			timeoutSec int) (bool, error)
-	}, originalMsg *chat.Message, songInfo, songURL, trackMood string) {
+	}, originalMsg *chat.Message, songInfo, songURL, previewURL, trackMood string) {
 	go func() {
-		approved, err := adminFrontend.AwaitAdminApproval(ctx, originalMsg, songInfo, songURL, trackMood,
+		approved, err := adminFrontend.AwaitAdminApproval(ctx, originalMsg, songInfo, songURL, previewURL, trackMood,
 			d.config.App.ConfirmAdminTimeoutSecs)
 		if err != nil {
 			errorResult <- err
@@ -379,7 +432,7 @@ func (d *Dispatcher) handleConcurrentApprovalResults(ctx context.Context, msgCtx
 	originalMsg *chat.Message, trackID, songInfo, approvalMsgID string,
 	adminResult, communityResult chan bool, errorResult chan error,
 	adminFrontend interface {
-		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, trackMood string,
+		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, previewURL, trackMood string,
 			timeoutSec int) (bool, error)
 	}) {
 	select {
@@ -390,9 +443,9 @@ func (d *Dispatcher) handleConcurrentApprovalResults(ctx context.Context, msgCtx
 			approved, adminResult, errorResult, adminFrontend)
 	case err := <-errorResult:
 		d.logger.Error("Approval process failed", zap.Error(err))
-		d.reactError(ctx, msgCtx, originalMsg, d.localizer.T("error.admin.process_failed"))
+		d.reactError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.admin.process_failed"))
 	case <-ctx.Done():
-		d.handleApprovalResult(ctx, msgCtx, originalMsg, trackID, songInfo, approvalMsgID, false, "timeout")
+		d.handleApprovalResult(ctx, msgCtx, originalMsg, trackID, songInfo, approvalMsgID, false, approvalSourceTimeout)
 	}
 }
 
@@ -401,7 +454,7 @@ func (d *Dispatcher) handleCommunityApprovalResult(ctx context.Context, msgCtx *
 	originalMsg *chat.Message, trackID, songInfo, approvalMsgID string, approved bool,
 	adminResult chan bool, errorResult chan error,
 	adminFrontend interface {
-		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, trackMood string,
+		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, previewURL, trackMood string,
 			timeoutSec int) (bool, error)
 	}) {
 	if approved {
@@ -416,16 +469,16 @@ func (d *Dispatcher) handleCommunityApprovalResult(ctx context.Context, msgCtx *
 		d.handleApprovalResult(ctx, msgCtx, originalMsg, trackID, songInfo, approvalMsgID, approved, "admin")
 	case err := <-errorResult:
 		d.logger.Error("Admin approval failed", zap.Error(err))
-		d.reactError(ctx, msgCtx, originalMsg, d.localizer.T("error.admin.process_failed"))
+		d.reactError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.admin.process_failed"))
 	case <-ctx.Done():
-		d.handleApprovalResult(ctx, msgCtx, originalMsg, trackID, songInfo, approvalMsgID, false, "timeout")
+		d.handleApprovalResult(ctx, msgCtx, originalMsg, trackID, songInfo, approvalMsgID, false, approvalSourceTimeout)
 	}
 }
 
 // cancelAdminApproval cancels admin approval if the frontend supports it.
 func (d *Dispatcher) cancelAdminApproval(ctx context.Context,
 	adminFrontend interface {
-		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, trackMood string,
+		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, previewURL, trackMood string,
 			timeoutSec int) (bool, error)
 	}, originalMsg *chat.Message) {
 	if adminCanceller, ok := adminFrontend.(interface {
@@ -438,17 +491,17 @@ func (d *Dispatcher) cancelAdminApproval(ctx context.Context,
 // awaitAdminApprovalOnly handles only admin approval (legacy behavior).
 func (d *Dispatcher) awaitAdminApprovalOnly(
 	ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message,
-	trackID, songInfo, songURL, trackMood, approvalMsgID string,
+	trackID, songInfo, songURL, previewURL, trackMood, approvalMsgID string,
 	adminFrontend interface {
-		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, trackMood string,
+		AwaitAdminApproval(ctx context.Context, origin *chat.Message, songInfo, songURL, previewURL, trackMood string,
 			timeoutSec int) (bool, error)
 	},
 ) {
-	approved, err := adminFrontend.AwaitAdminApproval(ctx, originalMsg, songInfo, songURL, trackMood,
+	approved, err := adminFrontend.AwaitAdminApproval(ctx, originalMsg, songInfo, songURL, previewURL, trackMood,
 		d.config.App.ConfirmAdminTimeoutSecs)
 	if err != nil {
 		d.logger.Error("Admin approval failed", zap.Error(err))
-		d.reactError(ctx, msgCtx, originalMsg, d.localizer.T("error.admin.process_failed"))
+		d.reactError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.admin.process_failed"))
 		return
 	}
 
@@ -473,6 +526,8 @@ func (d *Dispatcher) handleApprovalResult(
 			zap.String("song", songInfo),
 			zap.String("approval_source", approvalSource))
 
+		msgCtx.ApprovalSource = approvalSource
+
 		// Skip individual approval message - will be combined with success message
 		d.executePlaylistAddAfterApproval(ctx, msgCtx, originalMsg, trackID, approvalSource)
 	} else {
@@ -481,8 +536,13 @@ func (d *Dispatcher) handleApprovalResult(
 			zap.String("song", songInfo),
 			zap.String("approval_source", approvalSource))
 
+		if approvalSource == approvalSourceTimeout {
+			d.metrics.IncApprovalTimeouts()
+		}
+		d.recordRequestRejected(rejectReasonDenied)
+
 		// Notify user of denial
-		denialMessage := d.formatMessageWithMention(originalMsg, d.localizer.T("admin.denied"))
+		denialMessage := d.formatMessageWithMention(originalMsg, d.localizerFor(originalMsg).T("admin.denied"))
 		if _, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, denialMessage); err != nil {
 			d.logger.Error("Failed to notify user about denial", zap.Error(err))
 		}
@@ -513,16 +573,12 @@ func (d *Dispatcher) executePlaylistAddAfterApproval(
 		d.logger.Error("Failed to add to playlist",
 			zap.String("trackID", trackID),
 			zap.Error(err))
-		d.reactError(ctx, msgCtx, originalMsg, d.localizer.T("error.playlist.add_failed"))
+		d.recordFailedAddition(originalMsg, trackID, err)
+		d.reactError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.playlist.add_failed"))
 		return
 	}
 
-	// React with thumbs up
-	if reactErr := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, thumbsUpReaction); reactErr != nil {
-		d.logger.Error("Failed to react with thumbs up", zap.Error(reactErr))
-	}
-
-	// Send appropriate success message based on approval source
+	// Send appropriate success message based on approval source, reacting to the request
 	switch approvalSource {
 	case "admin":
 		d.reactAddedAfterApproval(ctx, msgCtx, originalMsg, trackID)
@@ -582,9 +638,9 @@ func (d *Dispatcher) sendAutoApprovalMessage(ctx context.Context, groupID, track
 		return
 	}
 
-	// Add thumbs up reaction for visual feedback
-	if reactErr := d.frontend.React(ctx, groupID, messageID, chat.ReactionThumbsUp); reactErr != nil {
-		d.logger.Debug("Failed to add thumbs up reaction for auto-approval", zap.Error(reactErr))
+	// React for visual feedback, queue-filling tracks play next
+	if reactErr := d.frontend.React(ctx, groupID, messageID, d.addedReaction(true)); reactErr != nil {
+		d.logger.Debug("Failed to add reaction for auto-approval", zap.Error(reactErr))
 	}
 
 	// Auto-approve after brief delay for visual effect
@@ -634,6 +690,7 @@ func (d *Dispatcher) startQueueTrackApprovalTimeout(ctx context.Context, message
 		trackID:    trackID,
 		chatID:     chatID,
 		messageID:  messageID,
+		createdAt:  time.Now(),
 		expiresAt:  time.Now().Add(time.Duration(timeoutSecs) * time.Second),
 		cancelFunc: cancel,
 	}
@@ -684,32 +741,62 @@ func (d *Dispatcher) handleQueueTrackApprovalTimeout(ctx context.Context, messag
 		delete(d.pendingApprovalMessages, messageID)
 		d.queueManagementMutex.Unlock()
 
-		d.logger.Info("Queue approval timed out, auto-accepting track",
-			zap.String("trackID", trackID),
-			zap.String("messageID", messageID))
-
 		// Create a fresh context for post-timeout operations (the original ctx is expired)
 		// We must use context.Background() here because ctx has exceeded its deadline.
 		const postTimeoutOperationTimeout = 10 * time.Second
 		freshCtx, cancel := context.WithTimeout(context.Background(), postTimeoutOperationTimeout)
 		defer cancel()
 
-		// Actually add the track to queue and playlist
 		//nolint:contextcheck // Parent context is intentionally expired; we need a fresh context
-		if err := d.addApprovedQueueTrack(freshCtx, trackID); err != nil {
+		d.applyQueueApprovalTimeoutAction(freshCtx, trackID, chatID, messageID)
+	}
+}
+
+// applyQueueApprovalTimeoutAction accepts, rejects or replaces a queue track nobody decided on in time.
+func (d *Dispatcher) applyQueueApprovalTimeoutAction(ctx context.Context, trackID, chatID, messageID string) {
+	action := d.config.App.QueueApprovalTimeoutAction
+	d.logger.Info("Queue approval timed out",
+		zap.String("trackID", trackID),
+		zap.String("messageID", messageID),
+		zap.String("action", action))
+
+	switch action {
+	case QueueApprovalTimeoutReject:
+		flow, _, _, _ := d.cleanupTrackFromFlow(trackID)
+		if flow != nil {
+			d.removeQueueManagementFlow(flow.FlowID)
+		}
+		d.resetQueueManagementFlag()
+		d.removeQueueTrackApprovalButtonsWithReaction(ctx, chatID, messageID, chat.ReactionThumbsDown)
+
+	case QueueApprovalTimeoutReplace:
+		d.removeQueueTrackApprovalButtonsWithReaction(ctx, chatID, messageID, chat.ReactionThumbsDown)
+		// The replacement is suggested in the background and must outlive the post-timeout context
+		d.handleQueueTrackDecision(context.WithoutCancel(ctx), trackID, false)
+
+	default:
+		// Actually add the track to queue and playlist
+		if err := d.addApprovedQueueTrack(ctx, trackID); err != nil {
 			d.logger.Error("Failed to add auto-accepted queue track",
 				zap.String("trackID", trackID),
 				zap.Error(err))
 		}
 
 		// Remove approval buttons to show auto-acceptance
-		//nolint:contextcheck // Parent context is intentionally expired; we need a fresh context
-		d.removeQueueTrackApprovalButtons(freshCtx, chatID, messageID)
+		d.removeQueueTrackApprovalButtons(ctx, chatID, messageID)
 	}
 }
 
 // removeQueueTrackApprovalButtons removes approval buttons from an queue message.
 func (d *Dispatcher) removeQueueTrackApprovalButtons(ctx context.Context, chatID, messageID string) {
+	// React to indicate auto-acceptance, queue-filling tracks play next
+	d.removeQueueTrackApprovalButtonsWithReaction(ctx, chatID, messageID, d.addedReaction(true))
+}
+
+// removeQueueTrackApprovalButtonsWithReaction removes approval buttons from an queue message
+// and reacts to it with the outcome of the approval.
+func (d *Dispatcher) removeQueueTrackApprovalButtonsWithReaction(ctx context.Context, chatID, messageID string,
+	reaction chat.Reaction) {
 	// For Telegram, we can edit the message to remove the inline keyboard
 	// This is a no-op for platforms that don't support inline buttons
 
@@ -721,8 +808,7 @@ func (d *Dispatcher) removeQueueTrackApprovalButtons(ctx context.Context, chatID
 			zap.Error(err))
 	}
 
-	// React with thumbs up to indicate auto-acceptance
-	if err := d.frontend.React(ctx, chatID, messageID, thumbsUpReaction); err != nil {
+	if err := d.frontend.React(ctx, chatID, messageID, reaction); err != nil {
 		d.logger.Debug("Could not react to queue message (platform may not support reactions)",
 			zap.String("messageID", messageID),
 			zap.Error(err))
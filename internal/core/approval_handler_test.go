@@ -70,3 +70,28 @@ func TestGetQueueApprovalMessageKey_AllKnownKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestScaledCommunityThreshold(t *testing.T) {
+	tests := []struct {
+		name        string
+		memberCount int
+		percent     int
+		expected    int
+	}{
+		{"Small group rounds up", 5, 30, 2},
+		{"Bot is not counted", 11, 50, 5},
+		{"Large group", 201, 10, 20},
+		{"Full agreement", 11, 100, 10},
+		{"At least one reaction", 2, 1, 1},
+		{"Empty group still needs one reaction", 0, 50, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scaledCommunityThreshold(tt.memberCount, tt.percent); got != tt.expected {
+				t.Errorf("scaledCommunityThreshold(%d, %d) = %d, want %d",
+					tt.memberCount, tt.percent, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Pending Approval Inspection
+// This module lets operators list approvals the dispatcher is waiting for
+// and force-resolve approvals that got stuck
+
+const (
+	approvalTypeUser  = "user"
+	approvalTypeAdmin = "admin"
+	approvalTypeQueue = "queue"
+
+	// Approval key prefixes distinguish song request approvals from queue track approvals
+	requestApprovalKeyPrefix = "request_"
+	queueApprovalKeyPrefix   = "queue_"
+)
+
+// ErrApprovalNotFound is returned when resolving an approval that is not pending (anymore).
+var ErrApprovalNotFound = errors.New("approval not found")
+
+// SnapshotApprovals returns all pending user, admin/community and queue track approvals, oldest first.
+func (d *Dispatcher) SnapshotApprovals() []PendingApproval {
+	now := time.Now()
+	approvals := d.snapshotRequestApprovals()
+	approvals = append(approvals, d.snapshotQueueApprovals()...)
+
+	for i := range approvals {
+		approvals[i].AgeSecs = int(now.Sub(approvals[i].CreatedAt).Seconds())
+	}
+	sort.Slice(approvals, func(i, j int) bool {
+		return approvals[i].CreatedAt.Before(approvals[j].CreatedAt)
+	})
+
+	return approvals
+}
+
+// snapshotRequestApprovals returns song requests waiting for user confirmation or admin/community approval.
+func (d *Dispatcher) snapshotRequestApprovals() []PendingApproval {
+	d.contextMutex.RLock()
+	defer d.contextMutex.RUnlock()
+
+	var approvals []PendingApproval
+	for messageID, msgCtx := range d.messageContexts {
+		var approvalType string
+		switch msgCtx.State {
+		case StateConfirmationPrompt:
+			approvalType = approvalTypeUser
+		case StateAwaitAdminApproval:
+			approvalType = approvalTypeAdmin
+		default:
+			continue
+		}
+
+		trackID, song := d.describeRequestTrack(msgCtx)
+		approvals = append(approvals, PendingApproval{
+			Key:       requestApprovalKeyPrefix + messageID,
+			Type:      approvalType,
+			TrackID:   trackID,
+			Song:      song,
+			Requester: msgCtx.Input.SenderJID,
+			CreatedAt: msgCtx.StartTime,
+		})
+	}
+
+	return approvals
+}
+
+// describeRequestTrack returns the track a request is waiting on, without calling Spotify.
+func (d *Dispatcher) describeRequestTrack(msgCtx *MessageContext) (trackID, song string) {
+	trackID = msgCtx.SelectedID
+	if trackID == "" && len(msgCtx.Candidates) > 0 {
+		trackID = msgCtx.Candidates[0].ID
+	}
+
+	for _, candidate := range msgCtx.Candidates {
+		if candidate.ID == trackID || trackID == "" {
+			return trackID, fmt.Sprintf("%s - %s", candidate.Artist, candidate.Title)
+		}
+	}
+
+	d.trackInfoCacheMutex.Lock()
+	defer d.trackInfoCacheMutex.Unlock()
+	if track, ok := d.trackInfoCache[trackID]; ok {
+		return trackID, fmt.Sprintf("%s - %s", track.Artist, track.Title)
+	}
+
+	return trackID, ""
+}
+
+// snapshotQueueApprovals returns queue tracks waiting for approval.
+func (d *Dispatcher) snapshotQueueApprovals() []PendingApproval {
+	d.queueManagementMutex.Lock()
+	defer d.queueManagementMutex.Unlock()
+
+	approvals := make([]PendingApproval, 0, len(d.pendingApprovalMessages))
+	for messageID, approvalCtx := range d.pendingApprovalMessages {
+		song := ""
+		for _, flow := range d.queueManagementFlows {
+			if name, exists := flow.PendingTracks[approvalCtx.trackID]; exists {
+				song = name
+				break
+			}
+		}
+
+		approvals = append(approvals, PendingApproval{
+			Key:       queueApprovalKeyPrefix + messageID,
+			Type:      approvalTypeQueue,
+			TrackID:   approvalCtx.trackID,
+			Song:      song,
+			CreatedAt: approvalCtx.createdAt,
+		})
+	}
+
+	return approvals
+}
+
+// ResolveApproval force-resolves a pending approval by the key reported in SnapshotApprovals.
+func (d *Dispatcher) ResolveApproval(ctx context.Context, key string, approved bool) error {
+	switch {
+	case strings.HasPrefix(key, requestApprovalKeyPrefix):
+		return d.resolveRequestApproval(strings.TrimPrefix(key, requestApprovalKeyPrefix), approved)
+	case strings.HasPrefix(key, queueApprovalKeyPrefix):
+		return d.resolveQueueApproval(ctx, strings.TrimPrefix(key, queueApprovalKeyPrefix), approved)
+	default:
+		return ErrApprovalNotFound
+	}
+}
+
+// resolveRequestApproval answers the confirmation or admin approval the frontend is waiting for.
+func (d *Dispatcher) resolveRequestApproval(messageID string, approved bool) error {
+	d.contextMutex.RLock()
+	msgCtx, exists := d.messageContexts[messageID]
+	var chatID string
+	if exists {
+		chatID = msgCtx.Input.GroupJID
+	}
+	d.contextMutex.RUnlock()
+	if !exists {
+		return ErrApprovalNotFound
+	}
+
+	resolver, ok := d.frontend.(interface {
+		ResolveApproval(chatID, messageID string, approved bool) bool
+	})
+	if !ok {
+		return errors.New("frontend doesn't support resolving approvals")
+	}
+
+	if !resolver.ResolveApproval(chatID, messageID, approved) {
+		return ErrApprovalNotFound
+	}
+
+	d.logger.Info("Force-resolved pending approval",
+		zap.String("messageID", messageID),
+		zap.Bool("approved", approved))
+	return nil
+}
+
+// resolveQueueApproval decides a pending queue track approval as if an admin pressed a button.
+func (d *Dispatcher) resolveQueueApproval(ctx context.Context, messageID string, approved bool) error {
+	d.queueManagementMutex.Lock()
+	approvalCtx, exists := d.pendingApprovalMessages[messageID]
+	if exists {
+		approvalCtx.cancelFunc()
+		delete(d.pendingApprovalMessages, messageID)
+	}
+	d.queueManagementMutex.Unlock()
+	if !exists {
+		return ErrApprovalNotFound
+	}
+
+	d.logger.Info("Force-resolved pending queue track approval",
+		zap.String("trackID", approvalCtx.trackID),
+		zap.String("messageID", messageID),
+		zap.Bool("approved", approved))
+
+	if err := d.frontend.EditMessage(ctx, approvalCtx.chatID, messageID, ""); err != nil {
+		d.logger.Debug("Could not remove queue approval buttons", zap.Error(err))
+	}
+
+	go d.handleQueueTrackDecision(context.WithoutCancel(ctx), approvalCtx.trackID, approved)
+	return nil
+}
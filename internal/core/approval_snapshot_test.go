@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSnapshotApprovals(t *testing.T) {
+	now := time.Now()
+	d := &Dispatcher{
+		messageContexts: map[string]*MessageContext{
+			"101": {
+				Input:      InputMessage{GroupJID: "-1", SenderJID: "42"},
+				State:      StateAwaitAdminApproval,
+				StartTime:  now.Add(-time.Minute),
+				SelectedID: "track1",
+				Candidates: []Track{{ID: "track1", Artist: "Artist", Title: "Title"}},
+			},
+			"102": {State: StateAddToPlaylist, StartTime: now},
+		},
+		pendingApprovalMessages: map[string]*queueApprovalContext{
+			"201": {trackID: "track2", createdAt: now.Add(-2 * time.Minute)},
+		},
+		queueManagementFlows: map[string]*QueueManagementFlow{
+			"flow": {PendingTracks: map[string]string{"track2": "Other - Song"}},
+		},
+		trackInfoCache: make(map[string]*Track),
+	}
+
+	approvals := d.SnapshotApprovals()
+	if len(approvals) != 2 {
+		t.Fatalf("Expected 2 pending approvals, got %d", len(approvals))
+	}
+
+	// Oldest approval comes first
+	if approvals[0].Key != "queue_201" || approvals[0].Type != approvalTypeQueue || approvals[0].Song != "Other - Song" {
+		t.Errorf("Unexpected queue approval: %+v", approvals[0])
+	}
+	if approvals[1].Key != "request_101" || approvals[1].Type != approvalTypeAdmin ||
+		approvals[1].Song != "Artist - Title" || approvals[1].Requester != "42" {
+		t.Errorf("Unexpected request approval: %+v", approvals[1])
+	}
+	if approvals[0].AgeSecs < 120 {
+		t.Errorf("Expected queue approval age of at least 120s, got %d", approvals[0].AgeSecs)
+	}
+}
+
+func TestResolveApproval_NotFound(t *testing.T) {
+	d := &Dispatcher{
+		messageContexts:         make(map[string]*MessageContext),
+		pendingApprovalMessages: make(map[string]*queueApprovalContext),
+	}
+
+	for _, key := range []string{"request_1", "queue_1", "unknown"} {
+		if err := d.ResolveApproval(context.Background(), key, true); !errors.Is(err, ErrApprovalNotFound) {
+			t.Errorf("ResolveApproval(%q) error = %v, expected ErrApprovalNotFound", key, err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Approval Timeouts
+// This module picks how long the bot waits for a requester to answer a confirmation prompt,
+// giving admins and VIP users longer windows than regular users
+
+// confirmTimeoutSecs returns the confirmation timeout for the requester, resolving it once per request.
+func (d *Dispatcher) confirmTimeoutSecs(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message) int {
+	if msgCtx.ConfirmTimeoutSecs == 0 {
+		msgCtx.ConfirmTimeoutSecs = d.resolveConfirmTimeoutSecs(ctx, originalMsg)
+		msgCtx.TimeoutAt = msgCtx.StartTime.Add(time.Duration(msgCtx.ConfirmTimeoutSecs) * time.Second)
+	}
+	return msgCtx.ConfirmTimeoutSecs
+}
+
+// resolveConfirmTimeoutSecs returns the longest confirmation timeout among the sender's roles.
+func (d *Dispatcher) resolveConfirmTimeoutSecs(ctx context.Context, msg *chat.Message) int {
+	timeout := d.config.App.ConfirmTimeoutSecs
+	if d.isVIPUser(msg.SenderID) {
+		timeout = max(timeout, d.config.App.ConfirmVIPTimeoutSecs)
+	}
+	if d.isUserAdmin(ctx, msg) {
+		timeout = max(timeout, d.config.App.ConfirmAdminTimeoutSecs)
+	}
+	return timeout
+}
+
+// isVIPUser reports whether the user is listed in --vip-user-ids.
+func (d *Dispatcher) isVIPUser(userID string) bool {
+	return slices.Contains(d.config.App.VIPUserIDs, userID)
+}
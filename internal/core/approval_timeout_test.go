@@ -0,0 +1,183 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+func newApprovalTimeoutTestDispatcher() *Dispatcher {
+	config := DefaultConfig()
+	config.App.ConfirmTimeoutSecs = 120
+	config.App.ConfirmVIPTimeoutSecs = 600
+	config.App.ConfirmAdminTimeoutSecs = 3600
+	config.App.VIPUserIDs = []string{"vip", "vip-admin"}
+
+	d, frontend := newTestDispatcher(config, nil)
+	frontend.SetAdmins("admin", "vip-admin")
+	return d
+}
+
+func TestResolveConfirmTimeoutSecs(t *testing.T) {
+	d := newApprovalTimeoutTestDispatcher()
+
+	tests := []struct {
+		name     string
+		senderID string
+		expected int
+	}{
+		{"Regular user", "user", 120},
+		{"VIP user", "vip", 600},
+		{"Admin", "admin", 3600},
+		{"Admin listed as VIP gets the longer window", "vip-admin", 3600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &chat.Message{ChatID: "-100", SenderID: tt.senderID}
+			if got := d.resolveConfirmTimeoutSecs(context.Background(), msg); got != tt.expected {
+				t.Errorf("resolveConfirmTimeoutSecs(%q) = %d, want %d", tt.senderID, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveConfirmTimeoutSecs_ShorterVIPTimeout(t *testing.T) {
+	d := newApprovalTimeoutTestDispatcher()
+	d.config.App.ConfirmVIPTimeoutSecs = 60
+
+	msg := &chat.Message{ChatID: "-100", SenderID: "vip"}
+	if got := d.resolveConfirmTimeoutSecs(context.Background(), msg); got != 120 {
+		t.Errorf("A VIP should never get less than the regular timeout, got %d", got)
+	}
+}
+
+func TestConfirmTimeoutSecs_ResolvedOncePerRequest(t *testing.T) {
+	d := newApprovalTimeoutTestDispatcher()
+	start := time.Now()
+	msgCtx := &MessageContext{StartTime: start}
+	msg := &chat.Message{ChatID: "-100", SenderID: "vip"}
+
+	if got := d.confirmTimeoutSecs(context.Background(), msgCtx, msg); got != 600 {
+		t.Fatalf("Expected the VIP timeout, got %d", got)
+	}
+	if !msgCtx.TimeoutAt.Equal(start.Add(600 * time.Second)) {
+		t.Errorf("Expected the request timeout to follow the resolved timeout, got %v", msgCtx.TimeoutAt.Sub(start))
+	}
+
+	d.config.App.VIPUserIDs = nil
+	if got := d.confirmTimeoutSecs(context.Background(), msgCtx, msg); got != 600 {
+		t.Errorf("Expected the resolved timeout to be reused for the request, got %d", got)
+	}
+}
+
+func TestBypassesApproval(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		senderID string
+		expected bool
+	}{
+		{"VIP with bypass enabled", true, "vip", true},
+		{"Regular user with bypass enabled", true, "user", false},
+		{"VIP with bypass disabled", false, "vip", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newApprovalTimeoutTestDispatcher()
+			d.config.App.VIPBypassApproval = tt.enabled
+
+			msgCtx := &MessageContext{SelectedID: "track"}
+			msg := &chat.Message{ChatID: "-100", SenderID: tt.senderID}
+			if got := d.bypassesApproval(msgCtx, msg); got != tt.expected {
+				t.Errorf("bypassesApproval(%q) = %v, want %v", tt.senderID, got, tt.expected)
+			}
+			if tt.expected && msgCtx.ApprovalSource != approvalSourceVIP {
+				t.Errorf("ApprovalSource = %q, want %q", msgCtx.ApprovalSource, approvalSourceVIP)
+			}
+		})
+	}
+}
+
+// queueTimeoutTestSpotify adds tracks to the playlist but has no replacement tracks to suggest.
+type queueTimeoutTestSpotify struct {
+	*failedAdditionTestSpotify
+	recommendations chan struct{}
+}
+
+func (s queueTimeoutTestSpotify) GetRecommendedTrack(_ context.Context, _ string) (trackID, searchQuery,
+	newTrackMood string, err error) {
+	s.recommendations <- struct{}{}
+	return "", "", "", ErrPlaylistEmpty
+}
+
+func newQueueTimeoutTestDispatcher(action string) (*Dispatcher, queueTimeoutTestSpotify, *fake.Frontend,
+	*QueueManagementFlow) {
+	d, added, frontend := newFailedAdditionTestDispatcher(0)
+	spotify := queueTimeoutTestSpotify{failedAdditionTestSpotify: added, recommendations: make(chan struct{}, 1)}
+	d.spotify = spotify
+	d.config.App.QueueApprovalTimeoutAction = action
+
+	flow := &QueueManagementFlow{FlowID: "flow", PendingTracks: map[string]string{"track": "Artist - Title"}}
+	d.queueManagementFlows = map[string]*QueueManagementFlow{flow.FlowID: flow}
+	d.pendingApprovalMessages = make(map[string]*queueApprovalContext)
+	return d, spotify, frontend, flow
+}
+
+func TestApplyQueueApprovalTimeoutAction_Accept(t *testing.T) {
+	d, spotify, frontend, _ := newQueueTimeoutTestDispatcher(QueueApprovalTimeoutAccept)
+
+	d.applyQueueApprovalTimeoutAction(context.Background(), "track", "-100", "msg")
+	if len(spotify.added) != 1 || spotify.added[0] != "track" {
+		t.Errorf("Expected the track to be added, got %v", spotify.added)
+	}
+	if len(d.queueManagementFlows) != 0 {
+		t.Errorf("Expected the flow to be completed, got %d flows", len(d.queueManagementFlows))
+	}
+	if !frontend.HasReacted("msg", chat.Reaction(DefaultQueuedReaction)) {
+		t.Errorf("Expected the queued reaction, got %+v", frontend.Reactions())
+	}
+}
+
+func TestApplyQueueApprovalTimeoutAction_Reject(t *testing.T) {
+	d, spotify, frontend, _ := newQueueTimeoutTestDispatcher(QueueApprovalTimeoutReject)
+	d.queueManagementActive = true
+
+	d.applyQueueApprovalTimeoutAction(context.Background(), "track", "-100", "msg")
+	if len(spotify.added) != 0 {
+		t.Errorf("Expected no track to be added, got %v", spotify.added)
+	}
+	if len(d.queueManagementFlows) != 0 || d.queueManagementActive {
+		t.Error("Expected the flow to be dropped and queue management to be reset")
+	}
+	if !frontend.HasReacted("msg", chat.ReactionThumbsDown) {
+		t.Errorf("Expected a thumbs down reaction, got %+v", frontend.Reactions())
+	}
+}
+
+func TestApplyQueueApprovalTimeoutAction_Replace(t *testing.T) {
+	d, spotify, frontend, flow := newQueueTimeoutTestDispatcher(QueueApprovalTimeoutReplace)
+
+	d.applyQueueApprovalTimeoutAction(context.Background(), "track", "-100", "msg")
+	select {
+	case <-spotify.recommendations:
+	case <-time.After(time.Second):
+		t.Fatal("Expected a replacement track to be looked for")
+	}
+
+	d.queueManagementMutex.RLock()
+	defer d.queueManagementMutex.RUnlock()
+	if len(spotify.added) != 0 || len(flow.PendingTracks) != 0 {
+		t.Errorf("Expected the track to be dropped, added %v, pending %v", spotify.added, flow.PendingTracks)
+	}
+	if flow.RejectionCount != 1 {
+		t.Errorf("Expected the timeout to count as a rejection, got %d", flow.RejectionCount)
+	}
+	if !frontend.HasReacted("msg", chat.ReactionThumbsDown) {
+		t.Errorf("Expected a thumbs down reaction, got %+v", frontend.Reactions())
+	}
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Artist Diversity
+// This module keeps the queue filler from stacking several tracks by the same artist
+// by checking candidates against the artists of the last played and queued tracks
+
+const (
+	// maxArtistDiversitySkips bounds how many playlist tracks are skipped per fill to keep the queue moving.
+	maxArtistDiversitySkips = 5
+	// maxArtistDiversityAttempts bounds how many recommendations are requested before accepting the same artist.
+	maxArtistDiversityAttempts = 3
+)
+
+// primaryArtist returns the normalized first artist of a comma separated artist list.
+func primaryArtist(artist string) string {
+	first, _, _ := strings.Cut(artist, ",")
+	return strings.ToLower(strings.TrimSpace(first))
+}
+
+// containsArtist reports whether the artist's primary artist appears in the list of recent artists.
+func containsArtist(recentArtists []string, artist string) bool {
+	candidate := primaryArtist(artist)
+	if candidate == "" {
+		return false
+	}
+
+	for _, recent := range recentArtists {
+		if primaryArtist(recent) == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// appendRecentArtist appends an artist and keeps only the last limit entries.
+func appendRecentArtist(recentArtists []string, artist string, limit int) []string {
+	recentArtists = append(recentArtists, artist)
+	if len(recentArtists) > limit {
+		recentArtists = recentArtists[len(recentArtists)-limit:]
+	}
+	return recentArtists
+}
+
+// recentArtists returns the artists of the last played tracks followed by the shadow queue,
+// limited to the configured number of most recent entries. Returns nil if the check is disabled.
+func (d *Dispatcher) recentArtists() []string {
+	limit := d.config.App.MaxConsecutiveSameArtist
+	if limit <= 0 {
+		return nil
+	}
+
+	d.shadowQueueMutex.RLock()
+	defer d.shadowQueueMutex.RUnlock()
+
+	artists := make([]string, 0, len(d.recentlyPlayedArtists)+len(d.shadowQueue))
+	artists = append(artists, d.recentlyPlayedArtists...)
+	for _, item := range d.shadowQueue {
+		artists = append(artists, item.Artist)
+	}
+
+	if len(artists) > limit {
+		artists = artists[len(artists)-limit:]
+	}
+	return artists
+}
+
+// artistDiversityFilter tracks recent artists while filling the queue from the playlist.
+// It skips at most maxArtistDiversitySkips tracks so a playlist dominated by one artist still fills the queue.
+type artistDiversityFilter struct {
+	recentArtists []string
+	limit         int // Number of recent artists to check (0 disables the filter)
+	skips         int
+}
+
+// newArtistDiversityFilter creates a filter seeded with the recently played and queued artists.
+func (d *Dispatcher) newArtistDiversityFilter() *artistDiversityFilter {
+	return &artistDiversityFilter{
+		recentArtists: d.recentArtists(),
+		limit:         d.config.App.MaxConsecutiveSameArtist,
+	}
+}
+
+// shouldSkip reports whether a track by the artist should be skipped, counting the skip.
+func (f *artistDiversityFilter) shouldSkip(artist string) bool {
+	if f.limit <= 0 || f.skips >= maxArtistDiversitySkips || !containsArtist(f.recentArtists, artist) {
+		return false
+	}
+	f.skips++
+	return true
+}
+
+// add records the artist of a track that was queued.
+func (f *artistDiversityFilter) add(artist string) {
+	if f.limit <= 0 {
+		return
+	}
+	f.recentArtists = appendRecentArtist(f.recentArtists, artist, f.limit)
+}
+
+// recordPlayedArtistUnsafe remembers the artist of a track that started playing (requires shadow queue lock).
+func (d *Dispatcher) recordPlayedArtistUnsafe(artist string) {
+	limit := d.config.App.MaxConsecutiveSameArtist
+	if limit <= 0 || artist == "" {
+		return
+	}
+	d.recentlyPlayedArtists = appendRecentArtist(d.recentlyPlayedArtists, artist, limit)
+}
+
+// cachedTrackArtist returns the artist of a track from the track info cache, without calling Spotify.
+func (d *Dispatcher) cachedTrackArtist(trackID string) string {
+	d.trackInfoCacheMutex.Lock()
+	defer d.trackInfoCacheMutex.Unlock()
+
+	if track, ok := d.trackInfoCache[trackID]; ok {
+		return track.Artist
+	}
+	return ""
+}
+
+// getDiverseRecommendedTrack requests a queue-filling track, retrying a bounded number of times
+// while the recommendation is by an artist that was played or queued recently.
+// Recommendations outside the track duration limits or skipped recently are never returned.
+func (d *Dispatcher) getDiverseRecommendedTrack(ctx context.Context) (track *Track, searchQuery,
+	newTrackMood string, err error) {
+	recentArtists := d.recentArtists()
+	previousTrackID := d.lastQueuedTrackID()
+
+	for attempt := 1; ; attempt++ {
+		var trackID string
+		trackID, searchQuery, newTrackMood, err = d.spotify.GetRecommendedTrack(ctx, previousTrackID)
+		if err != nil {
+			return nil, "", "", err
+		}
+
+		if d.isRecentlySkipped(trackID) {
+			if attempt >= maxSkipCooldownAttempts {
+				return nil, "", "", errOnlyRecentlySkippedTracks
+			}
+			d.logger.Debug("Recommended track was skipped recently, trying another",
+				zap.String("trackID", trackID),
+				zap.Int("attempt", attempt))
+			continue
+		}
+
+		var trackErr error
+		track, trackErr = d.spotify.GetTrack(ctx, trackID)
+		if trackErr != nil {
+			d.logger.Warn("Could not get track info for queue-filling track approval", zap.Error(trackErr))
+			return &Track{ID: trackID, Title: unknownTrack, Artist: unknownArtist, URL: ""}, searchQuery, newTrackMood, nil
+		}
+
+		if !d.isTrackDurationAllowed(track.Duration) {
+			if attempt >= maxTrackDurationAttempts {
+				return nil, "", "", errNoTrackWithinDurationLimits
+			}
+			d.logger.Debug("Recommended track is outside the duration limits, trying another",
+				zap.String("trackID", trackID),
+				zap.Duration("duration", track.Duration),
+				zap.Int("attempt", attempt))
+			continue
+		}
+
+		if attempt >= maxArtistDiversityAttempts || !containsArtist(recentArtists, track.Artist) {
+			return track, searchQuery, newTrackMood, nil
+		}
+
+		d.logger.Debug("Recommended track is by a recently played artist, trying another",
+			zap.String("trackID", trackID),
+			zap.String("artist", track.Artist),
+			zap.Int("attempt", attempt))
+	}
+}
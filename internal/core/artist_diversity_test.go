@@ -0,0 +1,83 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestContainsArtist(t *testing.T) {
+	recent := []string{"Daft Punk", "Queen, David Bowie"}
+
+	tests := []struct {
+		artist   string
+		expected bool
+	}{
+		{"Daft Punk", true},
+		{"daft punk", true},
+		{"Daft Punk, Pharrell Williams", true},
+		{"Queen", true},
+		{"David Bowie", false},
+		{"Justice", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := containsArtist(recent, tt.artist); got != tt.expected {
+			t.Errorf("containsArtist(%q) = %v, expected %v", tt.artist, got, tt.expected)
+		}
+	}
+}
+
+func TestRecentArtists(t *testing.T) {
+	d := &Dispatcher{
+		config:                &Config{App: AppConfig{MaxConsecutiveSameArtist: 3}},
+		recentlyPlayedArtists: []string{"A", "B"},
+		shadowQueue:           []ShadowQueueItem{{TrackID: "1", Artist: "C"}, {TrackID: "2", Artist: "D"}},
+	}
+
+	if got := d.recentArtists(); !slices.Equal(got, []string{"B", "C", "D"}) {
+		t.Errorf("recentArtists() = %v, expected [B C D]", got)
+	}
+
+	d.recordPlayedArtistUnsafe("E")
+	d.recordPlayedArtistUnsafe("F")
+	d.recordPlayedArtistUnsafe("G")
+	if !slices.Equal(d.recentlyPlayedArtists, []string{"E", "F", "G"}) {
+		t.Errorf("recentlyPlayedArtists = %v, expected to keep the last 3", d.recentlyPlayedArtists)
+	}
+
+	d.config.App.MaxConsecutiveSameArtist = 0
+	if got := d.recentArtists(); got != nil {
+		t.Errorf("recentArtists() = %v, expected nil when disabled", got)
+	}
+}
+
+func TestArtistDiversityFilter(t *testing.T) {
+	filter := &artistDiversityFilter{recentArtists: []string{"Daft Punk"}, limit: 2}
+
+	if !filter.shouldSkip("Daft Punk") {
+		t.Error("Expected track by a recent artist to be skipped")
+	}
+	if filter.shouldSkip("Justice") {
+		t.Error("Expected track by another artist not to be skipped")
+	}
+
+	filter.add("Justice")
+	filter.add("Air")
+	if filter.shouldSkip("Daft Punk") {
+		t.Error("Expected artist outside the recent window not to be skipped")
+	}
+
+	// Skips are bounded so a single-artist playlist still fills the queue
+	for range maxArtistDiversitySkips {
+		filter.shouldSkip("Air")
+	}
+	if filter.shouldSkip("Air") {
+		t.Error("Expected filter to stop skipping after the maximum number of skips")
+	}
+
+	disabled := &artistDiversityFilter{recentArtists: []string{"Air"}}
+	if disabled.shouldSkip("Air") {
+		t.Error("Disabled filter should never skip")
+	}
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Audio Feature Moods
+// This module describes the mood of a track from its audio features, for deployments without an LLM
+
+const (
+	lowFeatureThreshold  = 0.35 // Energy and valence below this are low
+	highFeatureThreshold = 0.65 // Energy and valence from this on are high
+	slowTempoBPM         = 90   // Tempos below this are slow
+	fastTempoBPM         = 125  // Tempos above this are fast
+)
+
+// Mood describes the features in a few words, like "high-energy, upbeat, fast".
+// The same features always give the same mood.
+func (f *AudioFeatures) Mood() string {
+	var words []string
+
+	switch {
+	case f.Energy < lowFeatureThreshold:
+		words = append(words, "calm")
+	case f.Energy >= highFeatureThreshold:
+		words = append(words, "high-energy")
+	default:
+		words = append(words, "mid-energy")
+	}
+
+	switch {
+	case f.Valence < lowFeatureThreshold:
+		words = append(words, "melancholic")
+	case f.Valence >= highFeatureThreshold:
+		words = append(words, "upbeat")
+	default:
+		words = append(words, "bittersweet")
+	}
+
+	switch {
+	case f.Tempo <= 0:
+		// Tempo unknown
+	case f.Tempo < slowTempoBPM:
+		words = append(words, "slow")
+	case f.Tempo > fastTempoBPM:
+		words = append(words, "fast")
+	default:
+		words = append(words, "mid-tempo")
+	}
+
+	return strings.Join(words, ", ")
+}
+
+// audioFeatureMood describes the mood of a track from its audio features, or returns unknownTrackMood without them.
+func (d *Dispatcher) audioFeatureMood(ctx context.Context, trackID string) string {
+	if trackID == "" {
+		return unknownTrackMood
+	}
+
+	features, err := d.spotify.GetAudioFeatures(ctx, trackID)
+	if err != nil {
+		d.logger.Debug("Failed to get audio features for track mood, using fallback",
+			zap.String("trackID", trackID), zap.Error(err))
+		return unknownTrackMood
+	}
+	return features.Mood()
+}
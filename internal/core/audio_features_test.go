@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// audioFeaturesTestSpotify returns fixed audio features, or an error for unknown tracks.
+type audioFeaturesTestSpotify struct {
+	SpotifyClient
+	features map[string]*AudioFeatures
+}
+
+func (s audioFeaturesTestSpotify) GetAudioFeatures(_ context.Context, trackID string) (*AudioFeatures, error) {
+	if features, ok := s.features[trackID]; ok {
+		return features, nil
+	}
+	return nil, errors.New("no audio features")
+}
+
+func TestAudioFeatures_Mood(t *testing.T) {
+	tests := []struct {
+		name     string
+		features AudioFeatures
+		expected string
+	}{
+		{
+			name:     "Party anthem",
+			features: AudioFeatures{Energy: 0.9, Valence: 0.8, Tempo: 128},
+			expected: "high-energy, upbeat, fast",
+		},
+		{
+			name:     "Ballad",
+			features: AudioFeatures{Energy: 0.2, Valence: 0.1, Tempo: 70},
+			expected: "calm, melancholic, slow",
+		},
+		{
+			name:     "Thresholds",
+			features: AudioFeatures{Energy: lowFeatureThreshold, Valence: highFeatureThreshold, Tempo: fastTempoBPM},
+			expected: "mid-energy, upbeat, mid-tempo",
+		},
+		{
+			name:     "Unknown tempo",
+			features: AudioFeatures{Energy: 0.5, Valence: 0.5},
+			expected: "mid-energy, bittersweet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.features.Mood(); got != tt.expected {
+				t.Errorf("Mood() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetOrGenerateTrackMood_WithoutLLM(t *testing.T) {
+	d := &Dispatcher{
+		config: DefaultConfig(),
+		spotify: audioFeaturesTestSpotify{features: map[string]*AudioFeatures{
+			"anthem": {Energy: 0.9, Valence: 0.8, Tempo: 128},
+		}},
+		logger: zap.NewNop(),
+	}
+	ctx := context.Background()
+
+	if mood := d.getOrGenerateTrackMood(ctx, &MessageContext{}, &Track{ID: "anthem"}, "anthem"); mood != "high-energy, upbeat, fast" {
+		t.Errorf("Expected the mood of the audio features, got %q", mood)
+	}
+	if mood := d.getOrGenerateTrackMood(ctx, &MessageContext{}, &Track{ID: "unknown"}, "unknown"); mood != unknownTrackMood {
+		t.Errorf("Expected the fallback mood without audio features, got %q", mood)
+	}
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Auto-Accept
+// This module adds the top search result without a confirmation prompt when it matches the request
+// well and clearly better than the runner-up
+
+// autoAcceptMargin is how much more relevant the top search result must be than the runner-up.
+const autoAcceptMargin = 0.1
+
+// autoAcceptCandidate returns the search result confident enough to add without confirmation, or nil.
+// Tracks must be ranked by relevance, as SearchTrack returns them.
+func (d *Dispatcher) autoAcceptCandidate(tracks []Track) *Track {
+	const percentBase = 100
+	threshold := d.config.App.AutoAcceptThreshold
+	if threshold <= 0 || len(tracks) == 0 || tracks[0].ID == "" {
+		return nil
+	}
+
+	best := tracks[0]
+	if best.Relevance*percentBase < float64(threshold) {
+		return nil
+	}
+	if len(tracks) > 1 && best.Relevance-tracks[1].Relevance < autoAcceptMargin {
+		return nil
+	}
+	return &best
+}
+
+// autoAcceptTrack adds the confident search result like a confirmed one, so filters and admin approval still apply.
+func (d *Dispatcher) autoAcceptTrack(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message,
+	track *Track) {
+	d.logger.Info("Auto-accepting confident search result",
+		zap.String("artist", track.Artist),
+		zap.String("title", track.Title),
+		zap.Float64("relevance", track.Relevance))
+
+	msgCtx.Candidates = []Track{*track}
+	if d.dedup.Has(track.ID) {
+		d.reactDuplicate(ctx, msgCtx, originalMsg, track.ID)
+		return
+	}
+
+	d.addToPlaylist(ctx, msgCtx, originalMsg, track.ID)
+}
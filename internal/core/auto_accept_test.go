@@ -0,0 +1,35 @@
+package core
+
+import "testing"
+
+func TestAutoAcceptCandidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		threshold  int
+		tracks     []Track
+		expectedID string
+	}{
+		{"Disabled", 0, []Track{{ID: "a", Relevance: 1.1}}, ""},
+		{"Single confident result", 90, []Track{{ID: "a", Relevance: 0.95}}, "a"},
+		{"Below threshold", 90, []Track{{ID: "a", Relevance: 0.85}, {ID: "b", Relevance: 0.2}}, ""},
+		{"Clearly ahead of runner-up", 90, []Track{{ID: "a", Relevance: 1.1}, {ID: "b", Relevance: 0.7}}, "a"},
+		{"Too close to runner-up", 90, []Track{{ID: "a", Relevance: 1.1}, {ID: "b", Relevance: 1.05}}, ""},
+		{"Not matched to Spotify", 90, []Track{{Relevance: 1.1}}, ""},
+		{"No results", 90, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dispatcher{config: DefaultConfig()}
+			d.config.App.AutoAcceptThreshold = tt.threshold
+
+			best := d.autoAcceptCandidate(tt.tracks)
+			switch {
+			case tt.expectedID == "" && best != nil:
+				t.Errorf("Expected confirmation to be asked, got auto-accepted %+v", best)
+			case tt.expectedID != "" && (best == nil || best.ID != tt.expectedID):
+				t.Errorf("Expected %q to be auto-accepted, got %+v", tt.expectedID, best)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Auto-DJ Share Monitoring
+// This module tracks whether recently added tracks were requested by the crowd or picked by the auto-DJ
+// filling the queue, warns admins once when the auto-DJ takes over, and clears the warning when requests pick up
+
+// autoDJShareWindow is the number of most recently added tracks the auto-DJ share is calculated over.
+const autoDJShareWindow = 10
+
+// additionSources is a rolling window of the sources of the most recently added tracks, safe for concurrent use.
+type additionSources struct {
+	mutex  sync.Mutex
+	autoDJ []bool // Whether each track was picked by the auto-DJ, oldest first
+}
+
+// add records the source of an added track and returns the number of auto-DJ tracks in the window,
+// or false while fewer than autoDJShareWindow tracks were added.
+func (s *additionSources) add(autoDJ bool) (autoDJTracks int, full bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.autoDJ = append(s.autoDJ, autoDJ)
+	if len(s.autoDJ) > autoDJShareWindow {
+		s.autoDJ = s.autoDJ[len(s.autoDJ)-autoDJShareWindow:]
+	}
+
+	for _, picked := range s.autoDJ {
+		if picked {
+			autoDJTracks++
+		}
+	}
+	return autoDJTracks, len(s.autoDJ) == autoDJShareWindow
+}
+
+// recordCrowdAddition records a track added because of a song request.
+func (d *Dispatcher) recordCrowdAddition(ctx context.Context) {
+	d.recordAdditionSource(ctx, false)
+}
+
+// recordAutoDJAddition records a track the auto-DJ added to fill the queue.
+func (d *Dispatcher) recordAutoDJAddition(ctx context.Context) {
+	d.recordAdditionSource(ctx, true)
+}
+
+// recordAdditionSource updates the auto-DJ share and warns admins once it exceeds the configured threshold,
+// or clears the warning once crowd requests bring it back below.
+func (d *Dispatcher) recordAdditionSource(ctx context.Context, autoDJ bool) {
+	const percentBase = 100
+	if d.config.App.AutoDJWarningPercent <= 0 {
+		return
+	}
+
+	autoDJTracks, full := d.additionSources.add(autoDJ)
+	if !full {
+		return
+	}
+
+	if autoDJTracks*percentBase <= d.config.App.AutoDJWarningPercent*autoDJShareWindow {
+		d.warningManager.ClearWarning(ctx, WarningTypeAutoDJ)
+		return
+	}
+	d.sendAutoDJWarningIfNeeded(ctx, autoDJTracks)
+}
+
+// sendAutoDJWarningIfNeeded tells admins once that the auto-DJ picked most of the recently added tracks.
+func (d *Dispatcher) sendAutoDJWarningIfNeeded(ctx context.Context, autoDJTracks int) {
+	if !d.warningManager.ShouldSendWarning(WarningTypeAutoDJ) {
+		return
+	}
+
+	groupID := d.getGroupID()
+	if groupID == "" {
+		d.logger.Warn("No group ID available for auto-DJ warning")
+		return
+	}
+
+	adminUserIDs, err := d.frontend.GetAdminUserIDs(ctx, groupID)
+	if err != nil {
+		d.logger.Warn("Failed to get admin user IDs for auto-DJ warning", zap.Error(err))
+		return
+	}
+
+	if len(adminUserIDs) == 0 {
+		d.logger.Warn("No admin user IDs found for auto-DJ warning")
+		return
+	}
+
+	message := d.localizer.T("admin.autodj_takeover", autoDJTracks, autoDJShareWindow)
+	if err := d.warningManager.SendWarningToAdmins(ctx, WarningTypeAutoDJ, adminUserIDs, message); err != nil {
+		d.logger.Warn("Failed to send auto-DJ warning", zap.Error(err))
+		return
+	}
+
+	d.logger.Info("Sent auto-DJ takeover warning message",
+		zap.Int("autoDJTracks", autoDJTracks),
+		zap.Int("window", autoDJShareWindow))
+}
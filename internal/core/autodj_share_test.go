@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+func TestRecordAdditionSource(t *testing.T) {
+	ctx := context.Background()
+	frontend := fake.New()
+	frontend.SetAdmins("42")
+
+	config := DefaultConfig()
+	config.Telegram.GroupID = -100
+	d := &Dispatcher{
+		config:         config,
+		frontend:       frontend,
+		localizer:      i18n.NewLocalizer(i18n.DefaultLanguage),
+		warningManager: NewAdminWarningManager(frontend, nil, zap.NewNop()),
+		logger:         zap.NewNop(),
+	}
+
+	// No warning until the window is full, even if the auto-DJ picked every track
+	for range autoDJShareWindow - 1 {
+		d.recordAutoDJAddition(ctx)
+	}
+	if len(frontend.SentMessages()) != 0 {
+		t.Fatalf("Expected no warning before the window is full, got %+v", frontend.SentMessages())
+	}
+
+	d.recordAutoDJAddition(ctx)
+	d.recordAutoDJAddition(ctx)
+	if len(frontend.SentMessages()) != 1 ||
+		!frontend.HasSentKey("admin.autodj_takeover", autoDJShareWindow, autoDJShareWindow) {
+		t.Errorf("Expected a single admin warning, got %+v", frontend.SentMessages())
+	}
+
+	// 8 of 10 is not above the default threshold of 80%
+	d.recordCrowdAddition(ctx)
+	d.recordCrowdAddition(ctx)
+	if len(frontend.DeletedMessages()) != 1 {
+		t.Errorf("Expected the admin warning to be cleared, got %v", frontend.DeletedMessages())
+	}
+}
+
+func TestRecordAdditionSource_Disabled(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultConfig()
+	config.App.AutoDJWarningPercent = 0
+	d := &Dispatcher{config: config, logger: zap.NewNop()}
+
+	// A nil warning manager would panic if the share was evaluated
+	for range autoDJShareWindow {
+		d.recordAutoDJAddition(ctx)
+	}
+}
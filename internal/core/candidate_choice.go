@@ -0,0 +1,199 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Candidate Choice
+// This module lets requesters pick their track among the best search results with inline buttons,
+// instead of confirming or rejecting the best result only
+
+const (
+	// candidateChoicePrefix prefixes the choice IDs of search result buttons.
+	candidateChoicePrefix = "pick:"
+	// candidateChoiceNone is the option of the button rejecting all offered search results.
+	candidateChoiceNone = "none"
+)
+
+// candidatePick is a search result choice waiting for its requester.
+type candidatePick struct {
+	requesterID string
+	picked      chan string // Receives the picked option
+}
+
+// candidatePicks holds the search result choices waiting for their requesters, safe for concurrent use.
+type candidatePicks struct {
+	mutex   sync.Mutex
+	pending map[string]*candidatePick
+	nextKey int
+}
+
+// register adds a choice waiting for the requester and returns the key its choice IDs are built from.
+func (p *candidatePicks) register(requesterID string) (string, *candidatePick) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.pending == nil {
+		p.pending = make(map[string]*candidatePick)
+	}
+	p.nextKey++
+	key := strconv.Itoa(p.nextKey)
+	pick := &candidatePick{requesterID: requesterID, picked: make(chan string, 1)}
+	p.pending[key] = pick
+	return key, pick
+}
+
+// get returns the choice waiting under the key, if it is still waiting.
+func (p *candidatePicks) get(key string) (*candidatePick, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	pick, ok := p.pending[key]
+	return pick, ok
+}
+
+// remove stops waiting for the choice under the key.
+func (p *candidatePicks) remove(key string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.pending, key)
+}
+
+// candidateChoices returns the search results to offer as buttons, or nil if the requester
+// should confirm the best result instead.
+func (d *Dispatcher) candidateChoices(tracks []Track) []Track {
+	if !d.config.App.DisambiguationButtons {
+		return nil
+	}
+
+	choices := make([]Track, 0, d.config.App.DisambiguationChoices)
+	seen := make(map[string]struct{}, len(tracks))
+	for i := range tracks {
+		if len(choices) == d.config.App.DisambiguationChoices {
+			break
+		}
+		// Only tracks matched to Spotify can be added
+		if tracks[i].ID == "" {
+			continue
+		}
+		if _, duplicate := seen[tracks[i].ID]; duplicate {
+			continue
+		}
+		seen[tracks[i].ID] = struct{}{}
+		choices = append(choices, tracks[i])
+	}
+
+	if len(choices) < 2 {
+		return nil
+	}
+	return choices
+}
+
+// promptCandidateChoice asks the requester to pick one of the search results, and adds the picked track.
+// Rejecting all results or letting the prompt time out asks which song was meant.
+func (d *Dispatcher) promptCandidateChoice(ctx context.Context, msgCtx *MessageContext,
+	originalMsg *chat.Message, candidates []Track) {
+	msgCtx.State = StateConfirmationPrompt
+
+	key, pick := d.candidatePicks.register(originalMsg.SenderID)
+	defer d.candidatePicks.remove(key)
+
+	localizer := d.localizerFor(originalMsg)
+	choices := make([]chat.Choice, 0, len(candidates)+1)
+	for i := range candidates {
+		choices = append(choices, chat.Choice{
+			ID:    fmt.Sprintf("%s%s:%d", candidateChoicePrefix, key, i),
+			Label: localizer.T("button.candidate", i+1, candidates[i].Artist, candidates[i].Title),
+		})
+	}
+	choices = append(choices, chat.Choice{
+		ID:    candidateChoicePrefix + key + ":" + candidateChoiceNone,
+		Label: localizer.T("button.candidate_none"),
+	})
+
+	d.awaitUserInput(ctx, msgCtx, originalMsg)
+	timeout := time.Duration(d.confirmTimeoutSecs(ctx, msgCtx, originalMsg)) * time.Second
+	prompt := d.formatMessageWithMention(originalMsg, localizer.T("prompt.candidate_choice"))
+	promptID, err := d.frontend.SendChoices(ctx, originalMsg.ChatID, originalMsg.ID, prompt, choices)
+	if err != nil {
+		d.logger.Error("Failed to send search result choices", zap.Error(err))
+		d.replyError(ctx, msgCtx, originalMsg, localizer.T("error.generic"))
+		return
+	}
+
+	option := awaitCandidatePick(ctx, pick, timeout)
+	if err := d.frontend.DeleteMessage(ctx, originalMsg.ChatID, promptID); err != nil {
+		d.logger.Debug("Failed to delete search result choices", zap.Error(err))
+	}
+
+	index, err := strconv.Atoi(option)
+	if err != nil || index < 0 || index >= len(candidates) {
+		d.askWhichSong(ctx, msgCtx, originalMsg)
+		return
+	}
+
+	track := candidates[index]
+	d.logger.Info("Requester picked search result",
+		zap.String("userID", originalMsg.SenderID),
+		zap.Int("position", index+1),
+		zap.String("artist", track.Artist),
+		zap.String("title", track.Title))
+	msgCtx.Candidates = []Track{track}
+
+	if d.dedup.Has(track.ID) {
+		d.reactDuplicate(ctx, msgCtx, originalMsg, track.ID)
+		return
+	}
+
+	d.addToPlaylist(ctx, msgCtx, originalMsg, track.ID)
+}
+
+// awaitCandidatePick waits for the requester's pick and returns the picked option,
+// or an empty string once the timeout passed.
+func awaitCandidatePick(ctx context.Context, pick *candidatePick, timeout time.Duration) string {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case option := <-pick.picked:
+		return option
+	case <-timer.C:
+		return ""
+	case <-ctx.Done():
+		return ""
+	}
+}
+
+// handleCandidateChoice passes a picked search result to the request waiting for it.
+// Picks of other users than the requester are ignored.
+func (d *Dispatcher) handleCandidateChoice(_ context.Context, msg *chat.Message, choiceID string) {
+	key, option, ok := strings.Cut(strings.TrimPrefix(choiceID, candidateChoicePrefix), ":")
+	if !ok {
+		return
+	}
+
+	pick, found := d.candidatePicks.get(key)
+	if !found {
+		d.logger.Debug("Ignoring pick of an expired search result choice", zap.String("choiceID", choiceID))
+		return
+	}
+	if msg.SenderID != pick.requesterID {
+		d.logger.Debug("Ignoring search result pick of another user than the requester",
+			zap.String("userID", msg.SenderID))
+		return
+	}
+
+	// Only the first pick counts
+	select {
+	case pick.picked <- option:
+	default:
+	}
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+func TestCandidateChoices(t *testing.T) {
+	d := &Dispatcher{config: DefaultConfig()}
+	tracks := []Track{{ID: "a"}, {ID: ""}, {ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+
+	if choices := d.candidateChoices(tracks); choices != nil {
+		t.Errorf("Expected no choices while the mode is off, got %+v", choices)
+	}
+
+	d.config.App.DisambiguationButtons = true
+	choices := d.candidateChoices(tracks)
+	if len(choices) != DefaultDisambiguationChoices || choices[0].ID != "a" || choices[1].ID != "b" || choices[2].ID != "c" {
+		t.Errorf("Expected the first distinct Spotify tracks, got %+v", choices)
+	}
+
+	if choices := d.candidateChoices([]Track{{ID: "a"}, {ID: "a"}, {ID: ""}}); choices != nil {
+		t.Errorf("Expected a single result to be confirmed instead, got %+v", choices)
+	}
+}
+
+func TestHandleCandidateChoice(t *testing.T) {
+	ctx := context.Background()
+	frontend := fake.New()
+	d := &Dispatcher{config: DefaultConfig(), frontend: frontend, logger: zap.NewNop()}
+	d.registerChoiceHandler()
+
+	key, pick := d.candidatePicks.register("requester")
+	choiceID := candidateChoicePrefix + key + ":1"
+
+	frontend.PickChoice(ctx, &chat.Message{SenderID: "someone-else"}, choiceID)
+	frontend.PickChoice(ctx, &chat.Message{SenderID: "requester"}, candidateChoicePrefix+"unknown:0")
+	frontend.PickChoice(ctx, &chat.Message{SenderID: "requester"}, choiceID)
+	frontend.PickChoice(ctx, &chat.Message{SenderID: "requester"}, candidateChoicePrefix+key+":"+candidateChoiceNone)
+
+	if option := awaitCandidatePick(ctx, pick, time.Second); option != "1" {
+		t.Errorf("Expected the first pick of the requester, got %q", option)
+	}
+
+	d.candidatePicks.remove(key)
+	if option := awaitCandidatePick(ctx, pick, 10*time.Millisecond); option != "" {
+		t.Errorf("Expected later picks to be ignored, got %q", option)
+	}
+}
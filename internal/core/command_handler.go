@@ -0,0 +1,315 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Chat Command Handling
+// This module handles slash commands sent to the group (e.g. /skip, /undo)
+// Admin-only commands are verified by the chat frontend before reaching these handlers
+
+const (
+	commandSkip   = "skip"
+	commandUndo   = "undo"
+	commandQueue  = "queue"
+	commandPause  = "pause"
+	commandResume = "resume"
+
+	// addedTrackMessageMaxAge is how long an added track can still be undone by replying to its message.
+	addedTrackMessageMaxAge = 24 * time.Hour
+
+	// queueListMaxEntries is the maximum number of upcoming tracks listed by /queue.
+	queueListMaxEntries = 10
+	// trackInfoCacheMaxSize bounds the track info cache; it is cleared once exceeded.
+	trackInfoCacheMaxSize = 500
+	secondsPerMinute      = 60
+)
+
+// registerCommandHandlers registers all chat command handlers with the frontend.
+func (d *Dispatcher) registerCommandHandlers() {
+	d.frontend.SetCommandHandler(commandSkip, true, d.handleSkipCommand)
+	d.frontend.SetCommandHandler(commandUndo, true, d.handleUndoCommand)
+	d.frontend.SetCommandHandler(commandQueue, false, d.handleQueueCommand)
+	d.frontend.SetCommandHandler(commandPause, true, d.handlePauseCommand)
+	d.frontend.SetCommandHandler(commandResume, true, d.handleResumeCommand)
+	d.frontend.SetCommandHandler(commandStats, true, d.handleStatsCommand)
+	d.frontend.SetCommandHandler(commandVolume, true, d.handleVolumeCommand)
+	d.frontend.SetCommandHandler(commandBump, true, d.handleBumpCommand)
+	d.frontend.SetCommandHandler(commandPauseMusic, true, d.handlePauseMusicCommand)
+	d.frontend.SetCommandHandler(commandResumeMusic, true, d.handleResumeMusicCommand)
+	d.frontend.SetCommandHandler(commandHistory, false, d.handleHistoryCommand)
+	d.frontend.SetCommandHandler(commandLeaderboard, false, d.handleLeaderboardCommand)
+	d.frontend.SetCommandHandler(commandRequeueFailed, true, d.handleRequeueFailedCommand)
+	d.frontend.SetCommandHandler(commandWhoami, false, d.handleWhoamiCommand)
+	if d.warningSubscribers != nil {
+		d.frontend.SetCommandHandler(commandStart, false, d.handleStartCommand)
+	}
+}
+
+// handleSkipCommand skips the currently playing track.
+func (d *Dispatcher) handleSkipCommand(ctx context.Context, msg *chat.Message) {
+	d.logger.Info("Skip command received",
+		zap.String("userID", msg.SenderID),
+		zap.String("userName", msg.SenderName))
+
+	hasDevice, err := d.spotify.HasActiveDevice(ctx)
+	if err != nil {
+		d.logger.Warn("Failed to check for active device before skipping", zap.Error(err))
+	}
+	if err == nil && !hasDevice {
+		d.replyCommandError(ctx, msg, "error.spotify.no_active_device")
+		return
+	}
+
+	d.shadowQueueMutex.RLock()
+	skippedTrackID := d.lastCurrentTrackID
+	d.shadowQueueMutex.RUnlock()
+
+	if err := d.spotify.SkipToNext(ctx); err != nil {
+		d.logger.Error("Failed to skip track", zap.Error(err))
+		d.replyCommandError(ctx, msg, "error.spotify.skip_failed")
+		return
+	}
+	d.recordSkippedTrack(skippedTrackID)
+
+	if err := d.frontend.React(ctx, msg.ChatID, msg.ID, chat.ReactionSkip); err != nil {
+		d.logger.Debug("Failed to add skip reaction", zap.Error(err))
+	}
+}
+
+// handleUndoCommand removes the track that was added by the replied-to message.
+func (d *Dispatcher) handleUndoCommand(ctx context.Context, msg *chat.Message) {
+	if msg.ReplyToID == "" {
+		d.replyCommandError(ctx, msg, "error.command.undo_no_reply")
+		return
+	}
+
+	trackID, ok := d.lookupAddedTrackMessage(msg.ReplyToID)
+	if !ok {
+		d.replyCommandError(ctx, msg, "error.command.undo_unknown_message")
+		return
+	}
+
+	d.logger.Info("Undo command received",
+		zap.String("userID", msg.SenderID),
+		zap.String("trackID", trackID))
+
+	if err := d.spotify.RemoveFromPlaylist(ctx, d.config.Spotify.PlaylistID, trackID); err != nil {
+		d.logger.Error("Failed to remove track from playlist",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		d.replyCommandError(ctx, msg, "error.playlist.remove_failed")
+		return
+	}
+
+	// Allow the track to be requested again
+	d.dedup.Remove(trackID)
+	d.forgetAddedTrack(trackID)
+
+	track, err := d.spotify.GetTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Debug("Failed to get track info for undo message", zap.Error(err))
+		track = &Track{ID: trackID, Title: unknownTrack, Artist: unknownArtist}
+	}
+
+	removedMessage := d.formatMessageWithMention(msg, d.localizerFor(msg).T("success.track_removed", track.Artist, track.Title))
+	if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, removedMessage); err != nil {
+		d.logger.Error("Failed to send undo confirmation", zap.Error(err))
+	}
+}
+
+// handleQueueCommand lists the upcoming tracks of the shadow queue.
+func (d *Dispatcher) handleQueueCommand(ctx context.Context, msg *chat.Message) {
+	items := d.ListShadowQueue()
+
+	var reply string
+	if len(items) == 0 {
+		reply = d.localizer.T("bot.queue_empty")
+	} else {
+		reply = d.formatQueueList(ctx, items)
+	}
+
+	if _, err := d.sendLongText(ctx, msg.ChatID, msg.ID, reply); err != nil {
+		d.logger.Error("Failed to send queue listing", zap.Error(err))
+	}
+}
+
+// formatQueueList formats a numbered list of the first upcoming tracks with the total remaining duration.
+func (d *Dispatcher) formatQueueList(ctx context.Context, items []ShadowQueueItem) string {
+	var builder strings.Builder
+	builder.WriteString(d.localizer.T("bot.queue_list_header",
+		len(items), formatQueueDuration(d.GetShadowQueueDuration())))
+
+	for i, item := range items[:min(len(items), queueListMaxEntries)] {
+		track, err := d.getCachedTrack(ctx, item.TrackID)
+		if err != nil {
+			d.logger.Debug("Failed to get track info for queue listing",
+				zap.String("trackID", item.TrackID),
+				zap.Error(err))
+			track = &Track{ID: item.TrackID, Title: unknownTrack, Artist: unknownArtist}
+		}
+		builder.WriteString("\n")
+		builder.WriteString(d.localizer.T("bot.queue_list_item", i+1, track.Artist, track.Title))
+	}
+
+	if len(items) > queueListMaxEntries {
+		builder.WriteString("\n")
+		builder.WriteString(d.localizer.T("bot.queue_list_more", len(items)-queueListMaxEntries))
+	}
+
+	return builder.String()
+}
+
+// getCachedTrack returns track info from the cache, fetching it from Spotify on a miss.
+func (d *Dispatcher) getCachedTrack(ctx context.Context, trackID string) (*Track, error) {
+	d.trackInfoCacheMutex.Lock()
+	track, ok := d.trackInfoCache[trackID]
+	d.trackInfoCacheMutex.Unlock()
+	if ok {
+		return track, nil
+	}
+
+	track, err := d.spotify.GetTrack(ctx, trackID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get track %s: %w", trackID, err)
+	}
+
+	d.trackInfoCacheMutex.Lock()
+	defer d.trackInfoCacheMutex.Unlock()
+	if len(d.trackInfoCache) >= trackInfoCacheMaxSize {
+		d.trackInfoCache = make(map[string]*Track)
+	}
+	d.trackInfoCache[trackID] = track
+
+	return track, nil
+}
+
+// formatQueueDuration formats a queue duration as minutes and seconds (e.g. "12:05").
+func formatQueueDuration(duration time.Duration) string {
+	totalSeconds := int(duration.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", totalSeconds/secondsPerMinute, totalSeconds%secondsPerMinute)
+}
+
+// IsIngestionPaused reports whether new song requests are currently rejected.
+func (d *Dispatcher) IsIngestionPaused() bool {
+	return d.ingestionPaused.Load()
+}
+
+// handlePauseCommand stops accepting song requests until /resume.
+func (d *Dispatcher) handlePauseCommand(ctx context.Context, msg *chat.Message) {
+	d.setIngestionPaused(ctx, msg, true, "success.ingestion_paused")
+}
+
+// handleResumeCommand accepts song requests again after /pause.
+func (d *Dispatcher) handleResumeCommand(ctx context.Context, msg *chat.Message) {
+	d.setIngestionPaused(ctx, msg, false, "success.ingestion_resumed")
+}
+
+// setIngestionPaused flips the ingestion toggle and confirms the new state in the chat.
+func (d *Dispatcher) setIngestionPaused(ctx context.Context, msg *chat.Message, paused bool, messageKey string) {
+	d.ingestionPaused.Store(paused)
+
+	d.logger.Info("Song request ingestion toggled",
+		zap.Bool("paused", paused),
+		zap.String("userID", msg.SenderID),
+		zap.String("userName", msg.SenderName))
+
+	if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, d.localizerFor(msg).T(messageKey)); err != nil {
+		d.logger.Error("Failed to confirm ingestion toggle", zap.Error(err))
+	}
+}
+
+// rejectPausedRequest tells the sender that song requests are paused, using the given message key and arguments.
+// Free text that isn't a music request is ignored silently to avoid chat spam.
+func (d *Dispatcher) rejectPausedRequest(ctx context.Context, inputMsg InputMessage, msg *chat.Message,
+	messageKey string, args ...any) {
+	if inputMsg.Type == MessageTypeFreeText && d.isNotMusicRequest(ctx, inputMsg.Text) {
+		return
+	}
+
+	d.logger.Debug("Ignoring message while ingestion is paused",
+		zap.String("messageID", msg.ID),
+		zap.String("sender", msg.SenderName))
+	d.recordRequest()
+	d.recordRequestRejected(rejectReasonPaused)
+
+	if err := d.frontend.React(ctx, msg.ChatID, msg.ID, chat.ReactionPaused); err != nil {
+		d.logger.Debug("Failed to add paused reaction", zap.Error(err))
+	}
+
+	d.replyCommandError(ctx, msg, messageKey, args...)
+}
+
+// rememberAddedTrackMessage records the messages that resulted in a track being added.
+func (d *Dispatcher) rememberAddedTrackMessage(trackID string, messageIDs ...string) {
+	d.addedTrackMessagesMutex.Lock()
+	defer d.addedTrackMessagesMutex.Unlock()
+
+	now := time.Now()
+	for messageID, added := range d.addedTrackMessages {
+		if now.Sub(added.addedAt) > addedTrackMessageMaxAge {
+			delete(d.addedTrackMessages, messageID)
+		}
+	}
+
+	for _, messageID := range messageIDs {
+		if messageID != "" {
+			d.addedTrackMessages[messageID] = addedTrackMessage{trackID: trackID, addedAt: now}
+		}
+	}
+}
+
+// lookupAddedTrackMessage returns the track added by the given message, if still undoable.
+func (d *Dispatcher) lookupAddedTrackMessage(messageID string) (string, bool) {
+	d.addedTrackMessagesMutex.Lock()
+	defer d.addedTrackMessagesMutex.Unlock()
+
+	added, ok := d.addedTrackMessages[messageID]
+	if !ok || time.Since(added.addedAt) > addedTrackMessageMaxAge {
+		return "", false
+	}
+	return added.trackID, true
+}
+
+// forgetAddedTrack drops all message references to a track so it can't be undone twice.
+func (d *Dispatcher) forgetAddedTrack(trackID string) {
+	d.addedTrackMessagesMutex.Lock()
+	defer d.addedTrackMessagesMutex.Unlock()
+
+	for messageID, added := range d.addedTrackMessages {
+		if added.trackID == trackID {
+			delete(d.addedTrackMessages, messageID)
+		}
+	}
+}
+
+// replyCommandError replies to a command message with a localized error.
+func (d *Dispatcher) replyCommandError(ctx context.Context, msg *chat.Message, messageKey string, args ...any) {
+	errorMessage := d.formatMessageWithMention(msg, d.localizerFor(msg).T(messageKey, args...))
+	if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, errorMessage); err != nil {
+		d.logger.Error("Failed to reply to command", zap.Error(err))
+	}
+}
+
+// sendLongText sends a reply split between lines into as many messages as the frontend's length limit requires.
+// It returns the ID of the first message.
+func (d *Dispatcher) sendLongText(ctx context.Context, chatID, replyToID, text string) (string, error) {
+	var firstID string
+	for i, chunk := range chat.SplitMessage(text, d.frontend.MaxMessageLength()) {
+		messageID, err := d.frontend.SendText(ctx, chatID, replyToID, chunk)
+		if err != nil {
+			return firstID, err
+		}
+		if i == 0 {
+			firstID = messageID
+		}
+	}
+	return firstID, nil
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+func newAddedTrackTestDispatcher() *Dispatcher {
+	d, _ := newTestDispatcher(nil, nil)
+	return d
+}
+
+func TestAddedTrackMessages_RememberAndLookup(t *testing.T) {
+	d := newAddedTrackTestDispatcher()
+
+	d.rememberAddedTrackMessage("track1", "100", "101", "")
+
+	for _, messageID := range []string{"100", "101"} {
+		trackID, ok := d.lookupAddedTrackMessage(messageID)
+		if !ok || trackID != "track1" {
+			t.Errorf("lookupAddedTrackMessage(%q) = %q, %v; want track1, true", messageID, trackID, ok)
+		}
+	}
+
+	if _, ok := d.lookupAddedTrackMessage(""); ok {
+		t.Error("Empty message IDs should not be remembered")
+	}
+}
+
+func TestAddedTrackMessages_Forget(t *testing.T) {
+	d := newAddedTrackTestDispatcher()
+
+	d.rememberAddedTrackMessage("track1", "100", "101")
+	d.rememberAddedTrackMessage("track2", "200")
+
+	d.forgetAddedTrack("track1")
+
+	if _, ok := d.lookupAddedTrackMessage("100"); ok {
+		t.Error("Forgotten track should not be found by request message")
+	}
+	if _, ok := d.lookupAddedTrackMessage("101"); ok {
+		t.Error("Forgotten track should not be found by reply message")
+	}
+	if trackID, ok := d.lookupAddedTrackMessage("200"); !ok || trackID != "track2" {
+		t.Error("Other tracks should not be forgotten")
+	}
+}
+
+func TestAddedTrackMessages_Expiry(t *testing.T) {
+	d := newAddedTrackTestDispatcher()
+
+	d.addedTrackMessages["100"] = addedTrackMessage{
+		trackID: "track1",
+		addedAt: time.Now().Add(-addedTrackMessageMaxAge - time.Minute),
+	}
+
+	if _, ok := d.lookupAddedTrackMessage("100"); ok {
+		t.Error("Expired messages should not be undoable")
+	}
+
+	// Remembering a new message prunes expired entries
+	d.rememberAddedTrackMessage("track2", "200")
+	if _, exists := d.addedTrackMessages["100"]; exists {
+		t.Error("Expired entries should be pruned")
+	}
+}
+
+func TestFormatQueueDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{0, "0:00"},
+		{59 * time.Second, "0:59"},
+		{3*time.Minute + 5*time.Second, "3:05"},
+		{75*time.Minute + 500*time.Millisecond, "75:01"},
+	}
+
+	for _, tt := range tests {
+		if got := formatQueueDuration(tt.duration); got != tt.want {
+			t.Errorf("formatQueueDuration(%v) = %q, want %q", tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestListShadowQueue_ReturnsCopy(t *testing.T) {
+	d := &Dispatcher{shadowQueue: []ShadowQueueItem{{TrackID: "track1"}, {TrackID: "track2"}}}
+
+	items := d.ListShadowQueue()
+	if len(items) != 2 || items[0].TrackID != "track1" || items[1].TrackID != "track2" {
+		t.Fatalf("ListShadowQueue() = %v, want track1, track2", items)
+	}
+
+	items[0].TrackID = "modified"
+	if d.shadowQueue[0].TrackID != "track1" {
+		t.Error("Modifying the listed items should not change the shadow queue")
+	}
+}
+
+func TestHandleQueueCommand_SplitsLongListings(t *testing.T) {
+	ctx := context.Background()
+	frontend := fake.New()
+	frontend.SetMaxMessageLength(60)
+
+	d := &Dispatcher{
+		config:         DefaultConfig(),
+		frontend:       frontend,
+		localizer:      i18n.NewLocalizer(i18n.DefaultLanguage),
+		logger:         zap.NewNop(),
+		trackInfoCache: make(map[string]*Track),
+	}
+	for i := range 5 {
+		id := string(rune('a' + i))
+		d.trackInfoCache[id] = &Track{ID: id, Title: strings.Repeat("Title ", 3), Artist: "Artist"}
+		d.shadowQueue = append(d.shadowQueue, ShadowQueueItem{TrackID: id})
+	}
+	d.frontend.SetCommandHandler(commandQueue, false, d.handleQueueCommand)
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", Text: "/queue"})
+
+	sent := frontend.SentMessages()
+	if len(sent) < 2 {
+		t.Fatalf("Expected the listing to be split into several messages, got %+v", sent)
+	}
+	for _, msg := range sent {
+		if length := len([]rune(msg.Text)); length > 60 {
+			t.Errorf("Expected messages within the limit of 60, got %d: %q", length, msg.Text)
+		}
+		if msg.ReplyToID != "1" {
+			t.Errorf("Expected every part to reply to the command, got %q", msg.ReplyToID)
+		}
+	}
+}
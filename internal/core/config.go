@@ -11,21 +11,67 @@ import (
 const (
 	DefaultServerPort                         = 8080
 	DefaultTimeoutSeconds                     = 10
+	DefaultServerIdleTimeoutSecs              = 60
 	DefaultConfirmTimeoutSecs                 = 120
 	DefaultConfirmAdminTimeoutSecs            = 3600
+	DefaultConfirmVIPTimeoutSecs              = 600
 	DefaultQueueTrackApprovalTimeoutSecs      = 30
 	DefaultMaxQueueTrackReplacements          = 3
+	DefaultDisambiguationChoices              = 3
 	DefaultQueueAheadDurationSecs             = 90
 	DefaultQueueCheckIntervalSecs             = 45
 	DefaultShadowQueueMaintenanceIntervalSecs = 30
 	DefaultShadowQueueMaxAgeHours             = 2
+	DefaultShadowQueueSaveIntervalSecs        = 60
 	DefaultQueueSyncWarningTimeoutMinutes     = 30
 	DefaultFloodLimitPerMinute                = 6
+	DefaultMaxURLsPerMessage                  = 3
+	DefaultMaxConcurrentRequests              = 4
+	DefaultUserQuotaWindowHours               = 24
+	DefaultMaxRetries                         = 3
+	DefaultMaxRetryWaitSecs                   = 30
+	DefaultFailedAdditionRetries              = 3
+	DefaultEventLogMaxSizeMB                  = 10
+	DefaultBumpCooldownMins                   = 30
+	DefaultSkipCooldownMins                   = 60
+	DefaultMaxAlbumTracks                     = 25
+	DefaultLLMTimeoutSecs                     = 15
+	DefaultLLMCircuitBreakerFailures          = 5
+	DefaultLLMCircuitBreakerCooldownSecs      = 60
+	DefaultTelegramReconnectMaxBackoffSecs    = 60
+	DefaultPhoneticMatchPercent               = 30
+	DefaultRecentlyPlayedHours                = 3
+	DefaultAutoDJWarningPercent               = 80
+)
+
+// Default reactions telling requesters when their song plays.
+const (
+	DefaultAddedReaction  = "👍" // Added to the playlist, plays later
+	DefaultQueuedReaction = "⚡" // Queued, plays next
+)
+
+// Strategies for recommending queue-filling tracks.
+const (
+	// RecommendationStrategyPlaylist picks tracks from playlists found by searching for the mood of recent tracks.
+	RecommendationStrategyPlaylist = "playlist"
+	// RecommendationStrategyAudioFeatures asks Spotify for recommendations seeded by recent tracks and their audio features.
+	RecommendationStrategyAudioFeatures = "audio-features"
+)
+
+// Actions taken when an admin doesn't decide on a queue-filling track before the approval timeout.
+const (
+	// QueueApprovalTimeoutAccept adds the track to the queue and playlist.
+	QueueApprovalTimeoutAccept = "accept"
+	// QueueApprovalTimeoutReject drops the track without suggesting another one.
+	QueueApprovalTimeoutReject = "reject"
+	// QueueApprovalTimeoutReplace drops the track and suggests a replacement, like a denial.
+	QueueApprovalTimeoutReplace = "replace"
 )
 
 // Config represents the main application configuration.
 type Config struct {
 	Telegram TelegramConfig
+	Matrix   MatrixConfig
 	Spotify  SpotifyConfig
 	LLM      LLMConfig
 	Server   ServerConfig
@@ -35,37 +81,84 @@ type Config struct {
 
 // TelegramConfig holds Telegram bot configuration settings.
 type TelegramConfig struct {
-	BotToken           string
+	BotToken                 string
+	GroupID                  int64
+	AdminApproval            bool
+	AdminNeedsApproval       bool
+	CommunityApproval        int
+	CommunityApprovalPercent int
+	ReconnectMaxBackoffSecs  int           // Maximum seconds between reconnect attempts of the update loop
+	WelcomeMessage           bool          // Post an introduction when the bot is added to the group
+	TopicID                  int           // Topic of a forum group to listen and reply in (0 uses the whole group)
+	Groups                   []GroupConfig // Additional groups served by the same bot (empty serves only GroupID)
+}
+
+// GroupConfig holds an additional Telegram group served by the same bot, with a playlist of its own.
+// Settings left unset are shared with the primary group.
+type GroupConfig struct {
 	GroupID            int64
-	AdminApproval      bool
-	AdminNeedsApproval bool
-	CommunityApproval  int
+	PlaylistID         string
+	Language           string // Bot language of the group (empty uses the primary language)
+	AdminApproval      *bool  // Require admin approval in the group (nil uses the primary setting)
+	AdminNeedsApproval *bool  // Require approval of admin requests in the group (nil uses the primary setting)
+	CommunityApproval  *int   // Community votes replacing admin approval in the group (nil uses the primary setting)
+}
+
+// MatrixConfig holds Matrix bot configuration settings.
+// Approval settings are shared with TelegramConfig.
+type MatrixConfig struct {
+	Enabled       bool   // Use Matrix instead of Telegram as chat frontend
+	HomeserverURL string // Base URL of the homeserver
+	AccessToken   string // Access token of the bot account
+	RoomID        string // ID or alias of the room to monitor
 }
 
 // SpotifyConfig holds Spotify API configuration settings.
 type SpotifyConfig struct {
-	ClientID      string
-	ClientSecret  string
-	RedirectURL   string
-	OAuthBindHost string // Host to bind OAuth callback server (defaults to Server.Host)
-	PlaylistID    string
-	TokenPath     string
+	ClientID                      string
+	ClientSecret                  string
+	RedirectURL                   string
+	OAuthBindHost                 string // Host to bind OAuth callback server (defaults to Server.Host)
+	OAuthTLSCertFile              string // Certificate the OAuth callback server serves HTTPS with (defaults to Server.TLSCertFile)
+	OAuthTLSKeyFile               string // Private key of the OAuth callback server certificate
+	OAuthQR                       bool   // Print the authorization URL as a QR code and serve it at /qr of the callback server
+	PlaylistID                    string
+	TokenPath                     string
+	DeviceName                    string   // Preferred playback device, activated when no device is active (empty disables)
+	PreferClean                   bool     // Rank explicit tracks below clean ones in search results
+	Market                        string   // ISO 3166-1 alpha-2 country code for availability (empty uses the user's country)
+	RecommendationStrategy        string   // How queue-filling tracks are found ("playlist" or "audio-features")
+	RecommendationSourcePlaylists []string // Playlists queue-filling tracks are drawn from before searching (empty searches only)
+	FallbackGenre                 string   // Search query seeding queue filling while the playlist is empty (empty waits for requests)
+	RecentlyPlayedHours           int      // Hours in which played tracks aren't picked again when filling the queue (0 disables)
+	PhoneticMatchPercent          int      // Weight in percent of how titles sound when ranking search results (0 disables)
 }
 
 // LLMConfig holds LLM provider configuration settings.
 type LLMConfig struct {
-	Provider string
-	Model    string
-	APIKey   string
-	BaseURL  string
+	Provider                   string
+	Model                      string
+	APIKey                     string
+	BaseURL                    string
+	TimeoutSecs                int // Per-call timeout after which the non-LLM fallback is used
+	CircuitBreakerFailures     int // Consecutive failures after which LLM calls are skipped (0 disables)
+	CircuitBreakerCooldownSecs int // Seconds LLM calls are skipped once the circuit breaker opened
 }
 
 // ServerConfig holds HTTP server configuration settings.
 type ServerConfig struct {
-	Host         string
-	Port         int
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
+	Host             string
+	Port             int
+	ReadTimeout      time.Duration
+	WriteTimeout     time.Duration
+	IdleTimeout      time.Duration // Time keep-alive connections are kept open between requests
+	ShutdownTimeout  time.Duration // Deadline for in-flight requests on shutdown
+	MetricsEnabled   bool          // Expose Prometheus metrics at /metrics
+	AdminToken       string        // Shared secret for the /approvals admin endpoints (empty disables them)
+	DashboardEnabled bool          // Serve the live web dashboard at /
+	TLSCertFile      string        // Certificate to serve HTTPS with (empty serves HTTP)
+	TLSKeyFile       string        // Private key of the TLS certificate
+	HTTPRedirectPort int           // Port redirecting plain HTTP requests to HTTPS (0 disables)
 }
 
 // LogConfig holds logging configuration settings.
@@ -78,15 +171,65 @@ type LogConfig struct {
 type AppConfig struct {
 	ConfirmTimeoutSecs                 int
 	ConfirmAdminTimeoutSecs            int
+	ConfirmVIPTimeoutSecs              int      // Confirmation timeout for VIP users in seconds
+	VIPUserIDs                         []string // User IDs that get the VIP confirmation timeout
+	VIPBypassApproval                  bool     // Add requests of VIP users without admin or community approval
+	DisambiguationButtons              bool     // Let requesters pick among the best search results instead of confirming the best one
+	DisambiguationChoices              int      // Number of search results offered with DisambiguationButtons
+	AutoAcceptThreshold                int      // Search relevance in percent above which a clear top result skips confirmation (0 disables)
+	RequireMembership                  bool     // Only accept requests of group members, administrators and creators
+	MinMembershipMins                  int      // Minutes a member must have been in the group with RequireMembership (0 disables)
 	QueueTrackApprovalTimeoutSecs      int
+	QueueApprovalTimeoutAction         string // What happens to undecided queue tracks ("accept", "reject" or "replace")
 	MaxQueueTrackReplacements          int
-	Language                           string // Bot language for user-facing messages
-	QueueAheadDurationSecs             int    // Target queue duration in seconds
-	QueueCheckIntervalSecs             int    // Queue check interval in seconds
-	ShadowQueueMaintenanceIntervalSecs int    // Shadow queue maintenance interval in seconds
-	ShadowQueueMaxAgeHours             int    // Maximum age of shadow queue items in hours
-	QueueSyncWarningTimeoutMinutes     int    // Timeout for queue sync warning in minutes
-	FloodLimitPerMinute                int    // Maximum messages per user per minute (default: 6)
+	Language                           string            // Bot language for user-facing messages
+	AutoDetectLanguage                 bool              // Reply in the requester's detected language when supported
+	MessagesFile                       string            // JSON or TOML file with custom wording for bot messages (empty disables)
+	MessageOverrides                   map[string]string // Custom wording loaded from MessagesFile, merged over the bundled messages
+	AddedReaction                      string            // Reaction on requests added to the playlist
+	QueuedReaction                     string            // Reaction on requests queued to play next
+	QueueAheadDurationSecs             int               // Target queue duration in seconds
+	QueueCheckIntervalSecs             int               // Queue check interval in seconds
+	ShadowQueueMaintenanceIntervalSecs int               // Shadow queue maintenance interval in seconds
+	ShadowQueueMaxAgeHours             int               // Maximum age of shadow queue items in hours
+	ShadowQueuePath                    string            // Path to persist the shadow queue across restarts (empty disables)
+	ShadowQueueSaveIntervalSecs        int               // Interval in seconds at which the shadow queue is persisted
+	QueueSyncWarningTimeoutMinutes     int               // Timeout for queue sync warning in minutes
+	FloodLimitPerMinute                int               // Maximum messages per user per minute (default: 6)
+	FloodBurst                         int               // Extra messages per user in quick succession on top of the flood limit (default: 0)
+	MaxURLsPerMessage                  int               // Maximum links processed per message (default: 3)
+	MaxConcurrentRequests              int               // Maximum requests processed simultaneously (default: 4, 0 for no limit)
+	MaxRequestsPerUser                 int               // Maximum accepted songs per user and quota window (0 disables)
+	UserQuotaWindowHours               int               // Quota window in hours after which user quotas reset
+	UserQuotaPath                      string            // Path to persist user quotas across restarts (empty disables)
+	EventLogPath                       string            // Path of the added track event log (empty disables)
+	LeaderboardPath                    string            // Path to persist the requester leaderboard across restarts (empty disables)
+	WarningSubscribersPath             string            // Path to persist the users opted in to admin warnings (empty keeps them in memory)
+	WarningSubscribeToken              string            // /start deep-link payload letting non-admins opt in to admin warnings
+	EventLogMaxSizeMB                  int               // Event log size in megabytes after which it is rotated
+	MaxRetries                         int               // Maximum retries for rate-limited API requests
+	MaxRetryWaitSecs                   int               // Longest wait in seconds before retrying a rate-limited request
+	MaxPlaylistSize                    int               // Maximum playlist tracks, the oldest are removed to make room (0 disables)
+	FailedAdditionRetries              int               // Background retries of failed playlist additions before giving up (0 disables)
+	AnnounceNowPlaying                 bool              // Post a "now playing" message to the group on track changes
+	ShowAlbumArt                       bool              // Post approval and now playing messages with the album cover art
+	EnforcePlaybackSettings            bool              // Turn shuffle and repeat off on drift instead of only warning admins
+	BumpVotes                          int               // 👍 reactions needed to bump a duplicate request to play next (0 disables)
+	BumpCooldownMins                   int               // Minutes before the same track can be bumped again
+	BlockExplicit                      bool              // Reject song requests for explicit tracks
+	MinTrackSecs                       int               // Minimum track duration in seconds for requests and queue filling (0 disables)
+	MaxTrackSecs                       int               // Maximum track duration in seconds for requests and queue filling (0 disables)
+	NearDuplicateThresholdPercent      int               // Title similarity in percent for a request to count as near-duplicate (0 disables)
+	TrackCooldownMins                  int               // Minutes before an added track can be requested again (0 disables)
+	SkipCooldownMins                   int               // Minutes before a skipped track is queued again when filling the queue (0 disables)
+	MaxConsecutiveSameArtist           int               // Recent queued/played tracks checked for the same artist on queue fill (0 disables)
+	AutoDJWarningPercent               int               // Auto-DJ share of recently added tracks above which admins are warned (0 disables)
+	MaxAlbumTracks                     int               // Maximum tracks of a shared album that can be added at once (0 disables album links)
+	AllowEpisodes                      bool              // Queue shared Spotify podcast episodes instead of rejecting them
+	RequestSchedule                    *Schedule         // Daily hours during which song requests are accepted (nil accepts them any time)
+	DeleteRequestMessages              bool              // Delete request messages once their song was added (needs delete permission)
+	RequestPrefix                      string            // Prefix marking song requests, e.g. "!play" (empty treats all messages as requests)
+	ShutdownSummary                    bool              // Append the tracks added and the top requester to the shutdown message
 }
 
 // DefaultConfig returns a new Config instance with sensible default values.
@@ -94,20 +237,31 @@ func DefaultConfig() *Config {
 	return &Config{
 		Telegram: TelegramConfig{
 			// Telegram is always required
+			ReconnectMaxBackoffSecs: DefaultTelegramReconnectMaxBackoffSecs,
+			WelcomeMessage:          true,
 		},
 		Spotify: SpotifyConfig{
-			RedirectURL: "", // Will be dynamically generated based on server config
-			TokenPath:   "./spotify_token.json",
+			RedirectURL:            "", // Will be dynamically generated based on server config
+			TokenPath:              "./spotify_token.json",
+			RecommendationStrategy: RecommendationStrategyPlaylist,
+			PhoneticMatchPercent:   DefaultPhoneticMatchPercent,
+			RecentlyPlayedHours:    DefaultRecentlyPlayedHours,
 		},
 		LLM: LLMConfig{
-			Provider: "", // Must be explicitly configured - no default
-			Model:    "",
+			Provider:                   "", // Must be explicitly configured - no default
+			Model:                      "",
+			TimeoutSecs:                DefaultLLMTimeoutSecs,
+			CircuitBreakerFailures:     DefaultLLMCircuitBreakerFailures,
+			CircuitBreakerCooldownSecs: DefaultLLMCircuitBreakerCooldownSecs,
 		},
 		Server: ServerConfig{
-			Host:         "127.0.0.1",
-			Port:         DefaultServerPort,
-			ReadTimeout:  DefaultTimeoutSeconds * time.Second,
-			WriteTimeout: DefaultTimeoutSeconds * time.Second,
+			Host:            "127.0.0.1",
+			Port:            DefaultServerPort,
+			ReadTimeout:     DefaultTimeoutSeconds * time.Second,
+			WriteTimeout:    DefaultTimeoutSeconds * time.Second,
+			IdleTimeout:     DefaultServerIdleTimeoutSecs * time.Second,
+			ShutdownTimeout: DefaultTimeoutSeconds * time.Second,
+			MetricsEnabled:  true,
 		},
 		Log: LogConfig{
 			Level:  "info",
@@ -116,15 +270,33 @@ func DefaultConfig() *Config {
 		App: AppConfig{
 			ConfirmTimeoutSecs:                 DefaultConfirmTimeoutSecs,
 			ConfirmAdminTimeoutSecs:            DefaultConfirmAdminTimeoutSecs,
+			ConfirmVIPTimeoutSecs:              DefaultConfirmVIPTimeoutSecs,
 			QueueTrackApprovalTimeoutSecs:      DefaultQueueTrackApprovalTimeoutSecs,
+			QueueApprovalTimeoutAction:         QueueApprovalTimeoutAccept,
 			MaxQueueTrackReplacements:          DefaultMaxQueueTrackReplacements,
+			DisambiguationChoices:              DefaultDisambiguationChoices,
 			Language:                           i18n.DefaultLanguage, // Default to English
+			AddedReaction:                      DefaultAddedReaction,
+			QueuedReaction:                     DefaultQueuedReaction,
 			QueueAheadDurationSecs:             DefaultQueueAheadDurationSecs,
+			AutoDJWarningPercent:               DefaultAutoDJWarningPercent,
 			QueueCheckIntervalSecs:             DefaultQueueCheckIntervalSecs,
 			ShadowQueueMaintenanceIntervalSecs: DefaultShadowQueueMaintenanceIntervalSecs,
 			ShadowQueueMaxAgeHours:             DefaultShadowQueueMaxAgeHours,
+			ShadowQueueSaveIntervalSecs:        DefaultShadowQueueSaveIntervalSecs,
 			QueueSyncWarningTimeoutMinutes:     DefaultQueueSyncWarningTimeoutMinutes,
 			FloodLimitPerMinute:                DefaultFloodLimitPerMinute,
+			MaxURLsPerMessage:                  DefaultMaxURLsPerMessage,
+			MaxConcurrentRequests:              DefaultMaxConcurrentRequests,
+			UserQuotaWindowHours:               DefaultUserQuotaWindowHours,
+			EventLogMaxSizeMB:                  DefaultEventLogMaxSizeMB,
+			BumpCooldownMins:                   DefaultBumpCooldownMins,
+			SkipCooldownMins:                   DefaultSkipCooldownMins,
+			MaxAlbumTracks:                     DefaultMaxAlbumTracks,
+			MaxRetries:                         DefaultMaxRetries,
+			MaxRetryWaitSecs:                   DefaultMaxRetryWaitSecs,
+			FailedAdditionRetries:              DefaultFailedAdditionRetries,
+			WarningSubscribersPath:             "./warning_subscribers.json",
 		},
 	}
 }
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Dashboard Snapshots
+// This module collects the live state shown on the web dashboard,
+// such as the current track, the upcoming queue and the latest additions.
+// Snapshots are built from the state the dispatcher already tracks, so polling
+// the dashboard doesn't call the Spotify API.
+
+const (
+	// dashboardRecentAdditions is the number of latest additions shown on the dashboard.
+	dashboardRecentAdditions = 10
+)
+
+// DashboardTrack is a track as shown on the web dashboard.
+type DashboardTrack struct {
+	ID      string    `json:"id"`
+	Title   string    `json:"title"`
+	Artist  string    `json:"artist"`
+	Source  string    `json:"source,omitempty"` // Shadow queue source of upcoming tracks
+	AddedAt time.Time `json:"added_at,omitzero"`
+}
+
+// DashboardSnapshot is the live state shown on the web dashboard.
+type DashboardSnapshot struct {
+	CurrentTrack     *DashboardTrack   `json:"current_track"`
+	Queue            []DashboardTrack  `json:"queue"`
+	RecentAdditions  []AdditionRecord  `json:"recent_additions"`
+	PendingApprovals []PendingApproval `json:"pending_approvals"`
+	IngestionPaused  bool              `json:"ingestion_paused"`
+	GeneratedAt      time.Time         `json:"generated_at"`
+}
+
+// DashboardSnapshot returns the current track, shadow queue, latest additions and pending approvals.
+// The keys of pending approvals are left out, as they allow resolving the approvals.
+func (d *Dispatcher) DashboardSnapshot(ctx context.Context) DashboardSnapshot {
+	snapshot := DashboardSnapshot{
+		CurrentTrack:     d.dashboardCurrentTrack(ctx),
+		Queue:            []DashboardTrack{},
+		RecentAdditions:  d.RecentAdditions(dashboardRecentAdditions),
+		PendingApprovals: d.SnapshotApprovals(),
+		IngestionPaused:  d.IsIngestionPaused(),
+		GeneratedAt:      time.Now(),
+	}
+	if snapshot.PendingApprovals == nil {
+		snapshot.PendingApprovals = []PendingApproval{}
+	}
+	for i := range snapshot.PendingApprovals {
+		snapshot.PendingApprovals[i].Key = ""
+	}
+
+	for _, item := range d.ListShadowQueue() {
+		track := d.dashboardTrack(ctx, item.TrackID)
+		track.Source = item.Source
+		track.AddedAt = item.AddedAt
+		snapshot.Queue = append(snapshot.Queue, *track)
+	}
+
+	return snapshot
+}
+
+// dashboardCurrentTrack returns the current track as last seen by the shadow queue maintenance,
+// or nil if nothing is playing.
+func (d *Dispatcher) dashboardCurrentTrack(ctx context.Context) *DashboardTrack {
+	d.shadowQueueMutex.RLock()
+	trackID := d.lastCurrentTrackID
+	d.shadowQueueMutex.RUnlock()
+
+	if trackID == "" {
+		return nil
+	}
+	return d.dashboardTrack(ctx, trackID)
+}
+
+// dashboardTrack resolves a track ID to a dashboard track, falling back to unknown titles.
+func (d *Dispatcher) dashboardTrack(ctx context.Context, trackID string) *DashboardTrack {
+	track, err := d.getCachedTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Debug("Failed to get track info for dashboard",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		track = &Track{ID: trackID, Title: unknownTrack, Artist: unknownArtist}
+	}
+	return &DashboardTrack{ID: track.ID, Title: track.Title, Artist: track.Artist}
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+func TestDashboardSnapshot(t *testing.T) {
+	d := &Dispatcher{
+		lastCurrentTrackID: "current",
+		trackInfoCache: map[string]*Track{
+			"current": {ID: "current", Title: "Bohemian Rhapsody", Artist: "Queen"},
+			"next":    {ID: "next", Title: "Under Pressure", Artist: "Queen"},
+		},
+		shadowQueue:     []ShadowQueueItem{{TrackID: "next", Source: sourcePlaylist}},
+		messageContexts: make(map[string]*MessageContext),
+		logger:          zap.NewNop(),
+	}
+
+	msg := &chat.Message{SenderName: "@alice"}
+	for i := range dashboardRecentAdditions + 2 {
+		d.recordRecentAddition(msg, &Track{ID: fmt.Sprintf("added%d", i), Title: "Song", Artist: "Band"})
+	}
+
+	snapshot := d.DashboardSnapshot(context.Background())
+
+	if snapshot.CurrentTrack == nil || snapshot.CurrentTrack.Title != "Bohemian Rhapsody" {
+		t.Errorf("Expected the current track, got %+v", snapshot.CurrentTrack)
+	}
+	if len(snapshot.Queue) != 1 || snapshot.Queue[0].Title != "Under Pressure" || snapshot.Queue[0].Source != sourcePlaylist {
+		t.Errorf("Expected the shadow queue with track info, got %+v", snapshot.Queue)
+	}
+	if len(snapshot.RecentAdditions) != dashboardRecentAdditions {
+		t.Fatalf("Expected %d recent additions, got %d", dashboardRecentAdditions, len(snapshot.RecentAdditions))
+	}
+	if newest := snapshot.RecentAdditions[0]; newest.TrackID != fmt.Sprintf("added%d", dashboardRecentAdditions+1) ||
+		newest.Requester != "@alice" {
+		t.Errorf("Expected the newest addition first, got %+v", newest)
+	}
+	if snapshot.PendingApprovals == nil {
+		t.Error("Pending approvals should be an empty list rather than nil")
+	}
+
+	d.pendingApprovalMessages = map[string]*queueApprovalContext{"queue_42": {trackID: "next", createdAt: time.Now()}}
+	if pending := d.DashboardSnapshot(context.Background()).PendingApprovals; len(pending) != 1 || pending[0].Key != "" {
+		t.Errorf("Expected the pending approval without its key, got %+v", pending)
+	}
+}
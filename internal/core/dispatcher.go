@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -30,14 +31,22 @@ type MusicLinkTrackInfo struct {
 
 // Dispatcher handles messages from any chat frontend using the unified interface.
 type Dispatcher struct {
-	config       *Config
-	frontend     chat.Frontend
-	spotify      SpotifyClient
-	llm          LLMProvider
-	dedup        DedupStore
-	logger       *zap.Logger
-	localizer    *i18n.Localizer
-	musicLinkMgr MusicLinkResolver // Music link resolver for multi-provider support.
+	config             *Config
+	frontend           chat.Frontend
+	spotify            SpotifyClient
+	llm                LLMProvider
+	dedup              DedupStore
+	quota              UserQuotaStore         // Optional per-user request quota (nil disables)
+	cooldown           TrackCooldownStore     // Optional track re-request cooldown (nil disables)
+	leaderboard        LeaderboardStore       // Optional all-time requester leaderboard (nil disables)
+	warningSubscribers WarningSubscriberStore // Optional users opted in to admin warnings by DM (nil disables /start)
+	metrics            MetricsRecorder
+	eventLog           EventLogger     // Optional added track event log (nil disables)
+	stats              *StatsCollector // Event statistics reported by /stats
+	logger             *zap.Logger
+	localizer          *i18n.Localizer
+	localizers         map[string]*i18n.Localizer // Per-language localizers for replies (nil unless auto-detection is enabled)
+	musicLinkMgr       MusicLinkResolver          // Music link resolver for multi-provider support.
 
 	messageContexts map[string]*MessageContext
 	contextMutex    sync.RWMutex
@@ -58,6 +67,7 @@ type Dispatcher struct {
 	lastShadowQueueModified time.Time // when shadow queue was last modified (addition/removal)
 	lastSuccessfulSync      time.Time // when sync with Spotify queue last succeeded
 	consecutiveSyncRemovals int       // count of consecutive sync operations that removed items
+	recentlyPlayedArtists   []string  // artists of the last played tracks, oldest first, for artist diversity
 
 	// Priority track registry for resume logic
 	priorityTracks      map[string]PriorityTrackInfo // track IDs of priority tracks with resume info
@@ -65,6 +75,60 @@ type Dispatcher struct {
 
 	// Queue management wake-up channel for event-driven queue filling
 	queueManagementWakeup chan struct{} // buffered channel to wake up queue manager when playlist changes
+
+	// Added track messages for /undo (message ID -> added track)
+	addedTrackMessages      map[string]addedTrackMessage
+	addedTrackMessagesMutex sync.Mutex
+
+	// Track info cache for /queue listings (track ID -> track)
+	trackInfoCache      map[string]*Track
+	trackInfoCacheMutex sync.Mutex
+
+	// Last now playing announcement, deleted when the next one is posted
+	nowPlayingMessageID string
+	nowPlayingMutex     sync.Mutex
+
+	// Song request ingestion toggle for /pause and /resume
+	ingestionPaused atomic.Bool
+
+	// Set while the target playlist is deleted or inaccessible, pausing track additions
+	playlistUnavailable atomic.Bool
+
+	// Set once deleting a request message failed, so missing permissions are only warned about once
+	requestDeletionFailed atomic.Bool
+
+	// Set once an empty playlist without fallback genre was reported, so queue checks don't repeat it
+	emptyPlaylistReported atomic.Bool
+
+	// Duplicate request bump votes (track ID -> running vote / last bump time)
+	pendingBumps map[string]struct{}
+	bumpedTracks map[string]time.Time
+	bumpMutex    sync.Mutex
+
+	// Recently skipped tracks kept out of the queue (track ID -> skip time)
+	skippedTracks      map[string]time.Time
+	skippedTracksMutex sync.Mutex
+
+	// Last playback settings correction, only accessed by the playback settings monitor
+	lastPlaybackCorrection time.Time
+
+	// Bounded history of added tracks for /history and the web dashboard
+	history additionHistory
+
+	// Chat member lookups of requesters for the membership check
+	members memberCache
+
+	// Search result choices waiting for their requesters (--disambiguation-buttons)
+	candidatePicks candidatePicks
+
+	// Failed playlist additions retried in the background and by /requeue-failed
+	failedAdditions failedAdditions
+
+	// Sources of the recently added tracks for the auto-DJ takeover warning
+	additionSources additionSources
+
+	// Processing slots bounding simultaneous requests (nil without a limit)
+	requestSlots chan struct{}
 }
 
 // NewDispatcher creates a new dispatcher with the provided chat frontend.
@@ -74,6 +138,12 @@ func NewDispatcher(
 	spotify SpotifyClient,
 	llm LLMProvider,
 	dedup DedupStore,
+	quota UserQuotaStore,
+	cooldown TrackCooldownStore,
+	leaderboard LeaderboardStore,
+	warningSubscribers WarningSubscriberStore,
+	metrics MetricsRecorder,
+	eventLog EventLogger,
 	musicLinkMgr MusicLinkResolver,
 	logger *zap.Logger,
 ) *Dispatcher {
@@ -83,10 +153,17 @@ func NewDispatcher(
 		spotify:                 spotify,
 		llm:                     llm,
 		dedup:                   dedup,
+		quota:                   quota,
+		cooldown:                cooldown,
+		leaderboard:             leaderboard,
+		metrics:                 metrics,
+		eventLog:                eventLog,
+		stats:                   NewStatsCollector(),
 		musicLinkMgr:            musicLinkMgr,
 		logger:                  logger,
-		localizer:               i18n.NewLocalizer(config.App.Language),
-		warningManager:          NewAdminWarningManager(frontend, logger),
+		localizer:               newConfiguredLocalizer(&config.App, logger),
+		warningSubscribers:      warningSubscribers,
+		warningManager:          NewAdminWarningManager(frontend, warningSubscribers, logger),
 		messageContexts:         make(map[string]*MessageContext),
 		pendingApprovalMessages: make(map[string]*queueApprovalContext),
 		queueManagementFlows:    make(map[string]*QueueManagementFlow),
@@ -95,6 +172,21 @@ func NewDispatcher(
 		lastSuccessfulSync:      time.Now(),
 		priorityTracks:          make(map[string]PriorityTrackInfo),
 		queueManagementWakeup:   make(chan struct{}, 1), // Buffer size 1 to coalesce multiple events
+		addedTrackMessages:      make(map[string]addedTrackMessage),
+		trackInfoCache:          make(map[string]*Track),
+		pendingBumps:            make(map[string]struct{}),
+		bumpedTracks:            make(map[string]time.Time),
+		requestSlots:            newRequestSlots(config.App.MaxConcurrentRequests),
+	}
+
+	if d.metrics == nil {
+		d.metrics = noopMetricsRecorder{}
+	}
+
+	if config.App.AutoDetectLanguage {
+		d.localizers = newLanguageLocalizers()
+		// Replies in the configured language keep the custom wording
+		d.localizers[config.App.Language] = d.localizer
 	}
 
 	return d
@@ -114,6 +206,12 @@ func (d *Dispatcher) Start(ctx context.Context) error {
 		d.logger.Warn("Failed to load playlist snapshot", zap.Error(err))
 	}
 
+	// Activate the preferred playback device if no device is active yet
+	d.activatePreferredDevice(ctx)
+
+	// Restore the shadow queue persisted before the last shutdown
+	d.restoreShadowQueue(ctx)
+
 	// Start the chat frontend
 	if err := d.frontend.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start chat frontend: %w", err)
@@ -122,6 +220,15 @@ func (d *Dispatcher) Start(ctx context.Context) error {
 	// Set up queue decision handler
 	d.frontend.SetQueueTrackDecisionHandler(d.handleQueueTrackDecision)
 
+	// Set up chat command handlers
+	d.registerCommandHandlers()
+
+	// Set up inline track search
+	d.registerSearchHandler()
+
+	// Set up requesting tracks again from /history and picking search results
+	d.registerChoiceHandler()
+
 	// Send startup message to the group
 	d.sendStartupMessage(ctx)
 
@@ -134,9 +241,21 @@ func (d *Dispatcher) Start(ctx context.Context) error {
 	// Start admin permissions monitoring
 	go d.runAdminPermissionsMonitoring(ctx)
 
+	// Start playlist health monitoring
+	go d.runPlaylistHealthMonitoring(ctx)
+
+	// Start Spotify authorization monitoring
+	go d.runSpotifyAuthMonitoring(ctx)
+
+	// Start retrying failed playlist additions
+	go d.runFailedAdditionRetries(ctx)
+
 	// Start shadow queue maintenance
 	go d.runShadowQueueMaintenance(ctx)
 
+	// Start shadow queue persistence
+	go d.runShadowQueuePersistence(ctx)
+
 	// Begin listening for messages
 	return d.frontend.Listen(ctx, d.handleMessage)
 }
@@ -148,9 +267,30 @@ func (d *Dispatcher) Stop(ctx context.Context) error {
 	// Send shutdown message to the group
 	d.sendShutdownMessage(ctx)
 
+	// Save the shadow queue so it can be restored on the next start
+	d.persistShadowQueue()
+
 	return nil
 }
 
+// DedupFalsePositiveRate returns the estimated false positive rate of the dedup store's Bloom filter.
+// Returns false if the dedup store doesn't report one.
+func (d *Dispatcher) DedupFalsePositiveRate() (float64, bool) {
+	if estimating, ok := d.dedup.(interface{ FalsePositiveRate() float64 }); ok {
+		return estimating.FalsePositiveRate(), true
+	}
+	return 0, false
+}
+
+// IsChatConnected reports whether the chat frontend can currently receive messages.
+// Frontends that don't track their connection are always considered connected.
+func (d *Dispatcher) IsChatConnected() bool {
+	if connectionAware, ok := d.frontend.(interface{ IsConnected() bool }); ok {
+		return connectionAware.IsConnected()
+	}
+	return true
+}
+
 // handleMessage processes incoming chat messages.
 func (d *Dispatcher) handleMessage(msg *chat.Message) {
 	ctx := context.Background()
@@ -164,6 +304,31 @@ func (d *Dispatcher) handleMessage(msg *chat.Message) {
 	// Convert chat message to internal format
 	inputMsg := d.convertToInputMessage(msg)
 
+	if d.IsIngestionPaused() {
+		go d.rejectPausedRequest(ctx, inputMsg, msg, "error.ingestion_paused")
+		return
+	}
+
+	if d.isPlaylistUnavailable() {
+		go d.rejectPausedRequest(ctx, inputMsg, msg, "error.playlist_unavailable")
+		return
+	}
+
+	if reopensAt, closed := d.requestsClosedUntil(time.Now()); closed {
+		go d.rejectPausedRequest(ctx, inputMsg, msg, "error.outside_request_hours", reopensAt.Format(requestHoursTimeLayout))
+		return
+	}
+
+	if inputMsg.Type == MessageTypeFreeText && len(msg.Requests) > 1 {
+		go d.processMultipleRequests(ctx, inputMsg, msg)
+		return
+	}
+
+	go d.processMessage(ctx, d.registerMessageContext(inputMsg), msg)
+}
+
+// registerMessageContext creates the processing context of a request and tracks it until it is cleaned up.
+func (d *Dispatcher) registerMessageContext(inputMsg InputMessage) *MessageContext {
 	msgCtx := &MessageContext{
 		Input:     inputMsg,
 		State:     StateDispatch,
@@ -172,10 +337,10 @@ func (d *Dispatcher) handleMessage(msg *chat.Message) {
 	}
 
 	d.contextMutex.Lock()
-	d.messageContexts[msg.ID] = msgCtx
+	d.messageContexts[inputMsg.MessageID] = msgCtx
 	d.contextMutex.Unlock()
 
-	go d.processMessage(ctx, msgCtx, msg)
+	return msgCtx
 }
 
 // processMessage handles the main message processing logic.
@@ -188,13 +353,28 @@ func (d *Dispatcher) processMessage(ctx context.Context, msgCtx *MessageContext,
 		zap.String("text", msgCtx.Input.Text),
 	)
 
-	// Add "eyes" reaction to show the message is being processed
+	if d.isMembershipRejected(ctx, &msgCtx.Input, originalMsg) {
+		return
+	}
+
+	releaseSlot, ok := d.acquireRequestSlot(ctx)
+	if !ok {
+		d.rejectBusyRequest(ctx, originalMsg)
+		return
+	}
+	msgCtx.releaseSlot = releaseSlot
+	defer releaseSlot()
+
+	// Show the message is being processed until it is answered
 	d.reactProcessing(ctx, originalMsg)
+	defer d.clearProcessingReaction(ctx, originalMsg)
 
 	switch msgCtx.Input.Type {
 	case MessageTypeSpotifyLink:
+		d.recordRequest()
 		d.handleSpotifyLink(ctx, msgCtx, originalMsg)
 	case MessageTypeNonSpotifyLink:
+		d.recordRequest()
 		d.handleNonSpotifyLink(ctx, msgCtx, originalMsg)
 	case MessageTypeFreeText:
 		// Filter out obvious chatter
@@ -215,6 +395,7 @@ func (d *Dispatcher) processMessage(ctx context.Context, msgCtx *MessageContext,
 			d.reactIgnored(ctx, originalMsg)
 			return
 		}
+		d.recordRequest()
 		d.llmDisambiguate(ctx, msgCtx, originalMsg)
 	}
 }
@@ -233,12 +414,26 @@ func (d *Dispatcher) handleSpotifyLink(ctx context.Context, msgCtx *MessageConte
 	}
 
 	if trackID == "" {
-		d.replyError(ctx, msgCtx, originalMsg, d.localizer.T("error.spotify.extract_track_id"))
+		if albumID := d.extractAlbumID(msgCtx.Input.URLs); albumID != "" {
+			d.handleAlbumLink(ctx, msgCtx, originalMsg, albumID)
+			return
+		}
+		if episodeID := d.extractEpisodeID(msgCtx.Input.URLs); episodeID != "" {
+			d.handleEpisodeLink(ctx, msgCtx, originalMsg, episodeID)
+			return
+		}
+		d.replyError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.spotify.extract_track_id"))
+		return
+	}
+
+	// Unavailable tracks are replaced with playable versions before dedup
+	trackID, ok := d.resolvePlayableTrack(ctx, msgCtx, originalMsg, trackID)
+	if !ok {
 		return
 	}
 
 	if d.dedup.Has(trackID) {
-		d.reactDuplicate(ctx, msgCtx, originalMsg)
+		d.reactDuplicate(ctx, msgCtx, originalMsg, trackID)
 		return
 	}
 
@@ -295,7 +490,7 @@ func (d *Dispatcher) handleNonSpotifyLink(ctx context.Context, msgCtx *MessageCo
 
 	// Check for duplicates.
 	if d.dedup.Has(track.ID) {
-		d.reactDuplicate(ctx, msgCtx, originalMsg)
+		d.reactDuplicate(ctx, msgCtx, originalMsg, track.ID)
 		return
 	}
 
@@ -364,6 +559,15 @@ func (d *Dispatcher) cleanupContext(messageID string) {
 	d.contextMutex.Unlock()
 }
 
+// withLLMTimeout bounds an LLM call by the configured timeout so that a slow provider
+// makes the caller fall back to its non-LLM behavior instead of hanging the request.
+func (d *Dispatcher) withLLMTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.config.LLM.TimeoutSecs <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(d.config.LLM.TimeoutSecs)*time.Second)
+}
+
 // isNotMusicRequest checks if a message is chatter that should be filtered out.
 func (d *Dispatcher) isNotMusicRequest(ctx context.Context, text string) bool {
 	// If no LLM provider is available, always return false (let everything through)
@@ -372,7 +576,9 @@ func (d *Dispatcher) isNotMusicRequest(ctx context.Context, text string) bool {
 	}
 
 	// Use LLM to determine if this is chatter
-	isNotMusicRequest, err := d.llm.IsNotMusicRequest(ctx, text)
+	llmCtx, cancel := d.withLLMTimeout(ctx)
+	defer cancel()
+	isNotMusicRequest, err := d.llm.IsNotMusicRequest(llmCtx, text)
 	if err != nil {
 		d.logger.Warn("LLM chatter detection failed, defaulting to false (letting message through)",
 			zap.Error(err),
@@ -391,7 +597,9 @@ func (d *Dispatcher) isHelpRequest(ctx context.Context, text string) bool {
 	}
 
 	// Use LLM to determine if this is a help request
-	isHelpRequest, err := d.llm.IsHelpRequest(ctx, text)
+	llmCtx, cancel := d.withLLMTimeout(ctx)
+	defer cancel()
+	isHelpRequest, err := d.llm.IsHelpRequest(llmCtx, text)
 	if err != nil {
 		d.logger.Warn("LLM help request detection failed, defaulting to false",
 			zap.Error(err),
@@ -403,7 +611,12 @@ func (d *Dispatcher) isHelpRequest(ctx context.Context, text string) bool {
 }
 
 const (
-	playbackSettingsCheckInterval = 30 * time.Second // Check playback settings every 30 seconds
-	adminPermissionsCheckInterval = 60 * time.Second // Check admin permissions every 60 seconds
-	maxPlaylistTracksToQueue      = 10               // Maximum playlist tracks to queue at once
+	playbackSettingsCheckInterval    = 30 * time.Second // Check playback settings every 30 seconds
+	playbackCorrectionDebounce       = 2 * time.Minute  // Minimum time between two playback settings corrections
+	adminPermissionsCheckInterval    = 60 * time.Second // Check admin permissions every 60 seconds
+	playlistHealthCheckInterval      = 60 * time.Second // Check playlist accessibility every 60 seconds
+	spotifyAuthCheckInterval         = 10 * time.Minute // Validate the Spotify authorization every 10 minutes
+	failedAdditionRetryCheckInterval = 15 * time.Second // Check for due retries of failed playlist additions every 15 seconds
+	maxPlaylistTracksToQueue         = 10               // Maximum playlist tracks to queue at once
+	shutdownMessageTimeout           = 5 * time.Second  // Maximum time to wait for the shutdown message to be sent
 )
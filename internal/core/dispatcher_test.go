@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+// newTestDispatcher creates a dispatcher the way NewDispatcher does, with the configuration (the default one
+// if nil), a fake frontend and the Spotify client. Tests set the other services they use on the dispatcher.
+func newTestDispatcher(config *Config, spotify SpotifyClient) (*Dispatcher, *fake.Frontend) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	frontend := fake.New()
+	d := NewDispatcher(config, frontend, spotify, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+	return d, frontend
+}
+
+// flowTestSpotify resolves track links, recommends the given tracks in order and records playlist additions.
+type flowTestSpotify struct {
+	SpotifyClient
+	mutex           sync.Mutex
+	recommendations []string
+	added           []string
+}
+
+func (s *flowTestSpotify) ExtractTrackID(url string) (string, error) {
+	return url[strings.LastIndex(url, "/")+1:], nil
+}
+
+func (s *flowTestSpotify) GetTrack(_ context.Context, trackID string) (*Track, error) {
+	return &Track{ID: trackID, Title: "Title " + trackID, Artist: "Artist " + trackID, Duration: 3 * time.Minute}, nil
+}
+
+func (s *flowTestSpotify) GetAudioFeatures(_ context.Context, _ string) (*AudioFeatures, error) {
+	return nil, errors.New("no audio features")
+}
+
+func (s *flowTestSpotify) GetRecommendedTrack(_ context.Context, _ string) (trackID, searchQuery,
+	newTrackMood string, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	trackID, s.recommendations = s.recommendations[0], s.recommendations[1:]
+	return trackID, "", "", nil
+}
+
+func (s *flowTestSpotify) AddToPlaylist(_ context.Context, _, trackID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.added = append(s.added, trackID)
+	return nil
+}
+
+func (s *flowTestSpotify) GetPlaylistTracksWithDetails(ctx context.Context, _ string) ([]Track, error) {
+	var tracks []Track
+	for _, trackID := range s.addedTracks() {
+		track, _ := s.GetTrack(ctx, trackID)
+		tracks = append(tracks, *track)
+	}
+	return tracks, nil
+}
+
+func (s *flowTestSpotify) GetCurrentTrackID(_ context.Context) (string, error) {
+	return "", errors.New("nothing playing")
+}
+
+func (s *flowTestSpotify) addedTracks() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Clone(s.added)
+}
+
+// flowTestDedup remembers added tracks in memory and finds no other recordings or versions of them.
+type flowTestDedup struct {
+	failedAdditionTestDedup
+}
+
+func (flowTestDedup) FindByISRC(_ string) string {
+	return ""
+}
+
+func (flowTestDedup) FindNearDuplicate(_, _ string, _ float64) (string, float64) {
+	return "", 0
+}
+
+func (flowTestDedup) AddSignature(_, _, _ string) {}
+
+// newFlowTestDispatcher creates a dispatcher serving group -100 that requires admin approval of requests.
+func newFlowTestDispatcher(recommendations ...string) (*Dispatcher, *flowTestSpotify, *fake.Frontend) {
+	config := DefaultConfig()
+	config.Telegram.GroupID = -100
+	config.Telegram.AdminApproval = true
+
+	spotify := &flowTestSpotify{recommendations: recommendations}
+	d, frontend := newTestDispatcher(config, spotify)
+	d.dedup = flowTestDedup{failedAdditionTestDedup{trackIDs: make(map[string]bool)}}
+	frontend.SetAdmins("1")
+	frontend.SetAdminApprovalEnabled(true)
+	frontend.SetQueueTrackDecisionHandler(d.handleQueueTrackDecision)
+	return d, spotify, frontend
+}
+
+// requestTrack sends a link to the track as a group member and processes it to the end.
+func requestTrack(d *Dispatcher, trackID string) *chat.Message {
+	url := "https://open.spotify.com/track/" + trackID
+	msg := &chat.Message{ID: "10", ChatID: "-100", SenderID: "2", SenderName: "@alice", Text: url, URLs: []string{url}}
+	d.processMessage(context.Background(), d.registerMessageContext(d.convertToInputMessage(msg)), msg)
+	return msg
+}
+
+func TestRequestFlow_AdminApproves(t *testing.T) {
+	d, spotify, frontend := newFlowTestDispatcher()
+	frontend.QueueAdminApprovals(true)
+
+	msg := requestTrack(d, "song")
+	if added := spotify.addedTracks(); !slices.Equal(added, []string{"song"}) {
+		t.Fatalf("Expected the approved track to be added, got %v", added)
+	}
+	if !frontend.HasReacted(msg.ID, thumbsUpReaction) {
+		t.Errorf("Expected the request to be confirmed, got %+v", frontend.Reactions())
+	}
+	if !d.dedup.Has("song") {
+		t.Error("Expected the added track to be remembered as duplicate")
+	}
+}
+
+func TestRequestFlow_AdminDenies(t *testing.T) {
+	d, spotify, frontend := newFlowTestDispatcher()
+	frontend.QueueAdminApprovals(false)
+
+	msg := requestTrack(d, "song")
+	if added := spotify.addedTracks(); len(added) != 0 {
+		t.Fatalf("Expected the denied track not to be added, got %v", added)
+	}
+	if !frontend.HasSent(d.formatMessageWithMention(msg, d.localizer.T("admin.denied"))) {
+		t.Errorf("Expected the requester to be told about the denial, got %+v", frontend.SentMessages())
+	}
+	if !frontend.HasReacted(msg.ID, thumbsDownReaction) {
+		t.Errorf("Expected the request to be marked as denied, got %+v", frontend.Reactions())
+	}
+}
+
+func TestRequestFlow_AdminSkipsApproval(t *testing.T) {
+	d, spotify, frontend := newFlowTestDispatcher()
+	frontend.QueueAdminApprovals(false) // Would deny the request if it was asked for
+
+	url := "https://open.spotify.com/track/song"
+	msg := &chat.Message{ID: "10", ChatID: "-100", SenderID: "1", SenderName: "@admin", Text: url, URLs: []string{url}}
+	d.processMessage(context.Background(), d.registerMessageContext(d.convertToInputMessage(msg)), msg)
+	if added := spotify.addedTracks(); !slices.Equal(added, []string{"song"}) {
+		t.Errorf("Expected the admin's track to be added without approval, got %v (sent %+v)", added,
+			frontend.SentMessages())
+	}
+}
+
+func TestQueueFlow_ApprovedTrackIsAdded(t *testing.T) {
+	ctx := context.Background()
+	d, spotify, frontend := newFlowTestDispatcher("filler")
+
+	d.fillQueueToTargetDuration(ctx, time.Hour, 0)
+	sent, ok := frontend.LastSent()
+	if !ok || sent.TrackID != "filler" {
+		t.Fatalf("Expected an approval message for the queue-filling track, got %+v", frontend.SentMessages())
+	}
+
+	frontend.DecideQueueTrack(ctx, "filler", true)
+	if added := spotify.addedTracks(); !slices.Equal(added, []string{"filler"}) {
+		t.Errorf("Expected the approved queue track to be added, got %v", added)
+	}
+	if len(d.queueManagementFlows) != 0 {
+		t.Errorf("Expected the queue management flow to be completed, got %d flows", len(d.queueManagementFlows))
+	}
+}
+
+func TestQueueFlow_RejectedTrackIsReplaced(t *testing.T) {
+	ctx := context.Background()
+	d, spotify, frontend := newFlowTestDispatcher("filler", "replacement")
+
+	d.fillQueueToTargetDuration(ctx, time.Hour, 0)
+	frontend.DecideQueueTrack(ctx, "filler", false)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if sent, ok := frontend.LastSent(); ok && sent.TrackID == "replacement" {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if sent, _ := frontend.LastSent(); sent.TrackID != "replacement" {
+		t.Fatalf("Expected a replacement track to be suggested, got %+v", frontend.SentMessages())
+	}
+
+	frontend.DecideQueueTrack(ctx, "replacement", true)
+	if added := spotify.addedTracks(); !slices.Equal(added, []string{"replacement"}) {
+		t.Errorf("Expected only the replacement to be added, got %v", added)
+	}
+}
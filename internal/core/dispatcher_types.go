@@ -27,11 +27,12 @@ const (
 
 // ShadowQueueItem represents a track in our shadow queue for reliable queue management.
 type ShadowQueueItem struct {
-	TrackID  string        // Spotify track ID
-	Position int           // Position in logical queue (0 = next)
-	Duration time.Duration // Track duration
-	Source   string        // sourcePlaylist, sourceQueueFill, sourcePriority
-	AddedAt  time.Time     // When we added this item
+	TrackID  string        `json:"track_id"`         // Spotify track ID
+	Artist   string        `json:"artist,omitempty"` // Track artist(s) for artist diversity checks
+	Position int           `json:"position"`         // Position in logical queue (0 = next)
+	Duration time.Duration `json:"duration"`         // Track duration
+	Source   string        `json:"source"`           // sourcePlaylist, sourceQueueFill, sourcePriority
+	AddedAt  time.Time     `json:"added_at"`         // When we added this item
 }
 
 // PriorityTrackInfo stores information about a priority track for resume logic.
@@ -44,10 +45,17 @@ type queueApprovalContext struct {
 	trackID    string
 	chatID     string
 	messageID  string
+	createdAt  time.Time
 	expiresAt  time.Time
 	cancelFunc context.CancelFunc
 }
 
+// addedTrackMessage links a chat message to the track that was added because of it.
+type addedTrackMessage struct {
+	trackID string
+	addedAt time.Time
+}
+
 // QueueManagementFlow represents a single queue management flow with its own rejection tracking.
 type QueueManagementFlow struct {
 	FlowID         string            // Unique identifier for this flow
@@ -0,0 +1,220 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Duplicate Request Bumping
+// This module lets the group vote to bump a track that is already in the playlist
+// so it plays next, instead of silently rejecting the duplicate request
+
+// errBumpTrackQueued is returned when a track to bump was queued in the meantime, so it plays soon anyway.
+var errBumpTrackQueued = errors.New("track is already queued")
+
+// tryStartBumpVote reserves a bump vote for the track if bumping is enabled, the track is not
+// queued already, no vote is running for it and it was not bumped within the cooldown.
+func (d *Dispatcher) tryStartBumpVote(trackID string) bool {
+	if d.config.App.BumpVotes <= 0 || d.GetShadowQueuePosition(trackID) >= 0 {
+		return false
+	}
+
+	d.bumpMutex.Lock()
+	defer d.bumpMutex.Unlock()
+
+	if _, pending := d.pendingBumps[trackID]; pending {
+		return false
+	}
+
+	cooldown := time.Duration(d.config.App.BumpCooldownMins) * time.Minute
+	if bumpedAt, exists := d.bumpedTracks[trackID]; exists && time.Since(bumpedAt) < cooldown {
+		return false
+	}
+
+	d.pendingBumps[trackID] = struct{}{}
+	return true
+}
+
+// finishBumpVote releases the track's bump vote and starts its cooldown if it was bumped.
+func (d *Dispatcher) finishBumpVote(trackID string, bumped bool) {
+	d.bumpMutex.Lock()
+	defer d.bumpMutex.Unlock()
+
+	delete(d.pendingBumps, trackID)
+	if bumped {
+		d.bumpedTracks[trackID] = time.Now()
+	}
+
+	// Drop expired cooldowns so the map doesn't grow forever
+	cooldown := time.Duration(d.config.App.BumpCooldownMins) * time.Minute
+	for id, bumpedAt := range d.bumpedTracks {
+		if time.Since(bumpedAt) >= cooldown {
+			delete(d.bumpedTracks, id)
+		}
+	}
+}
+
+// runBumpVote posts a bump vote for a duplicate request and bumps the track if enough users react.
+func (d *Dispatcher) runBumpVote(ctx context.Context, originalMsg *chat.Message, trackID string) {
+	bumped := false
+	defer func() {
+		d.finishBumpVote(trackID, bumped)
+	}()
+
+	votes := d.config.App.BumpVotes
+	voteMessage := d.formatMessageWithMention(originalMsg, d.localizerFor(originalMsg).T("success.duplicate_bump", votes))
+	voteMsgID, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, voteMessage)
+	if err != nil {
+		d.logger.Error("Failed to send bump vote message", zap.Error(err))
+		return
+	}
+
+	approved, err := d.frontend.AwaitCommunityApproval(ctx, voteMsgID, votes, d.config.App.ConfirmTimeoutSecs,
+		d.parseRequesterUserID(originalMsg.SenderID))
+	if err != nil {
+		d.logger.Warn("Bump vote failed", zap.String("trackID", trackID), zap.Error(err))
+		return
+	}
+
+	if !approved {
+		d.logger.Debug("Bump vote did not reach enough votes", zap.String("trackID", trackID))
+		return
+	}
+
+	err = d.bumpTrack(ctx, trackID)
+	if errors.Is(err, errBumpTrackQueued) {
+		// Queued during the vote, so it plays soon anyway
+		d.replyDuplicate(ctx, originalMsg, trackID)
+		return
+	}
+	if err != nil {
+		d.logger.Error("Failed to bump track", zap.String("trackID", trackID), zap.Error(err))
+		d.replyCommandError(ctx, originalMsg, "error.playlist.add_failed")
+		return
+	}
+	bumped = true
+
+	track, err := d.spotify.GetTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Warn("Failed to get bumped track info", zap.Error(err))
+		track = &Track{ID: trackID, Title: unknownTrack, Artist: unknownArtist}
+	}
+
+	bumpedMessage := d.formatMessageWithMention(originalMsg,
+		d.localizerFor(originalMsg).T("success.track_bumped", track.Artist, track.Title, track.URL))
+	if _, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, bumpedMessage); err != nil {
+		d.logger.Error("Failed to send bumped message", zap.Error(err))
+	}
+}
+
+// bumpTrack moves the track right after the currently playing track in the playlist
+// and wakes up the queue manager so it gets queued next. The track is added at its new position
+// before its old entries are removed, so a failure never drops it from the playlist.
+// Returns errBumpTrackQueued if the track is in the queue already.
+func (d *Dispatcher) bumpTrack(ctx context.Context, trackID string) error {
+	if d.GetShadowQueuePosition(trackID) >= 0 {
+		return errBumpTrackQueued
+	}
+
+	playlistID := d.config.Spotify.PlaylistID
+
+	tracks, err := d.spotify.GetPlaylistTracksWithDetails(ctx, playlistID)
+	if err != nil {
+		return err
+	}
+
+	currentTrackID, err := d.spotify.GetCurrentTrackID(ctx)
+	if err != nil {
+		currentTrackID = "" // Nothing playing, bump to the top of the playlist
+	}
+
+	position := bumpPosition(tracks, currentTrackID)
+	if position < len(tracks) && tracks[position].ID == trackID {
+		d.logger.Debug("Bumped track already plays next", zap.String("trackID", trackID))
+		return nil
+	}
+
+	if err := d.spotify.AddToPlaylistAtPosition(ctx, playlistID, trackID, position); err != nil {
+		return err
+	}
+
+	if err := d.removeBumpedTrackEntries(ctx, playlistID, trackID, position); err != nil {
+		return err
+	}
+
+	d.logger.Info("Bumped track to play next",
+		zap.String("trackID", trackID),
+		zap.Int("position", position))
+
+	select {
+	case d.queueManagementWakeup <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// removeBumpedTrackEntries removes the old entries of a bumped track that was just added at position.
+// If they can't be removed, the new entry is removed again to restore the playlist.
+func (d *Dispatcher) removeBumpedTrackEntries(ctx context.Context, playlistID, trackID string, position int) error {
+	tracks, err := d.spotify.GetPlaylistTracksWithDetails(ctx, playlistID)
+	if err != nil {
+		// Without the playlist the entries can't be told apart, the track is just listed twice
+		return fmt.Errorf("failed to get playlist after adding bumped track: %w", err)
+	}
+
+	var oldPositions []int
+	for i := range tracks {
+		if tracks[i].ID == trackID && i != position {
+			oldPositions = append(oldPositions, i)
+		}
+	}
+
+	if position >= len(tracks) || tracks[position].ID != trackID {
+		// The track was added, but not moved to its new position, so it is the last entry
+		if len(oldPositions) > 0 {
+			d.rollbackBump(ctx, playlistID, trackID, oldPositions[len(oldPositions)-1])
+		}
+		return errors.New("bumped track was not added at its new position")
+	}
+
+	if len(oldPositions) == 0 {
+		return nil
+	}
+
+	if err := d.spotify.RemoveFromPlaylistAtPositions(ctx, playlistID, trackID, oldPositions); err != nil {
+		d.rollbackBump(ctx, playlistID, trackID, position)
+		return err
+	}
+	return nil
+}
+
+// rollbackBump removes the entry a failed bump added to the playlist.
+func (d *Dispatcher) rollbackBump(ctx context.Context, playlistID, trackID string, position int) {
+	if err := d.spotify.RemoveFromPlaylistAtPositions(ctx, playlistID, trackID, []int{position}); err != nil {
+		d.logger.Warn("Failed to roll back bump, track is listed twice",
+			zap.String("trackID", trackID),
+			zap.Int("position", position),
+			zap.Error(err))
+	}
+}
+
+// bumpPosition returns the playlist position right after the current track,
+// or 0 if the current track is not in the playlist.
+func bumpPosition(tracks []Track, currentTrackID string) int {
+	if currentTrackID == "" {
+		return 0
+	}
+	for i := range tracks {
+		if tracks[i].ID == currentTrackID {
+			return i + 1
+		}
+	}
+	return 0
+}
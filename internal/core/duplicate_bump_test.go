@@ -0,0 +1,175 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// bumpTestSpotify keeps the playlist in memory and fails the configured playlist changes.
+type bumpTestSpotify struct {
+	playlistSizeTestSpotify
+	addErr         error
+	failedRemovals int // Number of removals to fail
+}
+
+func (s *bumpTestSpotify) AddToPlaylistAtPosition(_ context.Context, _, trackID string, position int) error {
+	if s.addErr != nil {
+		return s.addErr
+	}
+	s.playlist = slices.Insert(s.playlist, position, trackID)
+	return nil
+}
+
+func (s *bumpTestSpotify) RemoveFromPlaylistAtPositions(_ context.Context, _, trackID string, positions []int) error {
+	if s.failedRemovals > 0 {
+		s.failedRemovals--
+		return errors.New("remove failed")
+	}
+	for _, position := range slices.Backward(slices.Sorted(slices.Values(positions))) {
+		if s.playlist[position] != trackID {
+			return errors.New("track not at position")
+		}
+		s.playlist = slices.Delete(s.playlist, position, position+1)
+	}
+	return nil
+}
+
+func newTestBumpDispatcher(votes, cooldownMins int) *Dispatcher {
+	config := DefaultConfig()
+	config.App.BumpVotes = votes
+	config.App.BumpCooldownMins = cooldownMins
+	d, _ := newTestDispatcher(config, nil)
+	return d
+}
+
+func TestTryStartBumpVote(t *testing.T) {
+	if newTestBumpDispatcher(0, 30).tryStartBumpVote("track1") {
+		t.Error("Bump votes should be disabled when no votes are configured")
+	}
+
+	d := newTestBumpDispatcher(2, 30)
+	if !d.tryStartBumpVote("track1") {
+		t.Fatal("First bump vote should start")
+	}
+	if d.tryStartBumpVote("track1") {
+		t.Error("Only one bump vote per track should run at a time")
+	}
+
+	d.finishBumpVote("track1", false)
+	if !d.tryStartBumpVote("track1") {
+		t.Fatal("A failed vote should not start the cooldown")
+	}
+
+	d.finishBumpVote("track1", true)
+	if d.tryStartBumpVote("track1") {
+		t.Error("A bumped track should not be bumped again within the cooldown")
+	}
+	if !d.tryStartBumpVote("track2") {
+		t.Error("The cooldown should not affect other tracks")
+	}
+}
+
+func TestTryStartBumpVote_CooldownExpired(t *testing.T) {
+	d := newTestBumpDispatcher(2, 30)
+	d.bumpedTracks["track1"] = time.Now().Add(-31 * time.Minute)
+
+	if !d.tryStartBumpVote("track1") {
+		t.Error("Bump vote should start once the cooldown expired")
+	}
+}
+
+func TestBumpPosition(t *testing.T) {
+	tracks := []Track{{ID: "a"}, {ID: "bump"}, {ID: "b"}, {ID: "current"}, {ID: "c"}, {ID: "bump"}}
+
+	tests := []struct {
+		name           string
+		currentTrackID string
+		expected       int
+	}{
+		{"After current track", "current", 4},
+		{"Current track first", "a", 1},
+		{"Current track not in playlist", "other", 0},
+		{"Nothing playing", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bumpPosition(tracks, tt.currentTrackID); got != tt.expected {
+				t.Errorf("bumpPosition() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func newBumpTrackTestDispatcher(playlist ...string) (*Dispatcher, *bumpTestSpotify) {
+	spotify := &bumpTestSpotify{playlistSizeTestSpotify: playlistSizeTestSpotify{
+		playlist:       playlist,
+		currentTrackID: "current",
+	}}
+	d, _ := newTestDispatcher(nil, spotify)
+	return d, spotify
+}
+
+func TestBumpTrack(t *testing.T) {
+	ctx := context.Background()
+
+	d, spotify := newBumpTrackTestDispatcher("a", "current", "b", "bump", "c")
+	if err := d.bumpTrack(ctx, "bump"); err != nil {
+		t.Fatalf("bumpTrack failed: %v", err)
+	}
+	if want := []string{"a", "current", "bump", "b", "c"}; !slices.Equal(spotify.playlist, want) {
+		t.Errorf("Expected the track to be moved after the current track, got %v", spotify.playlist)
+	}
+
+	if err := d.bumpTrack(ctx, "bump"); err != nil || len(spotify.playlist) != 5 {
+		t.Errorf("Expected bumping a track that plays next to change nothing, got %v, %v", err, spotify.playlist)
+	}
+}
+
+func TestBumpTrack_KeepsTrackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	playlist := []string{"a", "current", "b", "bump", "c"}
+
+	d, spotify := newBumpTrackTestDispatcher(slices.Clone(playlist)...)
+	spotify.addErr = errors.New("add failed")
+	if err := d.bumpTrack(ctx, "bump"); err == nil || !slices.Equal(spotify.playlist, playlist) {
+		t.Errorf("Expected a failed addition to leave the playlist unchanged, got %v, %v", err, spotify.playlist)
+	}
+
+	d, spotify = newBumpTrackTestDispatcher(slices.Clone(playlist)...)
+	spotify.failedRemovals = 1
+	if err := d.bumpTrack(ctx, "bump"); err == nil || !slices.Equal(spotify.playlist, playlist) {
+		t.Errorf("Expected a failed removal to roll back the addition, got %v, %v", err, spotify.playlist)
+	}
+
+	d, spotify = newBumpTrackTestDispatcher(slices.Clone(playlist)...)
+	spotify.failedRemovals = 2
+	if err := d.bumpTrack(ctx, "bump"); err == nil {
+		t.Fatal("Expected a failed removal to fail the bump")
+	}
+	if want := []string{"a", "current", "bump", "b", "bump", "c"}; !slices.Equal(spotify.playlist, want) {
+		t.Errorf("Expected the track to stay in the playlist when the rollback fails, got %v", spotify.playlist)
+	}
+}
+
+func TestBumpTrack_SkipsQueuedTrack(t *testing.T) {
+	playlist := []string{"a", "current", "b", "bump", "c"}
+	d, spotify := newBumpTrackTestDispatcher(slices.Clone(playlist)...)
+	d.shadowQueue = []ShadowQueueItem{{TrackID: "bump"}}
+
+	if err := d.bumpTrack(context.Background(), "bump"); !errors.Is(err, errBumpTrackQueued) {
+		t.Errorf("Expected a queued track not to be bumped, got %v", err)
+	}
+	if !slices.Equal(spotify.playlist, playlist) {
+		t.Errorf("Expected the playlist to be unchanged, got %v", spotify.playlist)
+	}
+
+	d.config.App.BumpVotes = 2
+	d.pendingBumps = make(map[string]struct{})
+	if d.tryStartBumpVote("bump") {
+		t.Error("Expected no bump vote for a queued track")
+	}
+}
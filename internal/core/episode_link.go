@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Episode Links
+// This module queues shared Spotify podcast episodes when --allow-episodes is set.
+// Episodes can't be added to the playlist, so they go straight into the playback queue
+
+// extractEpisodeID returns the first Spotify episode ID found in the message URLs, or an empty string.
+func (d *Dispatcher) extractEpisodeID(urls []string) string {
+	for _, url := range urls {
+		if episodeID, err := d.spotify.ExtractEpisodeID(url); err == nil && episodeID != "" {
+			return episodeID
+		}
+	}
+	return ""
+}
+
+// handleEpisodeLink queues a shared episode, or rejects it if episodes are not allowed.
+func (d *Dispatcher) handleEpisodeLink(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message,
+	episodeID string) {
+	localizer := d.localizerFor(originalMsg)
+
+	if !d.config.App.AllowEpisodes {
+		d.recordRequestRejected(rejectReasonEpisode)
+		d.replyError(ctx, msgCtx, originalMsg, localizer.T("error.episode.not_allowed"))
+		return
+	}
+
+	episode, err := d.spotify.GetEpisode(ctx, episodeID)
+	if err != nil {
+		d.logger.Error("Failed to get episode",
+			zap.String("episodeID", episodeID),
+			zap.Error(err))
+		d.replyError(ctx, msgCtx, originalMsg, localizer.T("error.episode.not_found"))
+		return
+	}
+
+	if d.config.App.BlockExplicit && episode.Explicit {
+		d.recordRequestRejected(rejectReasonExplicit)
+		d.replyError(ctx, msgCtx, originalMsg, localizer.T("error.track.explicit"))
+		return
+	}
+
+	// Episodes bypass the per-track approval workflows, so they are only accepted where no approval is needed
+	isAdmin := d.isUserAdmin(ctx, originalMsg)
+	if d.isAdminApprovalRequired() && (!isAdmin || d.isAdminNeedsApproval()) {
+		d.replyError(ctx, msgCtx, originalMsg, localizer.T("error.episode.approval_required"))
+		return
+	}
+
+	if !isAdmin && d.isUserQuotaExceeded(ctx, msgCtx, originalMsg) {
+		return
+	}
+
+	if err := d.spotify.AddEpisodeToQueue(ctx, episode.ID); err != nil {
+		d.logger.Error("Failed to queue episode",
+			zap.String("episodeID", episode.ID),
+			zap.Error(err))
+		d.replyError(ctx, msgCtx, originalMsg, localizer.T("error.episode.queue_failed"))
+		return
+	}
+
+	d.logger.Info("Episode queued",
+		zap.String("episodeID", episode.ID),
+		zap.String("show", episode.Show),
+		zap.String("userID", originalMsg.SenderID))
+
+	msgCtx.State = StateReactAdded
+	d.recordUserQuota(originalMsg)
+	d.recordLeaderboard(originalMsg)
+	d.recordCrowdAddition(ctx)
+
+	if err := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, d.addedReaction(true)); err != nil {
+		d.logger.Debug("Failed to react to queued episode", zap.Error(err))
+	}
+
+	queuedMessage := d.formatMessageWithMention(originalMsg, localizer.T("success.episode_queued",
+		episode.Show, episode.Title, formatQueueDuration(episode.Duration)))
+	if _, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, queuedMessage); err != nil {
+		d.logger.Error("Failed to send episode queued message", zap.Error(err))
+	}
+
+	d.scheduleRequestMessageDeletion(originalMsg)
+}
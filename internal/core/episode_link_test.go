@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+// episodeTestSpotify resolves episode links and records queued episodes.
+type episodeTestSpotify struct {
+	SpotifyClient
+	episode  *Episode
+	queued   []string
+	queueErr error
+}
+
+func (s *episodeTestSpotify) ExtractTrackID(_ string) (string, error) {
+	return "", errors.New("no track ID found in URL")
+}
+
+func (s *episodeTestSpotify) ExtractAlbumID(_ string) (string, error) {
+	return "", errors.New("no album ID found in URL")
+}
+
+func (s *episodeTestSpotify) ExtractEpisodeID(url string) (string, error) {
+	episodeID, found := strings.CutPrefix(url, "https://open.spotify.com/episode/")
+	if !found {
+		return "", errors.New("no episode ID found in URL")
+	}
+	return episodeID, nil
+}
+
+func (s *episodeTestSpotify) GetEpisode(_ context.Context, _ string) (*Episode, error) {
+	return s.episode, nil
+}
+
+func (s *episodeTestSpotify) AddEpisodeToQueue(_ context.Context, episodeID string) error {
+	if s.queueErr != nil {
+		return s.queueErr
+	}
+	s.queued = append(s.queued, episodeID)
+	return nil
+}
+
+func newEpisodeTestDispatcher(allowEpisodes bool) (*Dispatcher, *episodeTestSpotify, *fake.Frontend) {
+	config := DefaultConfig()
+	config.App.AllowEpisodes = allowEpisodes
+
+	spotify := &episodeTestSpotify{episode: &Episode{
+		ID: "episode1", Title: "Pilot", Show: "The Show", Duration: 42 * time.Minute,
+	}}
+	d, frontend := newTestDispatcher(config, spotify)
+	return d, spotify, frontend
+}
+
+func episodeRequest() (*MessageContext, *chat.Message) {
+	msg := &chat.Message{
+		ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice",
+		URLs: []string{"https://open.spotify.com/episode/episode1"},
+	}
+	return &MessageContext{Input: InputMessage{URLs: msg.URLs}}, msg
+}
+
+func TestHandleEpisodeLink_RejectedWhenNotAllowed(t *testing.T) {
+	d, spotify, frontend := newEpisodeTestDispatcher(false)
+	msgCtx, msg := episodeRequest()
+
+	d.handleSpotifyLink(context.Background(), msgCtx, msg)
+
+	if len(spotify.queued) != 0 {
+		t.Errorf("Expected no episode to be queued, got %v", spotify.queued)
+	}
+	if !frontend.HasSent(d.formatMessageWithMention(msg, d.localizer.T("error.episode.not_allowed"))) {
+		t.Errorf("Expected the episode to be rejected, got %+v", frontend.SentMessages())
+	}
+}
+
+func TestHandleEpisodeLink_QueuedWhenAllowed(t *testing.T) {
+	d, spotify, frontend := newEpisodeTestDispatcher(true)
+	msgCtx, msg := episodeRequest()
+
+	d.handleSpotifyLink(context.Background(), msgCtx, msg)
+
+	if len(spotify.queued) != 1 || spotify.queued[0] != "episode1" {
+		t.Fatalf("Expected the episode to be queued, got %v", spotify.queued)
+	}
+	if !frontend.HasReacted(msg.ID, chat.Reaction(DefaultQueuedReaction)) ||
+		!frontend.HasSent(d.formatMessageWithMention(msg, d.localizer.T("success.episode_queued", "The Show", "Pilot", "42:00"))) {
+		t.Errorf("Expected the queued episode to be confirmed, got %+v", frontend.SentMessages())
+	}
+}
+
+func TestHandleEpisodeLink_QueueFailure(t *testing.T) {
+	d, spotify, frontend := newEpisodeTestDispatcher(true)
+	spotify.queueErr = errors.New("no active device")
+	msgCtx, msg := episodeRequest()
+
+	d.handleSpotifyLink(context.Background(), msgCtx, msg)
+
+	if !frontend.HasSent(d.formatMessageWithMention(msg, d.localizer.T("error.episode.queue_failed"))) {
+		t.Errorf("Expected the queue failure to be reported, got %+v", frontend.SentMessages())
+	}
+}
+
+func TestProcessMessage_ClearsProcessingReaction(t *testing.T) {
+	d, _, frontend := newEpisodeTestDispatcher(false)
+	msgCtx, msg := episodeRequest()
+	msgCtx.Input.Type = MessageTypeSpotifyLink
+
+	d.processMessage(context.Background(), msgCtx, msg)
+
+	if !frontend.HasReacted(msg.ID, chat.ReactionProcessing) {
+		t.Error("Expected the request to be marked as processing")
+	}
+	removed := frontend.RemovedReactions()
+	if len(removed) != 1 || removed[0].MessageID != msg.ID || removed[0].Reaction != chat.ReactionProcessing {
+		t.Errorf("Expected the processing reaction to be removed once answered, got %+v", removed)
+	}
+}
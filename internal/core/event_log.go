@@ -0,0 +1,45 @@
+package core
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Added Track Event Log
+// This module records every track added on a user's request for post-event analytics
+
+// approvalSourceNone marks tracks that were added without requiring approval.
+const approvalSourceNone = "none"
+
+// logTrackAddedEvent appends an added track event to the event log if one is configured.
+func (d *Dispatcher) logTrackAddedEvent(msgCtx *MessageContext, originalMsg *chat.Message, track *Track) {
+	if d.eventLog == nil {
+		return
+	}
+
+	approvalSource := msgCtx.ApprovalSource
+	if approvalSource == "" {
+		approvalSource = approvalSourceNone
+	}
+
+	event := TrackAddedEvent{
+		Timestamp:      time.Now().UTC(),
+		PlaylistID:     d.config.Spotify.PlaylistID,
+		TrackID:        track.ID,
+		Title:          track.Title,
+		Artist:         track.Artist,
+		SenderID:       originalMsg.SenderID,
+		SenderName:     originalMsg.SenderName,
+		ApprovalSource: approvalSource,
+		Priority:       msgCtx.IsPriority,
+	}
+
+	if err := d.eventLog.LogTrackAdded(event); err != nil {
+		d.logger.Warn("Failed to write added track event",
+			zap.String("trackID", track.ID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Explicit Content Filtering
+// This module rejects song requests for explicit tracks at family-friendly events
+
+// isExplicitTrackBlocked checks whether the track is explicit and blocked, and notifies the requester if so.
+func (d *Dispatcher) isExplicitTrackBlocked(ctx context.Context, msgCtx *MessageContext,
+	originalMsg *chat.Message, trackID string) bool {
+	if !d.config.App.BlockExplicit {
+		return false
+	}
+
+	track, err := d.spotify.GetTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Warn("Failed to get track for explicit check, allowing request",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		return false
+	}
+
+	if !d.isExplicitBlocked(track) {
+		return false
+	}
+
+	d.logger.Info("Rejected explicit track",
+		zap.String("trackID", trackID),
+		zap.String("userID", originalMsg.SenderID),
+		zap.String("userName", originalMsg.SenderName))
+
+	d.recordRequestRejected(rejectReasonExplicit)
+
+	if err := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, thumbsDownReaction); err != nil {
+		d.logger.Debug("Failed to add explicit track reaction", zap.Error(err))
+	}
+
+	d.reactError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.track.explicit"))
+	return true
+}
+
+// isExplicitBlocked reports whether the track is explicit and explicit tracks are blocked.
+func (d *Dispatcher) isExplicitBlocked(track *Track) bool {
+	return d.config.App.BlockExplicit && track.Explicit
+}
@@ -0,0 +1,211 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Failed Additions
+// This module remembers song requests whose playlist addition failed, retries them
+// in the background with exponential backoff, and lets admins retry them with /requeue-failed
+
+const (
+	commandRequeueFailed = "requeue-failed"
+	// failedAdditionsMaxEntries bounds the failed additions kept for retrying, dropping the oldest.
+	failedAdditionsMaxEntries = 20
+	// failedAdditionRetryBackoff is the delay before the first background retry, doubled after every failed retry.
+	failedAdditionRetryBackoff = 30 * time.Second
+)
+
+// failedAddition is a song request whose playlist addition failed.
+type failedAddition struct {
+	trackID     string
+	originalMsg *chat.Message // Request message, replied to with the retry result
+	err         string        // Error of the last attempt
+	failedAt    time.Time
+	retries     int       // Background retries so far
+	nextRetryAt time.Time // When the next background retry is due
+}
+
+// failedAdditions is a bounded list of failed additions, safe for concurrent use.
+type failedAdditions struct {
+	mutex   sync.Mutex
+	entries []failedAddition
+}
+
+// add stores a failed addition, dropping the oldest one once the list is full.
+func (f *failedAdditions) add(entry failedAddition) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.entries = append(f.entries, entry)
+	if len(f.entries) > failedAdditionsMaxEntries {
+		f.entries = f.entries[len(f.entries)-failedAdditionsMaxEntries:]
+	}
+}
+
+// takeDue removes and returns the failed additions with less than maxRetries retries whose next retry is due.
+func (f *failedAdditions) takeDue(now time.Time, maxRetries int) []failedAddition {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var due []failedAddition
+	remaining := f.entries[:0]
+	for _, entry := range f.entries {
+		if entry.retries < maxRetries && !entry.nextRetryAt.After(now) {
+			due = append(due, entry)
+		} else {
+			remaining = append(remaining, entry)
+		}
+	}
+	f.entries = remaining
+	return due
+}
+
+// takeAll removes and returns all failed additions.
+func (f *failedAdditions) takeAll() []failedAddition {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	entries := f.entries
+	f.entries = nil
+	return entries
+}
+
+// failedAdditionRetryDelay returns the backoff before the next background retry after the given number of retries.
+func failedAdditionRetryDelay(retries int) time.Duration {
+	return failedAdditionRetryBackoff << retries
+}
+
+// recordFailedAddition remembers a failed playlist addition so it can be retried.
+func (d *Dispatcher) recordFailedAddition(originalMsg *chat.Message, trackID string, err error) {
+	now := time.Now()
+	d.failedAdditions.add(failedAddition{
+		trackID:     trackID,
+		originalMsg: originalMsg,
+		err:         err.Error(),
+		failedAt:    now,
+		nextRetryAt: now.Add(failedAdditionRetryDelay(0)),
+	})
+}
+
+// retryFailedAddition adds a failed track to the playlist again and confirms it to the requester on success.
+func (d *Dispatcher) retryFailedAddition(ctx context.Context, entry failedAddition) error {
+	// Someone else may have added the track in the meantime
+	if !d.dedup.Has(entry.trackID) {
+		if err := d.addToPlaylistAndWakeQueueManager(ctx, entry.trackID); err != nil {
+			return err
+		}
+	}
+
+	d.logger.Info("Retried failed playlist addition",
+		zap.String("trackID", entry.trackID),
+		zap.String("userName", entry.originalMsg.SenderName),
+		zap.Duration("sinceFailure", time.Since(entry.failedAt)))
+
+	d.reactAdded(ctx, &MessageContext{}, entry.originalMsg, entry.trackID)
+	return nil
+}
+
+// runFailedAdditionRetries retries failed playlist additions in the background until they succeed or give up.
+func (d *Dispatcher) runFailedAdditionRetries(ctx context.Context) {
+	if d.config.App.FailedAdditionRetries <= 0 {
+		return
+	}
+
+	d.logger.Info("Starting failed addition retries",
+		zap.Int("maxRetries", d.config.App.FailedAdditionRetries))
+
+	ticker := time.NewTicker(failedAdditionRetryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Failed addition retries stopped")
+			return
+		case <-ticker.C:
+			d.retryDueFailedAdditions(ctx)
+		}
+	}
+}
+
+// retryDueFailedAdditions retries the failed additions whose backoff has passed.
+func (d *Dispatcher) retryDueFailedAdditions(ctx context.Context) {
+	if d.isPlaylistUnavailable() {
+		return
+	}
+
+	maxRetries := d.config.App.FailedAdditionRetries
+	for _, entry := range d.failedAdditions.takeDue(time.Now(), maxRetries) {
+		err := d.retryFailedAddition(ctx, entry)
+		if err == nil {
+			continue
+		}
+
+		entry.retries++
+		entry.err = err.Error()
+		entry.nextRetryAt = time.Now().Add(failedAdditionRetryDelay(entry.retries))
+		d.failedAdditions.add(entry)
+
+		d.logger.Warn("Retrying failed playlist addition failed",
+			zap.String("trackID", entry.trackID),
+			zap.Int("retries", entry.retries),
+			zap.Error(err))
+
+		if entry.retries >= maxRetries {
+			d.notifyFailedAdditionGaveUp(ctx, entry)
+		}
+	}
+}
+
+// notifyFailedAdditionGaveUp tells the requester that their track couldn't be added after all background retries.
+func (d *Dispatcher) notifyFailedAdditionGaveUp(ctx context.Context, entry failedAddition) {
+	message := d.formatMessageWithMention(entry.originalMsg,
+		d.localizerFor(entry.originalMsg).T("error.playlist.add_gave_up", entry.retries))
+	if _, err := d.frontend.SendText(ctx, entry.originalMsg.ChatID, entry.originalMsg.ID, message); err != nil {
+		d.logger.Error("Failed to send failed addition notice", zap.Error(err))
+	}
+}
+
+// handleRequeueFailedCommand retries all failed playlist additions and reports how many were added.
+func (d *Dispatcher) handleRequeueFailedCommand(ctx context.Context, msg *chat.Message) {
+	localizer := d.localizerFor(msg)
+
+	entries := d.failedAdditions.takeAll()
+	if len(entries) == 0 {
+		if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, localizer.T("bot.requeue_failed_empty")); err != nil {
+			d.logger.Error("Failed to reply to requeue command", zap.Error(err))
+		}
+		return
+	}
+
+	d.logger.Info("Requeue failed command received",
+		zap.Int("failedAdditions", len(entries)),
+		zap.String("userID", msg.SenderID),
+		zap.String("userName", msg.SenderName))
+
+	added := 0
+	for _, entry := range entries {
+		if err := d.retryFailedAddition(ctx, entry); err != nil {
+			d.logger.Warn("Requeueing failed playlist addition failed",
+				zap.String("trackID", entry.trackID),
+				zap.Error(err))
+			entry.err = err.Error()
+			entry.nextRetryAt = time.Now().Add(failedAdditionRetryDelay(entry.retries))
+			d.failedAdditions.add(entry)
+			continue
+		}
+		added++
+	}
+
+	reply := localizer.T("bot.requeue_failed_result", len(entries), added, len(entries)-added)
+	if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, reply); err != nil {
+		d.logger.Error("Failed to reply to requeue command", zap.Error(err))
+	}
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+// failedAdditionTestSpotify fails the given number of playlist additions before succeeding.
+type failedAdditionTestSpotify struct {
+	SpotifyClient
+	failures int
+	added    []string
+}
+
+func (s *failedAdditionTestSpotify) AddToPlaylist(_ context.Context, _, trackID string) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("spotify unavailable")
+	}
+	s.added = append(s.added, trackID)
+	return nil
+}
+
+func (s *failedAdditionTestSpotify) GetTrack(_ context.Context, trackID string) (*Track, error) {
+	return &Track{ID: trackID, Title: "Title", Artist: "Artist"}, nil
+}
+
+func (s *failedAdditionTestSpotify) GetPlaylistTracksWithDetails(_ context.Context, _ string) ([]Track, error) {
+	return nil, errors.New("spotify unavailable")
+}
+
+// failedAdditionTestDedup remembers added tracks in memory.
+type failedAdditionTestDedup struct {
+	DedupStore
+	trackIDs map[string]bool
+}
+
+func (s failedAdditionTestDedup) Has(trackID string) bool {
+	return s.trackIDs[trackID]
+}
+
+func (s failedAdditionTestDedup) Add(trackID string) {
+	s.trackIDs[trackID] = true
+}
+
+func (s failedAdditionTestDedup) AddISRC(_, _ string) {}
+
+func newFailedAdditionTestDispatcher(failures int) (*Dispatcher, *failedAdditionTestSpotify, *fake.Frontend) {
+	spotify := &failedAdditionTestSpotify{failures: failures}
+	d, frontend := newTestDispatcher(nil, spotify)
+	d.dedup = failedAdditionTestDedup{trackIDs: make(map[string]bool)}
+	return d, spotify, frontend
+}
+
+func TestFailedAdditions_Bounded(t *testing.T) {
+	var failed failedAdditions
+	for i := range failedAdditionsMaxEntries + 5 {
+		failed.add(failedAddition{trackID: string(rune('a' + i))})
+	}
+
+	entries := failed.takeAll()
+	if len(entries) != failedAdditionsMaxEntries {
+		t.Fatalf("Expected %d failed additions to be kept, got %d", failedAdditionsMaxEntries, len(entries))
+	}
+	if entries[0].trackID != string(rune('a'+5)) {
+		t.Errorf("Expected the oldest failed additions to be dropped, got %q first", entries[0].trackID)
+	}
+	if len(failed.takeAll()) != 0 {
+		t.Error("Expected takeAll to empty the list")
+	}
+}
+
+func TestRetryDueFailedAdditions(t *testing.T) {
+	ctx := context.Background()
+	msg := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice"}
+
+	d, spotify, frontend := newFailedAdditionTestDispatcher(2)
+	d.config.App.FailedAdditionRetries = 2
+	d.recordFailedAddition(msg, "song", errors.New("spotify unavailable"))
+
+	d.retryDueFailedAdditions(ctx)
+	if len(spotify.added) != 0 {
+		t.Fatal("Expected no retry before the backoff passed")
+	}
+
+	for range d.config.App.FailedAdditionRetries {
+		d.failedAdditions.entries[0].nextRetryAt = time.Time{}
+		d.retryDueFailedAdditions(ctx)
+	}
+	gaveUp := d.localizer.T("error.playlist.add_gave_up", d.config.App.FailedAdditionRetries)
+	if sent, _ := frontend.LastSent(); !strings.Contains(sent.Text, gaveUp) {
+		t.Errorf("Expected the requester to be told that the retries gave up, got %q", sent.Text)
+	}
+
+	d.failedAdditions.entries[0].nextRetryAt = time.Time{}
+	d.retryDueFailedAdditions(ctx)
+	if len(spotify.added) != 0 {
+		t.Error("Expected no background retry after giving up")
+	}
+}
+
+func TestRetryDueFailedAdditions_Succeeds(t *testing.T) {
+	ctx := context.Background()
+	msg := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice"}
+
+	d, spotify, frontend := newFailedAdditionTestDispatcher(0)
+	d.recordFailedAddition(msg, "song", errors.New("spotify unavailable"))
+	d.failedAdditions.entries[0].nextRetryAt = time.Time{}
+
+	d.retryDueFailedAdditions(ctx)
+	if len(spotify.added) != 1 || spotify.added[0] != "song" {
+		t.Fatalf("Expected the failed track to be added, got %v", spotify.added)
+	}
+	if !frontend.HasReacted(msg.ID, thumbsUpReaction) {
+		t.Error("Expected the request to be confirmed")
+	}
+	if len(d.failedAdditions.takeAll()) != 0 {
+		t.Error("Expected the added track to be removed from the failed additions")
+	}
+}
+
+func TestHandleRequeueFailedCommand(t *testing.T) {
+	ctx := context.Background()
+	d, spotify, frontend := newFailedAdditionTestDispatcher(1)
+	d.frontend.SetCommandHandler(commandRequeueFailed, true, d.handleRequeueFailedCommand)
+	frontend.SetAdmins("1")
+	admin := &chat.Message{ID: "10", ChatID: "-100", SenderID: "1", Text: "/requeue-failed"}
+
+	frontend.RunCommand(ctx, admin)
+	if !frontend.HasSentKey("bot.requeue_failed_empty") {
+		t.Error("Expected the empty message without failed additions")
+	}
+
+	d.recordFailedAddition(&chat.Message{ID: "1", ChatID: "-100", SenderID: "2"}, "first",
+		errors.New("spotify unavailable"))
+	d.recordFailedAddition(&chat.Message{ID: "2", ChatID: "-100", SenderID: "3"}, "second",
+		errors.New("spotify unavailable"))
+
+	frontend.RunCommand(ctx, admin)
+	if !frontend.HasSent(d.localizer.T("bot.requeue_failed_result", 2, 1, 1)) {
+		t.Error("Expected the retry results to be reported")
+	}
+	if len(spotify.added) != 1 || spotify.added[0] != "second" {
+		t.Errorf("Expected the second track to be added, got %v", spotify.added)
+	}
+
+	remaining := d.failedAdditions.takeAll()
+	if len(remaining) != 1 || remaining[0].trackID != "first" {
+		t.Errorf("Expected the still failing track to be kept, got %v", remaining)
+	}
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Request History
+// This module keeps a bounded history of added tracks for /history and the web dashboard,
+// and lets users pick a track from the history to request it again
+
+const (
+	commandHistory = "history"
+	// historyMaxEntries is the capacity of the addition history ring buffer.
+	historyMaxEntries = 50
+	// historyListEntries is the number of latest additions listed by /history.
+	historyListEntries = 10
+	// historyChoicePrefix prefixes the choice IDs of /history entries.
+	historyChoicePrefix = "history:"
+	// spotifyTrackURLPrefix builds a track link when Spotify returned none.
+	spotifyTrackURLPrefix = "https://open.spotify.com/track/"
+)
+
+// AdditionRecord is a track added to the playlist, as kept in the addition history.
+type AdditionRecord struct {
+	TrackID   string    `json:"id"`
+	Title     string    `json:"title"`
+	Artist    string    `json:"artist"`
+	URL       string    `json:"url"`
+	Requester string    `json:"requester"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// additionHistory is a bounded ring buffer of the latest additions, safe for concurrent use.
+type additionHistory struct {
+	mutex   sync.Mutex
+	entries []AdditionRecord // Allocated with historyMaxEntries slots on first add
+	next    int              // Slot the next record is written to
+	count   int
+}
+
+// add stores a record, overwriting the oldest one once the buffer is full.
+func (h *additionHistory) add(record AdditionRecord) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.entries == nil {
+		h.entries = make([]AdditionRecord, historyMaxEntries)
+	}
+	h.entries[h.next] = record
+	h.next = (h.next + 1) % len(h.entries)
+	h.count = min(h.count+1, len(h.entries))
+}
+
+// latest returns up to n records, newest first.
+func (h *additionHistory) latest(n int) []AdditionRecord {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	n = min(max(n, 0), h.count)
+	records := make([]AdditionRecord, 0, n)
+	for i := 1; i <= n; i++ {
+		records = append(records, h.entries[(h.next-i+len(h.entries))%len(h.entries)])
+	}
+	return records
+}
+
+// RecentAdditions returns up to n of the latest added tracks, newest first.
+func (d *Dispatcher) RecentAdditions(n int) []AdditionRecord {
+	return d.history.latest(n)
+}
+
+// recordRecentAddition remembers an added track and its requester in the addition history.
+func (d *Dispatcher) recordRecentAddition(originalMsg *chat.Message, track *Track) {
+	url := track.URL
+	if url == "" {
+		url = spotifyTrackURLPrefix + track.ID
+	}
+
+	d.history.add(AdditionRecord{
+		TrackID:   track.ID,
+		Title:     track.Title,
+		Artist:    track.Artist,
+		URL:       url,
+		Requester: originalMsg.SenderName,
+		AddedAt:   time.Now(),
+	})
+}
+
+// findRecentAddition returns the latest history record of a track, if it is still in the history.
+func (d *Dispatcher) findRecentAddition(trackID string) (AdditionRecord, bool) {
+	for _, record := range d.RecentAdditions(historyMaxEntries) {
+		if record.TrackID == trackID {
+			return record, true
+		}
+	}
+	return AdditionRecord{}, false
+}
+
+// registerChoiceHandler lets users request tracks again by picking them from /history,
+// and requesters pick among search results.
+func (d *Dispatcher) registerChoiceHandler() {
+	d.frontend.SetChoiceHandler(func(ctx context.Context, msg *chat.Message, choiceID string) {
+		if strings.HasPrefix(choiceID, candidateChoicePrefix) {
+			d.handleCandidateChoice(ctx, msg, choiceID)
+			return
+		}
+		d.handleHistoryChoice(ctx, msg, choiceID)
+	})
+}
+
+// handleHistoryCommand lists the latest additions with their requesters, each selectable to request it again.
+func (d *Dispatcher) handleHistoryCommand(ctx context.Context, msg *chat.Message) {
+	records := d.RecentAdditions(historyListEntries)
+	if len(records) == 0 {
+		if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, d.localizer.T("bot.history_empty")); err != nil {
+			d.logger.Error("Failed to send history listing", zap.Error(err))
+		}
+		return
+	}
+
+	var builder strings.Builder
+	builder.WriteString(d.localizer.T("bot.history_header"))
+	choices := make([]chat.Choice, 0, len(records))
+	for i := range records {
+		builder.WriteString("\n")
+		builder.WriteString(d.localizer.T("bot.history_item", i+1, records[i].Artist, records[i].Title, records[i].Requester))
+		choices = append(choices, chat.Choice{
+			ID:    historyChoicePrefix + records[i].TrackID,
+			Label: d.localizer.T("button.history_request", i+1, records[i].Artist, records[i].Title),
+		})
+	}
+
+	// Long listings are sent as several messages, the choices are attached to the last one
+	chunks := chat.SplitMessage(builder.String(), d.frontend.MaxMessageLength())
+	for _, chunk := range chunks[:len(chunks)-1] {
+		if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, chunk); err != nil {
+			d.logger.Error("Failed to send history listing", zap.Error(err))
+			return
+		}
+	}
+	if _, err := d.frontend.SendChoices(ctx, msg.ChatID, msg.ID, chunks[len(chunks)-1], choices); err != nil {
+		d.logger.Error("Failed to send history listing", zap.Error(err))
+	}
+}
+
+// handleHistoryChoice requests a track picked from /history again. The request is announced
+// and then handled like a posted link, so pausing, dedup, cooldown and quota rules all apply.
+func (d *Dispatcher) handleHistoryChoice(ctx context.Context, msg *chat.Message, choiceID string) {
+	trackID, ok := strings.CutPrefix(choiceID, historyChoicePrefix)
+	if !ok {
+		return
+	}
+
+	record, found := d.findRecentAddition(trackID)
+	if !found {
+		d.logger.Debug("Ignoring pick of a track no longer in the history", zap.String("trackID", trackID))
+		return
+	}
+
+	d.logger.Info("Track requested again from history",
+		zap.String("trackID", trackID),
+		zap.String("userID", msg.SenderID),
+		zap.String("userName", msg.SenderName))
+
+	announcement := d.formatMessageWithMention(msg, d.localizerFor(msg).T("bot.history_requested", record.Artist, record.Title))
+	requestID, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, announcement)
+	if err != nil {
+		d.logger.Error("Failed to announce history request", zap.Error(err))
+		return
+	}
+
+	// Replies and reactions for the request go to the announcement, which stands in for the user's message
+	d.handleMessage(&chat.Message{
+		ID:         requestID,
+		ChatID:     msg.ChatID,
+		SenderID:   msg.SenderID,
+		SenderName: msg.SenderName,
+		Text:       record.URL,
+		URLs:       []string{record.URL},
+		IsGroup:    msg.IsGroup,
+		Language:   msg.Language,
+	})
+}
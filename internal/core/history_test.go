@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+func TestRecentAdditions_BoundedNewestFirst(t *testing.T) {
+	d := &Dispatcher{}
+	msg := &chat.Message{SenderName: "@alice"}
+
+	var wg sync.WaitGroup
+	for i := range historyMaxEntries + 5 {
+		wg.Go(func() {
+			d.recordRecentAddition(msg, &Track{ID: fmt.Sprintf("concurrent%d", i)})
+		})
+	}
+	wg.Wait()
+
+	if records := d.RecentAdditions(historyMaxEntries * 2); len(records) != historyMaxEntries {
+		t.Fatalf("Expected the history to be bounded to %d entries, got %d", historyMaxEntries, len(records))
+	}
+
+	d.recordRecentAddition(msg, &Track{ID: "older", URL: "https://open.spotify.com/track/older"})
+	d.recordRecentAddition(msg, &Track{ID: "newest"})
+
+	records := d.RecentAdditions(2)
+	if len(records) != 2 || records[0].TrackID != "newest" || records[1].TrackID != "older" {
+		t.Fatalf("Expected the newest additions first, got %+v", records)
+	}
+	if records[0].URL != spotifyTrackURLPrefix+"newest" || records[0].Requester != "@alice" {
+		t.Errorf("Expected a fallback track URL and the requester, got %+v", records[0])
+	}
+	if len(d.RecentAdditions(0)) != 0 {
+		t.Error("Expected no records when asking for none")
+	}
+}
+
+func TestHandleHistoryCommand(t *testing.T) {
+	ctx := context.Background()
+	frontend := fake.New()
+
+	d := &Dispatcher{
+		config:          DefaultConfig(),
+		frontend:        frontend,
+		localizer:       i18n.NewLocalizer(i18n.DefaultLanguage),
+		messageContexts: make(map[string]*MessageContext),
+		metrics:         noopMetricsRecorder{},
+		logger:          zap.NewNop(),
+	}
+	d.frontend.SetCommandHandler(commandHistory, false, d.handleHistoryCommand)
+	d.registerChoiceHandler()
+
+	request := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@bob", Text: "/history"}
+	frontend.RunCommand(ctx, request)
+	if !frontend.HasSentKey("bot.history_empty") {
+		t.Errorf("Expected an empty history, got %+v", frontend.SentMessages())
+	}
+
+	d.recordRecentAddition(&chat.Message{SenderName: "@alice"}, &Track{ID: "track1", Title: "Song", Artist: "Band"})
+	frontend.Reset()
+	frontend.RunCommand(ctx, request)
+
+	listing, ok := frontend.LastSent()
+	if !ok || len(listing.Choices) != 1 || listing.Choices[0].ID != historyChoicePrefix+"track1" {
+		t.Fatalf("Expected a history listing with one choice, got %+v", listing)
+	}
+	if want := d.localizer.T("bot.history_item", 1, "Band", "Song", "@alice"); !frontend.HasSent(
+		d.localizer.T("bot.history_header") + "\n" + want) {
+		t.Errorf("Expected the addition with its requester, got %q", listing.Text)
+	}
+
+	// Picks are handled like a posted link, so they are rejected while requests are paused
+	d.ingestionPaused.Store(true)
+	picker := &chat.Message{ID: listing.ID, ChatID: "-100", SenderID: "3", SenderName: "@carol"}
+	frontend.PickChoice(ctx, picker, historyChoicePrefix+"unknown")
+	frontend.PickChoice(ctx, picker, listing.Choices[0].ID)
+
+	announcement, ok := frontend.LastSent()
+	if !ok || announcement.ReplyToID != listing.ID ||
+		announcement.Text != d.formatMessageWithMention(picker, d.localizer.T("bot.history_requested", "Band", "Song")) {
+		t.Fatalf("Expected the re-request to be announced once, got %+v", frontend.SentMessages())
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !frontend.HasReacted(announcement.ID, chat.ReactionPaused) {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the re-request to be rejected while requests are paused")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHandleHistoryChoice_RepliesInPickerLanguage(t *testing.T) {
+	ctx := context.Background()
+	frontend := fake.New()
+
+	d := &Dispatcher{
+		config:          DefaultConfig(),
+		frontend:        frontend,
+		localizer:       i18n.NewLocalizer(i18n.DefaultLanguage),
+		localizers:      newLanguageLocalizers(),
+		messageContexts: make(map[string]*MessageContext),
+		metrics:         noopMetricsRecorder{},
+		logger:          zap.NewNop(),
+	}
+	d.registerChoiceHandler()
+	d.ingestionPaused.Store(true)
+	d.recordRecentAddition(&chat.Message{SenderName: "@alice"}, &Track{ID: "track1", Title: "Song", Artist: "Band"})
+
+	picker := &chat.Message{ID: "1", ChatID: "-100", SenderID: "3", SenderName: "@carol", Language: "de"}
+	frontend.PickChoice(ctx, picker, historyChoicePrefix+"track1")
+
+	german := i18n.NewLocalizer(i18n.BerneseGermanMessages)
+	if !frontend.HasSent(d.formatMessageWithMention(picker, german.T("bot.history_requested", "Band", "Song"))) {
+		t.Errorf("Expected the re-request to be announced in the picker's language, got %+v", frontend.SentMessages())
+	}
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"context"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Inline Track Search
+// This module answers inline search queries so users can pick a track directly
+// instead of having their message disambiguated
+
+// registerSearchHandler registers the inline track search with the chat frontend.
+func (d *Dispatcher) registerSearchHandler() {
+	d.frontend.SetSearchHandler(d.handleInlineSearch)
+}
+
+// handleInlineSearch searches Spotify for the query and returns the matching tracks.
+func (d *Dispatcher) handleInlineSearch(ctx context.Context, query string) ([]chat.SearchResult, error) {
+	tracks, err := d.spotify.SearchTrack(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]chat.SearchResult, 0, len(tracks))
+	for i := range tracks {
+		results = append(results, chat.SearchResult{
+			ID:     tracks[i].ID,
+			Title:  tracks[i].Title,
+			Artist: tracks[i].Artist,
+			URL:    tracks[i].URL,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// ISRC Deduplication
+// This module catches requests for a recording that is already in the playlist under
+// a different Spotify track ID, e.g. the same song released on a single and a compilation
+
+// isRecordingDuplicate checks whether a track with the same ISRC is already in the playlist and,
+// if so, rejects the request as a duplicate. Tracks without an ISRC only use ID-based dedup.
+func (d *Dispatcher) isRecordingDuplicate(ctx context.Context, msgCtx *MessageContext,
+	originalMsg *chat.Message, trackID string) bool {
+	track, err := d.getCachedTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Debug("Failed to get track for ISRC duplicate check, allowing request",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		return false
+	}
+
+	existingID := d.dedup.FindByISRC(track.ISRC)
+	if existingID == "" || existingID == trackID {
+		return false
+	}
+
+	d.logger.Info("Detected duplicate recording with a different track ID",
+		zap.String("trackID", trackID),
+		zap.String("existingTrackID", existingID),
+		zap.String("isrc", track.ISRC))
+
+	d.reactDuplicate(ctx, msgCtx, originalMsg, existingID)
+	return true
+}
+
+// recordTrackISRC stores the track's ISRC so other releases of the same recording are detected.
+func (d *Dispatcher) recordTrackISRC(ctx context.Context, trackID string) {
+	track, err := d.getCachedTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Debug("Failed to get track for ISRC dedup",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		return
+	}
+
+	d.dedup.AddISRC(trackID, track.ISRC)
+}
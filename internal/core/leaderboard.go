@@ -0,0 +1,60 @@
+package core
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Requester Leaderboard
+// This module counts accepted requests per user across sessions for the /leaderboard command,
+// so recurring events can show their top contributors of all time
+
+const (
+	commandLeaderboard = "leaderboard"
+	// leaderboardTopEntries is the number of top contributors listed by /leaderboard.
+	leaderboardTopEntries = 10
+)
+
+// recordLeaderboard counts an accepted request for the sender on the leaderboard.
+func (d *Dispatcher) recordLeaderboard(originalMsg *chat.Message) {
+	if d.leaderboard == nil {
+		return
+	}
+
+	if err := d.leaderboard.Record(originalMsg.SenderID, originalMsg.SenderName); err != nil {
+		d.logger.Warn("Failed to record leaderboard entry",
+			zap.String("userID", originalMsg.SenderID),
+			zap.Error(err))
+	}
+}
+
+// handleLeaderboardCommand replies with the users who requested the most songs of all time.
+func (d *Dispatcher) handleLeaderboardCommand(ctx context.Context, msg *chat.Message) {
+	localizer := d.localizerFor(msg)
+
+	var entries []StatsEntry
+	if d.leaderboard != nil {
+		entries = d.leaderboard.Top(leaderboardTopEntries)
+	}
+
+	var reply string
+	if len(entries) == 0 {
+		reply = localizer.T("bot.leaderboard_empty")
+	} else {
+		var builder strings.Builder
+		builder.WriteString(localizer.T("bot.leaderboard_header"))
+		for i, entry := range entries {
+			builder.WriteString("\n")
+			builder.WriteString(localizer.T("bot.stats_top_entry", i+1, entry.Name, entry.Count))
+		}
+		reply = builder.String()
+	}
+
+	if _, err := d.sendLongText(ctx, msg.ChatID, msg.ID, reply); err != nil {
+		d.logger.Error("Failed to send leaderboard", zap.Error(err))
+	}
+}
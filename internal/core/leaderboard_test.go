@@ -0,0 +1,62 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+// fakeLeaderboard counts requests per user name in memory.
+type fakeLeaderboard struct {
+	counts map[string]int
+}
+
+func (l *fakeLeaderboard) Record(_, userName string) error {
+	l.counts[userName]++
+	return nil
+}
+
+func (l *fakeLeaderboard) Top(limit int) []StatsEntry {
+	return topStatsEntries(l.counts, limit)
+}
+
+func TestHandleLeaderboardCommand(t *testing.T) {
+	ctx := context.Background()
+	frontend := fake.New()
+	leaderboard := &fakeLeaderboard{counts: make(map[string]int)}
+
+	d := &Dispatcher{
+		config:      DefaultConfig(),
+		frontend:    frontend,
+		localizer:   i18n.NewLocalizer(i18n.DefaultLanguage),
+		leaderboard: leaderboard,
+		logger:      zap.NewNop(),
+	}
+	d.frontend.SetCommandHandler(commandLeaderboard, false, d.handleLeaderboardCommand)
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", Text: "/leaderboard"})
+	if !frontend.HasSentKey("bot.leaderboard_empty") {
+		t.Error("Expected the empty leaderboard message")
+	}
+
+	d.recordLeaderboard(&chat.Message{SenderID: "2", SenderName: "alice"})
+	d.recordLeaderboard(&chat.Message{SenderID: "3", SenderName: "bob"})
+	d.recordLeaderboard(&chat.Message{SenderID: "2", SenderName: "alice"})
+	if leaderboard.counts["alice"] != 2 || leaderboard.counts["bob"] != 1 {
+		t.Errorf("Expected accepted requests to be counted, got %v", leaderboard.counts)
+	}
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "2", ChatID: "-100", SenderID: "2", Text: "/leaderboard"})
+	localizer := d.localizer
+	expected := localizer.T("bot.leaderboard_header") + "\n" +
+		localizer.T("bot.stats_top_entry", 1, "alice", 2) + "\n" +
+		localizer.T("bot.stats_top_entry", 2, "bob", 1)
+	if !frontend.HasSent(expected) {
+		t.Errorf("Expected the leaderboard %q to be listed", expected)
+	}
+}
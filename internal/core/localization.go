@@ -0,0 +1,50 @@
+package core
+
+import (
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/i18n"
+)
+
+// Per-Message Localization
+// This module picks the language of replies to a request from the language detected
+// by the chat frontend, while group-wide announcements keep the configured language
+
+// newConfiguredLocalizer creates the localizer for the configured language with the custom wording
+// of the messages file. Overrides are validated at startup, so invalid ones are only logged here.
+func newConfiguredLocalizer(app *AppConfig, logger *zap.Logger) *i18n.Localizer {
+	localizer := i18n.NewLocalizer(app.Language)
+	if err := localizer.LoadOverrides(app.MessageOverrides); err != nil {
+		logger.Warn("Ignoring invalid message overrides", zap.Error(err))
+	}
+	return localizer
+}
+
+// newLanguageLocalizers creates a localizer for every supported language.
+func newLanguageLocalizers() map[string]*i18n.Localizer {
+	languages := i18n.GetSupportedLanguages()
+	localizers := make(map[string]*i18n.Localizer, len(languages))
+	for _, language := range languages {
+		localizers[language] = i18n.NewLocalizer(language)
+	}
+	return localizers
+}
+
+// localizerFor returns the localizer for replies to a message. It falls back to the configured
+// language if auto-detection is disabled or the detected language is not supported.
+func (d *Dispatcher) localizerFor(msg *chat.Message) *i18n.Localizer {
+	if d.localizers == nil || msg == nil {
+		return d.localizer
+	}
+
+	language, ok := i18n.MatchLanguage(msg.Language)
+	if !ok {
+		return d.localizer
+	}
+
+	if localizer, exists := d.localizers[language]; exists {
+		return localizer
+	}
+	return d.localizer
+}
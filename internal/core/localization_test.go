@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/i18n"
+)
+
+func TestLocalizerFor(t *testing.T) {
+	english := i18n.NewLocalizer(i18n.DefaultLanguage).T("error.generic")
+	bernese := i18n.NewLocalizer(i18n.BerneseGermanMessages).T("error.generic")
+
+	tests := []struct {
+		name       string
+		autoDetect bool
+		language   string
+		expected   string
+	}{
+		{"Disabled keeps configured language", false, "de", english},
+		{"Detected German", true, "de", bernese},
+		{"Detected English", true, "en", english},
+		{"Unknown language falls back", true, "", english},
+		{"Unsupported language falls back", true, "fr", english},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dispatcher{localizer: i18n.NewLocalizer(i18n.DefaultLanguage)}
+			if tt.autoDetect {
+				d.localizers = newLanguageLocalizers()
+			}
+
+			got := d.localizerFor(&chat.Message{Language: tt.language}).T("error.generic")
+			if got != tt.expected {
+				t.Errorf("localizerFor(%q) replied %q, want %q", tt.language, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewDispatcher_MessageOverrides(t *testing.T) {
+	config := DefaultConfig()
+	config.App.AutoDetectLanguage = true
+	config.App.MessageOverrides = map[string]string{"error.generic": "Oops!"}
+
+	d := NewDispatcher(config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	if got := d.localizer.T("error.generic"); got != "Oops!" {
+		t.Errorf("Expected the configured language to use the override, got %q", got)
+	}
+	if got := d.localizerFor(&chat.Message{Language: "en"}).T("error.generic"); got != "Oops!" {
+		t.Errorf("Expected replies in the configured language to use the override, got %q", got)
+	}
+	if got := d.localizerFor(&chat.Message{Language: "de"}).T("error.generic"); got == "Oops!" {
+		t.Error("Expected other languages to keep their bundled wording")
+	}
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Requester Membership
+// This module rejects song requests of users who aren't group members or joined only recently,
+// keeping spam bots that join and immediately post links out of the playlist
+
+// membershipCacheTTL is how long chat member lookups are reused.
+const membershipCacheTTL = 5 * time.Minute
+
+// allowedMemberStatuses are the chat member statuses that may request songs.
+var allowedMemberStatuses = []string{"member", "administrator", "creator"}
+
+// cachedMember is a chat member lookup with the time it was made.
+type cachedMember struct {
+	member    *chat.ChatMember
+	fetchedAt time.Time
+}
+
+// memberCache caches chat member lookups, safe for concurrent use.
+type memberCache struct {
+	mutex   sync.Mutex
+	members map[string]cachedMember
+}
+
+// get returns the cached member of the key if it was looked up within the TTL.
+func (c *memberCache) get(key string) (*chat.ChatMember, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cached, ok := c.members[key]
+	if !ok || time.Since(cached.fetchedAt) >= membershipCacheTTL {
+		return nil, false
+	}
+	return cached.member, true
+}
+
+// put caches a member lookup, dropping expired ones so the map doesn't grow forever.
+func (c *memberCache) put(key string, member *chat.ChatMember) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.members == nil {
+		c.members = make(map[string]cachedMember)
+	}
+	for cachedKey, cached := range c.members {
+		if time.Since(cached.fetchedAt) >= membershipCacheTTL {
+			delete(c.members, cachedKey)
+		}
+	}
+	c.members[key] = cachedMember{member: member, fetchedAt: time.Now()}
+}
+
+// getCachedChatMember returns the requester's chat membership, looking it up at most once per TTL.
+func (d *Dispatcher) getCachedChatMember(ctx context.Context, chatID, userID string) (*chat.ChatMember, error) {
+	key := chatID + ":" + userID
+	if member, ok := d.members.get(key); ok {
+		return member, nil
+	}
+
+	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chat ID: %w", err)
+	}
+	userIDInt, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	member, err := d.frontend.GetChatMember(ctx, chatIDInt, userIDInt)
+	if err != nil {
+		return nil, err
+	}
+
+	d.members.put(key, member)
+	return member, nil
+}
+
+// isMembershipRejected checks whether the requester is a group member long enough to request songs,
+// and notifies them if not. Chatter of rejected users is ignored without a reply.
+func (d *Dispatcher) isMembershipRejected(ctx context.Context, inputMsg *InputMessage, msg *chat.Message) bool {
+	if !d.config.App.RequireMembership {
+		return false
+	}
+
+	member, err := d.getCachedChatMember(ctx, msg.ChatID, msg.SenderID)
+	if err != nil {
+		d.logger.Debug("Failed to get chat member for membership check, allowing request",
+			zap.String("userID", msg.SenderID),
+			zap.Error(err))
+		return false
+	}
+
+	var messageKey string
+	var args []any
+	minMembership := time.Duration(d.config.App.MinMembershipMins) * time.Minute
+	isAdmin := member.Status == "administrator" || member.Status == "creator"
+	switch {
+	case !slices.Contains(allowedMemberStatuses, member.Status):
+		messageKey = "error.membership.not_member"
+	case !isAdmin && !member.JoinedAt.IsZero() && time.Since(member.JoinedAt) < minMembership:
+		messageKey = "error.membership.too_new"
+		args = append(args, formatQuotaResetIn(minMembership-time.Since(member.JoinedAt)))
+	default:
+		return false
+	}
+
+	if inputMsg.Type == MessageTypeFreeText && d.isNotMusicRequest(ctx, inputMsg.Text) {
+		return true
+	}
+
+	d.logger.Info("Rejected request of a user who isn't an established member",
+		zap.String("userID", msg.SenderID),
+		zap.String("userName", msg.SenderName),
+		zap.String("status", member.Status),
+		zap.Time("joinedAt", member.JoinedAt))
+
+	d.recordRequest()
+	d.recordRequestRejected(rejectReasonMembership)
+	d.replyCommandError(ctx, msg, messageKey, args...)
+	return true
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+func TestIsMembershipRejected(t *testing.T) {
+	tests := []struct {
+		name              string
+		requireMembership bool
+		member            chat.ChatMember
+		expectedKey       string
+	}{
+		{"Check disabled", false, chat.ChatMember{Status: "left"}, ""},
+		{"Established member", true, chat.ChatMember{Status: "member"}, ""},
+		{"Member who joined before the minimum", true,
+			chat.ChatMember{Status: "member", JoinedAt: time.Now().Add(-time.Hour)}, ""},
+		{"Member who just joined", true,
+			chat.ChatMember{Status: "member", JoinedAt: time.Now()}, "error.membership.too_new"},
+		{"Administrator who just joined", true,
+			chat.ChatMember{Status: "administrator", JoinedAt: time.Now()}, ""},
+		{"User who left", true, chat.ChatMember{Status: "left"}, "error.membership.not_member"},
+		{"Restricted user", true, chat.ChatMember{Status: "restricted"}, "error.membership.not_member"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontend := fake.New()
+			member := tt.member
+			member.User = &chat.User{ID: 2}
+			frontend.SetChatMember(&member)
+
+			d := &Dispatcher{
+				config:    DefaultConfig(),
+				frontend:  frontend,
+				localizer: i18n.NewLocalizer(i18n.DefaultLanguage),
+				metrics:   noopMetricsRecorder{},
+				logger:    zap.NewNop(),
+			}
+			d.config.App.RequireMembership = tt.requireMembership
+			d.config.App.MinMembershipMins = 10
+
+			msg := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice"}
+			input := &InputMessage{Type: MessageTypeSpotifyLink, Text: "https://open.spotify.com/track/abc"}
+			rejected := d.isMembershipRejected(context.Background(), input, msg)
+
+			if rejected != (tt.expectedKey != "") {
+				t.Fatalf("isMembershipRejected() = %v, want rejection with %q", rejected, tt.expectedKey)
+			}
+			if tt.expectedKey == "" {
+				return
+			}
+			reply, ok := frontend.LastSent()
+			if !ok || !strings.Contains(reply.Text, strings.SplitN(d.localizer.T(tt.expectedKey), "%", 2)[0]) {
+				t.Errorf("Expected a %q reply, got %+v", tt.expectedKey, frontend.SentMessages())
+			}
+		})
+	}
+}
+
+func TestGetCachedChatMember_ReusesLookups(t *testing.T) {
+	frontend := fake.New()
+	frontend.SetChatMember(&chat.ChatMember{Status: "member", User: &chat.User{ID: 2}})
+	d := &Dispatcher{config: DefaultConfig(), frontend: frontend, logger: zap.NewNop()}
+
+	if _, err := d.getCachedChatMember(context.Background(), "-100", "2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	frontend.SetChatMember(&chat.ChatMember{Status: "left", User: &chat.User{ID: 2}})
+
+	member, err := d.getCachedChatMember(context.Background(), "-100", "2")
+	if err != nil || member.Status != "member" {
+		t.Errorf("Expected the cached lookup to be reused, got %+v, %v", member, err)
+	}
+	if _, err := d.getCachedChatMember(context.Background(), "-100", "alice"); err == nil {
+		t.Error("Expected an error for a non-numeric user ID")
+	}
+}
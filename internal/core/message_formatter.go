@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/i18n"
 )
 
 // Message Formatting and User Interactions
@@ -44,21 +45,62 @@ func (d *Dispatcher) reactPriorityQueued(ctx context.Context, msgCtx *MessageCon
 func (d *Dispatcher) reactAddedWithMessage(
 	ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message, trackID, messageKey string) {
 	msgCtx.State = StateReactAdded
+	d.metrics.IncSongsAdded()
 
 	track, err := d.spotify.GetTrack(ctx, trackID)
 	if err != nil {
 		d.logger.Error("Failed to get track info", zap.Error(err))
 		track = &Track{ID: trackID, Title: unknownTrack, Artist: unknownArtist}
 	}
+	d.recordRequestAccepted(originalMsg, track)
 
-	// React with thumbs up
-	if reactErr := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, thumbsUpReaction); reactErr != nil {
-		d.logger.Error("Failed to react with thumbs up", zap.Error(reactErr))
+	// React telling whether the track plays next or was added to the playlist
+	queued := messageKey == "success.track_priority_playing"
+	if reactErr := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, d.addedReaction(queued)); reactErr != nil {
+		d.logger.Error("Failed to react to added track", zap.Error(reactErr))
 	}
 
+	localizer := d.localizerFor(originalMsg)
+	addedMessage := d.formatAddedMessage(localizer, track, messageKey)
+	if !queued {
+		// Priority tracks play next, everyone else wants to know how long to wait
+		addedMessage += d.formatPlayEstimate(ctx, localizer, trackID)
+	}
+	successMessage := d.formatMessageWithMention(originalMsg, addedMessage)
+	replyID, sendErr := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, successMessage)
+	if sendErr != nil {
+		d.logger.Error("Failed to send success message", zap.Error(sendErr))
+	}
+
+	// Remember both the request and our reply so admins can /undo by replying to either
+	d.rememberAddedTrackMessage(trackID, originalMsg.ID, replyID)
+
+	d.recordUserQuota(originalMsg)
+	d.recordLeaderboard(originalMsg)
+	d.recordRecentAddition(originalMsg, track)
+	d.recordCrowdAddition(ctx)
+	d.logTrackAddedEvent(msgCtx, originalMsg, track)
+	d.scheduleRequestMessageDeletion(originalMsg)
+}
+
+// addedReaction returns the configured reaction on added tracks, which differs for tracks queued to play next
+// and tracks added to the playlist to play later.
+func (d *Dispatcher) addedReaction(queued bool) chat.Reaction {
+	reaction := d.config.App.AddedReaction
+	if queued {
+		reaction = d.config.App.QueuedReaction
+	}
+	if reaction == "" {
+		return thumbsUpReaction
+	}
+	return chat.Reaction(reaction)
+}
+
+// formatAddedMessage builds the success message, including the queue position when known.
+func (d *Dispatcher) formatAddedMessage(localizer *i18n.Localizer, track *Track, messageKey string) string {
 	// Check if we should include queue position in the message
 	// Use shadow queue to get the track position directly (much simpler!)
-	queuePosition := d.GetShadowQueuePosition(trackID)
+	queuePosition := d.GetShadowQueuePosition(track.ID)
 	if queuePosition >= 0 {
 		// Track found in playlist - use message with queue position
 		var queueMessageKey string
@@ -74,34 +116,43 @@ func (d *Dispatcher) reactAddedWithMessage(
 
 		if queueMessageKey != messageKey {
 			// Use queue position message with 1-based indexing for user display
-			successMessage := d.formatMessageWithMention(originalMsg,
-				d.localizer.T(queueMessageKey, track.Artist, track.Title, track.URL, queuePosition+1))
-			if _, sendErr := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, successMessage); sendErr != nil {
-				d.logger.Error("Failed to send success message with queue position", zap.Error(sendErr))
-			}
-			return
+			return localizer.T(queueMessageKey, track.Artist, track.Title, track.URL, queuePosition+1)
 		}
 	}
 
 	// Use basic message format without queue position
-	successMessage := d.formatMessageWithMention(originalMsg,
-		d.localizer.T(messageKey, track.Artist, track.Title, track.URL))
-	if _, sendErr := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, successMessage); sendErr != nil {
-		d.logger.Error("Failed to send success message", zap.Error(sendErr))
-	}
+	return localizer.T(messageKey, track.Artist, track.Title, track.URL)
 }
 
-// reactDuplicate reacts to duplicate track attempts.
-func (d *Dispatcher) reactDuplicate(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message) {
+// reactDuplicate reacts to duplicate track attempts, offering a bump vote if enabled.
+func (d *Dispatcher) reactDuplicate(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message,
+	trackID string) {
 	msgCtx.State = StateReactDuplicate
+	d.recordRequestRejected(rejectReasonDuplicate)
 
 	// React with thumbs down
 	if err := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, thumbsDownReaction); err != nil {
 		d.logger.Error("Failed to react with thumbs down", zap.Error(err))
 	}
 
-	// Reply with duplicate message
-	duplicateMessage := d.formatMessageWithMention(originalMsg, d.localizer.T("success.duplicate"))
+	// Let the group vote to bump the track instead of only rejecting the request
+	if d.tryStartBumpVote(trackID) {
+		d.awaitUserInput(ctx, msgCtx, originalMsg)
+		d.runBumpVote(ctx, originalMsg, trackID)
+		return
+	}
+
+	d.replyDuplicate(ctx, originalMsg, trackID)
+}
+
+// replyDuplicate replies that the track is already in the playlist, telling where it sits in the queue.
+func (d *Dispatcher) replyDuplicate(ctx context.Context, originalMsg *chat.Message, trackID string) {
+	localizer := d.localizerFor(originalMsg)
+	reply := localizer.T("success.duplicate")
+	if position, timeUntilPlay, queued := d.findTrackQueueInfo(ctx, trackID); queued {
+		reply = localizer.T("success.duplicate_queued", position+1, formatQueueDuration(timeUntilPlay))
+	}
+	duplicateMessage := d.formatMessageWithMention(originalMsg, reply)
 	if _, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, duplicateMessage); err != nil {
 		d.logger.Error("Failed to reply with duplicate message", zap.Error(err))
 	}
@@ -125,7 +176,7 @@ func (d *Dispatcher) replyError(ctx context.Context, msgCtx *MessageContext, ori
 
 // replyHelp sends a help message to the user explaining how to use the bot.
 func (d *Dispatcher) replyHelp(ctx context.Context, originalMsg *chat.Message) {
-	helpMessage := d.localizer.T("bot.help_message")
+	helpMessage := d.localizerFor(originalMsg).T("bot.help_message")
 	if _, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, helpMessage); err != nil {
 		d.logger.Error("Failed to send help message", zap.Error(err))
 	}
@@ -133,11 +184,18 @@ func (d *Dispatcher) replyHelp(ctx context.Context, originalMsg *chat.Message) {
 
 // reactProcessing adds a processing reaction to show the message is being handled.
 func (d *Dispatcher) reactProcessing(ctx context.Context, msg *chat.Message) {
-	if err := d.frontend.React(ctx, msg.ChatID, msg.ID, "👀"); err != nil {
+	if err := d.frontend.React(ctx, msg.ChatID, msg.ID, chat.ReactionProcessing); err != nil {
 		d.logger.Debug("Failed to add processing reaction", zap.Error(err))
 	}
 }
 
+// clearProcessingReaction removes the processing reaction once the request awaits an answer or is done.
+func (d *Dispatcher) clearProcessingReaction(ctx context.Context, msg *chat.Message) {
+	if err := d.frontend.RemoveReaction(ctx, msg.ChatID, msg.ID, chat.ReactionProcessing); err != nil {
+		d.logger.Debug("Failed to remove processing reaction", zap.Error(err))
+	}
+}
+
 // reactIgnored adds a random "see/hear/speak no evil" emoji to ignored messages.
 func (d *Dispatcher) reactIgnored(ctx context.Context, msg *chat.Message) {
 	// Randomly choose one of the three "no evil" emojis
@@ -166,7 +224,7 @@ func (d *Dispatcher) formatMessageWithMention(msg *chat.Message, messageText str
 }
 
 // formatCommunityApprovalMessage formats the community approval message with track details (for channel).
-func (d *Dispatcher) formatCommunityApprovalMessage(track *Track, trackMood string) string {
+func (d *Dispatcher) formatCommunityApprovalMessage(track *Track, trackMood string, communityThreshold int) string {
 	// Format album and year information
 	var albumInfo, yearInfo string
 	if track.Album != "" {
@@ -181,9 +239,18 @@ func (d *Dispatcher) formatCommunityApprovalMessage(track *Track, trackMood stri
 	if track.URL != "" {
 		urlPart = d.localizer.T("format.url", track.URL)
 	}
+	urlPart += d.formatPreview(track)
 
 	return d.localizer.T("admin.approval_required_community",
-		track.Artist, track.Title, albumInfo, yearInfo, urlPart, trackMood, d.config.Telegram.CommunityApproval)
+		track.Artist, track.Title, albumInfo, yearInfo, urlPart, trackMood, communityThreshold)
+}
+
+// formatPreview returns the preview line of approval messages, or an empty string if the track has no preview.
+func (d *Dispatcher) formatPreview(track *Track) string {
+	if track.PreviewURL == "" {
+		return ""
+	}
+	return d.localizer.T("format.preview", track.PreviewURL)
 }
 
 // sendStartupMessage sends a startup notification to the group.
@@ -202,14 +269,24 @@ func (d *Dispatcher) sendShutdownMessage(ctx context.Context) {
 	if groupID := d.getGroupID(); groupID != "" {
 		playlistURL := "https://open.spotify.com/playlist/" + d.config.Spotify.PlaylistID
 		shutdownMessage := d.localizer.T("bot.shutdown", playlistURL)
-		if _, err := d.frontend.SendText(ctx, groupID, "", shutdownMessage); err != nil {
+		if d.config.App.ShutdownSummary && d.stats != nil {
+			shutdownMessage += d.formatShutdownSummary(d.stats.Snapshot())
+		}
+
+		// Don't let an unreachable chat hold up the rest of the shutdown
+		sendCtx, cancel := context.WithTimeout(ctx, shutdownMessageTimeout)
+		defer cancel()
+		if _, err := d.frontend.SendText(sendCtx, groupID, "", shutdownMessage); err != nil {
 			d.logger.Warn("Failed to send shutdown message", zap.Error(err))
 		}
 	}
 }
 
-// getGroupID returns the Telegram group ID.
+// getGroupID returns the Telegram group ID or the Matrix room.
 func (d *Dispatcher) getGroupID() string {
+	if d.config.Matrix.Enabled {
+		return d.config.Matrix.RoomID
+	}
 	if d.config.Telegram.GroupID != 0 {
 		return strconv.FormatInt(d.config.Telegram.GroupID, 10)
 	}
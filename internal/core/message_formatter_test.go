@@ -0,0 +1,51 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"djalgorhythm/internal/chat"
+)
+
+func TestReactAdded_Reactions(t *testing.T) {
+	ctx := context.Background()
+	d, _, frontend := newFailedAdditionTestDispatcher(0)
+	d.config.App.AddedReaction = "🔥"
+	d.config.App.QueuedReaction = "🎉"
+
+	added := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice"}
+	d.reactAdded(ctx, &MessageContext{}, added, "track1")
+	if !frontend.HasReacted(added.ID, "🔥") ||
+		!frontend.HasSent(d.formatMessageWithMention(added,
+			d.formatAddedMessage(d.localizer, &Track{Title: "Title", Artist: "Artist"}, "success.track_added"))) {
+		t.Errorf("Expected the playlist addition to be confirmed, got %+v", frontend.SentMessages())
+	}
+
+	queued := &chat.Message{ID: "2", ChatID: "-100", SenderID: "2", SenderName: "@alice"}
+	d.reactPriorityQueued(ctx, &MessageContext{}, queued, "track2")
+	if !frontend.HasReacted(queued.ID, "🎉") || frontend.HasReacted(queued.ID, "🔥") {
+		t.Errorf("Expected the queued track reaction, got %+v", frontend.Reactions())
+	}
+
+	d.config.App.QueuedReaction = ""
+	if got := d.addedReaction(true); got != thumbsUpReaction {
+		t.Errorf("Expected unset reactions to fall back to %q, got %q", thumbsUpReaction, got)
+	}
+}
+
+func TestFormatCommunityApprovalMessage_Preview(t *testing.T) {
+	d, _, _ := newFailedAdditionTestDispatcher(0)
+	track := &Track{Artist: "Artist", Title: "Title", URL: "https://open.spotify.com/track/1"}
+
+	preview := d.localizer.T("format.preview", "")
+	if message := d.formatCommunityApprovalMessage(track, "upbeat", 3); strings.Contains(message, preview) {
+		t.Errorf("Expected no preview line without a preview, got %q", message)
+	}
+
+	track.PreviewURL = "https://p.scdn.co/mp3-preview/1"
+	preview = d.localizer.T("format.preview", track.PreviewURL)
+	if message := d.formatCommunityApprovalMessage(track, "upbeat", 3); !strings.Contains(message, preview) {
+		t.Errorf("Expected the preview line %q, got %q", preview, message)
+	}
+}
@@ -23,7 +23,7 @@ func (d *Dispatcher) askWhichSong(ctx context.Context, msgCtx *MessageContext, o
 		d.logger.Debug("Failed to react with thumbs down", zap.Error(err))
 	}
 
-	message := d.formatMessageWithMention(originalMsg, d.localizer.T("prompt.which_song"))
+	message := d.formatMessageWithMention(originalMsg, d.localizerFor(originalMsg).T("prompt.which_song"))
 	_, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, message)
 	if err != nil {
 		d.logger.Error("Failed to ask which song", zap.Error(err))
@@ -35,7 +35,7 @@ func (d *Dispatcher) llmDisambiguate(ctx context.Context, msgCtx *MessageContext
 	msgCtx.State = StateLLMDisambiguate
 
 	if d.llm == nil {
-		d.replyError(ctx, msgCtx, originalMsg, d.localizer.T("error.llm.no_provider"))
+		d.replyError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.llm.no_provider"))
 		return
 	}
 
@@ -44,7 +44,10 @@ func (d *Dispatcher) llmDisambiguate(ctx context.Context, msgCtx *MessageContext
 
 	normalizedQuery := msgCtx.Input.Text
 	if d.llm != nil {
-		if extractedQuery, err := d.llm.ExtractSongQuery(ctx, msgCtx.Input.Text); err != nil {
+		llmCtx, cancel := d.withLLMTimeout(ctx)
+		extractedQuery, err := d.llm.ExtractSongQuery(llmCtx, msgCtx.Input.Text)
+		cancel()
+		if err != nil {
 			d.logger.Warn("Extraction failed; falling back to raw text", zap.Error(err))
 		} else if extractedQuery != "" {
 			normalizedQuery = extractedQuery
@@ -64,28 +67,36 @@ func (d *Dispatcher) llmDisambiguate(ctx context.Context, msgCtx *MessageContext
 	initialSpotifyTracks, err := d.spotify.SearchTrack(ctx, normalizedQuery)
 	if err != nil {
 		d.logger.Error("Initial Spotify search failed", zap.Error(err))
-		d.replyError(ctx, msgCtx, originalMsg, d.localizer.T("error.spotify.search_failed"))
+		d.replyError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.spotify.search_failed"))
 		return
 	}
 
 	d.logger.Info("Stage 1 complete: Found Spotify tracks",
 		zap.Int("count", len(initialSpotifyTracks)))
 
+	// A clearly matching top result skips the LLM ranking and the confirmation prompt
+	if best := d.autoAcceptCandidate(initialSpotifyTracks); best != nil {
+		d.autoAcceptTrack(ctx, msgCtx, originalMsg, best)
+		return
+	}
+
 	// Stage 2: LLM ranking of Spotify results with normalized query
 	var rankedTracks []Track
 
 	if len(initialSpotifyTracks) > 0 {
 		d.logger.Debug("Stage 2: LLM ranking of Spotify results")
-		rankedTracks = d.llm.RankTracks(ctx, normalizedQuery, initialSpotifyTracks)
+		llmCtx, cancel := d.withLLMTimeout(ctx)
+		rankedTracks = d.llm.RankTracks(llmCtx, normalizedQuery, initialSpotifyTracks)
+		cancel()
 	} else {
 		d.logger.Debug("Stage 2: No initial Spotify results, cannot process without tracks")
-		d.replyError(ctx, msgCtx, originalMsg, d.localizer.T("error.spotify.no_matches"))
+		d.replyError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.spotify.no_matches"))
 		return
 	}
 
 	if len(rankedTracks) == 0 {
 		d.logger.Warn("LLM returned no ranked tracks")
-		d.askWhichSong(ctx, msgCtx, originalMsg)
+		d.replyNoConfidentMatch(ctx, msgCtx, originalMsg, initialSpotifyTracks)
 		return
 	}
 
@@ -106,7 +117,7 @@ func (d *Dispatcher) enhancedLLMDisambiguate(ctx context.Context, msgCtx *Messag
 	allSpotifyTracks := d.performTargetedSpotifySearch(ctx, rankedTracks)
 	if len(allSpotifyTracks) == 0 {
 		d.logger.Error("No Spotify tracks found for any ranked candidates")
-		d.replyError(ctx, msgCtx, originalMsg, d.localizer.T("error.spotify.no_matches"))
+		d.replyNoConfidentMatch(ctx, msgCtx, originalMsg, rankedTracks)
 		return
 	}
 
@@ -167,10 +178,12 @@ func (d *Dispatcher) performFinalLLMRanking(ctx context.Context, msgCtx *Message
 	originalMsg *chat.Message, allSpotifyTracks []Track) []Track {
 	d.logger.Debug("Stage 3b: Final LLM ranking of targeted results")
 
-	finalTracks := d.llm.RankTracks(ctx, msgCtx.Input.Text, allSpotifyTracks)
+	llmCtx, cancel := d.withLLMTimeout(ctx)
+	finalTracks := d.llm.RankTracks(llmCtx, msgCtx.Input.Text, allSpotifyTracks)
+	cancel()
 	if len(finalTracks) == 0 {
 		d.logger.Warn("Final LLM returned no tracks, asking which song")
-		d.askWhichSong(ctx, msgCtx, originalMsg)
+		d.replyNoConfidentMatch(ctx, msgCtx, originalMsg, allSpotifyTracks)
 		return nil
 	}
 
@@ -192,6 +205,12 @@ func (d *Dispatcher) processFinalTrackSelection(ctx context.Context, msgCtx *Mes
 	msgCtx.Candidates = finalTracks
 	best := finalTracks[0]
 
+	// Let the requester pick among several results, if enabled
+	if choices := d.candidateChoices(finalTracks); choices != nil {
+		d.promptCandidateChoice(ctx, msgCtx, originalMsg, choices)
+		return
+	}
+
 	// Binary decision: if we have a valid Spotify URL, use enhanced approval, otherwise ask which song
 	if best.URL != "" {
 		d.promptEnhancedApproval(ctx, msgCtx, originalMsg, &best)
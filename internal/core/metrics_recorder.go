@@ -0,0 +1,29 @@
+package core
+
+// Metrics Recording
+// This module provides the no-op recorder used when metrics are disabled,
+// so dispatcher code can record metrics unconditionally
+
+// Rejection reasons for the requests rejected metric.
+const (
+	rejectReasonDuplicate   = "duplicate"
+	rejectReasonQuota       = "quota"
+	rejectReasonPaused      = "paused"
+	rejectReasonDenied      = "denied"
+	rejectReasonExplicit    = "explicit"
+	rejectReasonCooldown    = "cooldown"
+	rejectReasonEpisode     = "episode"
+	rejectReasonDuration    = "duration"
+	rejectReasonBusy        = "busy"
+	rejectReasonUnavailable = "unavailable"
+	rejectReasonMembership  = "membership"
+)
+
+// noopMetricsRecorder discards all metrics.
+type noopMetricsRecorder struct{}
+
+func (noopMetricsRecorder) IncSongsAdded()               {}
+func (noopMetricsRecorder) IncRequestsRejected(_ string) {}
+func (noopMetricsRecorder) IncApprovalTimeouts()         {}
+func (noopMetricsRecorder) SetShadowQueueSize(_ int)     {}
+func (noopMetricsRecorder) IncSpotifyAPIErrors(_ string) {}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Multiple Requests
+// This module handles free-text messages asking for several songs at once. The chat frontend
+// splits them into one query per song, which are processed one after another like single requests
+
+// processMultipleRequests processes each song query of a message in turn. Every query goes through
+// the full request flow, so confirmations, approvals, dedup and quotas apply per song.
+func (d *Dispatcher) processMultipleRequests(ctx context.Context, inputMsg InputMessage, originalMsg *chat.Message) {
+	d.logger.Info("Processing message with multiple requests",
+		zap.String("messageID", originalMsg.ID),
+		zap.Int("requests", len(originalMsg.Requests)))
+
+	if d.isMembershipRejected(ctx, &inputMsg, originalMsg) {
+		return
+	}
+
+	for _, query := range originalMsg.Requests {
+		requestMsg := inputMsg
+		requestMsg.Text = query
+
+		// Confirmations of a request block until answered, so the next request waits for its turn
+		d.processMessage(ctx, d.registerMessageContext(requestMsg), originalMsg)
+	}
+}
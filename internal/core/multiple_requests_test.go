@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+// multipleRequestsTestLLM lets every message through as a music request.
+type multipleRequestsTestLLM struct {
+	LLMProvider
+}
+
+func (l *multipleRequestsTestLLM) IsNotMusicRequest(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+func (l *multipleRequestsTestLLM) ExtractSongQuery(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
+// multipleRequestsTestSpotify records search queries and finds nothing.
+type multipleRequestsTestSpotify struct {
+	SpotifyClient
+	queries []string
+}
+
+func (s *multipleRequestsTestSpotify) SearchTrack(_ context.Context, query string) ([]Track, error) {
+	s.queries = append(s.queries, query)
+	return nil, errors.New("search unavailable")
+}
+
+func TestProcessMultipleRequests(t *testing.T) {
+	spotify := &multipleRequestsTestSpotify{}
+	frontend := fake.New()
+	d := &Dispatcher{
+		config:          DefaultConfig(),
+		frontend:        frontend,
+		spotify:         spotify,
+		llm:             &multipleRequestsTestLLM{},
+		localizer:       i18n.NewLocalizer(i18n.DefaultLanguage),
+		messageContexts: make(map[string]*MessageContext),
+		metrics:         noopMetricsRecorder{},
+		logger:          zap.NewNop(),
+	}
+
+	msg := &chat.Message{
+		ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice",
+		Text:     "play Creep by Radiohead and also Yellow by Coldplay",
+		Requests: []string{"play Creep by Radiohead", "Yellow by Coldplay"},
+	}
+	d.processMultipleRequests(context.Background(), d.convertToInputMessage(msg), msg)
+
+	if !slices.Equal(spotify.queries, msg.Requests) {
+		t.Fatalf("Expected each request to be searched in turn, got %q", spotify.queries)
+	}
+
+	failures := 0
+	for _, sent := range frontend.SentMessages() {
+		if sent.Text == d.formatMessageWithMention(msg, d.localizer.T("error.spotify.search_failed")) {
+			failures++
+		}
+	}
+	if failures != len(msg.Requests) {
+		t.Errorf("Expected a reply per request, got %+v", frontend.SentMessages())
+	}
+
+	d.contextMutex.RLock()
+	defer d.contextMutex.RUnlock()
+	if len(d.messageContexts) != 0 {
+		t.Errorf("Expected all request contexts to be cleaned up, got %d", len(d.messageContexts))
+	}
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Near-Duplicate Detection
+// This module catches requests for a different version of a song that is already
+// in the playlist (remaster, single vs album) and asks the user before adding it
+
+// isNearDuplicateDeclined checks whether the track looks like one already in the playlist and,
+// if so, asks the requester whether to add it anyway. Returns true if the request should stop.
+func (d *Dispatcher) isNearDuplicateDeclined(ctx context.Context, msgCtx *MessageContext,
+	originalMsg *chat.Message, trackID string) bool {
+	const percentBase = 100
+
+	if d.config.App.NearDuplicateThresholdPercent <= 0 {
+		return false
+	}
+	threshold := float64(d.config.App.NearDuplicateThresholdPercent) / percentBase
+
+	track, err := d.getCachedTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Warn("Failed to get track for near-duplicate check, allowing request",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		return false
+	}
+
+	existingID, similarity := d.dedup.FindNearDuplicate(track.Artist, track.Title, threshold)
+	if existingID == "" || existingID == trackID {
+		return false
+	}
+
+	existing, err := d.getCachedTrack(ctx, existingID)
+	if err != nil {
+		d.logger.Warn("Failed to get existing track info", zap.Error(err))
+		existing = &Track{ID: existingID, Title: unknownTrack, Artist: unknownArtist}
+	}
+
+	d.logger.Info("Detected near-duplicate track request",
+		zap.String("trackID", trackID),
+		zap.String("existingTrackID", existingID),
+		zap.Float64("similarity", similarity))
+
+	prompt := d.formatMessageWithMention(originalMsg,
+		d.localizerFor(originalMsg).T("prompt.near_duplicate", existing.Artist, existing.Title))
+	d.awaitUserInput(ctx, msgCtx, originalMsg)
+	approved, err := d.frontend.AwaitApproval(ctx, originalMsg, prompt, d.confirmTimeoutSecs(ctx, msgCtx, originalMsg))
+	if err != nil {
+		d.logger.Error("Failed to get near-duplicate approval", zap.Error(err))
+		d.replyError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.generic"))
+		return true
+	}
+
+	if approved {
+		return false
+	}
+
+	msgCtx.State = StateReactDuplicate
+	d.recordRequestRejected(rejectReasonDuplicate)
+	return true
+}
+
+// recordTrackSignature stores the track's artist and title for near-duplicate detection.
+func (d *Dispatcher) recordTrackSignature(ctx context.Context, trackID string) {
+	if d.config.App.NearDuplicateThresholdPercent <= 0 {
+		return
+	}
+
+	track, err := d.getCachedTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Debug("Failed to get track for near-duplicate signature",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		return
+	}
+
+	d.dedup.AddSignature(trackID, track.Artist, track.Title)
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Now Playing Announcements
+// This module handles posting "now playing" messages to the group on track changes
+// Only the latest announcement is kept and pinned; the previous one is unpinned and deleted to avoid chat spam
+
+// announceNowPlaying posts and pins the given track as now playing and removes the previous announcement.
+func (d *Dispatcher) announceNowPlaying(ctx context.Context, trackID string) {
+	groupID := d.getGroupID()
+	if groupID == "" {
+		return
+	}
+
+	track, err := d.getCachedTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Debug("Failed to get track info for now playing announcement",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		return
+	}
+
+	messageID, err := d.sendTrackMessage(ctx, groupID, "", track, d.localizer.T("bot.now_playing", track.Artist, track.Title))
+	if err != nil {
+		d.logger.Warn("Failed to send now playing announcement", zap.Error(err))
+		return
+	}
+
+	// Pinning needs the pin messages permission and isn't supported by every frontend
+	if err := d.frontend.PinMessage(ctx, groupID, messageID); err != nil {
+		d.logger.Debug("Failed to pin now playing announcement", zap.Error(err))
+	}
+
+	d.nowPlayingMutex.Lock()
+	previousMessageID := d.nowPlayingMessageID
+	d.nowPlayingMessageID = messageID
+	d.nowPlayingMutex.Unlock()
+
+	if previousMessageID == "" {
+		return
+	}
+
+	if err := d.frontend.UnpinMessage(ctx, groupID, previousMessageID); err != nil {
+		d.logger.Debug("Failed to unpin previous now playing announcement",
+			zap.String("messageID", previousMessageID),
+			zap.Error(err))
+	}
+
+	if err := d.frontend.DeleteMessage(ctx, groupID, previousMessageID); err != nil {
+		d.logger.Debug("Failed to delete previous now playing announcement",
+			zap.String("messageID", previousMessageID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+func TestAnnounceNowPlaying_PinsLatestAnnouncement(t *testing.T) {
+	ctx := context.Background()
+	frontend := fake.New()
+
+	config := DefaultConfig()
+	config.Telegram.GroupID = -100
+	d := &Dispatcher{
+		config:    config,
+		frontend:  frontend,
+		localizer: i18n.NewLocalizer(i18n.DefaultLanguage),
+		logger:    zap.NewNop(),
+		trackInfoCache: map[string]*Track{
+			"first":  {ID: "first", Title: "First", Artist: "Band"},
+			"second": {ID: "second", Title: "Second", Artist: "Band"},
+		},
+	}
+
+	d.announceNowPlaying(ctx, "first")
+	first, _ := frontend.LastSent()
+	if pinned := frontend.PinnedMessages(); !slices.Equal(pinned, []string{first.ID}) {
+		t.Fatalf("Expected the announcement %q to be pinned, got %v", first.ID, pinned)
+	}
+
+	d.announceNowPlaying(ctx, "second")
+	second, _ := frontend.LastSent()
+	if pinned := frontend.PinnedMessages(); !slices.Equal(pinned, []string{second.ID}) {
+		t.Errorf("Expected only the latest announcement %q to stay pinned, got %v", second.ID, pinned)
+	}
+	if deleted := frontend.DeletedMessages(); !slices.Equal(deleted, []string{first.ID}) {
+		t.Errorf("Expected the previous announcement to be deleted, got %v", deleted)
+	}
+}
+
+func TestAnnounceNowPlaying_AlbumArt(t *testing.T) {
+	ctx := context.Background()
+	frontend := fake.New()
+
+	config := DefaultConfig()
+	config.Telegram.GroupID = -100
+	config.App.ShowAlbumArt = true
+	d := &Dispatcher{
+		config:    config,
+		frontend:  frontend,
+		localizer: i18n.NewLocalizer(i18n.DefaultLanguage),
+		logger:    zap.NewNop(),
+		trackInfoCache: map[string]*Track{
+			"cover":    {ID: "cover", Title: "Cover", Artist: "Band", ImageURL: "https://i.scdn.co/image/cover"},
+			"no-cover": {ID: "no-cover", Title: "No Cover", Artist: "Band"},
+		},
+	}
+
+	d.announceNowPlaying(ctx, "cover")
+	if sent, _ := frontend.LastSent(); sent.PhotoURL != "https://i.scdn.co/image/cover" || sent.Text == "" {
+		t.Errorf("Expected the announcement as album art with a caption, got %+v", sent)
+	}
+
+	d.announceNowPlaying(ctx, "no-cover")
+	if sent, _ := frontend.LastSent(); sent.PhotoURL != "" {
+		t.Errorf("Expected a text announcement for a track without album art, got %+v", sent)
+	}
+}
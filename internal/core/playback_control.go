@@ -0,0 +1,72 @@
+package core
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Playback Control
+// This module implements the /pause-music and /resume-music admin commands which pause and resume
+// the music on the active Spotify playback device, e.g. for announcements. Unlike /pause, song requests
+// are still accepted while the music is paused
+
+const (
+	commandPauseMusic  = "pause-music"
+	commandResumeMusic = "resume-music"
+)
+
+var (
+	// ErrPlaybackAlreadyPaused is returned when pausing playback while nothing is playing.
+	ErrPlaybackAlreadyPaused = errors.New("playback is already paused")
+	// ErrPlaybackAlreadyPlaying is returned when resuming playback while music is playing.
+	ErrPlaybackAlreadyPlaying = errors.New("playback is already playing")
+)
+
+// handlePauseMusicCommand pauses the music until /resume-music.
+func (d *Dispatcher) handlePauseMusicCommand(ctx context.Context, msg *chat.Message) {
+	d.controlPlayback(ctx, msg, "pause", d.spotify.PausePlayback,
+		ErrPlaybackAlreadyPaused, "error.playback.already_paused", "error.spotify.pause_failed")
+}
+
+// handleResumeMusicCommand resumes the music paused with /pause-music.
+func (d *Dispatcher) handleResumeMusicCommand(ctx context.Context, msg *chat.Message) {
+	d.controlPlayback(ctx, msg, "resume", d.spotify.ResumePlayback,
+		ErrPlaybackAlreadyPlaying, "error.playback.already_playing", "error.spotify.resume_failed")
+}
+
+// controlPlayback runs a playback action on the active device and reacts to confirm it,
+// replying with the unchanged key if playback already was in the requested state.
+func (d *Dispatcher) controlPlayback(ctx context.Context, msg *chat.Message, action string,
+	control func(ctx context.Context) error, unchangedErr error, unchangedKey, failedKey string) {
+	d.logger.Info("Playback control command received",
+		zap.String("action", action),
+		zap.String("userID", msg.SenderID),
+		zap.String("userName", msg.SenderName))
+
+	hasDevice, err := d.spotify.HasActiveDevice(ctx)
+	if err != nil {
+		d.logger.Warn("Failed to check for active device before controlling playback", zap.Error(err))
+	}
+	if err == nil && !hasDevice {
+		d.replyCommandError(ctx, msg, "error.spotify.no_active_device")
+		return
+	}
+
+	if err := control(ctx); err != nil {
+		if errors.Is(err, unchangedErr) {
+			d.replyCommandError(ctx, msg, unchangedKey)
+			return
+		}
+		d.logger.Error("Failed to control playback", zap.String("action", action), zap.Error(err))
+		d.replyCommandError(ctx, msg, failedKey)
+		return
+	}
+
+	if err := d.frontend.React(ctx, msg.ChatID, msg.ID, chat.ReactionThumbsUp); err != nil {
+		d.logger.Debug("Failed to add playback control reaction", zap.Error(err))
+	}
+}
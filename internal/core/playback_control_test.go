@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+// playbackControlTestSpotify keeps the playing state of a single playback device.
+type playbackControlTestSpotify struct {
+	SpotifyClient
+	hasDevice bool
+	playing   bool
+}
+
+func (s *playbackControlTestSpotify) HasActiveDevice(_ context.Context) (bool, error) {
+	return s.hasDevice, nil
+}
+
+func (s *playbackControlTestSpotify) PausePlayback(_ context.Context) error {
+	if !s.playing {
+		return ErrPlaybackAlreadyPaused
+	}
+	s.playing = false
+	return nil
+}
+
+func (s *playbackControlTestSpotify) ResumePlayback(_ context.Context) error {
+	if s.playing {
+		return ErrPlaybackAlreadyPlaying
+	}
+	s.playing = true
+	return nil
+}
+
+func TestHandlePlaybackControlCommands(t *testing.T) {
+	ctx := context.Background()
+	spotify := &playbackControlTestSpotify{hasDevice: true, playing: true}
+	frontend := fake.New()
+	frontend.SetAdmins("1")
+
+	d := &Dispatcher{
+		config:    DefaultConfig(),
+		spotify:   spotify,
+		frontend:  frontend,
+		localizer: i18n.NewLocalizer(i18n.DefaultLanguage),
+		logger:    zap.NewNop(),
+	}
+	d.registerCommandHandlers()
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "1", ChatID: "-100", SenderID: "1", Text: "/pause-music"})
+	if spotify.playing || !frontend.HasReacted("1", chat.ReactionThumbsUp) {
+		t.Error("Expected the music to be paused and the command confirmed")
+	}
+	if d.IsIngestionPaused() {
+		t.Error("Pausing the music should keep accepting song requests")
+	}
+
+	paused := &chat.Message{ID: "2", ChatID: "-100", SenderID: "1", SenderName: "Admin", Text: "/pause-music"}
+	frontend.RunCommand(ctx, paused)
+	if !frontend.HasSent(d.formatMessageWithMention(paused, d.localizer.T("error.playback.already_paused"))) {
+		t.Errorf("Expected an already paused reply, got %+v", frontend.SentMessages())
+	}
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "3", ChatID: "-100", SenderID: "1", Text: "/resume-music"})
+	if !spotify.playing || !frontend.HasReacted("3", chat.ReactionThumbsUp) {
+		t.Error("Expected the music to be resumed and the command confirmed")
+	}
+
+	playing := &chat.Message{ID: "4", ChatID: "-100", SenderID: "1", SenderName: "Admin", Text: "/resume-music"}
+	frontend.RunCommand(ctx, playing)
+	if !frontend.HasSent(d.formatMessageWithMention(playing, d.localizer.T("error.playback.already_playing"))) {
+		t.Errorf("Expected an already playing reply, got %+v", frontend.SentMessages())
+	}
+
+	spotify.hasDevice = false
+	noDevice := &chat.Message{ID: "5", ChatID: "-100", SenderID: "1", SenderName: "Admin", Text: "/pause-music"}
+	frontend.RunCommand(ctx, noDevice)
+	if !spotify.playing || !frontend.HasSent(d.formatMessageWithMention(noDevice, d.localizer.T("error.spotify.no_active_device"))) {
+		t.Error("Pausing without an active device should be rejected")
+	}
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Playback Device Selection
+// This module transfers playback to the configured preferred device
+// when Spotify reports no active device, so queueing keeps working
+
+// activatePreferredDevice transfers playback to the preferred device on startup if no device is active.
+func (d *Dispatcher) activatePreferredDevice(ctx context.Context) {
+	if d.config.Spotify.DeviceName == "" {
+		return
+	}
+
+	hasActiveDevice, err := d.spotify.HasActiveDevice(ctx)
+	if err != nil {
+		d.logger.Warn("Failed to check for active Spotify device on startup", zap.Error(err))
+		return
+	}
+
+	if !hasActiveDevice {
+		d.transferToPreferredDevice(ctx)
+	}
+}
+
+// transferToPreferredDevice transfers playback to the configured preferred device.
+// Returns true if playback was transferred.
+func (d *Dispatcher) transferToPreferredDevice(ctx context.Context) bool {
+	deviceName := d.config.Spotify.DeviceName
+	if deviceName == "" {
+		return false
+	}
+
+	devices, err := d.spotify.ListDevices(ctx)
+	if err != nil {
+		d.logger.Warn("Failed to list Spotify devices", zap.Error(err))
+		return false
+	}
+
+	device := findDeviceByName(devices, deviceName)
+	if device == nil {
+		d.logger.Debug("Preferred Spotify device not available",
+			zap.String("deviceName", deviceName),
+			zap.Int("availableDevices", len(devices)))
+		return false
+	}
+
+	// Keep the current playback state; the device only needs to be active for queueing
+	if err := d.spotify.TransferPlayback(ctx, device.ID, false); err != nil {
+		d.logger.Warn("Failed to transfer playback to preferred device",
+			zap.String("deviceName", device.Name),
+			zap.Error(err))
+		return false
+	}
+
+	d.logger.Info("Transferred playback to preferred device",
+		zap.String("deviceName", device.Name),
+		zap.String("deviceType", device.Type))
+	return true
+}
+
+// findDeviceByName returns the device with the given name (case-insensitive), or nil if none matches.
+func findDeviceByName(devices []Device, name string) *Device {
+	for i := range devices {
+		if strings.EqualFold(strings.TrimSpace(devices[i].Name), strings.TrimSpace(name)) {
+			return &devices[i]
+		}
+	}
+	return nil
+}
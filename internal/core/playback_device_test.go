@@ -0,0 +1,34 @@
+package core
+
+import "testing"
+
+func TestFindDeviceByName(t *testing.T) {
+	devices := []Device{
+		{ID: "1", Name: "Kitchen Speaker"},
+		{ID: "2", Name: "Living Room"},
+	}
+
+	tests := []struct {
+		name       string
+		deviceName string
+		expectedID string
+	}{
+		{"Exact match", "Living Room", "2"},
+		{"Case-insensitive match", "kitchen speaker", "1"},
+		{"Surrounding whitespace ignored", " Living Room ", "2"},
+		{"No match", "Bedroom", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := findDeviceByName(devices, tt.deviceName)
+			gotID := ""
+			if device != nil {
+				gotID = device.ID
+			}
+			if gotID != tt.expectedID {
+				t.Errorf("findDeviceByName(%q) = %q, want %q", tt.deviceName, gotID, tt.expectedID)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 // Playback Settings Monitoring
 // This module handles monitoring of Spotify playback settings (shuffle/repeat)
 // and warns admins when settings are not optimal for auto-DJing
+// With --enforce-playback-settings, drifted settings are turned back off instead
 
 // runPlaybackSettingsMonitoring monitors playback settings compliance.
 func (d *Dispatcher) runPlaybackSettingsMonitoring(ctx context.Context) {
@@ -47,17 +48,10 @@ func (d *Dispatcher) checkPlaybackSettingsCompliance(ctx context.Context) {
 		return
 	}
 
-	// Settings issues detected - attempt auto-correction
-	d.logger.Info("Playback settings compliance issues detected, attempting auto-correction",
-		zap.Strings("issues", compliance.Issues))
-
-	corrected := d.attemptPlaybackSettingsCorrection(ctx, compliance)
-	if corrected {
-		d.logger.Info("Successfully auto-corrected playback settings")
+	// Settings issues detected - correct them if enforcement is enabled
+	if d.enforcePlaybackSettings(ctx, compliance) {
 		return
 	}
-	// Auto-correction failed, fall back to warning
-	d.logger.Warn("Auto-correction failed, falling back to admin warning")
 
 	// Check if warning should be sent (avoid spam)
 	if !d.warningManager.ShouldSendWarning(WarningTypeSettings) {
@@ -100,6 +94,35 @@ func (d *Dispatcher) checkPlaybackSettingsCompliance(ctx context.Context) {
 	d.logger.Info("Sent playback settings compliance warning message")
 }
 
+// enforcePlaybackSettings corrects drifted playback settings if enforcement is enabled.
+// Corrections are debounced so the bot doesn't fight a user who keeps changing the settings;
+// drift within the debounce window is reported to admins instead. Returns true if the settings were corrected.
+func (d *Dispatcher) enforcePlaybackSettings(ctx context.Context, compliance *PlaybackCompliance) bool {
+	if !d.config.App.EnforcePlaybackSettings {
+		return false
+	}
+
+	if sinceLast := time.Since(d.lastPlaybackCorrection); sinceLast < playbackCorrectionDebounce {
+		d.logger.Info("Playback settings changed again shortly after a correction, not correcting yet",
+			zap.Strings("issues", compliance.Issues),
+			zap.Duration("sinceLastCorrection", sinceLast))
+		return false
+	}
+	d.lastPlaybackCorrection = time.Now()
+
+	d.logger.Info("Playback settings compliance issues detected, attempting auto-correction",
+		zap.Strings("issues", compliance.Issues))
+
+	if !d.attemptPlaybackSettingsCorrection(ctx, compliance) {
+		d.logger.Warn("Auto-correction failed, falling back to admin warning")
+		return false
+	}
+
+	d.logger.Info("Successfully auto-corrected playback settings",
+		zap.Strings("issues", compliance.Issues))
+	return true
+}
+
 // attemptPlaybackSettingsCorrection tries to automatically fix playback settings.
 // Returns true if all settings were successfully corrected, false otherwise.
 func (d *Dispatcher) attemptPlaybackSettingsCorrection(ctx context.Context, compliance *PlaybackCompliance) bool {
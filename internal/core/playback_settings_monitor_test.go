@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"djalgorhythm/internal/chat/fake"
+)
+
+// playbackSettingsTestSpotify reports shuffle as enabled until it is turned off.
+type playbackSettingsTestSpotify struct {
+	SpotifyClient
+	shuffle      bool
+	corrections  int
+	repeatCalled bool
+}
+
+func (s *playbackSettingsTestSpotify) CheckPlaybackCompliance(_ context.Context) (*PlaybackCompliance, error) {
+	compliance := &PlaybackCompliance{IsCorrectShuffle: !s.shuffle, IsCorrectRepeat: true}
+	if s.shuffle {
+		compliance.Issues = append(compliance.Issues, "Shuffle is enabled")
+	}
+	return compliance, nil
+}
+
+func (s *playbackSettingsTestSpotify) SetShuffle(_ context.Context, shuffle bool) error {
+	s.shuffle = shuffle
+	s.corrections++
+	return nil
+}
+
+func (s *playbackSettingsTestSpotify) SetRepeat(_ context.Context, _ string) error {
+	s.repeatCalled = true
+	return nil
+}
+
+func newPlaybackSettingsTestDispatcher(spotify SpotifyClient, enforce bool) (*Dispatcher, *fake.Frontend) {
+	config := DefaultConfig()
+	config.Telegram.GroupID = -100
+	config.App.EnforcePlaybackSettings = enforce
+
+	d, frontend := newTestDispatcher(config, spotify)
+	frontend.SetAdmins("42")
+	return d, frontend
+}
+
+func TestCheckPlaybackSettingsCompliance_ReportOnlyByDefault(t *testing.T) {
+	spotify := &playbackSettingsTestSpotify{shuffle: true}
+	d, frontend := newPlaybackSettingsTestDispatcher(spotify, false)
+
+	d.checkPlaybackSettingsCompliance(context.Background())
+
+	if spotify.corrections != 0 {
+		t.Error("Settings should not be changed without enforcement")
+	}
+	if !frontend.HasSentKey("bot.shuffle_warning") {
+		t.Errorf("Expected a shuffle warning to admins, got %+v", frontend.SentMessages())
+	}
+}
+
+func TestCheckPlaybackSettingsCompliance_EnforcesWithDebounce(t *testing.T) {
+	ctx := context.Background()
+	spotify := &playbackSettingsTestSpotify{shuffle: true}
+	d, frontend := newPlaybackSettingsTestDispatcher(spotify, true)
+
+	d.checkPlaybackSettingsCompliance(ctx)
+	if spotify.shuffle || spotify.corrections != 1 || spotify.repeatCalled {
+		t.Fatalf("Expected only shuffle to be turned off, got %+v", spotify)
+	}
+	if len(frontend.SentMessages()) != 0 {
+		t.Errorf("Corrected settings should not warn admins, got %+v", frontend.SentMessages())
+	}
+
+	// A guest turns shuffle back on right away
+	spotify.shuffle = true
+	d.checkPlaybackSettingsCompliance(ctx)
+	if spotify.corrections != 1 || !frontend.HasSentKey("bot.shuffle_warning") {
+		t.Error("Repeated drift within the debounce window should warn admins instead of correcting")
+	}
+
+	d.lastPlaybackCorrection = time.Now().Add(-playbackCorrectionDebounce)
+	d.checkPlaybackSettingsCompliance(ctx)
+	if spotify.shuffle || spotify.corrections != 2 {
+		t.Error("Settings should be corrected again after the debounce window")
+	}
+}
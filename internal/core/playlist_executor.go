@@ -23,6 +23,10 @@ func (d *Dispatcher) loadPlaylistSnapshot(ctx context.Context) error {
 	}
 
 	d.dedup.Load(trackIDs)
+	for _, track := range tracks {
+		d.dedup.AddSignature(track.ID, track.Artist, track.Title)
+		d.dedup.AddISRC(track.ID, track.ISRC)
+	}
 	d.logger.Info("Loaded playlist snapshot", zap.Int("tracks", len(trackIDs)))
 	return nil
 }
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Playlist Health Monitoring
+// This module periodically checks that the target playlist is still accessible and writable by the bot,
+// pauses adding tracks and warns admins while it is not, and resumes once it is back
+
+// runPlaylistHealthMonitoring monitors the accessibility of the target playlist.
+func (d *Dispatcher) runPlaylistHealthMonitoring(ctx context.Context) {
+	d.logger.Info("Starting playlist health monitoring")
+
+	// Run immediately on startup
+	d.checkPlaylistHealth(ctx)
+
+	ticker := time.NewTicker(playlistHealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Playlist health monitoring stopped")
+			return
+		case <-ticker.C:
+			d.checkPlaylistHealth(ctx)
+		}
+	}
+}
+
+// isPlaylistUnavailable reports whether the last health check found the playlist inaccessible.
+func (d *Dispatcher) isPlaylistUnavailable() bool {
+	return d.playlistUnavailable.Load()
+}
+
+// checkPlaylistHealth checks the target playlist and pauses or resumes adding tracks accordingly.
+func (d *Dispatcher) checkPlaylistHealth(ctx context.Context) {
+	exists, err := d.spotify.PlaylistExists(ctx, d.config.Spotify.PlaylistID)
+	if err != nil {
+		// Transient failures (network, auth refresh) don't change the playlist state
+		d.logger.Debug("Could not check playlist health", zap.Error(err))
+		return
+	}
+
+	if !exists {
+		d.pausePlaylistAdditions(ctx, "Playlist was deleted or is no longer accessible, pausing track additions",
+			"admin.playlist_unavailable")
+		return
+	}
+
+	// Owners and collaborators can change at any time, e.g. when the playlist is made non-collaborative
+	writable, err := d.spotify.CanModifyPlaylist(ctx, d.config.Spotify.PlaylistID)
+	if err != nil {
+		d.logger.Debug("Could not check playlist permissions", zap.Error(err))
+		return
+	}
+
+	if !writable {
+		d.pausePlaylistAdditions(ctx, "Playlist is no longer writable by the bot, pausing track additions",
+			"admin.playlist_read_only")
+		return
+	}
+
+	if d.playlistUnavailable.Swap(false) {
+		d.logger.Info("Playlist is accessible again, resuming track additions",
+			zap.String("playlistID", d.config.Spotify.PlaylistID))
+	}
+	d.warningManager.ClearWarning(ctx, WarningTypePlaylist)
+}
+
+// pausePlaylistAdditions pauses adding tracks and warns admins with the message of the given key.
+func (d *Dispatcher) pausePlaylistAdditions(ctx context.Context, logMessage, messageKey string) {
+	if !d.playlistUnavailable.Swap(true) {
+		d.logger.Error(logMessage, zap.String("playlistID", d.config.Spotify.PlaylistID))
+	}
+
+	d.sendPlaylistWarningIfNeeded(ctx, messageKey)
+}
+
+// sendPlaylistWarningIfNeeded warns admins once that tracks can no longer be added to the playlist.
+func (d *Dispatcher) sendPlaylistWarningIfNeeded(ctx context.Context, messageKey string) {
+	if !d.warningManager.ShouldSendWarning(WarningTypePlaylist) {
+		return
+	}
+
+	groupID := d.getGroupID()
+	if groupID == "" {
+		d.logger.Warn("No group ID available for playlist warning")
+		return
+	}
+
+	adminUserIDs, err := d.frontend.GetAdminUserIDs(ctx, groupID)
+	if err != nil {
+		d.logger.Warn("Failed to get admin user IDs for playlist warning", zap.Error(err))
+		return
+	}
+
+	if len(adminUserIDs) == 0 {
+		d.logger.Warn("No admin user IDs found for playlist warning")
+		return
+	}
+
+	message := d.localizer.T(messageKey, d.config.Spotify.PlaylistID)
+	if err := d.warningManager.SendWarningToAdmins(ctx, WarningTypePlaylist, adminUserIDs, message); err != nil {
+		d.logger.Warn("Failed to send playlist warning", zap.Error(err))
+		return
+	}
+
+	d.logger.Info("Sent playlist unavailable warning message")
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+// playlistHealthTestSpotify reports a configurable playlist state.
+type playlistHealthTestSpotify struct {
+	SpotifyClient
+	exists   bool
+	readOnly bool
+	err      error
+}
+
+func (s *playlistHealthTestSpotify) PlaylistExists(_ context.Context, _ string) (bool, error) {
+	return s.exists, s.err
+}
+
+func (s *playlistHealthTestSpotify) CanModifyPlaylist(_ context.Context, _ string) (bool, error) {
+	return !s.readOnly, nil
+}
+
+func TestCheckPlaylistHealth(t *testing.T) {
+	ctx := context.Background()
+	spotify := &playlistHealthTestSpotify{exists: false}
+	frontend := fake.New()
+	frontend.SetAdmins("42")
+
+	config := DefaultConfig()
+	config.Telegram.GroupID = -100
+	d := &Dispatcher{
+		config:         config,
+		spotify:        spotify,
+		frontend:       frontend,
+		localizer:      i18n.NewLocalizer(i18n.DefaultLanguage),
+		warningManager: NewAdminWarningManager(frontend, nil, zap.NewNop()),
+		logger:         zap.NewNop(),
+	}
+
+	d.checkPlaylistHealth(ctx)
+	if !d.isPlaylistUnavailable() {
+		t.Fatal("Missing playlist should pause track additions")
+	}
+
+	d.checkPlaylistHealth(ctx)
+	if len(frontend.SentMessages()) != 1 || !frontend.HasSentKey("admin.playlist_unavailable", config.Spotify.PlaylistID) {
+		t.Errorf("Expected a single admin warning, got %+v", frontend.SentMessages())
+	}
+
+	// Transient errors keep the current state
+	spotify.err = errors.New("network error")
+	d.checkPlaylistHealth(ctx)
+	if !d.isPlaylistUnavailable() {
+		t.Error("Check errors should not resume track additions")
+	}
+
+	spotify.err = nil
+	spotify.exists = true
+	d.checkPlaylistHealth(ctx)
+	if d.isPlaylistUnavailable() {
+		t.Error("Accessible playlist should resume track additions")
+	}
+	if len(frontend.DeletedMessages()) != 1 {
+		t.Errorf("Expected the admin warning to be cleared, got %v", frontend.DeletedMessages())
+	}
+}
+
+func TestCheckPlaylistHealth_ReadOnly(t *testing.T) {
+	ctx := context.Background()
+	spotify := &playlistHealthTestSpotify{exists: true, readOnly: true}
+	frontend := fake.New()
+	frontend.SetAdmins("42")
+
+	config := DefaultConfig()
+	config.Telegram.GroupID = -100
+	d := &Dispatcher{
+		config:         config,
+		spotify:        spotify,
+		frontend:       frontend,
+		localizer:      i18n.NewLocalizer(i18n.DefaultLanguage),
+		warningManager: NewAdminWarningManager(frontend, nil, zap.NewNop()),
+		logger:         zap.NewNop(),
+	}
+
+	d.checkPlaylistHealth(ctx)
+	if !d.isPlaylistUnavailable() {
+		t.Fatal("Read-only playlist should pause track additions")
+	}
+	if !frontend.HasSentKey("admin.playlist_read_only", config.Spotify.PlaylistID) {
+		t.Errorf("Expected a read-only admin warning, got %+v", frontend.SentMessages())
+	}
+
+	spotify.readOnly = false
+	d.checkPlaylistHealth(ctx)
+	if d.isPlaylistUnavailable() {
+		t.Error("Writable playlist should resume track additions")
+	}
+}
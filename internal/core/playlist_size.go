@@ -0,0 +1,82 @@
+package core
+
+import (
+	"context"
+	"slices"
+
+	"go.uber.org/zap"
+)
+
+// Playlist Size Cap
+// This module keeps the playlist of long-running events within --max-playlist-size by removing
+// the oldest already played tracks before a new track is added
+
+// makeRoomInPlaylist removes the oldest playlist tracks until one more track fits within the maximum playlist size.
+// Only tracks before the currently playing track are removed, as the queue continues from its position
+// in the playlist. Priority tracks, which are inserted at the top, and queued tracks are kept until they played.
+// Without a playing track nothing is removed, since it is unknown which tracks already played.
+// Evicted tracks are dropped from deduplication, so they can be requested again.
+func (d *Dispatcher) makeRoomInPlaylist(ctx context.Context) {
+	maxSize := d.config.App.MaxPlaylistSize
+	if maxSize <= 0 {
+		return
+	}
+
+	playlistID := d.config.Spotify.PlaylistID
+	tracks, err := d.spotify.GetPlaylistTracksWithDetails(ctx, playlistID)
+	if err != nil {
+		d.logger.Warn("Failed to get playlist tracks for the playlist size cap", zap.Error(err))
+		return
+	}
+
+	excess := len(tracks) - maxSize + 1
+	if excess <= 0 {
+		return
+	}
+
+	currentTrackID, err := d.spotify.GetCurrentTrackID(ctx)
+	if err != nil {
+		currentTrackID = ""
+	}
+	currentIndex := slices.IndexFunc(tracks, func(track Track) bool { return track.ID == currentTrackID })
+	if currentTrackID == "" || currentIndex < 0 {
+		d.logger.Debug("Not evicting playlist tracks, the playback position in the playlist is unknown",
+			zap.Int("maxPlaylistSize", maxSize),
+			zap.Int("playlistSize", len(tracks)))
+		return
+	}
+
+	for _, track := range tracks[:currentIndex] {
+		if excess == 0 {
+			return
+		}
+		if d.isEvictionProtected(track.ID) {
+			continue
+		}
+
+		if err := d.spotify.RemoveFromPlaylist(ctx, playlistID, track.ID); err != nil {
+			d.logger.Warn("Failed to evict oldest playlist track",
+				zap.String("trackID", track.ID),
+				zap.Error(err))
+			return
+		}
+		d.dedup.Remove(track.ID)
+		excess--
+
+		d.logger.Info("Evicted oldest playlist track to stay within the maximum playlist size",
+			zap.String("trackID", track.ID),
+			zap.String("title", track.Title),
+			zap.String("artist", track.Artist),
+			zap.Int("maxPlaylistSize", maxSize))
+	}
+}
+
+// isEvictionProtected reports whether a track before the playback position still has to play,
+// because it is a priority track or waiting in the queue.
+func (d *Dispatcher) isEvictionProtected(trackID string) bool {
+	d.priorityTracksMutex.RLock()
+	_, isPriority := d.priorityTracks[trackID]
+	d.priorityTracksMutex.RUnlock()
+
+	return isPriority || d.GetShadowQueuePosition(trackID) >= 0
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// playlistSizeTestSpotify keeps the playlist in memory, in playlist order.
+type playlistSizeTestSpotify struct {
+	SpotifyClient
+	playlist       []string
+	currentTrackID string
+}
+
+func (s *playlistSizeTestSpotify) GetPlaylistTracksWithDetails(_ context.Context, _ string) ([]Track, error) {
+	tracks := make([]Track, 0, len(s.playlist))
+	for _, trackID := range s.playlist {
+		tracks = append(tracks, Track{ID: trackID})
+	}
+	return tracks, nil
+}
+
+func (s *playlistSizeTestSpotify) GetCurrentTrackID(_ context.Context) (string, error) {
+	return s.currentTrackID, nil
+}
+
+func (s *playlistSizeTestSpotify) RemoveFromPlaylist(_ context.Context, _, trackID string) error {
+	s.playlist = slices.DeleteFunc(s.playlist, func(id string) bool { return id == trackID })
+	return nil
+}
+
+func (s *playlistSizeTestSpotify) GetTrack(_ context.Context, trackID string) (*Track, error) {
+	return &Track{ID: trackID}, nil
+}
+
+func (s *playlistSizeTestSpotify) AddToPlaylist(_ context.Context, _, trackID string) error {
+	s.playlist = append(s.playlist, trackID)
+	return nil
+}
+
+// playlistSizeTestDedup remembers added tracks in memory, including removals.
+type playlistSizeTestDedup struct {
+	failedAdditionTestDedup
+}
+
+func (s playlistSizeTestDedup) Remove(trackID string) {
+	delete(s.trackIDs, trackID)
+}
+
+func newPlaylistSizeTestDispatcher(maxSize int, playlist ...string) (*Dispatcher, *playlistSizeTestSpotify,
+	playlistSizeTestDedup) {
+	spotify := &playlistSizeTestSpotify{playlist: playlist}
+	dedup := playlistSizeTestDedup{failedAdditionTestDedup{trackIDs: make(map[string]bool)}}
+	for _, trackID := range playlist {
+		dedup.Add(trackID)
+	}
+
+	config := DefaultConfig()
+	config.App.MaxPlaylistSize = maxSize
+
+	d, _ := newTestDispatcher(config, spotify)
+	d.dedup = dedup
+	return d, spotify, dedup
+}
+
+func TestMakeRoomInPlaylist_Boundary(t *testing.T) {
+	ctx := context.Background()
+
+	d, spotify, _ := newPlaylistSizeTestDispatcher(3, "a", "b")
+	spotify.currentTrackID = "b"
+	d.makeRoomInPlaylist(ctx)
+	if !slices.Equal(spotify.playlist, []string{"a", "b"}) {
+		t.Errorf("Expected no eviction while a track still fits, got %v", spotify.playlist)
+	}
+
+	d, spotify, dedup := newPlaylistSizeTestDispatcher(3, "a", "b", "c")
+	spotify.currentTrackID = "c"
+	d.makeRoomInPlaylist(ctx)
+	if !slices.Equal(spotify.playlist, []string{"b", "c"}) {
+		t.Errorf("Expected the oldest track to be evicted from a full playlist, got %v", spotify.playlist)
+	}
+	if dedup.Has("a") || !dedup.Has("b") {
+		t.Errorf("Expected only the evicted track to be removed from deduplication, got %v", dedup.trackIDs)
+	}
+
+	// A playlist above the cap, e.g. after lowering it, is trimmed down at once
+	d, spotify, _ = newPlaylistSizeTestDispatcher(2, "a", "b", "c", "d")
+	spotify.currentTrackID = "d"
+	d.makeRoomInPlaylist(ctx)
+	if !slices.Equal(spotify.playlist, []string{"d"}) {
+		t.Errorf("Expected the playlist to be trimmed to make room, got %v", spotify.playlist)
+	}
+}
+
+func TestMakeRoomInPlaylist_KeepsCurrentAndUpcomingTracks(t *testing.T) {
+	ctx := context.Background()
+
+	d, spotify, _ := newPlaylistSizeTestDispatcher(2, "a", "b", "c")
+	spotify.currentTrackID = "b"
+	d.makeRoomInPlaylist(ctx)
+	if !slices.Equal(spotify.playlist, []string{"b", "c"}) {
+		t.Errorf("Expected only the played track to be evicted, got %v", spotify.playlist)
+	}
+
+	d, spotify, _ = newPlaylistSizeTestDispatcher(2, "a", "b")
+	d.makeRoomInPlaylist(ctx)
+	if !slices.Equal(spotify.playlist, []string{"a", "b"}) {
+		t.Errorf("Expected no eviction while nothing is playing, got %v", spotify.playlist)
+	}
+}
+
+func TestMakeRoomInPlaylist_KeepsPriorityAndQueuedTracks(t *testing.T) {
+	// Priority tracks are inserted at the top of the playlist, before the playing track
+	d, spotify, dedup := newPlaylistSizeTestDispatcher(4, "priority", "queued", "a", "b", "current", "c")
+	spotify.currentTrackID = "current"
+	d.priorityTracks = map[string]PriorityTrackInfo{"priority": {ResumeSongID: "current"}}
+	d.shadowQueue = []ShadowQueueItem{{TrackID: "queued"}}
+
+	d.makeRoomInPlaylist(context.Background())
+	if !slices.Equal(spotify.playlist, []string{"priority", "queued", "current", "c"}) {
+		t.Errorf("Expected the priority and queued tracks to be kept, got %v", spotify.playlist)
+	}
+	if !dedup.Has("priority") || !dedup.Has("queued") {
+		t.Errorf("Expected the kept tracks to stay deduplicated, got %v", dedup.trackIDs)
+	}
+}
+
+func TestMakeRoomInPlaylist_Disabled(t *testing.T) {
+	d, spotify, _ := newPlaylistSizeTestDispatcher(0, "a", "b", "c")
+
+	if err := d.addToPlaylistAndWakeQueueManager(context.Background(), "d"); err != nil {
+		t.Fatalf("addToPlaylistAndWakeQueueManager() error = %v", err)
+	}
+	if !slices.Equal(spotify.playlist, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected no eviction without a cap, got %v", spotify.playlist)
+	}
+}
+
+func TestAddToPlaylist_EvictsAtCap(t *testing.T) {
+	d, spotify, _ := newPlaylistSizeTestDispatcher(3, "a", "b", "c")
+	spotify.currentTrackID = "b"
+
+	if err := d.addToPlaylistAndWakeQueueManager(context.Background(), "d"); err != nil {
+		t.Fatalf("addToPlaylistAndWakeQueueManager() error = %v", err)
+	}
+	if !slices.Equal(spotify.playlist, []string{"b", "c", "d"}) {
+		t.Errorf("Expected the playlist to stay at its cap, got %v", spotify.playlist)
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Queue Bump
+// This module implements the /bump admin command which moves a playlist track up to play next.
+// Spotify's queue can't be reordered, so tracks already in it (the ones listed by /queue) can't be
+// bumped. Other playlist tracks are moved right after the current track in the playlist, the same
+// way a duplicate request bump vote does, so they play once the queued tracks are done.
+
+const commandBump = "bump"
+
+// handleBumpCommand moves the linked playlist track right after the current track (/bump <track link>).
+func (d *Dispatcher) handleBumpCommand(ctx context.Context, msg *chat.Message) {
+	trackID := d.bumpCommandTrackID(msg)
+	if trackID == "" {
+		d.replyCommandError(ctx, msg, "error.bump.invalid")
+		return
+	}
+
+	d.logger.Info("Bump command received",
+		zap.String("trackID", trackID),
+		zap.String("userID", msg.SenderID),
+		zap.String("userName", msg.SenderName))
+
+	if d.GetShadowQueuePosition(trackID) >= 0 {
+		d.replyCommandError(ctx, msg, "error.bump.queued")
+		return
+	}
+
+	tracks, err := d.spotify.GetPlaylistTracksWithDetails(ctx, d.config.Spotify.PlaylistID)
+	if err != nil {
+		d.logger.Error("Failed to get playlist for bump", zap.Error(err))
+		d.replyCommandError(ctx, msg, "error.bump.failed")
+		return
+	}
+	if !slices.ContainsFunc(tracks, func(track Track) bool { return track.ID == trackID }) {
+		d.replyCommandError(ctx, msg, "error.bump.not_in_playlist")
+		return
+	}
+	if currentTrackID, err := d.spotify.GetCurrentTrackID(ctx); err == nil {
+		if position := bumpPosition(tracks, currentTrackID); position < len(tracks) && tracks[position].ID == trackID {
+			d.replyCommandError(ctx, msg, "error.bump.plays_next")
+			return
+		}
+	}
+
+	err = d.bumpTrack(ctx, trackID)
+	if errors.Is(err, errBumpTrackQueued) {
+		d.replyCommandError(ctx, msg, "error.bump.queued")
+		return
+	}
+	if err != nil {
+		d.logger.Error("Failed to bump track", zap.String("trackID", trackID), zap.Error(err))
+		d.replyCommandError(ctx, msg, "error.bump.failed")
+		return
+	}
+
+	track, err := d.spotify.GetTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Warn("Failed to get bumped track info", zap.Error(err))
+		track = &Track{ID: trackID, Title: unknownTrack, Artist: unknownArtist}
+	}
+
+	bumpedMessage := d.localizerFor(msg).T("success.queue_bumped", track.Artist, track.Title)
+	if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, bumpedMessage); err != nil {
+		d.logger.Error("Failed to confirm bump", zap.Error(err))
+	}
+}
+
+// bumpCommandTrackID returns the ID of the track linked in a /bump command, or "" if there is none.
+func (d *Dispatcher) bumpCommandTrackID(msg *chat.Message) string {
+	links := slices.Clone(msg.URLs)
+	if fields := strings.Fields(msg.Text); len(fields) > 1 {
+		links = append(links, fields[1]) // Also accept spotify:track: URIs
+	}
+	for _, link := range links {
+		if trackID, err := d.spotify.ExtractTrackID(link); err == nil && trackID != "" {
+			return trackID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+// queueBumpTestSpotify is a bump test client that also resolves track links and spotify:track: URIs.
+type queueBumpTestSpotify struct {
+	bumpTestSpotify
+}
+
+func (s *queueBumpTestSpotify) ExtractTrackID(link string) (string, error) {
+	for _, prefix := range []string{"https://open.spotify.com/track/", "spotify:track:"} {
+		if trackID, found := strings.CutPrefix(link, prefix); found {
+			return trackID, nil
+		}
+	}
+	return "", errors.New("not a track link")
+}
+
+// newQueueBumpTestDispatcher creates a dispatcher playing the first playlist track, with the queued tracks
+// already in Spotify's queue.
+func newQueueBumpTestDispatcher(playlist, queued []string) (*Dispatcher, *queueBumpTestSpotify, *fake.Frontend) {
+	spotify := &queueBumpTestSpotify{bumpTestSpotify{playlistSizeTestSpotify: playlistSizeTestSpotify{
+		playlist:       playlist,
+		currentTrackID: playlist[0],
+	}}}
+	d, frontend := newTestDispatcher(nil, spotify)
+	for _, trackID := range queued {
+		d.addToShadowQueue(trackID, "", sourcePlaylist, 0)
+	}
+
+	frontend.SetAdmins("1")
+	frontend.SetCommandHandler(commandBump, true, d.handleBumpCommand)
+	return d, spotify, frontend
+}
+
+// bumpCommand creates a /bump command from the admin with the given argument.
+func bumpCommand(argument string) *chat.Message {
+	msg := &chat.Message{ID: "1", ChatID: "-100", SenderID: "1", SenderName: "Admin", Text: "/bump " + argument}
+	if strings.HasPrefix(argument, "https://") {
+		msg.URLs = []string{argument}
+	}
+	return msg
+}
+
+func TestHandleBumpCommand(t *testing.T) {
+	ctx := context.Background()
+
+	for _, argument := range []string{"https://open.spotify.com/track/c", "spotify:track:c"} {
+		d, spotify, frontend := newQueueBumpTestDispatcher([]string{"current", "a", "b", "c"}, []string{"a"})
+
+		frontend.RunCommand(ctx, bumpCommand(argument))
+		// Once Spotify's queue is done, playback continues in the playlist right after the current track
+		if want := []string{"current", "c", "a", "b"}; !slices.Equal(spotify.playlist, want) {
+			t.Errorf("%q: expected the track to play next from the playlist, got %v", argument, spotify.playlist)
+		}
+		if !frontend.HasSentKey("success.queue_bumped", "", "") {
+			t.Errorf("%q: expected the bump to be confirmed, got %+v", argument, frontend.SentMessages())
+		}
+		if len(d.queueManagementWakeup) != 1 {
+			t.Errorf("%q: expected the queue manager to be woken up", argument)
+		}
+	}
+}
+
+func TestHandleBumpCommand_Refused(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		argument   string
+		messageKey string
+	}{
+		{"No argument", "", "error.bump.invalid"},
+		{"No track link", "3", "error.bump.invalid"},
+		{"Already in Spotify's queue", "spotify:track:a", "error.bump.queued"},
+		{"Not in the playlist", "spotify:track:unknown", "error.bump.not_in_playlist"},
+		{"Already plays next", "spotify:track:b", "error.bump.plays_next"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playlist := []string{"a", "current", "b", "c"}
+			d, spotify, frontend := newQueueBumpTestDispatcher(playlist, []string{"a"})
+			spotify.currentTrackID = "current"
+
+			msg := bumpCommand(tt.argument)
+			frontend.RunCommand(ctx, msg)
+			if !frontend.HasSent(d.formatMessageWithMention(msg, d.localizer.T(tt.messageKey))) {
+				t.Errorf("Expected %s, got %+v", tt.messageKey, frontend.SentMessages())
+			}
+			if !slices.Equal(spotify.playlist, playlist) || frontend.HasSentKey("success.queue_bumped", "", "") {
+				t.Errorf("Expected nothing to be moved, got %v", spotify.playlist)
+			}
+		})
+	}
+}
+
+func TestHandleBumpCommand_KeepsPlaylistOnFailure(t *testing.T) {
+	ctx := context.Background()
+	playlist := []string{"current", "a", "b", "c"}
+	d, spotify, frontend := newQueueBumpTestDispatcher(slices.Clone(playlist), nil)
+	spotify.failedRemovals = 1
+
+	msg := bumpCommand("spotify:track:c")
+	frontend.RunCommand(ctx, msg)
+	if !frontend.HasSent(d.formatMessageWithMention(msg, d.localizer.T("error.bump.failed"))) {
+		t.Errorf("Expected a bump failure reply, got %+v", frontend.SentMessages())
+	}
+	if frontend.HasSentKey("success.queue_bumped", "", "") {
+		t.Error("A failed bump should not be confirmed")
+	}
+	if !slices.Equal(spotify.playlist, playlist) {
+		t.Errorf("Expected the playlist to stay unchanged, got %v", spotify.playlist)
+	}
+}
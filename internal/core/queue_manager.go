@@ -15,6 +15,10 @@ import (
 // This module handles all queue-related operations including playlist addition,
 // priority queue management, queue filling, and queue-fill track logic
 
+// ErrPlaylistEmpty is returned for queue-filling recommendations when the playlist has no tracks to base them on
+// and no fallback genre is configured.
+var ErrPlaylistEmpty = errors.New("playlist is empty, cannot generate recommendations")
+
 // addToPlaylist adds a track to the Spotify playlist or queue based on priority.
 func (d *Dispatcher) addToPlaylist(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message,
 	trackID string) {
@@ -26,7 +30,9 @@ func (d *Dispatcher) addToPlaylist(ctx context.Context, msgCtx *MessageContext,
 
 	if isAdmin && d.llm != nil {
 		var err error
-		isPriority, err = d.llm.IsPriorityRequest(ctx, originalMsg.Text)
+		llmCtx, cancel := d.withLLMTimeout(ctx)
+		isPriority, err = d.llm.IsPriorityRequest(llmCtx, originalMsg.Text)
+		cancel()
 		if err != nil {
 			d.logger.Warn("Failed to check priority status, treating as regular request",
 				zap.Error(err),
@@ -42,11 +48,42 @@ func (d *Dispatcher) addToPlaylist(ctx context.Context, msgCtx *MessageContext,
 	// Store priority flag in message context for approval workflow
 	msgCtx.IsPriority = isPriority
 
+	// Reject explicit tracks before any approval is requested
+	if d.isExplicitTrackBlocked(ctx, msgCtx, originalMsg, trackID) {
+		return
+	}
+
+	// Reject interludes and overly long tracks
+	if d.isTrackDurationRejected(ctx, msgCtx, originalMsg, trackID) {
+		return
+	}
+
+	// Reject tracks that were added recently, even if they are no longer in the playlist
+	if d.isTrackCoolingDown(ctx, msgCtx, originalMsg, trackID) {
+		return
+	}
+
+	// Reject the same recording released under a different track ID
+	if d.isRecordingDuplicate(ctx, msgCtx, originalMsg, trackID) {
+		return
+	}
+
+	// Ask before adding a different version of a song that is already in the playlist
+	if d.isNearDuplicateDeclined(ctx, msgCtx, originalMsg, trackID) {
+		return
+	}
+
+	// Enforce per-user request quota before any approval is requested (admins are exempt)
+	if !isAdmin && d.isUserQuotaExceeded(ctx, msgCtx, originalMsg) {
+		return
+	}
+
 	// Check if admin approval is required
 	// If AdminNeedsApproval is enabled, even admins need approval
 	// Otherwise, only non-admins need approval when AdminApproval is enabled
+	// VIPs skip approval, but not the checks above
 	needsApproval := d.isAdminApprovalRequired() && (!isAdmin || d.isAdminNeedsApproval())
-	if needsApproval {
+	if needsApproval && !d.bypassesApproval(msgCtx, originalMsg) {
 		d.awaitAdminApproval(ctx, msgCtx, originalMsg, trackID)
 		return
 	}
@@ -107,15 +144,19 @@ func (d *Dispatcher) executePriorityQueue(ctx context.Context, msgCtx *MessageCo
 		zap.String("resumeSongID", currentTrackID))
 
 	// Add to playlist at position 0 (top) for history/deduplication to avoid replaying later
+	d.makeRoomInPlaylist(ctx)
 	if err := d.spotify.AddToPlaylistAtPosition(ctx, d.config.Spotify.PlaylistID, trackID, 0); err != nil {
 		d.logger.Error("Failed to add priority track to playlist",
 			zap.String("trackID", trackID),
 			zap.Error(err))
-		d.reactError(ctx, msgCtx, originalMsg, d.localizer.T("error.playlist.add_failed"))
+		d.reactError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.playlist.add_failed"))
 		return
 	}
 
 	d.dedup.Add(trackID)
+	d.recordTrackSignature(ctx, trackID)
+	d.recordTrackISRC(ctx, trackID)
+	d.recordTrackCooldown(trackID)
 	d.reactPriorityQueued(ctx, msgCtx, originalMsg, trackID)
 }
 
@@ -129,7 +170,8 @@ func (d *Dispatcher) executePlaylistAddWithReaction(
 		d.logger.Error("Failed to add to playlist",
 			zap.String("trackID", trackID),
 			zap.Error(err))
-		d.reactError(ctx, msgCtx, originalMsg, d.localizer.T("error.playlist.add_failed"))
+		d.recordFailedAddition(originalMsg, trackID, err)
+		d.reactError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.playlist.add_failed"))
 		return
 	}
 
@@ -140,13 +182,17 @@ func (d *Dispatcher) executePlaylistAddWithReaction(
 // and wakes up the queue manager to fill the queue from the updated playlist.
 // This should be used for all regular playlist additions (not priority tracks).
 func (d *Dispatcher) addToPlaylistAndWakeQueueManager(ctx context.Context, trackID string) error {
-	// Add track to playlist.
+	// Add track to playlist, evicting the oldest tracks if it is full.
+	d.makeRoomInPlaylist(ctx)
 	if err := d.spotify.AddToPlaylist(ctx, d.config.Spotify.PlaylistID, trackID); err != nil {
 		return err
 	}
 
 	// Mark as seen to prevent duplicates.
 	d.dedup.Add(trackID)
+	d.recordTrackSignature(ctx, trackID)
+	d.recordTrackISRC(ctx, trackID)
+	d.recordTrackCooldown(trackID)
 
 	// Wake up queue manager to fill queue from updated playlist.
 	select {
@@ -196,6 +242,11 @@ func (d *Dispatcher) runQueueAndPlaylistManagement(ctx context.Context) {
 func (d *Dispatcher) checkAndManageQueue(ctx context.Context) {
 	d.logger.Debug("checkAndManageQueue called")
 
+	if d.isPlaylistUnavailable() {
+		d.logger.Debug("Playlist is not accessible, skipping queue management")
+		return
+	}
+
 	if !d.checkSpotifyDeviceAvailability(ctx) {
 		return
 	}
@@ -216,6 +267,10 @@ func (d *Dispatcher) checkSpotifyDeviceAvailability(ctx context.Context) bool {
 		return false
 	}
 
+	if !hasActiveDevice && d.transferToPreferredDevice(ctx) {
+		hasActiveDevice = true
+	}
+
 	if !hasActiveDevice {
 		d.logger.Debug("No active Spotify device found, skipping queue management")
 		d.sendDeviceWarningIfNeeded(ctx)
@@ -335,6 +390,13 @@ func (d *Dispatcher) tryFillFromPlaylistTracks(ctx context.Context, targetDurati
 		return currentDuration, nil
 	}
 
+	// The cached playlist duration tells an empty playlist apart without fetching all of its tracks
+	if playlistDuration, err := d.spotify.GetPlaylistDuration(ctx, d.config.Spotify.PlaylistID); err == nil &&
+		playlistDuration == 0 {
+		d.logger.Debug("Playlist is empty, no playlist tracks to queue")
+		return currentDuration, nil
+	}
+
 	nextTracks, err := d.getNextPlaylistTracks(ctx)
 	if err != nil {
 		return currentDuration, fmt.Errorf("failed to get playlist tracks: %w", err)
@@ -348,6 +410,7 @@ func (d *Dispatcher) tryFillFromPlaylistTracks(ctx context.Context, targetDurati
 	// Add tracks one by one until we reach target duration
 	var addedDuration time.Duration
 	successCount := 0
+	diversity := d.newArtistDiversityFilter()
 
 	d.logger.Info("Adding playlist tracks to queue until target duration reached",
 		zap.Duration("neededDuration", neededDuration),
@@ -368,6 +431,23 @@ func (d *Dispatcher) tryFillFromPlaylistTracks(ctx context.Context, targetDurati
 			continue
 		}
 
+		// Skip tracks that were skipped recently
+		if d.isRecentlySkipped(track.ID) {
+			d.logger.Debug("Skipping recently skipped track",
+				zap.String("trackID", track.ID),
+				zap.String("artist", track.Artist),
+				zap.String("title", track.Title))
+			continue
+		}
+
+		// Skip tracks by recently played or queued artists
+		if diversity.shouldSkip(track.Artist) {
+			d.logger.Debug("Skipping track by recently played artist",
+				zap.String("trackID", track.ID),
+				zap.String("artist", track.Artist))
+			continue
+		}
+
 		if err := d.AddToQueueWithShadowTracking(ctx, &track, sourcePlaylist); err != nil {
 			d.logger.Warn("Failed to add track to queue",
 				zap.String("trackID", track.ID), zap.Error(err))
@@ -376,6 +456,7 @@ func (d *Dispatcher) tryFillFromPlaylistTracks(ctx context.Context, targetDurati
 
 		addedDuration += track.Duration
 		successCount++
+		diversity.add(track.Artist)
 		d.logger.Debug("Added playlist track to queue",
 			zap.String("trackID", track.ID),
 			zap.Duration("trackDuration", track.Duration),
@@ -398,9 +479,20 @@ func (d *Dispatcher) tryFillFromPlaylistTracks(ctx context.Context, targetDurati
 }
 
 // getNextPlaylistTracks retrieves the next tracks from the playlist based on current position.
+// An empty playlist has no next tracks, leaving the queue to be filled with recommendations.
 func (d *Dispatcher) getNextPlaylistTracks(ctx context.Context) ([]Track, error) {
+	playlistTracks, err := d.spotify.GetPlaylistTracksWithDetails(ctx, d.config.Spotify.PlaylistID)
+	if err != nil {
+		d.logger.Warn("Failed to get playlist tracks", zap.Error(err))
+		return nil, err
+	}
+	if len(playlistTracks) == 0 {
+		d.logger.Debug("Playlist is empty, no playlist tracks to queue")
+		return nil, nil
+	}
+
 	// Get logical playlist position to ensure correct progression after priority songs
-	logicalPosition, err := d.getLogicalPlaylistPosition(ctx)
+	logicalPosition, err := d.findLogicalPlaylistPosition(ctx, playlistTracks)
 	if err != nil {
 		d.logger.Warn("Failed to get logical playlist position", zap.Error(err))
 		return nil, err
@@ -437,25 +529,28 @@ func (d *Dispatcher) fillQueueToTargetDuration(ctx context.Context, targetDurati
 	d.logger.Info("Need to add tracks to fill queue duration",
 		zap.Duration("neededDuration", neededDuration))
 
-	// Create a new flow for this queue filling operation
-	flow := d.createQueueManagementFlow("fill")
-
-	// Check if we've exceeded the rejection limit for auto-approval
-	autoApprove := flow.RejectionCount >= d.config.App.MaxQueueTrackReplacements
-
-	// Always use the unified approval workflow
-	trackID, searchQuery, newTrackMood, err := d.spotify.GetRecommendedTrack(ctx)
+	// Always use the unified approval workflow, avoiding artists that were played or queued recently
+	track, searchQuery, newTrackMood, err := d.getDiverseRecommendedTrack(ctx)
+	if errors.Is(err, ErrPlaylistEmpty) {
+		// Expected on a fresh event, so only explain it once instead of on every queue check
+		if !d.emptyPlaylistReported.Swap(true) {
+			d.logger.Warn("Playlist is empty and no fallback genre is configured, " +
+				"waiting for the first song request to fill the queue (set --fallback-genre to start right away)")
+		}
+		return
+	}
 	if err != nil {
 		d.logger.Warn("Failed to get queue-filling track", zap.Error(err))
 		return
 	}
+	d.emptyPlaylistReported.Store(false)
+	trackID := track.ID
 
-	// Get track info for the approval message
-	track, trackErr := d.spotify.GetTrack(ctx, trackID)
-	if trackErr != nil {
-		d.logger.Warn("Could not get track info for queue-filling track approval", zap.Error(trackErr))
-		track = &Track{Title: unknownTrack, Artist: unknownArtist, URL: ""}
-	}
+	// Create a new flow for this queue filling operation
+	flow := d.createQueueManagementFlow("fill")
+
+	// Check if we've exceeded the rejection limit for auto-approval
+	autoApprove := flow.RejectionCount >= d.config.App.MaxQueueTrackReplacements
 
 	// Track this queue-filling track for approval (DO NOT add to queue/playlist yet in auto-approve case)
 	trackName := fmt.Sprintf("%s - %s", track.Artist, track.Title)
@@ -512,6 +607,7 @@ func (d *Dispatcher) addApprovedQueueTrack(ctx context.Context, trackID string)
 		zap.String("trackID", trackID),
 		zap.String("artist", track.Artist),
 		zap.String("title", track.Title))
+	d.recordAutoDJAddition(ctx)
 
 	// Find and remove the flow that handled this track
 	d.queueManagementMutex.Lock()
@@ -551,6 +647,10 @@ func (d *Dispatcher) handleQueueTrackDecision(ctx context.Context, trackID strin
 
 	if approved {
 		d.handleApprovedQueueTrack(ctx, trackID, trackName)
+		// The track already left the flow's pending tracks, so addApprovedQueueTrack can't find the flow
+		if flow != nil {
+			d.removeQueueManagementFlow(flow.FlowID)
+		}
 	} else {
 		d.handleRejectedQueueTrack(ctx, trackID, trackName, flow)
 	}
@@ -662,7 +762,7 @@ func (d *Dispatcher) findAndSuggestReplacementTrack(ctx context.Context, flow *Q
 	autoApprove := rejectionCount >= d.config.App.MaxQueueTrackReplacements
 
 	// Always use the unified approval workflow
-	newTrackID, newSearchQuery, newTrackMood, err := d.spotify.GetRecommendedTrack(ctx)
+	newTrackID, newSearchQuery, newTrackMood, err := d.spotify.GetRecommendedTrack(ctx, d.lastQueuedTrackID())
 	if err != nil {
 		d.logger.Warn("Failed to get replacement queue track", zap.Error(err))
 		d.resetQueueManagementFlag()
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// emptyPlaylistTestSpotify has an empty playlist and no fallback genre to recommend tracks from.
+type emptyPlaylistTestSpotify struct {
+	SpotifyClient
+}
+
+func (emptyPlaylistTestSpotify) GetPlaylistTracksWithDetails(_ context.Context, _ string) ([]Track, error) {
+	return nil, nil
+}
+
+func (emptyPlaylistTestSpotify) GetRecommendedTrack(_ context.Context, _ string) (trackID, searchQuery,
+	newTrackMood string, err error) {
+	return "", "", "", ErrPlaylistEmpty
+}
+
+func TestQueueFilling_EmptyPlaylist(t *testing.T) {
+	ctx := context.Background()
+	d := &Dispatcher{
+		config:               DefaultConfig(),
+		spotify:              emptyPlaylistTestSpotify{},
+		logger:               zap.NewNop(),
+		queueManagementFlows: make(map[string]*QueueManagementFlow),
+	}
+
+	tracks, err := d.getNextPlaylistTracks(ctx)
+	if err != nil || tracks != nil {
+		t.Errorf("getNextPlaylistTracks() = %v, %v, expected no tracks and no error", tracks, err)
+	}
+
+	for range 2 {
+		d.fillQueueToTargetDuration(ctx, time.Hour, 0)
+	}
+	if !d.emptyPlaylistReported.Load() {
+		t.Error("Expected the empty playlist to be reported")
+	}
+	if len(d.queueManagementFlows) != 0 {
+		t.Errorf("Expected no queue management flows, got %d", len(d.queueManagementFlows))
+	}
+}
+
+// playlistDurationTestSpotify has an empty playlist and counts the fetches of its tracks.
+type playlistDurationTestSpotify struct {
+	emptyPlaylistTestSpotify
+	durationErr  error
+	trackFetches int
+}
+
+func (s *playlistDurationTestSpotify) GetPlaylistDuration(_ context.Context, _ string) (time.Duration, error) {
+	return 0, s.durationErr
+}
+
+func (s *playlistDurationTestSpotify) GetPlaylistTracksWithDetails(_ context.Context, _ string) ([]Track, error) {
+	s.trackFetches++
+	return nil, nil
+}
+
+func TestTryFillFromPlaylistTracks_EmptyPlaylist(t *testing.T) {
+	ctx := context.Background()
+
+	spotify := &playlistDurationTestSpotify{}
+	d, _ := newTestDispatcher(nil, spotify)
+	if duration, err := d.tryFillFromPlaylistTracks(ctx, time.Hour, time.Minute); err != nil || duration != time.Minute {
+		t.Errorf("tryFillFromPlaylistTracks() = %v, %v; want the unchanged duration", duration, err)
+	}
+	if spotify.trackFetches != 0 {
+		t.Errorf("Expected the cached duration to skip fetching the tracks, got %d fetches", spotify.trackFetches)
+	}
+
+	// Without the duration, the tracks are fetched to find out
+	spotify.durationErr = errors.New("snapshot unavailable")
+	if _, err := d.tryFillFromPlaylistTracks(ctx, time.Hour, time.Minute); err != nil || spotify.trackFetches != 1 {
+		t.Errorf("Expected the tracks to be fetched, got %d fetches, %v", spotify.trackFetches, err)
+	}
+}
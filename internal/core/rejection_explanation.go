@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Rejection Explanation
+// This module suggests what users may have meant when their request had no confident match
+
+const (
+	// maxSuggestedCandidates is how many of the top search results are suggested.
+	maxSuggestedCandidates = 3
+	// rejectionExplanationTimeout bounds the optional LLM suggestion, as listing the candidates works too.
+	rejectionExplanationTimeout = 5 * time.Second
+)
+
+// replyNoConfidentMatch asks which song the user meant, suggesting the top search candidates.
+// Without candidates the user is just asked which song they meant.
+func (d *Dispatcher) replyNoConfidentMatch(ctx context.Context, msgCtx *MessageContext,
+	originalMsg *chat.Message, candidates []Track) {
+	if len(candidates) == 0 {
+		d.askWhichSong(ctx, msgCtx, originalMsg)
+		return
+	}
+	msgCtx.State = StateAskWhichSong
+
+	if err := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, thumbsDownReaction); err != nil {
+		d.logger.Debug("Failed to react with thumbs down", zap.Error(err))
+	}
+
+	candidates = candidates[:min(len(candidates), maxSuggestedCandidates)]
+	suggestion := d.suggestCandidates(ctx, originalMsg, msgCtx.Input.Text, candidates)
+	message := d.formatMessageWithMention(originalMsg, suggestion)
+	if _, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, message); err != nil {
+		d.logger.Error("Failed to suggest candidates", zap.Error(err))
+	}
+}
+
+// suggestCandidates lets the LLM phrase a suggestion in the requester's language,
+// falling back to listing the candidates if it fails or takes too long.
+func (d *Dispatcher) suggestCandidates(ctx context.Context, originalMsg *chat.Message, userText string,
+	candidates []Track) string {
+	localizer := d.localizerFor(originalMsg)
+
+	if d.llm != nil {
+		llmCtx, cancel := d.withLLMTimeout(ctx)
+		llmCtx, cancelExplanation := context.WithTimeout(llmCtx, rejectionExplanationTimeout)
+		explanation, err := d.llm.ExplainRejection(llmCtx, userText, candidates, localizer.T("format.language_name"))
+		cancelExplanation()
+		cancel()
+		if err == nil && strings.TrimSpace(explanation) != "" {
+			return strings.TrimSpace(explanation)
+		}
+		d.logger.Debug("Rejection explanation unavailable, listing candidates", zap.Error(err))
+	}
+
+	var list strings.Builder
+	for i := range candidates {
+		list.WriteString(localizer.T("format.suggestion", candidates[i].Artist, candidates[i].Title))
+	}
+	return localizer.T("prompt.which_song_suggestions", list.String())
+}
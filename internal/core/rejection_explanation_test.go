@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+// explainRejectionTestLLM returns a fixed explanation or error and records the candidates.
+type explainRejectionTestLLM struct {
+	LLMProvider
+	explanation string
+	err         error
+	candidates  []Track
+	language    string
+}
+
+func (l *explainRejectionTestLLM) ExplainRejection(_ context.Context, _ string, candidates []Track,
+	language string) (string, error) {
+	l.candidates = candidates
+	l.language = language
+	return l.explanation, l.err
+}
+
+func newRejectionTestDispatcher(llm LLMProvider) (*Dispatcher, *fake.Frontend) {
+	d, frontend := newTestDispatcher(nil, nil)
+	d.llm = llm
+	return d, frontend
+}
+
+func rejectionTestCandidates() []Track {
+	return []Track{
+		{ID: "1", Artist: "AC/DC", Title: "Hells Bells"},
+		{ID: "2", Artist: "AC/DC", Title: "Highway to Hell"},
+		{ID: "3", Artist: "AC/DC", Title: "Thunderstruck"},
+		{ID: "4", Artist: "AC/DC", Title: "Back in Black"},
+	}
+}
+
+func TestReplyNoConfidentMatch_UsesLLMSuggestion(t *testing.T) {
+	llm := &explainRejectionTestLLM{explanation: "Did you mean Hells Bells by AC/DC?"}
+	d, frontend := newRejectionTestDispatcher(llm)
+	msg := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice", Text: "hels bels"}
+
+	d.replyNoConfidentMatch(context.Background(), &MessageContext{Input: InputMessage{Text: msg.Text}}, msg,
+		rejectionTestCandidates())
+
+	if len(llm.candidates) != maxSuggestedCandidates || llm.language != "English" {
+		t.Errorf("Expected the top %d candidates in English, got %d in %q",
+			maxSuggestedCandidates, len(llm.candidates), llm.language)
+	}
+	if sent, _ := frontend.LastSent(); !strings.Contains(sent.Text, llm.explanation) {
+		t.Errorf("Expected the LLM suggestion, got %q", sent.Text)
+	}
+	if !frontend.HasReacted(msg.ID, thumbsDownReaction) {
+		t.Error("Expected the request to be marked as unclear")
+	}
+}
+
+func TestReplyNoConfidentMatch_ListsCandidatesWithoutLLM(t *testing.T) {
+	d, frontend := newRejectionTestDispatcher(&explainRejectionTestLLM{err: errors.New("timeout")})
+	msg := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice", Text: "hels bels"}
+
+	d.replyNoConfidentMatch(context.Background(), &MessageContext{Input: InputMessage{Text: msg.Text}}, msg,
+		rejectionTestCandidates())
+
+	expected := d.localizer.T("prompt.which_song_suggestions",
+		d.localizer.T("format.suggestion", "AC/DC", "Hells Bells")+
+			d.localizer.T("format.suggestion", "AC/DC", "Highway to Hell")+
+			d.localizer.T("format.suggestion", "AC/DC", "Thunderstruck"))
+	if sent, _ := frontend.LastSent(); !strings.Contains(sent.Text, expected) {
+		t.Errorf("Expected the top candidates to be listed, got %q", sent.Text)
+	}
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Request Message Cleanup
+// This module deletes request messages once their song was added and acknowledged,
+// keeping the group tidy when the bot is allowed to delete messages
+
+// requestMessageDeleteDelay gives requesters time to see the acknowledgment before their message disappears.
+const requestMessageDeleteDelay = 30 * time.Second
+
+// scheduleRequestMessageDeletion deletes the request message of an added song after requestMessageDeleteDelay.
+func (d *Dispatcher) scheduleRequestMessageDeletion(originalMsg *chat.Message) {
+	if !d.config.App.DeleteRequestMessages {
+		return
+	}
+
+	// Other songs of a message asking for several may still await confirmation
+	if len(originalMsg.Requests) > 1 {
+		return
+	}
+
+	time.AfterFunc(requestMessageDeleteDelay, func() {
+		d.deleteRequestMessage(context.Background(), originalMsg)
+	})
+}
+
+// deleteRequestMessage deletes a request message, leaving it in place if the bot may not delete it.
+func (d *Dispatcher) deleteRequestMessage(ctx context.Context, originalMsg *chat.Message) {
+	err := d.frontend.DeleteMessage(ctx, originalMsg.ChatID, originalMsg.ID)
+	if err == nil {
+		d.logger.Debug("Deleted request message",
+			zap.String("messageID", originalMsg.ID),
+			zap.String("userID", originalMsg.SenderID))
+		return
+	}
+
+	// Warn once, bots need the delete messages admin permission and will keep failing until they get it
+	if !d.requestDeletionFailed.Swap(true) {
+		d.logger.Warn("Failed to delete request message, make sure the bot is an admin allowed to delete messages",
+			zap.String("messageID", originalMsg.ID),
+			zap.Error(err))
+		return
+	}
+	d.logger.Debug("Failed to delete request message",
+		zap.String("messageID", originalMsg.ID),
+		zap.Error(err))
+}
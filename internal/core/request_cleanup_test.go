@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+// noDeletePermissionFrontend fails to delete messages like a bot without the delete permission.
+type noDeletePermissionFrontend struct {
+	*fake.Frontend
+}
+
+func (f noDeletePermissionFrontend) DeleteMessage(_ context.Context, _, _ string) error {
+	return errors.New("Bad Request: message can't be deleted")
+}
+
+func TestDeleteRequestMessage(t *testing.T) {
+	ctx := context.Background()
+	msg := &chat.Message{ID: "42", ChatID: "-100", SenderID: "2"}
+	frontend := fake.New()
+
+	d := &Dispatcher{config: DefaultConfig(), frontend: frontend, logger: zap.NewNop()}
+	d.deleteRequestMessage(ctx, msg)
+	if !slices.Contains(frontend.DeletedMessages(), "42") {
+		t.Errorf("Expected the request message to be deleted, got %v", frontend.DeletedMessages())
+	}
+
+	d.frontend = noDeletePermissionFrontend{Frontend: fake.New()}
+	d.deleteRequestMessage(ctx, msg)
+	d.deleteRequestMessage(ctx, msg)
+	if !d.requestDeletionFailed.Load() {
+		t.Error("Expected the missing delete permission to be remembered")
+	}
+}
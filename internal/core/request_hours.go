@@ -0,0 +1,21 @@
+package core
+
+import (
+	"time"
+)
+
+// Request Hours
+// This module limits song requests to daily opening hours, like those of a venue,
+// rejecting requests outside of them with the time requests reopen
+
+// requestHoursTimeLayout formats the reopening time in replies to requests outside the request hours.
+const requestHoursTimeLayout = "15:04"
+
+// requestsClosedUntil returns when song requests reopen if now is outside the request hours.
+func (d *Dispatcher) requestsClosedUntil(now time.Time) (time.Time, bool) {
+	schedule := d.config.App.RequestSchedule
+	if schedule == nil || schedule.IsOpen(now) {
+		return time.Time{}, false
+	}
+	return schedule.NextOpening(now).In(schedule.Location()), true
+}
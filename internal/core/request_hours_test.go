@@ -0,0 +1,79 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+func TestRequestsClosedUntil(t *testing.T) {
+	d := &Dispatcher{config: DefaultConfig()}
+	now := time.Date(2025, time.June, 14, 12, 0, 0, 0, time.UTC)
+
+	if _, closed := d.requestsClosedUntil(now); closed {
+		t.Error("Expected requests to be accepted any time without request hours")
+	}
+
+	schedule, err := ParseSchedule("18:00-02:00", time.UTC)
+	if err != nil {
+		t.Fatalf("ParseSchedule unexpected error: %v", err)
+	}
+	d.config.App.RequestSchedule = schedule
+
+	reopensAt, closed := d.requestsClosedUntil(now)
+	if !closed || !reopensAt.Equal(time.Date(2025, time.June, 14, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected requests to be closed until 18:00, got %v, %v", reopensAt, closed)
+	}
+	if _, closed := d.requestsClosedUntil(now.Add(7 * time.Hour)); closed {
+		t.Error("Expected requests to be accepted during the request hours")
+	}
+}
+
+func TestHandleMessage_OutsideRequestHours(t *testing.T) {
+	frontend := fake.New()
+	config := DefaultConfig()
+
+	// A window of one minute that closed a minute ago
+	now := time.Now().UTC()
+	window := now.Add(-2*time.Minute).Format(requestHoursTimeLayout) + "-" + now.Add(-time.Minute).Format(requestHoursTimeLayout)
+	schedule, err := ParseSchedule(window, time.UTC)
+	if err != nil {
+		t.Fatalf("ParseSchedule unexpected error: %v", err)
+	}
+	config.App.RequestSchedule = schedule
+
+	d := &Dispatcher{
+		config:    config,
+		frontend:  frontend,
+		localizer: i18n.NewLocalizer(i18n.DefaultLanguage),
+		metrics:   noopMetricsRecorder{},
+		logger:    zap.NewNop(),
+	}
+
+	msg := &chat.Message{
+		ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice",
+		Text: "https://open.spotify.com/track/abc", URLs: []string{"https://open.spotify.com/track/abc"},
+	}
+	d.handleMessage(msg)
+
+	closed := d.localizer.T("error.outside_request_hours", now.Add(-2*time.Minute).Format(requestHoursTimeLayout))
+	deadline := time.Now().Add(time.Second)
+	for {
+		if sent, ok := frontend.LastSent(); ok && strings.Contains(sent.Text, closed) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %q naming the reopening time, got %+v", closed, frontend.SentMessages())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !frontend.HasReacted(msg.ID, chat.ReactionPaused) {
+		t.Error("Expected the request to be marked as rejected")
+	}
+}
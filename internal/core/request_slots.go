@@ -0,0 +1,79 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Request Concurrency
+// This module bounds how many requests are searched and ranked at the same time, so a rush doesn't hit rate limits
+
+// requestSlotWait is how long a request waits for a free processing slot before the requester is told to retry.
+const requestSlotWait = 15 * time.Second
+
+// newRequestSlots creates the processing slots for the configured limit (nil without a limit).
+func newRequestSlots(limit int) chan struct{} {
+	if limit <= 0 {
+		return nil
+	}
+	return make(chan struct{}, limit)
+}
+
+// acquireRequestSlot waits for a free processing slot and returns the function releasing it again.
+// Returns false if no slot became free within requestSlotWait. Without a limit there is always a free slot.
+func (d *Dispatcher) acquireRequestSlot(ctx context.Context) (func(), bool) {
+	if d.requestSlots == nil {
+		return func() {}, true
+	}
+
+	var once sync.Once
+	release := func() { once.Do(func() { <-d.requestSlots }) }
+
+	// Take a free slot right away, even if the context is already done
+	select {
+	case d.requestSlots <- struct{}{}:
+		return release, true
+	default:
+	}
+
+	timer := time.NewTimer(requestSlotWait)
+	defer timer.Stop()
+
+	select {
+	case d.requestSlots <- struct{}{}:
+		return release, true
+	case <-timer.C:
+		return nil, false
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// awaitUserInput prepares a request for waiting on an answer: the processing reaction is removed
+// and its processing slot released, so requests waiting for approval don't hold up new ones.
+func (d *Dispatcher) awaitUserInput(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message) {
+	if msgCtx.releaseSlot != nil {
+		msgCtx.releaseSlot()
+	}
+	d.clearProcessingReaction(ctx, originalMsg)
+}
+
+// rejectBusyRequest tells the requester to try again, as no processing slot became free in time.
+func (d *Dispatcher) rejectBusyRequest(ctx context.Context, msg *chat.Message) {
+	d.logger.Warn("Rejecting request, too many requests are being processed",
+		zap.String("messageID", msg.ID),
+		zap.Int("maxConcurrentRequests", d.config.App.MaxConcurrentRequests))
+	d.recordRequest()
+	d.recordRequestRejected(rejectReasonBusy)
+
+	if err := d.frontend.React(ctx, msg.ChatID, msg.ID, chat.ReactionYawning); err != nil {
+		d.logger.Debug("Failed to add busy reaction", zap.Error(err))
+	}
+
+	d.replyCommandError(ctx, msg, "error.busy")
+}
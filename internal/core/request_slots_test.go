@@ -0,0 +1,81 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+func newRequestSlotsTestDispatcher(limit int) (*Dispatcher, *fake.Frontend) {
+	config := DefaultConfig()
+	config.App.MaxConcurrentRequests = limit
+	return newTestDispatcher(config, nil)
+}
+
+func TestAcquireRequestSlot(t *testing.T) {
+	d, _ := newRequestSlotsTestDispatcher(1)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	release, ok := d.acquireRequestSlot(context.Background())
+	if !ok {
+		t.Fatal("Expected a free slot")
+	}
+	if _, ok := d.acquireRequestSlot(cancelled); ok {
+		t.Fatal("Expected no free slot while the only one is taken")
+	}
+
+	// Releasing twice must not free a slot taken by another request
+	release()
+	release()
+	if _, ok := d.acquireRequestSlot(cancelled); !ok {
+		t.Fatal("Expected the released slot to be free again")
+	}
+	if _, ok := d.acquireRequestSlot(cancelled); ok {
+		t.Error("Expected a slot to be freed only once")
+	}
+}
+
+func TestAcquireRequestSlot_Unlimited(t *testing.T) {
+	d, _ := newRequestSlotsTestDispatcher(0)
+	for range DefaultMaxConcurrentRequests + 1 {
+		if _, ok := d.acquireRequestSlot(context.Background()); !ok {
+			t.Fatal("Expected requests not to be limited")
+		}
+	}
+}
+
+func TestAwaitUserInput_ReleasesSlot(t *testing.T) {
+	d, _ := newRequestSlotsTestDispatcher(1)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	release, _ := d.acquireRequestSlot(context.Background())
+	msgCtx := &MessageContext{releaseSlot: release}
+	d.awaitUserInput(context.Background(), msgCtx, &chat.Message{ID: "1", ChatID: "-100"})
+
+	if _, ok := d.acquireRequestSlot(cancelled); !ok {
+		t.Error("Expected requests awaiting an answer not to count against the limit")
+	}
+}
+
+func TestProcessMessage_RejectsWhenBusy(t *testing.T) {
+	d, frontend := newRequestSlotsTestDispatcher(1)
+	d.acquireRequestSlot(context.Background())
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msg := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice", Text: "Creep"}
+	d.processMessage(cancelled, &MessageContext{Input: d.convertToInputMessage(msg)}, msg)
+
+	busy := d.localizer.T("error.busy")
+	if sent, _ := frontend.LastSent(); !strings.Contains(sent.Text, busy) {
+		t.Errorf("Expected %q, got %+v", busy, frontend.SentMessages())
+	}
+	if frontend.HasReacted(msg.ID, chat.ReactionProcessing) {
+		t.Error("Expected the busy request not to be processed")
+	}
+}
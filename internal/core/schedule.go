@@ -0,0 +1,104 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// scheduleTimeLayout is the layout of the times of a schedule window, like "18:00".
+const scheduleTimeLayout = "15:04"
+
+// Schedule is a daily window of wall clock times in a time zone, like opening hours.
+// A window ending before it starts spans midnight, e.g. 18:00-02:00 is open from 18:00 until 02:00 the next day.
+// A window starting and ending at the same time is always open.
+type Schedule struct {
+	start    time.Duration // Opening time as offset from midnight
+	end      time.Duration // Closing time as offset from midnight
+	location *time.Location
+}
+
+// ParseSchedule parses a window like "18:00-02:00" in the given time zone (nil uses the local time zone).
+func ParseSchedule(window string, location *time.Location) (*Schedule, error) {
+	startText, endText, found := strings.Cut(window, "-")
+	if !found {
+		return nil, fmt.Errorf("invalid schedule %q, expected a window like 18:00-02:00", window)
+	}
+
+	start, err := parseScheduleTime(startText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule start: %w", err)
+	}
+	end, err := parseScheduleTime(endText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule end: %w", err)
+	}
+
+	if location == nil {
+		location = time.Local
+	}
+
+	return &Schedule{start: start, end: end, location: location}, nil
+}
+
+// parseScheduleTime parses a wall clock time like "18:00" into its offset from midnight.
+func parseScheduleTime(text string) (time.Duration, error) {
+	parsed, err := time.Parse(scheduleTimeLayout, strings.TrimSpace(text))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// sinceMidnight returns the wall clock time of t in the schedule's time zone as offset from midnight.
+// Using the wall clock keeps the window at the same local times across daylight saving time changes.
+func (s *Schedule) sinceMidnight(t time.Time) time.Duration {
+	local := t.In(s.location)
+	return time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second + time.Duration(local.Nanosecond())
+}
+
+// IsOpen reports whether t is within the window. The opening time is included, the closing time isn't.
+func (s *Schedule) IsOpen(t time.Time) bool {
+	now := s.sinceMidnight(t)
+	switch {
+	case s.start == s.end:
+		return true
+	case s.start < s.end:
+		return now >= s.start && now < s.end
+	default:
+		// Overnight window
+		return now >= s.start || now < s.end
+	}
+}
+
+// NextOpening returns when the window opens next after t, or t itself if the window is open.
+// An opening time skipped by a daylight saving time change opens as soon as the clocks have moved on.
+func (s *Schedule) NextOpening(t time.Time) time.Time {
+	if s.IsOpen(t) {
+		return t
+	}
+
+	local := t.In(s.location)
+	hour, minute := int(s.start/time.Hour), int(s.start%time.Hour/time.Minute)
+	opening := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, s.location)
+	if !opening.After(t) {
+		opening = time.Date(local.Year(), local.Month(), local.Day()+1, hour, minute, 0, 0, s.location)
+	}
+	if opening.Hour() != hour || opening.Minute() != minute {
+		// Skipped by the clocks springing forward, the window opens at the change
+		opening, _ = opening.ZoneBounds()
+	}
+	return opening
+}
+
+// Location returns the time zone of the schedule.
+func (s *Schedule) Location() *time.Location {
+	return s.location
+}
+
+// String returns the window like "18:00-02:00".
+func (s *Schedule) String() string {
+	midnight := time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)
+	return midnight.Add(s.start).Format(scheduleTimeLayout) + "-" + midnight.Add(s.end).Format(scheduleTimeLayout)
+}
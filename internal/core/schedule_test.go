@@ -0,0 +1,164 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("Failed to load time zone %s: %v", name, err)
+	}
+	return location
+}
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		window   string
+		expected string
+		wantErr  bool
+	}{
+		{window: "18:00-02:00", expected: "18:00-02:00"},
+		{window: " 9:30 - 17:00 ", expected: "09:30-17:00"},
+		{window: "18:00", wantErr: true},
+		{window: "18:00-25:00", wantErr: true},
+		{window: "evening-night", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.window, time.UTC)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseSchedule(%q) expected an error", tt.window)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSchedule(%q) unexpected error: %v", tt.window, err)
+			continue
+		}
+		if schedule.String() != tt.expected {
+			t.Errorf("ParseSchedule(%q) = %q, want %q", tt.window, schedule.String(), tt.expected)
+		}
+	}
+}
+
+func TestSchedule_IsOpen(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, time.June, 14, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		window   string
+		time     time.Time
+		expected bool
+	}{
+		{name: "Daytime before opening", window: "09:00-17:00", time: at(8, 59), expected: false},
+		{name: "Daytime at opening", window: "09:00-17:00", time: at(9, 0), expected: true},
+		{name: "Daytime before closing", window: "09:00-17:00", time: at(16, 59), expected: true},
+		{name: "Daytime at closing", window: "09:00-17:00", time: at(17, 0), expected: false},
+		{name: "Overnight before opening", window: "18:00-02:00", time: at(17, 59), expected: false},
+		{name: "Overnight at opening", window: "18:00-02:00", time: at(18, 0), expected: true},
+		{name: "Overnight at midnight", window: "18:00-02:00", time: at(0, 0), expected: true},
+		{name: "Overnight before closing", window: "18:00-02:00", time: at(1, 59), expected: true},
+		{name: "Overnight at closing", window: "18:00-02:00", time: at(2, 0), expected: false},
+		{name: "Overnight during the day", window: "18:00-02:00", time: at(12, 0), expected: false},
+		{name: "All day", window: "00:00-00:00", time: at(3, 0), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.window, time.UTC)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) unexpected error: %v", tt.window, err)
+			}
+			if got := schedule.IsOpen(tt.time); got != tt.expected {
+				t.Errorf("IsOpen(%s) = %v, want %v", tt.time.Format(time.Kitchen), got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSchedule_UsesTimeZone(t *testing.T) {
+	zurich := mustLoadLocation(t, "Europe/Zurich")
+	schedule, err := ParseSchedule("18:00-02:00", zurich)
+	if err != nil {
+		t.Fatalf("ParseSchedule unexpected error: %v", err)
+	}
+
+	// 16:30 UTC is 18:30 in Zurich during summer time
+	if !schedule.IsOpen(time.Date(2025, time.June, 14, 16, 30, 0, 0, time.UTC)) {
+		t.Error("Expected the window to be evaluated in the schedule's time zone")
+	}
+}
+
+func TestSchedule_NextOpening(t *testing.T) {
+	zurich := mustLoadLocation(t, "Europe/Zurich")
+
+	tests := []struct {
+		name     string
+		window   string
+		time     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Open now",
+			window:   "18:00-02:00",
+			time:     time.Date(2025, time.June, 14, 23, 0, 0, 0, zurich),
+			expected: time.Date(2025, time.June, 14, 23, 0, 0, 0, zurich),
+		},
+		{
+			name:     "Later today",
+			window:   "18:00-02:00",
+			time:     time.Date(2025, time.June, 14, 12, 0, 0, 0, zurich),
+			expected: time.Date(2025, time.June, 14, 18, 0, 0, 0, zurich),
+		},
+		{
+			name:     "Tomorrow",
+			window:   "09:00-12:00",
+			time:     time.Date(2025, time.June, 14, 12, 0, 0, 0, zurich),
+			expected: time.Date(2025, time.June, 15, 9, 0, 0, 0, zurich),
+		},
+		{
+			name:     "Across the end of the month",
+			window:   "09:00-12:00",
+			time:     time.Date(2025, time.June, 30, 13, 0, 0, 0, zurich),
+			expected: time.Date(2025, time.July, 1, 9, 0, 0, 0, zurich),
+		},
+		{
+			// Clocks move from 02:00 to 03:00 on March 30, 2025 in Zurich, 23 hours after the previous midnight
+			name:     "Across the start of summer time",
+			window:   "18:00-02:00",
+			time:     time.Date(2025, time.March, 30, 2, 0, 0, 0, zurich),
+			expected: time.Date(2025, time.March, 30, 18, 0, 0, 0, zurich),
+		},
+		{
+			name:     "Opening skipped by the start of summer time",
+			window:   "02:30-04:00",
+			time:     time.Date(2025, time.March, 30, 1, 0, 0, 0, zurich),
+			expected: time.Date(2025, time.March, 30, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			// Clocks move from 03:00 back to 02:00 on October 26, 2025 in Zurich
+			name:     "Across the end of summer time",
+			window:   "18:00-02:00",
+			time:     time.Date(2025, time.October, 26, 12, 0, 0, 0, zurich),
+			expected: time.Date(2025, time.October, 26, 17, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.window, zurich)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) unexpected error: %v", tt.window, err)
+			}
+			if got := schedule.NextOpening(tt.time); !got.Equal(tt.expected) {
+				t.Errorf("NextOpening(%v) = %v, want %v", tt.time, got, tt.expected)
+			}
+		})
+	}
+}
@@ -43,8 +43,18 @@ func (d *Dispatcher) GetShadowQueueSize() int {
 	return len(d.shadowQueue)
 }
 
+// ListShadowQueue returns a copy of the shadow queue in playback order (thread-safe).
+func (d *Dispatcher) ListShadowQueue() []ShadowQueueItem {
+	d.shadowQueueMutex.RLock()
+	defer d.shadowQueueMutex.RUnlock()
+
+	items := make([]ShadowQueueItem, len(d.shadowQueue))
+	copy(items, d.shadowQueue)
+	return items
+}
+
 // addToShadowQueue adds a track to the shadow queue with the specified source.
-func (d *Dispatcher) addToShadowQueue(trackID, source string, duration time.Duration) {
+func (d *Dispatcher) addToShadowQueue(trackID, artist, source string, duration time.Duration) {
 	d.shadowQueueMutex.Lock()
 	defer d.shadowQueueMutex.Unlock()
 
@@ -53,6 +63,7 @@ func (d *Dispatcher) addToShadowQueue(trackID, source string, duration time.Dura
 
 	item := ShadowQueueItem{
 		TrackID:  trackID,
+		Artist:   artist,
 		Position: nextPosition,
 		Duration: duration,
 		Source:   source,
@@ -60,6 +71,7 @@ func (d *Dispatcher) addToShadowQueue(trackID, source string, duration time.Dura
 	}
 
 	d.shadowQueue = append(d.shadowQueue, item)
+	d.metrics.SetShadowQueueSize(len(d.shadowQueue))
 
 	// Update modification timestamp
 	d.lastShadowQueueModified = time.Now()
@@ -84,6 +96,11 @@ func (d *Dispatcher) checkCurrentTrackChanged(ctx context.Context) {
 
 	if currentTrackID != lastTrackID {
 		d.updateShadowQueueProgression(currentTrackID, lastTrackID)
+		d.spotify.RecordPlayedTrack(currentTrackID)
+
+		if d.config.App.AnnounceNowPlaying {
+			d.announceNowPlaying(ctx, currentTrackID)
+		}
 	}
 }
 
@@ -93,6 +110,9 @@ func (d *Dispatcher) updateShadowQueueProgression(currentTrackID, lastTrackID st
 		zap.String("oldTrackID", lastTrackID),
 		zap.String("newTrackID", currentTrackID))
 
+	// Look up the artist of manually played tracks before taking the shadow queue lock
+	cachedArtist := d.cachedTrackArtist(currentTrackID)
+
 	// Update the last known current track ID
 	d.shadowQueueMutex.Lock()
 	d.lastCurrentTrackID = currentTrackID
@@ -106,6 +126,12 @@ func (d *Dispatcher) updateShadowQueueProgression(currentTrackID, lastTrackID st
 		}
 	}
 
+	playedArtist := cachedArtist
+	if currentTrackPosition >= 0 {
+		playedArtist = d.shadowQueue[currentTrackPosition].Artist
+	}
+	d.recordPlayedArtistUnsafe(playedArtist)
+
 	switch currentTrackPosition {
 	case -1:
 		d.handleManualTrackPlay(currentTrackID)
@@ -156,11 +182,12 @@ func (d *Dispatcher) handleManualTrackSkip(currentTrackID string, currentTrackPo
 		zap.Int("currentTrackPosition", currentTrackPosition),
 		zap.Int("skippedTracksCount", len(skippedTracks)))
 
-	// Log the skipped tracks for debugging
+	// Keep the skipped tracks from being queued again right away
 	for _, skipped := range skippedTracks {
 		d.logger.Debug("Removing skipped track from shadow queue",
 			zap.String("skippedTrackID", skipped.TrackID),
 			zap.String("source", skipped.Source))
+		d.recordSkippedTrack(skipped.TrackID)
 	}
 
 	// Remove all tracks up to and including current position
@@ -181,7 +208,7 @@ func (d *Dispatcher) AddToQueueWithShadowTracking(ctx context.Context, track *Tr
 	}
 
 	// Add to shadow queue with the known track duration
-	d.addToShadowQueue(track.ID, source, track.Duration)
+	d.addToShadowQueue(track.ID, track.Artist, source, track.Duration)
 
 	return nil
 }
@@ -215,6 +242,18 @@ func (d *Dispatcher) GetQueueRemainingDurationWithShadow(ctx context.Context) (t
 	return currentTrackRemaining, nil
 }
 
+// lastQueuedTrackID returns the track a newly queued track will follow: the last track of the shadow queue,
+// or the last known current track if nothing is queued. Returns an empty string if neither is known.
+func (d *Dispatcher) lastQueuedTrackID() string {
+	d.shadowQueueMutex.RLock()
+	defer d.shadowQueueMutex.RUnlock()
+
+	if len(d.shadowQueue) > 0 {
+		return d.shadowQueue[len(d.shadowQueue)-1].TrackID
+	}
+	return d.lastCurrentTrackID
+}
+
 // GetShadowQueuePosition finds the position of a track in the shadow queue.
 // Returns -1 if track is not found.
 func (d *Dispatcher) GetShadowQueuePosition(trackID string) int {
@@ -230,9 +269,32 @@ func (d *Dispatcher) GetShadowQueuePosition(trackID string) int {
 	return -1 // Track not found in shadow queue
 }
 
-// getLogicalPlaylistPosition returns the logical playlist position to use for next track selection.
+// findTrackQueueInfo returns the shadow queue position of a track and the estimated time until it plays,
+// which is the remaining time of the current track plus the durations of the tracks queued before it.
+// Returns false if the track is not in the shadow queue.
+func (d *Dispatcher) findTrackQueueInfo(ctx context.Context, trackID string) (int, time.Duration, bool) {
+	position := d.GetShadowQueuePosition(trackID)
+	if position < 0 {
+		return -1, 0, false
+	}
+
+	timeUntilPlay, err := d.spotify.GetCurrentTrackRemainingTime(ctx)
+	if err != nil {
+		d.logger.Debug("Failed to get current track remaining time for queue estimate", zap.Error(err))
+	}
+
+	d.shadowQueueMutex.RLock()
+	defer d.shadowQueueMutex.RUnlock()
+	for i := 0; i < position && i < len(d.shadowQueue); i++ {
+		timeUntilPlay += d.shadowQueue[i].Duration
+	}
+
+	return position, timeUntilPlay, true
+}
+
+// findLogicalPlaylistPosition returns the logical playlist position to use for next track selection.
 // Returns (position, error). Position is nil if current track is not found in playlist.
-func (d *Dispatcher) getLogicalPlaylistPosition(ctx context.Context) (*int, error) {
+func (d *Dispatcher) findLogicalPlaylistPosition(ctx context.Context, playlistTracks []Track) (*int, error) {
 	// Get current track ID
 	currentTrackID, err := d.spotify.GetCurrentTrackID(ctx)
 	usingFallback := false
@@ -259,12 +321,6 @@ func (d *Dispatcher) getLogicalPlaylistPosition(ctx context.Context) (*int, erro
 	priorityInfo, isPriorityTrack := d.priorityTracks[currentTrackID]
 	d.priorityTracksMutex.RUnlock()
 
-	// Get all playlist tracks to find positions
-	playlistTracks, err := d.spotify.GetPlaylistTracksWithDetails(ctx, d.config.Spotify.PlaylistID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get playlist tracks: %w", err)
-	}
-
 	if isPriorityTrack {
 		if pos := d.getPriorityTrackResumePosition(currentTrackID, &priorityInfo, playlistTracks); pos != nil {
 			return pos, nil
@@ -585,6 +641,8 @@ func (d *Dispatcher) performShadowQueueMaintenance(ctx context.Context) {
 
 	// Check for queue sync issues and warn admins if needed
 	d.checkQueueSyncStatus(ctx)
+
+	d.metrics.SetShadowQueueSize(d.GetShadowQueueSize())
 }
 
 // synchronizeWithSpotifyQueue synchronizes the shadow queue with the actual Spotify queue state.
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Shadow Queue Persistence
+// This module saves the shadow queue to disk so that queue duration tracking
+// survives bot restarts in the middle of an event
+
+const shadowQueueFilePermissions = 0600
+
+// shadowQueueState is the on-disk representation of the shadow queue.
+type shadowQueueState struct {
+	Items              []ShadowQueueItem `json:"items"`
+	LastCurrentTrackID string            `json:"last_current_track_id"`
+	SavedAt            time.Time         `json:"saved_at"`
+}
+
+// saveShadowQueueState writes the state to path, replacing the previous file atomically.
+func saveShadowQueueState(path string, state *shadowQueueState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal shadow queue: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, shadowQueueFilePermissions); err != nil {
+		return fmt.Errorf("failed to write shadow queue file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace shadow queue file: %w", err)
+	}
+
+	return nil
+}
+
+// loadShadowQueueState reads the state from path. A missing file returns nil without error.
+func loadShadowQueueState(path string) (*shadowQueueState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read shadow queue file: %w", err)
+	}
+
+	var state shadowQueueState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse shadow queue file: %w", err)
+	}
+
+	return &state, nil
+}
+
+// pruneRestoredShadowQueue drops restored items older than maxAge and, if liveTrackIDs is not nil,
+// items that are no longer in the Spotify queue. Positions of the remaining items are renumbered.
+func pruneRestoredShadowQueue(
+	items []ShadowQueueItem, liveTrackIDs []string, maxAge time.Duration, now time.Time,
+) []ShadowQueueItem {
+	var live map[string]bool
+	if liveTrackIDs != nil {
+		live = make(map[string]bool, len(liveTrackIDs))
+		for _, trackID := range liveTrackIDs {
+			live[trackID] = true
+		}
+	}
+
+	pruned := make([]ShadowQueueItem, 0, len(items))
+	for _, item := range items {
+		if now.Sub(item.AddedAt) > maxAge {
+			continue
+		}
+		if live != nil && !live[item.TrackID] {
+			continue
+		}
+		pruned = append(pruned, item)
+	}
+
+	for i := range pruned {
+		pruned[i].Position = i
+	}
+
+	return pruned
+}
+
+// restoreShadowQueue loads the persisted shadow queue and validates it against the live Spotify queue.
+func (d *Dispatcher) restoreShadowQueue(ctx context.Context) {
+	path := d.config.App.ShadowQueuePath
+	if path == "" {
+		return
+	}
+
+	state, err := loadShadowQueueState(path)
+	if err != nil {
+		d.logger.Warn("Failed to load persisted shadow queue", zap.Error(err))
+		return
+	}
+	if state == nil {
+		return
+	}
+
+	// If the Spotify queue is unavailable, keep the items; maintenance syncs them later
+	liveTrackIDs, err := d.spotify.GetQueueTrackIDs(ctx)
+	if err != nil {
+		d.logger.Warn("Failed to get Spotify queue for shadow queue restore, skipping validation",
+			zap.Error(err))
+		liveTrackIDs = nil
+	} else if liveTrackIDs == nil {
+		liveTrackIDs = []string{}
+	}
+
+	maxAge := time.Duration(d.config.App.ShadowQueueMaxAgeHours) * time.Hour
+	items := pruneRestoredShadowQueue(state.Items, liveTrackIDs, maxAge, time.Now())
+
+	d.shadowQueueMutex.Lock()
+	d.shadowQueue = items
+	d.lastCurrentTrackID = state.LastCurrentTrackID
+	d.lastShadowQueueModified = time.Now()
+	d.shadowQueueMutex.Unlock()
+
+	d.logger.Info("Restored shadow queue from disk",
+		zap.String("path", path),
+		zap.Int("restoredItems", len(items)),
+		zap.Int("droppedItems", len(state.Items)-len(items)),
+		zap.Time("savedAt", state.SavedAt))
+}
+
+// persistShadowQueue writes the current shadow queue to disk if persistence is enabled.
+func (d *Dispatcher) persistShadowQueue() {
+	path := d.config.App.ShadowQueuePath
+	if path == "" {
+		return
+	}
+
+	d.shadowQueueMutex.RLock()
+	state := &shadowQueueState{
+		Items:              make([]ShadowQueueItem, len(d.shadowQueue)),
+		LastCurrentTrackID: d.lastCurrentTrackID,
+		SavedAt:            time.Now(),
+	}
+	copy(state.Items, d.shadowQueue)
+	d.shadowQueueMutex.RUnlock()
+
+	if err := saveShadowQueueState(path, state); err != nil {
+		d.logger.Warn("Failed to persist shadow queue", zap.Error(err))
+		return
+	}
+
+	d.logger.Debug("Persisted shadow queue", zap.Int("items", len(state.Items)))
+}
+
+// runShadowQueuePersistence periodically saves the shadow queue to disk.
+func (d *Dispatcher) runShadowQueuePersistence(ctx context.Context) {
+	if d.config.App.ShadowQueuePath == "" {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(d.config.App.ShadowQueueSaveIntervalSecs) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.persistShadowQueue()
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestShadowQueueStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shadow_queue.json")
+	addedAt := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	state := &shadowQueueState{
+		Items: []ShadowQueueItem{
+			{TrackID: "a", Position: 0, Duration: 3 * time.Minute, Source: sourcePlaylist, AddedAt: addedAt},
+			{TrackID: "b", Position: 1, Duration: 4 * time.Minute, Source: sourcePriority, AddedAt: addedAt},
+		},
+		LastCurrentTrackID: "current",
+		SavedAt:            addedAt,
+	}
+
+	if err := saveShadowQueueState(path, state); err != nil {
+		t.Fatalf("saveShadowQueueState() error = %v", err)
+	}
+
+	loaded, err := loadShadowQueueState(path)
+	if err != nil {
+		t.Fatalf("loadShadowQueueState() error = %v", err)
+	}
+
+	if loaded.LastCurrentTrackID != "current" {
+		t.Errorf("LastCurrentTrackID = %q, want %q", loaded.LastCurrentTrackID, "current")
+	}
+	if len(loaded.Items) != len(state.Items) {
+		t.Fatalf("len(Items) = %d, want %d", len(loaded.Items), len(state.Items))
+	}
+	for i, item := range loaded.Items {
+		want := state.Items[i]
+		if item.TrackID != want.TrackID || item.Position != want.Position || item.Duration != want.Duration ||
+			item.Source != want.Source || !item.AddedAt.Equal(want.AddedAt) {
+			t.Errorf("Items[%d] = %+v, want %+v", i, item, want)
+		}
+	}
+}
+
+func TestLoadShadowQueueStateMissingFile(t *testing.T) {
+	state, err := loadShadowQueueState(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("loadShadowQueueState() error = %v", err)
+	}
+	if state != nil {
+		t.Errorf("loadShadowQueueState() = %+v, want nil", state)
+	}
+}
+
+func TestPruneRestoredShadowQueue(t *testing.T) {
+	now := time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC)
+	maxAge := 2 * time.Hour
+	items := []ShadowQueueItem{
+		{TrackID: "stale", Position: 0, AddedAt: now.Add(-3 * time.Hour)},
+		{TrackID: "fresh", Position: 1, AddedAt: now.Add(-10 * time.Minute)},
+		{TrackID: "gone", Position: 2, AddedAt: now.Add(-5 * time.Minute)},
+		{TrackID: "newest", Position: 3, AddedAt: now},
+	}
+
+	tests := []struct {
+		name         string
+		liveTrackIDs []string
+		expectedIDs  []string
+	}{
+		{"Prunes stale and missing items", []string{"stale", "fresh", "newest"}, []string{"fresh", "newest"}},
+		{"Only prunes stale items without live queue", nil, []string{"fresh", "gone", "newest"}},
+		{"Empty live queue drops everything", []string{}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pruned := pruneRestoredShadowQueue(items, tt.liveTrackIDs, maxAge, now)
+			if len(pruned) != len(tt.expectedIDs) {
+				t.Fatalf("len(pruned) = %d, want %d", len(pruned), len(tt.expectedIDs))
+			}
+			for i, item := range pruned {
+				if item.TrackID != tt.expectedIDs[i] {
+					t.Errorf("pruned[%d].TrackID = %q, want %q", i, item.TrackID, tt.expectedIDs[i])
+				}
+				if item.Position != i {
+					t.Errorf("pruned[%d].Position = %d, want %d", i, item.Position, i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+// queueInfoTestSpotify reports a fixed remaining time of the current track.
+type queueInfoTestSpotify struct {
+	SpotifyClient
+	remaining time.Duration
+}
+
+func (s queueInfoTestSpotify) GetCurrentTrackRemainingTime(_ context.Context) (time.Duration, error) {
+	return s.remaining, nil
+}
+
+func newQueueInfoTestDispatcher() (*Dispatcher, *fake.Frontend) {
+	d, frontend := newTestDispatcher(nil, queueInfoTestSpotify{remaining: time.Minute})
+	d.shadowQueue = []ShadowQueueItem{
+		{TrackID: "first", Duration: 3 * time.Minute},
+		{TrackID: "second", Duration: 4 * time.Minute},
+		{TrackID: "third", Duration: 5 * time.Minute},
+	}
+	return d, frontend
+}
+
+func TestFindTrackQueueInfo(t *testing.T) {
+	ctx := context.Background()
+	d, _ := newQueueInfoTestDispatcher()
+
+	tests := []struct {
+		trackID          string
+		expectedPosition int
+		expectedWait     time.Duration
+	}{
+		{trackID: "first", expectedPosition: 0, expectedWait: time.Minute},
+		{trackID: "third", expectedPosition: 2, expectedWait: 8 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		position, wait, found := d.findTrackQueueInfo(ctx, tt.trackID)
+		if !found || position != tt.expectedPosition || wait != tt.expectedWait {
+			t.Errorf("findTrackQueueInfo(%q) = %d, %v, %v, want %d, %v, true",
+				tt.trackID, position, wait, found, tt.expectedPosition, tt.expectedWait)
+		}
+	}
+
+	if _, _, found := d.findTrackQueueInfo(ctx, "unknown"); found {
+		t.Error("Expected tracks outside the shadow queue not to be found")
+	}
+}
+
+func TestReactDuplicate_ReportsQueuePosition(t *testing.T) {
+	ctx := context.Background()
+	d, frontend := newQueueInfoTestDispatcher()
+	msg := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice"}
+
+	d.reactDuplicate(ctx, &MessageContext{}, msg, "third")
+	queued := d.localizer.T("success.duplicate_queued", 3, "8:00")
+	if sent, _ := frontend.LastSent(); !strings.Contains(sent.Text, queued) {
+		t.Errorf("Expected %q, got %q", queued, sent.Text)
+	}
+
+	d.reactDuplicate(ctx, &MessageContext{}, msg, "played")
+	if sent, _ := frontend.LastSent(); !strings.Contains(sent.Text, d.localizer.T("success.duplicate")) {
+		t.Errorf("Expected the plain duplicate reply for tracks outside the queue, got %q", sent.Text)
+	}
+}
+
+func TestLastQueuedTrackID(t *testing.T) {
+	d, _ := newQueueInfoTestDispatcher()
+	d.lastCurrentTrackID = "current"
+
+	if got := d.lastQueuedTrackID(); got != "third" {
+		t.Errorf("Expected recommendations to follow the last queued track, got %q", got)
+	}
+
+	d.shadowQueue = nil
+	if got := d.lastQueuedTrackID(); got != "current" {
+		t.Errorf("Expected recommendations to follow the current track with nothing queued, got %q", got)
+	}
+}
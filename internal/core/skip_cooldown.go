@@ -0,0 +1,56 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Skip Cooldown
+// This module remembers skipped tracks for a while, so filling the queue doesn't bring them right back
+
+// maxSkipCooldownAttempts bounds how many recommendations are requested to find one that wasn't skipped recently.
+const maxSkipCooldownAttempts = 3
+
+// errOnlyRecentlySkippedTracks is returned when every recommendation was skipped recently.
+var errOnlyRecentlySkippedTracks = errors.New("only recently skipped tracks were recommended")
+
+// skipCooldown returns the window in which skipped tracks aren't queued again (zero if disabled).
+func (d *Dispatcher) skipCooldown() time.Duration {
+	return time.Duration(d.config.App.SkipCooldownMins) * time.Minute
+}
+
+// recordSkippedTrack keeps a skipped track from being queued again within the skip cooldown.
+func (d *Dispatcher) recordSkippedTrack(trackID string) {
+	cooldown := d.skipCooldown()
+	if cooldown <= 0 || trackID == "" {
+		return
+	}
+
+	d.skippedTracksMutex.Lock()
+	defer d.skippedTracksMutex.Unlock()
+
+	if d.skippedTracks == nil {
+		d.skippedTracks = make(map[string]time.Time)
+	}
+	d.skippedTracks[trackID] = time.Now()
+
+	// Drop expired skips so the map doesn't grow forever
+	for id, skippedAt := range d.skippedTracks {
+		if time.Since(skippedAt) >= cooldown {
+			delete(d.skippedTracks, id)
+		}
+	}
+
+	d.logger.Debug("Recorded skipped track", zap.String("trackID", trackID), zap.Duration("cooldown", cooldown))
+}
+
+// isRecentlySkipped reports whether the track was skipped within the skip cooldown.
+func (d *Dispatcher) isRecentlySkipped(trackID string) bool {
+	d.skippedTracksMutex.Lock()
+	defer d.skippedTracksMutex.Unlock()
+
+	skippedAt, exists := d.skippedTracks[trackID]
+	return exists && time.Since(skippedAt) < d.skipCooldown()
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestHandleManualTrackSkip_RecordsSkippedTracks(t *testing.T) {
+	tests := []struct {
+		name             string
+		skipCooldownMins int
+		expectedSkipped  bool
+	}{
+		{"Skip cooldown enabled", DefaultSkipCooldownMins, true},
+		{"Skip cooldown disabled", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dispatcher{
+				config: DefaultConfig(),
+				logger: zap.NewNop(),
+				shadowQueue: []ShadowQueueItem{
+					{TrackID: "first"}, {TrackID: "second"}, {TrackID: "third"}, {TrackID: "fourth"},
+				},
+			}
+			d.config.App.SkipCooldownMins = tt.skipCooldownMins
+
+			// Playback jumped from before "first" to "third"
+			d.handleManualTrackSkip("third", 2)
+
+			for _, trackID := range []string{"first", "second"} {
+				if got := d.isRecentlySkipped(trackID); got != tt.expectedSkipped {
+					t.Errorf("isRecentlySkipped(%q) = %v, want %v", trackID, got, tt.expectedSkipped)
+				}
+			}
+			for _, trackID := range []string{"third", "fourth"} {
+				if d.isRecentlySkipped(trackID) {
+					t.Errorf("Expected %q not to count as skipped", trackID)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordSkippedTrack_PrunesExpiredSkips(t *testing.T) {
+	d := &Dispatcher{config: DefaultConfig(), logger: zap.NewNop()}
+	d.skippedTracks = map[string]time.Time{"expired": time.Now().Add(-d.skipCooldown())}
+
+	d.recordSkippedTrack("fresh")
+
+	if d.isRecentlySkipped("expired") {
+		t.Error("Expected a skip older than the cooldown to have expired")
+	}
+	if _, exists := d.skippedTracks["expired"]; exists {
+		t.Error("Expected the expired skip to be pruned")
+	}
+	if !d.isRecentlySkipped("fresh") {
+		t.Error("Expected the recorded skip to be active")
+	}
+}
+
+func TestGetDiverseRecommendedTrack_AvoidsRecentlySkippedTracks(t *testing.T) {
+	ctx := context.Background()
+	d, spotify, _ := newTrackDurationTestDispatcher(0, 0)
+	d.recordSkippedTrack("epic")
+
+	spotify.recommendations = []string{"epic", "song"}
+	track, _, _, err := d.getDiverseRecommendedTrack(ctx)
+	if err != nil || track.ID != "song" {
+		t.Fatalf("Expected the recently skipped recommendation to be passed over, got %+v, %v", track, err)
+	}
+
+	spotify.recommendations = []string{"epic", "epic", "epic", "song"}
+	if _, _, _, err := d.getDiverseRecommendedTrack(ctx); !errors.Is(err, errOnlyRecentlySkippedTracks) {
+		t.Errorf("Expected errOnlyRecentlySkippedTracks after %d attempts, got %v", maxSkipCooldownAttempts, err)
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Spotify Authorization Monitoring
+// This module periodically validates the Spotify authorization, so an expired or revoked
+// refresh token is noticed before requests fail, and sends admins a link to authorize again
+
+// ErrSpotifyAuthExpired is returned by the Spotify client when the authorization can't be refreshed anymore.
+var ErrSpotifyAuthExpired = errors.New("spotify authorization expired")
+
+// runSpotifyAuthMonitoring monitors the validity of the Spotify authorization.
+func (d *Dispatcher) runSpotifyAuthMonitoring(ctx context.Context) {
+	d.logger.Info("Starting Spotify authorization monitoring")
+
+	ticker := time.NewTicker(spotifyAuthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Spotify authorization monitoring stopped")
+			return
+		case <-ticker.C:
+			d.checkSpotifyAuth(ctx)
+		}
+	}
+}
+
+// SpotifyTokenExpiry returns when the current Spotify access token expires, or the zero time if unknown.
+func (d *Dispatcher) SpotifyTokenExpiry() time.Time {
+	if d.spotify == nil {
+		return time.Time{}
+	}
+	return d.spotify.TokenExpiry()
+}
+
+// checkSpotifyAuth validates the authorization and warns admins while it has expired.
+func (d *Dispatcher) checkSpotifyAuth(ctx context.Context) {
+	err := d.spotify.ValidateToken(ctx)
+	if err == nil {
+		d.warningManager.ClearWarning(ctx, WarningTypeAuth)
+		return
+	}
+
+	if !errors.Is(err, ErrSpotifyAuthExpired) {
+		// Transient failures (network, rate limits) don't say anything about the authorization
+		d.logger.Debug("Could not validate Spotify authorization", zap.Error(err))
+		return
+	}
+
+	d.logger.Error("Spotify authorization expired, re-authorization required", zap.Error(err))
+	d.sendSpotifyAuthWarningIfNeeded(ctx)
+}
+
+// sendSpotifyAuthWarningIfNeeded sends admins a link to authorize the bot again, once per expiry.
+func (d *Dispatcher) sendSpotifyAuthWarningIfNeeded(ctx context.Context) {
+	if !d.warningManager.ShouldSendWarning(WarningTypeAuth) {
+		return
+	}
+
+	groupID := d.getGroupID()
+	if groupID == "" {
+		d.logger.Warn("No group ID available for Spotify authorization warning")
+		return
+	}
+
+	adminUserIDs, err := d.frontend.GetAdminUserIDs(ctx, groupID)
+	if err != nil {
+		d.logger.Warn("Failed to get admin user IDs for Spotify authorization warning", zap.Error(err))
+		return
+	}
+
+	if len(adminUserIDs) == 0 {
+		d.logger.Warn("No admin user IDs found for Spotify authorization warning")
+		return
+	}
+
+	message := d.localizer.T("admin.spotify_auth_expired", d.spotify.ReauthorizationURL())
+	if err := d.warningManager.SendWarningToAdmins(ctx, WarningTypeAuth, adminUserIDs, message); err != nil {
+		d.logger.Warn("Failed to send Spotify authorization warning", zap.Error(err))
+		return
+	}
+
+	d.logger.Info("Sent Spotify authorization warning message")
+}
@@ -0,0 +1,223 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/i18n"
+)
+
+// Event Statistics
+// This module collects request statistics for the /stats command,
+// such as accepted and rejected requests and the most active requesters and artists
+
+const (
+	commandStats = "stats"
+	// statsResetArgument resets the statistics when passed to /stats.
+	statsResetArgument = "reset"
+	// statsTopEntries is the number of top requesters and artists listed by /stats.
+	statsTopEntries = 5
+)
+
+// StatsEntry is a name with the number of accepted requests it accounts for.
+type StatsEntry struct {
+	Name  string
+	Count int
+}
+
+// StatsSnapshot is a point-in-time copy of the collected statistics.
+type StatsSnapshot struct {
+	Uptime        time.Duration
+	Requests      int
+	Accepted      int
+	Rejected      int
+	TopRequesters []StatsEntry
+	TopArtists    []StatsEntry
+}
+
+// StatsCollector counts song requests and their outcomes since startup or the last reset.
+type StatsCollector struct {
+	mu         sync.Mutex
+	startedAt  time.Time
+	requests   int
+	accepted   int
+	rejected   int
+	requesters map[string]int // requester name -> accepted requests
+	artists    map[string]int // primary artist -> accepted requests
+	now        func() time.Time
+}
+
+// NewStatsCollector creates an empty stats collector.
+func NewStatsCollector() *StatsCollector {
+	return &StatsCollector{
+		startedAt:  time.Now(),
+		requesters: make(map[string]int),
+		artists:    make(map[string]int),
+		now:        time.Now,
+	}
+}
+
+// RecordRequest counts an incoming song request.
+func (s *StatsCollector) RecordRequest() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+}
+
+// RecordAccepted counts a request whose track was added, attributing it to the requester and artist.
+func (s *StatsCollector) RecordAccepted(requester, artist string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accepted++
+	if requester != "" {
+		s.requesters[requester]++
+	}
+	if first, _, _ := strings.Cut(artist, ","); strings.TrimSpace(first) != "" && artist != unknownArtist {
+		s.artists[strings.TrimSpace(first)]++
+	}
+}
+
+// RecordRejected counts a rejected request.
+func (s *StatsCollector) RecordRejected() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rejected++
+}
+
+// Reset clears all counters. The uptime is not affected.
+func (s *StatsCollector) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = 0
+	s.accepted = 0
+	s.rejected = 0
+	s.requesters = make(map[string]int)
+	s.artists = make(map[string]int)
+}
+
+// Snapshot returns a copy of the current statistics.
+func (s *StatsCollector) Snapshot() StatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return StatsSnapshot{
+		Uptime:        s.now().Sub(s.startedAt),
+		Requests:      s.requests,
+		Accepted:      s.accepted,
+		Rejected:      s.rejected,
+		TopRequesters: topStatsEntries(s.requesters, statsTopEntries),
+		TopArtists:    topStatsEntries(s.artists, statsTopEntries),
+	}
+}
+
+// topStatsEntries returns the entries with the highest counts, ties ordered by name.
+func topStatsEntries(counts map[string]int, limit int) []StatsEntry {
+	entries := make([]StatsEntry, 0, len(counts))
+	for name, count := range counts {
+		entries = append(entries, StatsEntry{Name: name, Count: count})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries[:min(len(entries), limit)]
+}
+
+// recordRequest counts an incoming song request in the event statistics.
+func (d *Dispatcher) recordRequest() {
+	if d.stats == nil {
+		return
+	}
+	d.stats.RecordRequest()
+}
+
+// recordRequestAccepted counts an added track in the event statistics.
+func (d *Dispatcher) recordRequestAccepted(originalMsg *chat.Message, track *Track) {
+	if d.stats == nil {
+		return
+	}
+	d.stats.RecordAccepted(originalMsg.SenderName, track.Artist)
+}
+
+// recordRequestRejected counts a rejected request in the metrics and event statistics.
+func (d *Dispatcher) recordRequestRejected(reason string) {
+	d.metrics.IncRequestsRejected(reason)
+	if d.stats == nil {
+		return
+	}
+	d.stats.RecordRejected()
+}
+
+// handleStatsCommand replies with the event statistics, or resets them with "/stats reset".
+func (d *Dispatcher) handleStatsCommand(ctx context.Context, msg *chat.Message) {
+	localizer := d.localizerFor(msg)
+
+	var reply string
+	if fields := strings.Fields(msg.Text); len(fields) > 1 && strings.EqualFold(fields[1], statsResetArgument) {
+		d.logger.Info("Stats reset command received",
+			zap.String("userID", msg.SenderID),
+			zap.String("userName", msg.SenderName))
+		d.stats.Reset()
+		reply = localizer.T("bot.stats_reset")
+	} else {
+		reply = d.formatStats(localizer, d.stats.Snapshot())
+	}
+
+	if _, err := d.sendLongText(ctx, msg.ChatID, msg.ID, reply); err != nil {
+		d.logger.Error("Failed to send stats", zap.Error(err))
+	}
+}
+
+// formatShutdownSummary formats the tracks added this session and the top requester for the shutdown message.
+// Nothing is added if no track was added.
+func (d *Dispatcher) formatShutdownSummary(snapshot StatsSnapshot) string {
+	if snapshot.Accepted == 0 {
+		return ""
+	}
+
+	summary := d.localizer.T("bot.shutdown_summary", snapshot.Accepted)
+	if len(snapshot.TopRequesters) > 0 {
+		top := snapshot.TopRequesters[0]
+		summary += d.localizer.T("bot.shutdown_top_requester", top.Name, top.Count)
+	}
+	return summary
+}
+
+// formatStats formats the statistics together with the uptime and the current queue size.
+func (d *Dispatcher) formatStats(localizer *i18n.Localizer, snapshot StatsSnapshot) string {
+	var builder strings.Builder
+	builder.WriteString(localizer.T("bot.stats",
+		formatQuotaResetIn(snapshot.Uptime), snapshot.Requests, snapshot.Accepted, snapshot.Rejected,
+		len(d.ListShadowQueue()), formatQueueDuration(d.GetShadowQueueDuration())))
+
+	writeStatsEntries(&builder, localizer, localizer.T("bot.stats_top_requesters"), snapshot.TopRequesters)
+	writeStatsEntries(&builder, localizer, localizer.T("bot.stats_top_artists"), snapshot.TopArtists)
+
+	return builder.String()
+}
+
+// writeStatsEntries appends a titled, numbered list of entries. Nothing is written for an empty list.
+func writeStatsEntries(builder *strings.Builder, localizer *i18n.Localizer, title string, entries []StatsEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	builder.WriteString("\n\n")
+	builder.WriteString(title)
+	for i, entry := range entries {
+		builder.WriteString("\n")
+		builder.WriteString(localizer.T("bot.stats_top_entry", i+1, entry.Name, entry.Count))
+	}
+}
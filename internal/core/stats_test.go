@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+func TestStatsCollector_Snapshot(t *testing.T) {
+	stats := NewStatsCollector()
+
+	now := stats.startedAt
+	stats.now = func() time.Time { return now }
+
+	for range 4 {
+		stats.RecordRequest()
+	}
+	stats.RecordAccepted("alice", "Daft Punk, Pharrell Williams")
+	stats.RecordAccepted("bob", "Daft Punk")
+	stats.RecordAccepted("alice", "Justice")
+	stats.RecordRejected()
+
+	now = now.Add(90 * time.Minute)
+	snapshot := stats.Snapshot()
+
+	if snapshot.Uptime != 90*time.Minute {
+		t.Errorf("Expected uptime of 90m, got %v", snapshot.Uptime)
+	}
+	if snapshot.Requests != 4 || snapshot.Accepted != 3 || snapshot.Rejected != 1 {
+		t.Errorf("Unexpected counts: requests=%d accepted=%d rejected=%d",
+			snapshot.Requests, snapshot.Accepted, snapshot.Rejected)
+	}
+
+	expectedRequesters := []StatsEntry{{Name: "alice", Count: 2}, {Name: "bob", Count: 1}}
+	if !reflect.DeepEqual(snapshot.TopRequesters, expectedRequesters) {
+		t.Errorf("TopRequesters = %v, want %v", snapshot.TopRequesters, expectedRequesters)
+	}
+
+	expectedArtists := []StatsEntry{{Name: "Daft Punk", Count: 2}, {Name: "Justice", Count: 1}}
+	if !reflect.DeepEqual(snapshot.TopArtists, expectedArtists) {
+		t.Errorf("TopArtists = %v, want %v", snapshot.TopArtists, expectedArtists)
+	}
+}
+
+func TestStatsCollector_Reset(t *testing.T) {
+	stats := NewStatsCollector()
+
+	stats.RecordRequest()
+	stats.RecordAccepted("alice", unknownArtist)
+	stats.RecordRejected()
+	if artists := stats.Snapshot().TopArtists; len(artists) != 0 {
+		t.Errorf("Unknown artists should not be counted, got %v", artists)
+	}
+
+	stats.Reset()
+
+	snapshot := stats.Snapshot()
+	if snapshot.Requests != 0 || snapshot.Accepted != 0 || snapshot.Rejected != 0 {
+		t.Errorf("Expected counts to be reset, got %+v", snapshot)
+	}
+	if len(snapshot.TopRequesters) != 0 || len(snapshot.TopArtists) != 0 {
+		t.Errorf("Expected top lists to be reset, got %+v", snapshot)
+	}
+}
+
+func TestTopStatsEntries_Limit(t *testing.T) {
+	counts := map[string]int{"a": 1, "b": 3, "c": 2, "d": 2}
+
+	expected := []StatsEntry{{Name: "b", Count: 3}, {Name: "c", Count: 2}, {Name: "d", Count: 2}}
+	if got := topStatsEntries(counts, 3); !reflect.DeepEqual(got, expected) {
+		t.Errorf("topStatsEntries() = %v, want %v", got, expected)
+	}
+}
+
+func TestHandleStatsCommand(t *testing.T) {
+	ctx := context.Background()
+	frontend := fake.New()
+	frontend.SetAdmins("1")
+
+	d := &Dispatcher{
+		config:    DefaultConfig(),
+		frontend:  frontend,
+		localizer: i18n.NewLocalizer(i18n.DefaultLanguage),
+		stats:     NewStatsCollector(),
+		logger:    zap.NewNop(),
+	}
+	d.frontend.SetCommandHandler(commandStats, true, d.handleStatsCommand)
+	d.stats.RecordRequest()
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", Text: "/stats reset"})
+	if !frontend.HasSentKey("error.command.admin_only") || d.stats.Snapshot().Requests != 1 {
+		t.Error("Non-admins should not be able to reset the stats")
+	}
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "2", ChatID: "-100", SenderID: "1", Text: "/stats reset"})
+	if !frontend.HasSentKey("bot.stats_reset") || d.stats.Snapshot().Requests != 0 {
+		t.Error("Admins should be able to reset the stats")
+	}
+}
+
+func TestSendShutdownMessage_Summary(t *testing.T) {
+	frontend := fake.New()
+	config := DefaultConfig()
+	config.Telegram.GroupID = -100
+	config.App.ShutdownSummary = true
+
+	d := &Dispatcher{
+		config:    config,
+		frontend:  frontend,
+		localizer: i18n.NewLocalizer(i18n.DefaultLanguage),
+		stats:     NewStatsCollector(),
+		logger:    zap.NewNop(),
+	}
+
+	d.sendShutdownMessage(context.Background())
+	if sent, _ := frontend.LastSent(); strings.Contains(sent.Text, d.localizer.T("bot.shutdown_summary", 0)) {
+		t.Error("Expected no summary without added songs")
+	}
+
+	d.stats.RecordAccepted("alice", "Daft Punk")
+	d.stats.RecordAccepted("bob", "Justice")
+	d.stats.RecordAccepted("alice", "Justice")
+
+	d.sendShutdownMessage(context.Background())
+	expected := d.localizer.T("bot.shutdown_summary", 3) + d.localizer.T("bot.shutdown_top_requester", "alice", 2)
+	if sent, _ := frontend.LastSent(); !strings.Contains(sent.Text, expected) {
+		t.Errorf("Expected the session summary, got %q", sent.Text)
+	}
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Track Availability
+// This module resolves requested tracks to versions playable in the configured market, following
+// Spotify's track relinking and falling back to another release of the same recording
+
+// resolvePlayableTrack returns the ID of a playable version of the requested track, which dedup and the
+// playlist then use. Returns false after rejecting the request if no playable version exists.
+func (d *Dispatcher) resolvePlayableTrack(ctx context.Context, msgCtx *MessageContext,
+	originalMsg *chat.Message, trackID string) (string, bool) {
+	track, err := d.getCachedTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Debug("Failed to get track for availability check, allowing request",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		return trackID, true
+	}
+
+	if !track.Unplayable {
+		if track.LinkedFromID != "" && track.ID != trackID {
+			d.logger.Info("Spotify relinked requested track to a playable version",
+				zap.String("trackID", trackID),
+				zap.String("playableTrackID", track.ID))
+			return track.ID, true
+		}
+		return trackID, true
+	}
+
+	if alternateID := d.findPlayableAlternate(ctx, track); alternateID != "" {
+		d.logger.Info("Replaced unavailable track with another release of the recording",
+			zap.String("trackID", trackID),
+			zap.String("playableTrackID", alternateID),
+			zap.String("isrc", track.ISRC))
+		return alternateID, true
+	}
+
+	d.logger.Info("Rejected track not available in the market",
+		zap.String("trackID", trackID),
+		zap.String("userID", originalMsg.SenderID),
+		zap.String("userName", originalMsg.SenderName))
+
+	d.recordRequestRejected(rejectReasonUnavailable)
+	d.reactError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.track.unavailable"))
+	return "", false
+}
+
+// findPlayableAlternate searches a playable release of the same recording by ISRC.
+// Returns an empty string if there is none.
+func (d *Dispatcher) findPlayableAlternate(ctx context.Context, track *Track) string {
+	if track.ISRC == "" {
+		return ""
+	}
+
+	isrcSearcher, ok := d.spotify.(interface {
+		SearchTrackByISRC(ctx context.Context, isrc string) (*Track, error)
+	})
+	if !ok {
+		return ""
+	}
+
+	alternate, err := isrcSearcher.SearchTrackByISRC(ctx, track.ISRC)
+	if err != nil {
+		d.logger.Debug("No playable alternate found by ISRC",
+			zap.String("isrc", track.ISRC),
+			zap.Error(err))
+		return ""
+	}
+	if alternate.Unplayable || alternate.ID == track.ID {
+		return ""
+	}
+	return alternate.ID
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+// trackAvailabilityTestSpotify returns fixed tracks and finds playable releases by ISRC.
+type trackAvailabilityTestSpotify struct {
+	SpotifyClient
+	tracks map[string]*Track
+	isrcs  map[string]*Track
+}
+
+func (s *trackAvailabilityTestSpotify) GetTrack(_ context.Context, trackID string) (*Track, error) {
+	track, ok := s.tracks[trackID]
+	if !ok {
+		return nil, errors.New("track not found")
+	}
+	return track, nil
+}
+
+func (s *trackAvailabilityTestSpotify) SearchTrackByISRC(_ context.Context, isrc string) (*Track, error) {
+	track, ok := s.isrcs[isrc]
+	if !ok {
+		return nil, errors.New("no track found for ISRC")
+	}
+	return track, nil
+}
+
+func TestResolvePlayableTrack(t *testing.T) {
+	spotify := &trackAvailabilityTestSpotify{
+		tracks: map[string]*Track{
+			"playable":    {ID: "playable"},
+			"relinked":    {ID: "relinked-playable", LinkedFromID: "relinked"},
+			"unavailable": {ID: "unavailable", ISRC: "ISRC1", Unplayable: true},
+			"lost":        {ID: "lost", ISRC: "ISRC2", Unplayable: true},
+		},
+		isrcs: map[string]*Track{
+			"ISRC1": {ID: "other-release", ISRC: "ISRC1"},
+			"ISRC2": {ID: "lost", ISRC: "ISRC2", Unplayable: true},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		trackID    string
+		expectedID string
+		expectedOK bool
+	}{
+		{"Playable track", "playable", "playable", true},
+		{"Relinked track uses the playable ID", "relinked", "relinked-playable", true},
+		{"Unavailable track replaced by another release", "unavailable", "other-release", true},
+		{"Unavailable track without alternate", "lost", "", false},
+		{"Unknown track is allowed", "unknown", "unknown", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontend := fake.New()
+			d := &Dispatcher{
+				config:         DefaultConfig(),
+				spotify:        spotify,
+				frontend:       frontend,
+				localizer:      i18n.NewLocalizer(i18n.DefaultLanguage),
+				trackInfoCache: make(map[string]*Track),
+				metrics:        noopMetricsRecorder{},
+				logger:         zap.NewNop(),
+			}
+
+			msg := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice"}
+			trackID, ok := d.resolvePlayableTrack(context.Background(), &MessageContext{}, msg, tt.trackID)
+			if trackID != tt.expectedID || ok != tt.expectedOK {
+				t.Errorf("resolvePlayableTrack(%q) = %q, %v, want %q, %v",
+					tt.trackID, trackID, ok, tt.expectedID, tt.expectedOK)
+			}
+			reply, replied := frontend.LastSent()
+			rejected := replied && strings.Contains(reply.Text, d.localizer.T("error.track.unavailable"))
+			if rejected == tt.expectedOK {
+				t.Errorf("Expected an unavailable rejection only if no playable version exists, got %+v",
+					frontend.SentMessages())
+			}
+		})
+	}
+}
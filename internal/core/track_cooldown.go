@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Track Re-Request Cooldown
+// This module keeps recently added tracks from being requested again within a window,
+// independent of the dedup store which forgets tracks that are removed or reset
+
+// isTrackCoolingDown checks whether the track was added recently and notifies the requester if so.
+func (d *Dispatcher) isTrackCoolingDown(ctx context.Context, msgCtx *MessageContext,
+	originalMsg *chat.Message, trackID string) bool {
+	remaining := d.cooldownRemaining(trackID)
+	if remaining <= 0 {
+		return false
+	}
+
+	d.logger.Info("Rejected track still in cooldown",
+		zap.String("trackID", trackID),
+		zap.String("userID", originalMsg.SenderID),
+		zap.Duration("remaining", remaining))
+
+	d.recordRequestRejected(rejectReasonCooldown)
+
+	if err := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, chat.ReactionYawning); err != nil {
+		d.logger.Debug("Failed to add cooldown reaction", zap.Error(err))
+	}
+
+	d.reactError(ctx, msgCtx, originalMsg, d.localizerFor(originalMsg).T("error.track.cooldown", formatQuotaResetIn(remaining)))
+	return true
+}
+
+// recordTrackCooldown starts the re-request cooldown for an added track.
+func (d *Dispatcher) recordTrackCooldown(trackID string) {
+	if d.cooldown == nil {
+		return
+	}
+	d.cooldown.Record(trackID)
+}
+
+// cooldownRemaining returns how long the track is still cooling down (0 if it isn't or cooldowns are disabled).
+func (d *Dispatcher) cooldownRemaining(trackID string) time.Duration {
+	if d.cooldown == nil {
+		return 0
+	}
+	return d.cooldown.Remaining(trackID)
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Track Duration Filtering
+// This module rejects song requests for tracks that are too short or too long,
+// like interludes or extended prog epics, and keeps the queue filler within the same bounds
+
+// maxTrackDurationAttempts bounds how many recommendations are requested to find one within the duration limits.
+const maxTrackDurationAttempts = 3
+
+// errNoTrackWithinDurationLimits is returned when no recommendation was within the duration limits.
+var errNoTrackWithinDurationLimits = errors.New("no recommended track within the duration limits")
+
+// minTrackDuration returns the minimum track duration (0 disables the limit).
+func (d *Dispatcher) minTrackDuration() time.Duration {
+	return time.Duration(d.config.App.MinTrackSecs) * time.Second
+}
+
+// maxTrackDuration returns the maximum track duration (0 disables the limit).
+func (d *Dispatcher) maxTrackDuration() time.Duration {
+	return time.Duration(d.config.App.MaxTrackSecs) * time.Second
+}
+
+// isTrackDurationAllowed reports whether a track duration is within the configured limits.
+// Tracks with an unknown duration are allowed.
+func (d *Dispatcher) isTrackDurationAllowed(duration time.Duration) bool {
+	if duration <= 0 {
+		return true
+	}
+	if minDuration := d.minTrackDuration(); minDuration > 0 && duration < minDuration {
+		return false
+	}
+	if maxDuration := d.maxTrackDuration(); maxDuration > 0 && duration > maxDuration {
+		return false
+	}
+	return true
+}
+
+// isTrackDurationRejected checks whether the track is outside the duration limits, and notifies the requester if so.
+func (d *Dispatcher) isTrackDurationRejected(ctx context.Context, msgCtx *MessageContext,
+	originalMsg *chat.Message, trackID string) bool {
+	if d.config.App.MinTrackSecs <= 0 && d.config.App.MaxTrackSecs <= 0 {
+		return false
+	}
+
+	track, err := d.spotify.GetTrack(ctx, trackID)
+	if err != nil {
+		d.logger.Warn("Failed to get track for duration check, allowing request",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		return false
+	}
+
+	if d.isTrackDurationAllowed(track.Duration) {
+		return false
+	}
+
+	d.logger.Info("Rejected track outside the duration limits",
+		zap.String("trackID", trackID),
+		zap.Duration("duration", track.Duration),
+		zap.String("userID", originalMsg.SenderID),
+		zap.String("userName", originalMsg.SenderName))
+
+	d.recordRequestRejected(rejectReasonDuration)
+
+	if err := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, thumbsDownReaction); err != nil {
+		d.logger.Debug("Failed to add track duration reaction", zap.Error(err))
+	}
+
+	localizer := d.localizerFor(originalMsg)
+	var message string
+	if track.Duration < d.minTrackDuration() {
+		message = localizer.T("error.track.too_short",
+			formatQueueDuration(track.Duration), formatQueueDuration(d.minTrackDuration()))
+	} else {
+		message = localizer.T("error.track.too_long",
+			formatQueueDuration(track.Duration), formatQueueDuration(d.maxTrackDuration()))
+	}
+	d.reactError(ctx, msgCtx, originalMsg, message)
+	return true
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+// trackDurationTestSpotify returns tracks with fixed durations and recommends them in order.
+type trackDurationTestSpotify struct {
+	SpotifyClient
+	durations       map[string]time.Duration
+	recommendations []string
+}
+
+func (s *trackDurationTestSpotify) GetTrack(_ context.Context, trackID string) (*Track, error) {
+	duration, ok := s.durations[trackID]
+	if !ok {
+		return nil, errors.New("track not found")
+	}
+	return &Track{ID: trackID, Title: "Title " + trackID, Artist: "Artist " + trackID, Duration: duration}, nil
+}
+
+func (s *trackDurationTestSpotify) GetRecommendedTrack(_ context.Context, _ string) (trackID, searchQuery,
+	newTrackMood string, err error) {
+	if len(s.recommendations) == 0 {
+		return "", "", "", errors.New("no recommendations")
+	}
+	trackID, s.recommendations = s.recommendations[0], s.recommendations[1:]
+	return trackID, "query", "mood", nil
+}
+
+func newTrackDurationTestDispatcher(minSecs, maxSecs int) (*Dispatcher, *trackDurationTestSpotify, *fake.Frontend) {
+	config := DefaultConfig()
+	config.App.MinTrackSecs = minSecs
+	config.App.MaxTrackSecs = maxSecs
+
+	spotify := &trackDurationTestSpotify{durations: map[string]time.Duration{
+		"interlude": 20 * time.Second,
+		"song":      3 * time.Minute,
+		"epic":      15 * time.Minute,
+	}}
+	d, frontend := newTestDispatcher(config, spotify)
+	return d, spotify, frontend
+}
+
+func TestIsTrackDurationAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		minSecs  int
+		maxSecs  int
+		duration time.Duration
+		expected bool
+	}{
+		{name: "No limits", duration: 15 * time.Minute, expected: true},
+		{name: "Too short", minSecs: 60, maxSecs: 600, duration: 20 * time.Second, expected: false},
+		{name: "Too long", minSecs: 60, maxSecs: 600, duration: 15 * time.Minute, expected: false},
+		{name: "Within limits", minSecs: 60, maxSecs: 600, duration: 3 * time.Minute, expected: true},
+		{name: "At the limits", minSecs: 60, maxSecs: 600, duration: 10 * time.Minute, expected: true},
+		{name: "Only a maximum", maxSecs: 600, duration: 20 * time.Second, expected: true},
+		{name: "Unknown duration", minSecs: 60, maxSecs: 600, duration: 0, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _, _ := newTrackDurationTestDispatcher(tt.minSecs, tt.maxSecs)
+			if got := d.isTrackDurationAllowed(tt.duration); got != tt.expected {
+				t.Errorf("isTrackDurationAllowed(%v) = %v, want %v", tt.duration, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsTrackDurationRejected(t *testing.T) {
+	ctx := context.Background()
+	msg := &chat.Message{ID: "1", ChatID: "-100", SenderID: "2", SenderName: "@alice"}
+
+	d, _, frontend := newTrackDurationTestDispatcher(60, 600)
+
+	if d.isTrackDurationRejected(ctx, &MessageContext{}, msg, "song") {
+		t.Error("Expected a track within the limits to be allowed")
+	}
+
+	msgCtx := &MessageContext{}
+	if !d.isTrackDurationRejected(ctx, msgCtx, msg, "interlude") {
+		t.Fatal("Expected a too short track to be rejected")
+	}
+	if msgCtx.State != StateReactError {
+		t.Errorf("Expected the error state, got %v", msgCtx.State)
+	}
+	tooShort := d.localizer.T("error.track.too_short", "0:20", "1:00")
+	if sent, _ := frontend.LastSent(); !strings.Contains(sent.Text, tooShort) {
+		t.Errorf("Expected %q naming the minimum, got %q", tooShort, sent.Text)
+	}
+
+	if !d.isTrackDurationRejected(ctx, &MessageContext{}, msg, "epic") {
+		t.Fatal("Expected a too long track to be rejected")
+	}
+	tooLong := d.localizer.T("error.track.too_long", "15:00", "10:00")
+	if sent, _ := frontend.LastSent(); !strings.Contains(sent.Text, tooLong) {
+		t.Errorf("Expected %q naming the maximum, got %q", tooLong, sent.Text)
+	}
+
+	if d.isTrackDurationRejected(ctx, &MessageContext{}, msg, "unknown") {
+		t.Error("Expected requests to be allowed when the track can't be looked up")
+	}
+}
+
+func TestGetDiverseRecommendedTrack_DurationLimits(t *testing.T) {
+	ctx := context.Background()
+
+	d, spotify, _ := newTrackDurationTestDispatcher(60, 600)
+	spotify.recommendations = []string{"interlude", "epic", "song"}
+
+	track, _, _, err := d.getDiverseRecommendedTrack(ctx)
+	if err != nil {
+		t.Fatalf("Expected a recommendation within the limits, got %v", err)
+	}
+	if track.ID != "song" {
+		t.Errorf("Expected recommendations outside the limits to be skipped, got %q", track.ID)
+	}
+
+	spotify.recommendations = []string{"interlude", "epic", "interlude", "song"}
+	if _, _, _, err := d.getDiverseRecommendedTrack(ctx); !errors.Is(err, errNoTrackWithinDurationLimits) {
+		t.Errorf("Expected errNoTrackWithinDurationLimits after %d attempts, got %v", maxTrackDurationAttempts, err)
+	}
+}
@@ -37,6 +37,41 @@ type Track struct {
 	Year     int
 	Duration time.Duration
 	URL      string
+	Explicit bool
+	ISRC     string // International Standard Recording Code, empty if unknown
+
+	PreviewURL string  // 30 second preview clip, empty as Spotify doesn't provide one for most tracks
+	ImageURL   string  // Album cover art, empty if the album has none
+	Relevance  float64 // How well the track matches the search query, about 1.0 for an exact match (0 if not searched)
+
+	Unplayable   bool   // Spotify reported the track as not playable in the configured market
+	LinkedFromID string // ID of the requested track if Spotify relinked it to this playable one
+}
+
+// AudioFeatures holds Spotify's audio analysis of a track.
+type AudioFeatures struct {
+	Energy       float64 // Intensity and activity from 0.0 to 1.0
+	Valence      float64 // Musical positiveness from 0.0 (sad) to 1.0 (happy)
+	Danceability float64 // Suitability for dancing from 0.0 to 1.0
+	Tempo        float64 // Estimated tempo in beats per minute
+}
+
+// Episode represents a podcast episode with its metadata and identifiers.
+type Episode struct {
+	ID       string
+	Title    string
+	Show     string
+	Duration time.Duration
+	URL      string
+	Explicit bool
+}
+
+// Device represents a Spotify Connect device available for playback.
+type Device struct {
+	ID     string
+	Name   string
+	Type   string
+	Active bool
 }
 
 // Playlist represents a Spotify playlist with its metadata.
@@ -100,48 +135,141 @@ const (
 
 // MessageContext holds the state and data for a message being processed by the orchestrator.
 type MessageContext struct {
-	Input      InputMessage
-	State      MessageState
-	Candidates []Track
-	SelectedID string
-	Error      error
-	RetryCount int
-	StartTime  time.Time
-	TimeoutAt  time.Time
-	IsPriority bool
-	TrackMood  string
+	Input          InputMessage
+	State          MessageState
+	Candidates     []Track
+	SelectedID     string
+	Error          error
+	RetryCount     int
+	StartTime      time.Time
+	TimeoutAt      time.Time
+	IsPriority     bool
+	TrackMood      string
+	ApprovalSource string // How the request was approved ("admin", "community", "vip"; empty if no approval was needed)
+	// Confirmation timeout for the requester's role in seconds, see confirmTimeoutSecs (0 until resolved)
+	ConfirmTimeoutSecs int
+
+	releaseSlot func() // Releases the processing slot of the request, see acquireRequestSlot (nil without one)
+}
+
+// PendingApproval describes an approval the dispatcher is currently waiting for.
+type PendingApproval struct {
+	Key       string    `json:"key"`
+	Type      string    `json:"type"` // "user", "admin" or "queue"
+	TrackID   string    `json:"track_id,omitempty"`
+	Song      string    `json:"song,omitempty"`
+	Requester string    `json:"requester,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	AgeSecs   int       `json:"age_secs"`
 }
 
 // SpotifyClient defines the interface for interacting with the Spotify Web API.
 type SpotifyClient interface {
 	SearchTrack(ctx context.Context, query string) ([]Track, error)
 	GetTrack(ctx context.Context, trackID string) (*Track, error)
+	GetAudioFeatures(ctx context.Context, trackID string) (*AudioFeatures, error)
 	AddToPlaylist(ctx context.Context, playlistID, trackID string) error
 	AddToPlaylistAtPosition(ctx context.Context, playlistID, trackID string, position int) error
+	RemoveFromPlaylist(ctx context.Context, playlistID, trackID string) error
+	RemoveFromPlaylistAtPositions(ctx context.Context, playlistID, trackID string, positions []int) error
 	AddToQueue(ctx context.Context, trackID string) error
 	GetPlaylistTracksWithDetails(ctx context.Context, playlistID string) ([]Track, error)
+	GetPlaylistDuration(ctx context.Context, playlistID string) (time.Duration, error)
+	PlaylistExists(ctx context.Context, playlistID string) (bool, error)
+	CanModifyPlaylist(ctx context.Context, playlistID string) (bool, error)
+	RecordPlayedTrack(trackID string)
 	GetQueueTrackIDs(ctx context.Context) ([]string, error)
 	GetCurrentTrackID(ctx context.Context) (string, error)
 	ExtractTrackID(url string) (string, error)
+	ExtractAlbumID(url string) (string, error)
+	GetAlbumTracks(ctx context.Context, albumID string) ([]Track, error)
+	ExtractEpisodeID(url string) (string, error)
+	GetEpisode(ctx context.Context, episodeID string) (*Episode, error)
+	AddEpisodeToQueue(ctx context.Context, episodeID string) error
 	SetTargetPlaylist(playlistID string)
 	GetNextPlaylistTracks(ctx context.Context, count int) ([]Track, error)
 	GetNextPlaylistTracksFromPosition(ctx context.Context, startPosition, count int) ([]Track, error)
-	GetRecommendedTrack(ctx context.Context) (trackID, searchQuery, newTrackMood string, err error)
+	GetRecommendedTrack(ctx context.Context, previousTrackID string) (trackID, searchQuery, newTrackMood string, err error)
 	CheckPlaybackCompliance(ctx context.Context) (*PlaybackCompliance, error)
 	SetShuffle(ctx context.Context, shuffle bool) error
 	SetRepeat(ctx context.Context, state string) error
 	GetCurrentTrackRemainingTime(ctx context.Context) (time.Duration, error)
 	HasActiveDevice(ctx context.Context) (bool, error)
+	ListDevices(ctx context.Context) ([]Device, error)
+	TransferPlayback(ctx context.Context, deviceID string, play bool) error
+	SkipToNext(ctx context.Context) error
+	SetVolume(ctx context.Context, percent int) error
+	PausePlayback(ctx context.Context) error
+	ResumePlayback(ctx context.Context) error
+	GetVolume(ctx context.Context) (int, error)
+	ValidateToken(ctx context.Context) error
+	TokenExpiry() time.Time
+	ReauthorizationURL() string
+}
+
+// UserQuotaStore defines the interface for tracking per-user request quotas.
+type UserQuotaStore interface {
+	Limit() int
+	Remaining(userID string) int
+	ResetIn(userID string) time.Duration
+	Record(userID string) error
+}
+
+// LeaderboardStore defines the interface for counting accepted requests per user across sessions.
+type LeaderboardStore interface {
+	Record(userID, userName string) error
+	Top(limit int) []StatsEntry
+}
+
+// WarningSubscriberStore defines the interface for users who opted in to receive admin warnings by direct message.
+type WarningSubscriberStore interface {
+	Add(userID string) error
+	UserIDs() []string
+}
+
+// TrackCooldownStore defines the interface for tracking recently added tracks that can't be re-requested yet.
+type TrackCooldownStore interface {
+	Remaining(trackID string) time.Duration
+	Record(trackID string)
+}
+
+// MetricsRecorder defines the interface for recording operational metrics.
+type MetricsRecorder interface {
+	IncSongsAdded()
+	IncRequestsRejected(reason string)
+	IncApprovalTimeouts()
+	SetShadowQueueSize(size int)
+	IncSpotifyAPIErrors(operation string)
+}
+
+// TrackAddedEvent describes a track that was added on a user's request.
+type TrackAddedEvent struct {
+	Timestamp      time.Time `json:"timestamp"`
+	PlaylistID     string    `json:"playlist_id"`
+	TrackID        string    `json:"track_id"`
+	Title          string    `json:"title"`
+	Artist         string    `json:"artist"`
+	SenderID       string    `json:"sender_id"`
+	SenderName     string    `json:"sender_name"`
+	ApprovalSource string    `json:"approval_source"`
+	Priority       bool      `json:"priority"`
+}
+
+// EventLogger defines the interface for recording added track events.
+type EventLogger interface {
+	LogTrackAdded(event TrackAddedEvent) error
 }
 
 // LLMProvider defines the interface for interacting with Large Language Model providers.
 type LLMProvider interface {
 	RankTracks(ctx context.Context, searchQuery string, tracks []Track) []Track
+	RankTracksForTransition(ctx context.Context, searchQuery string, previousFeatures *AudioFeatures, tracks []Track) []Track
 	IsNotMusicRequest(ctx context.Context, text string) (bool, error)
 	IsPriorityRequest(ctx context.Context, text string) (bool, error)
 	IsHelpRequest(ctx context.Context, text string) (bool, error)
 	GenerateTrackMood(ctx context.Context, tracks []Track) (string, error)
 	ExtractSongQuery(ctx context.Context, userText string) (string, error)
+	ExplainRejection(ctx context.Context, userText string, candidates []Track, language string) (string, error)
 }
 
 // DedupStore defines the interface for a deduplication store to prevent duplicate track additions.
@@ -152,4 +280,8 @@ type DedupStore interface {
 	Load(trackIDs []string)
 	Size() int
 	Clear()
+	AddSignature(trackID, artist, title string)
+	FindNearDuplicate(artist, title string, threshold float64) (string, float64)
+	AddISRC(trackID, isrc string)
+	FindByISRC(isrc string) string
 }
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Per-User Request Quotas
+// This module caps how many songs each user can add per quota window,
+// on top of the per-minute flood prevention in the chat frontend
+
+// isUserQuotaExceeded checks the sender's quota and notifies them if it is used up.
+func (d *Dispatcher) isUserQuotaExceeded(ctx context.Context, msgCtx *MessageContext, originalMsg *chat.Message) bool {
+	if d.quota == nil || d.quota.Remaining(originalMsg.SenderID) > 0 {
+		return false
+	}
+
+	d.logger.Info("User request quota exceeded",
+		zap.String("userID", originalMsg.SenderID),
+		zap.String("userName", originalMsg.SenderName),
+		zap.Int("limit", d.quota.Limit()))
+
+	msgCtx.State = StateReactError
+	d.recordRequestRejected(rejectReasonQuota)
+
+	if err := d.frontend.React(ctx, originalMsg.ChatID, originalMsg.ID, chat.ReactionQuota); err != nil {
+		d.logger.Debug("Failed to add quota reaction", zap.Error(err))
+	}
+
+	quotaMessage := d.formatMessageWithMention(originalMsg, d.localizerFor(originalMsg).T("error.quota.exceeded",
+		d.quota.Limit(), formatQuotaResetIn(d.quota.ResetIn(originalMsg.SenderID))))
+	if _, err := d.frontend.SendText(ctx, originalMsg.ChatID, originalMsg.ID, quotaMessage); err != nil {
+		d.logger.Error("Failed to send quota exceeded message", zap.Error(err))
+	}
+
+	return true
+}
+
+// recordUserQuota counts an accepted request against the sender's quota.
+func (d *Dispatcher) recordUserQuota(originalMsg *chat.Message) {
+	if d.quota == nil {
+		return
+	}
+
+	if err := d.quota.Record(originalMsg.SenderID); err != nil {
+		d.logger.Warn("Failed to record user quota",
+			zap.String("userID", originalMsg.SenderID),
+			zap.Error(err))
+	}
+}
+
+// formatQuotaResetIn formats the time until a quota reset as hours and minutes (e.g. "3h05m").
+func formatQuotaResetIn(resetIn time.Duration) string {
+	resetIn = max(resetIn, time.Minute).Round(time.Minute)
+	hours := int(resetIn.Hours())
+	minutes := int(resetIn.Minutes()) % minutesPerHour
+
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", hours, minutes)
+}
+
+const minutesPerHour = 60
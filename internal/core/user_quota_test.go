@@ -0,0 +1,27 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatQuotaResetIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		resetIn  time.Duration
+		expected string
+	}{
+		{"Minutes only", 42 * time.Minute, "42m"},
+		{"Hours and minutes", 3*time.Hour + 5*time.Minute, "3h05m"},
+		{"Rounds to nearest minute", 89 * time.Second, "1m"},
+		{"Never below one minute", 0, "1m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatQuotaResetIn(tt.resetIn); got != tt.expected {
+				t.Errorf("formatQuotaResetIn(%v) = %q, want %q", tt.resetIn, got, tt.expected)
+			}
+		})
+	}
+}
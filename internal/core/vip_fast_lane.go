@@ -0,0 +1,28 @@
+package core
+
+import (
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// VIP Fast Lane
+// This module lets users listed in --vip-user-ids skip the admin and community approval of their requests
+
+// approvalSourceVIP marks tracks added without approval because the requester is a VIP.
+const approvalSourceVIP = "vip"
+
+// bypassesApproval reports whether the request skips approval because its sender is a VIP.
+// The bypass is recorded in the event log and logged for auditing.
+func (d *Dispatcher) bypassesApproval(msgCtx *MessageContext, originalMsg *chat.Message) bool {
+	if !d.config.App.VIPBypassApproval || !d.isVIPUser(originalMsg.SenderID) {
+		return false
+	}
+
+	msgCtx.ApprovalSource = approvalSourceVIP
+	d.logger.Info("VIP request bypassing approval",
+		zap.String("userID", originalMsg.SenderID),
+		zap.String("userName", originalMsg.SenderName),
+		zap.String("trackID", msgCtx.SelectedID))
+	return true
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Playback Volume
+// This module implements the /volume admin command which reports or sets
+// the volume of the active Spotify playback device
+
+const (
+	commandVolume = "volume"
+	// maxVolumePercent is the highest volume accepted by /volume.
+	maxVolumePercent = 100
+)
+
+// handleVolumeCommand reports the current volume, or sets it when a level is given (/volume 60).
+func (d *Dispatcher) handleVolumeCommand(ctx context.Context, msg *chat.Message) {
+	fields := strings.Fields(msg.Text)
+
+	percent := -1
+	if len(fields) > 1 {
+		parsed, err := strconv.Atoi(strings.TrimSuffix(fields[1], "%"))
+		if err != nil || parsed < 0 || parsed > maxVolumePercent {
+			d.replyCommandError(ctx, msg, "error.volume.invalid")
+			return
+		}
+		percent = parsed
+	}
+
+	hasDevice, err := d.spotify.HasActiveDevice(ctx)
+	if err != nil {
+		d.logger.Warn("Failed to check for active device before changing volume", zap.Error(err))
+	}
+	if err == nil && !hasDevice {
+		d.replyCommandError(ctx, msg, "error.spotify.no_active_device")
+		return
+	}
+
+	if percent < 0 {
+		d.reportVolume(ctx, msg)
+		return
+	}
+
+	d.logger.Info("Volume command received",
+		zap.Int("percent", percent),
+		zap.String("userID", msg.SenderID),
+		zap.String("userName", msg.SenderName))
+
+	if err := d.spotify.SetVolume(ctx, percent); err != nil {
+		d.logger.Error("Failed to set volume", zap.Error(err))
+		d.replyCommandError(ctx, msg, "error.spotify.volume_failed")
+		return
+	}
+
+	if err := d.frontend.React(ctx, msg.ChatID, msg.ID, chat.ReactionVolume); err != nil {
+		d.logger.Debug("Failed to add volume reaction", zap.Error(err))
+	}
+
+	if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, d.localizerFor(msg).T("success.volume_set", percent)); err != nil {
+		d.logger.Error("Failed to confirm volume change", zap.Error(err))
+	}
+}
+
+// reportVolume replies with the volume of the active playback device.
+func (d *Dispatcher) reportVolume(ctx context.Context, msg *chat.Message) {
+	percent, err := d.spotify.GetVolume(ctx)
+	if err != nil {
+		d.logger.Error("Failed to get volume", zap.Error(err))
+		d.replyCommandError(ctx, msg, "error.spotify.volume_failed")
+		return
+	}
+
+	if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, d.localizerFor(msg).T("bot.volume_current", percent)); err != nil {
+		d.logger.Error("Failed to send volume", zap.Error(err))
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+// volumeTestSpotify keeps the volume of a single playback device.
+type volumeTestSpotify struct {
+	SpotifyClient
+	hasDevice bool
+	volume    int
+}
+
+func (s *volumeTestSpotify) HasActiveDevice(_ context.Context) (bool, error) {
+	return s.hasDevice, nil
+}
+
+func (s *volumeTestSpotify) GetVolume(_ context.Context) (int, error) {
+	return s.volume, nil
+}
+
+func (s *volumeTestSpotify) SetVolume(_ context.Context, percent int) error {
+	s.volume = percent
+	return nil
+}
+
+func TestHandleVolumeCommand(t *testing.T) {
+	ctx := context.Background()
+	spotify := &volumeTestSpotify{hasDevice: true, volume: 40}
+	frontend := fake.New()
+	frontend.SetAdmins("1")
+
+	d := &Dispatcher{
+		config:    DefaultConfig(),
+		spotify:   spotify,
+		frontend:  frontend,
+		localizer: i18n.NewLocalizer(i18n.DefaultLanguage),
+		logger:    zap.NewNop(),
+	}
+	d.frontend.SetCommandHandler(commandVolume, true, d.handleVolumeCommand)
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "1", ChatID: "-100", SenderID: "1", Text: "/volume"})
+	if !frontend.HasSentKey("bot.volume_current", 40) {
+		t.Errorf("Expected the current volume, got %+v", frontend.SentMessages())
+	}
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "2", ChatID: "-100", SenderID: "1", Text: "/volume 75"})
+	if spotify.volume != 75 || !frontend.HasSentKey("success.volume_set", 75) || !frontend.HasReacted("2", chat.ReactionVolume) {
+		t.Errorf("Expected the volume to be set to 75, got %d", spotify.volume)
+	}
+
+	invalid := &chat.Message{ID: "3", ChatID: "-100", SenderID: "1", SenderName: "Admin", Text: "/volume 150"}
+	frontend.RunCommand(ctx, invalid)
+	if spotify.volume != 75 || !frontend.HasSent(d.formatMessageWithMention(invalid, d.localizer.T("error.volume.invalid"))) {
+		t.Error("Out of range volumes should be rejected")
+	}
+
+	spotify.hasDevice = false
+	noDevice := &chat.Message{ID: "4", ChatID: "-100", SenderID: "1", SenderName: "Admin", Text: "/volume 20"}
+	frontend.RunCommand(ctx, noDevice)
+	if spotify.volume != 75 || !frontend.HasSent(d.formatMessageWithMention(noDevice, d.localizer.T("error.spotify.no_active_device"))) {
+		t.Error("Volume changes without an active device should be rejected")
+	}
+}
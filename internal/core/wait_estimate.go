@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/i18n"
+)
+
+// Estimated Play Time
+// This module estimates when an added track will play, so requesters know how long to wait
+
+const (
+	// roughEstimateTrackCount is the number of tracks ahead from which a play time estimate is only rough,
+	// as skips, priority requests and queue filling add up over a long stretch of the playlist.
+	roughEstimateTrackCount = 25
+	// roughEstimateRounding is the precision rough play time estimates are shown with.
+	roughEstimateRounding = 15 * time.Minute
+)
+
+// estimatePlayTime estimates how long until a track plays: the remaining time of the current track,
+// the shadow queue and the playlist tracks between the current position and the track.
+// rough reports whether so many tracks are ahead that the estimate is unreliable.
+// Returns false if the track's position relative to the current track is unknown.
+func (d *Dispatcher) estimatePlayTime(ctx context.Context, trackID string) (wait time.Duration, rough, ok bool) {
+	if _, timeUntilPlay, queued := d.findTrackQueueInfo(ctx, trackID); queued {
+		return timeUntilPlay, false, true
+	}
+
+	playlistTracks, err := d.spotify.GetPlaylistTracksWithDetails(ctx, d.config.Spotify.PlaylistID)
+	if err != nil {
+		d.logger.Debug("Failed to get playlist tracks for play time estimate", zap.Error(err))
+		return 0, false, false
+	}
+	position, err := d.findLogicalPlaylistPosition(ctx, playlistTracks)
+	if err != nil || position == nil {
+		return 0, false, false
+	}
+
+	wait, err = d.GetQueueRemainingDurationWithShadow(ctx)
+	if err != nil {
+		return 0, false, false
+	}
+
+	// Playlist tracks already in the shadow queue are counted in its duration
+	d.shadowQueueMutex.RLock()
+	tracksAhead := len(d.shadowQueue)
+	queuedTrackIDs := make(map[string]bool, len(d.shadowQueue))
+	for _, item := range d.shadowQueue {
+		queuedTrackIDs[item.TrackID] = true
+	}
+	d.shadowQueueMutex.RUnlock()
+
+	for i := *position + 1; i < len(playlistTracks); i++ {
+		track := playlistTracks[i]
+		if track.ID == trackID {
+			return wait, tracksAhead >= roughEstimateTrackCount, true
+		}
+		if queuedTrackIDs[track.ID] {
+			continue
+		}
+		wait += track.Duration
+		tracksAhead++
+	}
+
+	// The track sits before the current position and only plays once the playlist wraps around
+	return 0, false, false
+}
+
+// formatPlayEstimate formats the estimated play time line of a success message.
+// Returns an empty string if no estimate can be made.
+func (d *Dispatcher) formatPlayEstimate(ctx context.Context, localizer *i18n.Localizer, trackID string) string {
+	wait, rough, ok := d.estimatePlayTime(ctx, trackID)
+	if !ok {
+		return ""
+	}
+	if rough {
+		return localizer.T("format.play_estimate_rough",
+			formatQuotaResetIn(max(wait.Round(roughEstimateRounding), roughEstimateRounding)))
+	}
+	return localizer.T("format.play_estimate", formatQuotaResetIn(wait))
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// waitEstimateTestSpotify plays the first track of a fixed playlist.
+type waitEstimateTestSpotify struct {
+	SpotifyClient
+	playlist []Track
+}
+
+func (s waitEstimateTestSpotify) GetCurrentTrackID(_ context.Context) (string, error) {
+	return s.playlist[0].ID, nil
+}
+
+func (s waitEstimateTestSpotify) GetCurrentTrackRemainingTime(_ context.Context) (time.Duration, error) {
+	return time.Minute, nil
+}
+
+func (s waitEstimateTestSpotify) GetPlaylistTracksWithDetails(_ context.Context, _ string) ([]Track, error) {
+	return s.playlist, nil
+}
+
+func newWaitEstimateTestDispatcher(playlistLength int) *Dispatcher {
+	playlist := make([]Track, playlistLength)
+	for i := range playlist {
+		playlist[i] = Track{ID: fmt.Sprintf("track%d", i), Duration: 3 * time.Minute}
+	}
+
+	d, _ := newTestDispatcher(nil, waitEstimateTestSpotify{playlist: playlist})
+	d.shadowQueue = []ShadowQueueItem{
+		{TrackID: "track1", Duration: 3 * time.Minute},
+	}
+	return d
+}
+
+func TestEstimatePlayTime(t *testing.T) {
+	ctx := context.Background()
+	d := newWaitEstimateTestDispatcher(40)
+
+	tests := []struct {
+		trackID       string
+		expectedWait  time.Duration
+		expectedRough bool
+	}{
+		// Shadow queued: current track remainder only
+		{trackID: "track1", expectedWait: time.Minute},
+		// Current remainder, shadow queue and track2
+		{trackID: "track3", expectedWait: 7 * time.Minute},
+		// Current remainder, shadow queue and 24 playlist tracks
+		{trackID: "track26", expectedWait: 76 * time.Minute, expectedRough: true},
+	}
+
+	for _, tt := range tests {
+		wait, rough, ok := d.estimatePlayTime(ctx, tt.trackID)
+		if !ok || wait != tt.expectedWait || rough != tt.expectedRough {
+			t.Errorf("estimatePlayTime(%q) = %v, %v, %v, want %v, %v, true",
+				tt.trackID, wait, rough, ok, tt.expectedWait, tt.expectedRough)
+		}
+	}
+
+	if _, _, ok := d.estimatePlayTime(ctx, "unknown"); ok {
+		t.Error("Expected no estimate for tracks outside the playlist")
+	}
+}
+
+func TestFormatPlayEstimate(t *testing.T) {
+	ctx := context.Background()
+	d := newWaitEstimateTestDispatcher(40)
+
+	if got, want := d.formatPlayEstimate(ctx, d.localizer, "track3"),
+		d.localizer.T("format.play_estimate", "7m"); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got, want := d.formatPlayEstimate(ctx, d.localizer, "track26"),
+		d.localizer.T("format.play_estimate_rough", "1h15m"); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got := d.formatPlayEstimate(ctx, d.localizer, "unknown"); got != "" {
+		t.Errorf("Expected no estimate line, got %q", got)
+	}
+}
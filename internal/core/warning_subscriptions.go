@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Warning Subscriptions
+// This module implements /start in private chats, which subscribes operators to admin warnings
+// (device, sync, auth, ...) by direct message even if they aren't admins of the group (yet).
+// Group admins may subscribe with a plain /start, others need the deep link with the configured token,
+// e.g. https://t.me/<bot>?start=<token>, since warnings can contain Spotify authorization links.
+
+const commandStart = "start"
+
+// handleStartCommand subscribes the sender of a private /start to admin warnings if they may receive them.
+func (d *Dispatcher) handleStartCommand(ctx context.Context, msg *chat.Message) {
+	if msg.IsGroup {
+		return
+	}
+
+	var payload string
+	if fields := strings.Fields(msg.Text); len(fields) > 1 {
+		payload = fields[1]
+	}
+
+	messageKey := "bot.warnings_subscribed"
+	if !d.maySubscribeToWarnings(ctx, msg.SenderID, payload) {
+		messageKey = "bot.warnings_subscribe_denied"
+	} else if err := d.warningSubscribers.Add(msg.SenderID); err != nil {
+		d.logger.Error("Failed to store warning subscriber", zap.Error(err))
+		messageKey = "error.generic"
+	} else {
+		d.logger.Info("User subscribed to admin warnings",
+			zap.String("userID", msg.SenderID),
+			zap.String("userName", msg.SenderName))
+	}
+
+	if _, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID, d.localizerFor(msg).T(messageKey)); err != nil {
+		d.logger.Error("Failed to reply to /start", zap.Error(err))
+	}
+}
+
+// maySubscribeToWarnings reports whether the user brought the configured deep-link token or is a group admin.
+func (d *Dispatcher) maySubscribeToWarnings(ctx context.Context, userID, payload string) bool {
+	token := d.config.App.WarningSubscribeToken
+	if token != "" && subtle.ConstantTimeCompare([]byte(payload), []byte(token)) == 1 {
+		return true
+	}
+
+	groupID := d.getGroupID()
+	if groupID == "" {
+		return false
+	}
+
+	isAdmin, err := d.frontend.IsUserAdmin(ctx, groupID, userID)
+	if err != nil {
+		d.logger.Warn("Failed to check admin status for /start", zap.Error(err))
+		return false
+	}
+	return isAdmin
+}
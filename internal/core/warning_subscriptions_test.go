@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+)
+
+// memoryWarningSubscribers is an in-memory WarningSubscriberStore.
+type memoryWarningSubscribers struct {
+	userIDs []string
+}
+
+func (s *memoryWarningSubscribers) Add(userID string) error {
+	if !slices.Contains(s.userIDs, userID) {
+		s.userIDs = append(s.userIDs, userID)
+	}
+	return nil
+}
+
+func (s *memoryWarningSubscribers) UserIDs() []string {
+	return s.userIDs
+}
+
+func newWarningSubscriptionTestDispatcher() (*Dispatcher, *fake.Frontend, *memoryWarningSubscribers) {
+	config := DefaultConfig()
+	config.Telegram.GroupID = -100
+	config.App.WarningSubscribeToken = "secret"
+
+	subscribers := &memoryWarningSubscribers{}
+	d, frontend := newTestDispatcher(config, nil)
+	frontend.SetAdmins("1")
+	d.warningSubscribers = subscribers
+	d.warningManager = NewAdminWarningManager(frontend, subscribers, zap.NewNop())
+	d.registerCommandHandlers()
+	return d, frontend, subscribers
+}
+
+func TestHandleStartCommand(t *testing.T) {
+	ctx := context.Background()
+	_, frontend, subscribers := newWarningSubscriptionTestDispatcher()
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "1", ChatID: "2", SenderID: "2", Text: "/start"})
+	if !frontend.HasSentKey("bot.warnings_subscribe_denied") || len(subscribers.userIDs) != 0 {
+		t.Errorf("Expected non-admins without the token to be denied, got %v", subscribers.userIDs)
+	}
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "2", ChatID: "2", SenderID: "2", Text: "/start wrong"})
+	if len(subscribers.userIDs) != 0 {
+		t.Errorf("Expected a wrong token to be denied, got %v", subscribers.userIDs)
+	}
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "3", ChatID: "1", SenderID: "1", Text: "/start"})
+	frontend.RunCommand(ctx, &chat.Message{ID: "4", ChatID: "2", SenderID: "2", Text: "/start secret"})
+	if !slices.Equal(subscribers.userIDs, []string{"1", "2"}) {
+		t.Errorf("Expected the admin and the user with the token to be subscribed, got %v", subscribers.userIDs)
+	}
+	if !frontend.HasSentKey("bot.warnings_subscribed") {
+		t.Errorf("Expected a confirmation, got %+v", frontend.SentMessages())
+	}
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "5", ChatID: "-100", SenderID: "3", Text: "/start secret", IsGroup: true})
+	if slices.Contains(subscribers.userIDs, "3") {
+		t.Error("Expected /start in the group to be ignored")
+	}
+}
+
+func TestSendWarningToAdmins_Subscribers(t *testing.T) {
+	ctx := context.Background()
+	d, frontend, subscribers := newWarningSubscriptionTestDispatcher()
+	subscribers.userIDs = []string{"1", "2"}
+
+	if err := d.warningManager.SendWarningToAdmins(ctx, WarningTypeDevice, []string{"1"}, "warning"); err != nil {
+		t.Fatalf("SendWarningToAdmins() error = %v", err)
+	}
+
+	var recipients []string
+	for _, message := range frontend.SentMessages() {
+		if message.Direct {
+			recipients = append(recipients, message.ChatID)
+		}
+	}
+	if !slices.Equal(recipients, []string{"1", "2"}) {
+		t.Errorf("Expected the admin and the subscriber to be warned once each, got %v", recipients)
+	}
+}
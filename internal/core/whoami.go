@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+)
+
+// Who Am I
+// This module implements the /whoami command which tells users their user ID, the chat ID
+// and whether they are detected as admin, to help setting up the group and admin approval
+
+const (
+	commandWhoami = "whoami"
+	// whoamiReplyLifetime is how long the /whoami reply stays in the chat before it is deleted.
+	whoamiReplyLifetime = time.Minute
+)
+
+// handleWhoamiCommand replies with the caller's user ID, the chat ID and their admin status.
+func (d *Dispatcher) handleWhoamiCommand(ctx context.Context, msg *chat.Message) {
+	localizer := d.localizerFor(msg)
+
+	adminStatus := localizer.T("bot.whoami_not_admin")
+	isAdmin, err := d.frontend.IsUserAdmin(ctx, msg.ChatID, msg.SenderID)
+	switch {
+	case err != nil:
+		d.logger.Warn("Failed to check admin status for /whoami", zap.Error(err))
+		adminStatus = localizer.T("bot.whoami_admin_unknown")
+	case isAdmin:
+		adminStatus = localizer.T("bot.whoami_admin")
+	}
+
+	replyID, err := d.frontend.SendText(ctx, msg.ChatID, msg.ID,
+		localizer.T("bot.whoami", msg.SenderID, msg.ChatID, adminStatus))
+	if err != nil {
+		d.logger.Error("Failed to send /whoami reply", zap.Error(err))
+		return
+	}
+
+	// IDs are only needed while setting things up, so don't clutter the chat with them
+	time.AfterFunc(whoamiReplyLifetime, func() {
+		d.deleteWhoamiMessages(context.Background(), msg.ChatID, msg.ID, replyID)
+	})
+}
+
+// deleteWhoamiMessages deletes a /whoami command and its reply, leaving them in place if the bot may not.
+func (d *Dispatcher) deleteWhoamiMessages(ctx context.Context, chatID string, messageIDs ...string) {
+	for _, messageID := range messageIDs {
+		if err := d.frontend.DeleteMessage(ctx, chatID, messageID); err != nil {
+			d.logger.Debug("Failed to delete /whoami message",
+				zap.String("messageID", messageID),
+				zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/chat"
+	"djalgorhythm/internal/chat/fake"
+	"djalgorhythm/internal/i18n"
+)
+
+func TestHandleWhoamiCommand(t *testing.T) {
+	ctx := context.Background()
+	frontend := fake.New()
+	frontend.SetAdmins("1")
+
+	d := &Dispatcher{
+		config:    DefaultConfig(),
+		frontend:  frontend,
+		localizer: i18n.NewLocalizer(i18n.DefaultLanguage),
+		logger:    zap.NewNop(),
+	}
+	d.frontend.SetCommandHandler(commandWhoami, false, d.handleWhoamiCommand)
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "1", ChatID: "-100", SenderID: "1", Text: "/whoami"})
+	if !frontend.HasSentKey("bot.whoami", "1", "-100", d.localizer.T("bot.whoami_admin")) {
+		t.Errorf("Expected the admin's IDs, got %+v", frontend.SentMessages())
+	}
+
+	frontend.RunCommand(ctx, &chat.Message{ID: "2", ChatID: "-100", SenderID: "2", Text: "/whoami"})
+	reply, _ := frontend.LastSent()
+	if !frontend.HasSentKey("bot.whoami", "2", "-100", d.localizer.T("bot.whoami_not_admin")) {
+		t.Errorf("Expected the member's IDs, got %+v", frontend.SentMessages())
+	}
+
+	d.deleteWhoamiMessages(ctx, "-100", "2", reply.ID)
+	if deleted := frontend.DeletedMessages(); !slices.Equal(deleted, []string{"2", reply.ID}) {
+		t.Errorf("Expected the command and its reply to be deleted, got %v", deleted)
+	}
+}
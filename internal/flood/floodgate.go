@@ -2,38 +2,48 @@
 package flood
 
 import (
+	"slices"
 	"sync"
 	"time"
 )
 
 const (
-	// windowDuration is the fixed time window for flood detection (always 1 minute).
+	// windowDuration is the time window in which the limit of messages refills (always 1 minute).
 	windowDuration = 60 * time.Second
 	// cleanupInterval is how often we clean up expired entries.
 	cleanupInterval = 10 * time.Minute
 	// idleTimeout is how long before we remove idle user entries.
 	idleTimeout = 10 * time.Minute
+	// repeatedLinkWindow is how long a shared link counts as a repeat when the same user sends it again.
+	repeatedLinkWindow = 2 * time.Minute
 )
 
-// Floodgate provides per-user, per-chat flood prevention with sliding window rate limiting.
+// Floodgate provides per-user, per-chat flood prevention with token bucket rate limiting.
+// Each user starts with a full bucket of limitPerMinute+burst messages, refilled at limitPerMinute
+// per minute, so quick bursts are allowed while sustained spam is held to the limit.
 type Floodgate struct {
-	limitPerMinute int                   // Maximum messages per user per minute
+	limitPerMinute int                   // Maximum sustained messages per user per minute
+	burst          int                   // Extra messages allowed in quick succession on top of the limit
 	entries        map[string]*userEntry // Key: "chatID:userID"
 	mutex          sync.RWMutex
 	stopCleanup    chan struct{}
 }
 
-// userEntry tracks message timestamps for a specific user in a specific chat.
+// userEntry tracks the token bucket of a specific user in a specific chat.
 type userEntry struct {
-	timestamps []time.Time // Sliding window of message timestamps
-	lastSeen   time.Time   // When this user was last seen (for cleanup)
+	tokens      float64   // Messages the user may still send right now
+	lastRefill  time.Time // When tokens were last refilled
+	lastSeen    time.Time // When this user was last seen (for cleanup)
+	lastLinks   []string  // Links of the user's previous message containing links
+	lastLinksAt time.Time // When the previous links were shared
 }
 
 // New creates a new Floodgate with the specified rate limiting configuration.
-// The time window is fixed at 60 seconds (1 minute).
-func New(limitPerMinute int) *Floodgate {
+// The limit refills over a fixed window of 60 seconds (1 minute); a negative burst is treated as 0.
+func New(limitPerMinute, burst int) *Floodgate {
 	fg := &Floodgate{
 		limitPerMinute: limitPerMinute,
+		burst:          max(burst, 0),
 		entries:        make(map[string]*userEntry),
 		stopCleanup:    make(chan struct{}),
 	}
@@ -58,39 +68,77 @@ func (fg *Floodgate) CheckMessage(chatID, userID string) bool {
 	fg.mutex.Lock()
 	defer fg.mutex.Unlock()
 
-	// Get or create user entry
-	entry, exists := fg.entries[key]
-	if !exists {
-		entry = &userEntry{
-			timestamps: make([]time.Time, 0, fg.limitPerMinute+1),
-		}
-		fg.entries[key] = entry
+	entry := fg.getEntry(key, now)
+
+	// Without a sustained limit no bucket refills, block everything
+	if fg.limitPerMinute <= 0 {
+		return false
 	}
 
-	// Update last seen time
-	entry.lastSeen = now
+	// Refill tokens for the time passed, up to the bucket capacity
+	refillRate := float64(fg.limitPerMinute) / windowDuration.Seconds()
+	entry.tokens = min(fg.capacity(), entry.tokens+now.Sub(entry.lastRefill).Seconds()*refillRate)
+	entry.lastRefill = now
 
-	// Remove timestamps outside the window
-	windowStart := now.Add(-windowDuration)
-	validTimestamps := entry.timestamps[:0] // Reuse slice capacity
-	for _, ts := range entry.timestamps {
-		if ts.After(windowStart) {
-			validTimestamps = append(validTimestamps, ts)
-		}
+	if entry.tokens < 1 {
+		// User has used up their messages, do not allow message
+		return false
 	}
-	entry.timestamps = validTimestamps
 
-	// Check if user has exceeded the limit
-	if len(entry.timestamps) >= fg.limitPerMinute {
-		// User has exceeded the limit, do not allow message
-		return false
+	// Take a token and allow message
+	entry.tokens--
+	return true
+}
+
+// capacity returns the number of messages a user may send at once after being quiet.
+func (fg *Floodgate) capacity() float64 {
+	return float64(fg.limitPerMinute + fg.burst)
+}
+
+// CheckLinks checks if a message repeats a link the same user shared in their previous message with links
+// within a short window. Returns true if the message should be processed, false if it should be blocked.
+func (fg *Floodgate) CheckLinks(chatID, userID string, links []string) bool {
+	if len(links) == 0 {
+		return true
+	}
+
+	key := chatID + ":" + userID
+	now := time.Now()
+
+	fg.mutex.Lock()
+	defer fg.mutex.Unlock()
+
+	entry := fg.getEntry(key, now)
+
+	if now.Sub(entry.lastLinksAt) < repeatedLinkWindow {
+		for _, link := range links {
+			if slices.Contains(entry.lastLinks, link) {
+				return false
+			}
+		}
 	}
 
-	// Add current timestamp and allow message
-	entry.timestamps = append(entry.timestamps, now)
+	entry.lastLinks = slices.Clone(links)
+	entry.lastLinksAt = now
 	return true
 }
 
+// getEntry returns the entry for the key, creating it if needed, and marks it as seen.
+// Must be called with the mutex held.
+func (fg *Floodgate) getEntry(key string, now time.Time) *userEntry {
+	entry, exists := fg.entries[key]
+	if !exists {
+		entry = &userEntry{
+			tokens:     fg.capacity(),
+			lastRefill: now,
+		}
+		fg.entries[key] = entry
+	}
+
+	entry.lastSeen = now
+	return entry
+}
+
 // cleanup removes idle user entries to prevent memory leaks.
 func (fg *Floodgate) cleanup() {
 	// Run immediately on startup
@@ -130,6 +178,7 @@ func (fg *Floodgate) GetStats() Stats {
 	return Stats{
 		ActiveUsers:    len(fg.entries),
 		LimitPerMinute: fg.limitPerMinute,
+		Burst:          fg.burst,
 		WindowSeconds:  int(windowDuration.Seconds()), // Fixed 1-minute window
 	}
 }
@@ -138,5 +187,6 @@ func (fg *Floodgate) GetStats() Stats {
 type Stats struct {
 	ActiveUsers    int `json:"active_users"`
 	LimitPerMinute int `json:"limit_per_minute"`
+	Burst          int `json:"burst"`
 	WindowSeconds  int `json:"window_seconds"`
 }
@@ -11,7 +11,7 @@ const (
 )
 
 func TestFloodgate_CheckMessage_AllowsNormalUsage(t *testing.T) {
-	fg := New(3) // 3 messages per minute
+	fg := New(3, 0) // 3 messages per minute
 	defer fg.Stop()
 
 	chatID := testChatID
@@ -33,7 +33,7 @@ func TestFloodgate_CheckMessage_AllowsNormalUsage(t *testing.T) {
 func TestFloodgate_CheckMessage_SlidingWindow(t *testing.T) {
 	// This test verifies the sliding window concept but doesn't wait the full 60 seconds
 	// Instead we test that the window works correctly by manipulating internal state
-	fg := New(2) // 2 messages per minute
+	fg := New(2, 0) // 2 messages per minute
 	defer fg.Stop()
 
 	chatID := testChatID
@@ -52,16 +52,13 @@ func TestFloodgate_CheckMessage_SlidingWindow(t *testing.T) {
 		t.Error("Third message should be blocked")
 	}
 
-	// Manually adjust timestamps to simulate time passing
+	// Manually adjust the token bucket to simulate time passing
 	// This is internal testing, so we access internal state
 	key := chatID + ":" + userID
 	fg.mutex.Lock()
 	if entry, exists := fg.entries[key]; exists {
-		// Move timestamps back by 61 seconds to simulate window expiry
-		pastTime := time.Now().Add(-61 * time.Second)
-		for i := range entry.timestamps {
-			entry.timestamps[i] = pastTime
-		}
+		// Move the last refill back by 61 seconds to simulate window expiry
+		entry.lastRefill = time.Now().Add(-61 * time.Second)
 	}
 	fg.mutex.Unlock()
 
@@ -72,7 +69,7 @@ func TestFloodgate_CheckMessage_SlidingWindow(t *testing.T) {
 }
 
 func TestFloodgate_CheckMessage_PerUserPerChat(t *testing.T) {
-	fg := New(2) // 2 messages per minute
+	fg := New(2, 0) // 2 messages per minute
 	defer fg.Stop()
 
 	chatID1 := "chat1"
@@ -110,7 +107,7 @@ func TestFloodgate_CheckMessage_PerUserPerChat(t *testing.T) {
 }
 
 func TestFloodgate_CheckMessage_WindowExpiry(t *testing.T) {
-	fg := New(1) // 1 message per minute
+	fg := New(1, 0) // 1 message per minute
 	defer fg.Stop()
 
 	chatID := testChatID
@@ -126,12 +123,12 @@ func TestFloodgate_CheckMessage_WindowExpiry(t *testing.T) {
 		t.Error("Second immediate message should be blocked")
 	}
 
-	// Simulate window expiry by manipulating internal timestamps
+	// Simulate window expiry by manipulating the internal token bucket
 	key := chatID + ":" + userID
 	fg.mutex.Lock()
 	if entry, exists := fg.entries[key]; exists {
-		// Move timestamp back by 61 seconds to simulate window expiry
-		entry.timestamps[0] = time.Now().Add(-61 * time.Second)
+		// Move the last refill back by 61 seconds to simulate window expiry
+		entry.lastRefill = time.Now().Add(-61 * time.Second)
 	}
 	fg.mutex.Unlock()
 
@@ -141,8 +138,75 @@ func TestFloodgate_CheckMessage_WindowExpiry(t *testing.T) {
 	}
 }
 
+// rewind moves the last refill of the user's bucket back to simulate time passing.
+func rewind(fg *Floodgate, chatID, userID string, elapsed time.Duration) {
+	fg.mutex.Lock()
+	defer fg.mutex.Unlock()
+	if entry, exists := fg.entries[chatID+":"+userID]; exists {
+		entry.lastRefill = entry.lastRefill.Add(-elapsed)
+	}
+}
+
+func TestFloodgate_CheckMessage_Burst(t *testing.T) {
+	fg := New(2, 3) // 2 messages per minute, 3 extra in quick succession
+	defer fg.Stop()
+
+	// A quiet user may send the limit and the burst at once
+	for i := range 5 {
+		if !fg.CheckMessage(testChatID, testUserID) {
+			t.Errorf("Message %d should be allowed within the burst", i+1)
+		}
+	}
+	if fg.CheckMessage(testChatID, testUserID) {
+		t.Error("Message beyond the burst should be blocked")
+	}
+}
+
+func TestFloodgate_CheckMessage_Sustained(t *testing.T) {
+	fg := New(2, 3) // A message every 30 seconds, sustained
+	defer fg.Stop()
+
+	for range 5 {
+		fg.CheckMessage(testChatID, testUserID)
+	}
+
+	// Sustained spam only gets through at the limit, the burst doesn't come back right away
+	rewind(fg, testChatID, testUserID, 30*time.Second)
+	if !fg.CheckMessage(testChatID, testUserID) {
+		t.Error("Message after half a minute should be allowed")
+	}
+	if fg.CheckMessage(testChatID, testUserID) {
+		t.Error("Second message after half a minute should be blocked")
+	}
+
+	rewind(fg, testChatID, testUserID, 15*time.Second)
+	if fg.CheckMessage(testChatID, testUserID) {
+		t.Error("Message after a quarter minute should be blocked")
+	}
+}
+
+func TestFloodgate_CheckMessage_WindowReset(t *testing.T) {
+	fg := New(2, 1)
+	defer fg.Stop()
+
+	for range 3 {
+		fg.CheckMessage(testChatID, testUserID)
+	}
+
+	// Being quiet for a long time refills the bucket up to the limit and burst, not beyond
+	rewind(fg, testChatID, testUserID, 10*time.Minute)
+	for i := range 3 {
+		if !fg.CheckMessage(testChatID, testUserID) {
+			t.Errorf("Message %d after the window reset should be allowed", i+1)
+		}
+	}
+	if fg.CheckMessage(testChatID, testUserID) {
+		t.Error("Message beyond the refilled bucket should be blocked")
+	}
+}
+
 func TestFloodgate_GetStats(t *testing.T) {
-	fg := New(5)
+	fg := New(5, 0)
 	defer fg.Stop()
 
 	// Check initial stats
@@ -170,7 +234,7 @@ func TestFloodgate_GetStats(t *testing.T) {
 
 func TestFloodgate_EdgeCases(t *testing.T) {
 	t.Run("Zero limit", func(t *testing.T) {
-		fg := New(0)
+		fg := New(0, 0)
 		defer fg.Stop()
 
 		// All messages should be blocked with zero limit
@@ -180,7 +244,7 @@ func TestFloodgate_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("Empty identifiers", func(t *testing.T) {
-		fg := New(1)
+		fg := New(1, 0)
 		defer fg.Stop()
 
 		// Should handle empty strings gracefully
@@ -193,7 +257,7 @@ func TestFloodgate_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("Window behavior", func(t *testing.T) {
-		fg := New(1) // 1 message per minute
+		fg := New(1, 0) // 1 message per minute
 		defer fg.Stop()
 
 		// First message should be allowed
@@ -211,7 +275,7 @@ func TestFloodgate_Cleanup(t *testing.T) {
 	// This test is more complex and would require manipulating internal state
 	// or waiting for actual cleanup cycles. For production use, we verify
 	// that cleanup doesn't crash and basic functionality works.
-	fg := New(1)
+	fg := New(1, 0)
 	defer fg.Stop()
 
 	// Add some entries
@@ -228,7 +292,7 @@ func TestFloodgate_Cleanup(t *testing.T) {
 }
 
 func TestFloodgate_ConcurrentAccess(t *testing.T) {
-	fg := New(10)
+	fg := New(10, 0)
 	defer fg.Stop()
 
 	// Test concurrent access from multiple goroutines
@@ -256,3 +320,33 @@ func TestFloodgate_ConcurrentAccess(t *testing.T) {
 		t.Error("Stats should be valid after concurrent access")
 	}
 }
+
+func TestFloodgate_CheckLinks_BlocksRepeatedLinks(t *testing.T) {
+	fg := New(10, 0)
+	defer fg.Stop()
+
+	link := "https://open.spotify.com/track/abc"
+
+	if !fg.CheckLinks(testChatID, testUserID, []string{link}) {
+		t.Error("First link should be allowed")
+	}
+	if fg.CheckLinks(testChatID, testUserID, []string{"https://example.com", link}) {
+		t.Error("Repeated link should be blocked")
+	}
+	if !fg.CheckLinks(testChatID, "user2", []string{link}) {
+		t.Error("Same link from another user should be allowed")
+	}
+	if !fg.CheckLinks(testChatID, testUserID, nil) {
+		t.Error("Messages without links should be allowed")
+	}
+
+	// Simulate the repeated link window expiring
+	key := testChatID + ":" + testUserID
+	fg.mutex.Lock()
+	fg.entries[key].lastLinksAt = time.Now().Add(-repeatedLinkWindow)
+	fg.mutex.Unlock()
+
+	if !fg.CheckLinks(testChatID, testUserID, []string{link}) {
+		t.Error("Link should be allowed again after the window expired")
+	}
+}
@@ -2,23 +2,38 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
 	"embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"html/template"
 	"io/fs"
+	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 
 	"djalgorhythm/internal/core"
+	"djalgorhythm/internal/metrics"
 )
 
 //go:embed web/static
 var staticFiles embed.FS
 
+//go:embed web/templates/dashboard.html
+var dashboardTemplateHTML string
+
+// dashboardTemplate renders the live dashboard page.
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(dashboardTemplateHTML))
+
 const homePageHTML = `<!DOCTYPE html>
 <html>
 <head>
@@ -50,62 +65,96 @@ const homePageHTML = `<!DOCTYPE html>
 const (
 	// ShutdownTimeoutSeconds is the timeout for graceful server shutdown.
 	ShutdownTimeoutSeconds = 10
+	// dashboardRefreshInterval is how often the dashboard page polls for new data.
+	dashboardRefreshInterval = 5 * time.Second
+	// spotifyCallbackPath is the path of the auto-generated Spotify redirect URL.
+	spotifyCallbackPath = "/callback"
 )
 
 // Server represents an HTTP server with metrics and health endpoints.
 type Server struct {
-	config  *core.ServerConfig
-	logger  *zap.Logger
-	server  *http.Server
-	metrics *Metrics
+	config   *core.ServerConfig
+	logger   *zap.Logger
+	server   *http.Server
+	redirect *http.Server // Redirects plain HTTP to HTTPS (nil if disabled)
 }
 
-// Metrics holds Prometheus metrics for the HTTP server.
-type Metrics struct {
-	PlaylistSize prometheus.Gauge
+// IngestionStatus reports whether song request ingestion is currently paused,
+// whether the chat frontend is connected, when the Spotify access token expires
+// and how likely the dedup store's Bloom filter reports a false positive.
+type IngestionStatus interface {
+	IsIngestionPaused() bool
+	IsChatConnected() bool
+	SpotifyTokenExpiry() time.Time
+	DedupFalsePositiveRate() (float64, bool)
 }
 
-// NewServer creates a new HTTP server with metrics and health endpoints.
-func NewServer(config *core.ServerConfig, logger *zap.Logger) *Server {
-	metrics := newMetrics()
-	mux := setupRoutes(logger)
-	server := createHTTPServer(config, mux)
+// ApprovalManager lists and force-resolves pending approvals.
+type ApprovalManager interface {
+	SnapshotApprovals() []core.PendingApproval
+	ResolveApproval(ctx context.Context, key string, approved bool) error
+}
 
-	return &Server{
-		config:  config,
-		logger:  logger,
-		server:  server,
-		metrics: metrics,
-	}
+// DashboardSource provides the live state shown on the web dashboard.
+type DashboardSource interface {
+	DashboardSnapshot(ctx context.Context) core.DashboardSnapshot
 }
 
-func newMetrics() *Metrics {
-	metrics := &Metrics{
-		PlaylistSize: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Name: "djalgorhythm_playlist_size",
-				Help: "Current number of tracks in playlist",
-			},
-		),
+// dashboardPage is the data rendered into the dashboard template.
+type dashboardPage struct {
+	core.DashboardSnapshot
+	RefreshIntervalMs int64
+}
+
+// healthResponse is the JSON body returned by the health endpoint.
+type healthResponse struct {
+	Status             string     `json:"status"`
+	Service            string     `json:"service"`
+	IngestionPaused    bool       `json:"ingestion_paused"`
+	Connected          *bool      `json:"connected,omitempty"`
+	SpotifyTokenExpiry *time.Time `json:"spotify_token_expiry,omitempty"`      // Refreshed automatically while authorized
+	DedupFPRate        *float64   `json:"dedup_false_positive_rate,omitempty"` // Estimated, bounded by rebuilding the filter
+}
+
+// NewServer creates a new HTTP server with health endpoints.
+// The optional ingestion status is reported by the health endpoint and
+// the optional metrics registry is served at /metrics. The optional approval manager
+// is exposed at /approvals when an admin token is configured, the optional
+// dashboard source replaces the home page with the live dashboard, and the optional
+// Spotify callback completes re-authorizations at /callback.
+func NewServer(config *core.ServerConfig, ingestion IngestionStatus, approvals ApprovalManager,
+	dashboard DashboardSource, spotifyCallback http.Handler, metricsRegistry *metrics.Registry,
+	logger *zap.Logger) *Server {
+	mux := setupRoutes(logger, ingestion, approvals, dashboard, config.AdminToken, metricsRegistry)
+	if spotifyCallback != nil {
+		mux.Handle("GET "+spotifyCallbackPath, spotifyCallback)
 	}
+	server := createHTTPServer(config, mux)
 
-	prometheus.MustRegister(
-		metrics.PlaylistSize,
-	)
+	var redirect *http.Server
+	if config.TLSCertFile != "" && config.HTTPRedirectPort > 0 {
+		redirect = createHTTPServer(&core.ServerConfig{
+			Host:         config.Host,
+			Port:         config.HTTPRedirectPort,
+			ReadTimeout:  config.ReadTimeout,
+			WriteTimeout: config.WriteTimeout,
+			IdleTimeout:  config.IdleTimeout,
+		}, httpsRedirectHandler(config.Port))
+	}
 
-	return metrics
+	return &Server{
+		config:   config,
+		logger:   logger,
+		server:   server,
+		redirect: redirect,
+	}
 }
 
-func setupRoutes(logger *zap.Logger) *http.ServeMux {
+func setupRoutes(logger *zap.Logger, ingestion IngestionStatus, approvals ApprovalManager,
+	dashboard DashboardSource, adminToken string, metricsRegistry *metrics.Registry) *http.ServeMux {
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		w.WriteHeader(http.StatusOK)
-		if _, err := w.Write([]byte(`{"status":"ok","service":"djalgorhythm"}`)); err != nil {
-			logger.Warn("Failed to write health response", zap.Error(err))
-		}
-	})
+	mux.HandleFunc("/healthz", healthHandler(logger, ingestion))
 
 	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -122,12 +171,134 @@ func setupRoutes(logger *zap.Logger) *http.ServeMux {
 	}
 	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.FS(staticFS))))
 
-	mux.Handle("/metrics", promhttp.Handler())
+	if metricsRegistry != nil {
+		mux.Handle("/metrics", metricsRegistry.Handler())
+	}
+	if approvals != nil && adminToken != "" {
+		mux.Handle("GET /approvals", requireAdminToken(adminToken, listApprovalsHandler(logger, approvals)))
+		mux.Handle("POST /approvals/{key}/resolve", requireAdminToken(adminToken, resolveApprovalHandler(logger, approvals)))
+	}
+
+	// With an admin token the dashboard requires it and shows requesters, without one it is public and doesn't
+	if dashboard != nil && adminToken != "" {
+		mux.Handle("GET /dashboard.json", requireDashboardToken(adminToken, dashboardDataHandler(logger, dashboard, true)))
+		mux.Handle("GET /{$}", requireDashboardToken(adminToken, dashboardHandler(logger, dashboard, true)))
+	} else if dashboard != nil {
+		mux.HandleFunc("GET /dashboard.json", dashboardDataHandler(logger, dashboard, false))
+		mux.HandleFunc("GET /{$}", dashboardHandler(logger, dashboard, false))
+	}
+
 	mux.HandleFunc("/", homeHandler(logger))
 
 	return mux
 }
 
+func healthHandler(logger *zap.Logger, ingestion IngestionStatus) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		response := healthResponse{Status: "ok", Service: "djalgorhythm"}
+		if ingestion != nil {
+			response.IngestionPaused = ingestion.IsIngestionPaused()
+			connected := ingestion.IsChatConnected()
+			response.Connected = &connected
+			if expiry := ingestion.SpotifyTokenExpiry(); !expiry.IsZero() {
+				response.SpotifyTokenExpiry = &expiry
+			}
+			if rate, ok := ingestion.DedupFalsePositiveRate(); ok {
+				response.DedupFPRate = &rate
+			}
+		}
+
+		body, err := json.Marshal(response)
+		if err != nil {
+			logger.Error("Failed to marshal health response", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			logger.Warn("Failed to write health response", zap.Error(err))
+		}
+	}
+}
+
+// requireAdminToken rejects requests that don't carry the admin token as bearer token.
+func requireAdminToken(adminToken string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || !isAdminToken(token, adminToken) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireDashboardToken rejects dashboard requests without the admin token. Browsers can't send headers
+// when opening a page, so the token may also be given as token query parameter (/?token=...).
+func requireDashboardToken(adminToken string, next http.Handler) http.Handler {
+	bearer := requireAdminToken(adminToken, next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("token"); token != "" && isAdminToken(token, adminToken) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		bearer.ServeHTTP(w, r)
+	})
+}
+
+// isAdminToken compares the token to the admin token in constant time.
+func isAdminToken(token, adminToken string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+}
+
+func listApprovalsHandler(logger *zap.Logger, approvals ApprovalManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		pending := approvals.SnapshotApprovals()
+		if pending == nil {
+			pending = []core.PendingApproval{}
+		}
+
+		body, err := json.Marshal(pending)
+		if err != nil {
+			logger.Error("Failed to marshal pending approvals", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			logger.Warn("Failed to write approvals response", zap.Error(err))
+		}
+	}
+}
+
+func resolveApprovalHandler(logger *zap.Logger, approvals ApprovalManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		approved, err := strconv.ParseBool(r.URL.Query().Get("approved"))
+		if err != nil {
+			http.Error(w, "query parameter approved must be true or false", http.StatusBadRequest)
+			return
+		}
+
+		key := r.PathValue("key")
+		if err := approvals.ResolveApproval(r.Context(), key, approved); err != nil {
+			if errors.Is(err, core.ErrApprovalNotFound) {
+				http.Error(w, "approval not found", http.StatusNotFound)
+				return
+			}
+			logger.Error("Failed to resolve approval", zap.String("key", key), zap.Error(err))
+			http.Error(w, "failed to resolve approval", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
 func homeHandler(logger *zap.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -138,35 +309,142 @@ func homeHandler(logger *zap.Logger) http.HandlerFunc {
 	}
 }
 
+// dashboardSnapshot returns the dashboard snapshot, without the requesters unless they may be shown.
+func dashboardSnapshot(ctx context.Context, dashboard DashboardSource, showRequesters bool) core.DashboardSnapshot {
+	snapshot := dashboard.DashboardSnapshot(ctx)
+	if showRequesters {
+		return snapshot
+	}
+
+	snapshot.RecentAdditions = slices.Clone(snapshot.RecentAdditions)
+	for i := range snapshot.RecentAdditions {
+		snapshot.RecentAdditions[i].Requester = ""
+	}
+	snapshot.PendingApprovals = slices.Clone(snapshot.PendingApprovals)
+	for i := range snapshot.PendingApprovals {
+		snapshot.PendingApprovals[i].Requester = ""
+	}
+	return snapshot
+}
+
+func dashboardHandler(logger *zap.Logger, dashboard DashboardSource, showRequesters bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page := dashboardPage{
+			DashboardSnapshot: dashboardSnapshot(r.Context(), dashboard, showRequesters),
+			RefreshIntervalMs: dashboardRefreshInterval.Milliseconds(),
+		}
+
+		var body bytes.Buffer
+		if err := dashboardTemplate.Execute(&body, page); err != nil {
+			logger.Error("Failed to render dashboard", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body.Bytes()); err != nil {
+			logger.Warn("Failed to write dashboard response", zap.Error(err))
+		}
+	}
+}
+
+func dashboardDataHandler(logger *zap.Logger, dashboard DashboardSource, showRequesters bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := json.Marshal(dashboardSnapshot(r.Context(), dashboard, showRequesters))
+		if err != nil {
+			logger.Error("Failed to marshal dashboard snapshot", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			logger.Warn("Failed to write dashboard response", zap.Error(err))
+		}
+	}
+}
+
 func createHTTPServer(config *core.ServerConfig, handler http.Handler) *http.Server {
 	return &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", config.Host, config.Port),
 		Handler:      handler,
 		ReadTimeout:  config.ReadTimeout,
 		WriteTimeout: config.WriteTimeout,
+		IdleTimeout:  config.IdleTimeout,
 	}
 }
 
-// Start starts the HTTP server and handles graceful shutdown on context cancellation.
+// httpsRedirectHandler permanently redirects requests to the same host and path on the HTTPS port.
+func httpsRedirectHandler(httpsPort int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hostname := (&url.URL{Host: r.Host}).Hostname()
+		target := url.URL{
+			Scheme:   "https",
+			Host:     net.JoinHostPort(hostname, strconv.Itoa(httpsPort)),
+			Path:     r.URL.Path,
+			RawQuery: r.URL.RawQuery,
+		}
+		http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+	}
+}
+
+// Start starts the HTTP server, serving HTTPS if a certificate is configured,
+// and handles graceful shutdown on context cancellation.
 func (s *Server) Start(ctx context.Context) error {
+	tlsEnabled := s.config.TLSCertFile != ""
 	s.logger.Info("Starting HTTP server",
-		zap.String("addr", s.server.Addr))
+		zap.String("addr", s.server.Addr),
+		zap.Bool("tls", tlsEnabled))
 
 	go func() {
 		<-ctx.Done()
 		s.logger.Info("Shutting down HTTP server")
 
-		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ShutdownTimeoutSeconds*time.Second)
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.shutdownTimeout())
 		defer cancel()
 
+		if s.redirect != nil {
+			if err := s.redirect.Shutdown(shutdownCtx); err != nil {
+				s.logger.Error("Failed to shutdown HTTPS redirect server gracefully", zap.Error(err))
+			}
+		}
 		if err := s.server.Shutdown(shutdownCtx); err != nil {
 			s.logger.Error("Failed to shutdown HTTP server gracefully", zap.Error(err))
 		}
 	}()
 
-	if err := s.server.ListenAndServe(); err != http.ErrServerClosed {
+	if s.redirect != nil {
+		go s.serveRedirect()
+	}
+
+	var err error
+	if tlsEnabled {
+		err = s.server.ListenAndServeTLS(s.config.TLSCertFile, s.config.TLSKeyFile)
+	} else {
+		err = s.server.ListenAndServe()
+	}
+	if err != http.ErrServerClosed {
 		return fmt.Errorf("HTTP server failed: %w", err)
 	}
 
 	return nil
 }
+
+// serveRedirect serves the redirect to HTTPS. Failures are only logged, as HTTPS keeps working without it.
+func (s *Server) serveRedirect() {
+	s.logger.Info("Redirecting HTTP to HTTPS", zap.String("addr", s.redirect.Addr))
+	if err := s.redirect.ListenAndServe(); err != http.ErrServerClosed {
+		s.logger.Error("HTTPS redirect server failed", zap.Error(err))
+	}
+}
+
+// shutdownTimeout returns the configured deadline for in-flight requests on shutdown.
+func (s *Server) shutdownTimeout() time.Duration {
+	if s.config.ShutdownTimeout > 0 {
+		return s.config.ShutdownTimeout
+	}
+	return ShutdownTimeoutSeconds * time.Second
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -11,10 +12,16 @@ import (
 	"go.uber.org/zap"
 
 	"djalgorhythm/internal/core"
+	"djalgorhythm/internal/metrics"
 )
 
 func TestNewServer(t *testing.T) {
-	t.Skip("Skipping NewServer test due to global prometheus registry conflicts")
+	config := &core.ServerConfig{Host: "127.0.0.1", Port: 8080}
+
+	server := NewServer(config, nil, nil, nil, nil, nil, zap.NewNop())
+	if server == nil || server.server == nil {
+		t.Fatal("NewServer() returned an incomplete server")
+	}
 }
 
 func TestCreateHTTPServer(t *testing.T) {
@@ -23,6 +30,7 @@ func TestCreateHTTPServer(t *testing.T) {
 		Port:         9090,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
 	}
 
 	mux := http.NewServeMux()
@@ -44,6 +52,49 @@ func TestCreateHTTPServer(t *testing.T) {
 	if server.WriteTimeout != config.WriteTimeout {
 		t.Errorf("createHTTPServer() WriteTimeout = %v, expected %v", server.WriteTimeout, config.WriteTimeout)
 	}
+
+	if server.IdleTimeout != config.IdleTimeout {
+		t.Errorf("createHTTPServer() IdleTimeout = %v, expected %v", server.IdleTimeout, config.IdleTimeout)
+	}
+}
+
+func TestNewServer_HTTPSRedirect(t *testing.T) {
+	config := &core.ServerConfig{Host: "127.0.0.1", Port: 8443, HTTPRedirectPort: 8080}
+	if server := NewServer(config, nil, nil, nil, nil, nil, zap.NewNop()); server.redirect != nil {
+		t.Error("Expected no HTTPS redirect without TLS")
+	}
+
+	config.TLSCertFile, config.TLSKeyFile = "cert.pem", "key.pem"
+	server := NewServer(config, nil, nil, nil, nil, nil, zap.NewNop())
+	if server.redirect == nil || server.redirect.Addr != "127.0.0.1:8080" {
+		t.Fatal("Expected plain HTTP on the redirect port to be redirected")
+	}
+}
+
+func TestHTTPSRedirectHandler(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected string
+	}{
+		{"dj.example.com", "https://dj.example.com:8443/callback?code=1"},
+		{"dj.example.com:80", "https://dj.example.com:8443/callback?code=1"},
+		{"[::1]:80", "https://[::1]:8443/callback?code=1"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/callback?code=1", http.NoBody)
+		req.Host = tt.host
+		recorder := httptest.NewRecorder()
+
+		httpsRedirectHandler(8443).ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusMovedPermanently {
+			t.Errorf("Host %q: expected status %d, got %d", tt.host, http.StatusMovedPermanently, recorder.Code)
+		}
+		if location := recorder.Header().Get("Location"); location != tt.expected {
+			t.Errorf("Host %q: expected redirect to %q, got %q", tt.host, tt.expected, location)
+		}
+	}
 }
 
 // testEndpoint is a helper function to test HTTP endpoints.
@@ -74,7 +125,7 @@ func testEndpoint(t *testing.T, server *httptest.Server, endpoint, expectedConte
 
 func TestSetupRoutes(t *testing.T) {
 	logger := zap.NewNop()
-	mux := setupRoutes(logger)
+	mux := setupRoutes(logger, nil, nil, nil, "", metrics.New())
 
 	if mux == nil {
 		t.Fatal("setupRoutes() returned nil")
@@ -104,7 +155,7 @@ func TestSetupRoutes(t *testing.T) {
 func testHealthEndpoint(t *testing.T, endpoint, expectedContent string) {
 	t.Helper()
 	logger := zap.NewNop()
-	mux := setupRoutes(logger)
+	mux := setupRoutes(logger, nil, nil, nil, "", metrics.New())
 	server := httptest.NewServer(mux)
 	defer server.Close()
 
@@ -134,7 +185,109 @@ func testHealthEndpoint(t *testing.T, endpoint, expectedContent string) {
 }
 
 func TestHealthzEndpoint(t *testing.T) {
-	testHealthEndpoint(t, "/healthz", `{"status":"ok","service":"djalgorhythm"}`)
+	testHealthEndpoint(t, "/healthz", `{"status":"ok","service":"djalgorhythm","ingestion_paused":false}`)
+}
+
+type fakeIngestionStatus struct {
+	paused       bool
+	disconnected bool
+	tokenExpiry  time.Time
+	dedupFPRate  *float64
+}
+
+func (f fakeIngestionStatus) IsIngestionPaused() bool {
+	return f.paused
+}
+
+func (f fakeIngestionStatus) IsChatConnected() bool {
+	return !f.disconnected
+}
+
+func (f fakeIngestionStatus) SpotifyTokenExpiry() time.Time {
+	return f.tokenExpiry
+}
+
+func (f fakeIngestionStatus) DedupFalsePositiveRate() (float64, bool) {
+	if f.dedupFPRate == nil {
+		return 0, false
+	}
+	return *f.dedupFPRate, true
+}
+
+func TestHealthzEndpoint_IngestionPaused(t *testing.T) {
+	handler := healthHandler(zap.NewNop(), fakeIngestionStatus{paused: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler(rec, req)
+
+	expected := `{"status":"ok","service":"djalgorhythm","ingestion_paused":true,"connected":true}`
+	if body := rec.Body.String(); body != expected {
+		t.Errorf("Expected body %q, got %q", expected, body)
+	}
+}
+
+func TestHealthzEndpoint_ChatDisconnected(t *testing.T) {
+	handler := healthHandler(zap.NewNop(), fakeIngestionStatus{disconnected: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler(rec, req)
+
+	expected := `{"status":"ok","service":"djalgorhythm","ingestion_paused":false,"connected":false}`
+	if body := rec.Body.String(); body != expected {
+		t.Errorf("Expected body %q, got %q", expected, body)
+	}
+}
+
+func TestHealthzEndpoint_SpotifyTokenExpiry(t *testing.T) {
+	expiry := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	handler := healthHandler(zap.NewNop(), fakeIngestionStatus{tokenExpiry: expiry})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler(rec, req)
+
+	expected := `{"status":"ok","service":"djalgorhythm","ingestion_paused":false,"connected":true,` +
+		`"spotify_token_expiry":"2026-10-16T12:30:00Z"}`
+	if body := rec.Body.String(); body != expected {
+		t.Errorf("Expected body %q, got %q", expected, body)
+	}
+}
+
+func TestHealthzEndpoint_DedupFalsePositiveRate(t *testing.T) {
+	rate := 0.0005
+	handler := healthHandler(zap.NewNop(), fakeIngestionStatus{dedupFPRate: &rate})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler(rec, req)
+
+	expected := `{"status":"ok","service":"djalgorhythm","ingestion_paused":false,"connected":true,` +
+		`"dedup_false_positive_rate":0.0005}`
+	if body := rec.Body.String(); body != expected {
+		t.Errorf("Expected body %q, got %q", expected, body)
+	}
+}
+
+func TestNewServer_SpotifyCallback(t *testing.T) {
+	called := false
+	callback := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+	server := NewServer(&core.ServerConfig{Host: "127.0.0.1", Port: 0}, nil, nil, nil, callback, nil, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?state=s&code=c", http.NoBody))
+
+	if !called || rec.Code != http.StatusOK {
+		t.Errorf("Expected the Spotify callback to be served, got status %d", rec.Code)
+	}
 }
 
 func TestReadyzEndpoint(t *testing.T) {
@@ -178,12 +331,23 @@ func TestHomeHandler(t *testing.T) {
 	}
 }
 
-func TestNewMetrics(t *testing.T) {
-	// Skip this test if run multiple times in the same process due to global registry
-	// In a production test environment, you would use a custom prometheus registry
-	// For now, we'll test the structure without the global registration
+func TestSetupRoutes_MetricsDisabled(t *testing.T) {
+	server := httptest.NewServer(setupRoutes(zap.NewNop(), nil, nil, nil, "", nil))
+	defer server.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/metrics", http.NoBody)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to call /metrics: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
 
-	t.Skip("Skipping newMetrics test due to global prometheus registry conflicts")
+	// Without a registry, /metrics falls through to the home page handler
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/html; charset=utf-8" {
+		t.Errorf("Expected /metrics to be disabled, got Content-Type %q", contentType)
+	}
 }
 
 func TestServer_StartContextCancellation(t *testing.T) {
@@ -193,3 +357,171 @@ func TestServer_StartContextCancellation(t *testing.T) {
 func TestServer_StartInvalidPort(t *testing.T) {
 	t.Skip("Skipping server start test due to global prometheus registry conflicts")
 }
+
+type fakeApprovalManager struct {
+	pending  []core.PendingApproval
+	resolved map[string]bool
+}
+
+func (f *fakeApprovalManager) SnapshotApprovals() []core.PendingApproval {
+	return f.pending
+}
+
+func (f *fakeApprovalManager) ResolveApproval(_ context.Context, key string, approved bool) error {
+	for _, approval := range f.pending {
+		if approval.Key == key {
+			f.resolved[key] = approved
+			return nil
+		}
+	}
+	return core.ErrApprovalNotFound
+}
+
+func TestApprovalEndpoints(t *testing.T) {
+	approvals := &fakeApprovalManager{
+		pending:  []core.PendingApproval{{Key: "queue_42", Type: "queue", TrackID: "track1", Song: "Artist - Title"}},
+		resolved: make(map[string]bool),
+	}
+	mux := setupRoutes(zap.NewNop(), nil, approvals, nil, "secret", nil)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		token          string
+		expectedStatus int
+	}{
+		{"list without token", http.MethodGet, "/approvals", "", http.StatusUnauthorized},
+		{"list with wrong token", http.MethodGet, "/approvals", "wrong", http.StatusUnauthorized},
+		{"list", http.MethodGet, "/approvals", "secret", http.StatusOK},
+		{"resolve without token", http.MethodPost, "/approvals/queue_42/resolve?approved=true", "", http.StatusUnauthorized},
+		{"resolve invalid decision", http.MethodPost, "/approvals/queue_42/resolve?approved=maybe", "secret", http.StatusBadRequest},
+		{"resolve unknown key", http.MethodPost, "/approvals/queue_7/resolve?approved=true", "secret", http.StatusNotFound},
+		{"resolve", http.MethodPost, "/approvals/queue_42/resolve?approved=false", "secret", http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, http.NoBody)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("%s %s returned status %d, expected %d", tt.method, tt.target, rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+
+	if approved, exists := approvals.resolved["queue_42"]; !exists || approved {
+		t.Errorf("Expected queue_42 to be resolved as denied, got %v (resolved: %v)", approved, exists)
+	}
+}
+
+func TestApprovalEndpoints_DisabledWithoutToken(t *testing.T) {
+	approvals := &fakeApprovalManager{resolved: make(map[string]bool)}
+	mux := setupRoutes(zap.NewNop(), nil, approvals, nil, "", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/approvals", http.NoBody)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	// Without a token, /approvals falls through to the home page handler
+	if contentType := rec.Header().Get("Content-Type"); contentType != "text/html; charset=utf-8" {
+		t.Errorf("Expected /approvals to be disabled, got Content-Type %q", contentType)
+	}
+}
+
+type fakeDashboardSource struct {
+	snapshot core.DashboardSnapshot
+}
+
+func (f *fakeDashboardSource) DashboardSnapshot(_ context.Context) core.DashboardSnapshot {
+	return f.snapshot
+}
+
+func TestDashboardEndpoints(t *testing.T) {
+	dashboard := &fakeDashboardSource{snapshot: core.DashboardSnapshot{
+		CurrentTrack:     &core.DashboardTrack{ID: "current", Title: "Bohemian Rhapsody", Artist: "Queen"},
+		Queue:            []core.DashboardTrack{{ID: "next", Title: "Under Pressure", Artist: "Queen", Source: "playlist"}},
+		RecentAdditions:  []core.AdditionRecord{{TrackID: "added", Title: "<script>", Artist: "Band", Requester: "@alice"}},
+		PendingApprovals: []core.PendingApproval{{Key: "queue_42", Type: "queue", Song: "Artist - Title"}},
+	}}
+	mux := setupRoutes(zap.NewNop(), nil, nil, dashboard, "", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "Queen – Bohemian Rhapsody") ||
+		!strings.Contains(body, "Under Pressure") || !strings.Contains(body, "Artist - Title") {
+		t.Errorf("Dashboard page is missing the snapshot, got status %d", rec.Code)
+	}
+	if !strings.Contains(body, "&lt;script&gt;") {
+		t.Error("Track titles should be HTML-escaped")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/dashboard.json", http.NoBody)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	var snapshot core.DashboardSnapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("Failed to decode dashboard data: %v", err)
+	}
+	if snapshot.CurrentTrack == nil || snapshot.CurrentTrack.ID != "current" || len(snapshot.PendingApprovals) != 1 {
+		t.Errorf("Unexpected dashboard data: %+v", snapshot)
+	}
+	if strings.Contains(body, "@alice") || snapshot.RecentAdditions[0].Requester != "" {
+		t.Error("Expected the public dashboard not to show requesters")
+	}
+	if dashboard.snapshot.RecentAdditions[0].Requester != "@alice" {
+		t.Error("Hiding the requesters should not change the snapshot of the source")
+	}
+}
+
+func TestDashboardEndpoints_RequireAdminToken(t *testing.T) {
+	dashboard := &fakeDashboardSource{snapshot: core.DashboardSnapshot{
+		RecentAdditions: []core.AdditionRecord{{TrackID: "added", Title: "Song", Artist: "Band", Requester: "@alice"}},
+	}}
+	mux := setupRoutes(zap.NewNop(), nil, nil, dashboard, "secret", nil)
+
+	serve := func(target, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, target := range []string{"/", "/dashboard.json", "/?token=wrong"} {
+		if rec := serve(target, ""); rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected %s to require the admin token, got status %d", target, rec.Code)
+		}
+	}
+
+	if rec := serve("/?token=secret", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "@alice") {
+		t.Errorf("Expected the dashboard with requesters for the token query parameter, got status %d", rec.Code)
+	}
+	if rec := serve("/dashboard.json", "Bearer secret"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "@alice") {
+		t.Errorf("Expected the dashboard data with requesters for the bearer token, got status %d", rec.Code)
+	}
+}
+
+func TestDashboardEndpoints_DisabledByDefault(t *testing.T) {
+	mux := setupRoutes(zap.NewNop(), nil, nil, nil, "", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if strings.Contains(rec.Body.String(), "Now Playing") {
+		t.Error("Expected the plain home page without a dashboard source")
+	}
+}
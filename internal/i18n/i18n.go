@@ -54,6 +54,27 @@ func GetSupportedLanguages() []string {
 	return []string{DefaultLanguage, BerneseGermanMessages}
 }
 
+// MatchLanguage returns the supported language for a language code, which is either
+// a supported language itself or the ISO 639-1 code of the language it belongs to.
+func MatchLanguage(code string) (string, bool) {
+	for _, language := range GetSupportedLanguages() {
+		if code == language || code == languageCode(language) {
+			return language, true
+		}
+	}
+	return "", false
+}
+
+// languageCode returns the ISO 639-1 code of a supported language.
+func languageCode(language string) string {
+	switch language {
+	case BerneseGermanMessages:
+		return "de"
+	default:
+		return language
+	}
+}
+
 // getMessages returns the message map for a given language.
 func getMessages(language string) map[string]string {
 	switch language {
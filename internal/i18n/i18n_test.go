@@ -157,13 +157,57 @@ func getTestsWithPlaceholders() map[string]int {
 		"success.admin_approved_and_added":  3, // artist, title, url
 		"success.track_priority_playing":    3, // artist, title, url
 		"bot.startup":                       1, // playlist url
+		"bot.shutdown_summary":              1, // tracks added
+		"bot.shutdown_top_requester":        2, // requester name, tracks added
+		"admin.playlist_unavailable":        1, // playlist ID
+		"admin.playlist_read_only":          1, // playlist ID
+		"admin.spotify_auth_expired":        1, // authorization URL
+		"admin.autodj_takeover":             2, // auto-DJ tracks, window size
 		"bot.queue_management":              5, // artist, title, url, mood, newTrackMood
 		"bot.queue_management_auto":         5, // artist, title, url, mood, newTrackMood
 		"bot.queue_replacement":             5, // artist, title, url, mood, newTrackMood
 		"bot.queue_replacement_auto":        5, // artist, title, url, mood, newTrackMood
+		"success.track_removed":             2, // artist, title
+		"error.quota.exceeded":              2, // limit, reset time
+		"bot.queue_list_header":             2, // track count, remaining duration
+		"bot.queue_list_item":               3, // position, artist, title
+		"bot.queue_list_more":               1, // remaining track count
+		"bot.now_playing":                   2, // artist, title
+		"success.duplicate_bump":            1, // required votes
+		"success.duplicate_queued":          2, // queue position, time until play
+		"success.track_bumped":              3, // artist, title, url
+		"prompt.near_duplicate":             2, // artist, title
+		"error.track.cooldown":              1, // remaining cooldown
+		"error.membership.too_new":          1, // time until requests are accepted
+		"error.outside_request_hours":       1, // reopening time
+		"error.track.too_short":             2, // track duration, minimum duration
+		"error.track.too_long":              2, // track duration, maximum duration
+		"error.playlist.add_gave_up":        1, // background retries
+		"bot.requeue_failed_result":         3, // retried, added, still failing
+		"bot.stats":                         6, // uptime, requests, accepted, rejected, queued tracks, queue duration
+		"bot.stats_top_entry":               3, // rank, name, count
+		"error.album.too_many_tracks":       2, // album track count, maximum
+		"error.album.quota_exceeded":        2, // new track count, remaining quota
+		"prompt.album":                      4, // track count, duration, album, artist
+		"success.album_added":               3, // added track count, album, artist
+		"success.volume_set":                1, // volume percent
+		"error.flood.too_many_links":        1, // maximum links per message
+		"bot.volume_current":                1, // volume percent
+		"bot.history_item":                  4, // position, artist, title, requester
+		"success.episode_queued":            3, // show, title, duration
+		"bot.history_requested":             2, // artist, title
+		"button.history_request":            3, // position, artist, title
+		"button.candidate":                  3, // position, artist, title
 		"format.album":                      1, // album name
 		"format.year":                       1, // year number
 		"format.url":                        1, // url
+		"format.preview":                    1, // preview url
+		"format.suggestion":                 2, // artist, title
+		"format.play_estimate":              1, // wait time
+		"format.play_estimate_rough":        1, // wait time
+		"bot.whoami":                        3, // user ID, chat ID, admin status
+		"bot.private_chat":                  1, // group title
+		"prompt.which_song_suggestions":     1, // suggested tracks
 	}
 }
 
@@ -172,20 +216,47 @@ func getTestsWithoutPlaceholders() []string {
 	return []string{
 		"admin.no_active_device",         // device notification message
 		"admin.insufficient_permissions", // bot permissions notification message
+		"error.command.admin_only",       // admin-only command rejection
+		"bot.queue_empty",                // empty queue listing
+		"bot.history_empty",              // empty history listing
+		"error.episode.not_allowed",      // episode rejected without --allow-episodes
+		"bot.history_header",             // history listing title
+		"prompt.candidate_choice",        // search result choice prompt
+		"button.candidate_none",          // button rejecting all search results
+		"error.ingestion_paused",         // request rejected while paused
+		"error.busy",                     // request rejected while too many are processed
+		"bot.private_chat_unknown_group", // private message reply without group title
+		"bot.welcome",                    // introduction when added to the group
+		"bot.whoami_admin",               // /whoami admin status
+		"bot.whoami_not_admin",           // /whoami non-admin status
+		"bot.whoami_admin_unknown",       // /whoami failed admin check
+		"bot.warnings_subscribed",        // /start confirmation
+		"bot.warnings_subscribe_denied",  // /start without admin status or token
+		"callback.already_handled",       // repeated tap on a decided approval
+		"error.playlist_unavailable",     // request rejected while the playlist is inaccessible
+		"success.ingestion_paused",       // pause confirmation
+		"success.ingestion_resumed",      // resume confirmation
+		"error.track.explicit",           // explicit track rejection
+		"error.track.unavailable",        // track not playable in the market
+		"error.membership.not_member",    // request of a non-member rejected
+		"bot.stats_top_requesters",       // stats requester list title
+		"bot.stats_top_artists",          // stats artist list title
+		"bot.stats_reset",                // stats reset confirmation
+		"bot.leaderboard_header",         // leaderboard title
+		"bot.leaderboard_empty",          // empty leaderboard
+		"bot.requeue_failed_empty",       // no failed additions to retry
+		"error.album.approval_required",  // album rejected while approval is required
+		"error.album.nothing_new",        // album without new tracks
+		"error.volume.invalid",           // invalid /volume argument
+		"error.spotify.volume_failed",    // volume change failure
+		"error.spotify.pause_failed",     // /pause-music failure
+		"error.spotify.resume_failed",    // /resume-music failure
+		"error.playback.already_paused",  // /pause-music while paused
+		"error.playback.already_playing", // /resume-music while playing
+		"format.language_name",           // language of LLM replies
 	}
 }
 
-// countPlaceholders counts the number of %s and %d placeholders in a message.
-func countPlaceholders(message string) int {
-	placeholderCount := 0
-	for i := range len(message) - 1 {
-		if message[i] == '%' && (message[i+1] == 's' || message[i+1] == 'd') {
-			placeholderCount++
-		}
-	}
-	return placeholderCount
-}
-
 // testMessagesWithPlaceholders tests that messages have the expected number of placeholders.
 func testMessagesWithPlaceholders(t *testing.T, referenceMessages map[string]string, tests map[string]int) {
 	t.Helper()
@@ -349,3 +420,24 @@ func BenchmarkLocalizerWithArgs(b *testing.B) {
 		_ = localizer.T("format.album", "Test Album Name")
 	}
 }
+
+func TestMatchLanguage(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+		found    bool
+	}{
+		{"en", DefaultLanguage, true},
+		{"ch_be", BerneseGermanMessages, true},
+		{"de", BerneseGermanMessages, true},
+		{"fr", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		language, found := MatchLanguage(tt.code)
+		if language != tt.expected || found != tt.found {
+			t.Errorf("MatchLanguage(%q) = (%q, %v), expected (%q, %v)", tt.code, language, found, tt.expected, tt.found)
+		}
+	}
+}
@@ -3,25 +3,79 @@ package i18n
 // berneseGermanMessages contains all Bernese Swiss German (Bärndütsch) translations.
 var berneseGermanMessages = map[string]string{
 	// Error messages
-	"error.spotify.extract_track_id": "Ha d Spotify-Track-ID nid chönne us em Link useläse.",
-	"error.llm.no_provider":          "Ha's nid chönne errate. Chasch mir äch e Spotify-Link vom Lied schicke?",
-	"error.spotify.search_failed":    "Ha das nid chönne uf Spotify sueche. Bitte probiers nomau.",
-	"error.llm.understand":           "Ha di nid ganz verstandä. Chasch es bitzeli konkreter si?",
-	"error.llm.no_songs":             "Ha kei Lieder gfunde. Chasch mir meh verzeuä?",
-	"error.spotify.no_matches":       "Ha kei passendi Lieder uf Spotify gfunde. Chasch es bitzeli genauer si?",
-	"error.generic":                  "Öppis isch schief gloffe. Probier's haut nomau, bitte.",
-	"error.spotify.not_found":        "Ha's uf Spotify nid gfunde – chasch das no chli erlüterä?",
-	"error.admin.process_failed":     "D Admin-Freigab het nid funktioniert.",
-	"error.playlist.add_failed":      "Ha's Lied nid chönne zur Playliste hinzuefüege.",
+	"error.spotify.extract_track_id":     "Ha d Spotify-Track-ID nid chönne us em Link useläse.",
+	"error.llm.no_provider":              "Ha's nid chönne errate. Chasch mir äch e Spotify-Link vom Lied schicke?",
+	"error.spotify.search_failed":        "Ha das nid chönne uf Spotify sueche. Bitte probiers nomau.",
+	"error.llm.understand":               "Ha di nid ganz verstandä. Chasch es bitzeli konkreter si?",
+	"error.llm.no_songs":                 "Ha kei Lieder gfunde. Chasch mir meh verzeuä?",
+	"error.spotify.no_matches":           "Ha kei passendi Lieder uf Spotify gfunde. Chasch es bitzeli genauer si?",
+	"error.generic":                      "Öppis isch schief gloffe. Probier's haut nomau, bitte.",
+	"error.spotify.not_found":            "Ha's uf Spotify nid gfunde – chasch das no chli erlüterä?",
+	"error.admin.process_failed":         "D Admin-Freigab het nid funktioniert.",
+	"error.playlist.add_failed":          "Ha's Lied nid chönne zur Playliste hinzuefüege.",
+	"error.command.admin_only":           "Nur Gruppe-Admins chöi dä Befähl bruuche.",
+	"error.spotify.no_active_device":     "🔇 Kei aktivs Spotify-Grät gfunde. Fang zersch uf emne Grät a spile.",
+	"error.spotify.skip_failed":          "Ha s aktuelle Lied nid chönne überspringe. Probier's haut nomau.",
+	"error.spotify.volume_failed":        "Ha d Luutstärchi nid chönne ändere. Probier's haut nomau.",
+	"error.spotify.pause_failed":         "Ha d Musig nid chönne pausiere. Probier's haut nomau.",
+	"error.spotify.resume_failed":        "Ha d Musig nid chönne wiiterspile. Probier's haut nomau.",
+	"error.playback.already_paused":      "⏸️ D Musig isch scho pausiert. Mit /resume-music geit's wiiter.",
+	"error.playback.already_playing":     "▶️ D Musig louft scho.",
+	"error.volume.invalid":               "Bruuch /volume mit ere Zahl vo 0 bis 100, z.B. /volume 60.",
+	"error.bump.invalid":                 "Bruuch /bump mit em Link vo nes Lied us dr Playliste, wo no nid wartet, z.B. /bump spotify:track:…",
+	"error.bump.queued":                  "Das Lied isch scho i dr Spotify-Warteschlange, u die cha me nid umsortiere. Es chunnt ja gly.",
+	"error.bump.not_in_playlist":         "Das Lied isch nid i dr Playliste. Schick sy Link, de chasch es wünsche.",
+	"error.bump.plays_next":              "Das Lied chunnt scho als nächschts us dr Playliste.",
+	"error.bump.failed":                  "Ha s Lied nid chönne füreschiebe. Probier's haut nomau.",
+	"error.busy":                         "🐢 Grad sehr vill Wünsch, probier's gly nomal.",
+	"error.ingestion_paused":             "😴 Liederwünsch sy grad pausiert. Probier's spöter nomau.",
+	"error.playlist_unavailable":         "😴 D Playlist isch grad nid erreichbar, Liederwünsch sy pausiert. Probier's spöter nomau.",
+	"error.outside_request_hours":        "🌙 Liederwünsch sy grad zue. Am %s geit's wieder los.",
+	"error.command.undo_no_reply":        "Antwort mit /undo uf d Nachricht vom hinzuegfüegte Lied zum es usez'näh.",
+	"error.command.undo_unknown_message": "Zu dere Nachricht kenn i kes hinzuegfüegts Lied.",
+	"error.playlist.remove_failed":       "Ha's Lied nid chönne us dr Playliste lösche.",
+	"error.quota.exceeded":               "🙈 Du hesch dini %d Lieder scho gwünscht. I %s chasch wieder neui wünsche.",
+	"error.track.explicit":               "🔞 Explizit Lieder sy hie nid erloubt. Probier's mit ere suubere Version!",
+	"error.track.unavailable":            "🌍 Das Lied git's i dere Region nid. Probier's mit ere andere Version!",
+	"error.membership.not_member":        "🚪 Nume Mitglieder vor Gruppe chöi Lieder wünsche.",
+	"error.membership.too_new":           "👋 Willkomme! Neui Mitglieder chöi i %s Lieder wünsche.",
+	"error.track.too_short":              "⏱️ Das Lied isch nume %s lang, Wünsch müesse mindeschtens %s ha.",
+	"error.track.too_long":               "⏱️ Das Lied isch %s lang, Wünsch dörfe höchschtens %s ha.",
+	"error.flood.too_many_links":         "🥱 Das sy aber vill Links! I luege nume di erschte %d aa.",
+	"error.track.cooldown":               "⏳ Das Lied isch grad erscht glüffe. Probier's i %s nomau.",
+	"error.album.not_found":              "Ha das Album nid vo Spotify chönne lade. Probier's nomau, bitte.",
+	"error.album.too_many_tracks":        "💿 Das Album het %d Lieder, aber meh aus %d geit nid ufs Mau. Wähl lieber dini Lieblingslieder us!",
+	"error.album.approval_required":      "💿 Ganzi Alben gö nid, solang Liederwünsch müesse guetgheisse wärde. Schick bitte einzelni Lieder.",
+	"error.album.nothing_new":            "💿 Aui Lieder vo däm Album sy scho i dr Playliste oder chöi grad nid hinzuegfüegt wärde.",
+	"error.album.quota_exceeded":         "🙈 Das Album het %d neui Lieder, aber du chasch grad nume no %d Lieder wünsche.",
+	"error.episode.not_allowed":          "🎙️ Podcast-Episode chöi hie nid gwünscht wärde. Schick bitte Lieder.",
+	"error.episode.not_found":            "Ha die Episode nid vo Spotify chönne lade. Probier's nomau, bitte.",
+	"error.episode.approval_required":    "🎙️ Episode gö nid, solang Liederwünsch müesse guetgheisse wärde.",
+	"error.episode.queue_failed":         "Ha die Episode nid i d Warteschlange chönne tue. Lauft Spotify?",
+	"error.playlist.add_gave_up": "😞 Ha dis Lied o nach %d Versüech nid chönne hinzuefüege. " +
+		"En Admin chas mit /requeue-failed nomau probiere.",
 
 	// Questions and prompts
-	"prompt.which_song":        "Weles Lied meinsch de gnau?",
-	"prompt.enhanced_approval": "🎵 Gfunde: %s - %s%s%s%s\n\n🎯 Track-Stimmig: %s\n\nIsch das z'richtige?",
+	"prompt.near_duplicate":         "🔁 Das gseht us wie %s - %s, wo scho i dr Playliste isch. Trotzdäm hinzuefüege?",
+	"prompt.which_song":             "Weles Lied meinsch de gnau?",
+	"prompt.which_song_suggestions": "🤔 I bi nid sicher, weles Lied du meinsch. Meinsch eis vo dene?%s",
+	"prompt.album":                  "💿 %d Lieder (%s) vo %s vo %s zur Playliste hinzuefüege?",
+	"prompt.enhanced_approval":      "🎵 Gfunde: %s - %s%s%s%s\n\n🎯 Track-Stimmig: %s\n\nIsch das z'richtige?",
+	"prompt.candidate_choice":       "🎵 I ha mehreri Lieder gfunde. Weles meinsch?",
+	"button.candidate":              "%d. %s - %s",
+	"button.candidate_none":         "👎 Keis vo dene",
 
 	// Format helpers for prompts
-	"format.album": " (Album: %s)",
-	"format.year":  " (%d)",
-	"format.url":   "\n🔗 %s",
+	"format.album":               " (Album: %s)",
+	"format.year":                " (%d)",
+	"format.url":                 "\n🔗 %s",
+	"format.preview":             "\n🎧 Ineloose: %s",
+	"format.suggestion":          "\n• %s - %s",
+	"format.play_estimate":       "\n⏱️ Sött i öppe %s lufe",
+	"format.play_estimate_rough": "\n⏱️ Sött ungfähr i %s lufe, aber mit so vilne Songs vorne dra cha sech das no ändere",
+
+	// Language the LLM answers users in
+	"format.language_name": "Bernese Swiss German (Bärndütsch)",
 
 	// Admin approval messages
 	"admin.approval_required_community": "⏳ Admin-Freigab nötig\n\n🎵 %s - %s%s%s%s\n\n🎯 Track-Stimmig: %s\n\n" +
@@ -33,32 +87,49 @@ var berneseGermanMessages = map[string]string{
 	"admin.button_deny":    "❌ Ablehnä",
 
 	// Success messages
-	"success.track_added":              "Hinzuegfüegt: %s - %s (%s)",
-	"success.track_added_with_queue":   "Hinzuegfüegt: %s - %s (%s) - Warteschlange-Position: %d",
-	"success.admin_approved_and_added": "✅ Admin hets guetgeheisse und hinzuegfüegt: %s - %s (%s)",
-	"success.admin_approved_and_added_queue": "✅ Admin hets guetgeheisse und hinzuegfüegt: %s - %s (%s) - " +
+	"success.track_added":              "📋 I d Playlist ta: %s - %s (%s)",
+	"success.track_added_with_queue":   "📋 I d Playlist ta: %s - %s (%s) - Warteschlange-Position: %d",
+	"success.admin_approved_and_added": "✅ Admin hets guetgeheisse und i d Playlist ta: %s - %s (%s)",
+	"success.admin_approved_and_added_queue": "✅ Admin hets guetgeheisse und i d Playlist ta: %s - %s (%s) - " +
 		"Warteschlange-Position: %d",
-	"success.community_approved_and_added": "✅ Community hets guetgeheisse und hinzuegfüegt: %s - %s (%s)",
-	"success.community_approved_and_added_queue": "✅ Community hets guetgeheisse und hinzuegfüegt: %s - %s (%s) - " +
+	"success.community_approved_and_added": "✅ Community hets guetgeheisse und i d Playlist ta: %s - %s (%s)",
+	"success.community_approved_and_added_queue": "✅ Community hets guetgeheisse und i d Playlist ta: %s - %s (%s) - " +
 		"Warteschlange-Position: %d",
-	"success.track_priority_playing": "🚀 Spielt jetzt: %s - %s (%s)",
+	"success.track_priority_playing": "🚀 Chunnt als nächschts: %s - %s (%s)",
 	"success.duplicate":              "Isch scho i dr Playliste.",
+	"success.duplicate_queued":       "Isch scho i dr Playliste, chunnt a Warteschlange-Position %d i öppe %s.",
+	"success.duplicate_bump":         "Isch scho i dr Playliste. Reagier mit 👍 zum's füreschiebe (%d Stimme nötig).",
+	"success.track_bumped":           "⏫ Uf Wunsch vo allne füregschobe, chunnt als nächschts: %s - %s (%s)",
+	"success.queue_bumped":           "⏫ I dr Playliste füregschobe, chunnt nach de Lieder i dr Warteschlange: %s - %s",
+	"success.track_removed":          "🗑️ Usegnoh: %s - %s",
+	"success.album_added":            "💿 %d Lieder vo %s vo %s hinzuegfüegt",
+	"success.episode_queued":         "🎙️ Episode chunnt als nächschts: %s - %s (%s)",
+	"success.ingestion_paused":       "⏸️ Liederwünsch sy pausiert. Mit /resume geit's wieder wyter.",
+	"success.ingestion_resumed":      "▶️ Liederwünsch sy wieder offe!",
+	"success.volume_set":             "🔊 Luutstärchi uf %d%% gstellt",
 
 	// Callback messages
-	"callback.approved":       "✅ Lied isch vom Admin guet geheisse worde.",
-	"callback.denied":         "❌ Lied isch vom Admin abglehnt worde.",
-	"callback.expired":        "D Freigab-Afrag isch abgloffe.",
-	"callback.unauthorized":   "Nur Gruppe-Admins chöi do druf antworte.",
-	"callback.sender_only":    "Nur dä, wo s Lied gschickt het, cha da antworte.",
-	"callback.prompt_expired": "Die Afrag isch abgloffe.",
+	"callback.approved":        "✅ Lied isch vom Admin guet geheisse worde.",
+	"callback.denied":          "❌ Lied isch vom Admin abglehnt worde.",
+	"callback.expired":         "D Freigab-Afrag isch abgloffe.",
+	"callback.unauthorized":    "Nur Gruppe-Admins chöi do druf antworte.",
+	"callback.sender_only":     "Nur dä, wo s Lied gschickt het, cha da antworte.",
+	"callback.prompt_expired":  "Die Afrag isch abgloffe.",
+	"callback.already_handled": "Das isch scho beantwortet worde.",
 
 	// Button texts
 	"button.confirm":  "👍 Ja, das isch's",
 	"button.not_this": "👎 Nö, nid das",
 
 	// Bot status messages
-	"bot.startup":  "🎵 Ig bi jetzt online und bereit för öii Musigwünsch!\n\n📀 Playlist: %s",
-	"bot.shutdown": "🎵 Ig ga offline. Bis spöter!\n\n📀 Aui Lieder vo dere Session: %s",
+	"bot.startup":                "🎵 Ig bi jetzt online und bereit för öii Musigwünsch!\n\n📀 Playlist: %s",
+	"bot.shutdown":               "🎵 Ig ga offline. Bis spöter!\n\n📀 Aui Lieder vo dere Session: %s",
+	"bot.shutdown_summary":       "\n\n📊 Lieder wo hüt derzue cho sy: %d",
+	"bot.shutdown_top_requester": "\n🏆 Fliissigschte Wünscher: %s (%d Lieder)",
+	"bot.welcome": "👋 Hoi zäme, ig bi DJAlgoRhythm und lueg für d Musig!\n\n" +
+		"🎵 Schicket e Spotify Link, e Link vo YouTube, Apple Music und angerne, " +
+		"oder schribet eifach was dir weit ghöre, z.B. \"Bohemian Rhapsody vo Queen\".\n\n" +
+		"Ig finde z'Lied und füeges zur Playlist hinzu. Los geit's mit dr Party! 🎶",
 	"bot.help_message": "🎵 DJAlgoRhythm Musig Bot Hiuf\n\n" +
 		"Ig cha dir häufe Lieder zur Playlist hinzuzfüege! So geit's:\n\n" +
 		"📍 Spotify Links schicke:\n" +
@@ -95,6 +166,47 @@ var berneseGermanMessages = map[string]string{
 		"🔁 Bitte ändere d Repeat-Modus uf 'us' oder 'Playlist' fürs Auto-DJing. " +
 		"Track-Repeat verhinderet Playlist-Fortschritt.",
 
+	// Queue listing messages
+	"bot.queue_empty":       "🎶 Im Momänt isch nüt i dr Warteschlange.",
+	"bot.queue_list_header": "🎶 Als Nächschts (%d Lieder, no %s):",
+	"bot.queue_list_item":   "%d. %s - %s",
+	"bot.queue_list_more":   "… und no %d meh",
+	"bot.now_playing":       "▶️ Jetzt lauft: %s – %s",
+	"bot.volume_current":    "🔊 D Luutstärchi isch uf %d%%",
+
+	// Request history messages
+	"bot.history_empty":      "🕘 Bis jetzt isch no nüt hinzuegfüegt worde.",
+	"bot.history_header":     "🕘 Zletscht hinzuegfüegt (tipp uf es Lied zum's nomal z'wünsche):",
+	"bot.history_item":       "%d. %s - %s (%s)",
+	"bot.history_requested":  "🔁 Nomal gwünscht: %s - %s",
+	"button.history_request": "🔁 %d. %s - %s",
+
+	// Event statistics messages
+	"bot.stats": "📊 Statistik vom Aabe (lauft sit %s)\n\n" +
+		"🎵 Wünsch: %d\n✅ Aagno: %d\n❌ Abglehnt: %d\n📋 I dr Warteschlange: %d Lieder (%s)",
+	"bot.stats_top_requesters": "🙋 Fliissigschti Wünscher:",
+	"bot.stats_top_artists":    "🎤 Beliebtschti Künschtler:",
+	"bot.stats_top_entry":      "%d. %s (%d)",
+	"bot.stats_reset":          "📊 D Statistik isch zrüggsetzt worde.",
+
+	// Requester leaderboard messages
+	"bot.leaderboard_header": "🏆 Di fliissigschte Wünscher vo allne Zyte:",
+	"bot.leaderboard_empty":  "🏆 Bis jetzt het no niemer es Lied gwünscht.",
+
+	// Setup helper messages
+	"bot.private_chat":               "👋 I nime Liederwünsch nume i dr Gruppe \"%s\" ah. Schick se bitte dert häre!",
+	"bot.private_chat_unknown_group": "👋 I nime Liederwünsch nume i mire Gruppe ah. Schick se bitte dert häre!",
+	"bot.whoami":                     "🪪 User-ID: %s\n💬 Chat-ID: %s\n👑 %s",
+	"bot.whoami_admin":               "Du bisch Admin",
+	"bot.whoami_not_admin":           "Du bisch ke Admin",
+	"bot.whoami_admin_unknown":       "Admin-Status unbekannt",
+	"bot.warnings_subscribed":        "🔔 Du überchunsch jetz Admin-Warnige hie, z.B. wenn kes Grät aktiv oder s Spotify-Login abgloffe isch.",
+	"bot.warnings_subscribe_denied":  "🔒 Nume Admins vo dr Gruppe oder Lüt mit emne Iladigslink chöi Admin-Warnige überchoo.",
+
+	// Failed addition retry messages
+	"bot.requeue_failed_empty":  "✅ Es git kei fählgschlagni Lieder zum nomau probiere.",
+	"bot.requeue_failed_result": "🔁 %d fählgschlagni Lieder nomau probiert: %d hinzuegfüegt, %d geit immer no nid.",
+
 	// Queue track approval messages
 	"button.queue_approve":    "✅ Isch ok",
 	"button.queue_deny":       "❌ Ou nei",
@@ -113,6 +225,24 @@ var berneseGermanMessages = map[string]string{
 		"• Gwüssi Bot-Features funktioniered nur mit Admin-Status\n\n" +
 		"💡 Admin-Berechtigunge ermögliched em Bot Events z'empfange und Gruppe-Interaktione z'verwalte.",
 
+	// Playlist notifications
+	"admin.playlist_unavailable": "🚫 Playlist nid erreichbar!\n\n" +
+		"D Playlist %s isch glöscht worde oder dr Bot het kei Zuegriff meh. Liederwünsch sy pausiert.\n\n" +
+		"💡 Stell d Playlist wieder her oder gib em Spotify-Account vom Bot wieder Zuegriff. D Wünsch gö automatisch wiiter.",
+	"admin.playlist_read_only": "🔒 Playlist nid beschriibbar!\n\n" +
+		"Dr Spotify-Account vom Bot cha kener Tracks meh zur Playlist %s hinzuefüege. Liederwünsch sy pausiert.\n\n" +
+		"💡 Mach d Playlist kollaborativ oder gib se em Spotify-Account vom Bot. D Wünsch gö automatisch wiiter.",
+
+	// Spotify authorization notifications
+	"admin.spotify_auth_expired": "🔑 Spotify-Autorisierig abgloffe!\n\n" +
+		"Dr Bot het kei Zuegriff meh uf Spotify, drum chöi keini Lieder meh hinzuegfüegt oder i d Queue gstellt wärde.\n\n" +
+		"💡 Mach dä Link mit em Spotify-Account vom Bot uf zum ne wieder z'autorisiere:\n%s",
+
+	// Auto-DJ notifications
+	"admin.autodj_takeover": "🤖 Dr Auto-DJ het übernoh!\n\n" +
+		"Dr Auto-DJ het %d vo de letschte %d Tracks usgwählt, d Lüt sy still worde.\n\n" +
+		"💡 Motivier d Gruppe, Lieder z'wünsche. Dä Hiiwis verschwindet, sobald wieder Wünsch chöme.",
+
 	// Queue sync notifications
 	"admin.queue_sync_warning": "🚨 Queue-Sync Problem detected!\n\n" +
 		"D Queue isch villicht nid synchron. Tracks i dr Queue:\n%s\n" +
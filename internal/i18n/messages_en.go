@@ -3,25 +3,78 @@ package i18n
 // englishMessages contains all English translations.
 var englishMessages = map[string]string{
 	// Error messages
-	"error.spotify.extract_track_id": "Couldn't extract Spotify track ID from the link",
-	"error.llm.no_provider":          "I couldn't guess. Could you send me a spotify link to the song?",
-	"error.spotify.search_failed":    "I couldn't search Spotify. Please try again.",
-	"error.llm.understand":           "I couldn't understand. Could you be more specific?",
-	"error.llm.no_songs":             "I couldn't find any songs. Could you be more specific?",
-	"error.spotify.no_matches":       "Couldn't find matching songs on Spotify. Could you be more specific?",
-	"error.generic":                  "Something went wrong. Please try again.",
-	"error.spotify.not_found":        "Couldn't find on Spotify—mind clarifying?",
-	"error.admin.process_failed":     "Admin approval process failed",
-	"error.playlist.add_failed":      "Failed to add track to playlist",
+	"error.spotify.extract_track_id":     "Couldn't extract Spotify track ID from the link",
+	"error.llm.no_provider":              "I couldn't guess. Could you send me a spotify link to the song?",
+	"error.spotify.search_failed":        "I couldn't search Spotify. Please try again.",
+	"error.llm.understand":               "I couldn't understand. Could you be more specific?",
+	"error.llm.no_songs":                 "I couldn't find any songs. Could you be more specific?",
+	"error.spotify.no_matches":           "Couldn't find matching songs on Spotify. Could you be more specific?",
+	"error.generic":                      "Something went wrong. Please try again.",
+	"error.spotify.not_found":            "Couldn't find on Spotify—mind clarifying?",
+	"error.admin.process_failed":         "Admin approval process failed",
+	"error.playlist.add_failed":          "Failed to add track to playlist",
+	"error.playlist.add_gave_up":         "😞 I still couldn't add your track after %d retries. An admin can try again with /requeue-failed.",
+	"error.command.admin_only":           "Only group administrators can use this command.",
+	"error.spotify.no_active_device":     "🔇 No active Spotify device found. Start playback on a device first.",
+	"error.spotify.skip_failed":          "Couldn't skip the current track. Please try again.",
+	"error.spotify.volume_failed":        "Couldn't change the volume. Please try again.",
+	"error.spotify.pause_failed":         "Couldn't pause the music. Please try again.",
+	"error.spotify.resume_failed":        "Couldn't resume the music. Please try again.",
+	"error.playback.already_paused":      "⏸️ The music is already paused. Use /resume-music to play it again.",
+	"error.playback.already_playing":     "▶️ The music is already playing.",
+	"error.volume.invalid":               "Use /volume with a level from 0 to 100, e.g. /volume 60.",
+	"error.bump.invalid":                 "Use /bump with a link to a playlist track that isn't queued yet, e.g. /bump spotify:track:…",
+	"error.bump.queued":                  "That track is already in Spotify's queue, which can't be reordered. It plays soon anyway.",
+	"error.bump.not_in_playlist":         "That track isn't in the playlist. Send its link to request it.",
+	"error.bump.plays_next":              "That track already plays next from the playlist.",
+	"error.bump.failed":                  "Couldn't move the track up. Please try again.",
+	"error.busy":                         "🐢 Lots of requests right now, please try again in a moment.",
+	"error.ingestion_paused":             "😴 Song requests are paused right now. Please try again later.",
+	"error.playlist_unavailable":         "😴 The playlist can't be reached right now, song requests are paused. Please try again later.",
+	"error.outside_request_hours":        "🌙 Song requests are closed right now. They open again at %s.",
+	"error.command.undo_no_reply":        "Reply to the added song's message with /undo to remove it.",
+	"error.command.undo_unknown_message": "I don't know of a song added by that message.",
+	"error.playlist.remove_failed":       "Failed to remove track from playlist",
+	"error.quota.exceeded":               "🙈 You've reached your limit of %d songs. You can request more in %s.",
+	"error.track.explicit":               "🔞 Explicit tracks aren't allowed here. Try a clean version!",
+	"error.track.unavailable":            "🌍 That track isn't available in this region. Try another version!",
+	"error.membership.not_member":        "🚪 Only group members can request songs.",
+	"error.membership.too_new":           "👋 Welcome! New members can request songs in %s.",
+	"error.track.too_short":              "⏱️ This track is only %s long, but requests must be at least %s.",
+	"error.track.too_long":               "⏱️ This track is %s long, but requests can be at most %s.",
+	"error.flood.too_many_links":         "🥱 That's a lot of links! Only the first %d will be looked at.",
+	"error.track.cooldown":               "⏳ This song was played recently. Try again in %s.",
+	"error.album.not_found":              "I couldn't load that album from Spotify. Please try again.",
+	"error.album.too_many_tracks":        "💿 That album has %d tracks, but at most %d can be added at once. Pick your favorites instead!",
+	"error.album.approval_required":      "💿 Whole albums can't be added while song requests need approval. Please share single songs.",
+	"error.album.nothing_new":            "💿 All tracks of that album are already in the playlist or can't be added right now.",
+	"error.album.quota_exceeded":         "🙈 That album has %d new tracks, but you can only request %d more songs right now.",
+	"error.episode.not_allowed":          "🎙️ Podcast episodes can't be requested here. Please share songs instead.",
+	"error.episode.not_found":            "I couldn't load that episode from Spotify. Please try again.",
+	"error.episode.approval_required":    "🎙️ Episodes can't be queued while song requests need approval.",
+	"error.episode.queue_failed":         "I couldn't add that episode to the queue. Is Spotify playing?",
 
 	// Questions and prompts
-	"prompt.which_song":        "Which song do you mean by that?",
-	"prompt.enhanced_approval": "🎵 Found: %s - %s%s%s%s\n\n🎯 Track mood: %s\n\nIs this what you're looking for?",
+	"prompt.near_duplicate":         "🔁 This looks like %s - %s, which is already in the playlist. Add it anyway?",
+	"prompt.which_song":             "Which song do you mean by that?",
+	"prompt.which_song_suggestions": "🤔 I'm not sure which song you mean. Did you mean one of these?%s",
+	"prompt.album":                  "💿 Add %d tracks (%s) from %s by %s to the playlist?",
+	"prompt.enhanced_approval":      "🎵 Found: %s - %s%s%s%s\n\n🎯 Track mood: %s\n\nIs this what you're looking for?",
+	"prompt.candidate_choice":       "🎵 I found several matches. Which one do you mean?",
+	"button.candidate":              "%d. %s - %s",
+	"button.candidate_none":         "👎 None of these",
 
 	// Format helpers for prompts
-	"format.album": " (Album: %s)",
-	"format.year":  " (%d)",
-	"format.url":   "\n🔗 %s",
+	"format.album":               " (Album: %s)",
+	"format.year":                " (%d)",
+	"format.url":                 "\n🔗 %s",
+	"format.preview":             "\n🎧 Preview: %s",
+	"format.suggestion":          "\n• %s - %s",
+	"format.play_estimate":       "\n⏱️ Should play in ~%s",
+	"format.play_estimate_rough": "\n⏱️ Should play in roughly %s, though with this many songs ahead that may change",
+
+	// Language the LLM answers users in
+	"format.language_name": "English",
 
 	// Admin approval messages
 	"admin.approval_required_community": "⏳ Admin Approval Required\n\n🎵 %s - %s%s%s%s\n\n🎯 Track mood: %s\n\n" +
@@ -35,30 +88,47 @@ var englishMessages = map[string]string{
 	"admin.button_deny":    "❌ Deny",
 
 	// Success messages
-	"success.track_added":                        "Added: %s - %s (%s)",
-	"success.track_added_with_queue":             "Added: %s - %s (%s) - Queue position: %d",
-	"success.admin_approved_and_added":           "✅ Admin approved and added: %s - %s (%s)",
-	"success.admin_approved_and_added_queue":     "✅ Admin approved and added: %s - %s (%s) - Queue position: %d",
-	"success.community_approved_and_added":       "✅ Community approved and added: %s - %s (%s)",
-	"success.community_approved_and_added_queue": "✅ Community approved and added: %s - %s (%s) - Queue position: %d",
-	"success.track_priority_playing":             "🚀 Now playing: %s - %s (%s)",
+	"success.track_added":                        "📋 Added to the playlist: %s - %s (%s)",
+	"success.track_added_with_queue":             "📋 Added to the playlist: %s - %s (%s) - Queue position: %d",
+	"success.admin_approved_and_added":           "✅ Admin approved and added to the playlist: %s - %s (%s)",
+	"success.admin_approved_and_added_queue":     "✅ Admin approved and added to the playlist: %s - %s (%s) - Queue position: %d",
+	"success.community_approved_and_added":       "✅ Community approved and added to the playlist: %s - %s (%s)",
+	"success.community_approved_and_added_queue": "✅ Community approved and added to the playlist: %s - %s (%s) - Queue position: %d",
+	"success.track_priority_playing":             "🚀 Playing next: %s - %s (%s)",
 	"success.duplicate":                          "Already in playlist.",
+	"success.duplicate_queued":                   "Already in playlist, coming up at queue position %d in about %s.",
+	"success.duplicate_bump":                     "Already in playlist. React with 👍 to bump it to play next (%d votes needed).",
+	"success.track_bumped":                       "⏫ Bumped by popular demand, playing next: %s - %s (%s)",
+	"success.queue_bumped":                       "⏫ Moved up in the playlist, plays after the queued tracks: %s - %s",
+	"success.track_removed":                      "🗑️ Removed: %s - %s",
+	"success.album_added":                        "💿 Added %d tracks from %s by %s",
+	"success.episode_queued":                     "🎙️ Episode playing next: %s - %s (%s)",
+	"success.ingestion_paused":                   "⏸️ Song requests are paused. Use /resume to accept them again.",
+	"success.ingestion_resumed":                  "▶️ Song requests are open again!",
+	"success.volume_set":                         "🔊 Volume set to %d%%",
 
 	// Callback messages
-	"callback.approved":       "✅ Song approved by admin",
-	"callback.denied":         "❌ Song denied by admin",
-	"callback.expired":        "This approval request has expired.",
-	"callback.unauthorized":   "Only group administrators can respond to this.",
-	"callback.sender_only":    "Only the original sender can respond to this.",
-	"callback.prompt_expired": "This prompt has expired.",
+	"callback.approved":        "✅ Song approved by admin",
+	"callback.denied":          "❌ Song denied by admin",
+	"callback.expired":         "This approval request has expired.",
+	"callback.unauthorized":    "Only group administrators can respond to this.",
+	"callback.sender_only":     "Only the original sender can respond to this.",
+	"callback.prompt_expired":  "This prompt has expired.",
+	"callback.already_handled": "This has already been answered.",
 
 	// Button texts
 	"button.confirm":  "👍 Confirm",
 	"button.not_this": "👎 Not this",
 
 	// Bot status messages
-	"bot.startup":  "🎵 I am now online and ready to add music to your playlist!\n\n📀 Playlist: %s",
-	"bot.shutdown": "🎵 I am going offline. See you later!\n\n📀 All songs from this session: %s",
+	"bot.startup":                "🎵 I am now online and ready to add music to your playlist!\n\n📀 Playlist: %s",
+	"bot.shutdown":               "🎵 I am going offline. See you later!\n\n📀 All songs from this session: %s",
+	"bot.shutdown_summary":       "\n\n📊 Songs added this session: %d",
+	"bot.shutdown_top_requester": "\n🏆 Top requester: %s (%d songs)",
+	"bot.welcome": "👋 Hi everyone, I'm DJAlgoRhythm and I take care of the music!\n\n" +
+		"🎵 Send a Spotify link, a link from YouTube, Apple Music and others, " +
+		"or just write what you want to hear, e.g. \"Bohemian Rhapsody by Queen\".\n\n" +
+		"I'll find the song and add it to the playlist. Let's get the party started! 🎶",
 	"bot.help_message": "🎵 DJAlgoRhythm Music Bot Help\n\n" +
 		"I can help you add songs to the playlist! Here's how:\n\n" +
 		"📍 Send Spotify Links:\n" +
@@ -95,6 +165,47 @@ var englishMessages = map[string]string{
 		"🔁 Please change repeat mode to 'off' or 'playlist' for auto-DJing. " +
 		"Track repeat prevents playlist progression.",
 
+	// Queue listing messages
+	"bot.queue_empty":       "🎶 Nothing is queued right now.",
+	"bot.queue_list_header": "🎶 Up next (%d tracks, %s remaining):",
+	"bot.queue_list_item":   "%d. %s - %s",
+	"bot.queue_list_more":   "… and %d more",
+	"bot.now_playing":       "▶️ Now playing: %s – %s",
+	"bot.volume_current":    "🔊 Volume is at %d%%",
+
+	// Request history messages
+	"bot.history_empty":      "🕘 No tracks have been added yet.",
+	"bot.history_header":     "🕘 Recently added (tap a track to request it again):",
+	"bot.history_item":       "%d. %s - %s (%s)",
+	"bot.history_requested":  "🔁 Requesting again: %s - %s",
+	"button.history_request": "🔁 %d. %s - %s",
+
+	// Event statistics messages
+	"bot.stats": "📊 Event statistics (uptime %s)\n\n" +
+		"🎵 Requests: %d\n✅ Accepted: %d\n❌ Rejected: %d\n📋 Queued: %d tracks (%s)",
+	"bot.stats_top_requesters": "🙋 Top requesters:",
+	"bot.stats_top_artists":    "🎤 Top artists:",
+	"bot.stats_top_entry":      "%d. %s (%d)",
+	"bot.stats_reset":          "📊 Statistics have been reset.",
+
+	// Requester leaderboard messages
+	"bot.leaderboard_header": "🏆 Top contributors of all time:",
+	"bot.leaderboard_empty":  "🏆 Nobody has requested a song yet.",
+
+	// Setup helper messages
+	"bot.private_chat":               "👋 I only take song requests in the group \"%s\". Please send your requests there!",
+	"bot.private_chat_unknown_group": "👋 I only take song requests in my group. Please send your requests there!",
+	"bot.whoami":                     "🪪 User ID: %s\n💬 Chat ID: %s\n👑 %s",
+	"bot.whoami_admin":               "You are an admin",
+	"bot.whoami_not_admin":           "You are not an admin",
+	"bot.whoami_admin_unknown":       "Admin status unknown",
+	"bot.warnings_subscribed":        "🔔 You will now receive admin warnings here, e.g. about inactive devices or an expired Spotify login.",
+	"bot.warnings_subscribe_denied":  "🔒 Only admins of the group or users with an invitation link can receive admin warnings.",
+
+	// Failed addition retry messages
+	"bot.requeue_failed_empty":  "✅ There are no failed additions to retry.",
+	"bot.requeue_failed_result": "🔁 Retried %d failed additions: %d added, %d still failing.",
+
 	// Queue track approval messages
 	"button.queue_approve":    "✅ Approve",
 	"button.queue_deny":       "❌ Deny",
@@ -113,6 +224,24 @@ var englishMessages = map[string]string{
 		"• Some bot features require admin status to work correctly\n\n" +
 		"💡 Admin permissions enable the bot to receive events and manage group interactions.",
 
+	// Playlist notifications
+	"admin.playlist_unavailable": "🚫 Playlist Not Accessible!\n\n" +
+		"The playlist %s was deleted or the bot lost access to it. Song requests are paused.\n\n" +
+		"💡 Restore the playlist or give the bot's Spotify account access again. Requests resume automatically.",
+	"admin.playlist_read_only": "🔒 Playlist Not Writable!\n\n" +
+		"The bot's Spotify account can no longer add tracks to the playlist %s. Song requests are paused.\n\n" +
+		"💡 Make the playlist collaborative or owned by the bot's Spotify account. Requests resume automatically.",
+
+	// Spotify authorization notifications
+	"admin.spotify_auth_expired": "🔑 Spotify Authorization Expired!\n\n" +
+		"The bot can no longer access Spotify, so songs can't be added or queued.\n\n" +
+		"💡 Open this link with the bot's Spotify account to authorize it again:\n%s",
+
+	// Auto-DJ notifications
+	"admin.autodj_takeover": "🤖 The Auto-DJ Took Over!\n\n" +
+		"The auto-DJ picked %d of the last %d tracks, the crowd went quiet.\n\n" +
+		"💡 Encourage the group to request songs. This notice disappears once requests pick back up.",
+
 	// Queue sync notifications
 	"admin.queue_sync_warning": "🚨 Queue Sync Issue Detected!\n\n" +
 		"The queue may be out of sync. Queued tracks:\n%s\n" +
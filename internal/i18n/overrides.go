@@ -0,0 +1,119 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// LoadOverrides replaces bundled messages with custom wording. Every override must name a known
+// message key and use as many format placeholders as the bundled message, so translating it can't
+// garble or drop arguments. Nothing is applied if any override is invalid. Keys without an override
+// keep the bundled message. It is meant to be called once at startup, before the localizer is shared.
+func (l *Localizer) LoadOverrides(overrides map[string]string) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(overrides)) {
+		bundled, exists := l.bundledMessage(key)
+		if !exists {
+			errs = append(errs, fmt.Errorf("unknown message key %q", key))
+			continue
+		}
+		if want, got := countPlaceholders(bundled), countPlaceholders(overrides[key]); want != got {
+			errs = append(errs, fmt.Errorf("message %q has %d placeholders, expected %d", key, got, want))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	// Copy before applying, the bundled message maps are shared by all localizers
+	messages := maps.Clone(l.messages)
+	maps.Copy(messages, overrides)
+	l.messages = messages
+	return nil
+}
+
+// bundledMessage returns the bundled message for a key, falling back to English like T.
+func (l *Localizer) bundledMessage(key string) (string, bool) {
+	if message, exists := getMessages(l.language)[key]; exists {
+		return message, true
+	}
+	message, exists := getMessages(DefaultLanguage)[key]
+	return message, exists
+}
+
+// countPlaceholders counts the format verbs in a message, ignoring escaped percent signs.
+func countPlaceholders(message string) int {
+	count := 0
+	for i := 0; i < len(message); i++ {
+		if message[i] != '%' {
+			continue
+		}
+		if i+1 < len(message) && message[i+1] == '%' {
+			i++
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// ReadOverridesFile reads message overrides from a JSON or TOML file, chosen by its extension.
+// Keys can be given flat ("bot.history_empty") or nested in objects and tables ([bot] history_empty).
+func ReadOverridesFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read messages file: %w", err)
+	}
+
+	var raw map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported messages file format %q, use .json or .toml", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse messages file: %w", err)
+	}
+
+	overrides := make(map[string]string)
+	if err := flattenOverrides("", raw, overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// flattenOverrides joins nested keys with dots into the flat message keys.
+func flattenOverrides(prefix string, raw map[string]any, overrides map[string]string) error {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch value := value.(type) {
+		case string:
+			overrides[key] = value
+		case map[string]any:
+			if err := flattenOverrides(key, value, overrides); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("message %q must be a string", key)
+		}
+	}
+	return nil
+}
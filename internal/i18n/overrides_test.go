@@ -0,0 +1,141 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadOverrides(t *testing.T) {
+	localizer := NewLocalizer(BerneseGermanMessages)
+
+	err := localizer.LoadOverrides(map[string]string{
+		"bot.history_requested": "%s – %s isch wider gwünscht 🎶",
+		testErrorGenericKey:     "Hoppla!",
+	})
+	if err != nil {
+		t.Fatalf("Expected valid overrides to load, got %v", err)
+	}
+
+	if got := localizer.T("bot.history_requested", "Band", "Song"); got != "Band – Song isch wider gwünscht 🎶" {
+		t.Errorf("Expected the override with its arguments, got %q", got)
+	}
+	if got := localizer.T(testErrorGenericKey); got != "Hoppla!" {
+		t.Errorf("Expected the override, got %q", got)
+	}
+	if got, want := localizer.T("bot.history_empty"), getMessages(BerneseGermanMessages)["bot.history_empty"]; got != want {
+		t.Errorf("Expected keys without override to keep the bundled message %q, got %q", want, got)
+	}
+	if NewLocalizer(BerneseGermanMessages).T(testErrorGenericKey) == "Hoppla!" {
+		t.Error("Expected overrides not to leak into other localizers")
+	}
+}
+
+func TestLoadOverrides_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		wantErr   string
+	}{
+		{
+			name:      "unknown key",
+			overrides: map[string]string{"error.does_not_exist": "Oops"},
+			wantErr:   "unknown message key",
+		},
+		{
+			name:      "missing placeholder",
+			overrides: map[string]string{"bot.history_requested": "Requested %s again"},
+			wantErr:   "has 1 placeholders, expected 2",
+		},
+		{
+			name:      "extra placeholder",
+			overrides: map[string]string{testErrorGenericKey: "Something went wrong: %s"},
+			wantErr:   "has 1 placeholders, expected 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localizer := NewLocalizer(DefaultLanguage)
+			// A valid override must not be applied when another one is rejected
+			tt.overrides["bot.history_empty"] = "Nothing yet"
+
+			err := localizer.LoadOverrides(tt.overrides)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if localizer.T("bot.history_empty") == "Nothing yet" {
+				t.Error("Expected no override to be applied")
+			}
+		})
+	}
+}
+
+func TestCountPlaceholders(t *testing.T) {
+	tests := map[string]int{
+		"":                   0,
+		"No placeholders":    0,
+		"100%% sure":         0,
+		"%s by %s":           2,
+		"%d%% of %d users":   2,
+		"Trailing percent %": 1,
+	}
+
+	for message, want := range tests {
+		if got := countPlaceholders(message); got != want {
+			t.Errorf("countPlaceholders(%q) = %d, expected %d", message, got, want)
+		}
+	}
+}
+
+func TestReadOverridesFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"messages.json": `{"error.generic": "Oops", "bot": {"history_empty": "Nothing yet"}}`,
+		"messages.toml": "\"error.generic\" = \"Oops\"\n\n[bot]\nhistory_empty = \"Nothing yet\"\n",
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			overrides, err := ReadOverridesFile(path)
+			if err != nil {
+				t.Fatalf("Expected the file to be read, got %v", err)
+			}
+			if len(overrides) != 2 || overrides[testErrorGenericKey] != "Oops" || overrides["bot.history_empty"] != "Nothing yet" {
+				t.Errorf("Expected flat and nested keys, got %v", overrides)
+			}
+		})
+	}
+}
+
+func TestReadOverridesFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"messages.yaml":     "error.generic: Oops",
+		"not-a-string.json": `{"error.generic": 42}`,
+		"malformed.json":    `{"error.generic": `,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ReadOverridesFile(path); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	if _, err := ReadOverridesFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
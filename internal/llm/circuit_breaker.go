@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrCircuitOpen is returned instead of calling the LLM while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("LLM circuit breaker is open, skipping request")
+
+// circuitBreaker stops calling a failing LLM provider after a number of consecutive failures.
+// Once the cooldown has elapsed, requests are let through again; the first success closes
+// the circuit while another failure opens it for a new cooldown period.
+type circuitBreaker struct {
+	threshold int           // Consecutive failures that open the circuit (0 disables)
+	cooldown  time.Duration // How long the circuit stays open
+	failures  int
+	openUntil time.Time
+	probing   bool // Cooldown elapsed and requests are let through again
+	mutex     sync.Mutex
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+// newCircuitBreaker creates a circuit breaker that opens after threshold consecutive failures.
+func newCircuitBreaker(threshold int, cooldown time.Duration, logger *zap.Logger) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a request may be sent to the provider.
+func (cb *circuitBreaker) allow() bool {
+	if cb.threshold <= 0 {
+		return true
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.openUntil.IsZero() {
+		return true
+	}
+	if cb.now().Before(cb.openUntil) {
+		return false
+	}
+
+	if !cb.probing {
+		cb.probing = true
+		cb.logger.Info("LLM circuit breaker half-open, retrying provider")
+	}
+	return true
+}
+
+// record updates the circuit state with the outcome of a request.
+func (cb *circuitBreaker) record(err error) {
+	if cb.threshold <= 0 {
+		return
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if err == nil {
+		if !cb.openUntil.IsZero() {
+			cb.logger.Info("LLM circuit breaker closed, provider recovered")
+		}
+		cb.failures = 0
+		cb.openUntil = time.Time{}
+		cb.probing = false
+		return
+	}
+
+	cb.failures++
+	if cb.failures < cb.threshold {
+		return
+	}
+
+	// Open the circuit, or reopen it if a request after the cooldown failed again
+	if cb.openUntil.IsZero() || !cb.now().Before(cb.openUntil) {
+		cb.openUntil = cb.now().Add(cb.cooldown)
+		cb.probing = false
+		cb.logger.Warn("LLM circuit breaker opened, falling back to non-LLM behavior",
+			zap.Int("consecutiveFailures", cb.failures),
+			zap.Duration("cooldown", cb.cooldown),
+			zap.Error(err))
+	}
+}
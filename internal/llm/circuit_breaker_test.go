@@ -0,0 +1,66 @@
+package llm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+	cb := newCircuitBreaker(3, time.Minute, zap.NewNop())
+	cb.now = func() time.Time { return now }
+	errFailed := errors.New("timeout")
+
+	// Failures below the threshold keep the circuit closed
+	cb.record(errFailed)
+	cb.record(errFailed)
+	if !cb.allow() {
+		t.Fatal("circuit should stay closed below the failure threshold")
+	}
+
+	// A success resets the consecutive failure count
+	cb.record(nil)
+	cb.record(errFailed)
+	cb.record(errFailed)
+	if !cb.allow() {
+		t.Fatal("circuit should stay closed after a success reset the failures")
+	}
+
+	// Reaching the threshold opens the circuit
+	cb.record(errFailed)
+	if cb.allow() {
+		t.Fatal("circuit should be open after reaching the failure threshold")
+	}
+
+	// After the cooldown requests are let through again
+	now = now.Add(time.Minute)
+	if !cb.allow() {
+		t.Fatal("circuit should let requests through after the cooldown")
+	}
+
+	// Another failure reopens the circuit for a new cooldown
+	cb.record(errFailed)
+	if cb.allow() {
+		t.Fatal("circuit should reopen after a failure following the cooldown")
+	}
+
+	// A success after the cooldown closes the circuit
+	now = now.Add(time.Minute)
+	cb.record(nil)
+	if !cb.allow() {
+		t.Fatal("circuit should be closed after a success")
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	cb := newCircuitBreaker(0, time.Minute, zap.NewNop())
+	for range 10 {
+		cb.record(errors.New("timeout"))
+	}
+	if !cb.allow() {
+		t.Error("disabled circuit breaker should always allow requests")
+	}
+}
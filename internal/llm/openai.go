@@ -29,6 +29,7 @@ const (
 	rankingTemperature    = 0.3
 	extractionTemperature = 0.1 // Deterministic for extraction
 	moodTemperature       = 0.2 // Slightly creative for mood descriptions
+	explainTemperature    = 0.3 // Slightly creative for natural suggestions
 	maxTokensRanking      = 1000
 	maxTokensChatter      = 200
 	maxTokensPriority     = 200
@@ -37,6 +38,7 @@ const (
 	maxTokensTrackRanking = 100
 	maxTokensExtraction   = 500 // For song extraction response
 	maxTokensMood         = 50  // For track mood generation
+	maxTokensExplanation  = 100 // For short suggestions after a rejected request
 	defaultModel          = "gpt-3.5-turbo"
 )
 
@@ -53,13 +55,35 @@ func NewOpenAIClient(config *core.LLMConfig, logger *zap.Logger) (*OpenAIClient,
 		opts = append(opts, option.WithBaseURL(config.BaseURL))
 	}
 
+	return newOpenAIClient(config, logger, opts), nil
+}
+
+// NewCompatibleClient creates a client for any OpenAI-compatible endpoint (e.g. vLLM, LM Studio).
+// The base URL is required, the API key is optional.
+func NewCompatibleClient(config *core.LLMConfig, logger *zap.Logger) (*OpenAIClient, error) {
+	if config.BaseURL == "" {
+		return nil, errors.New("base URL is required for OpenAI-compatible providers")
+	}
+
+	opts := []option.RequestOption{option.WithBaseURL(config.BaseURL)}
+	if config.APIKey != "" {
+		opts = append(opts, option.WithAPIKey(config.APIKey))
+	} else {
+		// Don't leak an OPENAI_API_KEY from the environment to a third-party endpoint
+		opts = append(opts, option.WithHeaderDel("authorization"))
+	}
+
+	return newOpenAIClient(config, logger, opts), nil
+}
+
+func newOpenAIClient(config *core.LLMConfig, logger *zap.Logger, opts []option.RequestOption) *OpenAIClient {
 	client := openai.NewClient(opts...)
 
 	return &OpenAIClient{
 		config: config,
 		logger: logger,
 		client: &client,
-	}, nil
+	}
 }
 
 // ChatterDetectionResponse represents the response from OpenAI for chatter detection.
@@ -299,14 +323,15 @@ func (o *OpenAIClient) GenerateTrackMood(ctx context.Context, tracks []core.Trac
 }
 
 // RankTracks ranks the given tracks based on their relevance to the search query using OpenAI.
-func (o *OpenAIClient) RankTracks(ctx context.Context, searchQuery string, tracks []core.Track) []core.Track {
+func (o *OpenAIClient) RankTracks(ctx context.Context, searchQuery string,
+	tracks []core.Track) ([]core.Track, error) {
 	if len(tracks) == 0 {
-		return tracks
+		return tracks, nil
 	}
 
 	if len(tracks) == 1 {
 		// No need to rank a single track
-		return tracks
+		return tracks, nil
 	}
 
 	o.logger.Debug("Calling OpenAI for track ranking",
@@ -339,12 +364,12 @@ func (o *OpenAIClient) RankTracks(ctx context.Context, searchQuery string, track
 	})
 	if err != nil {
 		o.logger.Warn("Failed to rank tracks with OpenAI, using original order", zap.Error(err))
-		return tracks // Fallback to original order
+		return tracks, fmt.Errorf("OpenAI API call failed: %w", err) // Fallback to original order
 	}
 
 	if len(resp.Choices) == 0 || resp.Choices[0].Message.Content == "" {
 		o.logger.Warn("OpenAI returned empty response for track ranking, using original order")
-		return tracks
+		return tracks, nil
 	}
 
 	// Parse the ranking response
@@ -357,7 +382,55 @@ func (o *OpenAIClient) RankTracks(ctx context.Context, searchQuery string, track
 		zap.Int("rankedCount", len(rankedTracks)),
 		zap.String("ranking", rankingText))
 
-	return rankedTracks
+	return rankedTracks, nil
+}
+
+// RankTracksForTransition ranks the given tracks by their relevance to the search query and how smoothly
+// they would follow a track with the given audio features, preferring compatible tempo and energy.
+func (o *OpenAIClient) RankTracksForTransition(ctx context.Context, searchQuery string,
+	previousFeatures *core.AudioFeatures, tracks []core.Track) ([]core.Track, error) {
+	if len(tracks) <= 1 {
+		// No need to rank a single track
+		return tracks, nil
+	}
+
+	o.logger.Debug("Calling OpenAI for transition ranking",
+		zap.String("searchQuery", searchQuery),
+		zap.Float64("tempo", previousFeatures.Tempo),
+		zap.Float64("energy", previousFeatures.Energy),
+		zap.Int("trackCount", len(tracks)))
+
+	resp, err := o.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage("You are a party DJ ranking tracks by how well they fit the set " +
+				"and how smoothly they follow the previous track."),
+			openai.UserMessage(buildTransitionRankingPrompt(searchQuery, previousFeatures, tracks)),
+		},
+		Model:       o.getModel(),
+		Temperature: openai.Float(rankingTemperature),
+		MaxTokens:   openai.Int(maxTokensTrackRanking),
+	})
+	if err != nil {
+		o.logger.Warn("Failed to rank tracks for transition with OpenAI, using original order", zap.Error(err))
+		return tracks, fmt.Errorf("OpenAI API call failed: %w", err)
+	}
+
+	if len(resp.Choices) == 0 || resp.Choices[0].Message.Content == "" {
+		o.logger.Warn("OpenAI returned empty response for transition ranking, using original order")
+		return tracks, nil
+	}
+
+	rankingText := strings.TrimSpace(resp.Choices[0].Message.Content)
+	rankedTracks := parseTrackRanking(rankingText, tracks, o.logger)
+
+	o.logger.Info("Ranked tracks for transition with OpenAI",
+		zap.String("searchQuery", searchQuery),
+		zap.Float64("tempo", previousFeatures.Tempo),
+		zap.Int("originalCount", len(tracks)),
+		zap.Int("rankedCount", len(rankedTracks)),
+		zap.String("ranking", rankingText))
+
+	return rankedTracks, nil
 }
 
 func (o *OpenAIClient) getModel() shared.ChatModel {
@@ -464,6 +537,49 @@ func (o *OpenAIClient) ExtractSongQuery(ctx context.Context, userText string) (s
 	return extractedQuery, nil
 }
 
+// ExplainRejection asks OpenAI for a short suggestion which of the candidates the user may have meant.
+// Unlike the other calls it doesn't fall back on errors, so the caller can list the candidates instead.
+func (o *OpenAIClient) ExplainRejection(ctx context.Context, userText string, candidates []core.Track,
+	language string) (string, error) {
+	if len(candidates) == 0 {
+		return "", errors.New("no candidates provided")
+	}
+
+	userPrompt := fmt.Sprintf("Request: %s\nReply language: %s\nCandidates:\n", userText, language)
+	for _, track := range candidates {
+		userPrompt += fmt.Sprintf("- %s by %s\n", track.Title, track.Artist)
+	}
+
+	o.logger.Debug("Calling OpenAI for rejection explanation",
+		zap.String("text", userText),
+		zap.Int("candidates", len(candidates)),
+		zap.String("model", o.config.Model))
+
+	resp, err := o.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(o.buildRejectionExplanationPrompt()),
+			openai.UserMessage(userPrompt),
+		},
+		Model:       o.getModel(),
+		Temperature: openai.Float(explainTemperature),
+		MaxTokens:   openai.Int(maxTokensExplanation),
+	})
+	if err != nil {
+		return "", fmt.Errorf("rejection explanation failed: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", errors.New("no response for rejection explanation")
+	}
+
+	explanation := strings.TrimSpace(resp.Choices[0].Message.Content)
+	if explanation == "" {
+		return "", errors.New("empty response for rejection explanation")
+	}
+
+	return explanation, nil
+}
+
 func (o *OpenAIClient) buildSongExtractionPrompt() string {
 	return `Extract and normalize song requests from chat messages. Return only the normalized search query as plain text.
 
@@ -554,6 +670,38 @@ Examples:
 - "classic jazz standards"`
 }
 
+// buildTransitionRankingPrompt describes the search query and the previous track's audio features
+// and lists the tracks to rank.
+func buildTransitionRankingPrompt(searchQuery string, previousFeatures *core.AudioFeatures, tracks []core.Track) string {
+	prompt := fmt.Sprintf("The set is looking for %q. The track these tracks would follow has a tempo of %.0f BPM, "+
+		"energy %.2f, danceability %.2f and valence %.2f (0.0 to 1.0).\n\nTracks to rank:\n",
+		searchQuery, previousFeatures.Tempo, previousFeatures.Energy, previousFeatures.Danceability,
+		previousFeatures.Valence)
+	for i, track := range tracks {
+		prompt += fmt.Sprintf("%d. %s by %s\n", i+1, track.Title, track.Artist)
+	}
+	prompt += "\nRank them by how well they match the search query first, and among similarly good matches " +
+		"prefer a similar tempo (or half/double time), energy within a small step and a compatible genre, " +
+		"so the set flows without abrupt jumps. Respond with only the track numbers in order of best fit " +
+		"first (e.g., \"3,1,5,2,4\")."
+	return prompt
+}
+
+func (o *OpenAIClient) buildRejectionExplanationPrompt() string {
+	return `You are a friendly party DJ bot. A guest requested a song, but no search result matched it confidently.
+
+Given the guest's request and the top search candidates, write ONE short sentence asking which song they meant,
+like "Did you mean Hells Bells by AC/DC?".
+
+RULES:
+- Answer in the requested reply language
+- Only suggest songs from the candidate list, never invent songs
+- Suggest at most two candidates, the most likely first
+- No greetings, links, lists or explanations
+
+Respond with just the sentence.`
+}
+
 func (o *OpenAIClient) buildHelpRequestPrompt() string {
 	return `You are analyzing messages to detect if someone is asking for help or instructions about a music bot.
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -16,19 +17,23 @@ const fallbackSearchQuery = "popular music"
 
 // Provider wraps an LLM client and provides a unified interface for AI operations.
 type Provider struct {
-	config *core.LLMConfig
-	logger *zap.Logger
-	client Client
+	config  *core.LLMConfig
+	logger  *zap.Logger
+	client  Client
+	breaker *circuitBreaker
 }
 
 // Client defines the interface for LLM client implementations.
 type Client interface {
-	RankTracks(ctx context.Context, searchQuery string, tracks []core.Track) []core.Track
+	RankTracks(ctx context.Context, searchQuery string, tracks []core.Track) ([]core.Track, error)
+	RankTracksForTransition(ctx context.Context, searchQuery string, previousFeatures *core.AudioFeatures,
+		tracks []core.Track) ([]core.Track, error)
 	IsNotMusicRequest(ctx context.Context, text string) (bool, error)
 	IsPriorityRequest(ctx context.Context, text string) (bool, error)
 	IsHelpRequest(ctx context.Context, text string) (bool, error)
 	GenerateTrackMood(ctx context.Context, tracks []core.Track) (string, error)
 	ExtractSongQuery(ctx context.Context, userText string) (string, error)
+	ExplainRejection(ctx context.Context, userText string, candidates []core.Track, language string) (string, error)
 }
 
 // NewProvider creates a new LLM provider based on the configuration.
@@ -39,14 +44,16 @@ func NewProvider(config *core.LLMConfig, logger *zap.Logger) (*Provider, error)
 	switch config.Provider {
 	case "openai":
 		client, err = NewOpenAIClient(config, logger)
+	case "compatible":
+		client, err = NewCompatibleClient(config, logger)
 	case "anthropic":
 		return nil, errors.New("anthropic provider not yet implemented - please use openai or ollama")
 	case "ollama":
 		return nil, errors.New("ollama provider not yet implemented - please use openai for now")
 	case "none", "":
-		return nil, errors.New("AI provider is required - please configure one of: openai, anthropic, ollama")
+		return nil, errors.New("AI provider is required - please configure one of: openai, compatible, anthropic, ollama")
 	default:
-		return nil, fmt.Errorf("unsupported AI provider '%s' - supported providers: openai, anthropic, ollama",
+		return nil, fmt.Errorf("unsupported AI provider '%s' - supported providers: openai, compatible, anthropic, ollama",
 			config.Provider)
 	}
 
@@ -58,37 +65,94 @@ func NewProvider(config *core.LLMConfig, logger *zap.Logger) (*Provider, error)
 		config: config,
 		logger: logger,
 		client: client,
+		breaker: newCircuitBreaker(config.CircuitBreakerFailures,
+			time.Duration(config.CircuitBreakerCooldownSecs)*time.Second, logger),
 	}, nil
 }
 
 // RankTracks ranks the given tracks based on their relevance to the search query using the LLM.
+// The original order is kept if the LLM fails or the circuit breaker is open.
 func (p *Provider) RankTracks(ctx context.Context, searchQuery string, tracks []core.Track) []core.Track {
-	return p.client.RankTracks(ctx, searchQuery, tracks)
+	if !p.breaker.allow() {
+		return tracks
+	}
+	rankedTracks, err := p.client.RankTracks(ctx, searchQuery, tracks)
+	p.breaker.record(err)
+	return rankedTracks
+}
+
+// RankTracksForTransition ranks the given tracks by their relevance to the search query and how smoothly
+// they follow a track with the given audio features. The original order is kept if the LLM fails
+// or the circuit breaker is open.
+func (p *Provider) RankTracksForTransition(ctx context.Context, searchQuery string, previousFeatures *core.AudioFeatures,
+	tracks []core.Track) []core.Track {
+	if !p.breaker.allow() {
+		return tracks
+	}
+	rankedTracks, err := p.client.RankTracksForTransition(ctx, searchQuery, previousFeatures, tracks)
+	p.breaker.record(err)
+	return rankedTracks
 }
 
 // IsNotMusicRequest determines if the given text is not a music-related request.
 func (p *Provider) IsNotMusicRequest(ctx context.Context, text string) (bool, error) {
-	return p.client.IsNotMusicRequest(ctx, text)
+	if !p.breaker.allow() {
+		return false, ErrCircuitOpen
+	}
+	result, err := p.client.IsNotMusicRequest(ctx, text)
+	p.breaker.record(err)
+	return result, err
 }
 
 // IsPriorityRequest determines if the given text represents a priority request that should skip the queue.
 func (p *Provider) IsPriorityRequest(ctx context.Context, text string) (bool, error) {
-	return p.client.IsPriorityRequest(ctx, text)
+	if !p.breaker.allow() {
+		return false, ErrCircuitOpen
+	}
+	result, err := p.client.IsPriorityRequest(ctx, text)
+	p.breaker.record(err)
+	return result, err
 }
 
 // IsHelpRequest determines if the given text is asking for help or instructions.
 func (p *Provider) IsHelpRequest(ctx context.Context, text string) (bool, error) {
-	return p.client.IsHelpRequest(ctx, text)
+	if !p.breaker.allow() {
+		return false, ErrCircuitOpen
+	}
+	result, err := p.client.IsHelpRequest(ctx, text)
+	p.breaker.record(err)
+	return result, err
 }
 
 // GenerateTrackMood generates a mood description for the given tracks using the LLM.
 func (p *Provider) GenerateTrackMood(ctx context.Context, tracks []core.Track) (string, error) {
-	return p.client.GenerateTrackMood(ctx, tracks)
+	if !p.breaker.allow() {
+		return "", ErrCircuitOpen
+	}
+	mood, err := p.client.GenerateTrackMood(ctx, tracks)
+	p.breaker.record(err)
+	return mood, err
 }
 
 // ExtractSongQuery extracts a search query from user text using the LLM.
 func (p *Provider) ExtractSongQuery(ctx context.Context, userText string) (string, error) {
-	return p.client.ExtractSongQuery(ctx, userText)
+	if !p.breaker.allow() {
+		return "", ErrCircuitOpen
+	}
+	query, err := p.client.ExtractSongQuery(ctx, userText)
+	p.breaker.record(err)
+	return query, err
+}
+
+// ExplainRejection suggests which of the candidates the user may have meant, in the given language.
+func (p *Provider) ExplainRejection(ctx context.Context, userText string, candidates []core.Track,
+	language string) (string, error) {
+	if !p.breaker.allow() {
+		return "", ErrCircuitOpen
+	}
+	explanation, err := p.client.ExplainRejection(ctx, userText, candidates, language)
+	p.breaker.record(err)
+	return explanation, err
 }
 
 // parseTrackRanking parses LLM ranking response and returns tracks in ranked order.
@@ -2,6 +2,7 @@ package llm
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"go.uber.org/zap"
@@ -74,3 +75,37 @@ func TestParseTrackRanking_LengthConsistency(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildTransitionRankingPrompt(t *testing.T) {
+	features := &core.AudioFeatures{Tempo: 124.4, Energy: 0.8, Danceability: 0.7, Valence: 0.55}
+	prompt := buildTransitionRankingPrompt("upbeat house", features, createSampleTracks()[:2])
+
+	for _, expected := range []string{`"upbeat house"`, "124 BPM", "energy 0.80", "1. Song 1 by Artist 1", "2. Song 2 by Artist 2"} {
+		if !strings.Contains(prompt, expected) {
+			t.Errorf("Expected the prompt to contain %q, got %q", expected, prompt)
+		}
+	}
+}
+
+func TestNewProvider_Compatible(t *testing.T) {
+	logger := zap.NewNop()
+	tests := []struct {
+		name      string
+		config    core.LLMConfig
+		expectErr bool
+	}{
+		{"Base URL without API key", core.LLMConfig{Provider: "compatible", BaseURL: "http://localhost:8000/v1"}, false},
+		{"Base URL with API key", core.LLMConfig{
+			Provider: "compatible", BaseURL: "http://localhost:8000/v1", APIKey: "secret", Model: "llama"}, false},
+		{"Missing base URL", core.LLMConfig{Provider: "compatible", APIKey: "secret"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewProvider(&tt.config, logger)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("NewProvider() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
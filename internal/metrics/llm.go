@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"djalgorhythm/internal/core"
+)
+
+// instrumentedLLMProvider wraps an LLM provider and records the latency of every request.
+type instrumentedLLMProvider struct {
+	provider core.LLMProvider
+	registry *Registry
+}
+
+// InstrumentLLMProvider wraps the provider so that request latencies are recorded in the registry.
+func InstrumentLLMProvider(provider core.LLMProvider, registry *Registry) core.LLMProvider {
+	return &instrumentedLLMProvider{provider: provider, registry: registry}
+}
+
+// observe records the time elapsed since start for the given operation.
+func (p *instrumentedLLMProvider) observe(operation string, start time.Time) {
+	p.registry.ObserveLLMLatency(operation, time.Since(start))
+}
+
+// RankTracks ranks tracks and records the request latency.
+func (p *instrumentedLLMProvider) RankTracks(ctx context.Context, searchQuery string, tracks []core.Track) []core.Track {
+	defer p.observe("rank_tracks", time.Now())
+	return p.provider.RankTracks(ctx, searchQuery, tracks)
+}
+
+// RankTracksForTransition ranks tracks for a smooth transition and records the request latency.
+func (p *instrumentedLLMProvider) RankTracksForTransition(ctx context.Context, searchQuery string,
+	previousFeatures *core.AudioFeatures, tracks []core.Track) []core.Track {
+	defer p.observe("rank_tracks_for_transition", time.Now())
+	return p.provider.RankTracksForTransition(ctx, searchQuery, previousFeatures, tracks)
+}
+
+// IsNotMusicRequest detects chatter and records the request latency.
+func (p *instrumentedLLMProvider) IsNotMusicRequest(ctx context.Context, text string) (bool, error) {
+	defer p.observe("is_not_music_request", time.Now())
+	return p.provider.IsNotMusicRequest(ctx, text)
+}
+
+// IsPriorityRequest detects priority requests and records the request latency.
+func (p *instrumentedLLMProvider) IsPriorityRequest(ctx context.Context, text string) (bool, error) {
+	defer p.observe("is_priority_request", time.Now())
+	return p.provider.IsPriorityRequest(ctx, text)
+}
+
+// IsHelpRequest detects help requests and records the request latency.
+func (p *instrumentedLLMProvider) IsHelpRequest(ctx context.Context, text string) (bool, error) {
+	defer p.observe("is_help_request", time.Now())
+	return p.provider.IsHelpRequest(ctx, text)
+}
+
+// GenerateTrackMood generates a track mood and records the request latency.
+func (p *instrumentedLLMProvider) GenerateTrackMood(ctx context.Context, tracks []core.Track) (string, error) {
+	defer p.observe("generate_track_mood", time.Now())
+	return p.provider.GenerateTrackMood(ctx, tracks)
+}
+
+// ExtractSongQuery extracts a song query and records the request latency.
+func (p *instrumentedLLMProvider) ExtractSongQuery(ctx context.Context, userText string) (string, error) {
+	defer p.observe("extract_song_query", time.Now())
+	return p.provider.ExtractSongQuery(ctx, userText)
+}
+
+// ExplainRejection suggests what the user may have meant and records the request latency.
+func (p *instrumentedLLMProvider) ExplainRejection(ctx context.Context, userText string, candidates []core.Track,
+	language string) (string, error) {
+	defer p.observe("explain_rejection", time.Now())
+	return p.provider.ExplainRejection(ctx, userText, candidates, language)
+}
@@ -0,0 +1,110 @@
+// Package metrics provides Prometheus instrumentation for song requests, the shadow queue and external APIs.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "djalgorhythm"
+
+// Registry holds all DJAlgoRhythm Prometheus collectors in a dedicated registry.
+type Registry struct {
+	registry         *prometheus.Registry
+	songsAdded       prometheus.Counter
+	requestsRejected *prometheus.CounterVec
+	approvalTimeouts prometheus.Counter
+	shadowQueueSize  prometheus.Gauge
+	spotifyAPIErrors *prometheus.CounterVec
+	llmLatency       *prometheus.HistogramVec
+}
+
+// New creates a metrics registry with all collectors registered.
+func New() *Registry {
+	r := &Registry{
+		registry: prometheus.NewRegistry(),
+		songsAdded: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "songs_added_total",
+			Help:      "Total number of songs added to the playlist",
+		}),
+		requestsRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_rejected_total",
+			Help:      "Total number of rejected song requests by reason",
+		}, []string{"reason"}),
+		approvalTimeouts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "approval_timeouts_total",
+			Help:      "Total number of song approvals that timed out",
+		}),
+		shadowQueueSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "shadow_queue_size",
+			Help:      "Current number of tracks in the shadow queue",
+		}),
+		spotifyAPIErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "spotify_api_errors_total",
+			Help:      "Total number of failed Spotify API requests by operation",
+		}, []string{"operation"}),
+		llmLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "llm_request_duration_seconds",
+			Help:      "Latency of LLM provider requests by operation",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+	}
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.songsAdded,
+		r.requestsRejected,
+		r.approvalTimeouts,
+		r.shadowQueueSize,
+		r.spotifyAPIErrors,
+		r.llmLatency,
+	)
+
+	return r
+}
+
+// Handler returns an HTTP handler serving the registry in the Prometheus exposition format.
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{})
+}
+
+// IncSongsAdded counts a song added to the playlist.
+func (r *Registry) IncSongsAdded() {
+	r.songsAdded.Inc()
+}
+
+// IncRequestsRejected counts a rejected song request with the given reason.
+func (r *Registry) IncRequestsRejected(reason string) {
+	r.requestsRejected.WithLabelValues(reason).Inc()
+}
+
+// IncApprovalTimeouts counts an approval that timed out.
+func (r *Registry) IncApprovalTimeouts() {
+	r.approvalTimeouts.Inc()
+}
+
+// SetShadowQueueSize sets the current number of tracks in the shadow queue.
+func (r *Registry) SetShadowQueueSize(size int) {
+	r.shadowQueueSize.Set(float64(size))
+}
+
+// IncSpotifyAPIErrors counts a failed Spotify API request for the given operation.
+func (r *Registry) IncSpotifyAPIErrors(operation string) {
+	r.spotifyAPIErrors.WithLabelValues(operation).Inc()
+}
+
+// ObserveLLMLatency records the duration of an LLM provider request for the given operation.
+func (r *Registry) ObserveLLMLatency(operation string, duration time.Duration) {
+	r.llmLatency.WithLabelValues(operation).Observe(duration.Seconds())
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Handler(t *testing.T) {
+	r := New()
+	r.IncSongsAdded()
+	r.IncRequestsRejected("duplicate")
+	r.IncApprovalTimeouts()
+	r.SetShadowQueueSize(4)
+	r.IncSpotifyAPIErrors("search")
+	r.ObserveLLMLatency("rank_tracks", 250*time.Millisecond)
+
+	server := httptest.NewServer(r.Handler())
+	defer server.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to scrape metrics: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+
+	expected := []string{
+		"djalgorhythm_songs_added_total 1",
+		`djalgorhythm_requests_rejected_total{reason="duplicate"} 1`,
+		"djalgorhythm_approval_timeouts_total 1",
+		"djalgorhythm_shadow_queue_size 4",
+		`djalgorhythm_spotify_api_errors_total{operation="search"} 1`,
+		`djalgorhythm_llm_request_duration_seconds_count{operation="rank_tracks"} 1`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("Expected metrics output to contain %q", want)
+		}
+	}
+}
+
+func TestNew_IndependentRegistries(t *testing.T) {
+	// Each registry is independent, so creating several must not panic on duplicate registration
+	first := New()
+	second := New()
+	if first == second {
+		t.Fatal("New() should return distinct registries")
+	}
+}
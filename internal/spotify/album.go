@@ -0,0 +1,104 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/zmb3/spotify/v2"
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/core"
+)
+
+// albumTracksPageSize is the maximum number of tracks Spotify returns per album tracks request.
+const albumTracksPageSize = 50
+
+var (
+	spotifyAlbumRegex    = regexp.MustCompile(`(?:https?://)?(?:open\.)?spotify\.com/album/([a-zA-Z0-9]+)`)
+	spotifyAlbumURIRegex = regexp.MustCompile(`spotify:album:([a-zA-Z0-9]+)`)
+)
+
+// ExtractAlbumID extracts a Spotify album ID from album URLs and URIs.
+// Shortened links are not resolved since they only resolve to tracks.
+func (c *Client) ExtractAlbumID(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	if matches := spotifyAlbumURIRegex.FindStringSubmatch(rawURL); len(matches) > 1 {
+		return matches[1], nil
+	}
+
+	if matches := spotifyAlbumRegex.FindStringSubmatch(rawURL); len(matches) > 1 {
+		return matches[1], nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+
+	albumID := extractAlbumIDFromPath(u.Path)
+	if albumID == "" {
+		return "", errors.New("no album ID found in URL")
+	}
+	return albumID, nil
+}
+
+// extractAlbumIDFromPath extracts a Spotify album ID from a URL path (e.g. "/intl-de/album/<id>").
+func extractAlbumIDFromPath(path string) string {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range pathParts {
+		if part == "album" && i+1 < len(pathParts) {
+			return pathParts[i+1]
+		}
+	}
+	return ""
+}
+
+// GetAlbumTracks retrieves all tracks of an album in album order.
+func (c *Client) GetAlbumTracks(ctx context.Context, albumID string) ([]core.Track, error) {
+	if c.client == nil {
+		return nil, errors.New("client not authenticated")
+	}
+
+	album, err := doWithRetry(ctx, c, "get album", func(ctx context.Context) (*spotify.FullAlbum, error) {
+		return c.client.GetAlbum(ctx, spotify.ID(albumID), spotify.Market(c.market()))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get album: %w", err)
+	}
+
+	page := &album.Tracks
+	tracks := make([]core.Track, 0, page.Total)
+	for {
+		for i := range page.Tracks {
+			tracks = append(tracks, c.convertSpotifyTrack(&spotify.FullTrack{
+				SimpleTrack: page.Tracks[i],
+				Album:       album.SimpleAlbum,
+			}))
+		}
+
+		if len(page.Tracks) == 0 || len(tracks) >= page.Total {
+			break
+		}
+
+		offset := len(tracks)
+		page, err = doWithRetry(ctx, c, "get album tracks", func(ctx context.Context) (*spotify.SimpleTrackPage, error) {
+			return c.client.GetAlbumTracks(ctx, spotify.ID(albumID),
+				spotify.Limit(albumTracksPageSize), spotify.Offset(offset), spotify.Market(c.market()))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album tracks: %w", err)
+		}
+	}
+
+	c.logger.Debug("Retrieved album tracks",
+		zap.String("albumID", albumID),
+		zap.String("album", album.Name),
+		zap.Int("count", len(tracks)))
+
+	return tracks, nil
+}
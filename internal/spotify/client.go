@@ -14,6 +14,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/zmb3/spotify/v2"
@@ -26,10 +27,6 @@ import (
 	"djalgorhythm/pkg/text"
 )
 
-// Package-level random number generator for consistent random operations
-// #nosec G404 Music selection doesn't require crypto-secure randomness
-var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
-
 const (
 	// MinValidYear represents the minimum reasonable year for music tracks.
 	MinValidYear = 1950
@@ -51,10 +48,16 @@ const (
 	MaxTotalCandidates = 12
 	// MaxPlaylistsForCandidates limits playlists used for candidate track collection.
 	MaxPlaylistsForCandidates = 3
+	// ExplicitTrackPenalty is subtracted from the relevance score of explicit tracks in prefer-clean mode.
+	ExplicitTrackPenalty = 0.2
+	// percentScale converts the configured phonetic match percent to a weight.
+	percentScale = 100.0
 	// ReleaseDateYearLength is the expected length of a release date year string.
 	ReleaseDateYearLength = 4
 	// UnknownArtist is the default value when artist name is not available.
 	UnknownArtist = "Unknown"
+	// MaxVolumePercent is the highest playback volume accepted by SetVolume.
+	MaxVolumePercent = 100
 
 	// RepeatStateTrack represents the "track" repeat state.
 	RepeatStateTrack = "track"
@@ -69,6 +72,9 @@ const (
 	oauthHTTPReadTimeout    = 10 * time.Second
 	oauthHTTPWriteTimeout   = 10 * time.Second
 	oauthServerStartupDelay = 100 * time.Millisecond
+
+	// playlistDurationCacheTTL is how long a cached playlist duration is trusted without checking the snapshot ID.
+	playlistDurationCacheTTL = 30 * time.Second
 )
 
 var (
@@ -81,52 +87,104 @@ type Client struct {
 	config         *core.SpotifyConfig
 	logger         *zap.Logger
 	client         *spotify.Client
+	httpClient     *http.Client // Authenticated HTTP client for endpoints the API client lacks
 	normalizer     *fuzzy.Normalizer
 	auth           *spotifyauth.Authenticator
-	llm            core.LLMProvider // LLM provider for search query generation
-	targetPlaylist string           // Playlist ID we're managing
+	llm            core.LLMProvider     // LLM provider for search query generation
+	metrics        core.MetricsRecorder // Optional metrics recorder (nil disables)
+	targetPlaylist string               // Playlist ID we're managing
+
+	// Rate limit handling
+	maxRetries   int           // Maximum retries for rate-limited requests
+	maxRetryWait time.Duration // Longest wait before a single retry
+
+	// Token handling, refreshed tokens are saved and re-authorizations swap in a new token
+	tokenSource *persistingTokenSource
+	scopes      []string // Scopes granted with the saved token (nil if unknown)
+	reauthState string   // State of the pending re-authorization (empty if none)
+	reauthMutex sync.Mutex
+
+	// Playlist duration cache keyed by playlist ID, validated by snapshot ID
+	durationCache      map[string]playlistDurationCacheEntry
+	durationCacheMutex sync.Mutex
+
+	// Playlists sampled for candidate tracks in this session, avoided by later selections
+	playlistUsage playlistUsage
+
+	// Tracks that started playing recently, not recommended again within the recently played window
+	recentlyPlayed recentlyPlayed
+
+	// Tracks of the recommendation source playlists, fetched again once a playlist changes
+	sourcePlaylists sourcePlaylistCache
+
+	// Random source of track and playlist sampling, seeded from the time (tests use a fixed seed)
+	rng *rand.Rand
+}
+
+// playlistDurationCacheEntry holds a cached playlist duration for a specific playlist snapshot.
+type playlistDurationCacheEntry struct {
+	snapshotID string
+	duration   time.Duration
+	checkedAt  time.Time
 }
 
 // TokenData holds OAuth2 token information for Spotify authentication.
 type TokenData struct {
-	Token *oauth2.Token `json:"token"`
+	Token  *oauth2.Token `json:"token"`
+	Scopes []string      `json:"scopes,omitempty"` // Scopes granted with the token, empty for tokens saved before they were tracked
 }
 
 // NewClient creates a new Spotify client with the provided configuration, logger, and LLM provider.
-func NewClient(config *core.SpotifyConfig, logger *zap.Logger, llm core.LLMProvider) *Client {
+func NewClient(config *core.SpotifyConfig, logger *zap.Logger, llm core.LLMProvider,
+	metrics core.MetricsRecorder) *Client {
 	auth := spotifyauth.New(
 		spotifyauth.WithRedirectURL(config.RedirectURL),
-		spotifyauth.WithScopes(
-			spotifyauth.ScopePlaylistModifyPublic,
-			spotifyauth.ScopePlaylistModifyPrivate,
-			spotifyauth.ScopePlaylistReadPrivate,
-			spotifyauth.ScopeUserModifyPlaybackState,
-			spotifyauth.ScopeUserReadCurrentlyPlaying,
-			spotifyauth.ScopeUserReadPlaybackState,
-		),
+		spotifyauth.WithScopes(requiredScopes...),
 		spotifyauth.WithClientID(config.ClientID),
 		spotifyauth.WithClientSecret(config.ClientSecret),
 	)
 
 	return &Client{
-		config:     config,
-		logger:     logger,
-		normalizer: fuzzy.NewNormalizer(),
-		auth:       auth,
-		llm:        llm,
+		config:        config,
+		logger:        logger,
+		normalizer:    newNormalizer(config),
+		auth:          auth,
+		llm:           llm,
+		metrics:       metrics,
+		maxRetries:    core.DefaultMaxRetries,
+		maxRetryWait:  core.DefaultMaxRetryWaitSecs * time.Second,
+		durationCache: make(map[string]playlistDurationCacheEntry),
+		rng:           rand.New(rand.NewSource(time.Now().UnixNano())), // #nosec G404 Music selection isn't security relevant
 	}
 }
 
-// searchWithFiltering performs a Spotify search and filters out empty/invalid results.
+// newNormalizer creates the normalizer ranking search results, weighting how titles sound as configured.
+func newNormalizer(config *core.SpotifyConfig) *fuzzy.Normalizer {
+	normalizer := fuzzy.NewNormalizer()
+	normalizer.SetPhoneticWeight(float64(config.PhoneticMatchPercent) / percentScale)
+	return normalizer
+}
+
+// market returns the configured market, or the market of the authenticated user when unset.
+func (c *Client) market() string {
+	if c.config.Market == "" {
+		return spotify.MarketFromToken
+	}
+	return c.config.Market
+}
+
+// searchWithFiltering performs a Spotify search in the given market and filters out empty/invalid results.
 func (c *Client) searchWithFiltering(ctx context.Context, query string,
-	searchType spotify.SearchType) (*spotify.SearchResult, error) {
+	searchType spotify.SearchType, market string) (*spotify.SearchResult, error) {
 	if c.client == nil {
 		return nil, errors.New("client not authenticated")
 	}
 
 	normalizedQuery := c.normalizer.NormalizeTitle(query)
 
-	results, err := c.client.Search(ctx, normalizedQuery, searchType)
+	results, err := doWithRetry(ctx, c, "search", func(ctx context.Context) (*spotify.SearchResult, error) {
+		return c.client.Search(ctx, normalizedQuery, searchType, spotify.Market(market))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
@@ -202,6 +260,27 @@ func (c *Client) filterEmptyPlaylists(results *spotify.SearchResult) {
 	results.Playlists.Playlists = validPlaylists
 }
 
+// filterUnplayableTracks removes tracks Spotify reports as not playable in the market from search results.
+// Tracks without playability information are kept.
+func (c *Client) filterUnplayableTracks(results *spotify.SearchResult, market string) {
+	if results.Tracks == nil {
+		return
+	}
+
+	playableTracks := make([]spotify.FullTrack, 0, len(results.Tracks.Tracks))
+	for i := range results.Tracks.Tracks {
+		track := &results.Tracks.Tracks[i]
+		if track.IsPlayable != nil && !*track.IsPlayable {
+			c.logger.Debug("Skipping track not playable in market",
+				zap.String("trackID", string(track.ID)),
+				zap.String("market", market))
+			continue
+		}
+		playableTracks = append(playableTracks, *track)
+	}
+	results.Tracks.Tracks = playableTracks
+}
+
 // logFilterResults logs the filtering results if any items were skipped.
 func (c *Client) logFilterResults(itemType string, skippedCount, validCount int) {
 	if skippedCount > 0 {
@@ -286,31 +365,57 @@ func (c *Client) filterEmptyEpisodes(results *spotify.SearchResult) {
 
 // Authenticate authenticates the client with Spotify using stored or new OAuth2 tokens.
 func (c *Client) Authenticate(ctx context.Context) error {
-	token, err := c.loadToken()
-	if err != nil {
+	if _, err := c.loadToken(); err != nil {
 		c.logger.Info("No saved token found, starting OAuth flow")
 		return c.startOAuthFlow(ctx)
 	}
 
-	client := spotify.New(c.auth.Client(ctx, token))
+	if _, err := c.AuthenticateWithSavedToken(ctx); err != nil {
+		c.logger.Warn("Saved token invalid, starting OAuth flow", zap.Error(err))
+		return c.startOAuthFlow(ctx)
+	}
+	return nil
+}
+
+// AuthenticateWithSavedToken authenticates the client with the stored OAuth2 token only,
+// failing instead of starting the interactive OAuth flow if there is no valid token.
+// It returns the display name of the authenticated user.
+func (c *Client) AuthenticateWithSavedToken(ctx context.Context) (string, error) {
+	token, err := c.loadToken()
+	if err != nil {
+		return "", fmt.Errorf("no saved token at %s: %w", c.config.TokenPath, err)
+	}
+
+	if err := checkScopes(c.scopes); err != nil {
+		return "", err
+	}
+
+	client := c.useToken(ctx, token)
 	c.client = client
 
 	user, err := client.CurrentUser(ctx)
 	if err != nil {
-		c.logger.Warn("Saved token invalid, starting OAuth flow", zap.Error(err))
-		return c.startOAuthFlow(ctx)
+		return "", fmt.Errorf("saved token invalid: %w", err)
 	}
 
 	c.logger.Info("Authenticated successfully", zap.String("user", user.DisplayName))
-	return nil
+	return user.DisplayName, nil
 }
 
-// SearchTrack searches for tracks on Spotify using the provided query string.
+// SearchTrack searches for tracks on Spotify using the provided query string,
+// limited to tracks playable in the configured market.
 func (c *Client) SearchTrack(ctx context.Context, query string) ([]core.Track, error) {
-	results, err := c.searchWithFiltering(ctx, query, spotify.SearchTypeTrack)
+	return c.SearchTrackWithMarket(ctx, query, c.market())
+}
+
+// SearchTrackWithMarket searches for tracks playable in the given market
+// (an ISO country code, or spotify.MarketFromToken for the user's country).
+func (c *Client) SearchTrackWithMarket(ctx context.Context, query, market string) ([]core.Track, error) {
+	results, err := c.searchWithFiltering(ctx, query, spotify.SearchTypeTrack, market)
 	if err != nil {
 		return nil, err
 	}
+	c.filterUnplayableTracks(results, market)
 
 	if results.Tracks == nil || len(results.Tracks.Tracks) == 0 {
 		return nil, errors.New("no tracks found")
@@ -342,7 +447,7 @@ func (c *Client) SearchTrackByISRC(ctx context.Context, isrc string) (*core.Trac
 	// Build ISRC search query.
 	query := "isrc:" + isrc
 
-	results, err := c.searchWithFiltering(ctx, query, spotify.SearchTypeTrack)
+	results, err := c.searchWithFiltering(ctx, query, spotify.SearchTypeTrack, c.market())
 	if err != nil {
 		return nil, fmt.Errorf("ISRC search failed: %w", err)
 	}
@@ -372,7 +477,7 @@ func (c *Client) SearchTrackByTitleArtist(ctx context.Context, title, artist str
 	// Build query combining title and artist.
 	query := fmt.Sprintf("%s %s", title, artist)
 
-	results, err := c.searchWithFiltering(ctx, query, spotify.SearchTypeTrack)
+	results, err := c.searchWithFiltering(ctx, query, spotify.SearchTypeTrack, c.market())
 	if err != nil {
 		return nil, fmt.Errorf("title/artist search failed: %w", err)
 	}
@@ -388,7 +493,7 @@ func (c *Client) SearchTrackByTitleArtist(ctx context.Context, title, artist str
 
 // SearchPlaylist searches for playlists based on a query string.
 func (c *Client) SearchPlaylist(ctx context.Context, query string) ([]core.Playlist, error) {
-	results, err := c.searchWithFiltering(ctx, query, spotify.SearchTypePlaylist)
+	results, err := c.searchWithFiltering(ctx, query, spotify.SearchTypePlaylist, c.market())
 	if err != nil {
 		return nil, fmt.Errorf("playlist search failed: %w", err)
 	}
@@ -430,7 +535,7 @@ func (c *Client) GetTrack(ctx context.Context, trackID string) (*core.Track, err
 		return nil, errors.New("client not authenticated")
 	}
 
-	track, err := c.client.GetTrack(ctx, spotify.ID(trackID))
+	track, err := c.client.GetTrack(ctx, spotify.ID(trackID), spotify.Market(c.market()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get track: %w", err)
 	}
@@ -453,9 +558,12 @@ func (c *Client) AddToPlaylistAtPosition(ctx context.Context, playlistID, trackI
 	spotifyTrackID := spotify.ID(trackID)
 	spotifyPlaylistID := spotify.ID(playlistID)
 
+	// Any addition changes the playlist, even if the later reorder fails
+	defer c.invalidatePlaylistDuration(playlistID)
+
 	if position < 0 {
 		// Add to end of playlist (existing behavior)
-		_, err := c.client.AddTracksToPlaylist(ctx, spotifyPlaylistID, spotifyTrackID)
+		_, err := c.addTracksToPlaylistWithRetry(ctx, spotifyPlaylistID, spotifyTrackID)
 		if err != nil {
 			return fmt.Errorf("failed to add track to playlist: %w", err)
 		}
@@ -469,13 +577,13 @@ func (c *Client) AddToPlaylistAtPosition(ctx context.Context, playlistID, trackI
 
 	// For specific positions, we need to add then reorder
 	// Step 1: Add track to end of playlist
-	_, err := c.client.AddTracksToPlaylist(ctx, spotifyPlaylistID, spotifyTrackID)
+	_, err := c.addTracksToPlaylistWithRetry(ctx, spotifyPlaylistID, spotifyTrackID)
 	if err != nil {
 		return fmt.Errorf("failed to add track to playlist: %w", err)
 	}
 
 	// Step 2: Get current playlist length to know where the track was added
-	items, err := c.client.GetPlaylistItems(ctx, spotifyPlaylistID, spotify.Limit(1))
+	items, err := c.getPlaylistItemsWithRetry(ctx, spotifyPlaylistID, spotify.Limit(1))
 	if err != nil {
 		// Track was added but we can't reorder - this is still a success
 		c.logger.Warn("Track added but failed to get playlist info for reordering",
@@ -510,6 +618,67 @@ func (c *Client) AddToPlaylistAtPosition(ctx context.Context, playlistID, trackI
 	return nil
 }
 
+// RemoveFromPlaylist removes all occurrences of a track from the specified playlist.
+func (c *Client) RemoveFromPlaylist(ctx context.Context, playlistID, trackID string) error {
+	if c.client == nil {
+		return errors.New("client not authenticated")
+	}
+
+	_, err := c.client.RemoveTracksFromPlaylist(ctx, spotify.ID(playlistID), spotify.ID(trackID))
+	if err != nil {
+		return fmt.Errorf("failed to remove track from playlist: %w", err)
+	}
+
+	c.invalidatePlaylistDuration(playlistID)
+
+	c.logger.Info("Track removed from playlist",
+		zap.String("trackID", trackID),
+		zap.String("playlistID", playlistID))
+
+	return nil
+}
+
+// RemoveFromPlaylistAtPositions removes the occurrences of a track at the given playlist positions,
+// keeping its other occurrences. Spotify rejects the request if the track isn't at all of the positions.
+func (c *Client) RemoveFromPlaylistAtPositions(ctx context.Context, playlistID, trackID string, positions []int) error {
+	if c.client == nil {
+		return errors.New("client not authenticated")
+	}
+
+	_, err := doWithRetry(ctx, c, "remove tracks from playlist", func(ctx context.Context) (string, error) {
+		return c.client.RemoveTracksFromPlaylistOpt(ctx, spotify.ID(playlistID),
+			[]spotify.TrackToRemove{spotify.NewTrackToRemove(trackID, positions)}, "")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove track from playlist positions: %w", err)
+	}
+
+	c.invalidatePlaylistDuration(playlistID)
+
+	c.logger.Info("Track removed from playlist positions",
+		zap.String("trackID", trackID),
+		zap.String("playlistID", playlistID),
+		zap.Ints("positions", positions))
+
+	return nil
+}
+
+// addTracksToPlaylistWithRetry adds tracks to a playlist, retrying on rate limits.
+func (c *Client) addTracksToPlaylistWithRetry(ctx context.Context, playlistID spotify.ID,
+	trackIDs ...spotify.ID) (string, error) {
+	return doWithRetry(ctx, c, "add tracks to playlist", func(ctx context.Context) (string, error) {
+		return c.client.AddTracksToPlaylist(ctx, playlistID, trackIDs...)
+	})
+}
+
+// getPlaylistItemsWithRetry fetches a page of playlist items, retrying on rate limits.
+func (c *Client) getPlaylistItemsWithRetry(ctx context.Context, playlistID spotify.ID,
+	opts ...spotify.RequestOption) (*spotify.PlaylistItemPage, error) {
+	return doWithRetry(ctx, c, "get playlist items", func(ctx context.Context) (*spotify.PlaylistItemPage, error) {
+		return c.client.GetPlaylistItems(ctx, playlistID, opts...)
+	})
+}
+
 // AddToQueue adds a track to the user's Spotify playback queue.
 func (c *Client) AddToQueue(ctx context.Context, trackID string) error {
 	if c.client == nil {
@@ -518,7 +687,9 @@ func (c *Client) AddToQueue(ctx context.Context, trackID string) error {
 
 	spotifyTrackID := spotify.ID(trackID)
 
-	err := c.client.QueueSong(ctx, spotifyTrackID)
+	_, err := doWithRetry(ctx, c, "queue song", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.client.QueueSong(ctx, spotifyTrackID)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to add track to queue: %w", err)
 	}
@@ -617,8 +788,11 @@ func (c *Client) checkSettingsCompliance(state *spotify.PlayerState, compliance
 	}
 }
 
-// GetRecommendedTrack gets a track ID using LLM-enhanced search based on recent playlist tracks.
-func (c *Client) GetRecommendedTrack(ctx context.Context) (trackID, searchQuery, newTrackMood string, err error) {
+// GetRecommendedTrack gets a track ID based on recent playlist tracks, using the configured recommendation strategy.
+// The LLM-generated mood of the recent tracks is the playlist search query, or seeds genres for audio features.
+// Searched candidates are also ranked by how smoothly they follow previousTrackID, the last queued track.
+func (c *Client) GetRecommendedTrack(ctx context.Context, previousTrackID string) (trackID, searchQuery,
+	newTrackMood string, err error) {
 	if c.client == nil {
 		return "", "", "", errors.New("client not authenticated")
 	}
@@ -630,7 +804,7 @@ func (c *Client) GetRecommendedTrack(ctx context.Context) (trackID, searchQuery,
 	}
 
 	if len(playlistTracks) == 0 {
-		return "", "", "", errors.New("playlist is empty, cannot generate recommendations")
+		return c.findFallbackGenreTrack(ctx)
 	}
 
 	// Get recent tracks for search context (simple approach)
@@ -640,7 +814,7 @@ func (c *Client) GetRecommendedTrack(ctx context.Context) (trackID, searchQuery,
 	searchQuery = c.generateSearchQuery(ctx, recentTracks)
 
 	// Find and return track along with search query
-	trackID, err = c.findTrackFromSearch(ctx, searchQuery, playlistTracks)
+	trackID, err = c.findRecommendedTrack(ctx, searchQuery, previousTrackID, recentTracks, playlistTracks)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -651,6 +825,42 @@ func (c *Client) GetRecommendedTrack(ctx context.Context) (trackID, searchQuery,
 	return trackID, searchQuery, newTrackMood, nil
 }
 
+// findFallbackGenreTrack bootstraps an empty playlist with a random track found for the configured fallback genre,
+// as there are no recent tracks to base recommendations on. Returns core.ErrPlaylistEmpty without a fallback genre.
+func (c *Client) findFallbackGenreTrack(ctx context.Context) (trackID, searchQuery, newTrackMood string, err error) {
+	searchQuery = c.config.FallbackGenre
+	if searchQuery == "" {
+		return "", "", "", core.ErrPlaylistEmpty
+	}
+
+	tracks, err := c.SearchTrack(ctx, searchQuery)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to search fallback genre %q: %w", searchQuery, err)
+	}
+
+	trackID = tracks[c.rng.Intn(len(tracks))].ID
+	c.logger.Info("Playlist is empty, seeding it with a fallback genre track",
+		zap.String("fallbackGenre", searchQuery),
+		zap.String("trackID", trackID))
+
+	return trackID, searchQuery, c.generateNewTrackMood(ctx, trackID), nil
+}
+
+// findRecommendedTrack finds a track with the configured strategy. Recommendations by audio features fall back
+// to the playlist search, since Spotify restricts these endpoints for some apps.
+func (c *Client) findRecommendedTrack(ctx context.Context, searchQuery, previousTrackID string,
+	recentTracks, playlistTracks []core.Track) (string, error) {
+	if c.config.RecommendationStrategy == core.RecommendationStrategyAudioFeatures {
+		trackID, err := c.findTrackFromRecommendations(ctx, recentTracks, searchQuery, playlistTracks)
+		if err == nil {
+			return trackID, nil
+		}
+		c.logger.Warn("Failed to get recommendations by audio features, searching playlists instead", zap.Error(err))
+	}
+
+	return c.findTrackFromSearch(ctx, searchQuery, previousTrackID, playlistTracks)
+}
+
 // generateSearchQuery generates a search query using LLM or falls back to default.
 func (c *Client) generateSearchQuery(ctx context.Context, recentTracks []core.Track) string {
 	if c.llm != nil && len(recentTracks) > 0 {
@@ -665,7 +875,8 @@ func (c *Client) generateSearchQuery(ctx context.Context, recentTracks []core.Tr
 	return DefaultPlaylistSearchQuery
 }
 
-// generateNewTrackMood generates mood for a new track or returns a fallback.
+// generateNewTrackMood generates mood for a new track with the LLM, or describes its audio features without one.
+// Returns a fallback if neither is available.
 func (c *Client) generateNewTrackMood(ctx context.Context, trackID string) string {
 	newTrack, err := c.GetTrack(ctx, trackID)
 	if err != nil {
@@ -676,13 +887,18 @@ func (c *Client) generateNewTrackMood(ctx context.Context, trackID string) strin
 
 	if c.llm != nil {
 		mood, err := c.llm.GenerateTrackMood(ctx, []core.Track{*newTrack})
-		if err != nil {
-			c.logger.Warn("Failed to generate new track mood, using fallback", zap.Error(err))
-			return DefaultPlaylistSearchQuery
+		if err == nil {
+			return mood
 		}
-		return mood
+		c.logger.Warn("Failed to generate new track mood, describing audio features instead", zap.Error(err))
 	}
-	return DefaultPlaylistSearchQuery
+
+	features, err := c.GetAudioFeatures(ctx, trackID)
+	if err != nil {
+		c.logger.Debug("Failed to get audio features for mood, using fallback", zap.Error(err))
+		return DefaultPlaylistSearchQuery
+	}
+	return features.Mood()
 }
 
 // getRecentTracksForSearch extracts recent tracks for LLM context (simplified).
@@ -707,11 +923,8 @@ func (c *Client) collectCandidateTracksFromPlaylists(
 	playlistTracks []core.Track,
 	maxCandidates int,
 ) ([]core.Track, error) {
-	// Build exclusion set from target playlist tracks
-	exclude := make(map[string]struct{}, len(playlistTracks))
-	for _, track := range playlistTracks {
-		exclude[track.ID] = struct{}{}
-	}
+	// Build exclusion set from target playlist tracks and recently played tracks
+	exclude := c.candidateExclusions(playlistTracks)
 
 	// Track seen tracks to avoid duplicates across playlists
 	const seenCapacityMultiplier = 2
@@ -759,7 +972,12 @@ func (c *Client) collectCandidateTracksFromPlaylists(
 	return candidates, nil
 }
 
-// selectRandomPlaylists selects up to maxCount playlists with bias towards earlier playlists.
+// selectRandomPlaylists selects up to maxCount playlists with bias towards earlier playlists
+// and away from playlists sampled earlier in the session.
+//
+// The playlist at search position i used n times before has the weight exp(-0.5 * i) / (1 + n).
+// Unused, the second result has 61% of the first result's weight; once the first result was used,
+// its weight halves to 50% and the second result becomes the likelier pick.
 func (c *Client) selectRandomPlaylists(playlists []core.Playlist, maxCount int) []core.Playlist {
 	if len(playlists) <= maxCount {
 		return playlists // Return all if we have fewer than maxCount
@@ -768,11 +986,11 @@ func (c *Client) selectRandomPlaylists(playlists []core.Playlist, maxCount int)
 	// Use weighted selection biased towards earlier playlists
 	const decayFactor = 0.5 // Controls bias strength - higher values = more bias
 
-	// Calculate weights using exponential decay
+	// Calculate weights using exponential decay, reduced by the session usage
 	weights := make([]float64, len(playlists))
 	totalWeight := 0.0
 	for i := range playlists {
-		weight := math.Exp(-float64(i) * decayFactor)
+		weight := math.Exp(-float64(i)*decayFactor) * c.playlistUsage.usageWeight(playlists[i].ID)
 		weights[i] = weight
 		totalWeight += weight
 	}
@@ -783,7 +1001,7 @@ func (c *Client) selectRandomPlaylists(playlists []core.Playlist, maxCount int)
 
 	for len(selected) < maxCount {
 		// Generate random value in [0, totalWeight)
-		r := rng.Float64() * totalWeight
+		r := c.rng.Float64() * totalWeight
 
 		// Find the selected playlist
 		cumWeight := 0.0
@@ -805,8 +1023,17 @@ func (c *Client) selectRandomPlaylists(playlists []core.Playlist, maxCount int)
 }
 
 // findTrackFromSearch searches for playlists and uses AI to select the best matching track.
-func (c *Client) findTrackFromSearch(ctx context.Context, searchQuery string,
+// Configured source playlists are preferred, the search is only used once they are exhausted.
+func (c *Client) findTrackFromSearch(ctx context.Context, searchQuery, previousTrackID string,
 	playlistTracks []core.Track) (string, error) {
+	if len(c.config.RecommendationSourcePlaylists) > 0 {
+		trackID, err := c.findTrackFromSourcePlaylists(ctx, searchQuery, previousTrackID, playlistTracks)
+		if err == nil {
+			return trackID, nil
+		}
+		c.logger.Warn("No track from source playlists, searching playlists instead", zap.Error(err))
+	}
+
 	// Search for playlists
 	playlists, err := c.SearchPlaylist(ctx, searchQuery)
 	if err != nil {
@@ -819,6 +1046,7 @@ func (c *Client) findTrackFromSearch(ctx context.Context, searchQuery string,
 
 	// Randomly select up to MaxPlaylistsForCandidates playlists for variety and performance
 	selectedPlaylists := c.selectRandomPlaylists(playlists, MaxPlaylistsForCandidates)
+	c.playlistUsage.record(selectedPlaylists)
 
 	// Collect candidate tracks from selected playlists
 	candidates, err := c.collectCandidateTracksFromPlaylists(ctx, selectedPlaylists, playlistTracks, MaxTotalCandidates)
@@ -830,8 +1058,19 @@ func (c *Client) findTrackFromSearch(ctx context.Context, searchQuery string,
 		return "", fmt.Errorf("no candidate tracks found in any of the %d playlists", len(playlists))
 	}
 
-	// Use AI to rank candidates based on search query relevance
-	rankedTracks := c.llm.RankTracks(ctx, searchQuery, candidates)
+	return c.selectRankedTrack(ctx, searchQuery, previousTrackID, candidates)
+}
+
+// selectRankedTrack uses AI to rank the candidates and returns the top-ranked track. Candidates are ranked
+// by the search query and, if the audio features of the previous track are known, a smooth transition from it.
+func (c *Client) selectRankedTrack(ctx context.Context, searchQuery, previousTrackID string,
+	candidates []core.Track) (string, error) {
+	var rankedTracks []core.Track
+	if previousFeatures := c.trackFeatures(ctx, previousTrackID); previousFeatures != nil {
+		rankedTracks = c.llm.RankTracksForTransition(ctx, searchQuery, previousFeatures, candidates)
+	} else {
+		rankedTracks = c.llm.RankTracks(ctx, searchQuery, candidates)
+	}
 
 	if len(rankedTracks) == 0 {
 		return "", errors.New("no ranked tracks available")
@@ -851,6 +1090,25 @@ func (c *Client) findTrackFromSearch(ctx context.Context, searchQuery string,
 	return selectedTrack.ID, nil
 }
 
+// trackFeatures returns the audio features of the track, or nil if unavailable.
+func (c *Client) trackFeatures(ctx context.Context, trackID string) *core.AudioFeatures {
+	if trackID == "" {
+		return nil
+	}
+
+	features, err := c.GetAudioFeatures(ctx, trackID)
+	if err != nil {
+		c.logger.Debug("No audio features of the previous track, ranking by search query",
+			zap.String("trackID", trackID),
+			zap.Error(err))
+		return nil
+	}
+	if features.Tempo <= 0 {
+		return nil // Spotify couldn't analyze the track
+	}
+	return features
+}
+
 // GetPlaylistTracksWithDetails gets full track objects from a playlist (avoids N+1 API calls).
 func (c *Client) GetPlaylistTracksWithDetails(ctx context.Context, playlistID string) ([]core.Track, error) {
 	if c.client == nil {
@@ -863,7 +1121,7 @@ func (c *Client) GetPlaylistTracksWithDetails(ctx context.Context, playlistID st
 	offset := 0
 
 	for {
-		items, err := c.client.GetPlaylistItems(ctx, spotifyPlaylistID,
+		items, err := c.getPlaylistItemsWithRetry(ctx, spotifyPlaylistID,
 			spotify.Limit(limit), spotify.Offset(offset))
 		if err != nil {
 			return nil, fmt.Errorf("failed to get playlist items: %w", err)
@@ -891,6 +1149,120 @@ func (c *Client) GetPlaylistTracksWithDetails(ctx context.Context, playlistID st
 	return allTracks, nil
 }
 
+// GetPlaylistDuration returns the total duration of all tracks in a playlist.
+// Results are cached per playlist snapshot: within the TTL the cache is used as-is,
+// afterwards only the snapshot ID is fetched and the tracks are re-read if it changed.
+func (c *Client) GetPlaylistDuration(ctx context.Context, playlistID string) (time.Duration, error) {
+	if c.client == nil {
+		return 0, errors.New("client not authenticated")
+	}
+
+	c.durationCacheMutex.Lock()
+	cached, ok := c.durationCache[playlistID]
+	c.durationCacheMutex.Unlock()
+
+	if ok && time.Since(cached.checkedAt) < playlistDurationCacheTTL {
+		return cached.duration, nil
+	}
+
+	playlist, err := doWithRetry(ctx, c, "get playlist snapshot", func(ctx context.Context) (*spotify.FullPlaylist, error) {
+		return c.client.GetPlaylist(ctx, spotify.ID(playlistID), spotify.Fields("snapshot_id"))
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get playlist snapshot: %w", err)
+	}
+
+	if ok && cached.snapshotID == playlist.SnapshotID {
+		c.storePlaylistDuration(playlistID, cached.snapshotID, cached.duration)
+		return cached.duration, nil
+	}
+
+	tracks, err := c.GetPlaylistTracksWithDetails(ctx, playlistID)
+	if err != nil {
+		return 0, err
+	}
+
+	var duration time.Duration
+	for i := range tracks {
+		duration += tracks[i].Duration
+	}
+
+	c.storePlaylistDuration(playlistID, playlist.SnapshotID, duration)
+
+	c.logger.Debug("Calculated playlist duration",
+		zap.String("playlistID", playlistID),
+		zap.String("snapshotID", playlist.SnapshotID),
+		zap.Duration("duration", duration))
+
+	return duration, nil
+}
+
+// PlaylistExists reports whether the playlist can still be read with the current credentials.
+// A deleted or inaccessible playlist returns false without an error; other failures return an error.
+func (c *Client) PlaylistExists(ctx context.Context, playlistID string) (bool, error) {
+	if c.client == nil {
+		return false, errors.New("client not authenticated")
+	}
+
+	_, err := doWithRetry(ctx, c, "get playlist", func(ctx context.Context) (*spotify.FullPlaylist, error) {
+		return c.client.GetPlaylist(ctx, spotify.ID(playlistID), spotify.Fields("id"))
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	var spotifyErr spotify.Error
+	if errors.As(err, &spotifyErr) &&
+		(spotifyErr.Status == http.StatusNotFound || spotifyErr.Status == http.StatusForbidden) {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check playlist: %w", err)
+}
+
+// CanModifyPlaylist reports whether the authenticated user can add tracks to the playlist,
+// which requires owning it or the playlist being collaborative.
+func (c *Client) CanModifyPlaylist(ctx context.Context, playlistID string) (bool, error) {
+	if c.client == nil {
+		return false, errors.New("client not authenticated")
+	}
+
+	user, err := doWithRetry(ctx, c, "get current user", func(ctx context.Context) (*spotify.PrivateUser, error) {
+		return c.client.CurrentUser(ctx)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	playlist, err := doWithRetry(ctx, c, "get playlist", func(ctx context.Context) (*spotify.FullPlaylist, error) {
+		return c.client.GetPlaylist(ctx, spotify.ID(playlistID), spotify.Fields("owner(id),collaborative"))
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get playlist: %w", err)
+	}
+
+	return playlist.Owner.ID == user.ID || playlist.Collaborative, nil
+}
+
+// storePlaylistDuration caches a playlist duration for the given snapshot.
+func (c *Client) storePlaylistDuration(playlistID, snapshotID string, duration time.Duration) {
+	c.durationCacheMutex.Lock()
+	defer c.durationCacheMutex.Unlock()
+
+	c.durationCache[playlistID] = playlistDurationCacheEntry{
+		snapshotID: snapshotID,
+		duration:   duration,
+		checkedAt:  time.Now(),
+	}
+}
+
+// invalidatePlaylistDuration drops the cached duration after we mutated the playlist.
+func (c *Client) invalidatePlaylistDuration(playlistID string) {
+	c.durationCacheMutex.Lock()
+	defer c.durationCacheMutex.Unlock()
+
+	delete(c.durationCache, playlistID)
+}
+
 // GetRandomPlaylistTracks efficiently samples up to n tracks from a playlist.
 func (c *Client) GetRandomPlaylistTracks(
 	ctx context.Context,
@@ -928,7 +1300,7 @@ func (c *Client) generateSamplingPages(total, want int) map[int][]int {
 
 	idxSet := make(map[int]struct{}, budget)
 	for len(idxSet) < budget {
-		idxSet[rng.Intn(total)] = struct{}{}
+		idxSet[c.rng.Intn(total)] = struct{}{}
 	}
 
 	// Page coalescing: group indices by page
@@ -960,7 +1332,7 @@ func (c *Client) fetchTracksFromPages(
 			return tracks, ctx.Err()
 		}
 
-		items, err := c.client.GetPlaylistItems(ctx, spotifyPlaylistID,
+		items, err := c.getPlaylistItemsWithRetry(ctx, spotifyPlaylistID,
 			spotify.Limit(pageSize), spotify.Offset(page*pageSize))
 		if err != nil {
 			c.logger.Debug("Sampler page fetch failed",
@@ -1153,14 +1525,38 @@ func (c *Client) convertSpotifyTrack(track *spotify.FullTrack) core.Track {
 		}
 	}
 
+	// Full track objects carry the ISRC in a map, simplified ones (album tracks) in a struct
+	isrc := track.ExternalIDs["isrc"]
+	if isrc == "" {
+		isrc = track.SimpleTrack.ExternalIDs.ISRC
+	}
+
+	// Spotify lists the album images largest first
+	var imageURL string
+	if len(track.Album.Images) > 0 {
+		imageURL = track.Album.Images[0].URL
+	}
+
+	// Playability and relinking are only reported for requests with a market
+	var linkedFromID string
+	if track.LinkedFrom != nil {
+		linkedFromID = string(track.LinkedFrom.ID)
+	}
+
 	return core.Track{
-		ID:       string(track.ID),
-		Title:    track.Name,
-		Artist:   strings.Join(artists, ", "),
-		Album:    track.Album.Name,
-		Year:     year,
-		Duration: time.Duration(track.Duration) * time.Millisecond,
-		URL:      track.ExternalURLs["spotify"],
+		ID:           string(track.ID),
+		Title:        track.Name,
+		Artist:       strings.Join(artists, ", "),
+		Album:        track.Album.Name,
+		Year:         year,
+		Duration:     time.Duration(track.Duration) * time.Millisecond,
+		URL:          track.ExternalURLs["spotify"],
+		PreviewURL:   track.PreviewURL,
+		ImageURL:     imageURL,
+		Explicit:     track.Explicit,
+		ISRC:         isrc,
+		Unplayable:   track.IsPlayable != nil && !*track.IsPlayable,
+		LinkedFromID: linkedFromID,
 	}
 }
 
@@ -1176,6 +1572,7 @@ func (c *Client) rankTracks(tracks []core.Track, originalQuery string) []core.Tr
 
 	for _, track := range tracks {
 		score := c.calculateRelevanceScore(&track, normalizedQuery)
+		track.Relevance = score
 		scored = append(scored, scoredTrack{track: track, score: score})
 	}
 
@@ -1216,6 +1613,11 @@ func (c *Client) calculateRelevanceScore(track *core.Track, normalizedQuery stri
 		score += 0.05
 	}
 
+	// Deprioritize explicit tracks so a clean version wins on similar matches
+	if c.config.PreferClean && track.Explicit {
+		score -= ExplicitTrackPenalty
+	}
+
 	return score
 }
 
@@ -1225,7 +1627,8 @@ func (c *Client) startOAuthFlow(ctx context.Context) error {
 	// Start temporary callback server
 	codeChan := make(chan string, 1)
 	errChan := make(chan error, 1)
-	server := c.startCallbackServer(codeChan, errChan, state)
+	authURL := c.auth.AuthURL(state)
+	server := c.startCallbackServer(codeChan, errChan, state, authURL)
 
 	// Ensure server cleanup
 	//nolint:contextcheck // Cleanup must complete even if parent canceled.
@@ -1237,11 +1640,11 @@ func (c *Client) startOAuthFlow(ctx context.Context) error {
 		}
 	}()
 
-	authURL := c.auth.AuthURL(state)
 	fmt.Printf("\n🔐 Spotify Authorization Required\n")
 	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 	fmt.Printf("Please visit the following URL to authorize:\n\n")
 	fmt.Printf("  %s\n\n", authURL)
+	c.printOAuthQR(authURL)
 	fmt.Printf("Waiting for authorization...\n")
 	fmt.Printf("(The browser will redirect to 127.0.0.1:8080/callback)\n")
 	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
@@ -1261,8 +1664,11 @@ func (c *Client) startOAuthFlow(ctx context.Context) error {
 }
 
 // startCallbackServer starts a temporary HTTP server to receive OAuth callback.
-func (c *Client) startCallbackServer(codeChan chan<- string, errChan chan<- error, expectedState string) *http.Server {
+func (c *Client) startCallbackServer(
+	codeChan chan<- string, errChan chan<- error, expectedState, authURL string,
+) *http.Server {
 	mux := http.NewServeMux()
+	c.handleOAuthQR(mux, authURL)
 
 	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
 		// Validate state parameter
@@ -1343,7 +1749,13 @@ func (c *Client) startCallbackServer(codeChan chan<- string, errChan chan<- erro
 	// Start server in background
 	go func() {
 		c.logger.Debug("Starting temporary OAuth callback server", zap.String("addr", addr))
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if parsedURL.Scheme == "https" && c.config.OAuthTLSCertFile != "" {
+			err = server.ListenAndServeTLS(c.config.OAuthTLSCertFile, c.config.OAuthTLSKeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			errChan <- fmt.Errorf("callback server error: %w", err)
 		}
 	}()
@@ -1361,13 +1773,12 @@ func (c *Client) completeOAuthFlow(ctx context.Context, code string) error {
 		return fmt.Errorf("failed to exchange code for token: %w", err)
 	}
 
+	client := c.useToken(ctx, token)
+	c.client = client
 	if saveErr := c.saveToken(token); saveErr != nil {
 		c.logger.Warn("Failed to save token", zap.Error(saveErr))
 	}
 
-	client := spotify.New(c.auth.Client(ctx, token))
-	c.client = client
-
 	user, err := client.CurrentUser(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get current user: %w", err)
@@ -1397,11 +1808,16 @@ func (c *Client) loadToken() (*oauth2.Token, error) {
 		return nil, err
 	}
 
+	c.scopes = tokenData.Scopes
 	return tokenData.Token, nil
 }
 
 func (c *Client) saveToken(token *oauth2.Token) error {
-	tokenData := TokenData{Token: token}
+	// Refresh responses may not list the scopes, which then stay those of the authorization
+	if scopes := grantedScopes(token); scopes != nil {
+		c.scopes = scopes
+	}
+	tokenData := TokenData{Token: token, Scopes: c.scopes}
 
 	data, err := json.MarshalIndent(tokenData, "", "  ")
 	if err != nil {
@@ -1598,3 +2014,155 @@ func (c *Client) HasActiveDevice(ctx context.Context) (bool, error) {
 		zap.Int("totalDevices", len(devices)))
 	return false, nil
 }
+
+// ListDevices returns the Spotify Connect devices available to the user.
+func (c *Client) ListDevices(ctx context.Context) ([]core.Device, error) {
+	if c.client == nil {
+		return nil, errors.New("spotify client not initialized")
+	}
+
+	devices, err := c.client.PlayerDevices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player devices: %w", err)
+	}
+
+	result := make([]core.Device, 0, len(devices))
+	for _, device := range devices {
+		result = append(result, core.Device{
+			ID:     device.ID.String(),
+			Name:   device.Name,
+			Type:   device.Type,
+			Active: device.Active,
+		})
+	}
+
+	return result, nil
+}
+
+// TransferPlayback transfers playback to the given device.
+// If play is false, the current playback state (playing or paused) is kept.
+func (c *Client) TransferPlayback(ctx context.Context, deviceID string, play bool) error {
+	if c.client == nil {
+		return errors.New("spotify client not initialized")
+	}
+
+	if err := c.client.TransferPlayback(ctx, spotify.ID(deviceID), play); err != nil {
+		return fmt.Errorf("failed to transfer playback: %w", err)
+	}
+
+	c.logger.Debug("Transferred playback",
+		zap.String("deviceID", deviceID),
+		zap.Bool("play", play))
+
+	return nil
+}
+
+// SkipToNext skips to the next track in the user's playback queue.
+func (c *Client) SkipToNext(ctx context.Context) error {
+	if c.client == nil {
+		return errors.New("spotify client not initialized")
+	}
+
+	if err := c.client.Next(ctx); err != nil {
+		return fmt.Errorf("failed to skip to next track: %w", err)
+	}
+
+	c.logger.Debug("Skipped to next track")
+
+	return nil
+}
+
+// PausePlayback pauses playback on the active device.
+// Returns core.ErrPlaybackAlreadyPaused if nothing is playing.
+func (c *Client) PausePlayback(ctx context.Context) error {
+	if c.client == nil {
+		return errors.New("spotify client not initialized")
+	}
+
+	state, err := c.client.PlayerState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get player state: %w", err)
+	}
+	if state == nil || !state.Playing {
+		return core.ErrPlaybackAlreadyPaused
+	}
+
+	_, err = doWithRetry(ctx, c, "pause playback", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.client.Pause(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pause playback: %w", err)
+	}
+
+	c.logger.Debug("Paused Spotify playback")
+
+	return nil
+}
+
+// ResumePlayback resumes playback on the active device where it was paused.
+// Returns core.ErrPlaybackAlreadyPlaying if music is playing already.
+func (c *Client) ResumePlayback(ctx context.Context) error {
+	if c.client == nil {
+		return errors.New("spotify client not initialized")
+	}
+
+	state, err := c.client.PlayerState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get player state: %w", err)
+	}
+	if state != nil && state.Playing {
+		return core.ErrPlaybackAlreadyPlaying
+	}
+
+	_, err = doWithRetry(ctx, c, "resume playback", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.client.Play(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resume playback: %w", err)
+	}
+
+	c.logger.Debug("Resumed Spotify playback")
+
+	return nil
+}
+
+// SetVolume sets the volume of the active playback device in percent (0-100).
+func (c *Client) SetVolume(ctx context.Context, percent int) error {
+	if c.client == nil {
+		return errors.New("spotify client not initialized")
+	}
+
+	if percent < 0 || percent > MaxVolumePercent {
+		return fmt.Errorf("invalid volume %d: must be between 0 and %d", percent, MaxVolumePercent)
+	}
+
+	_, err := doWithRetry(ctx, c, "set volume", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.client.Volume(ctx, percent)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set volume to %d: %w", percent, err)
+	}
+
+	c.logger.Debug("Set Spotify volume",
+		zap.Int("percent", percent))
+
+	return nil
+}
+
+// GetVolume returns the volume of the active playback device in percent.
+func (c *Client) GetVolume(ctx context.Context) (int, error) {
+	if c.client == nil {
+		return 0, errors.New("spotify client not initialized")
+	}
+
+	state, err := c.client.PlayerState(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get player state: %w", err)
+	}
+
+	if state == nil || state.Device.ID == "" {
+		return 0, errors.New("no active playback device")
+	}
+
+	return state.Device.Volume, nil
+}
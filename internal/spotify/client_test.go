@@ -0,0 +1,155 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zmb3/spotify/v2"
+	"go.uber.org/zap"
+)
+
+// durationPlaylistServer serves a playlist of one minute tracks that can be added to and removed from.
+// Every change gets a new snapshot ID; requests for the tracks are counted.
+type durationPlaylistServer struct {
+	mutex         sync.Mutex
+	snapshot      int
+	trackIDs      []string
+	trackRequests int
+}
+
+func (s *durationPlaylistServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !strings.HasSuffix(r.URL.Path, "/tracks") {
+		_ = json.NewEncoder(w).Encode(map[string]any{"snapshot_id": fmt.Sprint(s.snapshot)})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		var body struct{ URIs []string }
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for _, uri := range body.URIs {
+			s.trackIDs = append(s.trackIDs, strings.TrimPrefix(uri, "spotify:track:"))
+		}
+		s.snapshot++
+	case http.MethodDelete:
+		var body struct{ Tracks []struct{ URI string } }
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for _, track := range body.Tracks {
+			trackID := strings.TrimPrefix(track.URI, "spotify:track:")
+			s.trackIDs = slices.DeleteFunc(s.trackIDs, func(id string) bool { return id == trackID })
+		}
+		s.snapshot++
+	default:
+		s.trackRequests++
+		items := make([]map[string]any, 0, len(s.trackIDs))
+		for _, trackID := range s.trackIDs {
+			items = append(items, map[string]any{"track": map[string]any{
+				"id": trackID, "name": trackID, "type": "track", "duration_ms": time.Minute.Milliseconds(),
+			}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items, "total": len(items)})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"snapshot_id": fmt.Sprint(s.snapshot)})
+}
+
+// requests returns the number of requests for the tracks so far.
+func (s *durationPlaylistServer) requests() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.trackRequests
+}
+
+// newDurationTestClient creates a client whose API requests go to a server with a single playlist.
+func newDurationTestClient(t *testing.T, trackIDs ...string) (*Client, *durationPlaylistServer) {
+	t.Helper()
+	playlist := &durationPlaylistServer{trackIDs: trackIDs}
+	server := httptest.NewServer(playlist)
+	t.Cleanup(server.Close)
+
+	c := newSeededClient()
+	c.logger = zap.NewNop()
+	c.durationCache = make(map[string]playlistDurationCacheEntry)
+	c.client = spotify.New(server.Client(), spotify.WithBaseURL(server.URL+"/"))
+	return c, playlist
+}
+
+func TestGetPlaylistDuration_CachedPerSnapshot(t *testing.T) {
+	ctx := context.Background()
+	c, playlist := newDurationTestClient(t, "a", "b")
+
+	for range 3 {
+		if duration, err := c.GetPlaylistDuration(ctx, "playlist"); err != nil || duration != 2*time.Minute {
+			t.Fatalf("GetPlaylistDuration() = %v, %v; want 2m", duration, err)
+		}
+	}
+	if playlist.requests() != 1 {
+		t.Errorf("Expected the tracks to be fetched once, got %d requests", playlist.requests())
+	}
+
+	// Once the TTL passed, an unchanged snapshot keeps the cached duration
+	expirePlaylistDuration(c, "playlist")
+	if _, err := c.GetPlaylistDuration(ctx, "playlist"); err != nil || playlist.requests() != 1 {
+		t.Errorf("Expected an unchanged playlist not to be fetched again, got %d requests, %v", playlist.requests(), err)
+	}
+
+	expirePlaylistDuration(c, "playlist")
+	playlist.mutex.Lock()
+	playlist.trackIDs = append(playlist.trackIDs, "c")
+	playlist.snapshot++
+	playlist.mutex.Unlock()
+	if duration, err := c.GetPlaylistDuration(ctx, "playlist"); err != nil || duration != 3*time.Minute {
+		t.Errorf("Expected a changed playlist to be fetched again, got %v, %v", duration, err)
+	}
+}
+
+func TestGetPlaylistDuration_InvalidatedByChanges(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newDurationTestClient(t, "a", "b")
+
+	changes := []struct {
+		name     string
+		change   func() error
+		expected time.Duration
+	}{
+		{"AddToPlaylist", func() error { return c.AddToPlaylist(ctx, "playlist", "c") }, 3 * time.Minute},
+		{"RemoveFromPlaylist", func() error { return c.RemoveFromPlaylist(ctx, "playlist", "a") }, 2 * time.Minute},
+		{"RemoveFromPlaylistAtPositions", func() error {
+			return c.RemoveFromPlaylistAtPositions(ctx, "playlist", "b", []int{0})
+		}, time.Minute},
+	}
+
+	if _, err := c.GetPlaylistDuration(ctx, "playlist"); err != nil {
+		t.Fatalf("GetPlaylistDuration() error = %v", err)
+	}
+	for _, change := range changes {
+		if err := change.change(); err != nil {
+			t.Fatalf("%s() error = %v", change.name, err)
+		}
+		// Still within the TTL, so only the invalidation makes the change visible
+		if duration, err := c.GetPlaylistDuration(ctx, "playlist"); err != nil || duration != change.expected {
+			t.Errorf("After %s: GetPlaylistDuration() = %v, %v; want %v", change.name, duration, err, change.expected)
+		}
+	}
+}
+
+// expirePlaylistDuration makes the cached duration of the playlist due for a snapshot check.
+func expirePlaylistDuration(c *Client, playlistID string) {
+	c.durationCacheMutex.Lock()
+	defer c.durationCacheMutex.Unlock()
+
+	cached := c.durationCache[playlistID]
+	cached.checkedAt = time.Now().Add(-playlistDurationCacheTTL)
+	c.durationCache[playlistID] = cached
+}
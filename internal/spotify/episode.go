@@ -0,0 +1,122 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/zmb3/spotify/v2"
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/core"
+)
+
+// playerQueueURL is the Web API endpoint that adds an item to the playback queue.
+// The API client only queues tracks, so episodes are queued through it directly.
+const playerQueueURL = "https://api.spotify.com/v1/me/player/queue"
+
+var (
+	spotifyEpisodeRegex    = regexp.MustCompile(`(?:https?://)?(?:open\.)?spotify\.com/episode/([a-zA-Z0-9]+)`)
+	spotifyEpisodeURIRegex = regexp.MustCompile(`spotify:episode:([a-zA-Z0-9]+)`)
+)
+
+// ExtractEpisodeID extracts a Spotify podcast episode ID from episode URLs and URIs.
+// Shortened links are not resolved since they only resolve to tracks.
+func (c *Client) ExtractEpisodeID(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	if matches := spotifyEpisodeURIRegex.FindStringSubmatch(rawURL); len(matches) > 1 {
+		return matches[1], nil
+	}
+
+	if matches := spotifyEpisodeRegex.FindStringSubmatch(rawURL); len(matches) > 1 {
+		return matches[1], nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+
+	episodeID := extractEpisodeIDFromPath(u.Path)
+	if episodeID == "" {
+		return "", errors.New("no episode ID found in URL")
+	}
+	return episodeID, nil
+}
+
+// extractEpisodeIDFromPath extracts a Spotify episode ID from a URL path (e.g. "/intl-de/episode/<id>").
+func extractEpisodeIDFromPath(path string) string {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range pathParts {
+		if part == "episode" && i+1 < len(pathParts) {
+			return pathParts[i+1]
+		}
+	}
+	return ""
+}
+
+// GetEpisode retrieves a podcast episode with its show.
+func (c *Client) GetEpisode(ctx context.Context, episodeID string) (*core.Episode, error) {
+	if c.client == nil {
+		return nil, errors.New("client not authenticated")
+	}
+
+	episode, err := doWithRetry(ctx, c, "get episode", func(ctx context.Context) (*spotify.EpisodePage, error) {
+		return c.client.GetEpisode(ctx, episodeID, spotify.Market(c.market()))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get episode: %w", err)
+	}
+
+	return &core.Episode{
+		ID:       string(episode.ID),
+		Title:    episode.Name,
+		Show:     episode.Show.Name,
+		Duration: time.Duration(episode.Duration_ms) * time.Millisecond,
+		URL:      episode.ExternalURLs["spotify"],
+		Explicit: episode.Explicit,
+	}, nil
+}
+
+// AddEpisodeToQueue adds a podcast episode to the user's Spotify playback queue.
+// Episodes can't be added to the target playlist, so they are only ever queued.
+func (c *Client) AddEpisodeToQueue(ctx context.Context, episodeID string) error {
+	if c.client == nil || c.httpClient == nil {
+		return errors.New("client not authenticated")
+	}
+
+	queueURL := playerQueueURL + "?" + url.Values{"uri": {"spotify:episode:" + episodeID}}.Encode()
+
+	_, err := doWithRetry(ctx, c, "queue episode", func(ctx context.Context) (struct{}, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, queueURL, http.NoBody)
+		if err != nil {
+			return struct{}{}, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return struct{}{}, err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK &&
+			resp.StatusCode != http.StatusAccepted {
+			return struct{}{}, spotify.Error{Message: resp.Status, Status: resp.StatusCode}
+		}
+		return struct{}{}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add episode to queue: %w", err)
+	}
+
+	c.logger.Info("Episode added to queue",
+		zap.String("episodeID", episodeID))
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// OAuth QR Code
+// This module renders the Spotify authorization URL as a QR code, so operators of headless
+// servers can open it on their phone instead of copying it out of the terminal.
+
+package spotify
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/pkg/qr"
+)
+
+// oauthQRPath is the path of the OAuth callback server the QR code is served at.
+const oauthQRPath = "/qr"
+
+// printOAuthQR prints the authorization URL as a terminal QR code, if enabled.
+// The printed URL stays the fallback, so failing to encode it is only logged.
+func (c *Client) printOAuthQR(authURL string) {
+	if !c.config.OAuthQR {
+		return
+	}
+
+	code, err := qr.Encode(authURL)
+	if err != nil {
+		c.logger.Warn("Failed to encode authorization URL as QR code", zap.Error(err))
+		return
+	}
+
+	fmt.Printf("Or scan this QR code with your phone:\n\n")
+	fmt.Print(code.Terminal())
+	if qrURL := c.oauthQRURL(); qrURL != "" {
+		fmt.Printf("\n(Also available at %s)\n", qrURL)
+	}
+	fmt.Printf("\n")
+}
+
+// handleOAuthQR serves the authorization URL as an SVG QR code on the callback server, if enabled.
+func (c *Client) handleOAuthQR(mux *http.ServeMux, authURL string) {
+	if !c.config.OAuthQR {
+		return
+	}
+
+	mux.HandleFunc(oauthQRPath, func(w http.ResponseWriter, _ *http.Request) {
+		code, err := qr.Encode(authURL)
+		if err != nil {
+			http.Error(w, "Failed to encode QR code", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/svg+xml")
+		if _, writeErr := w.Write([]byte(code.SVG())); writeErr != nil {
+			c.logger.Warn("Failed to write QR code response", zap.Error(writeErr))
+		}
+	})
+}
+
+// oauthQRURL returns the URL the QR code is served at, on the host and port of the redirect URL.
+// Returns an empty string for loopback redirect hosts, which other devices can't reach.
+func (c *Client) oauthQRURL() string {
+	parsedURL, err := url.Parse(c.config.RedirectURL)
+	if err != nil || parsedURL.Host == "" {
+		return ""
+	}
+	if host := parsedURL.Hostname(); host == "localhost" || net.ParseIP(host).IsLoopback() {
+		return ""
+	}
+	return (&url.URL{Scheme: parsedURL.Scheme, Host: parsedURL.Host, Path: oauthQRPath}).String()
+}
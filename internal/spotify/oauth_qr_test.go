@@ -0,0 +1,60 @@
+package spotify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/core"
+)
+
+func TestHandleOAuthQR(t *testing.T) {
+	tests := []struct {
+		name           string
+		enabled        bool
+		expectedStatus int
+	}{
+		{"Enabled serves the QR code", true, http.StatusOK},
+		{"Disabled serves nothing", false, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{config: &core.SpotifyConfig{OAuthQR: tt.enabled}, logger: zap.NewNop()}
+			mux := http.NewServeMux()
+			c.handleOAuthQR(mux, "https://accounts.spotify.com/authorize?client_id=abc")
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, oauthQRPath, http.NoBody))
+
+			if recorder.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, recorder.Code)
+			}
+			if tt.enabled && !strings.HasPrefix(recorder.Body.String(), "<svg") {
+				t.Errorf("Expected an SVG image, got %q", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestOAuthQRURL(t *testing.T) {
+	tests := []struct {
+		redirectURL string
+		expected    string
+	}{
+		{"https://dj.example.com:8443/callback", "https://dj.example.com:8443/qr"},
+		{"http://127.0.0.1:8888/callback", ""},
+		{"http://[::1]:8888/callback", ""},
+		{"http://localhost:8888/callback", ""},
+	}
+
+	for _, tt := range tests {
+		c := &Client{config: &core.SpotifyConfig{RedirectURL: tt.redirectURL}}
+		if qrURL := c.oauthQRURL(); qrURL != tt.expected {
+			t.Errorf("oauthQRURL() for %s = %q, want %q", tt.redirectURL, qrURL, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zmb3/spotify/v2"
+	"go.uber.org/zap"
+)
+
+// playlistBatchSize is the maximum number of items Spotify accepts per playlist read or write request.
+const playlistBatchSize = 100
+
+// ExportPlaylist returns the URIs of all tracks in a playlist, in playlist order.
+// Episodes and local files can't be restored through the track endpoints and are skipped.
+func (c *Client) ExportPlaylist(ctx context.Context, playlistID string) ([]string, error) {
+	if c.client == nil {
+		return nil, errors.New("client not authenticated")
+	}
+
+	spotifyPlaylistID := spotify.ID(playlistID)
+	var trackURIs []string
+	skipped := 0
+	offset := 0
+
+	for {
+		items, err := c.getPlaylistItemsWithRetry(ctx, spotifyPlaylistID,
+			spotify.Limit(playlistBatchSize), spotify.Offset(offset))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get playlist items: %w", err)
+		}
+
+		for i := range items.Items {
+			track := items.Items[i].Track.Track
+			if track == nil || track.ID == "" {
+				skipped++
+				continue
+			}
+			trackURIs = append(trackURIs, string(track.URI))
+		}
+
+		if len(items.Items) < playlistBatchSize {
+			break
+		}
+
+		offset += playlistBatchSize
+	}
+
+	c.logger.Info("Exported playlist",
+		zap.String("playlistID", playlistID),
+		zap.Int("tracks", len(trackURIs)),
+		zap.Int("skipped", skipped))
+
+	return trackURIs, nil
+}
+
+// ReplacePlaylistContents replaces all items of a playlist with the given tracks, keeping their order.
+// Spotify replaces at most 100 items per request, so further tracks are appended in batches.
+func (c *Client) ReplacePlaylistContents(ctx context.Context, playlistID string, trackIDs []string) error {
+	if c.client == nil {
+		return errors.New("client not authenticated")
+	}
+
+	spotifyPlaylistID := spotify.ID(playlistID)
+	batches := playlistBatches(trackIDs)
+	defer c.invalidatePlaylistDuration(playlistID)
+
+	// The first batch replaces the playlist items, an empty one clears the playlist
+	firstBatch := make([]spotify.URI, 0, len(batches[0]))
+	for _, trackID := range batches[0] {
+		firstBatch = append(firstBatch, spotify.URI("spotify:track:"+trackID))
+	}
+
+	_, err := doWithRetry(ctx, c, "replace playlist items", func(ctx context.Context) (string, error) {
+		return c.client.ReplacePlaylistItems(ctx, spotifyPlaylistID, firstBatch...)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace playlist items: %w", err)
+	}
+
+	start := len(batches[0])
+	for _, trackIDBatch := range batches[1:] {
+		batch := make([]spotify.ID, 0, len(trackIDBatch))
+		for _, trackID := range trackIDBatch {
+			batch = append(batch, spotify.ID(trackID))
+		}
+
+		if _, err := c.addTracksToPlaylistWithRetry(ctx, spotifyPlaylistID, batch...); err != nil {
+			return fmt.Errorf("failed to add tracks %d-%d to playlist: %w", start+1, start+len(batch), err)
+		}
+		start += len(batch)
+	}
+
+	c.logger.Info("Replaced playlist contents",
+		zap.String("playlistID", playlistID),
+		zap.Int("tracks", len(trackIDs)))
+
+	return nil
+}
+
+// playlistBatches splits track IDs into batches of at most playlistBatchSize items, keeping their order.
+// There is always at least one batch, which is empty if there are no tracks.
+func playlistBatches(trackIDs []string) [][]string {
+	batches := make([][]string, 0, len(trackIDs)/playlistBatchSize+1)
+	for start := 0; start == 0 || start < len(trackIDs); start += playlistBatchSize {
+		batches = append(batches, trackIDs[start:min(start+playlistBatchSize, len(trackIDs))])
+	}
+	return batches
+}
@@ -0,0 +1,43 @@
+package spotify
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestPlaylistBatches(t *testing.T) {
+	tests := []struct {
+		tracks     int
+		batchSizes []int
+	}{
+		{tracks: 0, batchSizes: []int{0}},
+		{tracks: 1, batchSizes: []int{1}},
+		{tracks: 99, batchSizes: []int{99}},
+		{tracks: 100, batchSizes: []int{100}},
+		{tracks: 101, batchSizes: []int{100, 1}},
+		{tracks: 250, batchSizes: []int{100, 100, 50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d tracks", tt.tracks), func(t *testing.T) {
+			trackIDs := make([]string, tt.tracks)
+			for i := range trackIDs {
+				trackIDs[i] = fmt.Sprintf("track%d", i)
+			}
+
+			batches := playlistBatches(trackIDs)
+
+			batchSizes := make([]int, 0, len(batches))
+			for _, batch := range batches {
+				batchSizes = append(batchSizes, len(batch))
+			}
+			if !slices.Equal(batchSizes, tt.batchSizes) {
+				t.Fatalf("Expected batches of %v tracks, got %v", tt.batchSizes, batchSizes)
+			}
+			if joined := slices.Concat(batches...); !slices.Equal(joined, trackIDs) {
+				t.Errorf("Expected the batches to keep all tracks in order, got %v", joined)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package spotify
+
+import (
+	"sync"
+
+	"djalgorhythm/internal/core"
+)
+
+const (
+	// playlistUsagePenalty controls how strongly playlists sampled earlier in the session are avoided.
+	playlistUsagePenalty = 1.0
+	// maxTrackedPlaylistUsages bounds the playlists whose usage is counted, the counts start over once exceeded.
+	maxTrackedPlaylistUsages = 1000
+)
+
+// playlistUsage counts how often playlists were sampled for candidate tracks in this session, safe for concurrent use.
+type playlistUsage struct {
+	mutex  sync.Mutex
+	counts map[string]int
+}
+
+// record counts a use of each playlist.
+func (u *playlistUsage) record(playlists []core.Playlist) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if u.counts == nil || len(u.counts) >= maxTrackedPlaylistUsages {
+		u.counts = make(map[string]int)
+	}
+	for _, playlist := range playlists {
+		u.counts[playlist.ID]++
+	}
+}
+
+// count returns how often a playlist was used in this session.
+func (u *playlistUsage) count(playlistID string) int {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	return u.counts[playlistID]
+}
+
+// usageWeight scales the selection weight of a playlist used n times by 1 / (1 + playlistUsagePenalty * n),
+// so a playlist's weight halves after its first use and keeps shrinking with every further use.
+func (u *playlistUsage) usageWeight(playlistID string) float64 {
+	return 1 / (1 + playlistUsagePenalty*float64(u.count(playlistID)))
+}
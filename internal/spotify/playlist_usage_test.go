@@ -0,0 +1,66 @@
+package spotify
+
+import (
+	"testing"
+
+	"djalgorhythm/internal/core"
+)
+
+const playlistSelectionSamples = 10000
+
+// countFirstSelections returns how often each playlist was picked by single playlist selections.
+func countFirstSelections(c *Client, playlists []core.Playlist) map[string]int {
+	counts := make(map[string]int)
+	for range playlistSelectionSamples {
+		counts[c.selectRandomPlaylists(playlists, 1)[0].ID]++
+	}
+	return counts
+}
+
+func TestPlaylistUsage_Weight(t *testing.T) {
+	var usage playlistUsage
+	if weight := usage.usageWeight("unused"); weight != 1 {
+		t.Errorf("Expected unused playlists to keep their weight, got %v", weight)
+	}
+
+	usage.record([]core.Playlist{{ID: "used"}})
+	usage.record([]core.Playlist{{ID: "used"}, {ID: "other"}})
+	if weight := usage.usageWeight("used"); weight != 1.0/3 {
+		t.Errorf("Expected a playlist used twice to keep a third of its weight, got %v", weight)
+	}
+	if weight := usage.usageWeight("other"); weight != 0.5 {
+		t.Errorf("Expected a playlist used once to keep half its weight, got %v", weight)
+	}
+}
+
+func TestSelectRandomPlaylists_SkewsAwayFromUsedPlaylists(t *testing.T) {
+	playlists := []core.Playlist{{ID: "first"}, {ID: "second"}, {ID: "third"}, {ID: "fourth"}}
+	c := newSeededClient()
+
+	counts := countFirstSelections(c, playlists)
+	if counts["first"] <= counts["second"] || counts["second"] <= counts["third"] {
+		t.Fatalf("Expected unused playlists to be biased towards earlier results, got %v", counts)
+	}
+
+	// Used twice, the first result keeps a third of its weight: 0.33 against 0.61 of the second result
+	c.playlistUsage.record(playlists[:1])
+	c.playlistUsage.record(playlists[:1])
+
+	counts = countFirstSelections(c, playlists)
+	if counts["second"] <= counts["first"] {
+		t.Errorf("Expected the used first result to be picked less often than the second, got %v", counts)
+	}
+	if share := float64(counts["first"]) / playlistSelectionSamples; share > 0.25 {
+		t.Errorf("Expected the used first result to be picked at most 25%% of the time, got %.2f", share)
+	}
+}
+
+func TestSelectRandomPlaylists_ReturnsAllWhenFew(t *testing.T) {
+	playlists := []core.Playlist{{ID: "first"}, {ID: "second"}}
+	c := newSeededClient()
+	c.playlistUsage.record(playlists[:1])
+
+	if selected := c.selectRandomPlaylists(playlists, MaxPlaylistsForCandidates); len(selected) != len(playlists) {
+		t.Errorf("Expected all %d playlists to be selected, got %d", len(playlists), len(selected))
+	}
+}
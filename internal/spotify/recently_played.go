@@ -0,0 +1,77 @@
+package spotify
+
+import (
+	"sync"
+	"time"
+
+	"djalgorhythm/internal/core"
+)
+
+// recentlyPlayed remembers when tracks started playing, so queue filling doesn't pick them again within
+// the recently played window, even once they're no longer in the target playlist. Safe for concurrent use.
+type recentlyPlayed struct {
+	mutex    sync.Mutex
+	playedAt map[string]time.Time
+}
+
+// record remembers that the track started playing now, dropping tracks played before the window.
+func (r *recentlyPlayed) record(trackID string, now time.Time, window time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.playedAt == nil {
+		r.playedAt = make(map[string]time.Time)
+	}
+	for id, playedAt := range r.playedAt {
+		if now.Sub(playedAt) >= window {
+			delete(r.playedAt, id)
+		}
+	}
+	r.playedAt[trackID] = now
+}
+
+// trackIDs returns the tracks that started playing within the window.
+func (r *recentlyPlayed) trackIDs(now time.Time, window time.Duration) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	trackIDs := make([]string, 0, len(r.playedAt))
+	for id, playedAt := range r.playedAt {
+		if now.Sub(playedAt) < window {
+			trackIDs = append(trackIDs, id)
+		}
+	}
+	return trackIDs
+}
+
+// recentlyPlayedWindow returns the window in which played tracks aren't recommended again (zero if disabled).
+func (c *Client) recentlyPlayedWindow() time.Duration {
+	return time.Duration(c.config.RecentlyPlayedHours) * time.Hour
+}
+
+// RecordPlayedTrack keeps a track that started playing from being recommended again within the recently played window.
+func (c *Client) RecordPlayedTrack(trackID string) {
+	window := c.recentlyPlayedWindow()
+	if window <= 0 || trackID == "" {
+		return
+	}
+	c.recentlyPlayed.record(trackID, time.Now(), window)
+}
+
+// candidateExclusions returns the tracks never recommended: the ones in the target playlist
+// and the ones played within the recently played window.
+func (c *Client) candidateExclusions(playlistTracks []core.Track) map[string]struct{} {
+	var played []string
+	if window := c.recentlyPlayedWindow(); window > 0 {
+		played = c.recentlyPlayed.trackIDs(time.Now(), window)
+	}
+
+	exclude := make(map[string]struct{}, len(playlistTracks)+len(played))
+	for _, track := range playlistTracks {
+		exclude[track.ID] = struct{}{}
+	}
+	for _, trackID := range played {
+		exclude[trackID] = struct{}{}
+	}
+	return exclude
+}
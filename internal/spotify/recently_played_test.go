@@ -0,0 +1,43 @@
+package spotify
+
+import (
+	"testing"
+	"time"
+
+	"djalgorhythm/internal/core"
+)
+
+func TestRecentlyPlayed_Window(t *testing.T) {
+	var played recentlyPlayed
+	now := time.Now()
+	window := time.Hour
+
+	played.record("old", now.Add(-2*window), window)
+	played.record("recent", now.Add(-window/2), window)
+	played.record("current", now, window)
+
+	trackIDs := played.trackIDs(now, window)
+	if len(trackIDs) != 2 {
+		t.Errorf("Expected the tracks played within the window, got %v", trackIDs)
+	}
+	if _, exists := played.playedAt["old"]; exists {
+		t.Error("Expected tracks played before the window to be dropped")
+	}
+}
+
+func TestCandidateExclusions(t *testing.T) {
+	c := &Client{config: &core.SpotifyConfig{RecentlyPlayedHours: 1}}
+	c.RecordPlayedTrack("played")
+
+	exclude := c.candidateExclusions([]core.Track{{ID: "inPlaylist"}})
+	for _, trackID := range []string{"inPlaylist", "played"} {
+		if _, excluded := exclude[trackID]; !excluded {
+			t.Errorf("Expected %s to be excluded, got %v", trackID, exclude)
+		}
+	}
+
+	c.config.RecentlyPlayedHours = 0
+	if _, excluded := c.candidateExclusions(nil)["played"]; excluded {
+		t.Error("Expected recently played tracks to be recommended with the exclusion disabled")
+	}
+}
@@ -0,0 +1,203 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zmb3/spotify/v2"
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/core"
+)
+
+const (
+	// maxGenreSeeds bounds the genres taken from the mood, leaving room for track and artist seeds.
+	maxGenreSeeds = 2
+	// maxRecommendations is the number of tracks requested from Spotify's recommendations.
+	maxRecommendations = 20
+)
+
+// GetRecommendationsBySeeds returns tracks recommended by Spotify for the seed tracks (oldest first).
+// The primary artist of the most recent track and genres named in the mood are used as further seeds,
+// and the energy, danceability and valence of the recommendations target the average of the seed tracks.
+func (c *Client) GetRecommendationsBySeeds(ctx context.Context, seedTracks []core.Track,
+	mood string) ([]core.Track, error) {
+	if c.client == nil {
+		return nil, errors.New("client not authenticated")
+	}
+	if len(seedTracks) == 0 {
+		return nil, errors.New("no seed tracks for recommendations")
+	}
+
+	seeds := spotify.Seeds{Genres: c.genreSeedsForMood(ctx, mood)}
+	if artistID := c.primaryArtistID(ctx, seedTracks[len(seedTracks)-1].ID); artistID != "" {
+		seeds.Artists = []spotify.ID{artistID}
+	}
+	trackSeeds := spotify.MaxNumberOfSeeds - len(seeds.Genres) - len(seeds.Artists)
+	for i := len(seedTracks) - 1; i >= 0 && len(seeds.Tracks) < trackSeeds; i-- {
+		seeds.Tracks = append(seeds.Tracks, spotify.ID(seedTracks[i].ID))
+	}
+
+	recommendations, err := doWithRetry(ctx, c, "get recommendations", func(ctx context.Context) (*spotify.Recommendations, error) {
+		return c.client.GetRecommendations(ctx, seeds, c.audioFeatureTargets(ctx, seedTracks),
+			spotify.Limit(maxRecommendations), spotify.Market(c.market()))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommendations: %w", err)
+	}
+
+	tracks := make([]core.Track, 0, len(recommendations.Tracks))
+	for i := range recommendations.Tracks {
+		tracks = append(tracks, c.convertSpotifyTrack(&spotify.FullTrack{
+			SimpleTrack: recommendations.Tracks[i],
+			Album:       recommendations.Tracks[i].Album,
+		}))
+	}
+
+	c.logger.Debug("Retrieved recommendations by seeds",
+		zap.Strings("genres", seeds.Genres),
+		zap.Int("artistSeeds", len(seeds.Artists)),
+		zap.Int("trackSeeds", len(seeds.Tracks)),
+		zap.Int("count", len(tracks)))
+
+	return tracks, nil
+}
+
+// genreSeedsForMood returns the genre seeds Spotify knows that are named in the mood, like "indie" or "hip hop".
+func (c *Client) genreSeedsForMood(ctx context.Context, mood string) []string {
+	if mood == "" || mood == DefaultPlaylistSearchQuery {
+		return nil
+	}
+
+	available, err := doWithRetry(ctx, c, "get genre seeds", func(ctx context.Context) ([]string, error) {
+		return c.client.GetAvailableGenreSeeds(ctx)
+	})
+	if err != nil {
+		c.logger.Debug("Failed to get genre seeds, recommending without genres", zap.Error(err))
+		return nil
+	}
+
+	words := " " + strings.Join(strings.FieldsFunc(strings.ToLower(mood), isNotGenreRune), " ") + " "
+	var genres []string
+	for _, genre := range available {
+		if len(genres) == maxGenreSeeds {
+			break
+		}
+		if strings.Contains(words, " "+strings.ReplaceAll(genre, "-", " ")+" ") {
+			genres = append(genres, genre)
+		}
+	}
+	return genres
+}
+
+// isNotGenreRune reports whether a rune separates the words of a mood.
+func isNotGenreRune(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+}
+
+// primaryArtistID returns the ID of the first artist of a track, or an empty ID if it can't be looked up.
+func (c *Client) primaryArtistID(ctx context.Context, trackID string) spotify.ID {
+	track, err := doWithRetry(ctx, c, "get track", func(ctx context.Context) (*spotify.FullTrack, error) {
+		return c.client.GetTrack(ctx, spotify.ID(trackID))
+	})
+	if err != nil || len(track.Artists) == 0 {
+		c.logger.Debug("Failed to get seed artist, recommending without artist", zap.Error(err))
+		return ""
+	}
+	return track.Artists[0].ID
+}
+
+// GetAudioFeatures returns Spotify's audio analysis of a track.
+func (c *Client) GetAudioFeatures(ctx context.Context, trackID string) (*core.AudioFeatures, error) {
+	if c.client == nil {
+		return nil, errors.New("client not authenticated")
+	}
+
+	features, err := doWithRetry(ctx, c, "get audio features", func(ctx context.Context) ([]*spotify.AudioFeatures, error) {
+		return c.client.GetAudioFeatures(ctx, spotify.ID(trackID))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audio features: %w", err)
+	}
+	if len(features) == 0 || features[0] == nil {
+		return nil, fmt.Errorf("no audio features for track %s", trackID)
+	}
+
+	return &core.AudioFeatures{
+		Energy:       float64(features[0].Energy),
+		Valence:      float64(features[0].Valence),
+		Danceability: float64(features[0].Danceability),
+		Tempo:        float64(features[0].Tempo),
+	}, nil
+}
+
+// audioFeatureTargets returns the average energy, danceability and valence of the tracks as recommendation targets,
+// or nil if their audio features are unavailable.
+func (c *Client) audioFeatureTargets(ctx context.Context, tracks []core.Track) *spotify.TrackAttributes {
+	ids := make([]spotify.ID, 0, len(tracks))
+	for _, track := range tracks {
+		ids = append(ids, spotify.ID(track.ID))
+	}
+
+	features, err := doWithRetry(ctx, c, "get audio features", func(ctx context.Context) ([]*spotify.AudioFeatures, error) {
+		return c.client.GetAudioFeatures(ctx, ids...)
+	})
+	if err != nil {
+		c.logger.Debug("Failed to get audio features, recommending without targets", zap.Error(err))
+		return nil
+	}
+
+	var energy, danceability, valence float64
+	count := 0
+	for _, feature := range features {
+		if feature == nil {
+			continue
+		}
+		energy += float64(feature.Energy)
+		danceability += float64(feature.Danceability)
+		valence += float64(feature.Valence)
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+
+	return spotify.NewTrackAttributes().
+		TargetEnergy(energy / float64(count)).
+		TargetDanceability(danceability / float64(count)).
+		TargetValence(valence / float64(count))
+}
+
+// findTrackFromRecommendations picks a random recommended track that isn't in the playlist yet
+// and wasn't played recently.
+func (c *Client) findTrackFromRecommendations(ctx context.Context, recentTracks []core.Track, mood string,
+	playlistTracks []core.Track) (string, error) {
+	recommendations, err := c.GetRecommendationsBySeeds(ctx, recentTracks, mood)
+	if err != nil {
+		return "", err
+	}
+
+	exclude := c.candidateExclusions(playlistTracks)
+
+	candidates := make([]core.Track, 0, len(recommendations))
+	for _, track := range recommendations {
+		if _, excluded := exclude[track.ID]; !excluded {
+			candidates = append(candidates, track)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", errors.New("no recommended tracks outside the playlist that weren't played recently")
+	}
+
+	selectedTrack := candidates[c.rng.Intn(len(candidates))]
+	c.logger.Info("Selected recommended track for queue management",
+		zap.String("mood", mood),
+		zap.String("selectedTrackID", selectedTrack.ID),
+		zap.String("title", selectedTrack.Title),
+		zap.String("artist", selectedTrack.Artist),
+		zap.Int("totalCandidates", len(candidates)))
+
+	return selectedTrack.ID, nil
+}
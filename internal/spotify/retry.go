@@ -0,0 +1,153 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/zmb3/spotify/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	// retryBaseDelay is the initial backoff delay after a rate-limited request.
+	retryBaseDelay = 500 * time.Millisecond
+	// retryJitterDivisor limits the random jitter to a fraction (1/n) of the delay.
+	retryJitterDivisor = 2
+	// retryMaxShift bounds the exponent to avoid overflowing the backoff duration.
+	retryMaxShift = 10
+)
+
+// retryAfterKey is the context key of the Retry-After recorder of a request, see doWithRetry.
+type retryAfterKey struct{}
+
+// retryAfterRecorder holds the last Retry-After value Spotify sent for a single retried request.
+// Each call of doWithRetry has its own recorder, so concurrent requests don't see each other's waits.
+type retryAfterRecorder struct {
+	retryAfter atomic.Int64 // Last Retry-After value in nanoseconds (0 if none)
+}
+
+// take returns and clears the last recorded Retry-After duration.
+func (r *retryAfterRecorder) take() time.Duration {
+	return time.Duration(r.retryAfter.Swap(0))
+}
+
+// retryAfterTransport records the Retry-After header of rate-limited responses in the recorder
+// of the request context, since spotify.Error only exposes the status code and message.
+// The library's own WithRetry option retries without limit, so retries are handled by doWithRetry.
+type retryAfterTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip executes the request and records the Retry-After header on 429 responses.
+func (t *retryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder, ok := req.Context().Value(retryAfterKey{}).(*retryAfterRecorder)
+	if ok && resp.StatusCode == http.StatusTooManyRequests {
+		if seconds, parseErr := strconv.Atoi(resp.Header.Get("Retry-After")); parseErr == nil && seconds > 0 {
+			recorder.retryAfter.Store(int64(time.Duration(seconds) * time.Second))
+		}
+	}
+
+	return resp, nil
+}
+
+// newAPIClient creates a Spotify API client whose transport records Retry-After headers.
+func (c *Client) newAPIClient(httpClient *http.Client) *spotify.Client {
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	httpClient.Transport = &retryAfterTransport{base: base}
+	c.httpClient = httpClient
+
+	return spotify.New(httpClient)
+}
+
+// SetRetryPolicy sets how often rate-limited Spotify requests are retried and how long a single retry may wait.
+// Requests Spotify asks to wait longer for fail right away instead of blocking the chat.
+func (c *Client) SetRetryPolicy(maxRetries int, maxWait time.Duration) {
+	c.maxRetries = maxRetries
+	c.maxRetryWait = maxWait
+}
+
+// doWithRetry runs fn and retries it with capped exponential backoff plus jitter
+// while Spotify responds with 429 Too Many Requests. fn must use the context it is given,
+// which carries the recorder of the Retry-After header of this request.
+func doWithRetry[T any](ctx context.Context, c *Client, operation string,
+	fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+
+	recorder := &retryAfterRecorder{}
+	ctx = context.WithValue(ctx, retryAfterKey{}, recorder)
+
+	for attempt := 0; ; attempt++ {
+		result, err := fn(ctx)
+		if err == nil {
+			return result, nil
+		}
+		if !isRateLimitError(err) {
+			c.recordAPIError(operation)
+			return result, err
+		}
+
+		if attempt >= c.maxRetries {
+			c.recordAPIError(operation)
+			return zero, fmt.Errorf("%s: still rate limited by Spotify after %d retries: %w", operation, attempt, err)
+		}
+
+		retryAfter := recorder.take()
+		if retryAfter > c.maxRetryWait {
+			c.recordAPIError(operation)
+			return zero, fmt.Errorf("%s: rate limited by Spotify for %v, longer than the maximum retry wait of %v: %w",
+				operation, retryAfter, c.maxRetryWait, err)
+		}
+
+		wait := retryDelay(attempt, retryAfter, c.maxRetryWait)
+		c.logger.Debug("Spotify rate limit hit, retrying",
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt+1),
+			zap.Int("maxRetries", c.maxRetries),
+			zap.Duration("wait", wait))
+
+		select {
+		case <-ctx.Done():
+			return zero, fmt.Errorf("%s: %w", operation, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryDelay calculates the wait before the next retry: exponential backoff with jitter, capped at maxWait,
+// but never shorter than the Retry-After duration requested by Spotify.
+func retryDelay(attempt int, retryAfter, maxWait time.Duration) time.Duration {
+	delay := min(retryBaseDelay<<min(attempt, retryMaxShift), maxWait)
+
+	// #nosec G404 Jitter doesn't require crypto-secure randomness
+	jitter := time.Duration(rand.Int63n(int64(delay/retryJitterDivisor) + 1))
+
+	return max(min(delay+jitter, maxWait), retryAfter)
+}
+
+// recordAPIError counts a failed Spotify API request if metrics are enabled.
+func (c *Client) recordAPIError(operation string) {
+	if c.metrics != nil {
+		c.metrics.IncSpotifyAPIErrors(operation)
+	}
+}
+
+// isRateLimitError checks whether err is a Spotify 429 Too Many Requests error.
+func isRateLimitError(err error) bool {
+	var spotifyErr spotify.Error
+	return errors.As(err, &spotifyErr) && spotifyErr.Status == http.StatusTooManyRequests
+}
@@ -0,0 +1,138 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zmb3/spotify/v2"
+	"go.uber.org/zap"
+)
+
+// newRetryTestClient creates a client whose API requests go through the Retry-After recording transport.
+func newRetryTestClient(maxRetries int, maxWait time.Duration) *Client {
+	c := &Client{logger: zap.NewNop()}
+	c.newAPIClient(&http.Client{})
+	c.SetRetryPolicy(maxRetries, maxWait)
+	return c
+}
+
+// newRateLimitedServer responds with 429 and the given Retry-After header to the first rateLimited requests.
+func newRateLimitedServer(t *testing.T, rateLimited int32, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) <= rateLimited {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// getRequest returns a request function for doWithRetry that fails with a Spotify error on 429 responses.
+func getRequest(c *Client, url string) func(ctx context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return 0, err
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		_ = resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return 0, spotify.Error{Status: resp.StatusCode, Message: "API rate limit exceeded"}
+		}
+		return resp.StatusCode, nil
+	}
+}
+
+func TestDoWithRetry_RetriesRateLimitedRequests(t *testing.T) {
+	server, requests := newRateLimitedServer(t, 2, "")
+	c := newRetryTestClient(3, time.Millisecond)
+
+	status, err := doWithRetry(context.Background(), c, "get", getRequest(c, server.URL))
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("doWithRetry() = %d, %v; want 200 after retries", status, err)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("Expected two retries, got %d requests", requests.Load())
+	}
+}
+
+func TestDoWithRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	server, requests := newRateLimitedServer(t, 10, "")
+	c := newRetryTestClient(2, time.Millisecond)
+
+	_, err := doWithRetry(context.Background(), c, "get", getRequest(c, server.URL))
+	if err == nil || !strings.Contains(err.Error(), "after 2 retries") || !isRateLimitError(err) {
+		t.Fatalf("Expected a rate limit error after 2 retries, got %v", err)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("Expected the request and two retries, got %d requests", requests.Load())
+	}
+}
+
+func TestDoWithRetry_FailsWhenRetryAfterExceedsMaxWait(t *testing.T) {
+	server, requests := newRateLimitedServer(t, 1, "120")
+	c := newRetryTestClient(3, time.Second)
+
+	_, err := doWithRetry(context.Background(), c, "get", getRequest(c, server.URL))
+	if err == nil || !strings.Contains(err.Error(), "longer than the maximum retry wait") {
+		t.Fatalf("Expected the request to fail without waiting two minutes, got %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected no retry, got %d requests", requests.Load())
+	}
+}
+
+func TestRetryAfterTransport_ScopedPerRequest(t *testing.T) {
+	server, _ := newRateLimitedServer(t, 1, "7")
+	c := newRetryTestClient(0, time.Minute)
+
+	limited, other := &retryAfterRecorder{}, &retryAfterRecorder{}
+	first := getRequest(c, server.URL)
+	if _, err := first(context.WithValue(context.Background(), retryAfterKey{}, limited)); !isRateLimitError(err) {
+		t.Fatalf("Expected the first request to be rate limited, got %v", err)
+	}
+	second := getRequest(c, server.URL)
+	if _, err := second(context.WithValue(context.Background(), retryAfterKey{}, other)); err != nil {
+		t.Fatalf("Expected the second request to succeed, got %v", err)
+	}
+
+	if other.take() != 0 {
+		t.Error("Expected the Retry-After of one request not to leak into another")
+	}
+	if wait := limited.take(); wait != 7*time.Second {
+		t.Errorf("Expected the Retry-After of the rate-limited request to be recorded, got %v", wait)
+	}
+	if limited.take() != 0 {
+		t.Error("Expected take to clear the recorded Retry-After")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	maxWait := 30 * time.Second
+
+	if delay := retryDelay(0, 0, maxWait); delay < retryBaseDelay || delay > retryBaseDelay*3/2 {
+		t.Errorf("Expected the first retry to wait the base delay plus at most half as jitter, got %v", delay)
+	}
+	if delay := retryDelay(20, 0, maxWait); delay != maxWait {
+		t.Errorf("Expected the backoff to be capped at %v, got %v", maxWait, delay)
+	}
+	if delay := retryDelay(0, 10*time.Second, maxWait); delay != 10*time.Second {
+		t.Errorf("Expected the Retry-After wait to be honored, got %v", delay)
+	}
+}
@@ -0,0 +1,89 @@
+package spotify
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"djalgorhythm/internal/core"
+)
+
+// testSeed seeds the random source of test clients, so sampling tests are reproducible.
+const testSeed = 42
+
+// newSeededClient returns a client whose random sampling is reproducible.
+func newSeededClient() *Client {
+	return &Client{rng: rand.New(rand.NewSource(testSeed))}
+}
+
+func TestSelectRandomPlaylists_WeightedDecay(t *testing.T) {
+	playlists := []core.Playlist{{ID: "first"}, {ID: "second"}, {ID: "third"}, {ID: "fourth"}}
+	counts := countFirstSelections(newSeededClient(), playlists)
+
+	// The playlist at position i has the weight exp(-0.5 * i)
+	totalWeight := 0.0
+	for i := range playlists {
+		totalWeight += math.Exp(-0.5 * float64(i))
+	}
+	for i, playlist := range playlists {
+		expected := math.Exp(-0.5*float64(i)) / totalWeight
+		share := float64(counts[playlist.ID]) / playlistSelectionSamples
+		if math.Abs(share-expected) > 0.02 {
+			t.Errorf("Expected %s to be picked %.3f of the time, got %.3f", playlist.ID, expected, share)
+		}
+	}
+}
+
+func TestSelectRandomPlaylists_Reproducible(t *testing.T) {
+	playlists := []core.Playlist{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "e"}, {ID: "f"}}
+	first, second := newSeededClient(), newSeededClient()
+
+	for range 10 {
+		selectedFirst := first.selectRandomPlaylists(playlists, 3)
+		selectedSecond := second.selectRandomPlaylists(playlists, 3)
+		if !slices.Equal(selectedFirst, selectedSecond) {
+			t.Fatalf("Expected the same selection with the same seed, got %v and %v", selectedFirst, selectedSecond)
+		}
+	}
+}
+
+func TestGenerateSamplingPages(t *testing.T) {
+	const total, want = 250, 10
+	pages := newSeededClient().generateSamplingPages(total, want)
+
+	count := 0
+	for page, offsets := range pages {
+		for _, offset := range offsets {
+			if index := page*100 + offset; offset >= 100 || index >= total {
+				t.Errorf("Expected indices within the playlist, got page %d offset %d", page, offset)
+			}
+		}
+		count += len(offsets)
+	}
+	if count != want*2 {
+		t.Errorf("Expected %d oversampled indices, got %d", want*2, count)
+	}
+
+	// Sampling more than the playlist has takes every track once
+	pages = newSeededClient().generateSamplingPages(5, 4)
+	offsets := slices.Sorted(slices.Values(pages[0]))
+	if len(pages) != 1 || !slices.Equal(offsets, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Expected all tracks of the first page, got %v", pages)
+	}
+}
+
+func TestGenerateSamplingPages_Reproducible(t *testing.T) {
+	first := newSeededClient().generateSamplingPages(1000, 20)
+	second := newSeededClient().generateSamplingPages(1000, 20)
+
+	if len(first) != len(second) {
+		t.Fatalf("Expected the same pages with the same seed, got %v and %v", first, second)
+	}
+	for page, offsets := range first {
+		// Offsets are grouped from a set, so only their order may differ
+		if !slices.Equal(slices.Sorted(slices.Values(offsets)), slices.Sorted(slices.Values(second[page]))) {
+			t.Errorf("Expected the same offsets of page %d with the same seed, got %v and %v", page, offsets, second[page])
+		}
+	}
+}
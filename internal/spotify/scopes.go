@@ -0,0 +1,56 @@
+// OAuth Scopes
+// This module tracks the scopes Spotify granted with the saved token, so adding a scope to
+// requiredScopes triggers a new authorization on startup instead of failing calls with 403.
+
+package spotify
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	spotifyauth "github.com/zmb3/spotify/v2/auth"
+	"golang.org/x/oauth2"
+)
+
+// requiredScopes are the scopes requested on authorization and needed by the client.
+var requiredScopes = []string{
+	spotifyauth.ScopePlaylistModifyPublic,
+	spotifyauth.ScopePlaylistModifyPrivate,
+	spotifyauth.ScopePlaylistReadPrivate,
+	spotifyauth.ScopeUserModifyPlaybackState,
+	spotifyauth.ScopeUserReadCurrentlyPlaying,
+	spotifyauth.ScopeUserReadPlaybackState,
+}
+
+// errMissingScopes is returned for saved tokens granted before a required scope was added.
+var errMissingScopes = errors.New("saved token lacks required scopes")
+
+// grantedScopes returns the scopes Spotify granted with the token, or nil if the token response didn't list them.
+func grantedScopes(token *oauth2.Token) []string {
+	scope, ok := token.Extra("scope").(string)
+	if !ok || scope == "" {
+		return nil
+	}
+	return strings.Fields(scope)
+}
+
+// checkScopes returns an error naming the required scopes that weren't granted.
+// Tokens saved without their scopes are accepted, since re-authorizing them on every start isn't needed.
+func checkScopes(granted []string) error {
+	if granted == nil {
+		return nil
+	}
+
+	var missing []string
+	for _, scope := range requiredScopes {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", errMissingScopes, strings.Join(missing, ", "))
+	}
+	return nil
+}
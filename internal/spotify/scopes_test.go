@@ -0,0 +1,50 @@
+package spotify
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"djalgorhythm/internal/core"
+)
+
+func TestCheckScopes(t *testing.T) {
+	if err := checkScopes(nil); err != nil {
+		t.Errorf("Expected tokens without recorded scopes to be accepted, got %v", err)
+	}
+	if err := checkScopes(requiredScopes); err != nil {
+		t.Errorf("Expected all required scopes to be accepted, got %v", err)
+	}
+
+	err := checkScopes(requiredScopes[1:])
+	if !errors.Is(err, errMissingScopes) || !strings.Contains(err.Error(), requiredScopes[0]) {
+		t.Errorf("Expected the missing scope to be named, got %v", err)
+	}
+}
+
+func TestSaveToken_Scopes(t *testing.T) {
+	c := &Client{config: &core.SpotifyConfig{TokenPath: filepath.Join(t.TempDir(), "token.json")}}
+
+	granted := (&oauth2.Token{AccessToken: "a"}).WithExtra(map[string]any{"scope": strings.Join(requiredScopes, " ")})
+	if err := c.saveToken(granted); err != nil {
+		t.Fatalf("saveToken() error = %v", err)
+	}
+
+	// Refreshed tokens often don't list the scopes again
+	if err := c.saveToken(&oauth2.Token{AccessToken: "b"}); err != nil {
+		t.Fatalf("saveToken() error = %v", err)
+	}
+
+	loaded := &Client{config: c.config}
+	token, err := loaded.loadToken()
+	if err != nil {
+		t.Fatalf("loadToken() error = %v", err)
+	}
+	if token.AccessToken != "b" || len(loaded.scopes) != len(requiredScopes) {
+		t.Errorf("Expected the refreshed token with the scopes of the authorization, got %q and %v",
+			token.AccessToken, loaded.scopes)
+	}
+}
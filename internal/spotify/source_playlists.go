@@ -0,0 +1,162 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zmb3/spotify/v2"
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/core"
+)
+
+// sourcePlaylistRefreshInterval is how long the cached tracks of a source playlist are used
+// before its snapshot is checked for changes again.
+const sourcePlaylistRefreshInterval = 10 * time.Minute
+
+// cachedSourcePlaylist holds the tracks of a source playlist at one snapshot.
+type cachedSourcePlaylist struct {
+	snapshotID string
+	checkedAt  time.Time
+	tracks     []core.Track
+}
+
+// sourcePlaylistCache keeps the tracks of the source playlists per snapshot, so filling the queue only
+// fetches a source playlist again once it changed. Safe for concurrent use.
+type sourcePlaylistCache struct {
+	mutex     sync.Mutex
+	playlists map[string]cachedSourcePlaylist
+}
+
+// get returns the cached tracks of the playlist, if any.
+func (s *sourcePlaylistCache) get(playlistID string) (cachedSourcePlaylist, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cached, ok := s.playlists[playlistID]
+	return cached, ok
+}
+
+// put caches the tracks of the playlist at the given snapshot.
+func (s *sourcePlaylistCache) put(playlistID string, cached cachedSourcePlaylist) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.playlists == nil {
+		s.playlists = make(map[string]cachedSourcePlaylist)
+	}
+	s.playlists[playlistID] = cached
+}
+
+// findTrackFromSourcePlaylists draws candidates from the configured source playlists and ranks them by the
+// search query and the transition from the previous track. Returns an error once the pool has no tracks left
+// that aren't in the target playlist.
+func (c *Client) findTrackFromSourcePlaylists(ctx context.Context, searchQuery, previousTrackID string,
+	playlistTracks []core.Track) (string, error) {
+	playlists := c.getSourcePlaylistTracks(ctx)
+	if len(playlists) == 0 {
+		return "", errors.New("no source playlists available")
+	}
+
+	// Visit the source playlists in random order, so the first ones don't dominate the candidates
+	c.rng.Shuffle(len(playlists), func(i, j int) { playlists[i], playlists[j] = playlists[j], playlists[i] })
+
+	candidates := c.sampleSourcePlaylistTracks(playlists, c.candidateExclusions(playlistTracks), MaxTotalCandidates)
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("source playlists exhausted: no candidate tracks left in %d playlists", len(playlists))
+	}
+
+	return c.selectRankedTrack(ctx, searchQuery, previousTrackID, candidates)
+}
+
+// getSourcePlaylistTracks returns the tracks of each configured source playlist, skipping the ones that can't be read.
+func (c *Client) getSourcePlaylistTracks(ctx context.Context) [][]core.Track {
+	playlists := make([][]core.Track, 0, len(c.config.RecommendationSourcePlaylists))
+	for _, playlistID := range c.config.RecommendationSourcePlaylists {
+		tracks, err := c.sourcePlaylistTracks(ctx, playlistID)
+		if err != nil {
+			c.logger.Warn("Failed to get source playlist",
+				zap.String("playlistID", playlistID),
+				zap.Error(err))
+			continue
+		}
+		playlists = append(playlists, tracks)
+	}
+	return playlists
+}
+
+// sourcePlaylistTracks returns the tracks of a source playlist from the cache. They are fetched again
+// only if the snapshot of the playlist changed; while it can't be checked, the cached tracks are used.
+func (c *Client) sourcePlaylistTracks(ctx context.Context, playlistID string) ([]core.Track, error) {
+	cached, ok := c.sourcePlaylists.get(playlistID)
+	if ok && time.Since(cached.checkedAt) < sourcePlaylistRefreshInterval {
+		return cached.tracks, nil
+	}
+
+	playlist, err := doWithRetry(ctx, c, "get playlist", func(ctx context.Context) (*spotify.FullPlaylist, error) {
+		return c.client.GetPlaylist(ctx, spotify.ID(playlistID), spotify.Fields("snapshot_id"))
+	})
+	if err != nil {
+		if ok {
+			c.logger.Debug("Failed to check source playlist snapshot, using cached tracks",
+				zap.String("playlistID", playlistID),
+				zap.Error(err))
+			return cached.tracks, nil
+		}
+		return nil, err
+	}
+
+	if ok && cached.snapshotID == playlist.SnapshotID {
+		cached.checkedAt = time.Now()
+		c.sourcePlaylists.put(playlistID, cached)
+		return cached.tracks, nil
+	}
+
+	tracks, err := c.GetPlaylistTracksWithDetails(ctx, playlistID)
+	if err != nil {
+		if ok {
+			return cached.tracks, nil
+		}
+		return nil, err
+	}
+
+	c.sourcePlaylists.put(playlistID, cachedSourcePlaylist{
+		snapshotID: playlist.SnapshotID,
+		checkedAt:  time.Now(),
+		tracks:     tracks,
+	})
+	return tracks, nil
+}
+
+// sampleSourcePlaylistTracks draws up to MaxCandidateTracksPerPlaylist random tracks from each playlist,
+// skipping excluded tracks and tracks drawn before, until maxCandidates tracks are collected.
+func (c *Client) sampleSourcePlaylistTracks(playlists [][]core.Track, exclude map[string]struct{},
+	maxCandidates int) []core.Track {
+	seen := make(map[string]struct{}, maxCandidates)
+	candidates := make([]core.Track, 0, maxCandidates)
+
+	for _, tracks := range playlists {
+		sampled := 0
+		for _, i := range c.rng.Perm(len(tracks)) {
+			if sampled >= MaxCandidateTracksPerPlaylist || len(candidates) >= maxCandidates {
+				break
+			}
+
+			track := tracks[i]
+			if _, excluded := exclude[track.ID]; excluded {
+				continue
+			}
+			if _, duplicate := seen[track.ID]; duplicate {
+				continue
+			}
+			seen[track.ID] = struct{}{}
+			candidates = append(candidates, track)
+			sampled++
+		}
+	}
+
+	return candidates
+}
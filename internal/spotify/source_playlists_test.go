@@ -0,0 +1,113 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zmb3/spotify/v2"
+	"go.uber.org/zap"
+
+	"djalgorhythm/internal/core"
+)
+
+// sourcePlaylistServer serves a playlist with the given snapshot and tracks and counts the requests for its tracks.
+type sourcePlaylistServer struct {
+	snapshotID    atomic.Value
+	trackIDs      []string
+	trackRequests atomic.Int32
+}
+
+func (s *sourcePlaylistServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/tracks") {
+		s.trackRequests.Add(1)
+		items := make([]map[string]any, 0, len(s.trackIDs))
+		for _, trackID := range s.trackIDs {
+			items = append(items, map[string]any{"track": map[string]any{"id": trackID, "name": trackID, "type": "track"}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items, "total": len(items)})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"snapshot_id": s.snapshotID.Load()})
+}
+
+// newSourcePlaylistTestClient creates a client whose API requests go to a server with a single source playlist.
+func newSourcePlaylistTestClient(t *testing.T, trackIDs ...string) (*Client, *sourcePlaylistServer) {
+	t.Helper()
+	playlist := &sourcePlaylistServer{trackIDs: trackIDs}
+	playlist.snapshotID.Store("first")
+	server := httptest.NewServer(playlist)
+	t.Cleanup(server.Close)
+
+	c := newSeededClient()
+	c.logger = zap.NewNop()
+	c.config = &core.SpotifyConfig{RecommendationSourcePlaylists: []string{"source"}}
+	c.client = spotify.New(server.Client(), spotify.WithBaseURL(server.URL+"/"))
+	return c, playlist
+}
+
+func TestSourcePlaylistTracks_CachedPerSnapshot(t *testing.T) {
+	ctx := context.Background()
+	c, playlist := newSourcePlaylistTestClient(t, "a", "b")
+
+	for range 3 {
+		if tracks, err := c.sourcePlaylistTracks(ctx, "source"); err != nil || len(tracks) != 2 {
+			t.Fatalf("sourcePlaylistTracks() = %v, %v; want the 2 tracks", tracks, err)
+		}
+	}
+	if playlist.trackRequests.Load() != 1 {
+		t.Errorf("Expected the tracks to be fetched once, got %d requests", playlist.trackRequests.Load())
+	}
+
+	// Once the refresh interval passed, an unchanged snapshot keeps the cached tracks
+	expireSourcePlaylist(c, "source")
+	if _, err := c.sourcePlaylistTracks(ctx, "source"); err != nil || playlist.trackRequests.Load() != 1 {
+		t.Errorf("Expected an unchanged playlist not to be fetched again, got %d requests, %v",
+			playlist.trackRequests.Load(), err)
+	}
+
+	expireSourcePlaylist(c, "source")
+	playlist.snapshotID.Store("second")
+	playlist.trackIDs = append(playlist.trackIDs, "c")
+	if tracks, err := c.sourcePlaylistTracks(ctx, "source"); err != nil || len(tracks) != 3 {
+		t.Errorf("Expected a changed playlist to be fetched again, got %v, %v", tracks, err)
+	}
+}
+
+// expireSourcePlaylist makes the cached tracks of the playlist due for a snapshot check.
+func expireSourcePlaylist(c *Client, playlistID string) {
+	cached, _ := c.sourcePlaylists.get(playlistID)
+	cached.checkedAt = time.Now().Add(-sourcePlaylistRefreshInterval)
+	c.sourcePlaylists.put(playlistID, cached)
+}
+
+func TestSampleSourcePlaylistTracks(t *testing.T) {
+	playlists := [][]core.Track{
+		{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "excluded"}},
+		{{ID: "a"}, {ID: "d"}},
+		{{ID: "excluded"}},
+	}
+	exclude := map[string]struct{}{"excluded": {}}
+
+	candidates := newSeededClient().sampleSourcePlaylistTracks(playlists, exclude, MaxTotalCandidates)
+	if len(candidates) < 3 || len(candidates) > 2*MaxCandidateTracksPerPlaylist {
+		t.Errorf("Expected at most %d tracks per playlist, got %v", MaxCandidateTracksPerPlaylist, candidates)
+	}
+
+	seen := make(map[string]bool)
+	for _, track := range candidates {
+		if track.ID == "excluded" || seen[track.ID] {
+			t.Errorf("Expected no excluded or duplicate tracks, got %v", candidates)
+		}
+		seen[track.ID] = true
+	}
+
+	if limited := newSeededClient().sampleSourcePlaylistTracks(playlists, exclude, 1); len(limited) != 1 {
+		t.Errorf("Expected the candidates to be limited to 1, got %v", limited)
+	}
+}
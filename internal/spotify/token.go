@@ -0,0 +1,189 @@
+package spotify
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/zmb3/spotify/v2"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+
+	"djalgorhythm/internal/core"
+)
+
+// reauthStateBytes is the number of random bytes in the state of a re-authorization.
+const reauthStateBytes = 16
+
+// persistingTokenSource refreshes the OAuth2 token once it expired and saves every new token,
+// so a refresh token rotated by Spotify survives restarts. The token can be replaced after a
+// re-authorization without recreating the API client.
+type persistingTokenSource struct {
+	ctx    context.Context //nolint:containedctx // Used for token refreshes of the long-lived API client.
+	client *Client
+	mutex  sync.Mutex
+	token  *oauth2.Token
+}
+
+// Token returns the current token, refreshing and saving it if it expired.
+func (s *persistingTokenSource) Token() (*oauth2.Token, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	token, err := s.client.auth.RefreshToken(s.ctx, s.token)
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			return nil, fmt.Errorf("%w: %w", core.ErrSpotifyAuthExpired, err)
+		}
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	if token.RefreshToken == "" {
+		token.RefreshToken = s.token.RefreshToken
+	}
+	if token.RefreshToken != s.token.RefreshToken {
+		s.client.logger.Info("Spotify rotated the refresh token")
+	}
+
+	s.setToken(token)
+	return token, nil
+}
+
+// replace swaps in the token of a re-authorization.
+func (s *persistingTokenSource) replace(token *oauth2.Token) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.setToken(token)
+}
+
+// expiry returns when the current access token expires.
+func (s *persistingTokenSource) expiry() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.token.Expiry
+}
+
+// setToken stores and saves a token. The caller must hold the mutex.
+func (s *persistingTokenSource) setToken(token *oauth2.Token) {
+	s.token = token
+	if err := s.client.saveToken(token); err != nil {
+		s.client.logger.Warn("Failed to save token", zap.Error(err))
+	}
+}
+
+// useToken creates the API client, authenticated with the token and refreshing it as needed.
+func (c *Client) useToken(ctx context.Context, token *oauth2.Token) *spotify.Client {
+	c.tokenSource = &persistingTokenSource{ctx: ctx, client: c, token: token}
+	return c.newAPIClient(oauth2.NewClient(ctx, c.tokenSource))
+}
+
+// TokenExpiry returns when the current access token expires, or the zero time if not authenticated.
+// The token is refreshed automatically while the refresh token is valid.
+func (c *Client) TokenExpiry() time.Time {
+	if c.tokenSource == nil {
+		return time.Time{}
+	}
+	return c.tokenSource.expiry()
+}
+
+// ValidateToken checks that the authorization still works by fetching the current user.
+// It returns an error wrapping core.ErrSpotifyAuthExpired if Spotify requires a re-authorization.
+func (c *Client) ValidateToken(ctx context.Context) error {
+	if c.client == nil {
+		return errors.New("client not authenticated")
+	}
+
+	_, err := doWithRetry(ctx, c, "validate token", func(ctx context.Context) (*spotify.PrivateUser, error) {
+		return c.client.CurrentUser(ctx)
+	})
+	if err == nil {
+		return nil
+	}
+
+	var spotifyErr spotify.Error
+	if errors.As(err, &spotifyErr) && spotifyErr.Status == http.StatusUnauthorized {
+		return fmt.Errorf("%w: %w", core.ErrSpotifyAuthExpired, err)
+	}
+	return err
+}
+
+// ReauthorizationURL starts a re-authorization and returns the URL an admin has to visit.
+// Spotify redirects back to ReauthorizationCallback, which must be served at the redirect URL.
+func (c *Client) ReauthorizationURL() string {
+	stateBytes := make([]byte, reauthStateBytes)
+	_, _ = rand.Read(stateBytes)
+	state := hex.EncodeToString(stateBytes)
+
+	c.reauthMutex.Lock()
+	c.reauthState = state
+	c.reauthMutex.Unlock()
+
+	return c.auth.AuthURL(state)
+}
+
+// ReauthorizationRouter handles the redirects of re-authorizations started by any of the clients, which share
+// one redirect URL, by passing each to the client that started it.
+func ReauthorizationRouter(clients ...*Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := r.URL.Query().Get("state")
+		for _, c := range clients {
+			if c.startedReauthorization(state) {
+				c.ReauthorizationCallback().ServeHTTP(w, r)
+				return
+			}
+		}
+		http.Error(w, "Invalid or expired authorization request", http.StatusBadRequest)
+	})
+}
+
+// startedReauthorization reports whether the pending re-authorization of the client has the given state.
+func (c *Client) startedReauthorization(state string) bool {
+	c.reauthMutex.Lock()
+	defer c.reauthMutex.Unlock()
+	return c.reauthState != "" && c.reauthState == state
+}
+
+// ReauthorizationCallback handles the redirect of a re-authorization started with ReauthorizationURL
+// and swaps in the new token.
+func (c *Client) ReauthorizationCallback() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := r.URL.Query().Get("state")
+		if !c.startedReauthorization(state) {
+			http.Error(w, "Invalid or expired authorization request", http.StatusBadRequest)
+			return
+		}
+		if c.tokenSource == nil {
+			http.Error(w, "Spotify client not initialized", http.StatusServiceUnavailable)
+			return
+		}
+
+		token, err := c.auth.Token(r.Context(), state, r)
+		if err != nil {
+			c.logger.Warn("Spotify re-authorization failed", zap.Error(err))
+			http.Error(w, "Authorization failed", http.StatusBadRequest)
+			return
+		}
+
+		c.reauthMutex.Lock()
+		c.reauthState = ""
+		c.reauthMutex.Unlock()
+
+		c.tokenSource.replace(token)
+		c.logger.Info("Spotify re-authorization completed")
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if _, err := w.Write([]byte("DJAlgoRhythm has been authorized again. You can close this window.")); err != nil {
+			c.logger.Debug("Failed to write re-authorization response", zap.Error(err))
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package spotify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReauthorizationRouter(t *testing.T) {
+	primary, group := &Client{}, &Client{reauthState: "group-state"}
+	router := ReauthorizationRouter(primary, group)
+
+	// The group client started the re-authorization, but has no token source to swap the token into
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?state=group-state", http.NoBody))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected the callback to reach the group client, got %d: %s", rec.Code, rec.Body)
+	}
+
+	for _, target := range []string{"/callback?state=other", "/callback", "/callback?state="} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected an unknown state to be rejected, got %d", target, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"sync"
+	"time"
+)
+
+// Cooldown remembers when keys were last used and reports how long until they may be used again.
+// Unlike the dedup store, entries expire on their own once the window has elapsed.
+type Cooldown struct {
+	window     time.Duration
+	lastUsed   map[string]time.Time
+	lastPruned time.Time
+	mutex      sync.Mutex
+	now        func() time.Time
+}
+
+// NewCooldown creates a new cooldown tracker with the given window.
+func NewCooldown(window time.Duration) *Cooldown {
+	return &Cooldown{
+		window:     window,
+		lastUsed:   make(map[string]time.Time),
+		lastPruned: time.Now(),
+		now:        time.Now,
+	}
+}
+
+// Remaining returns how long the key is still cooling down (zero if it may be used).
+func (c *Cooldown) Remaining(key string) time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	lastUsed, exists := c.lastUsed[key]
+	if !exists {
+		return 0
+	}
+	return max(lastUsed.Add(c.window).Sub(c.now()), 0)
+}
+
+// Record starts a new cooldown window for the key.
+func (c *Cooldown) Record(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Drop expired entries once per window so the map doesn't grow forever
+	if c.now().Sub(c.lastPruned) >= c.window {
+		c.prune()
+	}
+
+	c.lastUsed[key] = c.now()
+}
+
+// Len returns the number of keys currently tracked, including expired ones not yet pruned.
+func (c *Cooldown) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.lastUsed)
+}
+
+// prune removes all entries whose window has elapsed. Must be called with the mutex held.
+func (c *Cooldown) prune() {
+	now := c.now()
+	for key, lastUsed := range c.lastUsed {
+		if now.Sub(lastUsed) >= c.window {
+			delete(c.lastUsed, key)
+		}
+	}
+	c.lastPruned = now
+}
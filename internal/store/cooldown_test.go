@@ -0,0 +1,62 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCooldown_Remaining(t *testing.T) {
+	cooldown := NewCooldown(10 * time.Minute)
+
+	now := time.Now()
+	cooldown.now = func() time.Time { return now }
+
+	if remaining := cooldown.Remaining("track1"); remaining != 0 {
+		t.Errorf("Unknown track should not be cooling down, got %v", remaining)
+	}
+
+	cooldown.Record("track1")
+	if remaining := cooldown.Remaining("track1"); remaining != 10*time.Minute {
+		t.Errorf("Expected 10m remaining right after recording, got %v", remaining)
+	}
+
+	now = now.Add(4 * time.Minute)
+	if remaining := cooldown.Remaining("track1"); remaining != 6*time.Minute {
+		t.Errorf("Expected 6m remaining, got %v", remaining)
+	}
+	if remaining := cooldown.Remaining("track2"); remaining != 0 {
+		t.Errorf("Other tracks should not be affected, got %v", remaining)
+	}
+
+	now = now.Add(6 * time.Minute)
+	if remaining := cooldown.Remaining("track1"); remaining != 0 {
+		t.Errorf("Cooldown should be over after the window, got %v", remaining)
+	}
+}
+
+func TestCooldown_PrunesExpiredEntries(t *testing.T) {
+	cooldown := NewCooldown(time.Hour)
+
+	now := time.Now()
+	cooldown.now = func() time.Time { return now }
+	cooldown.lastPruned = now
+
+	cooldown.Record("track1")
+	cooldown.Record("track2")
+
+	// Expired entries are kept until the next periodic prune
+	now = now.Add(30 * time.Minute)
+	cooldown.Record("track3")
+	if cooldown.Len() != 3 {
+		t.Errorf("Expected 3 tracked entries before pruning, got %d", cooldown.Len())
+	}
+
+	now = now.Add(45 * time.Minute)
+	cooldown.Record("track4")
+	if cooldown.Len() != 2 {
+		t.Errorf("Expected expired entries to be pruned, got %d entries", cooldown.Len())
+	}
+	if cooldown.Remaining("track3") == 0 {
+		t.Error("Track still within its window should not be pruned")
+	}
+}
@@ -2,25 +2,52 @@
 package store
 
 import (
+	"math"
+	"strings"
 	"sync"
 
 	"github.com/bits-and-blooms/bloom/v3"
 	lru "github.com/hashicorp/golang-lru/v2"
+
+	"djalgorhythm/pkg/fuzzy"
 )
 
+// bloomRotationRatio is the share of its capacity after which the Bloom filter is rebuilt.
+const bloomRotationRatio = 0.9
+
 // DedupStore provides thread-safe deduplication storage using Bloom filters and LRU cache.
+// The Bloom filter is only used as a fast negative check; the exact trackIDs set is authoritative.
+// This allows tracks to be removed even though Bloom filters cannot delete entries.
+// Removed and evicted tracks still fill the Bloom filter, so it is rebuilt from the stored tracks
+// before it saturates, keeping its false positive rate bounded in long-running deployments.
+// Normalized artist+title signatures are kept alongside to detect different versions of the same song,
+// and ISRCs to detect the same recording released under different track IDs.
 type DedupStore struct {
 	trackIDs               map[string]struct{}
+	signatures             map[string]trackSignature    // trackID -> normalized signature
+	artistIndex            map[string]map[string]string // normalized artist -> trackID -> normalized title
+	trackISRCs             map[string]string            // trackID -> normalized ISRC
+	isrcIndex              map[string]string            // normalized ISRC -> trackID
+	normalizer             *fuzzy.Normalizer
 	bloom                  *bloom.BloomFilter
+	bloomCapacity          uint // Number of insertions the current Bloom filter was sized for
+	bloomInserts           uint // Number of insertions into the current Bloom filter
 	lru                    *lru.Cache[string, struct{}]
 	mutex                  sync.RWMutex
 	maxTracks              int
 	bloomFalsePositiveRate float64
 }
 
+// trackSignature is the normalized artist and title of a track.
+type trackSignature struct {
+	artist string
+	title  string
+}
+
 // NewDedupStore creates a new deduplication store with the specified capacity and false positive rate.
 func NewDedupStore(maxTracks int, bloomFalsePositiveRate float64) *DedupStore {
-	lruCache, _ := lru.New[string, struct{}](maxTracks)
+	// One spare slot, so the LRU cache never evicts on its own and evictOldest keeps all indexes in sync
+	lruCache, _ := lru.New[string, struct{}](maxTracks + 1)
 
 	if maxTracks < 0 || maxTracks > int(^uint(0)>>1) {
 		panic("maxTracks value out of range for uint conversion")
@@ -29,7 +56,13 @@ func NewDedupStore(maxTracks int, bloomFalsePositiveRate float64) *DedupStore {
 
 	return &DedupStore{
 		trackIDs:               make(map[string]struct{}),
+		signatures:             make(map[string]trackSignature),
+		artistIndex:            make(map[string]map[string]string),
+		trackISRCs:             make(map[string]string),
+		isrcIndex:              make(map[string]string),
+		normalizer:             fuzzy.NewNormalizer(),
 		bloom:                  bloomFilter,
+		bloomCapacity:          uint(maxTracks),
 		lru:                    lruCache,
 		maxTracks:              maxTracks,
 		bloomFalsePositiveRate: bloomFalsePositiveRate,
@@ -59,7 +92,7 @@ func (ds *DedupStore) Add(trackID string) {
 	}
 
 	ds.trackIDs[trackID] = struct{}{}
-	ds.bloom.AddString(trackID)
+	ds.addToBloom(trackID)
 	ds.lru.Add(trackID, struct{}{})
 
 	if len(ds.trackIDs) > ds.maxTracks {
@@ -78,8 +111,10 @@ func (ds *DedupStore) Remove(trackID string) {
 
 	delete(ds.trackIDs, trackID)
 	ds.lru.Remove(trackID)
-	// Note: We can't remove from bloom filter as it doesn't support removal
-	// This may cause false positives, but that's acceptable for this use case
+	ds.removeSignature(trackID)
+	ds.removeISRC(trackID)
+	// Note: We can't remove from bloom filter as it doesn't support removal.
+	// The stale bloom bit only costs an extra exact-set lookup in Has, which then reports false.
 }
 
 // Load clears the store and loads the provided track IDs.
@@ -92,7 +127,7 @@ func (ds *DedupStore) Load(trackIDs []string) {
 	for _, trackID := range trackIDs {
 		if trackID != "" {
 			ds.trackIDs[trackID] = struct{}{}
-			ds.bloom.AddString(trackID)
+			ds.addToBloom(trackID)
 			ds.lru.Add(trackID, struct{}{})
 		}
 	}
@@ -109,6 +144,17 @@ func (ds *DedupStore) Size() int {
 	return len(ds.trackIDs)
 }
 
+// FalsePositiveRate returns the estimated false positive rate of the Bloom filter,
+// which grows with every insertion until the filter is rebuilt.
+func (ds *DedupStore) FalsePositiveRate() float64 {
+	ds.mutex.RLock()
+	defer ds.mutex.RUnlock()
+
+	k := float64(ds.bloom.K())
+	m := float64(ds.bloom.Cap())
+	return math.Pow(1-math.Exp(-k*float64(ds.bloomInserts)/m), k)
+}
+
 // Clear removes all track IDs from the store.
 func (ds *DedupStore) Clear() {
 	ds.mutex.Lock()
@@ -116,15 +162,180 @@ func (ds *DedupStore) Clear() {
 	ds.clear()
 }
 
+// AddSignature stores the normalized artist and title of a tracked track for near-duplicate detection.
+// Signatures of unknown track IDs are ignored.
+func (ds *DedupStore) AddSignature(trackID, artist, title string) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	if _, exists := ds.trackIDs[trackID]; !exists {
+		return
+	}
+
+	ds.removeSignature(trackID)
+
+	signature := trackSignature{
+		artist: ds.normalizer.NormalizeArtist(artist),
+		title:  ds.normalizer.NormalizeTitle(title),
+	}
+	if signature.title == "" {
+		return
+	}
+
+	ds.signatures[trackID] = signature
+	titles, exists := ds.artistIndex[signature.artist]
+	if !exists {
+		titles = make(map[string]string)
+		ds.artistIndex[signature.artist] = titles
+	}
+	titles[trackID] = signature.title
+}
+
+// FindNearDuplicate returns the ID of a stored track by the same artist whose normalized title
+// is at least threshold similar to the given title, preferring the most similar one.
+// Returns an empty ID if no such track exists.
+func (ds *DedupStore) FindNearDuplicate(artist, title string, threshold float64) (string, float64) {
+	normalizedArtist := ds.normalizer.NormalizeArtist(artist)
+	normalizedTitle := ds.normalizer.NormalizeTitle(title)
+	if normalizedTitle == "" {
+		return "", 0
+	}
+
+	ds.mutex.RLock()
+	defer ds.mutex.RUnlock()
+
+	// Only tracks by the same artist are compared, which keeps the check cheap for large playlists
+	bestID := ""
+	bestSimilarity := 0.0
+	for trackID, storedTitle := range ds.artistIndex[normalizedArtist] {
+		if storedTitle == normalizedTitle {
+			return trackID, 1.0
+		}
+
+		// The similarity can never exceed the length ratio, so skip the comparison if that is too low
+		shorter, longer := len(storedTitle), len(normalizedTitle)
+		if shorter > longer {
+			shorter, longer = longer, shorter
+		}
+		if float64(shorter)/float64(longer) < threshold {
+			continue
+		}
+
+		similarity := ds.normalizer.CalculateSimilarity(storedTitle, normalizedTitle)
+		if similarity >= threshold && similarity > bestSimilarity {
+			bestID = trackID
+			bestSimilarity = similarity
+		}
+	}
+
+	return bestID, bestSimilarity
+}
+
+// AddISRC stores the ISRC of a tracked track so other releases of the same recording are detected.
+// ISRCs of unknown track IDs and empty ISRCs are ignored.
+func (ds *DedupStore) AddISRC(trackID, isrc string) {
+	normalized := normalizeISRC(isrc)
+	if normalized == "" {
+		return
+	}
+
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	if _, exists := ds.trackIDs[trackID]; !exists {
+		return
+	}
+
+	ds.removeISRC(trackID)
+	ds.trackISRCs[trackID] = normalized
+	ds.isrcIndex[normalized] = trackID
+}
+
+// FindByISRC returns the ID of a stored track with the given ISRC, or an empty ID if there is none.
+func (ds *DedupStore) FindByISRC(isrc string) string {
+	normalized := normalizeISRC(isrc)
+	if normalized == "" {
+		return ""
+	}
+
+	ds.mutex.RLock()
+	defer ds.mutex.RUnlock()
+
+	return ds.isrcIndex[normalized]
+}
+
+// normalizeISRC uppercases an ISRC and strips the optional hyphens (e.g. "us-rc1-76-07839").
+func normalizeISRC(isrc string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(isrc), "-", ""))
+}
+
+// removeISRC drops the track's ISRC. Must be called with the mutex held.
+func (ds *DedupStore) removeISRC(trackID string) {
+	isrc, exists := ds.trackISRCs[trackID]
+	if !exists {
+		return
+	}
+
+	delete(ds.trackISRCs, trackID)
+	if ds.isrcIndex[isrc] == trackID {
+		delete(ds.isrcIndex, isrc)
+	}
+}
+
+// removeSignature drops the track's signature. Must be called with the mutex held.
+func (ds *DedupStore) removeSignature(trackID string) {
+	signature, exists := ds.signatures[trackID]
+	if !exists {
+		return
+	}
+
+	delete(ds.signatures, trackID)
+	titles := ds.artistIndex[signature.artist]
+	delete(titles, trackID)
+	if len(titles) == 0 {
+		delete(ds.artistIndex, signature.artist)
+	}
+}
+
 func (ds *DedupStore) clear() {
 	ds.trackIDs = make(map[string]struct{})
+	ds.signatures = make(map[string]trackSignature)
+	ds.artistIndex = make(map[string]map[string]string)
+	ds.trackISRCs = make(map[string]string)
+	ds.isrcIndex = make(map[string]string)
 	if ds.maxTracks < 0 || ds.maxTracks > int(^uint(0)>>1) {
 		panic("maxTracks value out of range for uint conversion")
 	}
 	ds.bloom = bloom.NewWithEstimates(uint(ds.maxTracks), ds.bloomFalsePositiveRate)
+	ds.bloomCapacity = uint(ds.maxTracks)
+	ds.bloomInserts = 0
 	ds.lru.Purge()
 }
 
+// addToBloom adds a track ID to the Bloom filter, rebuilding the filter when it nears its capacity.
+// Must be called with the mutex held.
+func (ds *DedupStore) addToBloom(trackID string) {
+	ds.bloom.AddString(trackID)
+	ds.bloomInserts++
+
+	if float64(ds.bloomInserts) >= float64(ds.bloomCapacity)*bloomRotationRatio {
+		ds.rotateBloom()
+	}
+}
+
+// rotateBloom replaces the Bloom filter with a fresh one holding only the stored track IDs,
+// sized for twice as many tracks so it doesn't need to be rebuilt again right away.
+// Must be called with the mutex held.
+func (ds *DedupStore) rotateBloom() {
+	ds.bloomCapacity = max(uint(ds.maxTracks), 2*uint(len(ds.trackIDs)), 1)
+	ds.bloom = bloom.NewWithEstimates(ds.bloomCapacity, ds.bloomFalsePositiveRate)
+	ds.bloomInserts = 0
+	for trackID := range ds.trackIDs {
+		ds.bloom.AddString(trackID)
+		ds.bloomInserts++
+	}
+}
+
 func (ds *DedupStore) evictOldest() {
 	if ds.lru.Len() == 0 {
 		return
@@ -137,4 +348,6 @@ func (ds *DedupStore) evictOldest() {
 
 	delete(ds.trackIDs, oldestKey)
 	ds.lru.Remove(oldestKey)
+	ds.removeSignature(oldestKey)
+	ds.removeISRC(oldestKey)
 }
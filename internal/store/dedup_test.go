@@ -157,6 +157,44 @@ func TestDedupStore_MaxCapacity(t *testing.T) {
 	}
 }
 
+func TestDedupStore_EvictionKeepsIndexesInSync(t *testing.T) {
+	maxTracks := 3
+	store := NewDedupStore(maxTracks, 0.001)
+
+	for i := range maxTracks + 1 {
+		trackID := fmt.Sprintf("track%d", i)
+		store.Add(trackID)
+		store.AddSignature(trackID, "Artist", fmt.Sprintf("Title %d", i))
+		store.AddISRC(trackID, fmt.Sprintf("USRC1700000%d", i))
+	}
+
+	// Exactly the oldest track is evicted, from every index
+	if store.Size() != maxTracks {
+		t.Errorf("Expected the store to stay full with %d tracks, got %d", maxTracks, store.Size())
+	}
+	if store.Has("track0") {
+		t.Error("Store should not have the evicted track0")
+	}
+	if trackID := store.FindByISRC("USRC17000000"); trackID != "" {
+		t.Errorf("Evicted track should not match its ISRC, got %q", trackID)
+	}
+	if trackID, _ := store.FindNearDuplicate("Artist", "Title 0", 1); trackID != "" {
+		t.Errorf("Evicted track should not be a near-duplicate, got %q", trackID)
+	}
+
+	// The LRU cache must not drop the next oldest track on its own
+	if !store.Has("track1") || store.FindByISRC("USRC17000001") != "track1" {
+		t.Error("Store should still have track1 with its ISRC")
+	}
+	store.Add("track4")
+	if store.Has("track1") || store.FindByISRC("USRC17000001") != "" {
+		t.Error("Store should evict track1 from every index next")
+	}
+	if store.Size() != maxTracks {
+		t.Errorf("Expected the store to stay full with %d tracks, got %d", maxTracks, store.Size())
+	}
+}
+
 func TestDedupStore_BloomFilterEffectiveness(t *testing.T) {
 	store := NewDedupStore(1000, 0.001)
 
@@ -193,6 +231,142 @@ func TestDedupStore_BloomFilterEffectiveness(t *testing.T) {
 	}
 }
 
+func TestDedupStore_BloomRotation(t *testing.T) {
+	maxTracks := 100
+	store := NewDedupStore(maxTracks, 0.001)
+
+	// Churn through many more tracks than the capacity, as a multi-day deployment would
+	for i := range 20 * maxTracks {
+		store.Add(fmt.Sprintf("track_%d", i))
+	}
+
+	if rate := store.FalsePositiveRate(); rate > 0.01 {
+		t.Errorf("Expected rotation to keep the false positive rate bounded, got %f", rate)
+	}
+	if store.bloomInserts >= store.bloomCapacity {
+		t.Errorf("Expected the Bloom filter to stay below its capacity, got %d of %d inserts",
+			store.bloomInserts, store.bloomCapacity)
+	}
+
+	// Rotation must keep the most recent tracks
+	for i := 19 * maxTracks; i < 20*maxTracks; i++ {
+		trackID := fmt.Sprintf("track_%d", i)
+		if !store.Has(trackID) {
+			t.Errorf("Store should still have recent track %s after rotation", trackID)
+		}
+	}
+	if store.Has("track_0") {
+		t.Error("Store should not have the evicted track_0")
+	}
+}
+
+func TestDedupStore_FalsePositiveRate(t *testing.T) {
+	store := NewDedupStore(1000, 0.001)
+
+	if rate := store.FalsePositiveRate(); rate != 0 {
+		t.Errorf("Expected an empty store to have no false positives, got %f", rate)
+	}
+
+	for i := range 800 {
+		store.Add(fmt.Sprintf("track_%d", i))
+	}
+	if rate := store.FalsePositiveRate(); rate <= 0 || rate > 0.001 {
+		t.Errorf("Expected a rate within the configured 0.001 below capacity, got %f", rate)
+	}
+
+	store.Clear()
+	if rate := store.FalsePositiveRate(); rate != 0 {
+		t.Errorf("Expected a cleared store to have no false positives, got %f", rate)
+	}
+}
+
+func TestDedupStore_FindNearDuplicate(t *testing.T) {
+	store := NewDedupStore(100, 0.001)
+	store.Add("original")
+	store.AddSignature("original", "Queen", "Bohemian Rhapsody")
+	store.Add("other")
+	store.AddSignature("other", "Queen", "Somebody to Love")
+
+	tests := []struct {
+		name       string
+		artist     string
+		title      string
+		expectedID string
+	}{
+		{"Remastered version", "Queen", "Bohemian Rhapsody - Remastered 2011", "original"},
+		{"Parenthesized version", "queen", "Bohemian Rhapsody (Live Aid)", ""},
+		{"Slightly different title", "Queen", "Bohemian Rhapsodie", "original"},
+		{"Different song by same artist", "Queen", "Radio Ga Ga", ""},
+		{"Same title by different artist", "Panic! At The Disco", "Bohemian Rhapsody", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trackID, _ := store.FindNearDuplicate(tt.artist, tt.title, 0.85)
+			if trackID != tt.expectedID {
+				t.Errorf("FindNearDuplicate(%q, %q) = %q, want %q", tt.artist, tt.title, trackID, tt.expectedID)
+			}
+		})
+	}
+}
+
+func TestDedupStore_SignatureRemoval(t *testing.T) {
+	store := NewDedupStore(100, 0.001)
+
+	// Signatures of untracked IDs are ignored
+	store.AddSignature("unknown", "Queen", "Bohemian Rhapsody")
+	if trackID, _ := store.FindNearDuplicate("Queen", "Bohemian Rhapsody", 0.9); trackID != "" {
+		t.Errorf("Untracked signature should be ignored, got %q", trackID)
+	}
+
+	store.Add("track1")
+	store.AddSignature("track1", "Queen", "Bohemian Rhapsody")
+	store.Remove("track1")
+	if trackID, _ := store.FindNearDuplicate("Queen", "Bohemian Rhapsody", 0.9); trackID != "" {
+		t.Errorf("Removed track should not be a near-duplicate, got %q", trackID)
+	}
+
+	store.Load([]string{"track2"})
+	store.AddSignature("track2", "Queen", "Bohemian Rhapsody")
+	store.Clear()
+	if trackID, _ := store.FindNearDuplicate("Queen", "Bohemian Rhapsody", 0.9); trackID != "" {
+		t.Errorf("Cleared store should have no near-duplicates, got %q", trackID)
+	}
+}
+
+func TestDedupStore_FindByISRC(t *testing.T) {
+	store := NewDedupStore(100, 0.001)
+
+	// ISRCs of untracked IDs and empty ISRCs are ignored
+	store.AddISRC("unknown", "GBUM71029604")
+	store.Add("noisrc")
+	store.AddISRC("noisrc", "")
+	if trackID := store.FindByISRC("GBUM71029604"); trackID != "" {
+		t.Errorf("Untracked ISRC should be ignored, got %q", trackID)
+	}
+	if trackID := store.FindByISRC(""); trackID != "" {
+		t.Errorf("Empty ISRC should never match, got %q", trackID)
+	}
+
+	store.Add("single")
+	store.AddISRC("single", "GBUM71029604")
+	if trackID := store.FindByISRC("gb-um7-10-29604"); trackID != "single" {
+		t.Errorf("Expected ISRC match regardless of case and hyphens, got %q", trackID)
+	}
+
+	store.Remove("single")
+	if trackID := store.FindByISRC("GBUM71029604"); trackID != "" {
+		t.Errorf("Removed track should not match by ISRC, got %q", trackID)
+	}
+
+	store.Load([]string{"compilation"})
+	store.AddISRC("compilation", "GBUM71029604")
+	store.Clear()
+	if trackID := store.FindByISRC("GBUM71029604"); trackID != "" {
+		t.Errorf("Cleared store should have no ISRCs, got %q", trackID)
+	}
+}
+
 func BenchmarkDedupStore_Add(b *testing.B) {
 	store := NewDedupStore(10000, 0.001)
 
@@ -233,3 +407,37 @@ func BenchmarkDedupStore_Load(b *testing.B) {
 		store.Load(tracks)
 	}
 }
+
+func TestDedupStore_Remove(t *testing.T) {
+	store := NewDedupStore(100, 0.001)
+
+	store.Add("track1")
+	store.Add("track2")
+
+	store.Remove("track1")
+
+	// Bloom filter still has track1, but the exact set must win
+	if store.Has("track1") {
+		t.Error("Store should not have track1 after removal")
+	}
+
+	if !store.Has("track2") {
+		t.Error("Store should still have track2")
+	}
+
+	if store.Size() != 1 {
+		t.Errorf("Store size should be 1 after removal, got %d", store.Size())
+	}
+
+	// Removing an unknown track is a no-op
+	store.Remove("unknown")
+	if store.Size() != 1 {
+		t.Errorf("Store size should still be 1, got %d", store.Size())
+	}
+
+	// Removed tracks can be added again
+	store.Add("track1")
+	if !store.Has("track1") {
+		t.Error("Store should have track1 after re-adding")
+	}
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"djalgorhythm/internal/core"
+)
+
+const (
+	eventLogFilePermissions = 0600
+	eventLogMaxBackups      = 5
+)
+
+// EventLog appends added track events as line-delimited JSON to a file.
+// Each event is written straight to the file, so nothing is lost if the process dies,
+// and the file is rotated once it exceeds the maximum size;
+// rotated files are kept as <path>.1 (newest) up to <path>.5 (oldest).
+type EventLog struct {
+	path    string
+	maxSize int64 // Rotation threshold in bytes (0 disables rotation)
+	file    *os.File
+	size    int64
+	mutex   sync.Mutex
+}
+
+// NewEventLog opens (or creates) the event log at path for appending.
+func NewEventLog(path string, maxSize int64) (*EventLog, error) {
+	l := &EventLog{
+		path:    path,
+		maxSize: maxSize,
+	}
+
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// LogTrackAdded appends the event as a single JSON line, rotating the file first if it would grow too large.
+func (l *EventLog) LogTrackAdded(event core.TrackAddedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	data = append(data, '\n')
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return errors.New("event log is closed")
+	}
+
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(data)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.file.Write(data)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the file.
+func (l *EventLog) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.close()
+}
+
+// open opens the log file for appending. Must be called with the mutex held or before first use.
+func (l *EventLog) open() error {
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, eventLogFilePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to open event log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat event log file: %w", err)
+	}
+
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+// close closes the current file. Must be called with the mutex held.
+func (l *EventLog) close() error {
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+	if err != nil {
+		return fmt.Errorf("failed to close event log file: %w", err)
+	}
+	return nil
+}
+
+// rotate shifts existing backups, moves the current file to <path>.1 and reopens a fresh file.
+// Must be called with the mutex held.
+func (l *EventLog) rotate() error {
+	if err := l.close(); err != nil {
+		return err
+	}
+
+	for i := eventLogMaxBackups - 1; i >= 1; i-- {
+		src := fmt.Sprintf("%s.%d", l.path, i)
+		dst := fmt.Sprintf("%s.%d", l.path, i+1)
+		if err := os.Rename(src, dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to rotate event log backup: %w", err)
+		}
+	}
+
+	if err := os.Rename(l.path, l.path+".1"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to rotate event log file: %w", err)
+	}
+
+	return l.open()
+}
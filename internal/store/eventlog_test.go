@@ -0,0 +1,122 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"djalgorhythm/internal/core"
+)
+
+func readEventLog(t *testing.T, path string) []core.TrackAddedEvent {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open event log: %v", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var events []core.TrackAddedEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event core.TrackAddedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Invalid event log line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestEventLog_WritesEventsImmediately(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	eventLog, err := NewEventLog(path, 0)
+	if err != nil {
+		t.Fatalf("NewEventLog failed: %v", err)
+	}
+
+	event := core.TrackAddedEvent{
+		TrackID:        "track1",
+		Title:          "Song",
+		Artist:         "Artist",
+		SenderID:       "user1",
+		SenderName:     "User",
+		ApprovalSource: "admin",
+		Priority:       true,
+	}
+	if err := eventLog.LogTrackAdded(event); err != nil {
+		t.Fatalf("LogTrackAdded failed: %v", err)
+	}
+
+	// Events are on disk right away, without waiting for Close
+	events := readEventLog(t, path)
+	if len(events) != 1 || events[0] != event {
+		t.Fatalf("Expected %+v, got %+v", event, events)
+	}
+
+	if err := eventLog.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if err := eventLog.LogTrackAdded(event); err == nil {
+		t.Error("LogTrackAdded should fail after Close")
+	}
+}
+
+func TestEventLog_AppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	for _, trackID := range []string{"track1", "track2"} {
+		eventLog, err := NewEventLog(path, 0)
+		if err != nil {
+			t.Fatalf("NewEventLog failed: %v", err)
+		}
+		if err := eventLog.LogTrackAdded(core.TrackAddedEvent{TrackID: trackID}); err != nil {
+			t.Fatalf("LogTrackAdded failed: %v", err)
+		}
+		if err := eventLog.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+	}
+
+	if events := readEventLog(t, path); len(events) != 2 {
+		t.Errorf("Expected 2 events after reopening, got %d", len(events))
+	}
+}
+
+func TestEventLog_RotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	// Each event is well over 50 bytes, so every write after the first triggers a rotation
+	eventLog, err := NewEventLog(path, 50)
+	if err != nil {
+		t.Fatalf("NewEventLog failed: %v", err)
+	}
+
+	for _, trackID := range []string{"track1", "track2", "track3"} {
+		if err := eventLog.LogTrackAdded(core.TrackAddedEvent{TrackID: trackID}); err != nil {
+			t.Fatalf("LogTrackAdded failed: %v", err)
+		}
+	}
+	if err := eventLog.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	expected := map[string]string{
+		path:        "track3",
+		path + ".1": "track2",
+		path + ".2": "track1",
+	}
+	for file, trackID := range expected {
+		events := readEventLog(t, file)
+		if len(events) != 1 || events[0].TrackID != trackID {
+			t.Errorf("Expected %s to contain only %s, got %+v", file, trackID, events)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"djalgorhythm/internal/core"
+)
+
+const leaderboardFilePermissions = 0600
+
+// Leaderboard counts accepted song requests per user across sessions.
+// Users are keyed by ID, so a renamed user keeps their count and is shown with the latest known name.
+type Leaderboard struct {
+	path    string // Optional persistence path (empty disables persistence)
+	entries map[string]*leaderboardEntry
+	mutex   sync.Mutex
+}
+
+// leaderboardEntry holds the request count and latest known name of a single user.
+type leaderboardEntry struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// NewLeaderboard creates a new requester leaderboard.
+// If path is set, existing counts are loaded from it and every change is persisted.
+func NewLeaderboard(path string) (*Leaderboard, error) {
+	l := &Leaderboard{
+		path:    path,
+		entries: make(map[string]*leaderboardEntry),
+	}
+
+	if path != "" {
+		if err := l.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// Record counts an accepted request for the user, remembers their current name
+// and persists the leaderboard if configured.
+func (l *Leaderboard) Record(userID, userName string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	entry, exists := l.entries[userID]
+	if !exists {
+		entry = &leaderboardEntry{}
+		l.entries[userID] = entry
+	}
+	entry.Count++
+	if userName != "" {
+		entry.Name = userName
+	}
+
+	return l.save()
+}
+
+// Top returns the users with the most requests, ties ordered by name.
+func (l *Leaderboard) Top(limit int) []core.StatsEntry {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	entries := make([]core.StatsEntry, 0, len(l.entries))
+	for userID, entry := range l.entries {
+		name := entry.Name
+		if name == "" {
+			name = userID
+		}
+		entries = append(entries, core.StatsEntry{Name: name, Count: entry.Count})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries[:min(len(entries), max(limit, 0))]
+}
+
+// load reads the persisted leaderboard from disk. A missing file is not an error.
+func (l *Leaderboard) load() error {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read leaderboard file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &l.entries); err != nil {
+		return fmt.Errorf("failed to parse leaderboard file: %w", err)
+	}
+
+	if l.entries == nil {
+		l.entries = make(map[string]*leaderboardEntry)
+	}
+
+	return nil
+}
+
+// save writes the leaderboard to disk. Must be called with the mutex held.
+func (l *Leaderboard) save() error {
+	if l.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(l.entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal leaderboard: %w", err)
+	}
+
+	if err := os.WriteFile(l.path, data, leaderboardFilePermissions); err != nil {
+		return fmt.Errorf("failed to write leaderboard file: %w", err)
+	}
+
+	return nil
+}
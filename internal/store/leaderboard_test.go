@@ -0,0 +1,98 @@
+package store
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"djalgorhythm/internal/core"
+)
+
+func TestLeaderboard_Record(t *testing.T) {
+	leaderboard, err := NewLeaderboard("")
+	if err != nil {
+		t.Fatalf("NewLeaderboard failed: %v", err)
+	}
+
+	for _, userID := range []string{"user1", "user2", "user1"} {
+		if err := leaderboard.Record(userID, "Name of "+userID); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	expected := []core.StatsEntry{{Name: "Name of user1", Count: 2}, {Name: "Name of user2", Count: 1}}
+	if top := leaderboard.Top(10); !slices.Equal(top, expected) {
+		t.Errorf("Expected %v, got %v", expected, top)
+	}
+}
+
+func TestLeaderboard_Rename(t *testing.T) {
+	leaderboard, err := NewLeaderboard("")
+	if err != nil {
+		t.Fatalf("NewLeaderboard failed: %v", err)
+	}
+
+	if err := leaderboard.Record("user1", "Alice"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := leaderboard.Record("user1", "Alicia"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := leaderboard.Record("user2", ""); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	expected := []core.StatsEntry{{Name: "Alicia", Count: 2}, {Name: "user2", Count: 1}}
+	if top := leaderboard.Top(10); !slices.Equal(top, expected) {
+		t.Errorf("Expected renamed users to keep their count under the latest name, got %v", top)
+	}
+}
+
+func TestLeaderboard_Top(t *testing.T) {
+	leaderboard, err := NewLeaderboard("")
+	if err != nil {
+		t.Fatalf("NewLeaderboard failed: %v", err)
+	}
+
+	requests := map[string]int{"a": 1, "b": 3, "c": 2, "d": 2}
+	for userID, count := range requests {
+		for range count {
+			if err := leaderboard.Record(userID, userID); err != nil {
+				t.Fatalf("Record failed: %v", err)
+			}
+		}
+	}
+
+	expected := []core.StatsEntry{{Name: "b", Count: 3}, {Name: "c", Count: 2}, {Name: "d", Count: 2}}
+	if top := leaderboard.Top(3); !slices.Equal(top, expected) {
+		t.Errorf("Expected the top 3 with ties ordered by name %v, got %v", expected, top)
+	}
+	if top := leaderboard.Top(0); len(top) != 0 {
+		t.Errorf("Expected no entries for a limit of 0, got %v", top)
+	}
+}
+
+func TestLeaderboard_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+
+	leaderboard, err := NewLeaderboard(path)
+	if err != nil {
+		t.Fatalf("NewLeaderboard failed: %v", err)
+	}
+	if err := leaderboard.Record("user1", "Alice"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	reloaded, err := NewLeaderboard(path)
+	if err != nil {
+		t.Fatalf("Reloading leaderboard failed: %v", err)
+	}
+	if err := reloaded.Record("user1", "Alice"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	expected := []core.StatsEntry{{Name: "Alice", Count: 2}}
+	if top := reloaded.Top(10); !slices.Equal(top, expected) {
+		t.Errorf("Leaderboard should survive restarts, got %v", top)
+	}
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const quotaFilePermissions = 0600
+
+// UserQuota tracks accepted song requests per user within a fixed, resettable window.
+// Each user's window starts with their first accepted request and resets once it has elapsed.
+type UserQuota struct {
+	limit   int
+	window  time.Duration
+	path    string // Optional persistence path (empty disables persistence)
+	entries map[string]*quotaEntry
+	mutex   sync.Mutex
+	now     func() time.Time
+}
+
+// quotaEntry holds the request count for a single user's current window.
+type quotaEntry struct {
+	Count       int       `json:"count"`
+	WindowStart time.Time `json:"window_start"`
+}
+
+// NewUserQuota creates a new per-user quota tracker.
+// If path is set, existing quotas are loaded from it and every change is persisted.
+func NewUserQuota(limit int, window time.Duration, path string) (*UserQuota, error) {
+	q := &UserQuota{
+		limit:   limit,
+		window:  window,
+		path:    path,
+		entries: make(map[string]*quotaEntry),
+		now:     time.Now,
+	}
+
+	if path != "" {
+		if err := q.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	return q, nil
+}
+
+// Limit returns the maximum number of accepted requests per user and window.
+func (q *UserQuota) Limit() int {
+	return q.limit
+}
+
+// Remaining returns how many more requests the user may make in the current window.
+func (q *UserQuota) Remaining(userID string) int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	entry := q.currentEntry(userID)
+	if entry == nil {
+		return q.limit
+	}
+	return max(q.limit-entry.Count, 0)
+}
+
+// ResetIn returns the time until the user's quota resets (zero if no window is active).
+func (q *UserQuota) ResetIn(userID string) time.Duration {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	entry := q.currentEntry(userID)
+	if entry == nil {
+		return 0
+	}
+	return entry.WindowStart.Add(q.window).Sub(q.now())
+}
+
+// Record counts an accepted request for the user and persists the quota state if configured.
+func (q *UserQuota) Record(userID string) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	// Drop expired windows of all users so the state doesn't grow forever
+	for id := range q.entries {
+		q.currentEntry(id)
+	}
+
+	entry := q.currentEntry(userID)
+	if entry == nil {
+		entry = &quotaEntry{WindowStart: q.now()}
+		q.entries[userID] = entry
+	}
+	entry.Count++
+
+	return q.save()
+}
+
+// currentEntry returns the user's entry, dropping it if its window has expired.
+// Must be called with the mutex held.
+func (q *UserQuota) currentEntry(userID string) *quotaEntry {
+	entry, exists := q.entries[userID]
+	if !exists {
+		return nil
+	}
+
+	if q.now().Sub(entry.WindowStart) >= q.window {
+		delete(q.entries, userID)
+		return nil
+	}
+
+	return entry
+}
+
+// load reads persisted quotas from disk. A missing file is not an error.
+func (q *UserQuota) load() error {
+	data, err := os.ReadFile(q.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read user quota file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &q.entries); err != nil {
+		return fmt.Errorf("failed to parse user quota file: %w", err)
+	}
+
+	if q.entries == nil {
+		q.entries = make(map[string]*quotaEntry)
+	}
+
+	return nil
+}
+
+// save writes the quotas to disk. Must be called with the mutex held.
+func (q *UserQuota) save() error {
+	if q.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(q.entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal user quotas: %w", err)
+	}
+
+	if err := os.WriteFile(q.path, data, quotaFilePermissions); err != nil {
+		return fmt.Errorf("failed to write user quota file: %w", err)
+	}
+
+	return nil
+}
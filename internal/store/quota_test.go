@@ -0,0 +1,86 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUserQuota_Basic(t *testing.T) {
+	quota, err := NewUserQuota(2, time.Hour, "")
+	if err != nil {
+		t.Fatalf("NewUserQuota failed: %v", err)
+	}
+
+	if quota.Remaining("user1") != 2 {
+		t.Errorf("New user should have full quota, got %d", quota.Remaining("user1"))
+	}
+
+	if err := quota.Record("user1"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := quota.Record("user1"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := quota.Record("user1"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	if quota.Remaining("user1") != 0 {
+		t.Errorf("Remaining should never be negative, got %d", quota.Remaining("user1"))
+	}
+
+	if quota.Remaining("user2") != 2 {
+		t.Errorf("Other users should not be affected, got %d", quota.Remaining("user2"))
+	}
+}
+
+func TestUserQuota_WindowReset(t *testing.T) {
+	quota, err := NewUserQuota(1, time.Hour, "")
+	if err != nil {
+		t.Fatalf("NewUserQuota failed: %v", err)
+	}
+
+	now := time.Now()
+	quota.now = func() time.Time { return now }
+
+	if err := quota.Record("user1"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	if quota.Remaining("user1") != 0 {
+		t.Errorf("Quota should be used up, got %d", quota.Remaining("user1"))
+	}
+	if quota.ResetIn("user1") != time.Hour {
+		t.Errorf("Quota should reset in 1h, got %v", quota.ResetIn("user1"))
+	}
+
+	now = now.Add(time.Hour)
+	if quota.Remaining("user1") != 1 {
+		t.Errorf("Quota should reset after the window, got %d", quota.Remaining("user1"))
+	}
+	if quota.ResetIn("user1") != 0 {
+		t.Errorf("ResetIn should be 0 without an active window, got %v", quota.ResetIn("user1"))
+	}
+}
+
+func TestUserQuota_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quota.json")
+
+	quota, err := NewUserQuota(3, time.Hour, path)
+	if err != nil {
+		t.Fatalf("NewUserQuota failed: %v", err)
+	}
+	if err := quota.Record("user1"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	reloaded, err := NewUserQuota(3, time.Hour, path)
+	if err != nil {
+		t.Fatalf("Reloading quota failed: %v", err)
+	}
+
+	if reloaded.Remaining("user1") != 2 {
+		t.Errorf("Quota should survive restarts, got %d remaining", reloaded.Remaining("user1"))
+	}
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+)
+
+const warningSubscribersFilePermissions = 0600
+
+// WarningSubscribers keeps the users who opted in to receive admin warnings by direct message,
+// including operators who aren't admins of the group.
+type WarningSubscribers struct {
+	path    string // Optional persistence path (empty disables persistence)
+	userIDs []string
+	mutex   sync.Mutex
+}
+
+// NewWarningSubscribers creates a new warning subscriber list.
+// If path is set, existing subscribers are loaded from it and every change is persisted.
+func NewWarningSubscribers(path string) (*WarningSubscribers, error) {
+	s := &WarningSubscribers{path: path}
+
+	if path != "" {
+		if err := s.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Add subscribes the user to admin warnings and persists the list if configured.
+// Adding a subscribed user again is a no-op.
+func (s *WarningSubscribers) Add(userID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if slices.Contains(s.userIDs, userID) {
+		return nil
+	}
+	s.userIDs = append(s.userIDs, userID)
+
+	return s.save()
+}
+
+// UserIDs returns the subscribed users in the order they opted in.
+func (s *WarningSubscribers) UserIDs() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return slices.Clone(s.userIDs)
+}
+
+// load reads the persisted subscribers from disk. A missing file is not an error.
+func (s *WarningSubscribers) load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read warning subscribers file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s.userIDs); err != nil {
+		return fmt.Errorf("failed to parse warning subscribers file: %w", err)
+	}
+
+	return nil
+}
+
+// save writes the subscribers to disk. Must be called with the mutex held.
+func (s *WarningSubscribers) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(s.userIDs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal warning subscribers: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, warningSubscribersFilePermissions); err != nil {
+		return fmt.Errorf("failed to write warning subscribers file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestWarningSubscribers_Add(t *testing.T) {
+	subscribers, err := NewWarningSubscribers("")
+	if err != nil {
+		t.Fatalf("NewWarningSubscribers failed: %v", err)
+	}
+
+	for _, userID := range []string{"user1", "user2", "user1"} {
+		if err := subscribers.Add(userID); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	expected := []string{"user1", "user2"}
+	if userIDs := subscribers.UserIDs(); !slices.Equal(userIDs, expected) {
+		t.Errorf("Expected %v, got %v", expected, userIDs)
+	}
+}
+
+func TestWarningSubscribers_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "warning_subscribers.json")
+
+	subscribers, err := NewWarningSubscribers(path)
+	if err != nil {
+		t.Fatalf("NewWarningSubscribers failed: %v", err)
+	}
+	if err := subscribers.Add("user1"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	reloaded, err := NewWarningSubscribers(path)
+	if err != nil {
+		t.Fatalf("Reloading warning subscribers failed: %v", err)
+	}
+
+	expected := []string{"user1"}
+	if userIDs := reloaded.UserIDs(); !slices.Equal(userIDs, expected) {
+		t.Errorf("Warning subscribers should survive restarts, got %v", userIDs)
+	}
+}
@@ -0,0 +1,526 @@
+package fuzzy
+
+import "strings"
+
+// MetaphoneCodeLength is the maximum length of the Double Metaphone codes of a word.
+const MetaphoneCodeLength = 4
+
+// DoubleMetaphone encodes a word by how it sounds using Lawrence Philips' Double Metaphone algorithm.
+// It returns the primary encoding and an alternate one for words with an ambiguous pronunciation,
+// e.g. "rapsody" and "rhapsody" both encode to "RPST". Letters other than A-Z are ignored.
+func DoubleMetaphone(word string) (primary, alternate string) {
+	m := &metaphone{word: strings.ToUpper(word)}
+	m.slavoGermanic = strings.ContainsAny(m.word, "WK") || strings.Contains(m.word, "CZ") ||
+		strings.Contains(m.word, "WITZ")
+	m.encode()
+
+	primary, alternate = m.primary.String(), m.alternate.String()
+	return truncateCode(primary), truncateCode(alternate)
+}
+
+// phoneticCodes returns the primary and alternate Double Metaphone codes of each word of a normalized text.
+func phoneticCodes(text string) (primary, alternate string) {
+	var primaries, alternates []string
+	for _, word := range strings.Fields(text) {
+		wordPrimary, wordAlternate := DoubleMetaphone(word)
+		if wordPrimary == "" {
+			continue
+		}
+		primaries = append(primaries, wordPrimary)
+		alternates = append(alternates, wordAlternate)
+	}
+	return strings.Join(primaries, " "), strings.Join(alternates, " ")
+}
+
+func truncateCode(code string) string {
+	if len(code) > MetaphoneCodeLength {
+		return code[:MetaphoneCodeLength]
+	}
+	return code
+}
+
+// metaphone holds the state of encoding a single upper-case word.
+type metaphone struct {
+	word               string
+	primary, alternate strings.Builder
+	slavoGermanic      bool
+}
+
+func (m *metaphone) encode() {
+	index := 0
+	// Skip silent letters at the start
+	if m.has(0, "GN", "KN", "PN", "WR", "PS") {
+		index++
+	}
+	// Initial X is pronounced Z, e.g. "Xavier"
+	if m.at(0) == 'X' {
+		m.add("S")
+		index++
+	}
+
+	for index < len(m.word) &&
+		(m.primary.Len() < MetaphoneCodeLength || m.alternate.Len() < MetaphoneCodeLength) {
+		index = m.encodeAt(index)
+	}
+}
+
+// encodeAt encodes the letter at index and returns the index of the next letter to encode.
+func (m *metaphone) encodeAt(index int) int {
+	switch m.at(index) {
+	case 'A', 'E', 'I', 'O', 'U', 'Y':
+		// Vowels are only kept at the start
+		if index == 0 {
+			m.add("A")
+		}
+		return index + 1
+	case 'B':
+		m.add("P")
+		return m.skipDouble(index, "B")
+	case 'C':
+		return m.encodeC(index)
+	case 'D':
+		return m.encodeD(index)
+	case 'F':
+		m.add("F")
+		return m.skipDouble(index, "F")
+	case 'G':
+		return m.encodeG(index)
+	case 'H':
+		// Only kept at the start or between vowels
+		if (index == 0 || isVowel(m.at(index-1))) && isVowel(m.at(index+1)) {
+			m.add("H")
+			return index + 2
+		}
+		return index + 1
+	case 'J':
+		return m.encodeJ(index)
+	case 'K':
+		m.add("K")
+		return m.skipDouble(index, "K")
+	case 'L':
+		return m.encodeL(index)
+	case 'M':
+		m.add("M")
+		if m.at(index+1) == 'M' ||
+			(m.has(index-1, "UMB") && (index+1 == len(m.word)-1 || m.has(index+2, "ER"))) {
+			return index + 2
+		}
+		return index + 1
+	case 'N':
+		m.add("N")
+		return m.skipDouble(index, "N")
+	case 'P':
+		if m.at(index+1) == 'H' {
+			m.add("F")
+			return index + 2
+		}
+		m.add("P")
+		return m.skipDouble(index, "P", "B")
+	case 'Q':
+		m.add("K")
+		return m.skipDouble(index, "Q")
+	case 'R':
+		// French words ending in -ier, e.g. "Rogier", but not "Meier"
+		if index == len(m.word)-1 && !m.slavoGermanic && m.has(index-2, "IE") && !m.has(index-4, "ME", "MA") {
+			m.addAlternate("", "R")
+		} else {
+			m.add("R")
+		}
+		return m.skipDouble(index, "R")
+	case 'S':
+		return m.encodeS(index)
+	case 'T':
+		return m.encodeT(index)
+	case 'V':
+		m.add("F")
+		return m.skipDouble(index, "V")
+	case 'W':
+		return m.encodeW(index)
+	case 'X':
+		// French words ending in -eaux or -oux are silent
+		if !(index == len(m.word)-1 && (m.has(index-3, "IAU", "EAU") || m.has(index-2, "AU", "OU"))) {
+			m.add("KS")
+		}
+		return m.skipDouble(index, "C", "X")
+	case 'Z':
+		return m.encodeZ(index)
+	default:
+		return index + 1
+	}
+}
+
+func (m *metaphone) encodeC(index int) int {
+	switch {
+	case m.isGermanicCH(index):
+		m.add("K")
+		return index + 2
+	case index == 0 && m.has(index, "CAESAR"):
+		m.add("S")
+		return index + 2
+	case m.has(index, "CH"):
+		return m.encodeCH(index)
+	case m.has(index, "CZ") && !m.has(index-2, "WICZ"):
+		// "Czerny"
+		m.addAlternate("S", "X")
+		return index + 2
+	case m.has(index+1, "CIA"):
+		// "focaccia"
+		m.add("X")
+		return index + 3
+	case m.has(index, "CC") && !(index == 1 && m.at(0) == 'M'):
+		// Double C, but not "McClelland"
+		if m.has(index+2, "I", "E", "H") && !m.has(index+2, "HU") {
+			if (index == 1 && m.at(0) == 'A') || m.has(index-1, "UCCEE", "UCCES") {
+				// "accident", "succeed"
+				m.add("KS")
+			} else {
+				// "bacci", "bertucci"
+				m.add("X")
+			}
+			return index + 3
+		}
+		m.add("K")
+		return index + 2
+	case m.has(index, "CK", "CG", "CQ"):
+		m.add("K")
+		return index + 2
+	case m.has(index, "CI", "CE", "CY"):
+		// Italian vs. English
+		if m.has(index, "CIO", "CIE", "CIA") {
+			m.addAlternate("S", "X")
+		} else {
+			m.add("S")
+		}
+		return index + 2
+	}
+
+	m.add("K")
+	if m.has(index+1, "C", "K", "Q") && !m.has(index+1, "CE", "CI") {
+		return index + 2
+	}
+	return index + 1
+}
+
+// isGermanicCH reports whether the C at index is part of a Germanic -ach- pronounced K, e.g. "Bacher".
+func (m *metaphone) isGermanicCH(index int) bool {
+	if m.has(index, "CHIA") {
+		return true
+	}
+	if index <= 1 || isVowel(m.at(index-2)) || !m.has(index-1, "ACH") {
+		return false
+	}
+	next := m.at(index + 2)
+	return (next != 'I' && next != 'E') || m.has(index-2, "BACHER", "MACHER")
+}
+
+func (m *metaphone) encodeCH(index int) int {
+	switch {
+	case index > 0 && m.has(index, "CHAE"):
+		// "Michael"
+		m.addAlternate("K", "X")
+	case index == 0 && (m.has(index+1, "HARAC", "HARIS") || m.has(index+1, "HOR", "HYM", "HIA", "HEM")) &&
+		!m.has(0, "CHORE"):
+		// Greek roots, e.g. "chemistry", "chorus"
+		m.add("K")
+	case m.has(0, "SCH") || m.has(index-2, "ORCHES", "ARCHIT", "ORCHID") || m.has(index+2, "T", "S") ||
+		((index == 0 || m.has(index-1, "A", "O", "U", "E")) &&
+			(m.has(index+2, "L", "R", "N", "M", "B", "H", "F", "V", "W") || index+1 == len(m.word)-1)):
+		// Germanic, Greek or otherwise pronounced KH, e.g. "orchestra", "Achtung"
+		m.add("K")
+	case index == 0:
+		m.add("X")
+	case m.has(0, "MC"):
+		// "McHugh"
+		m.add("K")
+	default:
+		m.addAlternate("X", "K")
+	}
+	return index + 2
+}
+
+func (m *metaphone) encodeD(index int) int {
+	if m.has(index, "DG") {
+		if m.has(index+2, "I", "E", "Y") {
+			// "edge"
+			m.add("J")
+			return index + 3
+		}
+		// "Edgar"
+		m.add("TK")
+		return index + 2
+	}
+	m.add("T")
+	return m.skipDouble(index, "T", "D")
+}
+
+func (m *metaphone) encodeG(index int) int {
+	next := m.at(index + 1)
+	switch {
+	case next == 'H':
+		return m.encodeGH(index)
+	case next == 'N':
+		switch {
+		case index == 1 && isVowel(m.at(0)) && !m.slavoGermanic:
+			m.addAlternate("KN", "N")
+		case !m.has(index+2, "EY") && !m.slavoGermanic:
+			m.addAlternate("N", "KN")
+		default:
+			m.add("KN")
+		}
+		return index + 2
+	case m.has(index+1, "LI") && !m.slavoGermanic:
+		// "tagliaro"
+		m.addAlternate("KL", "L")
+		return index + 2
+	case index == 0 && (next == 'Y' ||
+		m.has(index+1, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		// -ges-, -gep-, -gel-, -gie- at the start
+		m.addAlternate("K", "J")
+		return index + 2
+	case (m.has(index+1, "ER") || next == 'Y') && !m.has(0, "DANGER", "RANGER", "MANGER") &&
+		!m.has(index-1, "E", "I") && !m.has(index-1, "RGY", "OGY"):
+		// -ger-, -gy-
+		m.addAlternate("K", "J")
+		return index + 2
+	case m.has(index+1, "E", "I", "Y") || m.has(index-1, "AGGI", "OGGI"):
+		switch {
+		case m.has(0, "SCH") || m.has(index+1, "ET"):
+			// Obviously Germanic
+			m.add("K")
+		case m.has(index+1, "IER"):
+			m.add("J")
+		default:
+			m.addAlternate("J", "K")
+		}
+		return index + 2
+	}
+
+	m.add("K")
+	return m.skipDouble(index, "G")
+}
+
+func (m *metaphone) encodeGH(index int) int {
+	switch {
+	case index > 0 && !isVowel(m.at(index-1)):
+		m.add("K")
+	case index == 0:
+		// "ghislane", "ghost"
+		if m.at(index+2) == 'I' {
+			m.add("J")
+		} else {
+			m.add("K")
+		}
+	case (index > 1 && m.has(index-2, "B", "H", "D")) || (index > 2 && m.has(index-3, "B", "H", "D")) ||
+		(index > 3 && m.has(index-4, "B", "H")):
+		// Silent, e.g. "hugh", "bough", "broughton"
+	case index > 2 && m.at(index-1) == 'U' && m.has(index-3, "C", "G", "L", "R", "T"):
+		// "laugh", "cough", "rough", "tough"
+		m.add("F")
+	case m.at(index-1) != 'I':
+		m.add("K")
+	}
+	return index + 2
+}
+
+func (m *metaphone) encodeJ(index int) int {
+	if m.has(index, "JOSE") || m.has(0, "SAN ") {
+		// Obviously Spanish, e.g. "Jose", "San Jacinto"
+		if (index == 0 && m.at(index+4) == ' ') || len(m.word) == 4 || m.has(0, "SAN ") {
+			m.add("H")
+		} else {
+			m.addAlternate("J", "H")
+		}
+		return index + 1
+	}
+
+	switch {
+	case index == 0:
+		// "Yankelovich", "Jankelowicz"
+		m.addAlternate("J", "A")
+	case isVowel(m.at(index-1)) && !m.slavoGermanic && (m.at(index+1) == 'A' || m.at(index+1) == 'O'):
+		// Spanish pronunciation of e.g. "bajador"
+		m.addAlternate("J", "H")
+	case index == len(m.word)-1:
+		m.addAlternate("J", "")
+	case !m.has(index+1, "L", "T", "K", "S", "N", "M", "B", "Z") && !m.has(index-1, "S", "K", "L"):
+		m.add("J")
+	}
+	return m.skipDouble(index, "J")
+}
+
+func (m *metaphone) encodeL(index int) int {
+	if m.at(index+1) != 'L' {
+		m.add("L")
+		return index + 1
+	}
+
+	last := len(m.word) - 1
+	// Spanish, e.g. "cabrillo", "gallegos"
+	if (index == last-2 && m.has(index-1, "ILLO", "ILLA", "ALLE")) ||
+		((m.has(last-1, "AS", "OS") || m.has(last, "A", "O")) && m.has(index-1, "ALLE")) {
+		m.addAlternate("L", "")
+	} else {
+		m.add("L")
+	}
+	return index + 2
+}
+
+func (m *metaphone) encodeS(index int) int {
+	switch {
+	case m.has(index-1, "ISL", "YSL"):
+		// Silent, e.g. "island", "isle", "carlisle"
+		return index + 1
+	case index == 0 && m.has(index, "SUGAR"):
+		m.addAlternate("X", "S")
+		return index + 1
+	case m.has(index, "SH"):
+		if m.has(index+1, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			// Germanic
+			m.add("S")
+		} else {
+			m.add("X")
+		}
+		return index + 2
+	case m.has(index, "SIO", "SIA"):
+		// Italian and Armenian
+		if m.slavoGermanic {
+			m.add("S")
+		} else {
+			m.addAlternate("S", "X")
+		}
+		return index + 3
+	case (index == 0 && m.has(index+1, "M", "N", "L", "W")) || m.has(index+1, "Z"):
+		// German and anglicisations, e.g. "smith" matches "schmidt", "snider" matches "schneider"
+		m.addAlternate("S", "X")
+		return m.skipDouble(index, "Z")
+	case m.has(index, "SC"):
+		return m.encodeSC(index)
+	case index == len(m.word)-1 && m.has(index-2, "AI", "OI"):
+		// French, e.g. "resnais", "artois"
+		m.addAlternate("", "S")
+	default:
+		m.add("S")
+	}
+	return m.skipDouble(index, "S", "Z")
+}
+
+func (m *metaphone) encodeSC(index int) int {
+	switch {
+	case m.at(index+2) == 'H':
+		switch {
+		case m.has(index+3, "ER", "EN"):
+			// Dutch, e.g. "schermerhorn", "schenker"
+			m.addAlternate("X", "SK")
+		case m.has(index+3, "OO", "UY", "ED", "EM"):
+			// Dutch, e.g. "school", "schooner"
+			m.add("SK")
+		case index == 0 && !isVowel(m.at(3)) && m.at(3) != 'W':
+			m.addAlternate("X", "S")
+		default:
+			m.add("X")
+		}
+	case m.has(index+2, "I", "E", "Y"):
+		m.add("S")
+	default:
+		m.add("SK")
+	}
+	return index + 3
+}
+
+func (m *metaphone) encodeT(index int) int {
+	switch {
+	case m.has(index, "TION", "TIA", "TCH"):
+		m.add("X")
+		return index + 3
+	case m.has(index, "TH", "TTH"):
+		// "Thomas", "Thames" or Germanic
+		if m.has(index+2, "OM", "AM") || m.has(0, "SCH") {
+			m.add("T")
+		} else {
+			m.addAlternate("0", "T")
+		}
+		return index + 2
+	}
+	m.add("T")
+	return m.skipDouble(index, "T", "D")
+}
+
+func (m *metaphone) encodeW(index int) int {
+	switch {
+	case m.has(index, "WR"):
+		m.add("R")
+		return index + 2
+	case index == 0 && isVowel(m.at(index+1)):
+		// "Wasserman" matches "Vasserman"
+		m.addAlternate("A", "F")
+	case index == 0 && m.has(index, "WH"):
+		m.add("A")
+	case (index == len(m.word)-1 && isVowel(m.at(index-1))) ||
+		m.has(index-1, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || m.has(0, "SCH"):
+		// "Arnow" matches "Arnoff"
+		m.addAlternate("", "F")
+	case m.has(index, "WICZ", "WITZ"):
+		// Polish, e.g. "filipowicz"
+		m.addAlternate("TS", "FX")
+		return index + 4
+	}
+	return index + 1
+}
+
+func (m *metaphone) encodeZ(index int) int {
+	switch {
+	case m.at(index+1) == 'H':
+		// Chinese pinyin, e.g. "Zhao"
+		m.add("J")
+		return index + 2
+	case m.has(index+1, "ZO", "ZI", "ZA") || (m.slavoGermanic && index > 0 && m.at(index-1) != 'T'):
+		m.addAlternate("S", "TS")
+	default:
+		m.add("S")
+	}
+	return m.skipDouble(index, "Z")
+}
+
+// at returns the letter at index, or 0 outside the word.
+func (m *metaphone) at(index int) byte {
+	if index < 0 || index >= len(m.word) {
+		return 0
+	}
+	return m.word[index]
+}
+
+// has reports whether one of the options occurs in the word at index.
+func (m *metaphone) has(index int, options ...string) bool {
+	if index < 0 {
+		return false
+	}
+	for _, option := range options {
+		if strings.HasPrefix(m.word[min(index, len(m.word)):], option) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipDouble returns the index after the letter at index, skipping the next letter if it sounds the same.
+func (m *metaphone) skipDouble(index int, sameSounding ...string) int {
+	if m.has(index+1, sameSounding...) {
+		return index + 2
+	}
+	return index + 1
+}
+
+// add appends code to both the primary and the alternate encoding.
+func (m *metaphone) add(code string) {
+	m.addAlternate(code, code)
+}
+
+// addAlternate appends different codes to the primary and the alternate encoding.
+func (m *metaphone) addAlternate(primary, alternate string) {
+	m.primary.WriteString(primary)
+	m.alternate.WriteString(alternate)
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("AEIOUY", c) >= 0
+}
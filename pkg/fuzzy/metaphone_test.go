@@ -0,0 +1,40 @@
+package fuzzy
+
+import "testing"
+
+func TestDoubleMetaphone(t *testing.T) {
+	tests := []struct {
+		word              string
+		expectedPrimary   string
+		expectedAlternate string
+	}{
+		{"rhapsody", "RPST", "RPST"},
+		{"rapsody", "RPST", "RPST"},
+		{"photograph", "FTKR", "FTKR"},
+		{"knight", "NT", "NT"},
+		{"smith", "SM0", "XMT"},
+		{"schmidt", "XMT", "SMT"},
+		{"michael", "MKL", "MXL"},
+		{"caesar", "SSR", "SSR"},
+		{"edge", "AJ", "AJ"},
+		{"laugh", "LF", "LF"},
+		{"Xavier", "SF", "SFR"},
+		{"", "", ""},
+		{"42", "", ""},
+	}
+
+	for _, tt := range tests {
+		primary, alternate := DoubleMetaphone(tt.word)
+		if primary != tt.expectedPrimary || alternate != tt.expectedAlternate {
+			t.Errorf("DoubleMetaphone(%q) = %q, %q, want %q, %q",
+				tt.word, primary, alternate, tt.expectedPrimary, tt.expectedAlternate)
+		}
+	}
+}
+
+func TestPhoneticCodes(t *testing.T) {
+	primary, alternate := phoneticCodes("smith and 42 sons")
+	if primary != "SM0 ANT SNS" || alternate != "XMT ANT SNS" {
+		t.Errorf("phoneticCodes() = %q, %q, want %q, %q", primary, alternate, "SM0 ANT SNS", "XMT ANT SNS")
+	}
+}
@@ -30,13 +30,21 @@ var (
 )
 
 // Normalizer provides text normalization and similarity matching for music metadata.
-type Normalizer struct{}
+type Normalizer struct {
+	phoneticWeight float64 // Weight of the phonetic similarity in CalculateSimilarity (0 compares only literally)
+}
 
 // NewNormalizer creates a new Normalizer instance for text processing.
 func NewNormalizer() *Normalizer {
 	return &Normalizer{}
 }
 
+// SetPhoneticWeight sets the weight between 0 and 1 of the phonetic similarity in CalculateSimilarity,
+// so misspelled but similar sounding texts such as "bohemian rapsody" score higher (0 disables it).
+func (n *Normalizer) SetPhoneticWeight(weight float64) {
+	n.phoneticWeight = min(max(weight, 0), 1)
+}
+
 // NormalizeArtist normalizes artist names for consistent comparison and matching.
 func (n *Normalizer) NormalizeArtist(artist string) string {
 	artist = n.basicNormalize(artist)
@@ -84,6 +92,8 @@ func (n *Normalizer) basicNormalize(text string) string {
 }
 
 // CalculateSimilarity calculates the similarity between two strings using longest common subsequence.
+// With a phonetic weight, the similarity of how the strings sound raises the score of misspellings;
+// it never lowers the literal similarity.
 func (n *Normalizer) CalculateSimilarity(s1, s2 string) float64 {
 	if s1 == s2 {
 		return 1.0
@@ -93,13 +103,43 @@ func (n *Normalizer) CalculateSimilarity(s1, s2 string) float64 {
 		return 0.0
 	}
 
+	similarity := n.literalSimilarity(s1, s2)
+	if n.phoneticWeight > 0 {
+		if phonetic := n.phoneticSimilarity(s1, s2); phonetic > similarity {
+			similarity += n.phoneticWeight * (phonetic - similarity)
+		}
+	}
+	return similarity
+}
+
+func (n *Normalizer) literalSimilarity(s1, s2 string) float64 {
 	maxLen := len(s1)
 	if len(s2) > maxLen {
 		maxLen = len(s2)
 	}
+	if maxLen == 0 {
+		return 0.0
+	}
 	return float64(n.longestCommonSubsequence(s1, s2)) / float64(maxLen)
 }
 
+// phoneticSimilarity compares the Double Metaphone codes of the words of two strings,
+// taking the best match of their primary and alternate pronunciations.
+func (n *Normalizer) phoneticSimilarity(s1, s2 string) float64 {
+	primary1, alternate1 := phoneticCodes(s1)
+	primary2, alternate2 := phoneticCodes(s2)
+	if primary1 == "" || primary2 == "" {
+		return 0.0
+	}
+
+	return max(
+		n.literalSimilarity(primary1, primary2),
+		n.literalSimilarity(primary1, alternate2),
+		n.literalSimilarity(alternate1, primary2),
+		n.literalSimilarity(alternate1, alternate2),
+	)
+}
+
 func (n *Normalizer) longestCommonSubsequence(s1, s2 string) int {
 	m, length := len(s1), len(s2)
 	dp := make([][]int, m+1)
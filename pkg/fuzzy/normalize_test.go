@@ -135,6 +135,53 @@ func TestNormalizer_CalculateSimilarity(t *testing.T) {
 	}
 }
 
+func TestNormalizer_CalculateSimilarity_Phonetic(t *testing.T) {
+	literal := NewNormalizer()
+	phonetic := NewNormalizer()
+	phonetic.SetPhoneticWeight(0.3)
+
+	misspellings := []struct {
+		title       string
+		misspelling string
+	}{
+		{"bohemian rhapsody", "bohemian rapsody"},
+		{"photograph", "fotograf"},
+		{"smells like teen spirit", "smels lyke tean spirrit"},
+		{"despacito", "dispacito"},
+	}
+	for _, tt := range misspellings {
+		literalScore := literal.CalculateSimilarity(tt.title, tt.misspelling)
+		phoneticScore := phonetic.CalculateSimilarity(tt.title, tt.misspelling)
+		if phoneticScore <= literalScore || phoneticScore >= 1.0 {
+			t.Errorf("CalculateSimilarity(%q, %q) = %f, expected above the literal %f and below an exact match",
+				tt.title, tt.misspelling, phoneticScore, literalScore)
+		}
+	}
+
+	// Exact matches and unrelated titles score as before
+	for _, tt := range createSimilarityTestCases() {
+		if got, want := phonetic.CalculateSimilarity(tt.s1, tt.s2), literal.CalculateSimilarity(tt.s1, tt.s2); got < want {
+			t.Errorf("%s: CalculateSimilarity() = %f, lower than the literal %f", tt.name, got, want)
+		}
+	}
+	if got := phonetic.CalculateSimilarity("hey jude", "hey jude"); got != 1.0 {
+		t.Errorf("CalculateSimilarity() of an exact match = %f, want 1", got)
+	}
+	if got, want := phonetic.CalculateSimilarity("hey jude", "creep"), literal.CalculateSimilarity("hey jude", "creep"); got != want {
+		t.Errorf("CalculateSimilarity() of unrelated titles = %f, want %f", got, want)
+	}
+}
+
+func TestNormalizer_SetPhoneticWeight(t *testing.T) {
+	normalizer := NewNormalizer()
+	for _, tt := range []struct{ weight, expected float64 }{{-1, 0}, {0.3, 0.3}, {2, 1}} {
+		normalizer.SetPhoneticWeight(tt.weight)
+		if normalizer.phoneticWeight != tt.expected {
+			t.Errorf("SetPhoneticWeight(%f) = %f, want %f", tt.weight, normalizer.phoneticWeight, tt.expected)
+		}
+	}
+}
+
 // similarityTestCase represents a test case for similarity calculation.
 type similarityTestCase struct {
 	name     string
@@ -0,0 +1,170 @@
+package musiclink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	// deezerTrackAPIURL is the Deezer public API track endpoint.
+	deezerTrackAPIURL = "https://api.deezer.com/track/"
+	// DeezerMaxReadSize limits the amount of HTML we read for the Open Graph fallback.
+	DeezerMaxReadSize = 102400 // 100 KB should be enough for metadata.
+	// deezerTrackIDMatches is the number of regex matches expected for track ID extraction.
+	deezerTrackIDMatches = 2
+)
+
+var (
+	// deezerTrackPathRegex matches Deezer track paths like /track/123 or /en/track/123.
+	deezerTrackPathRegex = regexp.MustCompile(`^/(?:[a-z]{2}/)?track/(\d+)/?$`)
+	// deezerOGTitleRegex matches the Open Graph title meta tag.
+	deezerOGTitleRegex = regexp.MustCompile(`<meta\s+property="og:title"\s+content="([^"]+)"`)
+)
+
+// deezerTrackResponse represents the response from the Deezer track API.
+type deezerTrackResponse struct {
+	ID     int64  `json:"id"`
+	Title  string `json:"title"`
+	ISRC   string `json:"isrc"`
+	Artist struct {
+		Name string `json:"name"`
+	} `json:"artist"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// DeezerResolver resolves Deezer links to track information using the Deezer API.
+type DeezerResolver struct {
+	client *http.Client
+}
+
+// NewDeezerResolver creates a new Deezer link resolver.
+func NewDeezerResolver() *DeezerResolver {
+	return &DeezerResolver{
+		client: newHTTPClient(),
+	}
+}
+
+// CanResolve checks if the URL is a Deezer link.
+func (r *DeezerResolver) CanResolve(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	hostname := strings.ToLower(u.Hostname())
+	return hostname == "deezer.com" || hostname == "www.deezer.com"
+}
+
+// Resolve extracts track information from a Deezer URL.
+// The Deezer API is used first (including ISRC); the page's og:title is the fallback.
+func (r *DeezerResolver) Resolve(ctx context.Context, rawURL string) (*TrackInfo, error) {
+	if !r.CanResolve(rawURL) {
+		return nil, errors.New("not a Deezer URL")
+	}
+
+	trackID, err := r.extractTrackID(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract track ID: %w", err)
+	}
+
+	trackData, apiErr := r.fetchTrackData(ctx, trackID)
+	if apiErr == nil {
+		return &TrackInfo{
+			Title:  trackData.Title,
+			Artist: trackData.Artist.Name,
+			ISRC:   trackData.ISRC,
+		}, nil
+	}
+
+	// Fallback: scrape the Open Graph title from the track page.
+	page, err := fetchHTMLFromURL(ctx, r.client, rawURL, "Deezer", DeezerMaxReadSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch track data: %w (page fallback: %w)", apiErr, err)
+	}
+
+	title, artist := r.extractFromOGTitle(page)
+	if title == "" {
+		return nil, fmt.Errorf("could not extract track information from Deezer: %w", apiErr)
+	}
+
+	return &TrackInfo{
+		Title:  title,
+		Artist: artist,
+	}, nil
+}
+
+// extractTrackID extracts the track ID from a Deezer URL.
+func (r *DeezerResolver) extractTrackID(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	matches := deezerTrackPathRegex.FindStringSubmatch(u.Path)
+	if len(matches) < deezerTrackIDMatches {
+		return "", errors.New("no track ID found in Deezer URL (only /track/ URLs are supported)")
+	}
+
+	return matches[1], nil
+}
+
+// fetchTrackData fetches track metadata from the Deezer API.
+func (r *DeezerResolver) fetchTrackData(ctx context.Context, trackID string) (*deezerTrackResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, deezerTrackAPIURL+trackID, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("deezer API returned status %d", resp.StatusCode)
+	}
+
+	var trackResp deezerTrackResponse
+	if err := json.NewDecoder(resp.Body).Decode(&trackResp); err != nil {
+		return nil, fmt.Errorf("failed to decode Deezer API response: %w", err)
+	}
+
+	// Deezer reports errors with status 200 and an error object.
+	if trackResp.Error != nil {
+		return nil, fmt.Errorf("deezer API error: %s", trackResp.Error.Message)
+	}
+
+	if trackResp.Title == "" {
+		return nil, errors.New("no track found in Deezer API response")
+	}
+
+	return &trackResp, nil
+}
+
+// extractFromOGTitle extracts track info from the og:title meta tag ("Title - Artist").
+func (r *DeezerResolver) extractFromOGTitle(page string) (title, artist string) {
+	matches := deezerOGTitleRegex.FindStringSubmatch(page)
+	if len(matches) < minTitleTagMatches {
+		return "", ""
+	}
+
+	ogTitle := html.UnescapeString(matches[1])
+	parts := strings.SplitN(ogTitle, " - ", expectedSplitParts)
+	if len(parts) == expectedSplitParts {
+		return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	}
+
+	return strings.TrimSpace(ogTitle), ""
+}
@@ -0,0 +1,157 @@
+package musiclink
+
+import (
+	"testing"
+)
+
+//nolint:dupl // CanResolve tests intentionally follow same pattern across all resolvers for consistency.
+func TestDeezerResolver_CanResolve(t *testing.T) {
+	t.Helper()
+
+	resolver := NewDeezerResolver()
+
+	tests := []struct {
+		name     string
+		url      string
+		expected bool
+	}{
+		{
+			name:     "Valid www.deezer.com URL",
+			url:      "https://www.deezer.com/en/track/3135556",
+			expected: true,
+		},
+		{
+			name:     "Valid deezer.com URL without language",
+			url:      "https://deezer.com/track/3135556",
+			expected: true,
+		},
+		{
+			name:     "Invalid - non-Deezer URL",
+			url:      "https://example.com/track/123",
+			expected: false,
+		},
+		{
+			name:     "Invalid - Spotify URL",
+			url:      "https://open.spotify.com/track/123",
+			expected: false,
+		},
+		{
+			name:     "Invalid - malformed URL",
+			url:      "not-a-valid-url",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := resolver.CanResolve(tt.url)
+			if result != tt.expected {
+				t.Errorf("CanResolve() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDeezerResolver_extractTrackID(t *testing.T) {
+	t.Helper()
+
+	resolver := NewDeezerResolver()
+
+	tests := []struct {
+		name       string
+		url        string
+		expectedID string
+		wantError  bool
+	}{
+		{
+			name:       "Track URL with language",
+			url:        "https://www.deezer.com/en/track/3135556",
+			expectedID: "3135556",
+		},
+		{
+			name:       "Track URL without language",
+			url:        "https://www.deezer.com/track/3135556",
+			expectedID: "3135556",
+		},
+		{
+			name:       "Track URL with query and trailing slash",
+			url:        "https://www.deezer.com/de/track/3135556/?utm_source=share",
+			expectedID: "3135556",
+		},
+		{
+			name:      "Album URL",
+			url:       "https://www.deezer.com/en/album/302127",
+			wantError: true,
+		},
+		{
+			name:      "Non-numeric track ID",
+			url:       "https://www.deezer.com/en/track/abc",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trackID, err := resolver.extractTrackID(tt.url)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("extractTrackID() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("extractTrackID() unexpected error: %v", err)
+			}
+			if trackID != tt.expectedID {
+				t.Errorf("extractTrackID() = %v, want %v", trackID, tt.expectedID)
+			}
+		})
+	}
+}
+
+func TestDeezerResolver_extractFromOGTitle(t *testing.T) {
+	t.Helper()
+
+	resolver := NewDeezerResolver()
+
+	tests := []struct {
+		name           string
+		html           string
+		expectedTitle  string
+		expectedArtist string
+	}{
+		{
+			name:           "Title and artist",
+			html:           `<meta property="og:title" content="Never Gonna Give You Up - Rick Astley">`,
+			expectedTitle:  "Never Gonna Give You Up",
+			expectedArtist: "Rick Astley",
+		},
+		{
+			name:           "HTML entities are unescaped",
+			html:           `<meta property="og:title" content="Don&#39;t Stop Me Now - Queen">`,
+			expectedTitle:  "Don't Stop Me Now",
+			expectedArtist: "Queen",
+		},
+		{
+			name:          "Title only",
+			html:          `<meta property="og:title" content="Track Title">`,
+			expectedTitle: "Track Title",
+		},
+		{
+			name: "No og:title",
+			html: `<title>Deezer</title>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, artist := resolver.extractFromOGTitle(tt.html)
+			if title != tt.expectedTitle {
+				t.Errorf("extractFromOGTitle() title = %v, want %v", title, tt.expectedTitle)
+			}
+			if artist != tt.expectedArtist {
+				t.Errorf("extractFromOGTitle() artist = %v, want %v", artist, tt.expectedArtist)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ func NewManager() *Manager {
 			NewBeatportResolver(),
 			NewAmazonMusicResolver(),
 			NewSoundCloudResolver(),
+			NewDeezerResolver(),
 		},
 	}
 }
@@ -56,6 +56,11 @@ func TestManager_CanResolve(t *testing.T) {
 			url:      "https://soundcloud.com/artist/track",
 			expected: true,
 		},
+		{
+			name:     "Deezer URL",
+			url:      "https://www.deezer.com/en/track/3135556",
+			expected: true,
+		},
 		{
 			name:     "Spotify URL - not supported",
 			url:      "https://open.spotify.com/track/123",
@@ -8,6 +8,8 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+
+	"djalgorhythm/pkg/text"
 )
 
 const (
@@ -124,42 +126,15 @@ func (r *YouTubeResolver) parseTrackInfo(resp *YouTubeOEmbedResponse) (title, ar
 	// Clean the title by removing common video-specific terms.
 	title = r.cleanTitle(resp.Title)
 
-	// Try to extract artist from the author name or title.
-	artist = r.extractArtist(resp.Title, resp.AuthorName)
+	// Try to extract artist from the author name or title, without featuring credits.
+	artist = r.extractArtist(title, resp.AuthorName)
 
 	return title, artist
 }
 
-// cleanTitle removes common YouTube video metadata from titles.
+// cleanTitle strips video-specific noise like "(Official Video)" or featuring credits from a YouTube title.
 func (r *YouTubeResolver) cleanTitle(title string) string {
-	// Remove common patterns in parentheses or brackets.
-	patterns := []string{
-		`\(Official Video\)`,
-		`\(Official Music Video\)`,
-		`\(Official Audio\)`,
-		`\(Lyric Video\)`,
-		`\(Lyrics\)`,
-		`\[Official Video\]`,
-		`\[Official Music Video\]`,
-		`\[Official Audio\]`,
-		`\[Lyric Video\]`,
-		`\[Lyrics\]`,
-		`\(HD\)`,
-		`\[HD\]`,
-		`\(4K\)`,
-		`\[4K\]`,
-	}
-
-	cleaned := title
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(`(?i)` + pattern)
-		cleaned = re.ReplaceAllString(cleaned, "")
-	}
-
-	// Trim whitespace.
-	cleaned = strings.TrimSpace(cleaned)
-
-	return cleaned
+	return text.CleanYouTubeTitle(title)
 }
 
 // extractArtist attempts to extract the artist name from title and author.
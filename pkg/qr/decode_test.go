@@ -0,0 +1,349 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// The decoder below reads codes back following ISO/IEC 18004 with its own tables, so the round trip
+// checks the encoder against the standard rather than against itself.
+
+// decodeBlocks are the error correction blocks at level M, as (count, total codewords, data codewords) groups.
+var decodeBlocks = map[int][][3]int{
+	1:  {{1, 26, 16}},
+	7:  {{4, 49, 31}},
+	10: {{4, 69, 43}, {1, 70, 44}},
+	14: {{4, 64, 40}, {5, 65, 41}},
+}
+
+// decodeAlignmentPositions are the rows and columns of the alignment pattern centers, by version.
+var decodeAlignmentPositions = map[int][]int{
+	1:  nil,
+	7:  {6, 22, 38},
+	10: {6, 28, 50},
+	14: {6, 26, 46, 66},
+}
+
+// decodeVersionInformation are the version information codewords, by version.
+var decodeVersionInformation = map[int]int{
+	7:  0x07C94,
+	10: 0x0A4D3,
+	14: 0x0E60D,
+}
+
+func TestEncode_DecodeRoundTrip(t *testing.T) {
+	authURL := "https://accounts.spotify.com/authorize?client_id=0123456789abcdef0123456789abcdef" +
+		"&response_type=code&redirect_uri=http%3A%2F%2F127.0.0.1%3A8888%2Fcallback"
+	tests := []struct {
+		name            string
+		text            string
+		expectedVersion int
+	}{
+		{"Version 1", "hello", 1},
+		{"Version 7", strings.Repeat("x", 120), 7},
+		{"Version 10 with a 16 bit length", strings.Repeat("y", 200), 10},
+		{"Spotify authorization URL", authURL + "&scope=playlist-modify-public+playlist-modify-private" +
+			"+user-read-playback-state+user-modify-playback-state+user-read-recently-played&state=" +
+			strings.Repeat("f", 64), 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Encode(tt.text)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if version := (code.Size() - 17) / 4; version != tt.expectedVersion {
+				t.Fatalf("Encode() version = %d, want %d", version, tt.expectedVersion)
+			}
+
+			decoded, err := decode(code)
+			if err != nil {
+				t.Fatalf("decode() error = %v", err)
+			}
+			if decoded != tt.text {
+				t.Errorf("decode() = %q, want %q", decoded, tt.text)
+			}
+
+			// Flipping the first data module must break the error correction of its block
+			code.modules[code.Size()-1][code.Size()-1] = !code.modules[code.Size()-1][code.Size()-1]
+			if _, err := decode(code); err == nil {
+				t.Error("decode() of a damaged code succeeded")
+			}
+		})
+	}
+}
+
+// decode reads the text of a byte mode code at level M and checks its version, format and error correction.
+func decode(code *Code) (string, error) {
+	size := code.Size()
+	version := (size - 17) / 4
+	blocks, ok := decodeBlocks[version]
+	if !ok {
+		return "", fmt.Errorf("no test tables for version %d", version)
+	}
+
+	if expected, ok := decodeVersionInformation[version]; ok {
+		topRight, bottomLeft := 0, 0
+		for i := range 18 {
+			topRight |= boolBit(code.Dark(i/3, size-11+i%3)) << i
+			bottomLeft |= boolBit(code.Dark(size-11+i%3, i/3)) << i
+		}
+		if topRight != expected || bottomLeft != expected {
+			return "", fmt.Errorf("version information %#x and %#x, want %#x", topRight, bottomLeft, expected)
+		}
+	}
+
+	mask, err := decodeFormat(code)
+	if err != nil {
+		return "", err
+	}
+
+	isFunction := decodeFunctionModules(version, size)
+	var bits []bool
+	upward := true
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right-- // The vertical timing pattern shifts the columns to its left by one
+		}
+		for vertical := range size {
+			row := vertical
+			if upward {
+				row = size - 1 - vertical
+			}
+			for _, col := range []int{right, right - 1} {
+				if !isFunction[row][col] {
+					bits = append(bits, code.Dark(row, col) != decodeMaskSelects(mask, row, col))
+				}
+			}
+		}
+		upward = !upward
+	}
+
+	codewords := make([]byte, len(bits)/8)
+	for i := range codewords {
+		for j := range 8 {
+			codewords[i] = codewords[i]<<1 | byte(boolBit(bits[i*8+j]))
+		}
+	}
+
+	data, err := decodeDeinterleave(codewords, blocks)
+	if err != nil {
+		return "", err
+	}
+	return decodeByteSegment(data, version)
+}
+
+// decodeFormat reads both copies of the format information and returns the mask pattern.
+func decodeFormat(code *Code) (int, error) {
+	size := code.Size()
+	first, second := 0, 0
+	firstPositions := [15][2]int{
+		{0, 8}, {1, 8}, {2, 8}, {3, 8}, {4, 8}, {5, 8}, {7, 8}, {8, 8},
+		{8, 7}, {8, 5}, {8, 4}, {8, 3}, {8, 2}, {8, 1}, {8, 0},
+	}
+	for i, position := range firstPositions {
+		first |= boolBit(code.Dark(position[0], position[1])) << i
+		if i < 8 {
+			second |= boolBit(code.Dark(8, size-1-i)) << i
+		} else {
+			second |= boolBit(code.Dark(size-15+i, 8)) << i
+		}
+	}
+	if first != second {
+		return 0, fmt.Errorf("format copies differ: %#x and %#x", first, second)
+	}
+	if !code.Dark(size-8, 8) {
+		return 0, errors.New("dark module is light")
+	}
+
+	format := first ^ 0x5412
+	remainder := format
+	for i := 14; i >= 10; i-- {
+		if remainder&(1<<i) != 0 {
+			remainder ^= 0x537 << (i - 10)
+		}
+	}
+	if remainder != 0 {
+		return 0, fmt.Errorf("format information %#x is no BCH codeword", first)
+	}
+	if level := format >> 13; level != 0 {
+		return 0, fmt.Errorf("error correction level bits %02b, want 00 (M)", level)
+	}
+	return (format >> 10) & 0x7, nil
+}
+
+// decodeFunctionModules marks the modules that don't hold data: finder patterns with their separators,
+// format and version information, timing and alignment patterns.
+func decodeFunctionModules(version, size int) [][]bool {
+	isFunction := newGrid(size)
+	mark := func(top, left, height, width int) {
+		for row := top; row < top+height; row++ {
+			for col := left; col < left+width; col++ {
+				isFunction[row][col] = true
+			}
+		}
+	}
+
+	mark(0, 0, 9, 9)      // Top left finder, separator and format information
+	mark(0, size-8, 9, 8) // Top right finder, separator and format information
+	mark(size-8, 0, 8, 9) // Bottom left finder, separator, format information and dark module
+	mark(6, 0, 1, size)   // Horizontal timing pattern
+	mark(0, 6, size, 1)   // Vertical timing pattern
+	if version >= 7 {
+		mark(0, size-11, 6, 3) // Top right version information
+		mark(size-11, 0, 3, 6) // Bottom left version information
+	}
+
+	positions := decodeAlignmentPositions[version]
+	for _, row := range positions {
+		for _, col := range positions {
+			if (row < 9 && col < 9) || (row < 9 && col > size-9) || (row > size-9 && col < 9) {
+				continue
+			}
+			mark(row-2, col-2, 5, 5)
+		}
+	}
+	return isFunction
+}
+
+// decodeMaskSelects reports whether the mask pattern inverts the module, as in table 10 of the standard.
+func decodeMaskSelects(mask, i, j int) bool {
+	switch mask {
+	case 0b000:
+		return (i+j)%2 == 0
+	case 0b001:
+		return i%2 == 0
+	case 0b010:
+		return j%3 == 0
+	case 0b011:
+		return (i+j)%3 == 0
+	case 0b100:
+		return (i/2+j/3)%2 == 0
+	case 0b101:
+		return (i*j)%2+(i*j)%3 == 0
+	case 0b110:
+		return ((i*j)%2+(i*j)%3)%2 == 0
+	default:
+		return ((i+j)%2+(i*j)%3)%2 == 0
+	}
+}
+
+// decodeDeinterleave splits the codewords into their blocks, checks the error correction of each block
+// and returns the data codewords in order.
+func decodeDeinterleave(codewords []byte, groups [][3]int) ([]byte, error) {
+	var blocks [][]byte
+	var dataLengths []int
+	for _, group := range groups {
+		for range group[0] {
+			blocks = append(blocks, make([]byte, 0, group[1]))
+			dataLengths = append(dataLengths, group[2])
+		}
+	}
+
+	next := 0
+	take := func(block int) {
+		blocks[block] = append(blocks[block], codewords[next])
+		next++
+	}
+	for i := range dataLengths[len(dataLengths)-1] {
+		for block, dataLength := range dataLengths {
+			if i < dataLength {
+				take(block)
+			}
+		}
+	}
+	eccLength := groups[0][1] - groups[0][2]
+	for range eccLength {
+		for block := range blocks {
+			take(block)
+		}
+	}
+	if remainder := len(codewords) - next; remainder != 0 {
+		return nil, fmt.Errorf("%d codewords left after the blocks", remainder)
+	}
+
+	var data []byte
+	for i, block := range blocks {
+		for power := range eccLength {
+			if syndrome := decodeEvaluate(block, decodeExp(power)); syndrome != 0 {
+				return nil, fmt.Errorf("block %d has error correction syndrome %d at power %d", i, syndrome, power)
+			}
+		}
+		data = append(data, block[:dataLengths[i]]...)
+	}
+	return data, nil
+}
+
+// decodeByteSegment reads a byte mode segment followed by a terminator or the end of the data.
+func decodeByteSegment(data []byte, version int) (string, error) {
+	position := 0
+	read := func(length int) int {
+		value := 0
+		for range length {
+			value = value<<1 | int(data[position/8]>>(7-position%8)&1)
+			position++
+		}
+		return value
+	}
+
+	if mode := read(4); mode != 0b0100 {
+		return "", fmt.Errorf("mode %04b, want byte mode 0100", mode)
+	}
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	count := read(countBits)
+	if position+count*8 > len(data)*8 {
+		return "", fmt.Errorf("%d bytes don't fit into %d data codewords", count, len(data))
+	}
+
+	text := make([]byte, count)
+	for i := range text {
+		text[i] = byte(read(8))
+	}
+	if remaining := len(data)*8 - position; remaining >= 4 && read(4) != 0 {
+		return "", errors.New("missing terminator after the byte segment")
+	}
+	return string(text), nil
+}
+
+// decodeExp returns α^power in GF(2^8) with the primitive polynomial x^8 + x^4 + x^3 + x^2 + 1.
+func decodeExp(power int) byte {
+	value := 1
+	for range power {
+		value <<= 1
+		if value&0x100 != 0 {
+			value ^= 0x11D
+		}
+	}
+	return byte(value)
+}
+
+// decodeEvaluate evaluates the codewords as a polynomial, highest degree first, at x in GF(2^8).
+func decodeEvaluate(codewords []byte, x byte) byte {
+	result := byte(0)
+	for _, codeword := range codewords {
+		result = decodeMultiply(result, x) ^ codeword
+	}
+	return result
+}
+
+// decodeMultiply multiplies in GF(2^8) by shifting and reducing, one bit of y at a time.
+func decodeMultiply(x, y byte) byte {
+	result := byte(0)
+	for ; y != 0; y >>= 1 {
+		if y&1 != 0 {
+			result ^= x
+		}
+		carry := x&0x80 != 0
+		x <<= 1
+		if carry {
+			x ^= 0x1D
+		}
+	}
+	return result
+}
@@ -0,0 +1,192 @@
+package qr
+
+// setFunction sets a module of a function pattern, which data and masks leave alone.
+func (c *Code) setFunction(row, col int, dark bool) {
+	c.modules[row][col] = dark
+	c.isFunction[row][col] = true
+}
+
+// drawFunctionPatterns draws the timing, finder and alignment patterns and reserves the format information.
+func (c *Code) drawFunctionPatterns(version int) {
+	for i := range c.size {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinderPattern(3, 3)
+	c.drawFinderPattern(3, c.size-4)
+	c.drawFinderPattern(c.size-4, 3)
+
+	positions := alignmentPatternPositions(version, c.size)
+	last := len(positions) - 1
+	for i, row := range positions {
+		for j, col := range positions {
+			// Skip the corners taken by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignmentPattern(row, col)
+		}
+	}
+
+	c.drawFormatBits(0) // Reserve the format information, it's drawn once the mask is chosen
+	c.drawVersion(version)
+}
+
+// drawFinderPattern draws a finder pattern and its separator around the center module.
+func (c *Code) drawFinderPattern(centerRow, centerCol int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			row, col := centerRow+dy, centerCol+dx
+			if row < 0 || row >= c.size || col < 0 || col >= c.size {
+				continue
+			}
+			distance := max(abs(dx), abs(dy))
+			c.setFunction(row, col, distance != 2 && distance != 4)
+		}
+	}
+}
+
+// drawAlignmentPattern draws an alignment pattern around the center module.
+func (c *Code) drawAlignmentPattern(centerRow, centerCol int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(centerRow+dy, centerCol+dx, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// alignmentPatternPositions returns the rows and columns of the alignment pattern centers.
+func alignmentPatternPositions(version, size int) []int {
+	if version == 1 {
+		return nil
+	}
+
+	count := version/7 + 2
+	step := (version*8 + count*3 + 5) / (count*4 - 4) * 2
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, position := count-1, size-7; i >= 1; i, position = i-1, position-step {
+		positions[i] = position
+	}
+	return positions
+}
+
+// drawFormatBits draws both copies of the error correction level and mask pattern, and the dark module.
+func (c *Code) drawFormatBits(mask int) {
+	data := formatECCLevelM<<3 | mask
+	remainder := data
+	for range 10 {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+
+	// First copy, around the top left finder pattern
+	for i := range 6 {
+		c.setFunction(i, 8, bit(bits, i))
+	}
+	c.setFunction(7, 8, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(8, 7, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(8, 14-i, bit(bits, i))
+	}
+
+	// Second copy, split between the top right and bottom left finder patterns
+	for i := range 8 {
+		c.setFunction(8, c.size-1-i, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(c.size-15+i, 8, bit(bits, i))
+	}
+	c.setFunction(c.size-8, 8, true)
+}
+
+// drawVersion draws both copies of the version information of versions 7 and up.
+func (c *Code) drawVersion(version int) {
+	const minVersionWithInformation = 7
+	if version < minVersionWithInformation {
+		return
+	}
+
+	remainder := version
+	for range 12 {
+		remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1F25)
+	}
+	bits := version<<12 | remainder
+
+	for i := range 18 {
+		a, b := c.size-11+i%3, i/3
+		c.setFunction(b, a, bit(bits, i))
+		c.setFunction(a, b, bit(bits, i))
+	}
+}
+
+// drawCodewords places the codewords in the two module wide columns zigzagging up and down from the bottom right.
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vertical := range c.size {
+			row := vertical
+			if upward {
+				row = c.size - 1 - vertical
+			}
+			for j := range 2 {
+				col := right - j
+				if c.isFunction[row][col] || i >= len(codewords)*8 {
+					continue // Remainder bits stay light
+				}
+				c.modules[row][col] = bit(int(codewords[i>>3]), 7-i&7)
+				i++
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by the mask pattern.
+func (c *Code) applyMask(mask int) {
+	for row := range c.size {
+		for col := range c.size {
+			if !c.isFunction[row][col] && maskSelects(mask, row, col) {
+				c.modules[row][col] = !c.modules[row][col]
+			}
+		}
+	}
+}
+
+// maskSelects reports whether the mask pattern inverts the module.
+func maskSelects(mask, row, col int) bool {
+	switch mask {
+	case 0:
+		return (row+col)%2 == 0
+	case 1:
+		return row%2 == 0
+	case 2:
+		return col%3 == 0
+	case 3:
+		return (row+col)%3 == 0
+	case 4:
+		return (row/2+col/3)%2 == 0
+	case 5:
+		return row*col%2+row*col%3 == 0
+	case 6:
+		return (row*col%2+row*col%3)%2 == 0
+	default:
+		return ((row+col)%2+row*col%3)%2 == 0
+	}
+}
+
+func bit(value, i int) bool {
+	return (value>>i)&1 != 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,89 @@
+package qr
+
+const (
+	penaltyRun        = 3  // Runs of five or more same colored modules in a row or column
+	penaltyBlock      = 3  // Two by two blocks of same colored modules
+	penaltyFinderLike = 40 // Patterns in a row or column that look like finder patterns
+	penaltyBalance    = 10 // Each five percent of dark modules away from half
+)
+
+// penalty scores how hard the code is to scan, lower is better. It's used to choose the mask pattern.
+func (c *Code) penalty() int {
+	result := 0
+	for i := range c.size {
+		result += linePenalty(c.size, func(j int) bool { return c.modules[i][j] })
+		result += linePenalty(c.size, func(j int) bool { return c.modules[j][i] })
+	}
+
+	dark := 0
+	for row := range c.size {
+		for col := range c.size {
+			if c.modules[row][col] {
+				dark++
+			}
+			if row+1 < c.size && col+1 < c.size {
+				color := c.modules[row][col]
+				if c.modules[row][col+1] == color && c.modules[row+1][col] == color && c.modules[row+1][col+1] == color {
+					result += penaltyBlock
+				}
+			}
+		}
+	}
+
+	// Deviation of the dark modules from half, in steps of five percent
+	total := c.size * c.size
+	deviation := (abs(dark*20-total*10) + total - 1) / total
+	return result + (deviation-1)*penaltyBalance
+}
+
+// linePenalty scores the runs and finder like patterns of one row or column.
+func linePenalty(size int, dark func(int) bool) int {
+	const minRun = 5
+	result := 0
+
+	runLength := 0
+	for i := range size {
+		if i > 0 && dark(i) == dark(i-1) {
+			runLength++
+		} else {
+			runLength = 1
+		}
+		if runLength == minRun {
+			result += penaltyRun
+		} else if runLength > minRun {
+			result++
+		}
+	}
+
+	// Dark, light, dark dark dark, light, dark with four light modules on either side
+	finderLike := []bool{true, false, true, true, true, false, true}
+	for start := range size - len(finderLike) + 1 {
+		if !matches(dark, start, finderLike) {
+			continue
+		}
+		if lightRun(dark, size, start-4, start) || lightRun(dark, size, start+len(finderLike), start+len(finderLike)+4) {
+			result += penaltyFinderLike
+		}
+	}
+	return result
+}
+
+// matches reports whether the modules starting at start match the pattern.
+func matches(dark func(int) bool, start int, pattern []bool) bool {
+	for i, want := range pattern {
+		if dark(start+i) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// lightRun reports whether the modules from start up to end are light. Modules outside the code count as light.
+func lightRun(dark func(int) bool, size, start, end int) bool {
+	for i := start; i < end; i++ {
+		if i >= 0 && i < size && dark(i) {
+			return false
+		}
+	}
+	return true
+}
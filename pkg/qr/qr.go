@@ -0,0 +1,292 @@
+// Package qr encodes text as QR codes (ISO/IEC 18004) and renders them for terminals and browsers.
+// It only supports what sharing links needs: byte mode and error correction level M.
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	minVersion = 1
+	maxVersion = 40
+
+	// quietZone is the light border around the code, in modules, that scanners need to find it.
+	quietZone = 2
+
+	// formatECCLevelM are the format information bits of error correction level M.
+	formatECCLevelM = 0
+	// maskPatterns is the number of mask patterns a code can be drawn with.
+	maskPatterns = 8
+)
+
+// eccCodewordsPerBlock is the number of error correction codewords of each block at level M, by version.
+var eccCodewordsPerBlock = [maxVersion + 1]int{
+	-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26,
+	26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28,
+}
+
+// errorCorrectionBlocks is the number of error correction blocks at level M, by version.
+var errorCorrectionBlocks = [maxVersion + 1]int{
+	-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16,
+	17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49,
+}
+
+// ErrTooLong is returned for text that doesn't fit into the largest QR code.
+var ErrTooLong = errors.New("text too long for a QR code")
+
+// Code is an encoded QR code, a square grid of dark and light modules.
+type Code struct {
+	size       int
+	modules    [][]bool // Dark modules, indexed by row and column
+	isFunction [][]bool // Modules of finder, timing, alignment and format patterns, excluded from data and masking
+}
+
+// Encode encodes the text as the smallest QR code that fits it.
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+	for version := minVersion; version <= maxVersion; version++ {
+		if dataBits(version, len(data)) <= dataCodewords(version)*8 {
+			return encode(version, data), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d bytes", ErrTooLong, len(data))
+}
+
+// Size returns the number of modules per side, without the quiet zone.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark reports whether the module at the row and column is dark. Modules outside the code are light.
+func (c *Code) Dark(row, col int) bool {
+	return row >= 0 && row < c.size && col >= 0 && col < c.size && c.modules[row][col]
+}
+
+// Terminal renders the code with block characters, two modules per character, including the quiet zone.
+// Dark modules are drawn as blocks and light modules, including the quiet zone, are left blank.
+func (c *Code) Terminal() string {
+	var b strings.Builder
+	for row := -quietZone; row < c.size+quietZone; row += 2 {
+		for col := -quietZone; col < c.size+quietZone; col++ {
+			top, bottom := c.Dark(row, col), c.Dark(row+1, col)
+			switch {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// SVG renders the code as an SVG image, one unit per module, including the quiet zone.
+func (c *Code) SVG() string {
+	dimension := c.size + 2*quietZone
+	var path strings.Builder
+	for row := range c.size {
+		for col := range c.size {
+			if c.modules[row][col] {
+				fmt.Fprintf(&path, "M%d,%dh1v1h-1z", col+quietZone, row+quietZone)
+			}
+		}
+	}
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+
+		`<rect width="100%%" height="100%%" fill="#fff"/><path d="%s" fill="#000"/></svg>`,
+		dimension, dimension, path.String())
+}
+
+// encode draws the data as a code of the given version, with the mask pattern scoring the lowest penalty.
+func encode(version int, data []byte) *Code {
+	codewords := addErrorCorrection(version, dataCodewordsFor(version, data))
+
+	size := version*4 + 17
+	c := &Code{size: size, modules: newGrid(size), isFunction: newGrid(size)}
+	c.drawFunctionPatterns(version)
+	c.drawCodewords(codewords)
+
+	bestMask, minPenalty := 0, -1
+	for mask := range maskPatterns {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if penalty := c.penalty(); minPenalty < 0 || penalty < minPenalty {
+			bestMask, minPenalty = mask, penalty
+		}
+		c.applyMask(mask) // Masks are XOR, applying one again removes it
+	}
+
+	c.applyMask(bestMask)
+	c.drawFormatBits(bestMask)
+	return c
+}
+
+func newGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+// charCountBits returns the length of the byte mode character count indicator.
+func charCountBits(version int) int {
+	const largeVersion = 10
+	if version < largeVersion {
+		return 8
+	}
+	return 16
+}
+
+// dataBits returns the number of bits needed to encode the data in byte mode.
+func dataBits(version, length int) int {
+	const modeBits = 4
+	if length >= 1<<charCountBits(version) {
+		return 1 << 30 // The length can't be represented
+	}
+	return modeBits + charCountBits(version) + length*8
+}
+
+// rawCodewords returns the number of codewords, data and error correction, that fit into a code of the version.
+func rawCodewords(version int) int {
+	modules := (16*version+128)*version + 64
+	if version >= 2 {
+		alignments := version/7 + 2
+		modules -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			modules -= 36 // Version information
+		}
+	}
+	return modules / 8
+}
+
+// dataCodewords returns the number of data codewords of a code of the version.
+func dataCodewords(version int) int {
+	return rawCodewords(version) - eccCodewordsPerBlock[version]*errorCorrectionBlocks[version]
+}
+
+// dataCodewordsFor encodes the data in byte mode, padded to the data capacity of the version.
+func dataCodewordsFor(version int, data []byte) []byte {
+	const byteModeIndicator = 0x4
+	capacity := dataCodewords(version) * 8
+
+	var bits bitBuffer
+	bits.append(byteModeIndicator, 4)
+	bits.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	// Terminator, then zero bits up to a byte boundary
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+
+	// Alternating pad bytes up to the capacity
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	codewords := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			codewords[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return codewords
+}
+
+// bitBuffer is a sequence of bits, most significant bit first.
+type bitBuffer []bool
+
+// append appends the lowest length bits of the value.
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
+
+// addErrorCorrection splits the data into blocks, adds their error correction codewords and interleaves them.
+func addErrorCorrection(version int, data []byte) []byte {
+	numBlocks := errorCorrectionBlocks[version]
+	eccLength := eccCodewordsPerBlock[version]
+	raw := rawCodewords(version)
+	numShortBlocks := numBlocks - raw%numBlocks
+	shortBlockLength := raw / numBlocks
+
+	divisor := reedSolomonDivisor(eccLength)
+	blocks := make([][]byte, 0, numBlocks)
+	for i, offset := 0, 0; i < numBlocks; i++ {
+		dataLength := shortBlockLength - eccLength
+		if i >= numShortBlocks {
+			dataLength++
+		}
+		blockData := data[offset : offset+dataLength]
+		offset += dataLength
+
+		block := append([]byte{}, blockData...)
+		if i < numShortBlocks {
+			block = append(block, 0) // Placeholder, so all blocks have the same length
+		}
+		blocks = append(blocks, append(block, reedSolomonRemainder(blockData, divisor)...))
+	}
+
+	result := make([]byte, 0, raw)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortBlockLength-eccLength || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// reedSolomonDivisor returns the generator polynomial of the degree, without its leading term.
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for range degree {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder returns the error correction codewords of the data.
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range divisor {
+			result[i] ^= gfMultiply(coefficient, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	const modulus = 0x11D
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * modulus)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
@@ -0,0 +1,156 @@
+package qr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// Data and error correction codewords of the "HELLO WORLD" 1-M example of ISO/IEC 18004
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expected := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	result := reedSolomonRemainder(data, reedSolomonDivisor(len(expected)))
+	if string(result) != string(expected) {
+		t.Errorf("reedSolomonRemainder() = %v, want %v", result, expected)
+	}
+}
+
+func TestDataCodewords(t *testing.T) {
+	tests := []struct {
+		version  int
+		expected int
+	}{
+		{1, 16},
+		{2, 28},
+		{7, 124},
+		{10, 216},
+		{21, 714},
+		{40, 2334},
+	}
+
+	for _, tt := range tests {
+		if result := dataCodewords(tt.version); result != tt.expected {
+			t.Errorf("dataCodewords(%d) = %d, want %d", tt.version, result, tt.expected)
+		}
+	}
+}
+
+func TestAddErrorCorrection_FillsAllCodewords(t *testing.T) {
+	for version := minVersion; version <= maxVersion; version++ {
+		codewords := addErrorCorrection(version, dataCodewordsFor(version, []byte("https://example.com")))
+		if len(codewords) != rawCodewords(version) {
+			t.Errorf("version %d: got %d codewords, want %d", version, len(codewords), rawCodewords(version))
+		}
+	}
+}
+
+func TestEncode_Version(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		expectedSize int
+	}{
+		{"Fits version 1", strings.Repeat("a", 14), 21},
+		{"Needs version 2", strings.Repeat("a", 15), 25},
+		{"Largest version", strings.Repeat("a", 2331), 177},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Encode(tt.text)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if code.Size() != tt.expectedSize {
+				t.Errorf("Size() = %d, want %d", code.Size(), tt.expectedSize)
+			}
+		})
+	}
+}
+
+func TestEncode_TooLong(t *testing.T) {
+	if _, err := Encode(strings.Repeat("a", 2332)); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Encode() error = %v, want %v", err, ErrTooLong)
+	}
+}
+
+func TestEncode_FormatBits(t *testing.T) {
+	code, err := Encode("https://accounts.spotify.com/authorize?client_id=abc&state=xyz")
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	// Read back the first copy of the format information and check it is a valid level M codeword
+	bits := 0
+	for i := range 6 {
+		bits |= boolBit(code.Dark(i, 8)) << i
+	}
+	bits |= boolBit(code.Dark(7, 8)) << 6
+	bits |= boolBit(code.Dark(8, 8)) << 7
+	bits |= boolBit(code.Dark(8, 7)) << 8
+	for i := 9; i < 15; i++ {
+		bits |= boolBit(code.Dark(8, 14-i)) << i
+	}
+
+	data := (bits ^ 0x5412) >> 10
+	if data>>3 != formatECCLevelM {
+		t.Errorf("format error correction level = %d, want %d", data>>3, formatECCLevelM)
+	}
+
+	// The second copy must match the first
+	for i := range 8 {
+		if code.Dark(8, code.Size()-1-i) != bit(bits, i) {
+			t.Errorf("second format copy differs at bit %d", i)
+		}
+	}
+	if !code.Dark(code.Size()-8, 8) {
+		t.Error("dark module is light")
+	}
+}
+
+func TestCode_Terminal(t *testing.T) {
+	code, err := Encode("hello")
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(code.Terminal(), "\n"), "\n")
+	dimension := code.Size() + 2*quietZone
+	if len(lines) != (dimension+1)/2 {
+		t.Errorf("got %d lines, want %d", len(lines), (dimension+1)/2)
+	}
+	for _, line := range lines {
+		if n := len([]rune(line)); n != dimension {
+			t.Errorf("line has %d characters, want %d", n, dimension)
+		}
+	}
+
+	// The quiet zone is blank and the dark border of the top left finder pattern is drawn
+	if strings.TrimSpace(lines[0]) != "" {
+		t.Errorf("quiet zone line = %q, want it blank", lines[0])
+	}
+	if corner := string([]rune(lines[quietZone/2])[quietZone : quietZone+2]); corner != "█▀" {
+		t.Errorf("top left finder corner = %q, want %q", corner, "█▀")
+	}
+}
+
+func TestCode_SVG(t *testing.T) {
+	code, err := Encode("hello")
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	svg := code.SVG()
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `viewBox="0 0 25 25"`) {
+		t.Errorf("SVG() = %q, want an svg with a 25 module view box", svg)
+	}
+}
+
+func boolBit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,67 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// LanguageEnglish is the ISO 639-1 code returned for English messages.
+	LanguageEnglish = "en"
+	// LanguageGerman is the ISO 639-1 code returned for German and Swiss German messages.
+	LanguageGerman = "de"
+)
+
+var (
+	// Common words of short song requests, chosen to rarely appear in the other language.
+	englishStopwords = map[string]bool{
+		"the": true, "a": true, "an": true, "and": true, "please": true, "play": true, "song": true,
+		"by": true, "from": true, "of": true, "some": true, "can": true, "you": true, "me": true,
+		"my": true, "to": true, "is": true, "this": true, "that": true, "with": true, "for": true,
+		"want": true, "like": true, "put": true, "next": true, "love": true, "track": true,
+		"something": true, "could": true, "would": true, "add": true, "let's": true, "lets": true,
+		"music": true, "thanks": true, "what": true, "about": true, "more": true, "one": true,
+	}
+
+	germanStopwords = map[string]bool{
+		"bitte": true, "spiel": true, "spil": true, "spiu": true, "lied": true, "vo": true,
+		"von": true, "und": true, "der": true, "die": true, "das": true, "ds": true,
+		"isch": true, "ist": true, "ich": true, "mir": true, "mer": true, "chasch": true,
+		"chöit": true, "gärn": true, "gern": true, "öppis": true, "eppis": true, "etwas": true,
+		"mit": true, "nomau": true, "nochmal": true, "ein": true, "eine": true, "es": true,
+		"emne": true, "bi": true, "dr": true, "dä": true, "nid": true, "nicht": true, "ou": true,
+		"auch": true, "doch": true, "mau": true, "wünsche": true, "wunsch": true, "hoi": true,
+		"merci": true, "danke": true, "lueg": true, "spielen": true, "bitteschön": true,
+		"vom": true, "zum": true, "musig": true, "musik": true, "wär": true,
+	}
+)
+
+// DetectLanguage guesses the language of a short chat message from stopwords and German umlauts.
+// URLs are ignored. Returns an empty string if the text gives no clear hint.
+func DetectLanguage(text string) string {
+	text = urlRegex.ReplaceAllString(strings.ToLower(text), " ")
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+
+	englishScore, germanScore := 0, 0
+	for _, word := range words {
+		switch {
+		case englishStopwords[word]:
+			englishScore++
+		case germanStopwords[word]:
+			germanScore++
+		case strings.ContainsAny(word, "äöüß"):
+			germanScore++
+		}
+	}
+
+	switch {
+	case englishScore > germanScore:
+		return LanguageEnglish
+	case germanScore > englishScore:
+		return LanguageGerman
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,29 @@
+package text
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"english request", "Can you please play something by Daft Punk", LanguageEnglish},
+		{"english short", "play the new one from Dua Lipa", LanguageEnglish},
+		{"bernese request", "Chasch bitte öppis vo Patent Ochsner spile", LanguageGerman},
+		{"german request", "Spiel bitte das Lied von Rammstein", LanguageGerman},
+		{"umlauts only", "Züri West", LanguageGerman},
+		{"spotify link only", "https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC", ""},
+		{"link words are ignored", "https://example.com/the/song/by/you bitte", LanguageGerman},
+		{"artist only", "Rammstein", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.input); got != tt.expected {
+				t.Errorf("DetectLanguage(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
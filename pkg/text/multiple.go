@@ -0,0 +1,137 @@
+package text
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxRequestsPerMessage is the maximum number of song requests taken from one message.
+const MaxRequestsPerMessage = 3
+
+// RequestCandidate is a single song request found in a free-text message.
+type RequestCandidate struct {
+	Query string
+}
+
+var (
+	// requestSeparators always separate two requests, like "Song A and also Song B".
+	requestSeparators = []string{";", " and also ", " and then ", " as well as ", " followed by "}
+	// conjunctionSeparators also occur within titles and artists ("Rock and Roll", "Simon & Garfunkel"),
+	// so they only separate two parts that each look like a complete request.
+	conjunctionSeparators = []string{" and ", " & "}
+	// quoteRunes delimit titles in which separators are ignored.
+	quoteRunes = map[rune]bool{'"': true, '“': true, '”': true, '„': true, '«': true, '»': true}
+)
+
+// ParseMultiple splits a free-text message that asks for several songs into one candidate per song,
+// like "play Song A and also Song B by X" or one song per line. Separators within quotes are ignored,
+// and "and" only separates parts that are complete requests on their own ("Song A by X and Song B by Y"
+// or two quoted titles), so titles like "Rock and Roll" stay intact. At most MaxRequestsPerMessage
+// candidates are returned. A message with a single request yields a single candidate.
+func (p *Parser) ParseMultiple(text string) []RequestCandidate {
+	var queries []string
+	for line := range strings.SplitSeq(norm.NFKC.String(text), "\n") {
+		parts, _ := splitOutsideQuotes(line, requestSeparators)
+		for _, part := range parts {
+			queries = append(queries, splitConjunctions(part)...)
+		}
+	}
+
+	candidates := make([]RequestCandidate, 0, min(len(queries), MaxRequestsPerMessage))
+	for _, query := range queries {
+		query = cleanRequestQuery(query)
+		if query == "" {
+			continue
+		}
+		if len(candidates) == MaxRequestsPerMessage {
+			break
+		}
+		candidates = append(candidates, RequestCandidate{Query: query})
+	}
+	return candidates
+}
+
+// RequestQueries returns the queries of a message that asks for several songs, or nil if it asks for at most one.
+func (p *Parser) RequestQueries(text string) []string {
+	candidates := p.ParseMultiple(text)
+	if len(candidates) < 2 {
+		return nil
+	}
+
+	queries := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		queries = append(queries, candidate.Query)
+	}
+	return queries
+}
+
+// splitConjunctions splits a part at "and" or "&" wherever both sides look like complete requests.
+func splitConjunctions(part string) []string {
+	pieces, separators := splitOutsideQuotes(part, conjunctionSeparators)
+
+	queries := make([]string, 0, len(pieces))
+	current := pieces[0]
+	for i, piece := range pieces[1:] {
+		if isCompleteRequest(current) && isCompleteRequest(piece) {
+			queries = append(queries, current)
+			current = piece
+			continue
+		}
+		current += separators[i] + piece
+	}
+	return append(queries, current)
+}
+
+// isCompleteRequest reports whether a part names a song on its own, with an artist or as a quoted title.
+func isCompleteRequest(part string) bool {
+	if strings.Contains(strings.ToLower(part), " by ") {
+		return true
+	}
+	for _, r := range part {
+		if quoteRunes[r] {
+			return true
+		}
+	}
+	return false
+}
+
+// splitOutsideQuotes splits text at the given case-insensitive separators, except within quotes.
+// It returns the parts and the separators found between them, as written in the text.
+func splitOutsideQuotes(text string, separators []string) (parts, found []string) {
+	inQuotes := false
+	start := 0
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if quoteRunes[r] {
+			inQuotes = !inQuotes
+		} else if separator := matchSeparator(text[i:], separators); !inQuotes && separator != "" {
+			parts = append(parts, text[start:i])
+			found = append(found, text[i:i+len(separator)])
+			i += len(separator)
+			start = i
+			continue
+		}
+		i += size
+	}
+	return append(parts, text[start:]), found
+}
+
+// matchSeparator returns the separator the text starts with, ignoring case, or an empty string.
+func matchSeparator(text string, separators []string) string {
+	for _, separator := range separators {
+		if len(text) >= len(separator) && strings.EqualFold(text[:len(separator)], separator) {
+			return separator
+		}
+	}
+	return ""
+}
+
+// cleanRequestQuery trims list markers, whitespace and trailing punctuation from a query.
+func cleanRequestQuery(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	query = strings.TrimLeft(query, "-*•· ")
+	return strings.TrimRight(query, ",.!? ")
+}
@@ -0,0 +1,104 @@
+package text
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParser_ParseMultiple(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "Single request",
+			input:    "play Wonderwall by Oasis",
+			expected: []string{"play Wonderwall by Oasis"},
+		},
+		{
+			name:     "And also",
+			input:    "play Song A and also Song B by X",
+			expected: []string{"play Song A", "Song B by X"},
+		},
+		{
+			name:     "One request per line",
+			input:    "- Creep by Radiohead\n\n- Yellow by Coldplay\n",
+			expected: []string{"Creep by Radiohead", "Yellow by Coldplay"},
+		},
+		{
+			name:     "Semicolons and then",
+			input:    "Creep; Yellow AND THEN Hurt.",
+			expected: []string{"Creep", "Yellow", "Hurt"},
+		},
+		{
+			name:     "Two requests with artists",
+			input:    "Rock and Roll by Led Zeppelin and Kashmir by Led Zeppelin",
+			expected: []string{"Rock and Roll by Led Zeppelin", "Kashmir by Led Zeppelin"},
+		},
+		{
+			name:     "Two quoted titles",
+			input:    `play "Hello" & "Goodbye"`,
+			expected: []string{`play "Hello"`, `"Goodbye"`},
+		},
+		{
+			name:     "Capped per message",
+			input:    "A; B; C; D; E",
+			expected: []string{"A", "B", "C"},
+		},
+		{
+			name:     "Title with and",
+			input:    "play Rock and Roll",
+			expected: []string{"play Rock and Roll"},
+		},
+		{
+			name:     "Artist with and",
+			input:    "The Boxer by Simon and Garfunkel",
+			expected: []string{"The Boxer by Simon and Garfunkel"},
+		},
+		{
+			name:     "Artist with ampersand followed by a request",
+			input:    "Sound of Silence by Simon & Garfunkel and Kashmir by Led Zeppelin",
+			expected: []string{"Sound of Silence by Simon & Garfunkel", "Kashmir by Led Zeppelin"},
+		},
+		{
+			name:     "Separators within quotes",
+			input:    `“Love and Theft; Live” and also "Me and Bobby McGee"`,
+			expected: []string{"“Love and Theft; Live”", `"Me and Bobby McGee"`},
+		},
+		{
+			name:     "Plain and without artists",
+			input:    "play Song A and Song B",
+			expected: []string{"play Song A and Song B"},
+		},
+		{
+			name:     "Empty parts",
+			input:    " ; \n ;",
+			expected: []string{},
+		},
+	}
+
+	parser := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			for _, candidate := range parser.ParseMultiple(tt.input) {
+				queries = append(queries, candidate.Query)
+			}
+			if !slices.Equal(queries, tt.expected) {
+				t.Errorf("ParseMultiple(%q) = %q, want %q", tt.input, queries, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParser_RequestQueries(t *testing.T) {
+	parser := NewParser()
+
+	if queries := parser.RequestQueries("play Rock and Roll"); queries != nil {
+		t.Errorf("Expected no queries for a single request, got %q", queries)
+	}
+	if queries := parser.RequestQueries("Creep\nYellow"); !slices.Equal(queries, []string{"Creep", "Yellow"}) {
+		t.Errorf("Expected both queries, got %q", queries)
+	}
+}
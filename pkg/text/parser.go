@@ -53,6 +53,7 @@ var (
 		"beatport.com":      true,
 		"music.amazon.com":  true, // Amazon Music (various TLDs handled by prefix check).
 		"soundcloud.com":    true,
+		"deezer.com":        true,
 	}
 )
 
@@ -218,6 +219,11 @@ func (p *Parser) isMusicURL(rawURL string) bool {
 		hostname = "beatport.com"
 	}
 
+	// Normalize Deezer domain.
+	if hostname == "www.deezer.com" {
+		hostname = "deezer.com"
+	}
+
 	// Check exact match in the whitelist.
 	if nonSpotifyMusicDomains[hostname] {
 		return true
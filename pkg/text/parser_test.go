@@ -354,6 +354,11 @@ func TestParser_isMusicURL(t *testing.T) {
 			input:    "https://soundcloud.com/artist/track",
 			expected: true,
 		},
+		{
+			name:     "Deezer URL",
+			input:    "https://www.deezer.com/en/track/3135556",
+			expected: true,
+		},
 		{
 			name:     "Spotify URL",
 			input:    "https://open.spotify.com/track/123",
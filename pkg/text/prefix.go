@@ -0,0 +1,29 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StripRequestPrefix removes a request prefix like "!play" from the start of a message, ignoring case.
+// It reports false if the message doesn't start with the prefix as a word of its own, i.e. isn't a request.
+// Without a prefix, every message is a request and is returned unchanged.
+func StripRequestPrefix(message, prefix string) (string, bool) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return message, true
+	}
+
+	trimmed := strings.TrimLeftFunc(message, unicode.IsSpace)
+	if len(trimmed) < len(prefix) || !strings.EqualFold(trimmed[:len(prefix)], prefix) {
+		return message, false
+	}
+
+	rest := trimmed[len(prefix):]
+	if next, _ := utf8.DecodeRuneInString(rest); rest != "" && !unicode.IsSpace(next) {
+		// "!playlist" doesn't start with the prefix "!play"
+		return message, false
+	}
+	return strings.TrimSpace(rest), true
+}
@@ -0,0 +1,32 @@
+package text
+
+import "testing"
+
+func TestStripRequestPrefix(t *testing.T) {
+	tests := []struct {
+		name          string
+		message       string
+		prefix        string
+		expected      string
+		expectedFound bool
+	}{
+		{"No prefix configured", "check this https://example.com", "", "check this https://example.com", true},
+		{"Prefixed link", "!play https://open.spotify.com/track/abc", "!play", "https://open.spotify.com/track/abc", true},
+		{"Prefixed free text", "  !PLAY Creep by Radiohead", "!play", "Creep by Radiohead", true},
+		{"Prefix on its own line", "!play\nCreep\nYellow", "!play", "Creep\nYellow", true},
+		{"Prefix only", "!play", "!play", "", true},
+		{"Chatter with link", "look https://open.spotify.com/track/abc", "!play", "look https://open.spotify.com/track/abc",
+			false},
+		{"Longer word", "!playlist is great", "!play", "!playlist is great", false},
+		{"Prefix later in message", "please !play Creep", "!play", "please !play Creep", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, found := StripRequestPrefix(tt.message, tt.prefix)
+			if result != tt.expected || found != tt.expectedFound {
+				t.Errorf("StripRequestPrefix() = %q, %v, want %q, %v", result, found, tt.expected, tt.expectedFound)
+			}
+		})
+	}
+}
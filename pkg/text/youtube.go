@@ -0,0 +1,47 @@
+package text
+
+import (
+	"regexp"
+	"strings"
+)
+
+// youTubeTitleSeparator separates artist and song title in most YouTube music video titles.
+const youTubeTitleSeparator = " - "
+
+var (
+	// youTubeNoiseRegex matches bracketed video markers like "(Official Music Video)" or "[Lyrics]".
+	youTubeNoiseRegex = regexp.MustCompile(`(?i)\s*[\(\[][^\)\]]*\b(?:official|video|audio|lyrics?|visuali[sz]er|` +
+		`hd|hq|4k|mv|m/v|clip officiel|videoclip)\b[^\)\]]*[\)\]]`)
+	// youTubeBracketedFeatRegex matches bracketed featuring credits like "(ft. Artist)" or "[feat. Artist]".
+	youTubeBracketedFeatRegex = regexp.MustCompile(`(?i)\s*[\(\[]\s*(?:ft|feat|featuring)\b\.?[^\)\]]*[\)\]]`)
+	// youTubeTrailingFeatRegex matches unbracketed featuring credits up to the end of a title segment.
+	youTubeTrailingFeatRegex = regexp.MustCompile(`(?i)\s+(?:ft|feat|featuring)\b\.?\s.*$`)
+	// youTubeWhitespaceRegex matches runs of whitespace.
+	youTubeWhitespaceRegex = regexp.MustCompile(`\s+`)
+)
+
+// CleanYouTubeTitle strips video-specific noise from a YouTube title to improve Spotify search matches.
+// It removes markers like "(Official Video)" or "[Lyrics]", "| ..." channel suffixes
+// and featuring credits ("ft.", "feat.", "featuring"), keeping the "Artist - Title" structure.
+func CleanYouTubeTitle(title string) string {
+	// Drop suffixes like "| Official Video" or "| Channel Name".
+	if idx := strings.Index(title, " | "); idx > 0 {
+		title = title[:idx]
+	}
+
+	title = youTubeNoiseRegex.ReplaceAllString(title, "")
+	title = youTubeBracketedFeatRegex.ReplaceAllString(title, "")
+
+	// Featuring credits can appear on either side of the separator ("Artist ft. X - Title").
+	segments := strings.Split(title, youTubeTitleSeparator)
+	cleaned := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		segment = youTubeTrailingFeatRegex.ReplaceAllString(segment, "")
+		segment = strings.TrimSpace(youTubeWhitespaceRegex.ReplaceAllString(segment, " "))
+		if segment != "" {
+			cleaned = append(cleaned, segment)
+		}
+	}
+
+	return strings.Join(cleaned, youTubeTitleSeparator)
+}
@@ -0,0 +1,76 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestCleanYouTubeTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Official Video suffix",
+			input:    "Rick Astley - Never Gonna Give You Up (Official Video)",
+			expected: "Rick Astley - Never Gonna Give You Up",
+		},
+		{
+			name:     "Official Music Video suffix",
+			input:    "Queen - Bohemian Rhapsody (Official Music Video)",
+			expected: "Queen - Bohemian Rhapsody",
+		},
+		{
+			name:     "Lyrics in square brackets",
+			input:    "Arctic Monkeys - Do I Wanna Know? [Lyrics]",
+			expected: "Arctic Monkeys - Do I Wanna Know?",
+		},
+		{
+			name:     "Multiple markers",
+			input:    "Song Title (Official Audio) [HD]",
+			expected: "Song Title",
+		},
+		{
+			name:     "Remastered marker with video keyword",
+			input:    "a-ha - Take On Me (Official Video) [Remastered in 4K]",
+			expected: "a-ha - Take On Me",
+		},
+		{
+			name:     "Bracketed ft. credit",
+			input:    "Calvin Harris - This Is What You Came For (ft. Rihanna)",
+			expected: "Calvin Harris - This Is What You Came For",
+		},
+		{
+			name:     "Unbracketed feat. credit after title",
+			input:    "Mark Ronson - Uptown Funk feat. Bruno Mars",
+			expected: "Mark Ronson - Uptown Funk",
+		},
+		{
+			name:     "Featuring credit in artist part",
+			input:    "Daft Punk ft. Pharrell Williams - Get Lucky (Official Audio)",
+			expected: "Daft Punk - Get Lucky",
+		},
+		{
+			name:     "Pipe suffix",
+			input:    "Dua Lipa - Levitating | Official Video",
+			expected: "Dua Lipa - Levitating",
+		},
+		{
+			name:     "Words containing ft are kept",
+			input:    "Swift - Left Behind",
+			expected: "Swift - Left Behind",
+		},
+		{
+			name:     "Meaningful brackets are kept",
+			input:    "Artist - Song (Acoustic)",
+			expected: "Artist - Song (Acoustic)",
+		},
+		{
+			name:     "Clean title unchanged",
+			input:    "Simple Song Title",
+			expected: "Simple Song Title",
+		},
+	}
+
+	runStringTransformationTest(t, "CleanYouTubeTitle", CleanYouTubeTitle, tests)
+}